@@ -330,6 +330,11 @@ The "delete create" command creates a new log deletion request for the specified
 Example:
 
 	logcli delete create '{job="app"}' --from="2023-01-01T00:00:00Z" --to="2023-01-02T00:00:00Z"
+
+Use --dry-run to report the streams, chunks, estimated lines and bytes the
+request would affect, per day, without creating it:
+
+	logcli delete create '{job="app"}' --from="2023-01-01T00:00:00Z" --to="2023-01-02T00:00:00Z" --dry-run
 `)
 	deleteCreateQuery = newDeleteCreateQuery(deleteCreateCmd)
 
@@ -515,7 +520,15 @@ func main() {
 	case detectedFieldsCmd.FullCommand():
 		detectedFieldsQuery.Do(queryClient, *outputMode)
 	case deleteCreateCmd.FullCommand():
-		if err := deleteCreateQuery.CreateQuery(queryClient); err != nil {
+		if deleteCreateQuery.DryRun {
+			dryRun := deleteCreateQuery.DryRunQuery
+			if *outputMode == "jsonl" {
+				dryRun = deleteCreateQuery.DryRunQueryJSON
+			}
+			if err := dryRun(queryClient); err != nil {
+				log.Fatalf("Error estimating delete request: %s", err)
+			}
+		} else if err := deleteCreateQuery.CreateQuery(queryClient); err != nil {
 			log.Fatalf("Error creating delete request: %s", err)
 		}
 	case deleteListCmd.FullCommand():
@@ -865,6 +878,8 @@ func newDeleteCreateQuery(cmd *kingpin.CmdClause) *delete.Query {
 	cmd.Flag("from", "Start time for deletion (inclusive)").StringVar(&from)
 	cmd.Flag("to", "End time for deletion (exclusive)").StringVar(&to)
 	cmd.Flag("max-interval", "Maximum time interval for delete request").StringVar(&q.MaxInterval)
+	cmd.Flag("mode", "Whether the delete request deletes the matching log lines or rewrites them with the drop and line_format stages of the query").EnumVar(&q.Mode, "delete", "rewrite")
+	cmd.Flag("dry-run", "Estimate the impact of the delete request without creating it").BoolVar(&q.DryRun)
 
 	return q
 }
@@ -8,19 +8,24 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-kit/log"
 	"github.com/grafana/dskit/backoff"
+	"github.com/grafana/dskit/flagext"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/common/config"
 	"github.com/prometheus/common/version"
 
 	"github.com/grafana/loki/v3/pkg/canary/comparator"
+	"github.com/grafana/loki/v3/pkg/canary/kafkatracker"
 	"github.com/grafana/loki/v3/pkg/canary/reader"
+	"github.com/grafana/loki/v3/pkg/canary/verifier"
 	"github.com/grafana/loki/v3/pkg/canary/writer"
+	"github.com/grafana/loki/v3/pkg/kafka"
 	_ "github.com/grafana/loki/v3/pkg/util/build"
 )
 
@@ -36,6 +41,8 @@ type canary struct {
 	writer     *writer.Writer
 	reader     *reader.Reader
 	comparator *comparator.Comparator
+	verifier   *verifier.Verifier
+	tracker    *kafkatracker.Tracker
 }
 
 func main() {
@@ -88,6 +95,23 @@ func main() {
 	spotCheckQueryRate := flag.Duration("spot-check-query-rate", 1*time.Minute, "Interval that the canary will query Loki for the current list of all spot check entries")
 	spotCheckWait := flag.Duration("spot-check-initial-wait", 10*time.Second, "How long should the spot check query wait before starting to check for entries")
 
+	verificationTenants := flag.String("verification-tenants", "", "Comma separated list of tenants to periodically write entries with structured metadata and out of order timestamps to, "+
+		"verifying that the structured metadata round-trips, that the out of order entries are accepted and that the tenants are isolated. Requires -push, disabled if empty")
+	verificationInterval := flag.Duration("verification-interval", 5*time.Minute, "The interval the verification entries should be written to the verification tenants")
+	verificationWait := flag.Duration("verification-wait", 30*time.Second, "Duration to wait after writing the verification entries before querying Loki for them")
+	verificationOutOfOrderOffset := flag.Duration("verification-out-of-order-offset", 5*time.Minute, "How far back the out of order verification entries should be written, must be within the out of order window of Loki")
+	verificationStreamValue := flag.String("verification-stream-value", "verification", "The stream value of the verification entries, must be different from -streamvalue")
+
+	kafkaAddress := flag.String("kafka-address", "", "The Kafka address of the Kafka ingest path, enables tracking the entries along the Kafka ingest path if set")
+	kafkaTopic := flag.String("kafka-topic", "", "The Kafka topic the distributors write the entries to")
+	kafkaSASLUsername := flag.String("kafka-sasl-username", "", "The SASL username for authentication to Kafka using the PLAIN mechanism")
+	kafkaSASLPassword := flag.String("kafka-sasl-password", "", "The SASL password for authentication to Kafka using the PLAIN mechanism")
+	kafkaConsumerGroup := flag.String("kafka-consumer-group", "dataobj-consumer", "The consumer group of the data object consumers, whose committed offsets tell which entries were flushed to data objects")
+	dataobjCheckInterval := flag.Duration("dataobj-check-interval", 1*time.Minute, "The interval the entries tracked along the Kafka ingest path should be checked")
+	dataobjMaxWait := flag.Duration("dataobj-max-wait", 2*time.Hour, "Duration to wait for entries written to Kafka to be flushed to data objects before reporting them missing")
+	dataobjQueryLag := flag.Duration("dataobj-query-lag", 1*time.Hour, "How old the entries flushed to data objects should be before querying Loki for them, "+
+		"must be greater than the data object storage lag of the queriers for them to use the v2 engine. 0 disables the queries")
+
 	logBatchSize := flag.Int("logs-batch-size", writer.DefaultLogBatchSize, "Send logs to Loki in batches of a specified size.  Must be a non-negative value (0 or 1 will disable batching)")
 	logBatchSizeMax := flag.Int("logs-batch-size-max", writer.DefaultLogBatchSizeMax, "Upper bound on -logs-batch-size.  Only increase this value if you have increased memory limits for the canary pods")
 
@@ -129,6 +153,40 @@ func main() {
 		os.Exit(1)
 	}
 
+	var tenants []string
+	if *verificationTenants != "" {
+		if !*push {
+			_, _ = fmt.Fprintf(os.Stderr, "Must set -push when specifying -verification-tenants\n")
+			os.Exit(1)
+		}
+		if *verificationStreamValue == *sValue {
+			_, _ = fmt.Fprintf(os.Stderr, "-verification-stream-value must be different from -streamvalue\n")
+			os.Exit(1)
+		}
+		for _, tenant := range strings.Split(*verificationTenants, ",") {
+			if tenant = strings.TrimSpace(tenant); tenant != "" {
+				tenants = append(tenants, tenant)
+			}
+		}
+	}
+
+	var kafkaCfg kafka.Config
+	if *kafkaAddress != "" {
+		flagext.DefaultValues(&kafkaCfg)
+		kafkaCfg.ReaderConfig.Address = *kafkaAddress
+		kafkaCfg.ReaderConfig.ClientID = "loki-canary"
+		kafkaCfg.Topic = *kafkaTopic
+		kafkaCfg.SASLUsername = *kafkaSASLUsername
+		if err := kafkaCfg.SASLPassword.Set(*kafkaSASLPassword); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Invalid Kafka SASL password: %s\n", err)
+			os.Exit(1)
+		}
+		if err := kafkaCfg.Validate(); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Kafka configuration error: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
 	var tlsConfig *tls.Config
 	tc := config.TLSConfig{}
 	if *certFile != "" || *keyFile != "" || *caFile != "" {
@@ -160,6 +218,11 @@ func main() {
 	}
 
 	sentChan := make(chan time.Time)
+	// The Kafka tracker receives the entries sent by the writer and forwards them to the comparator
+	writerSentChan := sentChan
+	if *kafkaAddress != "" {
+		writerSentChan = make(chan time.Time)
+	}
 	receivedChan := make(chan time.Time)
 
 	logger := log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr))
@@ -205,7 +268,7 @@ func main() {
 			entryWriter = writer.NewStreamWriter(os.Stdout, logger)
 		}
 
-		c.writer = writer.NewWriter(entryWriter, sentChan, *interval, *outOfOrderMin, *outOfOrderMax, *outOfOrderPercentage, *size, logger)
+		c.writer = writer.NewWriter(entryWriter, writerSentChan, *interval, *outOfOrderMin, *outOfOrderMax, *outOfOrderPercentage, *size, logger)
 		var err error
 		c.reader, err = reader.NewReader(os.Stderr, receivedChan, *useTLS, tlsConfig, *caFile, *certFile, *keyFile, *addr, *user, *pass, *tenantID, *queryTimeout, *lName, *lVal, *sName, *sValue, *interval, *queryAppend)
 		if err != nil {
@@ -213,6 +276,24 @@ func main() {
 			os.Exit(1)
 		}
 		c.comparator = comparator.NewComparator(os.Stderr, *wait, *maxWait, *pruneInterval, *spotCheckInterval, *spotCheckMax, *spotCheckQueryRate, *spotCheckWait, *metricTestInterval, *metricTestQueryRange, *cacheTestInterval, *cacheTestQueryRange, *cacheTestQueryNow, *interval, *buckets, sentChan, receivedChan, c.reader, true)
+
+		if *kafkaAddress != "" {
+			c.tracker, err = kafkatracker.NewTracker(os.Stderr, kafkaCfg, *kafkaConsumerGroup, c.reader,
+				*tenantID, *lName, *lVal, *sName, *sValue,
+				*dataobjCheckInterval, *maxWait, *dataobjMaxWait, *dataobjQueryLag,
+				writerSentChan, sentChan, logger)
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Unable to create Kafka tracker, check config: %s", err)
+				os.Exit(1)
+			}
+		}
+
+		// Verification tenants require -push, so the entry writer pushes to Loki
+		if len(tenants) > 0 {
+			c.verifier = verifier.NewVerifier(os.Stderr, entryWriter.(writer.StreamPusher), c.reader, tenants,
+				*lName, *lVal, *sName, *verificationStreamValue,
+				*verificationInterval, *verificationWait, *verificationOutOfOrderOffset, *writeTimeout)
+		}
 	}
 
 	startCanary()
@@ -259,7 +340,15 @@ func (c *canary) stop() {
 		return
 	}
 
+	if c.verifier != nil {
+		c.verifier.Stop()
+		c.verifier = nil
+	}
 	c.writer.Stop()
+	if c.tracker != nil {
+		c.tracker.Stop()
+		c.tracker = nil
+	}
 	c.reader.Stop()
 	c.comparator.Stop()
 
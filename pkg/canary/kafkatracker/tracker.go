@@ -0,0 +1,449 @@
+package kafkatracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/grafana/loki/v3/pkg/kafka"
+	"github.com/grafana/loki/v3/pkg/kafka/client"
+	"github.com/grafana/loki/v3/pkg/logqlmodel/stats"
+)
+
+const (
+	stageConsumer = "dataobj_consumer"
+	stageQuery    = "query"
+
+	statusSuccess = "success"
+	statusError   = "error"
+	statusNotUsed = "not_used"
+
+	// queryBatchSize is the maximum number of entries checked by a single query, as the reader queries at most 1000 entries
+	queryBatchSize = 1000
+
+	ErrEntryNotProduced     = "entry %v was not written to the Kafka topic within %v\n"
+	ErrEntryNotFlushed      = "entry %v written to partition %d at offset %d was not flushed to a data object within %v\n"
+	ErrEntryNotQueried      = "entry %v flushed to a data object from partition %d at offset %d was not returned by the v2 engine\n"
+	ErrV2EngineNotUsed      = "query for entries between %v and %v was not executed by the v2 engine, not checking %d entries\n"
+	ErrV2EngineQuery        = "failed to query entries between %v and %v, not checking %d entries: %v\n"
+	ErrCommittedOffsets     = "failed to fetch the offsets committed by the data object consumers: %v\n"
+	ErrKafkaFetch           = "failed to fetch records from partition %d: %v\n"
+	ErrKafkaRecordDecode    = "failed to decode record of partition %d at offset %d: %v\n"
+	DebugEntriesQueriedByV2 = "%d entries flushed to data objects were queried with the v2 engine, %d were missing\n"
+)
+
+var (
+	produceLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "loki_canary",
+		Name:      "kafka_produce_latency_seconds",
+		Help:      "is how long it takes for log lines to be written to the Kafka topic, by partition",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"partition"})
+	flushLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "loki_canary",
+		Name:      "dataobj_flush_latency_seconds",
+		Help:      "is how long it takes for log lines to be flushed to a data object by the data object consumers, by partition",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 11),
+	}, []string{"partition"})
+	unproducedEntries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "loki_canary",
+		Name:      "kafka_path_unproduced_entries_total",
+		Help:      "counts log lines which were not written to the Kafka topic",
+	})
+	missingEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "loki_canary",
+		Name:      "kafka_path_missing_entries_total",
+		Help:      "counts log lines lost after being written to the Kafka topic, by the stage they were lost at (dataobj_consumer/query) and partition",
+	}, []string{"stage", "partition"})
+	v2EngineQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "loki_canary",
+		Name:      "v2_engine_queries_total",
+		Help:      "counts the queries for log lines flushed to data objects, by status",
+	}, []string{"status"}) // status=success/error/not_used
+)
+
+// OffsetReader reads the offsets committed by the data object consumers
+type OffsetReader interface {
+	// CommittedOffsets returns the next offset to consume of each partition
+	CommittedOffsets(ctx context.Context) (map[int32]int64, error)
+}
+
+// Querier queries Loki for the canary entries
+type Querier interface {
+	QueryWithStats(start, end time.Time) ([]time.Time, stats.Result, error)
+}
+
+// entry is a canary entry tracked along the Kafka ingest path
+type entry struct {
+	ts time.Time
+	// sent is false for the entries read from the Kafka topic before the writer sent them,
+	// or which the writer never sent, e.g. the entries of a previous canary run
+	sent      bool
+	partition int32
+	// offset is -1 until the entry is found in the Kafka topic
+	offset     int64
+	producedAt time.Time
+	flushed    bool
+}
+
+func (e *entry) produced() bool {
+	return e.offset >= 0
+}
+
+// Tracker follows the canary entries along the Kafka ingest path:
+// the records written by the distributors to the Kafka topic, the data objects flushed by the data object consumers,
+// then the queries executed by the v2 engine on these data objects.
+//
+// An entry is flushed to a data object once the data object consumers committed an offset past the offset of its record.
+// Lost entries are reported with the partition and offset of their record, to tell at which stage they were lost.
+type Tracker struct {
+	w            io.Writer
+	client       *kgo.Client
+	offsets      OffsetReader
+	querier      Querier
+	tenantID     string
+	labelName    string
+	labelValue   string
+	streamName   string
+	streamValue  string
+	interval     time.Duration
+	maxWait      time.Duration
+	flushMaxWait time.Duration
+	queryLag     time.Duration
+
+	mtx     sync.Mutex
+	entries map[int64]*entry
+
+	sent    <-chan time.Time
+	forward chan<- time.Time
+	quit    chan struct{}
+	wg      sync.WaitGroup
+	cancel  context.CancelFunc
+}
+
+// NewTracker creates a tracker reading the canary entries written to the Kafka topic from now on.
+// The timestamps of the entries sent by the writer are received from sent and forwarded unchanged,
+// so that the tracker can sit between the writer and the comparator.
+// The v2 engine queries are disabled if queryLag is 0.
+func NewTracker(writer io.Writer,
+	kafkaCfg kafka.Config,
+	consumerGroup string,
+	querier Querier,
+	tenantID, labelName, labelValue, streamName, streamValue string,
+	interval, maxWait, flushMaxWait, queryLag time.Duration,
+	sent <-chan time.Time,
+	forward chan<- time.Time,
+	logger log.Logger,
+) (*Tracker, error) {
+	// The Kafka client metrics aren't registered, as the tracker is recreated every time the canary resumes
+	kafkaClient, err := client.NewReaderClient("loki-canary", kafkaCfg, logger, nil,
+		kgo.ConsumeTopics(kafkaCfg.Topic),
+		// Starting from the creation time rather than from the end doesn't miss the entries written while the client connects
+		kgo.ConsumeResetOffset(kgo.NewOffset().AfterMilli(time.Now().UnixMilli())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	t := newTracker(writer, &groupOffsetReader{
+		admin: kadm.NewClient(kafkaClient),
+		topic: kafkaCfg.Topic,
+		group: consumerGroup,
+	}, querier, tenantID, labelName, labelValue, streamName, streamValue, interval, maxWait, flushMaxWait, queryLag, sent, forward)
+	t.client = kafkaClient
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	decoder, err := kafka.NewDecoder()
+	if err != nil {
+		cancel()
+		kafkaClient.Close()
+		return nil, err
+	}
+	go t.consume(ctx, decoder)
+	t.wg.Add(2)
+	go t.run()
+	go t.checkLoop()
+
+	return t, nil
+}
+
+func newTracker(writer io.Writer,
+	offsets OffsetReader,
+	querier Querier,
+	tenantID, labelName, labelValue, streamName, streamValue string,
+	interval, maxWait, flushMaxWait, queryLag time.Duration,
+	sent <-chan time.Time,
+	forward chan<- time.Time,
+) *Tracker {
+	return &Tracker{
+		w:            writer,
+		offsets:      offsets,
+		querier:      querier,
+		tenantID:     tenantID,
+		labelName:    labelName,
+		labelValue:   labelValue,
+		streamName:   streamName,
+		streamValue:  streamValue,
+		interval:     interval,
+		maxWait:      maxWait,
+		flushMaxWait: flushMaxWait,
+		queryLag:     queryLag,
+		entries:      map[int64]*entry{},
+		sent:         sent,
+		forward:      forward,
+		quit:         make(chan struct{}),
+	}
+}
+
+func (t *Tracker) Stop() {
+	if t.quit != nil {
+		close(t.quit)
+		t.wg.Wait()
+		t.quit = nil
+		t.cancel()
+		t.client.Close()
+	}
+}
+
+// run forwards the timestamps of the entries sent by the writer, which blocks until they are received
+func (t *Tracker) run() {
+	defer t.wg.Done()
+
+	for {
+		select {
+		case ts := <-t.sent:
+			t.entrySent(ts)
+			select {
+			case t.forward <- ts:
+			case <-t.quit:
+				return
+			}
+		case <-t.quit:
+			return
+		}
+	}
+}
+
+// checkLoop checks the tracked entries every interval,
+// apart from run so that slow Kafka admin requests and queries don't hold back the writer
+func (t *Tracker) checkLoop() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.check(time.Now())
+		case <-t.quit:
+			return
+		}
+	}
+}
+
+// consume reads the records of the canary stream from the Kafka topic
+func (t *Tracker) consume(ctx context.Context, decoder *kafka.Decoder) {
+	for ctx.Err() == nil {
+		fetches := t.client.PollFetches(ctx)
+		fetches.EachError(func(_ string, partition int32, err error) {
+			if !errors.Is(err, context.Canceled) {
+				fmt.Fprintf(t.w, ErrKafkaFetch, partition, err)
+			}
+		})
+		fetches.EachRecord(func(record *kgo.Record) {
+			t.recordConsumed(decoder, record)
+		})
+	}
+}
+
+func (t *Tracker) recordConsumed(decoder *kafka.Decoder, record *kgo.Record) {
+	// The distributors use the tenant as the record key
+	if t.tenantID != "" && string(record.Key) != t.tenantID {
+		return
+	}
+
+	stream, lbls, err := decoder.Decode(record.Value)
+	if err != nil {
+		fmt.Fprintf(t.w, ErrKafkaRecordDecode, record.Partition, record.Offset, err)
+		return
+	}
+	if lbls.Get(t.labelName) != t.labelValue || lbls.Get(t.streamName) != t.streamValue {
+		return
+	}
+
+	for _, e := range stream.Entries {
+		t.entryProduced(e.Timestamp, record.Partition, record.Offset, record.Timestamp)
+	}
+}
+
+func (t *Tracker) entrySent(ts time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	e, ok := t.entries[ts.UnixNano()]
+	if !ok {
+		t.entries[ts.UnixNano()] = &entry{ts: ts, sent: true, offset: -1}
+		return
+	}
+	if !e.sent {
+		e.sent = true
+		if e.produced() {
+			produceLatency.WithLabelValues(partitionLabel(e.partition)).Observe(e.producedAt.Sub(ts).Seconds())
+		}
+	}
+}
+
+// entryProduced records the partition and offset of the record of an entry.
+// The record may be read before the entry is sent, so the entries which are not sent yet are kept until maxWait.
+func (t *Tracker) entryProduced(ts time.Time, partition int32, offset int64, produced time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	e, ok := t.entries[ts.UnixNano()]
+	if !ok {
+		e = &entry{ts: ts, offset: -1}
+		t.entries[ts.UnixNano()] = e
+	}
+	if e.produced() {
+		return
+	}
+	e.partition = partition
+	e.offset = offset
+	e.producedAt = produced
+	if e.sent {
+		produceLatency.WithLabelValues(partitionLabel(partition)).Observe(produced.Sub(ts).Seconds())
+	}
+}
+
+// check follows the tracked entries to the next stage of the Kafka ingest path,
+// and reports the entries which didn't reach it in time.
+// The flush latency is measured when the committed offsets are checked, so it is rounded up to the check interval.
+func (t *Tracker) check(now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.interval)
+	defer cancel()
+
+	committed, err := t.offsets.CommittedOffsets(ctx)
+	if err != nil {
+		fmt.Fprintf(t.w, ErrCommittedOffsets, err)
+	}
+
+	t.mtx.Lock()
+	var queryable []*entry
+	for key, e := range t.entries {
+		switch {
+		case !e.sent:
+			// Not an entry of this canary run
+			if now.Sub(e.ts) > t.maxWait {
+				delete(t.entries, key)
+			}
+			continue
+		case !e.produced():
+			if now.Sub(e.ts) > t.maxWait {
+				fmt.Fprintf(t.w, ErrEntryNotProduced, e.ts.UnixNano(), t.maxWait)
+				unproducedEntries.Inc()
+				delete(t.entries, key)
+			}
+		case !e.flushed:
+			if offset, ok := committed[e.partition]; ok && offset > e.offset {
+				e.flushed = true
+				flushLatency.WithLabelValues(partitionLabel(e.partition)).Observe(now.Sub(e.ts).Seconds())
+				if t.queryLag == 0 {
+					delete(t.entries, key)
+				}
+			} else if err == nil && now.Sub(e.ts) > t.flushMaxWait {
+				fmt.Fprintf(t.w, ErrEntryNotFlushed, e.ts.UnixNano(), e.partition, e.offset, t.flushMaxWait)
+				missingEntries.WithLabelValues(stageConsumer, partitionLabel(e.partition)).Inc()
+				delete(t.entries, key)
+			}
+		}
+
+		// The queriers only use the v2 engine for queries ending before the data object storage lag
+		if e.flushed && t.queryLag > 0 && e.ts.Before(now.Add(-t.queryLag)) {
+			queryable = append(queryable, e)
+			delete(t.entries, key)
+		}
+	}
+	t.mtx.Unlock()
+
+	// Each query covers at most queryBatchSize entries, so that none of them is cut off by the query limit
+	sort.Slice(queryable, func(i, j int) bool { return queryable[i].ts.Before(queryable[j].ts) })
+	for len(queryable) > 0 {
+		n := min(len(queryable), queryBatchSize)
+		t.queryEntries(queryable[:n])
+		queryable = queryable[n:]
+	}
+}
+
+// queryEntries checks that the entries flushed to data objects, sorted by timestamp, are returned by the v2 engine
+func (t *Tracker) queryEntries(entries []*entry) {
+	start, end := entries[0].ts, entries[len(entries)-1].ts.Add(time.Nanosecond)
+
+	tss, result, err := t.querier.QueryWithStats(start, end)
+	if err != nil {
+		fmt.Fprintf(t.w, ErrV2EngineQuery, start.UnixNano(), end.UnixNano(), len(entries), err)
+		v2EngineQueries.WithLabelValues(statusError).Inc()
+		return
+	}
+	if !result.Querier.Store.QueryUsedV2Engine {
+		fmt.Fprintf(t.w, ErrV2EngineNotUsed, start.UnixNano(), end.UnixNano(), len(entries))
+		v2EngineQueries.WithLabelValues(statusNotUsed).Inc()
+		return
+	}
+	v2EngineQueries.WithLabelValues(statusSuccess).Inc()
+
+	returned := make(map[int64]struct{}, len(tss))
+	for _, ts := range tss {
+		returned[ts.UnixNano()] = struct{}{}
+	}
+	missing := 0
+	for _, e := range entries {
+		if _, ok := returned[e.ts.UnixNano()]; !ok {
+			missing++
+			fmt.Fprintf(t.w, ErrEntryNotQueried, e.ts.UnixNano(), e.partition, e.offset)
+			missingEntries.WithLabelValues(stageQuery, partitionLabel(e.partition)).Inc()
+		}
+	}
+	fmt.Fprintf(t.w, DebugEntriesQueriedByV2, len(entries), missing)
+}
+
+func partitionLabel(partition int32) string {
+	return strconv.Itoa(int(partition))
+}
+
+// groupOffsetReader reads the offsets committed by a consumer group to the Kafka topic
+type groupOffsetReader struct {
+	admin *kadm.Client
+	topic string
+	group string
+}
+
+func (r *groupOffsetReader) CommittedOffsets(ctx context.Context) (map[int32]int64, error) {
+	offsets, err := r.admin.FetchOffsets(ctx, r.group)
+	if err != nil {
+		return nil, err
+	}
+	if err := offsets.Error(); err != nil {
+		return nil, err
+	}
+
+	committed := map[int32]int64{}
+	offsets.Each(func(o kadm.OffsetResponse) {
+		if o.Topic == r.topic && o.Err == nil {
+			committed[o.Partition] = o.At
+		}
+	})
+	return committed, nil
+}
@@ -0,0 +1,204 @@
+package kafkatracker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/kafka"
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logqlmodel/stats"
+)
+
+type fakeOffsets struct {
+	committed map[int32]int64
+	err       error
+}
+
+func (o *fakeOffsets) CommittedOffsets(_ context.Context) (map[int32]int64, error) {
+	return o.committed, o.err
+}
+
+type fakeQuerier struct {
+	returned []time.Time
+	v2Engine bool
+	err      error
+
+	queries int
+}
+
+func (q *fakeQuerier) QueryWithStats(start, end time.Time) ([]time.Time, stats.Result, error) {
+	q.queries++
+	if q.err != nil {
+		return nil, stats.Result{}, q.err
+	}
+
+	var tss []time.Time
+	for _, ts := range q.returned {
+		if !ts.Before(start) && ts.Before(end) {
+			tss = append(tss, ts)
+		}
+	}
+	// Like the reader, only return the most recent entries up to the query limit
+	if len(tss) > queryBatchSize {
+		tss = tss[len(tss)-queryBatchSize:]
+	}
+	result := stats.Result{}
+	result.Querier.Store.QueryUsedV2Engine = q.v2Engine
+	return tss, result, nil
+}
+
+func newTestTracker(offsets OffsetReader, querier Querier, queryLag time.Duration) *Tracker {
+	return newTracker(&bytes.Buffer{}, offsets, querier, "tenant", "name", "loki-canary", "stream", "stdout",
+		time.Minute, 5*time.Minute, time.Hour, queryLag, nil, nil)
+}
+
+func TestTrackerRecordConsumed(t *testing.T) {
+	tracker := newTestTracker(&fakeOffsets{}, &fakeQuerier{}, 0)
+	decoder, err := kafka.NewDecoder()
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0).UTC()
+	for _, tc := range []struct {
+		tenant string
+		labels labels.Labels
+		ts     time.Time
+	}{
+		{tenant: "tenant", labels: labels.FromStrings("name", "loki-canary", "stream", "stdout", "service_name", "loki-canary"), ts: now},
+		{tenant: "other", labels: labels.FromStrings("name", "loki-canary", "stream", "stdout"), ts: now.Add(time.Second)},
+		{tenant: "tenant", labels: labels.FromStrings("name", "loki-canary", "stream", "verification"), ts: now.Add(2 * time.Second)},
+	} {
+		records, err := kafka.Encode(3, tc.tenant, logproto.Stream{
+			Labels:  tc.labels.String(),
+			Entries: []logproto.Entry{{Timestamp: tc.ts, Line: "line"}},
+		}, kafka.MaxProducerRecordDataBytesLimit)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+
+		records[0].Offset = 42
+		records[0].Timestamp = tc.ts.Add(time.Second)
+		tracker.recordConsumed(decoder, records[0])
+	}
+
+	require.Equal(t, map[int64]*entry{
+		now.UnixNano(): {ts: now, partition: 3, offset: 42, producedAt: now.Add(time.Second)},
+	}, tracker.entries)
+}
+
+func TestTrackerCheck(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sent := func(tracker *Tracker, ts time.Time, partition int32, offset int64) {
+		tracker.entrySent(ts)
+		if offset >= 0 {
+			tracker.entryProduced(ts, partition, offset, ts)
+		}
+	}
+
+	t.Run("entries not produced", func(t *testing.T) {
+		tracker := newTestTracker(&fakeOffsets{}, &fakeQuerier{}, 0)
+		sent(tracker, now.Add(-10*time.Minute), 0, -1)
+		sent(tracker, now.Add(-time.Minute), 0, -1)
+
+		before := testutil.ToFloat64(unproducedEntries)
+		tracker.check(now)
+		require.Equal(t, before+1, testutil.ToFloat64(unproducedEntries))
+		require.Len(t, tracker.entries, 1)
+		require.Contains(t, tracker.entries, now.Add(-time.Minute).UnixNano())
+	})
+
+	t.Run("entries not sent", func(t *testing.T) {
+		tracker := newTestTracker(&fakeOffsets{committed: map[int32]int64{1: 100}}, &fakeQuerier{}, 0)
+		tracker.entryProduced(now.Add(-10*time.Minute), 1, 10, now.Add(-10*time.Minute))
+		tracker.entryProduced(now.Add(-time.Minute), 1, 11, now.Add(-time.Minute))
+
+		before := testutil.ToFloat64(unproducedEntries)
+		tracker.check(now)
+		require.Equal(t, before, testutil.ToFloat64(unproducedEntries))
+		// The entries not sent aren't followed to the next stages, but they are kept until maxWait in case they are sent later
+		require.Equal(t, map[int64]*entry{
+			now.Add(-time.Minute).UnixNano(): {ts: now.Add(-time.Minute), partition: 1, offset: 11, producedAt: now.Add(-time.Minute)},
+		}, tracker.entries)
+
+		tracker.entrySent(now.Add(-time.Minute))
+		tracker.check(now)
+		require.Empty(t, tracker.entries)
+	})
+
+	t.Run("entries flushed", func(t *testing.T) {
+		tracker := newTestTracker(&fakeOffsets{committed: map[int32]int64{1: 11}}, &fakeQuerier{}, 0)
+		sent(tracker, now.Add(-2*time.Minute), 1, 10)
+		sent(tracker, now.Add(-time.Minute), 1, 11)
+		sent(tracker, now.Add(-2*time.Hour), 2, 10)
+
+		before := testutil.ToFloat64(missingEntries.WithLabelValues(stageConsumer, "2"))
+		tracker.check(now)
+		require.Equal(t, before+1, testutil.ToFloat64(missingEntries.WithLabelValues(stageConsumer, "2")))
+		// The entry at the committed offset isn't flushed yet
+		require.Equal(t, map[int64]*entry{
+			now.Add(-time.Minute).UnixNano(): {ts: now.Add(-time.Minute), sent: true, partition: 1, offset: 11, producedAt: now.Add(-time.Minute)},
+		}, tracker.entries)
+	})
+
+	t.Run("committed offsets unavailable", func(t *testing.T) {
+		tracker := newTestTracker(&fakeOffsets{err: errors.New("unavailable")}, &fakeQuerier{}, 0)
+		sent(tracker, now.Add(-2*time.Hour), 1, 10)
+
+		tracker.check(now)
+		require.Len(t, tracker.entries, 1)
+	})
+
+	t.Run("entries queried with the v2 engine", func(t *testing.T) {
+		querier := &fakeQuerier{v2Engine: true, returned: []time.Time{now.Add(-3 * time.Hour)}}
+		tracker := newTestTracker(&fakeOffsets{committed: map[int32]int64{1: 100}}, querier, time.Hour)
+		sent(tracker, now.Add(-3*time.Hour), 1, 10)
+		sent(tracker, now.Add(-2*time.Hour), 1, 11)
+		sent(tracker, now.Add(-time.Minute), 1, 12)
+
+		beforeMissing := testutil.ToFloat64(missingEntries.WithLabelValues(stageQuery, "1"))
+		beforeQueries := testutil.ToFloat64(v2EngineQueries.WithLabelValues(statusSuccess))
+		tracker.check(now)
+		require.Equal(t, beforeMissing+1, testutil.ToFloat64(missingEntries.WithLabelValues(stageQuery, "1")))
+		require.Equal(t, beforeQueries+1, testutil.ToFloat64(v2EngineQueries.WithLabelValues(statusSuccess)))
+		// The last entry is flushed but too recent to be queried with the v2 engine
+		require.Equal(t, map[int64]*entry{
+			now.Add(-time.Minute).UnixNano(): {ts: now.Add(-time.Minute), sent: true, partition: 1, offset: 12, producedAt: now.Add(-time.Minute), flushed: true},
+		}, tracker.entries)
+
+		tracker.check(now)
+		require.Equal(t, 1, querier.queries)
+	})
+
+	t.Run("v2 engine not used", func(t *testing.T) {
+		querier := &fakeQuerier{returned: []time.Time{}}
+		tracker := newTestTracker(&fakeOffsets{committed: map[int32]int64{1: 100}}, querier, time.Hour)
+		sent(tracker, now.Add(-2*time.Hour), 1, 10)
+
+		beforeMissing := testutil.ToFloat64(missingEntries.WithLabelValues(stageQuery, "1"))
+		beforeQueries := testutil.ToFloat64(v2EngineQueries.WithLabelValues(statusNotUsed))
+		tracker.check(now)
+		require.Equal(t, beforeMissing, testutil.ToFloat64(missingEntries.WithLabelValues(stageQuery, "1")))
+		require.Equal(t, beforeQueries+1, testutil.ToFloat64(v2EngineQueries.WithLabelValues(statusNotUsed)))
+		require.Empty(t, tracker.entries)
+	})
+	t.Run("entries queried in batches", func(t *testing.T) {
+		querier := &fakeQuerier{v2Engine: true}
+		tracker := newTestTracker(&fakeOffsets{committed: map[int32]int64{1: 10000}}, querier, time.Hour)
+		for i := 0; i <= queryBatchSize; i++ {
+			ts := now.Add(-2*time.Hour + time.Duration(i)*time.Millisecond)
+			querier.returned = append(querier.returned, ts)
+			sent(tracker, ts, 1, int64(i))
+		}
+
+		before := testutil.ToFloat64(missingEntries.WithLabelValues(stageQuery, "1"))
+		tracker.check(now)
+		require.Equal(t, 2, querier.queries)
+		require.Equal(t, before, testutil.ToFloat64(missingEntries.WithLabelValues(stageQuery, "1")))
+		require.Empty(t, tracker.entries)
+	})
+}
@@ -25,7 +25,9 @@ import (
 
 	"github.com/grafana/loki/v3/pkg/loghttp"
 	"github.com/grafana/loki/v3/pkg/logqlmodel"
+	"github.com/grafana/loki/v3/pkg/logqlmodel/stats"
 	"github.com/grafana/loki/v3/pkg/util/build"
+	"github.com/grafana/loki/v3/pkg/util/httpreq"
 	"github.com/grafana/loki/v3/pkg/util/unmarshal"
 )
 
@@ -275,6 +277,13 @@ func (r *Reader) QueryCountOverTime(queryRange string, now time.Time, cache bool
 // Query will ask Loki for all canary timestamps in the requested timerange.
 // Query blocks if a previous query has failed until the appropriate backoff time has been reached.
 func (r *Reader) Query(start time.Time, end time.Time) ([]time.Time, error) {
+	tss, _, err := r.QueryWithStats(start, end)
+	return tss, err
+}
+
+// QueryWithStats is like Query, but also returns the statistics of the query execution,
+// e.g. to know which query engine Loki used.
+func (r *Reader) QueryWithStats(start time.Time, end time.Time) ([]time.Time, stats.Result, error) {
 	r.backoffMtx.RLock()
 	next := r.nextQuery
 	r.backoffMtx.RUnlock()
@@ -305,7 +314,7 @@ func (r *Reader) Query(start time.Time, end time.Time) ([]time.Time, error) {
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
-		return nil, err
+		return nil, stats.Result{}, err
 	}
 
 	if r.user != "" {
@@ -318,7 +327,7 @@ func (r *Reader) Query(start time.Time, end time.Time) ([]time.Time, error) {
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		return nil, errors.Wrap(err, "query_range request failed")
+		return nil, stats.Result{}, errors.Wrap(err, "query_range request failed")
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -331,7 +340,7 @@ func (r *Reader) Query(start time.Time, end time.Time) ([]time.Time, error) {
 		r.nextQuery = nextBackoff(r.w, resp.StatusCode, r.backoff)
 		r.backoffMtx.Unlock()
 		buf, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("error response from server: %s (%v)", string(buf), err)
+		return nil, stats.Result{}, fmt.Errorf("error response from server: %s (%v)", string(buf), err)
 	}
 	// No Errors, reset backoff
 	r.backoffMtx.Lock()
@@ -341,7 +350,7 @@ func (r *Reader) Query(start time.Time, end time.Time) ([]time.Time, error) {
 	var decoded loghttp.QueryResponse
 	err = json.NewDecoder(resp.Body).Decode(&decoded)
 	if err != nil {
-		return nil, err
+		return nil, stats.Result{}, err
 	}
 
 	tss := []time.Time{}
@@ -359,10 +368,74 @@ func (r *Reader) Query(start time.Time, end time.Time) ([]time.Time, error) {
 			}
 		}
 	default:
-		return nil, fmt.Errorf("unexpected result type, expected a log stream result instead received %v", value.Type())
+		return nil, stats.Result{}, fmt.Errorf("unexpected result type, expected a log stream result instead received %v", value.Type())
+	}
+
+	return tss, decoded.Data.Statistics, nil
+}
+
+// QueryStreams runs the log query on behalf of the tenant over the requested timerange and returns the streams.
+// Structured metadata is returned separately from the stream labels.
+// Unlike Query, QueryStreams doesn't back off after failed queries as it is meant to be run rarely.
+func (r *Reader) QueryStreams(tenantID, query string, start, end time.Time) (loghttp.Streams, error) {
+	scheme := "http"
+	if r.useTLS {
+		scheme = "https"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   r.addr,
+		Path:   "/loki/api/v1/query_range",
+		RawQuery: fmt.Sprintf("start=%d&end=%d", start.UnixNano(), end.UnixNano()) +
+			"&query=" + url.QueryEscape(query) +
+			"&limit=1000",
+	}
+	fmt.Fprintf(r.w, "Querying loki for logs of tenant %s with query: %v\n", tenantID, u.String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.queryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.user != "" {
+		req.SetBasicAuth(r.user, r.pass)
+	}
+	if tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", tenantID)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(httpreq.LokiEncodingFlagsHeader, string(httpreq.FlagCategorizeLabels))
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "query_range request failed")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Println("error closing body", err)
+		}
+	}()
+
+	if resp.StatusCode/100 != 2 {
+		buf, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error response from server: %s (%v)", string(buf), resp.StatusCode)
+	}
+
+	var decoded loghttp.QueryResponse
+	err = json.NewDecoder(resp.Body).Decode(&decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	streams, ok := decoded.Data.Result.(loghttp.Streams)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type, expected a log stream result instead received %v", decoded.Data.ResultType)
 	}
 
-	return tss, nil
+	return streams, nil
 }
 
 // run uses the established websocket connection to tail logs from Loki
@@ -0,0 +1,300 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/v3/pkg/loghttp"
+	"github.com/grafana/loki/v3/pkg/logproto"
+)
+
+const (
+	// Structured metadata attached to the verification entries
+	runLabel    = "canary_run"
+	tenantLabel = "canary_tenant"
+	checkLabel  = "canary_check"
+
+	structuredMetadataCheck = "structured_metadata"
+	outOfOrderCheck         = "out_of_order"
+
+	statusSuccess = "success"
+	statusFailure = "failure"
+	statusError   = "error"
+
+	ErrStructuredMetadataNotReturned = "structured metadata entry %v of tenant %s was not returned with its structured metadata\n"
+	ErrOutOfOrderEntryRejected       = "out of order entry %v of tenant %s was rejected with status %d: %v\n"
+	ErrOutOfOrderEntryNotReturned    = "accepted out of order entry %v of tenant %s was not returned\n"
+	ErrTenantIsolation               = "query of tenant %s returned entries written to tenants %v\n"
+	ErrVerificationWrite             = "failed to write verification entries of tenant %s: %v\n"
+	ErrVerificationQuery             = "failed to query verification entries of tenant %s: %v\n"
+)
+
+var (
+	structuredMetadataChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "loki_canary",
+		Name:      "structured_metadata_checks_total",
+		Help:      "counts the checks that structured metadata is returned as written, by tenant and status",
+	}, []string{"tenant", "status"}) // status=success/failure/error
+	outOfOrderChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "loki_canary",
+		Name:      "out_of_order_checks_total",
+		Help:      "counts the checks that out of order entries within the accepted window are accepted and returned, by tenant and status",
+	}, []string{"tenant", "status"}) // status=success/failure/error
+	tenantIsolationChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "loki_canary",
+		Name:      "tenant_isolation_checks_total",
+		Help:      "counts the checks that the queries of a tenant don't return entries written to the other tenants, by tenant and status",
+	}, []string{"tenant", "status"}) // status=success/failure/error
+)
+
+// Pusher pushes streams to Loki on behalf of a tenant
+type Pusher interface {
+	PushStreams(ctx context.Context, tenantID string, streams []logproto.Stream) (int, error)
+}
+
+// Querier queries the streams of a tenant from Loki, with their structured metadata
+type Querier interface {
+	QueryStreams(tenantID, query string, start, end time.Time) (loghttp.Streams, error)
+}
+
+// Verifier periodically writes entries with structured metadata and out of order timestamps to several tenants,
+// and verifies that they are returned by Loki as written:
+//   - the structured metadata of the entries round-trips,
+//   - the out of order entries are accepted and returned,
+//   - the queries of a tenant only return the entries written to this tenant.
+//
+// All the entries of a verification round have the same run ID in their structured metadata,
+// so that a query for the run ID returning entries of other tenants breaks tenant isolation.
+type Verifier struct {
+	w                io.Writer
+	pusher           Pusher
+	querier          Querier
+	tenants          []string
+	stream           model.LabelSet
+	interval         time.Duration
+	wait             time.Duration
+	outOfOrderOffset time.Duration
+	timeout          time.Duration
+	quit             chan struct{}
+	done             chan struct{}
+}
+
+func NewVerifier(writer io.Writer,
+	pusher Pusher,
+	querier Querier,
+	tenants []string,
+	labelName, labelValue, streamName, streamValue string,
+	interval, wait, outOfOrderOffset, timeout time.Duration,
+) *Verifier {
+	v := &Verifier{
+		w:       writer,
+		pusher:  pusher,
+		querier: querier,
+		tenants: tenants,
+		stream: model.LabelSet{
+			model.LabelName(labelName):  model.LabelValue(labelValue),
+			model.LabelName(streamName): model.LabelValue(streamValue),
+		},
+		interval:         interval,
+		wait:             wait,
+		outOfOrderOffset: outOfOrderOffset,
+		timeout:          timeout,
+		quit:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+
+	go v.run()
+
+	return v
+}
+
+func (v *Verifier) Stop() {
+	if v.quit != nil {
+		close(v.quit)
+		<-v.done
+		v.quit = nil
+	}
+}
+
+func (v *Verifier) run() {
+	t := time.NewTicker(v.interval)
+	defer func() {
+		t.Stop()
+		close(v.done)
+	}()
+
+	for {
+		select {
+		case <-t.C:
+			if !v.verify(time.Now()) {
+				return
+			}
+		case <-v.quit:
+			return
+		}
+	}
+}
+
+// verification is the outcome of writing the entries of a verification round to a tenant
+type verification struct {
+	written           bool
+	outOfOrderWritten bool
+}
+
+// verify runs a verification round, returning false if the verifier was stopped while waiting for the entries.
+func (v *Verifier) verify(now time.Time) bool {
+	run := strconv.FormatInt(now.UnixNano(), 10)
+
+	verifications := make(map[string]verification, len(v.tenants))
+	for _, tenant := range v.tenants {
+		verifications[tenant] = v.write(tenant, run, now)
+	}
+
+	select {
+	case <-time.After(v.wait):
+	case <-v.quit:
+		return false
+	}
+
+	for _, tenant := range v.tenants {
+		v.check(tenant, run, now, verifications[tenant])
+	}
+	return true
+}
+
+// write writes the entries of a verification round to the tenant:
+// an entry with structured metadata, then an entry older than it by the out of order offset.
+func (v *Verifier) write(tenant, run string, now time.Time) verification {
+	ctx, cancel := context.WithTimeout(context.Background(), v.timeout)
+	defer cancel()
+
+	if _, err := v.pusher.PushStreams(ctx, tenant, []logproto.Stream{v.buildStream(tenant, run, structuredMetadataCheck, now)}); err != nil {
+		fmt.Fprintf(v.w, ErrVerificationWrite, tenant, err)
+		structuredMetadataChecks.WithLabelValues(tenant, statusError).Inc()
+		outOfOrderChecks.WithLabelValues(tenant, statusError).Inc()
+		v.countTenantIsolationCheck(tenant, statusError)
+		return verification{}
+	}
+
+	outOfOrderTs := now.Add(-v.outOfOrderOffset)
+	status, err := v.pusher.PushStreams(ctx, tenant, []logproto.Stream{v.buildStream(tenant, run, outOfOrderCheck, outOfOrderTs)})
+	switch {
+	case err == nil:
+		return verification{written: true, outOfOrderWritten: true}
+	case status/100 == 4 && status != 429:
+		// Loki rejected the entry, which is what this check is about
+		fmt.Fprintf(v.w, ErrOutOfOrderEntryRejected, outOfOrderTs.UnixNano(), tenant, status, err)
+		outOfOrderChecks.WithLabelValues(tenant, statusFailure).Inc()
+	default:
+		fmt.Fprintf(v.w, ErrVerificationWrite, tenant, err)
+		outOfOrderChecks.WithLabelValues(tenant, statusError).Inc()
+	}
+	return verification{written: true}
+}
+
+func (v *Verifier) buildStream(tenant, run, check string, ts time.Time) logproto.Stream {
+	return logproto.Stream{
+		Labels: v.stream.String(),
+		Entries: []logproto.Entry{
+			{
+				Timestamp: ts,
+				Line:      fmt.Sprintf("%d %s", ts.UnixNano(), check),
+				StructuredMetadata: logproto.FromLabelsToLabelAdapters(labels.FromStrings(
+					runLabel, run,
+					tenantLabel, tenant,
+					checkLabel, check,
+				)),
+			},
+		},
+		Hash: uint64(v.stream.Fingerprint()),
+	}
+}
+
+// check queries the entries of the verification round written to the tenant, and checks them
+func (v *Verifier) check(tenant, run string, now time.Time, verification verification) {
+	if !verification.written {
+		return
+	}
+
+	query := fmt.Sprintf("%s | %s=%q", v.stream.String(), runLabel, run)
+	streams, err := v.querier.QueryStreams(tenant, query, now.Add(-v.outOfOrderOffset).Add(-time.Second), now.Add(time.Second))
+	if err != nil {
+		fmt.Fprintf(v.w, ErrVerificationQuery, tenant, err)
+		structuredMetadataChecks.WithLabelValues(tenant, statusError).Inc()
+		if verification.outOfOrderWritten {
+			outOfOrderChecks.WithLabelValues(tenant, statusError).Inc()
+		}
+		v.countTenantIsolationCheck(tenant, statusError)
+		return
+	}
+
+	var structuredMetadataFound, outOfOrderFound bool
+	otherTenants := map[string]struct{}{}
+	for _, stream := range streams {
+		for _, entry := range stream.Entries {
+			// Structured metadata is part of the stream labels if Loki doesn't categorize labels
+			metadata := func(name string) string {
+				if value := entry.StructuredMetadata.Get(name); value != "" {
+					return value
+				}
+				return stream.Labels[name]
+			}
+
+			if entryTenant := metadata(tenantLabel); entryTenant != tenant {
+				otherTenants[entryTenant] = struct{}{}
+				continue
+			}
+
+			switch metadata(checkLabel) {
+			case structuredMetadataCheck:
+				structuredMetadataFound = structuredMetadataFound || (entry.Timestamp.Equal(now) && metadata(runLabel) == run)
+			case outOfOrderCheck:
+				outOfOrderFound = outOfOrderFound || entry.Timestamp.Equal(now.Add(-v.outOfOrderOffset))
+			}
+		}
+	}
+
+	if structuredMetadataFound {
+		structuredMetadataChecks.WithLabelValues(tenant, statusSuccess).Inc()
+	} else {
+		fmt.Fprintf(v.w, ErrStructuredMetadataNotReturned, now.UnixNano(), tenant)
+		structuredMetadataChecks.WithLabelValues(tenant, statusFailure).Inc()
+	}
+
+	if verification.outOfOrderWritten {
+		if outOfOrderFound {
+			outOfOrderChecks.WithLabelValues(tenant, statusSuccess).Inc()
+		} else {
+			fmt.Fprintf(v.w, ErrOutOfOrderEntryNotReturned, now.Add(-v.outOfOrderOffset).UnixNano(), tenant)
+			outOfOrderChecks.WithLabelValues(tenant, statusFailure).Inc()
+		}
+	}
+
+	if len(otherTenants) == 0 {
+		v.countTenantIsolationCheck(tenant, statusSuccess)
+	} else {
+		tenants := make([]string, 0, len(otherTenants))
+		for t := range otherTenants {
+			tenants = append(tenants, t)
+		}
+		sort.Strings(tenants)
+		fmt.Fprintf(v.w, ErrTenantIsolation, tenant, tenants)
+		v.countTenantIsolationCheck(tenant, statusFailure)
+	}
+}
+
+// countTenantIsolationCheck counts a tenant isolation check, which is only meaningful with several tenants
+func (v *Verifier) countTenantIsolationCheck(tenant, status string) {
+	if len(v.tenants) > 1 {
+		tenantIsolationChecks.WithLabelValues(tenant, status).Inc()
+	}
+}
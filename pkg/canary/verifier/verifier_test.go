@@ -0,0 +1,191 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/loghttp"
+	"github.com/grafana/loki/v3/pkg/logproto"
+)
+
+// fakeLoki stores the pushed entries by tenant and returns the ones of the queried run
+type fakeLoki struct {
+	mtx     sync.Mutex
+	entries map[string][]logproto.Entry
+
+	// rejectOlderThan rejects the entries older than the newest entry of the tenant by more than this duration
+	rejectOlderThan time.Duration
+	// dropStructuredMetadata drops the structured metadata of the entries
+	dropStructuredMetadata bool
+	// leakTo returns the entries of the tenant to the queries of another tenant
+	leakTo map[string]string
+}
+
+func (l *fakeLoki) PushStreams(_ context.Context, tenantID string, streams []logproto.Stream) (int, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	for _, stream := range streams {
+		for _, entry := range stream.Entries {
+			for _, existing := range l.entries[tenantID] {
+				if l.rejectOlderThan > 0 && existing.Timestamp.Sub(entry.Timestamp) > l.rejectOlderThan {
+					return 400, errors.New("entry too far behind")
+				}
+			}
+			if l.dropStructuredMetadata {
+				entry.StructuredMetadata = nil
+			}
+			l.entries[tenantID] = append(l.entries[tenantID], entry)
+		}
+	}
+	return 204, nil
+}
+
+func (l *fakeLoki) QueryStreams(tenantID, _ string, _, _ time.Time) (loghttp.Streams, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	stream := loghttp.Stream{Labels: loghttp.LabelSet{"name": "loki-canary", "stream": "checks"}}
+	for tenant, entries := range l.entries {
+		if tenant != tenantID && l.leakTo[tenant] != tenantID {
+			continue
+		}
+		for _, e := range entries {
+			// the query filters on the run of the structured metadata
+			if len(e.StructuredMetadata) == 0 {
+				continue
+			}
+			stream.Entries = append(stream.Entries, loghttp.Entry{
+				Timestamp:          e.Timestamp,
+				Line:               e.Line,
+				StructuredMetadata: logproto.FromLabelAdaptersToLabels(e.StructuredMetadata),
+			})
+		}
+	}
+	return loghttp.Streams{stream}, nil
+}
+
+func TestVerifier(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		loki    *fakeLoki
+		tenants []string
+
+		expectedStructuredMetadata map[string]string
+		expectedOutOfOrder         map[string]string
+		expectedTenantIsolation    map[string]string
+	}{
+		{
+			name:                       "success",
+			loki:                       &fakeLoki{},
+			tenants:                    []string{"success-a", "success-b"},
+			expectedStructuredMetadata: map[string]string{"success-a": statusSuccess, "success-b": statusSuccess},
+			expectedOutOfOrder:         map[string]string{"success-a": statusSuccess, "success-b": statusSuccess},
+			expectedTenantIsolation:    map[string]string{"success-a": statusSuccess, "success-b": statusSuccess},
+		},
+		{
+			name:                       "out of order entries rejected",
+			loki:                       &fakeLoki{rejectOlderThan: time.Minute},
+			tenants:                    []string{"rejected-a", "rejected-b"},
+			expectedStructuredMetadata: map[string]string{"rejected-a": statusSuccess, "rejected-b": statusSuccess},
+			expectedOutOfOrder:         map[string]string{"rejected-a": statusFailure, "rejected-b": statusFailure},
+			expectedTenantIsolation:    map[string]string{"rejected-a": statusSuccess, "rejected-b": statusSuccess},
+		},
+		{
+			name:                       "structured metadata dropped",
+			loki:                       &fakeLoki{dropStructuredMetadata: true},
+			tenants:                    []string{"dropped-a", "dropped-b"},
+			expectedStructuredMetadata: map[string]string{"dropped-a": statusFailure, "dropped-b": statusFailure},
+			expectedOutOfOrder:         map[string]string{"dropped-a": statusFailure, "dropped-b": statusFailure},
+			expectedTenantIsolation:    map[string]string{"dropped-a": statusSuccess, "dropped-b": statusSuccess},
+		},
+		{
+			name:                       "tenant isolation broken",
+			loki:                       &fakeLoki{leakTo: map[string]string{"leak-a": "leak-b"}},
+			tenants:                    []string{"leak-a", "leak-b"},
+			expectedStructuredMetadata: map[string]string{"leak-a": statusSuccess, "leak-b": statusSuccess},
+			expectedOutOfOrder:         map[string]string{"leak-a": statusSuccess, "leak-b": statusSuccess},
+			expectedTenantIsolation:    map[string]string{"leak-a": statusSuccess, "leak-b": statusFailure},
+		},
+		{
+			name:                       "single tenant",
+			loki:                       &fakeLoki{},
+			tenants:                    []string{"single"},
+			expectedStructuredMetadata: map[string]string{"single": statusSuccess},
+			expectedOutOfOrder:         map[string]string{"single": statusSuccess},
+			expectedTenantIsolation:    map[string]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.loki.entries = map[string][]logproto.Entry{}
+			v := &Verifier{
+				w:                &bytes.Buffer{},
+				pusher:           tc.loki,
+				querier:          tc.loki,
+				tenants:          tc.tenants,
+				stream:           model.LabelSet{"name": "loki-canary", "stream": "checks"},
+				outOfOrderOffset: 5 * time.Minute,
+				timeout:          time.Second,
+				quit:             make(chan struct{}),
+			}
+			require.True(t, v.verify(time.Now()))
+
+			for _, tenant := range tc.tenants {
+				requireCounted(t, structuredMetadataChecks, tenant, tc.expectedStructuredMetadata[tenant])
+				requireCounted(t, outOfOrderChecks, tenant, tc.expectedOutOfOrder[tenant])
+				requireCounted(t, tenantIsolationChecks, tenant, tc.expectedTenantIsolation[tenant])
+			}
+		})
+	}
+}
+
+func TestVerifierEntries(t *testing.T) {
+	loki := &fakeLoki{entries: map[string][]logproto.Entry{}}
+	v := &Verifier{
+		w:                &bytes.Buffer{},
+		pusher:           loki,
+		querier:          loki,
+		tenants:          []string{"entries"},
+		stream:           model.LabelSet{"name": "loki-canary", "stream": "checks"},
+		outOfOrderOffset: 5 * time.Minute,
+		timeout:          time.Second,
+	}
+
+	now := time.Unix(0, 1700000000000000000)
+	require.Equal(t, verification{written: true, outOfOrderWritten: true}, v.write("entries", "run", now))
+	require.Equal(t, []logproto.Entry{
+		{
+			Timestamp:          now,
+			Line:               "1700000000000000000 structured_metadata",
+			StructuredMetadata: logproto.FromLabelsToLabelAdapters(labels.FromStrings(runLabel, "run", tenantLabel, "entries", checkLabel, structuredMetadataCheck)),
+		},
+		{
+			Timestamp:          now.Add(-5 * time.Minute),
+			Line:               "1699999700000000000 out_of_order",
+			StructuredMetadata: logproto.FromLabelsToLabelAdapters(labels.FromStrings(runLabel, "run", tenantLabel, "entries", checkLabel, outOfOrderCheck)),
+		},
+	}, loki.entries["entries"])
+}
+
+// requireCounted checks that a single check of the tenant was counted, with the expected status if any
+func requireCounted(t *testing.T, counter *prometheus.CounterVec, tenant, expectedStatus string) {
+	t.Helper()
+
+	for _, status := range []string{statusSuccess, statusFailure, statusError} {
+		expected := 0.0
+		if status == expectedStatus {
+			expected = 1
+		}
+		require.Equal(t, expected, testutil.ToFloat64(counter.WithLabelValues(tenant, status)), "status %s", status)
+	}
+}
@@ -51,6 +51,11 @@ func (p *BatchedPush) WriteEntry(ts time.Time, e string) {
 	p.pusher.WriteEntry(ts, e)
 }
 
+// implements `StreamPusher.PushStreams` by delegating to the `Push` reference
+func (p *BatchedPush) PushStreams(ctx context.Context, tenantID string, streams []logproto.Stream) (int, error) {
+	return p.pusher.PushStreams(ctx, tenantID, streams)
+}
+
 // implements `EntryWriter.Stop` by delegating to the `Push` reference
 func (p *BatchedPush) Stop() {
 	p.pusher.Stop()
@@ -107,7 +112,7 @@ func (p *BatchedPush) run() {
 			level.Error(p.pusher.logger).Log("msg", "failed to build payload", "err", err)
 		} else {
 			for {
-				status, err := p.pusher.send(ctx, payload)
+				status, err := p.pusher.send(ctx, p.pusher.tenantID, payload)
 				if err == nil {
 					break
 				}
@@ -32,6 +32,12 @@ const (
 
 var defaultUserAgent = fmt.Sprintf("canary-push/%s", build.GetVersion().Version)
 
+// StreamPusher pushes streams to Loki on behalf of any tenant, in a single attempt.
+// It returns the status code of the response, or -1 if the request failed before getting one.
+type StreamPusher interface {
+	PushStreams(ctx context.Context, tenantID string, streams []logproto.Stream) (int, error)
+}
+
 // Push is a io.Writer, that writes given log entries by pushing
 // directly to the given loki server URL. Each `Push` instance handles for a single tenant.
 type Push struct {
@@ -237,7 +243,7 @@ func (p *Push) run() {
 			// send log with retry
 			for {
 				status := 0
-				status, err = p.send(ctx, payload)
+				status, err = p.send(ctx, p.tenantID, payload)
 				if err == nil {
 					break
 				}
@@ -258,8 +264,17 @@ func (p *Push) run() {
 	}
 }
 
-// send makes one attempt to send the payload to Loki
-func (p *Push) send(ctx context.Context, payload []byte) (int, error) {
+// PushStreams implements StreamPusher
+func (p *Push) PushStreams(ctx context.Context, tenantID string, streams []logproto.Stream) (int, error) {
+	payload, err := p.serializePayload(&logproto.PushRequest{Streams: streams})
+	if err != nil {
+		return -1, err
+	}
+	return p.send(ctx, tenantID, payload)
+}
+
+// send makes one attempt to send the payload to Loki on behalf of the tenant
+func (p *Push) send(ctx context.Context, tenantID string, payload []byte) (int, error) {
 	var (
 		err  error
 		resp *http.Response
@@ -275,8 +290,8 @@ func (p *Push) send(ctx context.Context, payload []byte) (int, error) {
 	req.Header.Set("User-Agent", p.userAgent)
 
 	// set org-id
-	if p.tenantID != "" {
-		req.Header.Set("X-Scope-OrgID", p.tenantID)
+	if tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", tenantID)
 	}
 
 	// basic auth if provided
@@ -1,6 +1,7 @@
 package writer
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"math"
@@ -15,6 +16,7 @@ import (
 	"github.com/grafana/dskit/backoff"
 	"github.com/prometheus/common/config"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -105,6 +107,36 @@ func Test_Push(t *testing.T) {
 	assertResponse(t, resp, true, labelSet("name", "loki-canary", "pod", "abc"), ts, payload, 1)
 }
 
+// test pushing streams on behalf of other tenants, whether batching or not
+func Test_PushStreams(t *testing.T) {
+	testCfg := newTestConfig(t)
+	defer func() {
+		testCfg.mock.Close()
+	}()
+
+	for _, logBatchSize := range []int{1, 10} {
+		push, err := newPush(testCfg, logBatchSize)
+		require.NoError(t, err)
+
+		stream := logproto.Stream{
+			Labels: labelSet("name", "loki-canary", "stream", "verification").String(),
+			Entries: []logproto.Entry{{
+				Timestamp:          time.Now().UTC(),
+				Line:               "line",
+				StructuredMetadata: logproto.FromLabelsToLabelAdapters(labels.FromStrings("canary_tenant", "other")),
+			}},
+		}
+		status, err := push.(StreamPusher).PushStreams(context.Background(), "other", []logproto.Stream{stream})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status)
+
+		resp := <-testCfg.responses
+		assert.Equal(t, "other", resp.tenantID)
+		assert.Equal(t, []logproto.Stream{stream}, resp.pushReq.Streams)
+		push.Stop()
+	}
+}
+
 // test batching log lines and ensure the testing resp contains exactly 10 unique entries
 func Test_BatchedPush(t *testing.T) {
 	testCfg := newTestConfig(t)
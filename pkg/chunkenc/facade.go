@@ -106,8 +106,8 @@ func (f Facade) LokiChunk() Chunk {
 	return f.c
 }
 
-func (f Facade) Rewrite(filter filter.Func) (chunk.Data, error) {
-	newChunk, err := f.c.Rewrite(filter)
+func (f Facade) Rewrite(filter filter.Func, rewrite filter.RewriteFunc) (chunk.Data, error) {
+	newChunk, err := f.c.Rewrite(filter, rewrite)
 	if err != nil {
 		return nil, err
 	}
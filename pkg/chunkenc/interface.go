@@ -69,7 +69,7 @@ type Chunk interface {
 	CompressedSize() int
 	Close() error
 	Encoding() compression.Codec
-	Rewrite(filter filter.Func) (Chunk, error)
+	Rewrite(filter filter.Func, rewrite filter.RewriteFunc) (Chunk, error)
 }
 
 // Block is a chunk block.
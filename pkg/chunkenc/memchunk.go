@@ -1161,26 +1161,34 @@ func (c *MemChunk) Blocks(mintT, maxtT time.Time) []Block {
 
 // Rewrite rewrites the chunk after filtering out lines based on response from filter.Func.
 // Filter.Func would be called for each log entry, and the ones for which it returns true would be removed.
+// If set, filter.RewriteFunc would then be called for each remaining log entry to rewrite it.
 // The new chunk would have data in the same order as the original chunk.
-func (c *MemChunk) Rewrite(filter filter.Func) (Chunk, error) {
+func (c *MemChunk) Rewrite(filter filter.Func, rewrite filter.RewriteFunc) (Chunk, error) {
 	newChunk := NewMemChunk(c.format, c.Encoding(), c.headFmt, math.MaxInt, math.MaxInt)
 
 	// iterate through the entries block-by-block to avoid re-encoding unchanged blocks
 	for _, b := range c.blocks {
 		itr := newBufferedIterator(context.Background(), compression.GetReaderPool(c.encoding), b.b, c.format, c.symbolizer)
 
-		entriesRemoved := false
+		entriesChanged := false
 		for itr.Next() {
 			timestamp := time.Unix(0, itr.currTs)
 			line := string(itr.currLine)
-			if filter != nil && filter(timestamp, line, itr.currStructuredMetadata) {
-				entriesRemoved = true
+			structuredMetadata := itr.currStructuredMetadata
+			if filter != nil && filter(timestamp, line, structuredMetadata) {
+				entriesChanged = true
 				continue
 			}
+			if rewrite != nil {
+				if newLine, newStructuredMetadata, ok := rewrite(timestamp, line, structuredMetadata); ok {
+					line, structuredMetadata = newLine, newStructuredMetadata
+					entriesChanged = true
+				}
+			}
 			entry := logproto.Entry{
 				Timestamp:          timestamp,
 				Line:               line,
-				StructuredMetadata: logproto.FromLabelsToLabelAdapters(itr.currStructuredMetadata),
+				StructuredMetadata: logproto.FromLabelsToLabelAdapters(structuredMetadata),
 			}
 			if _, err := newChunk.Append(&entry); err != nil {
 				return nil, err
@@ -1191,8 +1199,8 @@ func (c *MemChunk) Rewrite(filter filter.Func) (Chunk, error) {
 			return nil, err
 		}
 
-		if !entriesRemoved {
-			// no entries were removed so copy the existing block as is to save on the work of re-encoding it
+		if !entriesChanged {
+			// no entries were removed or rewritten so copy the existing block as is to save on the work of re-encoding it
 			newChunk.blocks = append(newChunk.blocks, b)
 			newChunk.cutBlockSize += len(b.b)
 
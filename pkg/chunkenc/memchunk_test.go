@@ -1576,6 +1576,7 @@ func TestMemChunk_Rebound(t *testing.T) {
 		for _, tc := range []struct {
 			name                 string
 			filterFunc           filter.Func
+			rewriteFunc          filter.RewriteFunc
 			shouldNotChangeChunk bool
 			expectedNumBlocks    int
 			err                  error
@@ -1634,9 +1635,37 @@ func TestMemChunk_Rebound(t *testing.T) {
 				shouldNotChangeChunk: true,
 				expectedNumBlocks:    4,
 			},
+			{
+				name: "rewrite entries of the last block",
+				rewriteFunc: func(ts time.Time, s string, structuredMetadata labels.Labels) (string, labels.Labels, bool) {
+					if ts.UnixNano() < originalChunk.blocks[3].mint {
+						return "", labels.EmptyLabels(), false
+					}
+					return "rewritten " + s, structuredMetadata, true
+				},
+				expectedNumBlocks: 4,
+			},
+			{
+				name: "remove first half and rewrite the rest",
+				filterFunc: func(ts time.Time, _ string, _ labels.Labels) bool {
+					return ts.UnixNano() <= originalChunk.blocks[1].maxt
+				},
+				rewriteFunc: func(_ time.Time, s string, structuredMetadata labels.Labels) (string, labels.Labels, bool) {
+					return "rewritten " + s, structuredMetadata, true
+				},
+				expectedNumBlocks: 2,
+			},
+			{
+				name: "rewrite nothing",
+				rewriteFunc: func(_ time.Time, _ string, _ labels.Labels) (string, labels.Labels, bool) {
+					return "", labels.EmptyLabels(), false
+				},
+				shouldNotChangeChunk: true,
+				expectedNumBlocks:    4,
+			},
 		} {
 			t.Run(fmt.Sprintf("%v - %s", format, tc.name), func(t *testing.T) {
-				newChunk, err := originalChunk.Rewrite(tc.filterFunc)
+				newChunk, err := originalChunk.Rewrite(tc.filterFunc, tc.rewriteFunc)
 				if tc.err != nil {
 					require.Equal(t, tc.err, err)
 					return
@@ -1663,6 +1692,11 @@ func TestMemChunk_Rebound(t *testing.T) {
 					if tc.filterFunc != nil && tc.filterFunc(entry.Timestamp, entry.Line, logproto.FromLabelAdaptersToLabels(entry.StructuredMetadata)) {
 						continue
 					}
+					if tc.rewriteFunc != nil {
+						if line, structuredMetadata, ok := tc.rewriteFunc(entry.Timestamp, entry.Line, logproto.FromLabelAdaptersToLabels(entry.StructuredMetadata)); ok {
+							entry.Line, entry.StructuredMetadata = line, logproto.FromLabelsToLabelAdapters(structuredMetadata)
+						}
+					}
 
 					expectedEntries = append(expectedEntries, entry)
 				}
@@ -2454,7 +2488,7 @@ func BenchmarkChunkRewrite(b *testing.B) {
 			b.ResetTimer()
 			b.ReportAllocs()
 			for i := 0; i < b.N; i++ {
-				newChunk, err := c.Rewrite(nil)
+				newChunk, err := c.Rewrite(nil, nil)
 				require.NoError(b, err)
 
 				o, err := c.Bytes()
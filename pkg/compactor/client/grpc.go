@@ -113,6 +113,7 @@ func (s *compactorGRPCClient) GetAllDeleteRequestsForUser(ctx context.Context, u
 			Query:     dr.Query,
 			Status:    dr.Status,
 			CreatedAt: dr.CreatedAt,
+			Mode:      dr.Mode,
 		}
 	}
 
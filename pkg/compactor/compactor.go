@@ -350,6 +350,7 @@ func (c *Compactor) initDeletes(objectClient client.ObjectClient, indexUpdatePro
 	if err != nil {
 		return err
 	}
+	c.DeleteRequestsHandler.SetDryRunner(c.deleteRequestsManager)
 
 	c.expirationChecker = newExpirationChecker(retention.NewExpirationChecker(limits), c.deleteRequestsManager)
 	return nil
@@ -529,6 +530,19 @@ func (e *expirationChecker) Expired(userID []byte, chk retention.Chunk, lbls lab
 	return e.deletionExpiryChecker.Expired(userID, chk, lbls, seriesID, tableName, now)
 }
 
+func (e *expirationChecker) RewriteFunc(userID []byte, chk retention.Chunk, lbls labels.Labels, seriesID []byte, tableName string, now model.Time) filter.RewriteFunc {
+	// as with Expired, the delete requests do not apply to the chunks expired by the retention
+	if expired, _ := e.retentionExpiryChecker.Expired(userID, chk, lbls, seriesID, tableName, now); expired {
+		return nil
+	}
+
+	rewriter, ok := e.deletionExpiryChecker.(retention.EntryRewriter)
+	if !ok {
+		return nil
+	}
+	return rewriter.RewriteFunc(userID, chk, lbls, seriesID, tableName, now)
+}
+
 func (e *expirationChecker) MarkPhaseStarted() {
 	e.retentionExpiryChecker.MarkPhaseStarted()
 	e.deletionExpiryChecker.MarkPhaseStarted()
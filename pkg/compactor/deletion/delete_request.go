@@ -13,7 +13,9 @@ import (
 
 	"github.com/grafana/loki/v3/pkg/compactor/deletion/deletionproto"
 	"github.com/grafana/loki/v3/pkg/compactor/retention"
+	"github.com/grafana/loki/v3/pkg/logql/log"
 	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
 	"github.com/grafana/loki/v3/pkg/util/filter"
 	util_log "github.com/grafana/loki/v3/pkg/util/log"
 )
@@ -65,7 +67,8 @@ func (d *deleteRequest) SetQuery(logQL string) error {
 
 // FilterFunction returns a filter function that returns true if the given line should be deleted based on the DeleteRequest
 func (d *deleteRequest) FilterFunction(lbls labels.Labels) (filter.Func, error) {
-	if !allMatch(d.matchers, lbls) {
+	// the entries selected by the rewrite requests are rewritten by RewriteFunction
+	if d.Mode == deletionproto.ModeRewrite || !allMatch(d.matchers, lbls) {
 		return func(_ time.Time, _ string, _ labels.Labels) bool {
 			return false
 		}, nil
@@ -103,6 +106,40 @@ func (d *deleteRequest) FilterFunction(lbls labels.Labels) (filter.Func, error)
 	}, nil
 }
 
+// RewriteFunction returns a rewrite function that rewrites the given line with the output of the pipeline of
+// the DeleteRequest if it selects the line. It returns nil if the DeleteRequest does not rewrite the log entries
+// or does not select the stream.
+func (d *deleteRequest) RewriteFunction(lbls labels.Labels) (filter.RewriteFunc, error) {
+	if d.Mode != deletionproto.ModeRewrite || !allMatch(d.matchers, lbls) {
+		return nil, nil
+	}
+
+	p, err := d.logSelectorExpr.Pipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	f := p.ForStream(lbls).ProcessString
+	return func(ts time.Time, s string, structuredMetadata labels.Labels) (string, labels.Labels, bool) {
+		if ts.Before(d.timeInterval.start) || ts.After(d.timeInterval.end) {
+			return "", labels.EmptyLabels(), false
+		}
+
+		line, lbs, matches := f(ts.UnixNano(), s, structuredMetadata)
+		if !matches || lbs.Parsed().Has(logqlmodel.ErrorLabel) {
+			// keep the entries the pipeline failed for as is rather than writing the error
+			return "", labels.EmptyLabels(), false
+		}
+		// the stream labels are not rewritten, only the line and the structured metadata
+		newStructuredMetadata := log.KeptStructuredMetadata(structuredMetadata, lbs)
+		if line == s && labels.Equal(newStructuredMetadata, structuredMetadata) {
+			return "", labels.EmptyLabels(), false
+		}
+
+		return line, newStructuredMetadata, true
+	}, nil
+}
+
 func allMatch(matchers []*labels.Matcher, labels labels.Labels) bool {
 	for _, m := range matchers {
 		if !m.Matches(labels.Get(m.Name)) {
@@ -155,8 +192,8 @@ func (d *deleteRequest) GetChunkFilter(userID []byte, lbls labels.Labels, chunk
 		return false, nil
 	}
 
-	if d.StartTime <= chunk.From && d.EndTime >= chunk.Through && !d.logSelectorExpr.HasFilter() {
-		// Delete request covers the whole chunk and there are no line filters in the logSelectorExpr so the whole chunk will be deleted
+	if d.StartTime <= chunk.From && d.EndTime >= chunk.Through && !processesEntries(d.logSelectorExpr, d.Mode) {
+		// Delete request covers the whole chunk and there are no line filters or rewrite stages in the logSelectorExpr so the whole chunk will be deleted
 		return true, nil
 	}
 
@@ -181,7 +218,7 @@ func (d *deleteRequest) IsDuplicate(o *deleteRequest) (bool, error) {
 	if d.RequestID == o.RequestID {
 		return false, nil
 	}
-	if d.UserID != o.UserID || d.StartTime != o.StartTime || d.EndTime != o.EndTime {
+	if d.UserID != o.UserID || d.StartTime != o.StartTime || d.EndTime != o.EndTime || d.Mode != o.Mode {
 		return false, nil
 	}
 
@@ -146,6 +146,38 @@ func (b *deleteRequestBatch) expired(userID []byte, chk retention.Chunk, lbls la
 	}
 }
 
+func (b *deleteRequestBatch) rewriteFunc(userID []byte, chk retention.Chunk, lbls labels.Labels, skipRequest func(*deleteRequest) bool) filter.RewriteFunc {
+	userIDStr := unsafeGetString(userID)
+	if b.deleteRequestsToProcess[userIDStr] == nil {
+		return nil
+	}
+
+	var rewriteFuncs []filter.RewriteFunc
+	for _, deleteRequest := range b.deleteRequestsToProcess[userIDStr].requests {
+		if deleteRequest.Mode != deletionproto.ModeRewrite || skipRequest(deleteRequest) || !deleteRequest.IsDeleted(userID, lbls, chk) {
+			continue
+		}
+
+		rf, err := deleteRequest.RewriteFunction(lbls)
+		if err != nil {
+			// The query in the delete request is checked when added to the table.
+			// So this error should not occur.
+			level.Error(util_log.Logger).Log(
+				"msg", "unexpected error getting rewrite function",
+				"delete_request_id", deleteRequest.RequestID,
+				"user", deleteRequest.UserID,
+				"err", err,
+			)
+			continue
+		}
+		if rf != nil {
+			rewriteFuncs = append(rewriteFuncs, rf)
+		}
+	}
+
+	return chainRewriteFuncs(rewriteFuncs)
+}
+
 func (b *deleteRequestBatch) intervalMayHaveExpiredChunks(userID string) bool {
 	// We can't do the overlap check between the passed interval and delete requests interval from a user because
 	// if a request is issued just for today and there are chunks spanning today and yesterday then
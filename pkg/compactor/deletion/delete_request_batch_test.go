@@ -652,6 +652,78 @@ func TestDeleteRequestBatch_Expired(t *testing.T) {
 	}
 }
 
+func TestDeleteRequestBatch_RewriteFunc(t *testing.T) {
+	now := model.Now()
+	lblFoo, err := syntax.ParseLabels(`{foo="bar"}`)
+	require.NoError(t, err)
+
+	chunkEntry := retention.Chunk{
+		From:    now.Add(-12 * time.Hour),
+		Through: now.Add(-time.Hour),
+	}
+
+	metrics := newDeleteRequestsManagerMetrics(nil)
+	batch := newDeleteRequestBatch(metrics)
+	for _, dr := range []deletionproto.DeleteRequest{
+		{
+			UserID:    testUserID,
+			RequestID: "1",
+			Query:     lblFoo.String() + ` |= "fizz"`,
+			StartTime: now.Add(-24 * time.Hour),
+			EndTime:   now,
+		},
+		{
+			UserID:    testUserID,
+			RequestID: "2",
+			Query:     lblFoo.String() + ` | drop ping`,
+			Mode:      deletionproto.ModeRewrite,
+			StartTime: now.Add(-24 * time.Hour),
+			EndTime:   now,
+		},
+		{
+			UserID:    testUserID,
+			RequestID: "3",
+			Query:     lblFoo.String() + ` |= "foo" | line_format "{{ regexReplaceAll \"bar\" __line__ \"***\" }}"`,
+			Mode:      deletionproto.ModeRewrite,
+			StartTime: now.Add(-24 * time.Hour),
+			EndTime:   now,
+		},
+	} {
+		req, err := newDeleteRequest(dr, metrics.deletedLinesTotal)
+		require.NoError(t, err)
+		batch.addDeleteRequest(req)
+	}
+
+	// the chunk is expired with a filter which only deletes the lines selected by the request with a line filter
+	isExpired, filterFunc := batch.expired([]byte(testUserID), chunkEntry, lblFoo, func(_ *deleteRequest) bool {
+		return false
+	})
+	require.True(t, isExpired)
+	require.True(t, filterFunc(now.Add(-2*time.Hour).Time(), "fizz buzz", labels.FromStrings(lblPing, lblPong)))
+	require.False(t, filterFunc(now.Add(-2*time.Hour).Time(), "foo bar", labels.FromStrings(lblPing, lblPong)))
+
+	rewriteFunc := batch.rewriteFunc([]byte(testUserID), chunkEntry, lblFoo, func(_ *deleteRequest) bool {
+		return false
+	})
+	require.NotNil(t, rewriteFunc)
+
+	line, structuredMetadata, ok := rewriteFunc(now.Add(-2*time.Hour).Time(), "foo bar", labels.FromStrings(lblPing, lblPong, "ting", "tong"))
+	require.True(t, ok)
+	require.Equal(t, "foo ***", line)
+	require.Equal(t, labels.FromStrings("ting", "tong"), structuredMetadata)
+
+	_, _, ok = rewriteFunc(now.Add(-2*time.Hour).Time(), "buzz", labels.FromStrings("ting", "tong"))
+	require.False(t, ok)
+
+	// skipped requests, e.g. for the series already processed, are not applied
+	require.Nil(t, batch.rewriteFunc([]byte(testUserID), chunkEntry, lblFoo, func(_ *deleteRequest) bool {
+		return true
+	}))
+	require.Nil(t, batch.rewriteFunc([]byte("other-user"), chunkEntry, lblFoo, func(_ *deleteRequest) bool {
+		return false
+	}))
+}
+
 func TestDeleteRequestBatch_IntervalMayHaveExpiredChunks(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -426,6 +426,109 @@ func TestDeleteRequest_FilterFunction(t *testing.T) {
 	})
 }
 
+func TestDeleteRequest_RewriteFunction(t *testing.T) {
+	t.Run("drop structured metadata of the lines matching the line filter", func(t *testing.T) {
+		dr, err := newDeleteRequest(deletionproto.DeleteRequest{
+			Query:     `{foo="bar"} |= "some" | drop ping`,
+			Mode:      deletionproto.ModeRewrite,
+			StartTime: 0,
+			EndTime:   math.MaxInt64,
+		}, newDeleteRequestsManagerMetrics(prometheus.NewPedanticRegistry()).deletedLinesTotal)
+		require.NoError(t, err)
+
+		lbls := mustParseLabel(lblFooBar)
+
+		f, err := dr.FilterFunction(lbls)
+		require.NoError(t, err)
+		require.False(t, f(time.Now(), "some line", labels.FromStrings(lblPing, lblPong)))
+
+		rf, err := dr.RewriteFunction(lbls)
+		require.NoError(t, err)
+
+		line, structuredMetadata, ok := rf(time.Now(), "some line", labels.FromStrings(lblPing, lblPong, "fizz", "buzz"))
+		require.True(t, ok)
+		require.Equal(t, "some line", line)
+		require.Equal(t, labels.FromStrings("fizz", "buzz"), structuredMetadata)
+
+		_, _, ok = rf(time.Now(), "other line", labels.FromStrings(lblPing, lblPong))
+		require.False(t, ok)
+		_, _, ok = rf(time.Now(), "some line", labels.FromStrings("fizz", "buzz"))
+		require.False(t, ok)
+		require.Equal(t, int32(0), dr.DeletedLines.Load())
+	})
+
+	t.Run("replace part of the lines", func(t *testing.T) {
+		now := model.Now()
+		dr, err := newDeleteRequest(deletionproto.DeleteRequest{
+			Query:     `{foo="bar"} | line_format "{{ regexReplaceAll \"[a-z]+@[a-z.]+\" __line__ \"<redacted>\" }}"`,
+			Mode:      deletionproto.ModeRewrite,
+			StartTime: now.Add(-time.Hour),
+			EndTime:   now,
+		}, newDeleteRequestsManagerMetrics(prometheus.NewPedanticRegistry()).deletedLinesTotal)
+		require.NoError(t, err)
+
+		lbls := mustParseLabel(lblFooBar)
+
+		rf, err := dr.RewriteFunction(lbls)
+		require.NoError(t, err)
+
+		line, structuredMetadata, ok := rf(now.Time(), "login from user@example.com", labels.FromStrings(lblPing, lblPong))
+		require.True(t, ok)
+		require.Equal(t, "login from <redacted>", line)
+		require.Equal(t, labels.FromStrings(lblPing, lblPong), structuredMetadata)
+
+		_, _, ok = rf(now.Time().Add(-2*time.Hour), "login from user@example.com", labels.EmptyLabels())
+		require.False(t, ok)
+		_, _, ok = rf(now.Time(), "logout", labels.EmptyLabels())
+		require.False(t, ok)
+	})
+
+	t.Run("format the lines with the stream and parsed labels", func(t *testing.T) {
+		dr, err := newDeleteRequest(deletionproto.DeleteRequest{
+			Query:     `{foo="bar"} | json | line_format "{{.foo}}: {{.msg}}"`,
+			Mode:      deletionproto.ModeRewrite,
+			StartTime: 0,
+			EndTime:   math.MaxInt64,
+		}, newDeleteRequestsManagerMetrics(prometheus.NewPedanticRegistry()).deletedLinesTotal)
+		require.NoError(t, err)
+
+		rf, err := dr.RewriteFunction(mustParseLabel(lblFooBar))
+		require.NoError(t, err)
+
+		line, structuredMetadata, ok := rf(time.Now(), `{"msg":"login","email":"user@example.com"}`, labels.FromStrings(lblPing, lblPong))
+		require.True(t, ok)
+		require.Equal(t, "bar: login", line)
+		require.Equal(t, labels.FromStrings(lblPing, lblPong), structuredMetadata)
+
+		// the lines the pipeline fails for are kept as is
+		_, _, ok = rf(time.Now(), "not json", labels.EmptyLabels())
+		require.False(t, ok)
+	})
+
+	t.Run("labels not matching", func(t *testing.T) {
+		dr, err := newDeleteRequest(deletionproto.DeleteRequest{
+			Query: `{foo="bar"} | drop ping`,
+			Mode:  deletionproto.ModeRewrite,
+		}, newDeleteRequestsManagerMetrics(prometheus.NewPedanticRegistry()).deletedLinesTotal)
+		require.NoError(t, err)
+
+		rf, err := dr.RewriteFunction(mustParseLabel(`{foo2="buzz"}`))
+		require.NoError(t, err)
+		require.Nil(t, rf)
+	})
+
+	t.Run("delete mode", func(t *testing.T) {
+		dr, err := newDeleteRequest(deletionproto.DeleteRequest{
+			Query: `{foo="bar"} |= "some" | drop ping`,
+		}, newDeleteRequestsManagerMetrics(prometheus.NewPedanticRegistry()).deletedLinesTotal)
+		require.NoError(t, err)
+
+		rf, err := dr.RewriteFunction(mustParseLabel(lblFooBar))
+		require.NoError(t, err)
+		require.Nil(t, rf)
+	})
+}
+
 func TestDeleteRequest_IsDuplicate(t *testing.T) {
 	query1 := `{foo="bar", fizz="buzz"} |= "foo"`
 	query2 := `{foo="bar", fizz="buzz2"} |= "foo"`
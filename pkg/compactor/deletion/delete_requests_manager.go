@@ -58,6 +58,9 @@ type Table interface {
 type TablesManager interface {
 	ApplyStorageUpdates(ctx context.Context, iterator StorageUpdatesIterator) error
 	IterateTables(ctx context.Context, callback func(string, Table) error) (err error)
+	// ReadUserIndexes reads the index of the user in the tables overlapping the interval, without compacting nor modifying them.
+	// It returns the tables with index files waiting for compaction, which aren't read.
+	ReadUserIndexes(ctx context.Context, userID string, interval model.Interval, callback func(string, retention.SeriesIterator) error) (uncompactedTables []string, err error)
 }
 
 type TableIteratorFunc func(ctx context.Context, callback func(string, Table) error) (err error)
@@ -350,9 +353,10 @@ func (d *DeleteRequestsManager) loadDeleteRequestsToProcess(kind DeleteRequestsK
 				return nil, errors.Wrapf(err, "failed to init log selector expr for request_id=%s, user_id=%s", deleteRequest.RequestID, deleteRequest.UserID)
 			}
 		}
-		if kind == DeleteRequestsWithLineFilters && !deleteRequest.logSelectorExpr.HasFilter() {
+		// the requests rewriting the log entries are processed along with the ones with line filters
+		if kind == DeleteRequestsWithLineFilters && !processesEntries(deleteRequest.logSelectorExpr, deleteRequest.Mode) {
 			continue
-		} else if kind == DeleteRequestsWithoutLineFilters && deleteRequest.logSelectorExpr.HasFilter() {
+		} else if kind == DeleteRequestsWithoutLineFilters && processesEntries(deleteRequest.logSelectorExpr, deleteRequest.Mode) {
 			continue
 		}
 		maxRetentionInterval := getMaxRetentionInterval(deleteRequest.UserID, d.limits)
@@ -481,6 +485,14 @@ func (d *DeleteRequestsManager) Expired(userID []byte, chk retention.Chunk, lbls
 	})
 }
 
+// RewriteFunc returns the function rewriting the entries of a chunk reported as expired by Expired,
+// for the delete requests with rewrite stages.
+func (d *DeleteRequestsManager) RewriteFunc(userID []byte, chk retention.Chunk, lbls labels.Labels, seriesID []byte, tableName string, _ model.Time) filter.RewriteFunc {
+	return d.currentBatch.rewriteFunc(userID, chk, lbls, func(request *deleteRequest) bool {
+		return d.isSeriesProcessed(buildProcessedSeriesKey(request.RequestID, request.StartTime, request.EndTime, seriesID, tableName))
+	})
+}
+
 // isSeriesProcessed checks if the series with given key is processed.
 // Returns false if the series progress file reference is nil.
 func (d *DeleteRequestsManager) isSeriesProcessed(seriesKey []byte) bool {
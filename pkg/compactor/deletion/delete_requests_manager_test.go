@@ -1422,6 +1422,7 @@ func TestDeleteRequestsManagerWithoutHorizontalScalingMode_SeriesProgress(t *tes
 
 type storeAddReqDetails struct {
 	userID, query      string
+	mode               deletionproto.DeleteRequestMode
 	startTime, endTime model.Time
 	shardByInterval    time.Duration
 }
@@ -1471,10 +1472,11 @@ func (m *mockDeleteRequestsStore) GetAllRequests(_ context.Context) ([]deletionp
 	return m.deleteRequests, nil
 }
 
-func (m *mockDeleteRequestsStore) AddDeleteRequest(_ context.Context, userID, query string, startTime, endTime model.Time, shardByInterval time.Duration) (string, error) {
+func (m *mockDeleteRequestsStore) AddDeleteRequest(_ context.Context, userID, query string, mode deletionproto.DeleteRequestMode, startTime, endTime model.Time, shardByInterval time.Duration) (string, error) {
 	m.addReq = storeAddReqDetails{
 		userID:          userID,
 		query:           query,
+		mode:            mode,
 		startTime:       startTime,
 		endTime:         endTime,
 		shardByInterval: shardByInterval,
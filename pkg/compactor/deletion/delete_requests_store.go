@@ -24,8 +24,8 @@ const (
 var SupportedDeleteRequestsStoreDBTypes = []DeleteRequestsStoreDBType{DeleteRequestsStoreDBTypeBoltDB, DeleteRequestsStoreDBTypeSQLite}
 
 type DeleteRequestsStore interface {
-	AddDeleteRequest(ctx context.Context, userID, query string, startTime, endTime model.Time, shardByInterval time.Duration) (string, error)
-	addDeleteRequestWithID(ctx context.Context, requestID, userID, query string, startTime, endTime model.Time, shardByInterval time.Duration) error
+	AddDeleteRequest(ctx context.Context, userID, query string, mode deletionproto.DeleteRequestMode, startTime, endTime model.Time, shardByInterval time.Duration) (string, error)
+	addDeleteRequestWithID(ctx context.Context, requestID, userID, query string, mode deletionproto.DeleteRequestMode, startTime, endTime model.Time, shardByInterval time.Duration) error
 	GetAllRequests(ctx context.Context) ([]deletionproto.DeleteRequest, error)
 	GetAllDeleteRequestsForUser(ctx context.Context, userID string, forQuerytimeFiltering bool) ([]deletionproto.DeleteRequest, error)
 	RemoveDeleteRequest(ctx context.Context, userID string, requestID string) error
@@ -146,26 +146,26 @@ func newDeleteRequestsStoreTee(primaryStore, backupStore DeleteRequestsStore) De
 	}
 }
 
-func (d deleteRequestsStoreTee) AddDeleteRequest(ctx context.Context, userID, query string, startTime, endTime model.Time, shardByInterval time.Duration) (string, error) {
-	reqID, err := d.primaryStore.AddDeleteRequest(ctx, userID, query, startTime, endTime, shardByInterval)
+func (d deleteRequestsStoreTee) AddDeleteRequest(ctx context.Context, userID, query string, mode deletionproto.DeleteRequestMode, startTime, endTime model.Time, shardByInterval time.Duration) (string, error) {
+	reqID, err := d.primaryStore.AddDeleteRequest(ctx, userID, query, mode, startTime, endTime, shardByInterval)
 	if err != nil {
 		return "", err
 	}
 
 	// Use request ID from primary store to have request with same ID in backup store.
-	if err := d.backupStore.addDeleteRequestWithID(ctx, reqID, userID, query, startTime, endTime, shardByInterval); err != nil {
+	if err := d.backupStore.addDeleteRequestWithID(ctx, reqID, userID, query, mode, startTime, endTime, shardByInterval); err != nil {
 		return "", err
 	}
 
 	return reqID, nil
 }
 
-func (d deleteRequestsStoreTee) addDeleteRequestWithID(ctx context.Context, requestID, userID, query string, startTime, endTime model.Time, shardByInterval time.Duration) error {
-	if err := d.primaryStore.addDeleteRequestWithID(ctx, requestID, userID, query, startTime, endTime, shardByInterval); err != nil {
+func (d deleteRequestsStoreTee) addDeleteRequestWithID(ctx context.Context, requestID, userID, query string, mode deletionproto.DeleteRequestMode, startTime, endTime model.Time, shardByInterval time.Duration) error {
+	if err := d.primaryStore.addDeleteRequestWithID(ctx, requestID, userID, query, mode, startTime, endTime, shardByInterval); err != nil {
 		return err
 	}
 
-	return d.backupStore.addDeleteRequestWithID(ctx, requestID, userID, query, startTime, endTime, shardByInterval)
+	return d.backupStore.addDeleteRequestWithID(ctx, requestID, userID, query, mode, startTime, endTime, shardByInterval)
 }
 
 func (d deleteRequestsStoreTee) GetAllRequests(ctx context.Context) ([]deletionproto.DeleteRequest, error) {
@@ -63,12 +63,12 @@ func (ds *deleteRequestsStoreBoltDB) Stop() {
 
 // AddDeleteRequest creates entries for new delete requests. All passed delete requests will be associated to
 // each other by request id
-func (ds *deleteRequestsStoreBoltDB) AddDeleteRequest(ctx context.Context, userID, query string, startTime, endTime model.Time, shardByInterval time.Duration) (string, error) {
+func (ds *deleteRequestsStoreBoltDB) AddDeleteRequest(ctx context.Context, userID, query string, mode deletionproto.DeleteRequestMode, startTime, endTime model.Time, shardByInterval time.Duration) (string, error) {
 	// Generate unique request ID
 	requestID := generateUniqueID(userID, query)
 
 	// Use common implementation
-	err := ds.addDeleteRequestWithID(ctx, requestID, userID, query, startTime, endTime, shardByInterval)
+	err := ds.addDeleteRequestWithID(ctx, requestID, userID, query, mode, startTime, endTime, shardByInterval)
 	if err != nil {
 		return "", err
 	}
@@ -76,8 +76,8 @@ func (ds *deleteRequestsStoreBoltDB) AddDeleteRequest(ctx context.Context, userI
 	return requestID, nil
 }
 
-func (ds *deleteRequestsStoreBoltDB) addDeleteRequestWithID(ctx context.Context, requestID, userID, query string, startTime, endTime model.Time, shardByInterval time.Duration) error {
-	reqs := buildRequests(shardByInterval, query, userID, startTime, endTime)
+func (ds *deleteRequestsStoreBoltDB) addDeleteRequestWithID(ctx context.Context, requestID, userID, query string, mode deletionproto.DeleteRequestMode, startTime, endTime model.Time, shardByInterval time.Duration) error {
+	reqs := buildRequests(shardByInterval, query, mode, userID, startTime, endTime)
 	if len(reqs) == 0 {
 		return fmt.Errorf("zero delete requests created")
 	}
@@ -132,9 +132,19 @@ func (ds *deleteRequestsStoreBoltDB) writeDeleteRequest(req deletionproto.Delete
 	// delete requests by just status
 	writeBatch.Add(DeleteRequestsTableName, string(deleteRequestID), []byte(userIDAndRequestID), []byte(req.Status))
 
-	// Add another entry with additional details like creation time, time range of delete request and the logQL requests in value
+	// Add another entry with additional details like creation time, time range and mode of delete request and the logQL requests in value
+	writeBatch.Add(DeleteRequestsTableName, fmt.Sprintf("%s:%s", deleteRequestDetails, userIDAndRequestID), deleteRequestDetailsRangeValue(req), []byte(req.Query))
+}
+
+// deleteRequestDetailsRangeValue encodes the creation time and the time range of the delete request,
+// followed by its mode unless it deletes the log entries, to keep the range value of the requests
+// written before the mode was introduced.
+func deleteRequestDetailsRangeValue(req deletionproto.DeleteRequest) []byte {
 	rangeValue := fmt.Sprintf("%x:%x:%x", int64(req.CreatedAt), int64(req.StartTime), int64(req.EndTime))
-	writeBatch.Add(DeleteRequestsTableName, fmt.Sprintf("%s:%s", deleteRequestDetails, userIDAndRequestID), []byte(rangeValue), []byte(req.Query))
+	if req.Mode != deletionproto.ModeDelete {
+		rangeValue = fmt.Sprintf("%s:%s", rangeValue, req.Mode)
+	}
+	return []byte(rangeValue)
 }
 
 func (ds *deleteRequestsStoreBoltDB) updateCacheGen(userID string, writeBatch index.WriteBatch) {
@@ -373,9 +383,8 @@ func (ds *deleteRequestsStoreBoltDB) removeRequest(req deletionproto.DeleteReque
 	userIDAndRequestID := backwardCompatibleDeleteRequestHash(req.UserID, req.RequestID, req.SequenceNum)
 	writeBatch.Delete(DeleteRequestsTableName, string(deleteRequestID), []byte(userIDAndRequestID))
 
-	// Add another entry with additional details like creation time, time range of delete request and selectors in value
-	rangeValue := fmt.Sprintf("%x:%x:%x", int64(req.CreatedAt), int64(req.StartTime), int64(req.EndTime))
-	writeBatch.Delete(DeleteRequestsTableName, fmt.Sprintf("%s:%s", deleteRequestDetails, userIDAndRequestID), []byte(rangeValue))
+	// Add another entry with additional details like creation time, time range and mode of delete request and selectors in value
+	writeBatch.Delete(DeleteRequestsTableName, fmt.Sprintf("%s:%s", deleteRequestDetails, userIDAndRequestID), deleteRequestDetailsRangeValue(req))
 }
 
 // MergeShardedRequests merges the sharded requests back to a single request when we are done with processing all the shards
@@ -474,7 +483,7 @@ func (ds *deleteRequestsStoreBoltDB) getAllData(ctx context.Context) ([]deletion
 
 func parseDeleteRequestTimestamps(rangeValue []byte, deleteRequest deletionproto.DeleteRequest) (deletionproto.DeleteRequest, error) {
 	hexParts := strings.Split(string(rangeValue), ":")
-	if len(hexParts) != 3 {
+	if len(hexParts) != 3 && len(hexParts) != 4 {
 		return deleteRequest, errors.New("invalid key in parsing delete request lookup response")
 	}
 
@@ -495,6 +504,9 @@ func parseDeleteRequestTimestamps(rangeValue []byte, deleteRequest deletionproto
 	deleteRequest.CreatedAt = model.Time(createdAt)
 	deleteRequest.StartTime = model.Time(from)
 	deleteRequest.EndTime = model.Time(through)
+	if len(hexParts) == 4 {
+		deleteRequest.Mode = deletionproto.DeleteRequestMode(hexParts[3])
+	}
 
 	return deleteRequest, nil
 }
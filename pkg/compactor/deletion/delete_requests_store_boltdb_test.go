@@ -25,6 +25,7 @@ func TestDeleteRequestsStoreBoltDB(t *testing.T) {
 			context.Background(),
 			tc.user1Requests[i].UserID,
 			tc.user1Requests[i].Query,
+			deletionproto.ModeDelete,
 			tc.user1Requests[i].StartTime,
 			tc.user1Requests[i].EndTime,
 			0,
@@ -36,6 +37,7 @@ func TestDeleteRequestsStoreBoltDB(t *testing.T) {
 			context.Background(),
 			tc.user2Requests[i].UserID,
 			tc.user2Requests[i].Query,
+			deletionproto.ModeDelete,
 			tc.user2Requests[i].StartTime,
 			tc.user2Requests[i].EndTime,
 			0,
@@ -145,7 +147,7 @@ func TestBatchCreateGetBoltDB(t *testing.T) {
 		tc := setupStoreType(t, DeleteRequestsStoreDBTypeBoltDB)
 		defer tc.store.Stop()
 
-		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-24*time.Hour), now, time.Hour)
+		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 		require.NoError(t, err)
 
 		requests, err := tc.store.(*deleteRequestsStoreBoltDB).getDeleteRequestGroup(context.Background(), user1, reqID)
@@ -169,7 +171,7 @@ func TestBatchCreateGetBoltDB(t *testing.T) {
 		tc := setupStoreType(t, DeleteRequestsStoreDBTypeBoltDB)
 		defer tc.store.Stop()
 
-		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-24*time.Hour), now, time.Hour)
+		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 		require.NoError(t, err)
 
 		savedRequests, err := tc.store.(*deleteRequestsStoreBoltDB).getDeleteRequestGroup(context.Background(), user1, reqID)
@@ -188,7 +190,7 @@ func TestBatchCreateGetBoltDB(t *testing.T) {
 		tc := setupStoreType(t, DeleteRequestsStoreDBTypeBoltDB)
 		defer tc.store.Stop()
 
-		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-24*time.Hour), now, time.Hour)
+		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 		require.NoError(t, err)
 
 		err = tc.store.RemoveDeleteRequest(context.Background(), user1, reqID)
@@ -293,7 +295,7 @@ func TestDeleteRequestsStore_MergeShardedRequests(t *testing.T) {
 			require.NoError(t, err)
 
 			for _, addReqDetails := range tc.reqsToAdd {
-				_, err := ds.AddDeleteRequest(context.Background(), addReqDetails.userID, addReqDetails.query, addReqDetails.startTime, addReqDetails.endTime, addReqDetails.shardByInterval)
+				_, err := ds.AddDeleteRequest(context.Background(), addReqDetails.userID, addReqDetails.query, deletionproto.ModeDelete, addReqDetails.startTime, addReqDetails.endTime, addReqDetails.shardByInterval)
 				require.NoError(t, err)
 			}
 
@@ -354,6 +356,7 @@ func TestGetAllDeleteRequestsForUser_ExactMatch(t *testing.T) {
 		context.Background(),
 		user1,
 		`{foo="bar"}`,
+		deletionproto.ModeDelete,
 		now.Add(-24*time.Hour),
 		now,
 		0,
@@ -365,6 +368,7 @@ func TestGetAllDeleteRequestsForUser_ExactMatch(t *testing.T) {
 		context.Background(),
 		user123,
 		`{foo="bar"}`,
+		deletionproto.ModeDelete,
 		now.Add(-24*time.Hour),
 		now,
 		0,
@@ -24,6 +24,7 @@ const (
 	columnNameTotalShards     = "total_shards"
 	columnNameProcessedShards = "processed_shards"
 	columnNameQuery           = "query"
+	columnNameMode            = "mode"
 	columnNameGenNum          = "gen_num"
 )
 
@@ -37,8 +38,12 @@ const (
        end_time INT NOT NULL,
        total_shards INT NOT NULL,
        processed_shards INT DEFAULT 0,
-       query TEXT NOT NULL
+       query TEXT NOT NULL,
+       mode TEXT NOT NULL DEFAULT ''
     );`
+	// the mode column was added after the requests table, so it has to be added to the tables created before.
+	sqlCountRequestsTableModeColumn            = `SELECT COUNT(*) FROM pragma_table_info('requests') WHERE name = 'mode';`
+	sqlAddRequestsTableModeColumn              = `ALTER TABLE requests ADD COLUMN mode TEXT NOT NULL DEFAULT '';`
 	sqlCreateRequestsTableIndex                = `CREATE INDEX IF NOT EXISTS idx_requests_user_id ON requests(user_id);`
 	sqlCreateRequestsTableUserCompletedAtIndex = `CREATE INDEX IF NOT EXISTS idx_requests_user_completed ON requests(user_id, completed_at);`
 	sqlCreateDeleteRequestShardsTable          = `CREATE TABLE IF NOT EXISTS shards (
@@ -55,8 +60,11 @@ const (
     );`
 	sqlCreateCacheTableIndex = `CREATE INDEX IF NOT EXISTS idx_cache_gen_user_id ON shards(user_id);`
 
-	sqlInsertDeleteRequest = `INSERT INTO requests (id, user_id, created_at, start_time, end_time, total_shards, query) 
-                             VALUES (?, ?, ?, ?, ?, ?, ?);`
+	// the columns of the requests table are listed explicitly rather than with * to not depend on the schema
+	// cached by the connections, which does not have the mode column if it was added after they cached it.
+	sqlRequestsColumns     = `id, user_id, created_at, completed_at, start_time, end_time, total_shards, processed_shards, query, mode`
+	sqlInsertDeleteRequest = `INSERT INTO requests (id, user_id, created_at, start_time, end_time, total_shards, query, mode) 
+                             VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
 	sqlInsertDeleteRequestShard = `INSERT INTO shards VALUES (?, ?, ?, ?)`
 	sqlUpdateCacheGen           = `INSERT OR REPLACE INTO cache_gen VALUES (?, ?);`
 	sqlDeleteShard              = `DELETE FROM shards WHERE id=? AND start_time=? AND end_time=?;`
@@ -70,14 +78,14 @@ const (
                               WHERE id=? AND processed_shards < total_shards;`
 	sqlDeleteShards          = `DELETE FROM shards WHERE id=? AND user_id=?;`
 	sqlRemoveDeleteRequest   = `DELETE FROM requests WHERE id=? AND user_id=?`
-	sqlSelectRequestByID     = `SELECT * FROM requests WHERE id = ? AND user_id = ?;`
-	sqlSelectRequests        = `SELECT * FROM requests;`
-	sqlSelectRequestsForUser = `SELECT * FROM requests WHERE user_id = ?;`
+	sqlSelectRequestByID     = `SELECT ` + sqlRequestsColumns + ` FROM requests WHERE id = ? AND user_id = ?;`
+	sqlSelectRequests        = `SELECT ` + sqlRequestsColumns + ` FROM requests;`
+	sqlSelectRequestsForUser = `SELECT ` + sqlRequestsColumns + ` FROM requests WHERE user_id = ?;`
 	// while listing requests for query-time filtering, consider only the requests which are unprocessed or
 	// a specific duration has elapsed since they completed, to let the index updates get propagated.
-	sqlSelectUserRequestsForQueryTimeFiltering = `SELECT * FROM requests WHERE user_id = ? AND (completed_at IS NULL OR completed_at > ?);`
+	sqlSelectUserRequestsForQueryTimeFiltering = `SELECT ` + sqlRequestsColumns + ` FROM requests WHERE user_id = ? AND (completed_at IS NULL OR completed_at > ?);`
 	sqlSelectCacheGen                          = `SELECT gen_num FROM cache_gen WHERE user_id = ?;`
-	sqlGetUnprocessedShards                    = `SELECT dr.id, dr.user_id, dr.created_at, sh.start_time, sh.end_time, dr.query
+	sqlGetUnprocessedShards                    = `SELECT dr.id, dr.user_id, dr.created_at, sh.start_time, sh.end_time, dr.query, dr.mode
                               FROM shards sh
                               JOIN requests dr ON sh.id = dr.id`
 	sqlCountDeleteRequests   = `SELECT COUNT(*) FROM requests;`
@@ -122,6 +130,9 @@ func newDeleteRequestsStoreSQLite(workingDirectory string, indexStorageClient st
 	if err != nil {
 		return nil, err
 	}
+	if err := addModeColumn(sqliteStore); err != nil {
+		return nil, err
+	}
 
 	s := &deleteRequestsStoreSQLite{
 		sqliteStore:                    sqliteStore,
@@ -131,6 +142,27 @@ func newDeleteRequestsStoreSQLite(workingDirectory string, indexStorageClient st
 	return s, nil
 }
 
+// addModeColumn adds the mode column to the requests table if it was created without it.
+func addModeColumn(sqliteStore *sqliteDB) error {
+	hasModeColumn := false
+	if err := sqliteStore.Exec(context.Background(), false, sqlQuery{
+		query: sqlCountRequestsTableModeColumn,
+		execOpts: &sqlitex.ExecOptions{
+			ResultFunc: func(stmt *sqlite.Stmt) error {
+				hasModeColumn = stmt.ColumnInt(0) != 0
+				return nil
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	if hasModeColumn {
+		return nil
+	}
+
+	return sqliteStore.Exec(context.Background(), true, sqlQuery{query: sqlAddRequestsTableModeColumn})
+}
+
 func (ds *deleteRequestsStoreSQLite) Stop() {
 	ds.sqliteStore.Stop()
 }
@@ -198,6 +230,7 @@ func (ds *deleteRequestsStoreSQLite) buildAddDeleteRequestQueries(req deletionpr
 					req.EndTime,
 					len(shards),
 					req.Query,
+					string(req.Mode),
 				},
 			},
 		},
@@ -242,12 +275,12 @@ func (ds *deleteRequestsStoreSQLite) isEmpty(ctx context.Context) (bool, error)
 
 // AddDeleteRequest creates entries for new delete requests. All passed delete requests will be associated to
 // each other by request id
-func (ds *deleteRequestsStoreSQLite) AddDeleteRequest(ctx context.Context, userID, query string, startTime, endTime model.Time, shardByInterval time.Duration) (string, error) {
+func (ds *deleteRequestsStoreSQLite) AddDeleteRequest(ctx context.Context, userID, query string, mode deletionproto.DeleteRequestMode, startTime, endTime model.Time, shardByInterval time.Duration) (string, error) {
 	// Generate unique request ID
 	requestID := generateUniqueID(userID, query)
 
 	// Use common implementation
-	err := ds.addDeleteRequestWithID(ctx, requestID, userID, query, startTime, endTime, shardByInterval)
+	err := ds.addDeleteRequestWithID(ctx, requestID, userID, query, mode, startTime, endTime, shardByInterval)
 	if err != nil {
 		return "", err
 	}
@@ -255,16 +288,17 @@ func (ds *deleteRequestsStoreSQLite) AddDeleteRequest(ctx context.Context, userI
 	return requestID, nil
 }
 
-func (ds *deleteRequestsStoreSQLite) addDeleteRequestWithID(ctx context.Context, requestID, userID, query string, startTime, endTime model.Time, shardByInterval time.Duration) error {
+func (ds *deleteRequestsStoreSQLite) addDeleteRequestWithID(ctx context.Context, requestID, userID, query string, mode deletionproto.DeleteRequestMode, startTime, endTime model.Time, shardByInterval time.Duration) error {
 	var req deletionproto.DeleteRequest
 
 	req.RequestID = requestID
 	req.UserID = userID
 	req.Query = query
+	req.Mode = mode
 	req.StartTime = startTime
 	req.EndTime = endTime
 	req.CreatedAt = model.Now()
-	shards := buildRequests(shardByInterval, query, userID, startTime, endTime)
+	shards := buildRequests(shardByInterval, query, mode, userID, startTime, endTime)
 	if len(shards) == 0 {
 		return fmt.Errorf("zero delete requests created")
 	}
@@ -413,6 +447,7 @@ func (ds *deleteRequestsStoreSQLite) GetUnprocessedShards(ctx context.Context) (
 					StartTime: model.Time(stmt.GetInt64(columnNameStartTime)),
 					EndTime:   model.Time(stmt.GetInt64(columnNameEndTime)),
 					Query:     stmt.GetText(columnNameQuery),
+					Mode:      deletionproto.DeleteRequestMode(stmt.GetText(columnNameMode)),
 					Status:    deletionproto.StatusReceived,
 				},
 				)
@@ -474,6 +509,7 @@ func (ds *deleteRequestsStoreSQLite) queryDeleteRequests(ctx context.Context, qu
 					StartTime: model.Time(stmt.GetInt64(columnNameStartTime)),
 					EndTime:   model.Time(stmt.GetInt64(columnNameEndTime)),
 					Query:     stmt.GetText(columnNameQuery),
+					Mode:      deletionproto.DeleteRequestMode(stmt.GetText(columnNameMode)),
 					Status:    deleteRequestStatus(int(stmt.GetInt64(columnNameProcessedShards)), int(stmt.GetInt64(columnNameTotalShards))),
 				})
 				return nil
@@ -2,6 +2,7 @@ package deletion
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	"zombiezen.com/go/sqlite/sqlitex"
 
 	"github.com/grafana/loki/v3/pkg/compactor/deletion/deletionproto"
+	"github.com/grafana/loki/v3/pkg/storage/chunk/client/local"
+	"github.com/grafana/loki/v3/pkg/storage/stores/shipper/indexshipper/storage"
 )
 
 func TestDeleteRequestsStoreSQLite(t *testing.T) {
@@ -22,6 +25,7 @@ func TestDeleteRequestsStoreSQLite(t *testing.T) {
 			context.Background(),
 			tc.user1Requests[i].UserID,
 			tc.user1Requests[i].Query,
+			deletionproto.ModeDelete,
 			tc.user1Requests[i].StartTime,
 			tc.user1Requests[i].EndTime,
 			0,
@@ -33,6 +37,7 @@ func TestDeleteRequestsStoreSQLite(t *testing.T) {
 			context.Background(),
 			tc.user2Requests[i].UserID,
 			tc.user2Requests[i].Query,
+			deletionproto.ModeDelete,
 			tc.user2Requests[i].StartTime,
 			tc.user2Requests[i].EndTime,
 			0,
@@ -159,7 +164,7 @@ func TestBatchCreateGetSQLite(t *testing.T) {
 		tc := setupStoreType(t, DeleteRequestsStoreDBTypeSQLite)
 		defer tc.store.Stop()
 
-		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-24*time.Hour), now, time.Hour)
+		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 		require.NoError(t, err)
 
 		requests, err := tc.store.GetUnprocessedShards(context.Background())
@@ -181,7 +186,7 @@ func TestBatchCreateGetSQLite(t *testing.T) {
 		tc := setupStoreType(t, DeleteRequestsStoreDBTypeSQLite)
 		defer tc.store.Stop()
 
-		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-24*time.Hour), now, time.Hour)
+		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 		require.NoError(t, err)
 
 		savedRequests, err := tc.store.GetUnprocessedShards(context.Background())
@@ -205,7 +210,7 @@ func TestBatchCreateGetSQLite(t *testing.T) {
 		tc := setupStoreType(t, DeleteRequestsStoreDBTypeSQLite)
 		defer tc.store.Stop()
 
-		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-24*time.Hour), now, time.Hour)
+		reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 		require.NoError(t, err)
 
 		err = tc.store.RemoveDeleteRequest(context.Background(), user1, reqID)
@@ -222,7 +227,7 @@ func TestFixProcessedShardCount(t *testing.T) {
 	defer tc.store.Stop()
 
 	// add a delete request
-	reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-24*time.Hour), now, time.Hour)
+	reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 	require.NoError(t, err)
 
 	// get the current state from db
@@ -305,3 +310,52 @@ func TestFixProcessedShardCount(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, deletionproto.StatusProcessed, after.Status)
 }
+
+func TestAddModeColumn(t *testing.T) {
+	tempDir := t.TempDir()
+	workingDir := filepath.Join(tempDir, "working-dir")
+
+	objectClient, err := local.NewFSObjectClient(local.FSConfig{
+		Directory: filepath.Join(tempDir, "object-store"),
+	})
+	require.NoError(t, err)
+	indexStorageClient := storage.NewIndexStorageClient(objectClient, "")
+
+	// create the requests table without the mode column, as it was before the column was added
+	sqliteDB, err := newSQLiteDB(workingDir, indexStorageClient)
+	require.NoError(t, err)
+	require.NoError(t, sqliteDB.Exec(context.Background(), true, sqlQuery{
+		query: `CREATE TABLE requests (
+       id TEXT PRIMARY KEY,
+       user_id TEXT NOT NULL,
+       created_at INT NOT NULL,
+       completed_at INT,
+       start_time INT NOT NULL,
+       end_time INT NOT NULL,
+       total_shards INT NOT NULL,
+       processed_shards INT DEFAULT 0,
+       query TEXT NOT NULL
+    );`,
+	}, sqlQuery{
+		query: `INSERT INTO requests (id, user_id, created_at, start_time, end_time, total_shards, query) VALUES (?, ?, ?, ?, ?, ?, ?);`,
+		execOpts: &sqlitex.ExecOptions{
+			Args: []any{"1", user1, now, now.Add(-time.Hour), now, 1, `{foo="bar"}`},
+		},
+	}))
+	sqliteDB.Stop()
+
+	store, err := newDeleteRequestsStoreSQLite(workingDir, indexStorageClient, time.Hour)
+	require.NoError(t, err)
+	defer store.Stop()
+
+	// the existing requests delete the log entries
+	req, err := store.GetDeleteRequest(context.Background(), user1, "1")
+	require.NoError(t, err)
+	require.Equal(t, deletionproto.ModeDelete, req.Mode)
+
+	reqID, err := store.AddDeleteRequest(context.Background(), user1, `{foo="bar"} | drop email`, deletionproto.ModeRewrite, now.Add(-time.Hour), now, 0)
+	require.NoError(t, err)
+	req, err = store.GetDeleteRequest(context.Background(), user1, reqID)
+	require.NoError(t, err)
+	require.Equal(t, deletionproto.ModeRewrite, req.Mode)
+}
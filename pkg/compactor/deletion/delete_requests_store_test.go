@@ -28,6 +28,7 @@ func TestAllDeleteRequestsStoreTypes(t *testing.T) {
 					context.Background(),
 					tc.user1Requests[i].UserID,
 					tc.user1Requests[i].Query,
+					deletionproto.ModeDelete,
 					tc.user1Requests[i].StartTime,
 					tc.user1Requests[i].EndTime,
 					0,
@@ -39,6 +40,7 @@ func TestAllDeleteRequestsStoreTypes(t *testing.T) {
 					context.Background(),
 					tc.user2Requests[i].UserID,
 					tc.user2Requests[i].Query,
+					deletionproto.ModeDelete,
 					tc.user2Requests[i].StartTime,
 					tc.user2Requests[i].EndTime,
 					0,
@@ -152,7 +154,7 @@ func TestBatchCreateGetAllStoreTypes(t *testing.T) {
 				tc := setupStoreType(t, storeType)
 				defer tc.store.Stop()
 
-				reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-24*time.Hour), now, time.Hour)
+				reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 				require.NoError(t, err)
 
 				requests, err := tc.store.GetUnprocessedShards(context.Background())
@@ -175,7 +177,7 @@ func TestBatchCreateGetAllStoreTypes(t *testing.T) {
 				defer tc.store.Stop()
 
 				deletionQuery := `{foo="bar"}`
-				reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, deletionQuery, now.Add(-24*time.Hour), now, time.Hour)
+				reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, deletionQuery, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 				require.NoError(t, err)
 
 				// GetAllDeleteRequestsForUser should list consolidated requests and not shards
@@ -216,11 +218,36 @@ func TestBatchCreateGetAllStoreTypes(t *testing.T) {
 
 			})
 
+			t.Run("it persists the mode of the requests", func(t *testing.T) {
+				tc := setupStoreType(t, storeType)
+				defer tc.store.Stop()
+
+				reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"} | drop email`, deletionproto.ModeRewrite, now.Add(-24*time.Hour), now, time.Hour)
+				require.NoError(t, err)
+
+				shards, err := tc.store.GetUnprocessedShards(context.Background())
+				require.NoError(t, err)
+				require.NotEmpty(t, shards)
+				for _, shard := range shards {
+					require.Equal(t, deletionproto.ModeRewrite, shard.Mode)
+				}
+
+				requests, err := tc.store.GetAllDeleteRequestsForUser(context.Background(), user1, true)
+				require.NoError(t, err)
+				require.Len(t, requests, 1)
+				require.Equal(t, deletionproto.ModeRewrite, requests[0].Mode)
+
+				// the requests are removed along with their mode
+				require.NoError(t, tc.store.RemoveDeleteRequest(context.Background(), user1, reqID))
+				_, err = tc.store.GetDeleteRequest(context.Background(), user1, reqID)
+				require.ErrorIs(t, err, ErrDeleteRequestNotFound)
+			})
+
 			t.Run("deletes several delete requests", func(t *testing.T) {
 				tc := setupStoreType(t, storeType)
 				defer tc.store.Stop()
 
-				reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-24*time.Hour), now, time.Hour)
+				reqID, err := tc.store.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 				require.NoError(t, err)
 
 				err = tc.store.RemoveDeleteRequest(context.Background(), user1, reqID)
@@ -251,11 +278,11 @@ func TestCopyData(t *testing.T) {
 	require.NoError(t, err)
 
 	// Add some test data to boltdb
-	reqID1, err := boltdbStore.AddDeleteRequest(context.Background(), user1, `{foo="bar1"}`, now.Add(-24*time.Hour), now, time.Hour)
+	reqID1, err := boltdbStore.AddDeleteRequest(context.Background(), user1, `{foo="bar1"}`, deletionproto.ModeDelete, now.Add(-24*time.Hour), now, time.Hour)
 	require.NoError(t, err)
-	reqID2, err := boltdbStore.AddDeleteRequest(context.Background(), user2, `{foo="bar2"}`, now.Add(-48*time.Hour), now, 2*time.Hour)
+	reqID2, err := boltdbStore.AddDeleteRequest(context.Background(), user2, `{foo="bar2"}`, deletionproto.ModeDelete, now.Add(-48*time.Hour), now, 2*time.Hour)
 	require.NoError(t, err)
-	reqID3, err := boltdbStore.AddDeleteRequest(context.Background(), user2, `{foo="bar3"}`, now.Add(-48*time.Hour), now, time.Hour)
+	reqID3, err := boltdbStore.AddDeleteRequest(context.Background(), user2, `{foo="bar3"} | drop email`, deletionproto.ModeRewrite, now.Add(-48*time.Hour), now, time.Hour)
 	require.NoError(t, err)
 
 	// Mark all shards for reqID1 as processed
@@ -321,6 +348,8 @@ func TestCopyData(t *testing.T) {
 		require.True(t, req1.Status == deletionproto.StatusProcessed)
 		require.True(t, req2.Status != deletionproto.StatusReceived && req2.Status != deletionproto.StatusProcessed)
 		require.True(t, req3.Status == deletionproto.StatusReceived)
+		// the mode of req3 should have been copied
+		require.Equal(t, deletionproto.ModeRewrite, req3.Mode)
 	})
 
 	t.Run("verify unprocessed shards were copied", func(t *testing.T) {
@@ -371,7 +400,7 @@ func TestNewDeleteRequestsStoreTee(t *testing.T) {
 	storeTee := deleteRequestsStore.(deleteRequestsStoreTee)
 
 	t.Run("add and get delete request", func(t *testing.T) {
-		reqID, err := storeTee.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-2*time.Hour), now, time.Hour)
+		reqID, err := storeTee.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-2*time.Hour), now, time.Hour)
 		require.NoError(t, err)
 
 		_, err = storeTee.GetDeleteRequest(context.Background(), user1, reqID)
@@ -417,7 +446,7 @@ func TestNewDeleteRequestsStoreTee(t *testing.T) {
 	})
 
 	t.Run("remove delete request", func(t *testing.T) {
-		reqID, err := storeTee.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, now.Add(-2*time.Hour), now, time.Hour)
+		reqID, err := storeTee.AddDeleteRequest(context.Background(), user1, `{foo="bar"}`, deletionproto.ModeDelete, now.Add(-2*time.Hour), now, time.Hour)
 		require.NoError(t, err)
 
 		err = storeTee.RemoveDeleteRequest(context.Background(), user1, reqID)
@@ -80,14 +80,51 @@ func (d *deletionManifestBuilder) canSkipSeries(userID []byte, lbls labels.Label
 		return true, fmt.Errorf("no requests loaded for user: %s", userIDStr)
 	}
 
-	for _, deleteRequest := range d.deleteRequestBatch.getAllRequestsForUser(userIDStr) {
-		// if the delete request touches the series, do not skip it
+	return !seriesSelectedByRequests(userRequests, lbls), nil
+}
+
+// seriesSelectedByRequests returns true if the series is selected by any of the delete requests.
+func seriesSelectedByRequests(deleteRequests []*deleteRequest, lbls labels.Labels) bool {
+	for _, deleteRequest := range deleteRequests {
 		if labels.Selector(deleteRequest.matchers).Matches(lbls) {
-			return false, nil
+			return true
 		}
 	}
 
-	return true, nil
+	return false
+}
+
+// forEachSelectedChunk calls the callback for each chunk of the series overlapping the deletion interval
+// which is selected by at least one of the delete requests, with a bit field of the requests selecting it.
+// We use a uint64 as a bit field, so it only handles upto 64 delete requests.
+func forEachSelectedChunk(series retention.Series, deleteRequests []*deleteRequest, deletionInterval model.Interval, callback func(chk retention.Chunk, requestsBitField uint64) error) error {
+	for _, chk := range series.Chunks() {
+		if !intervalsOverlap(deletionInterval, model.Interval{
+			Start: chk.From,
+			End:   chk.Through,
+		}) {
+			continue
+		}
+
+		var requestsBitField uint64
+		for i, deleteRequest := range deleteRequests {
+			if !deleteRequest.IsDeleted(series.UserID(), series.Labels(), chk) {
+				continue
+			}
+
+			requestsBitField |= 1 << i
+		}
+
+		if requestsBitField == 0 {
+			continue
+		}
+
+		if err := callback(chk, requestsBitField); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // AddSeries adds a series and its chunks to the current segment.
@@ -117,14 +154,7 @@ func (d *deletionManifestBuilder) AddSeries(ctx context.Context, tableName strin
 		d.deletionInterval = d.deleteRequestBatch.getDeletionIntervalForUser(userIDStr)
 	}
 
-	var chunksGroupIdentifier uint64
-	for _, chk := range series.Chunks() {
-		if !intervalsOverlap(d.deletionInterval, model.Interval{
-			Start: chk.From,
-			End:   chk.Through,
-		}) {
-			continue
-		}
+	return forEachSelectedChunk(series, d.allUserRequests, d.deletionInterval, func(chk retention.Chunk, chunksGroupIdentifier uint64) error {
 		if d.currentSegmentChunksCount >= maxChunksPerSegment {
 			if err := d.flushCurrentBatch(ctx); err != nil {
 				return err
@@ -137,19 +167,6 @@ func (d *deletionManifestBuilder) AddSeries(ctx context.Context, tableName strin
 			}
 		}
 
-		// We use a uint64 as a bit field to track which delete requests apply to each chunk.
-		chunksGroupIdentifier = 0
-		for i, deleteRequest := range d.allUserRequests {
-			if !deleteRequest.IsDeleted(series.UserID(), series.Labels(), chk) {
-				continue
-			}
-
-			chunksGroupIdentifier |= 1 << i
-		}
-
-		if chunksGroupIdentifier == 0 {
-			continue
-		}
 		d.currentSegmentChunksCount++
 
 		if _, ok := d.currentSegment[chunksGroupIdentifier]; !ok {
@@ -182,9 +199,9 @@ func (d *deletionManifestBuilder) AddSeries(ctx context.Context, tableName strin
 		chunks.IDs = append(chunks.IDs, chk.ChunkID)
 		group.Chunks[currentLabels] = chunks
 		d.currentSegment[chunksGroupIdentifier] = group
-	}
 
-	return nil
+		return nil
+	})
 }
 
 // Finish flushes the current segment and builds the manifest.
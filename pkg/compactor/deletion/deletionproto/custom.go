@@ -1,16 +1,45 @@
 package deletionproto
 
-import "github.com/prometheus/common/model"
+import (
+	"fmt"
+
+	"github.com/prometheus/common/model"
+)
 
 const (
 	StatusReceived  DeleteRequestStatus = "received"
 	StatusProcessed DeleteRequestStatus = "processed"
 )
 
+const (
+	// ModeDelete deletes the log entries selected by the request. It is the zero value so that
+	// the requests persisted before the mode was introduced keep deleting their log entries.
+	ModeDelete DeleteRequestMode = ""
+	// ModeRewrite rewrites the log entries selected by the request with the line and the
+	// structured metadata output by its pipeline.
+	ModeRewrite DeleteRequestMode = "rewrite"
+)
+
 type (
 	DeleteRequestStatus string
+	DeleteRequestMode   string
 )
 
+// ParseDeleteRequestMode parses the mode of a delete request, defaulting to ModeDelete.
+func ParseDeleteRequestMode(mode string) (DeleteRequestMode, error) {
+	switch mode {
+	case "", "delete":
+		return ModeDelete, nil
+	case string(ModeRewrite):
+		return ModeRewrite, nil
+	}
+	return ModeDelete, fmt.Errorf("invalid mode %q, must be one of delete or rewrite", mode)
+}
+
+func (m DeleteRequestMode) Equal(mode DeleteRequestMode) bool {
+	return m == mode
+}
+
 func (s DeleteRequestStatus) Equal(status DeleteRequestStatus) bool {
 	return s == status
 }
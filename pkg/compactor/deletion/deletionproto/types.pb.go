@@ -36,6 +36,7 @@ type DeleteRequest struct {
 	CreatedAt   github_com_prometheus_common_model.Time `protobuf:"varint,6,opt,name=createdAt,proto3,customtype=github.com/prometheus/common/model.Time" json:"created_at"`
 	UserID      string                                  `protobuf:"bytes,7,opt,name=userID,proto3" json:"user_id"`
 	SequenceNum int64                                   `protobuf:"varint,8,opt,name=sequenceNum,proto3" json:"-"`
+	Mode        DeleteRequestMode                       `protobuf:"bytes,9,opt,name=mode,proto3,customtype=DeleteRequestMode" json:"mode,omitempty"`
 }
 
 func (m *DeleteRequest) Reset()      { *m = DeleteRequest{} }
@@ -604,66 +605,67 @@ func init() {
 }
 
 var fileDescriptor_8a14ac54fc425be6 = []byte{
-	// 929 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4d, 0x8f, 0xdb, 0xc4,
-	0x1b, 0xcf, 0xe4, 0x3d, 0x4f, 0xfe, 0xc9, 0xbf, 0x4c, 0x2b, 0xb0, 0xa2, 0xc5, 0x59, 0x85, 0x97,
-	0xae, 0x10, 0x4d, 0xaa, 0xee, 0x05, 0x51, 0x15, 0x44, 0xd6, 0x08, 0xa5, 0x15, 0x15, 0x9a, 0x6d,
-	0x7b, 0x80, 0xc3, 0xe2, 0xd8, 0xb3, 0x89, 0x95, 0xd8, 0xe3, 0x7a, 0xc6, 0x15, 0x7b, 0xe3, 0x23,
-	0xf0, 0x05, 0x38, 0x83, 0xc4, 0x17, 0xe9, 0x71, 0x8f, 0x15, 0x87, 0x88, 0xcd, 0x1e, 0x40, 0x11,
-	0x87, 0x7e, 0x00, 0x24, 0xd0, 0x8c, 0x27, 0x89, 0x9d, 0x86, 0x6a, 0x77, 0x39, 0x79, 0xe6, 0x79,
-	0xf9, 0xcd, 0xf3, 0xfc, 0xe6, 0xf7, 0x8c, 0x0c, 0xb7, 0xc3, 0xc9, 0xa8, 0xe7, 0x30, 0x3f, 0xb4,
-	0x1d, 0xc1, 0xa2, 0x9e, 0x4b, 0xa7, 0x54, 0x78, 0x2c, 0x58, 0x2d, 0xc2, 0x88, 0x09, 0xd6, 0x13,
-	0x27, 0x21, 0xe5, 0x5d, 0xb5, 0xc6, 0x8d, 0x8c, 0xab, 0x75, 0x63, 0xc4, 0x46, 0x2c, 0x89, 0x92,
-	0xab, 0x24, 0xa8, 0xf3, 0x57, 0x01, 0x1a, 0x96, 0x8c, 0xa3, 0x84, 0x3e, 0x8d, 0x29, 0x17, 0xf8,
-	0x43, 0xa8, 0x45, 0xc9, 0x72, 0x60, 0x19, 0x68, 0x17, 0xed, 0xd5, 0xfa, 0xcd, 0xc5, 0xac, 0x0d,
-	0xda, 0x78, 0xe4, 0xb9, 0x64, 0x1d, 0x80, 0xbf, 0x81, 0x1a, 0x17, 0x76, 0x24, 0x1e, 0x79, 0x3e,
-	0x35, 0xf2, 0xbb, 0x68, 0xaf, 0xd0, 0xbf, 0xf7, 0x7c, 0xd6, 0xce, 0xfd, 0x3a, 0x6b, 0xdf, 0x1c,
-	0x79, 0x62, 0x1c, 0x0f, 0xbb, 0x0e, 0xf3, 0x7b, 0x61, 0xc4, 0x7c, 0x2a, 0xc6, 0x34, 0xe6, 0xb2,
-	0x07, 0x9f, 0x05, 0x3d, 0x9f, 0xb9, 0x74, 0xda, 0x95, 0x69, 0x12, 0x5c, 0x61, 0x1c, 0x09, 0xcf,
-	0xa7, 0x64, 0x8d, 0x87, 0x1f, 0x43, 0x85, 0x06, 0xae, 0x82, 0x2e, 0x28, 0xe8, 0xbb, 0x97, 0x87,
-	0xae, 0xd2, 0xc0, 0x4d, 0x80, 0x97, 0x58, 0xb8, 0x0d, 0xa5, 0xa7, 0x31, 0x8d, 0x4e, 0x8c, 0xa2,
-	0xea, 0xae, 0xb6, 0x98, 0xb5, 0x13, 0x03, 0x49, 0x3e, 0xf8, 0x1e, 0x94, 0xb9, 0xb0, 0x45, 0xcc,
-	0x8d, 0x92, 0x8a, 0x78, 0x4f, 0x1f, 0x7b, 0x3d, 0xc3, 0xd4, 0xa1, 0x0a, 0x59, 0xcc, 0xda, 0x3a,
-	0x98, 0xe8, 0xaf, 0xe4, 0xc4, 0x89, 0xa8, 0x2d, 0xa8, 0xfb, 0x99, 0x30, 0xca, 0x57, 0xe6, 0x44,
-	0x63, 0x1c, 0xd9, 0x82, 0xac, 0xf1, 0xf0, 0x3b, 0x50, 0x8e, 0x39, 0x8d, 0x06, 0x96, 0x51, 0x51,
-	0xb5, 0xd5, 0x17, 0xb3, 0x76, 0x45, 0x5a, 0xe4, 0xc5, 0x68, 0x17, 0xbe, 0x09, 0x75, 0x2e, 0x8b,
-	0x0c, 0x1c, 0xfa, 0x30, 0xf6, 0x8d, 0xaa, 0xaa, 0xa1, 0xb4, 0x98, 0xb5, 0xd1, 0x2d, 0x92, 0xf6,
-	0x74, 0xfe, 0x44, 0x70, 0xcd, 0xd2, 0x32, 0xf9, 0xd2, 0x0e, 0xbc, 0x63, 0xa9, 0x80, 0x4f, 0xa0,
-	0xaa, 0x2f, 0x98, 0x1b, 0x68, 0xb7, 0xb0, 0x57, 0xbf, 0xb3, 0xd3, 0xcd, 0x68, 0xa9, 0x9b, 0xe1,
-	0xa1, 0x5f, 0x94, 0xcd, 0x91, 0x55, 0x0e, 0xfe, 0x0a, 0xde, 0x70, 0xe3, 0x70, 0xea, 0x39, 0xf6,
-	0x2a, 0x86, 0x1b, 0xf9, 0x0b, 0x03, 0xbd, 0x9a, 0x8c, 0xdf, 0x85, 0x06, 0xa7, 0x23, 0x9f, 0x06,
-	0x82, 0x1f, 0xb0, 0x38, 0x10, 0x4a, 0x0e, 0x25, 0x92, 0x35, 0xe2, 0x5d, 0xa8, 0x3b, 0xe3, 0x38,
-	0x98, 0xe8, 0x98, 0xa2, 0x8a, 0x49, 0x9b, 0x3a, 0x3b, 0x50, 0x3d, 0x90, 0xdb, 0x81, 0xc5, 0xf1,
-	0x35, 0x28, 0x0c, 0xac, 0xa4, 0xc1, 0x1a, 0x91, 0xcb, 0xce, 0xef, 0x08, 0xea, 0xca, 0xcd, 0xbf,
-	0x88, 0x58, 0x1c, 0xfe, 0x67, 0x1e, 0x2c, 0x28, 0x27, 0x87, 0xeb, 0xe6, 0xdf, 0xdf, 0xc8, 0x4e,
-	0x9d, 0xa5, 0xd7, 0x9f, 0x07, 0x22, 0x3a, 0xd1, 0x38, 0x3a, 0xb7, 0x45, 0x96, 0x45, 0x29, 0xa7,
-	0x2c, 0x7b, 0x42, 0x4f, 0x92, 0xc1, 0x24, 0x72, 0x89, 0x6f, 0x41, 0xe9, 0x99, 0x3d, 0x8d, 0x93,
-	0xf1, 0xab, 0xdf, 0x79, 0x6b, 0xdb, 0x29, 0x03, 0x8b, 0x93, 0x24, 0xea, 0xe3, 0xfc, 0x47, 0xa8,
-	0xf3, 0x13, 0x82, 0xca, 0x61, 0xc2, 0x1d, 0xde, 0x81, 0x9a, 0xb0, 0x87, 0x53, 0xfa, 0xd0, 0xf6,
-	0xa9, 0x86, 0x5d, 0x1b, 0xf0, 0x9b, 0x2b, 0xb9, 0xe5, 0x95, 0x6b, 0xa9, 0x30, 0x0b, 0xfe, 0xe7,
-	0xac, 0xcb, 0xe7, 0x46, 0x41, 0x75, 0xd8, 0xfa, 0xf7, 0x0e, 0x75, 0x57, 0x99, 0xac, 0x0b, 0xdc,
-	0xd8, 0x2f, 0x08, 0xea, 0x4b, 0x81, 0xde, 0x67, 0xc3, 0x2b, 0x56, 0xdb, 0x82, 0xaa, 0xa3, 0x69,
-	0x50, 0x95, 0xd6, 0xc8, 0x6a, 0x8f, 0xef, 0x43, 0xd3, 0x4d, 0x5f, 0x23, 0x37, 0x8a, 0x17, 0xbe,
-	0xeb, 0x8d, 0xcc, 0xce, 0xdf, 0x08, 0x4a, 0xaa, 0x67, 0x7c, 0x00, 0xc5, 0xe3, 0x88, 0xf9, 0xaa,
-	0xc4, 0x42, 0xbf, 0x77, 0xc9, 0xf1, 0x27, 0x2a, 0x19, 0x0f, 0xa0, 0x22, 0xc6, 0x11, 0x8b, 0x47,
-	0x63, 0xfd, 0xb4, 0x5e, 0x1a, 0x67, 0x99, 0x2f, 0x99, 0x3e, 0xf6, 0x82, 0x11, 0x8d, 0xc2, 0xc8,
-	0xd3, 0xf3, 0x53, 0x24, 0x69, 0x53, 0xc2, 0x11, 0x75, 0x26, 0x3c, 0xf6, 0xd5, 0x45, 0x34, 0xc8,
-	0x6a, 0x8f, 0x9b, 0x90, 0x7f, 0xd0, 0x57, 0x8f, 0x61, 0x83, 0xe4, 0x1f, 0xf4, 0xb1, 0x21, 0x1f,
-	0x66, 0x11, 0x79, 0x94, 0xab, 0xf7, 0xad, 0x41, 0x96, 0xdb, 0xce, 0x02, 0x41, 0xf3, 0x50, 0xb0,
-	0xc8, 0x1e, 0xd1, 0xc7, 0xa1, 0x6b, 0x0b, 0xca, 0xf1, 0x13, 0x68, 0x44, 0x74, 0x18, 0x7b, 0x53,
-	0x91, 0xc8, 0x41, 0xcf, 0xd2, 0xed, 0x0d, 0x7e, 0xb3, 0x59, 0x5d, 0x92, 0x4e, 0x51, 0xd2, 0x27,
-	0x59, 0x18, 0xbc, 0x07, 0xff, 0x4f, 0x94, 0xf2, 0x88, 0x59, 0x74, 0x10, 0xb8, 0xf4, 0x3b, 0x35,
-	0x67, 0x35, 0xb2, 0x69, 0x6e, 0x3d, 0x01, 0xfc, 0x2a, 0xdc, 0x96, 0x49, 0xfa, 0x20, 0x3b, 0x49,
-	0x37, 0xb6, 0xa9, 0x39, 0x3d, 0x46, 0x3f, 0xe6, 0xc1, 0xc8, 0x96, 0x7d, 0xc0, 0xa6, 0x53, 0xea,
-	0xc8, 0x94, 0x2b, 0x2a, 0xd5, 0x83, 0x26, 0xcf, 0x20, 0xea, 0xc9, 0xba, 0xfb, 0x5a, 0xb6, 0xd6,
-	0xc7, 0x6e, 0x38, 0xd2, 0x0f, 0xca, 0x06, 0x70, 0xeb, 0x5b, 0xb8, 0xbe, 0x25, 0x78, 0x0b, 0x2d,
-	0xfb, 0x59, 0x5a, 0xde, 0x7e, 0x6d, 0x29, 0x29, 0x7e, 0xfa, 0xf1, 0xe9, 0x99, 0x99, 0x7b, 0x71,
-	0x66, 0xe6, 0x5e, 0x9e, 0x99, 0xe8, 0xfb, 0xb9, 0x89, 0x7e, 0x9e, 0x9b, 0xe8, 0xf9, 0xdc, 0x44,
-	0xa7, 0x73, 0x13, 0xfd, 0x36, 0x37, 0xd1, 0x1f, 0x73, 0x33, 0xf7, 0x72, 0x6e, 0xa2, 0x1f, 0xce,
-	0xcd, 0xdc, 0xe9, 0xb9, 0x99, 0x7b, 0x71, 0x6e, 0xe6, 0xbe, 0xfe, 0x34, 0x25, 0xee, 0x51, 0x64,
-	0x1f, 0xdb, 0x81, 0xdd, 0x9b, 0xb2, 0x89, 0xd7, 0x7b, 0xb6, 0xdf, 0xbb, 0xc8, 0x4f, 0xd0, 0xb0,
-	0xac, 0x3e, 0xfb, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x01, 0xb0, 0x0a, 0x6d, 0x33, 0x09, 0x00,
-	0x00,
+	// 955 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4d, 0x8f, 0xdb, 0x44,
+	0x18, 0xce, 0xe4, 0x63, 0x93, 0xbc, 0x21, 0xa1, 0x9d, 0x56, 0x60, 0x45, 0x8b, 0xb3, 0x0a, 0x1f,
+	0x5d, 0x41, 0x9b, 0x54, 0xdd, 0x0b, 0xa2, 0x2a, 0x15, 0x59, 0x23, 0x94, 0x56, 0xad, 0xd0, 0x6c,
+	0xdb, 0x03, 0x1c, 0x16, 0xc7, 0x9e, 0x4d, 0xac, 0xc4, 0x1e, 0xd7, 0x33, 0xae, 0xc8, 0x8d, 0x9f,
+	0xc0, 0x1f, 0xe0, 0x0c, 0x12, 0xbf, 0x81, 0x7b, 0x8f, 0x7b, 0xac, 0x38, 0x44, 0x6c, 0xf6, 0x00,
+	0x8a, 0x38, 0xf4, 0x1f, 0x80, 0x66, 0x3c, 0x9b, 0xd8, 0x69, 0xa8, 0xb6, 0xcb, 0xc9, 0xef, 0xbc,
+	0x1f, 0xcf, 0xfb, 0x31, 0xcf, 0x3b, 0x32, 0xdc, 0x0c, 0xc7, 0xc3, 0xae, 0xc3, 0xfc, 0xd0, 0x76,
+	0x04, 0x8b, 0xba, 0x2e, 0x9d, 0x50, 0xe1, 0xb1, 0x60, 0x29, 0x84, 0x11, 0x13, 0xac, 0x2b, 0xa6,
+	0x21, 0xe5, 0x1d, 0x25, 0xe3, 0x7a, 0xc6, 0xd4, 0xbc, 0x3a, 0x64, 0x43, 0x96, 0x78, 0x49, 0x29,
+	0x71, 0x6a, 0xff, 0x56, 0x84, 0xba, 0x25, 0xfd, 0x28, 0xa1, 0x4f, 0x63, 0xca, 0x05, 0xbe, 0x0e,
+	0xd5, 0x28, 0x11, 0xfb, 0x96, 0x81, 0x76, 0xd0, 0x6e, 0xb5, 0xd7, 0x58, 0xcc, 0x5a, 0xa0, 0x95,
+	0x87, 0x9e, 0x4b, 0x56, 0x0e, 0xf8, 0x5b, 0xa8, 0x72, 0x61, 0x47, 0xe2, 0x91, 0xe7, 0x53, 0x23,
+	0xbf, 0x83, 0x76, 0x0b, 0xbd, 0x3b, 0xcf, 0x67, 0xad, 0xdc, 0xef, 0xb3, 0xd6, 0xb5, 0xa1, 0x27,
+	0x46, 0xf1, 0xa0, 0xe3, 0x30, 0xbf, 0x1b, 0x46, 0xcc, 0xa7, 0x62, 0x44, 0x63, 0x2e, 0x7b, 0xf0,
+	0x59, 0xd0, 0xf5, 0x99, 0x4b, 0x27, 0x1d, 0x19, 0x26, 0xc1, 0x15, 0xc6, 0xa1, 0xf0, 0x7c, 0x4a,
+	0x56, 0x78, 0xf8, 0x31, 0x94, 0x69, 0xe0, 0x2a, 0xe8, 0x82, 0x82, 0xbe, 0xfd, 0xe6, 0xd0, 0x15,
+	0x1a, 0xb8, 0x09, 0xf0, 0x19, 0x16, 0x6e, 0x41, 0xe9, 0x69, 0x4c, 0xa3, 0xa9, 0x51, 0x54, 0xdd,
+	0x55, 0x17, 0xb3, 0x56, 0xa2, 0x20, 0xc9, 0x07, 0xdf, 0x81, 0x2d, 0x2e, 0x6c, 0x11, 0x73, 0xa3,
+	0xa4, 0x3c, 0x3e, 0xd4, 0x69, 0xaf, 0x64, 0x26, 0x75, 0xa0, 0x5c, 0x16, 0xb3, 0x96, 0x76, 0x26,
+	0xfa, 0x2b, 0x67, 0xe2, 0x44, 0xd4, 0x16, 0xd4, 0xfd, 0x42, 0x18, 0x5b, 0x17, 0x9e, 0x89, 0xc6,
+	0x38, 0xb4, 0x05, 0x59, 0xe1, 0xe1, 0xf7, 0x61, 0x2b, 0xe6, 0x34, 0xea, 0x5b, 0x46, 0x59, 0xd5,
+	0x56, 0x5b, 0xcc, 0x5a, 0x65, 0xa9, 0x91, 0x17, 0xa3, 0x4d, 0xf8, 0x1a, 0xd4, 0xb8, 0x2c, 0x32,
+	0x70, 0xe8, 0xc3, 0xd8, 0x37, 0x2a, 0xaa, 0x86, 0xd2, 0x62, 0xd6, 0x42, 0x37, 0x48, 0xda, 0x82,
+	0xef, 0x42, 0x51, 0x26, 0x35, 0xaa, 0x0a, 0xeb, 0x13, 0x5d, 0xe5, 0xe5, 0x4c, 0x9f, 0x0f, 0x98,
+	0x2b, 0xeb, 0x69, 0x48, 0xc7, 0xeb, 0xcc, 0xf7, 0x04, 0xf5, 0x43, 0x31, 0x25, 0x2a, 0xb0, 0xfd,
+	0x37, 0x82, 0x4b, 0x96, 0xe6, 0xd9, 0x03, 0x3b, 0xf0, 0x8e, 0x24, 0x85, 0x3e, 0x87, 0x8a, 0x66,
+	0x08, 0x37, 0xd0, 0x4e, 0x61, 0xb7, 0x76, 0x6b, 0xbb, 0x93, 0x21, 0x63, 0x27, 0x93, 0xa0, 0x57,
+	0x94, 0x79, 0xc9, 0x32, 0x06, 0x7f, 0x0d, 0x97, 0xdd, 0x38, 0x9c, 0x78, 0x8e, 0xbd, 0xf4, 0xe1,
+	0x46, 0xfe, 0xdc, 0x40, 0xaf, 0x06, 0xe3, 0x0f, 0xa0, 0xce, 0xe9, 0xd0, 0xa7, 0x81, 0xe0, 0xfb,
+	0x2c, 0x0e, 0x84, 0xe2, 0x53, 0x89, 0x64, 0x95, 0x78, 0x07, 0x6a, 0xce, 0x28, 0x0e, 0xc6, 0xda,
+	0xa7, 0xa8, 0x7c, 0xd2, 0xaa, 0xf6, 0x36, 0x54, 0xf6, 0xe5, 0xb1, 0x6f, 0x71, 0x7c, 0x09, 0x0a,
+	0x7d, 0x2b, 0x69, 0xb0, 0x4a, 0xa4, 0xd8, 0xfe, 0x13, 0x41, 0x4d, 0x99, 0xf9, 0x57, 0x11, 0x8b,
+	0xc3, 0xff, 0x3d, 0x07, 0x0b, 0xb6, 0x92, 0xe4, 0xba, 0xf9, 0x8f, 0xd6, 0xa2, 0x53, 0xb9, 0xb4,
+	0xfc, 0x65, 0x20, 0xa2, 0xa9, 0xc6, 0xd1, 0xb1, 0x4d, 0x02, 0xb5, 0x94, 0x51, 0x96, 0x3d, 0xa6,
+	0xd3, 0x64, 0xb3, 0x89, 0x14, 0xf1, 0x0d, 0x28, 0x3d, 0xb3, 0x27, 0x71, 0xb2, 0xbf, 0xb5, 0x5b,
+	0xef, 0x6e, 0xca, 0xd2, 0xb7, 0x38, 0x49, 0xbc, 0x3e, 0xcb, 0x7f, 0x8a, 0xda, 0x3f, 0x23, 0x28,
+	0x1f, 0x24, 0xb3, 0xc3, 0xdb, 0x50, 0x15, 0xf6, 0x60, 0x42, 0x1f, 0xda, 0x3e, 0xd5, 0xb0, 0x2b,
+	0x05, 0x7e, 0x67, 0xc9, 0xd7, 0xbc, 0x32, 0xe9, 0x13, 0xb6, 0xe0, 0x2d, 0x67, 0x55, 0x3e, 0x37,
+	0x0a, 0xaa, 0xc3, 0xe6, 0x7f, 0x77, 0xa8, 0xbb, 0xca, 0x44, 0x9d, 0xe3, 0xc6, 0x7e, 0x45, 0x50,
+	0x3b, 0x23, 0xe8, 0x3d, 0x36, 0xb8, 0x60, 0xb5, 0x4d, 0xa8, 0x38, 0x7a, 0x0c, 0xaa, 0xd2, 0x2a,
+	0x59, 0x9e, 0xf1, 0x3d, 0x68, 0xb8, 0xe9, 0x6b, 0xe4, 0x46, 0xf1, 0xdc, 0x77, 0xbd, 0x16, 0xd9,
+	0xfe, 0x07, 0x41, 0x49, 0xf5, 0x8c, 0xf7, 0xa1, 0x78, 0x14, 0x31, 0x5f, 0x95, 0x58, 0xe8, 0x75,
+	0xdf, 0xf0, 0xfd, 0x20, 0x2a, 0x18, 0xf7, 0xa1, 0x2c, 0x46, 0x11, 0x8b, 0x87, 0x23, 0x23, 0x7f,
+	0x31, 0x9c, 0xb3, 0x78, 0x39, 0xe9, 0x23, 0x2f, 0x18, 0xd2, 0x28, 0x8c, 0x3c, 0xbd, 0x3f, 0x45,
+	0x92, 0x56, 0x25, 0x33, 0xa2, 0xce, 0x98, 0xc7, 0xbe, 0xba, 0x88, 0x3a, 0x59, 0x9e, 0x71, 0x03,
+	0xf2, 0xf7, 0x7b, 0xea, 0x35, 0xad, 0x93, 0xfc, 0xfd, 0x1e, 0x36, 0xe4, 0xcb, 0x2e, 0x22, 0x8f,
+	0x72, 0xf5, 0x40, 0xd6, 0xc9, 0xd9, 0xb1, 0xbd, 0x40, 0xd0, 0x38, 0x10, 0x2c, 0xb2, 0x87, 0xf4,
+	0x71, 0xe8, 0xda, 0x82, 0x72, 0xfc, 0x04, 0xea, 0x11, 0x1d, 0xc4, 0xde, 0x44, 0x24, 0x74, 0xd0,
+	0xbb, 0x74, 0x73, 0x6d, 0xbe, 0xd9, 0xa8, 0x0e, 0x49, 0x87, 0x28, 0xea, 0x93, 0x2c, 0x0c, 0xde,
+	0x85, 0xb7, 0x13, 0xa6, 0x3c, 0x62, 0x16, 0xed, 0x07, 0x2e, 0xfd, 0x5e, 0xed, 0x59, 0x95, 0xac,
+	0xab, 0x9b, 0x4f, 0x00, 0xbf, 0x0a, 0xb7, 0x61, 0x93, 0x3e, 0xce, 0x6e, 0xd2, 0xd5, 0x4d, 0x6c,
+	0x4e, 0xaf, 0xd1, 0x4f, 0x79, 0x30, 0xb2, 0x65, 0xef, 0xb3, 0xc9, 0x84, 0x3a, 0x32, 0xe4, 0x82,
+	0x4c, 0xf5, 0xa0, 0xc1, 0x33, 0x88, 0x7a, 0xb3, 0x6e, 0xbf, 0x76, 0x5a, 0xab, 0xb4, 0x6b, 0x86,
+	0xf4, 0x83, 0xb2, 0x06, 0xdc, 0xfc, 0x0e, 0xae, 0x6c, 0x70, 0xde, 0x30, 0x96, 0xbd, 0xec, 0x58,
+	0xde, 0x7b, 0x6d, 0x29, 0xa9, 0xf9, 0xf4, 0xe2, 0xe3, 0x13, 0x33, 0xf7, 0xe2, 0xc4, 0xcc, 0xbd,
+	0x3c, 0x31, 0xd1, 0x0f, 0x73, 0x13, 0xfd, 0x32, 0x37, 0xd1, 0xf3, 0xb9, 0x89, 0x8e, 0xe7, 0x26,
+	0xfa, 0x63, 0x6e, 0xa2, 0xbf, 0xe6, 0x66, 0xee, 0xe5, 0xdc, 0x44, 0x3f, 0x9e, 0x9a, 0xb9, 0xe3,
+	0x53, 0x33, 0xf7, 0xe2, 0xd4, 0xcc, 0x7d, 0x73, 0x37, 0x45, 0xee, 0x61, 0x64, 0x1f, 0xd9, 0x81,
+	0xdd, 0x9d, 0xb0, 0xb1, 0xd7, 0x7d, 0xb6, 0xd7, 0x3d, 0xcf, 0x5f, 0xd4, 0x60, 0x4b, 0x7d, 0xf6,
+	0xfe, 0x1d, 0x00, 0xbf, 0xcf, 0xab, 0x5d, 0x74, 0x09, 0x00, 0x00,
 }
 
 func (this *DeleteRequest) Equal(that interface{}) bool {
@@ -709,6 +711,9 @@ func (this *DeleteRequest) Equal(that interface{}) bool {
 	if this.SequenceNum != that1.SequenceNum {
 		return false
 	}
+	if !this.Mode.Equal(that1.Mode) {
+		return false
+	}
 	return true
 }
 func (this *DeletionManifest) Equal(that interface{}) bool {
@@ -1020,7 +1025,7 @@ func (this *DeleteRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 12)
+	s := make([]string, 0, 13)
 	s = append(s, "&deletionproto.DeleteRequest{")
 	s = append(s, "RequestID: "+fmt.Sprintf("%#v", this.RequestID)+",\n")
 	s = append(s, "StartTime: "+fmt.Sprintf("%#v", this.StartTime)+",\n")
@@ -1030,6 +1035,7 @@ func (this *DeleteRequest) GoString() string {
 	s = append(s, "CreatedAt: "+fmt.Sprintf("%#v", this.CreatedAt)+",\n")
 	s = append(s, "UserID: "+fmt.Sprintf("%#v", this.UserID)+",\n")
 	s = append(s, "SequenceNum: "+fmt.Sprintf("%#v", this.SequenceNum)+",\n")
+	s = append(s, "Mode: "+fmt.Sprintf("%#v", this.Mode)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1225,6 +1231,13 @@ func (m *DeleteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Mode) > 0 {
+		i -= len(m.Mode)
+		copy(dAtA[i:], m.Mode)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Mode)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if m.SequenceNum != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.SequenceNum))
 		i--
@@ -1763,6 +1776,10 @@ func (m *DeleteRequest) Size() (n int) {
 	if m.SequenceNum != 0 {
 		n += 1 + sovTypes(uint64(m.SequenceNum))
 	}
+	l = len(m.Mode)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1987,6 +2004,7 @@ func (this *DeleteRequest) String() string {
 		`CreatedAt:` + fmt.Sprintf("%v", this.CreatedAt) + `,`,
 		`UserID:` + fmt.Sprintf("%v", this.UserID) + `,`,
 		`SequenceNum:` + fmt.Sprintf("%v", this.SequenceNum) + `,`,
+		`Mode:` + fmt.Sprintf("%v", this.Mode) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2385,6 +2403,38 @@ func (m *DeleteRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mode = DeleteRequestMode(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
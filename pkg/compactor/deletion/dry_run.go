@@ -0,0 +1,168 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/loki/v3/pkg/compactor/deletion/deletionproto"
+	"github.com/grafana/loki/v3/pkg/compactor/retention"
+)
+
+const (
+	DryRunQueryParam = "dry_run"
+
+	dryRunDayFormat = "2006-01-02"
+)
+
+var errDryRunNotSupported = errors.New("dry runs of delete requests are not supported by this compactor")
+
+// DeleteRequestsDryRunner estimates the impact of delete requests without adding them.
+type DeleteRequestsDryRunner interface {
+	DryRun(ctx context.Context, userID, query string, startTime, endTime model.Time) (DryRunResult, error)
+}
+
+// DryRunResult is the estimated impact of a delete request.
+// The lines and bytes of the chunks partially covered by the request interval are prorated,
+// and all the lines of the selected chunks are counted for the requests with line filters.
+// The tables with index files not compacted yet are not read, and are listed as uncompacted.
+type DryRunResult struct {
+	Streams           int         `json:"streams"`
+	Chunks            int         `json:"chunks"`
+	EstimatedLines    uint64      `json:"estimated_lines"`
+	EstimatedBytes    uint64      `json:"estimated_bytes"`
+	Days              []DryRunDay `json:"days"`
+	UncompactedTables []string    `json:"uncompacted_tables,omitempty"`
+}
+
+// DryRunDay is the estimated impact of a delete request on the chunks starting on a day, in UTC.
+type DryRunDay struct {
+	Day            string `json:"day"`
+	Streams        int    `json:"streams"`
+	Chunks         int    `json:"chunks"`
+	EstimatedLines uint64 `json:"estimated_lines"`
+	EstimatedBytes uint64 `json:"estimated_bytes"`
+}
+
+// dryRunEstimator accumulates the chunks of the series selected by a delete request,
+// the same way the deletion manifest builder selects the chunks to process.
+type dryRunEstimator struct {
+	deleteRequests   []*deleteRequest
+	deletionInterval model.Interval
+
+	streams map[string]struct{}
+	days    map[string]*dryRunDayEstimate
+	// chunks overlapping multiple days are indexed in the tables of all of them,
+	// and chunks can be indexed in multiple index files of a table
+	seenChunks map[string]struct{}
+	result     DryRunResult
+}
+
+type dryRunDayEstimate struct {
+	DryRunDay
+	streams map[string]struct{}
+}
+
+func newDryRunEstimator(request *deleteRequest) *dryRunEstimator {
+	return &dryRunEstimator{
+		deleteRequests:   []*deleteRequest{request},
+		deletionInterval: model.Interval{Start: request.StartTime, End: request.EndTime},
+		streams:          map[string]struct{}{},
+		days:             map[string]*dryRunDayEstimate{},
+		seenChunks:       map[string]struct{}{},
+	}
+}
+
+// AddSeries adds the chunks of the series selected by the delete request to the estimate.
+func (e *dryRunEstimator) AddSeries(series retention.Series) {
+	lbls := series.Labels()
+	if !seriesSelectedByRequests(e.deleteRequests, lbls) {
+		return
+	}
+	stream := lbls.String()
+
+	_ = forEachSelectedChunk(series, e.deleteRequests, e.deletionInterval, func(chk retention.Chunk, _ uint64) error {
+		if _, ok := e.seenChunks[chk.ChunkID]; ok {
+			return nil
+		}
+		e.seenChunks[chk.ChunkID] = struct{}{}
+
+		start := max(chk.From, e.deletionInterval.Start)
+		day := start.Time().UTC().Format(dryRunDayFormat)
+		dayEstimate, ok := e.days[day]
+		if !ok {
+			dayEstimate = &dryRunDayEstimate{DryRunDay: DryRunDay{Day: day}, streams: map[string]struct{}{}}
+			e.days[day] = dayEstimate
+		}
+		if _, ok := dayEstimate.streams[stream]; !ok {
+			dayEstimate.streams[stream] = struct{}{}
+			dayEstimate.Streams++
+		}
+		e.streams[stream] = struct{}{}
+
+		ratio := 1.0
+		if chk.Through > chk.From {
+			end := min(chk.Through, e.deletionInterval.End)
+			ratio = float64(end-start) / float64(chk.Through-chk.From)
+		}
+		lines := uint64(float64(chk.Entries) * ratio)
+		bytes := uint64(float64(chk.KB) * 1024 * ratio)
+
+		dayEstimate.Chunks++
+		dayEstimate.EstimatedLines += lines
+		dayEstimate.EstimatedBytes += bytes
+		e.result.Chunks++
+		e.result.EstimatedLines += lines
+		e.result.EstimatedBytes += bytes
+		return nil
+	})
+}
+
+func (e *dryRunEstimator) Result() DryRunResult {
+	result := e.result
+	result.Streams = len(e.streams)
+	result.Days = make([]DryRunDay, 0, len(e.days))
+	for _, day := range e.days {
+		result.Days = append(result.Days, day.DryRunDay)
+	}
+	sort.Slice(result.Days, func(i, j int) bool {
+		return result.Days[i].Day < result.Days[j].Day
+	})
+
+	return result
+}
+
+// DryRun estimates the impact of a delete request of the user from the chunks it selects in the index,
+// without adding the request. The index of the tables overlapping the request interval is only read.
+func (d *DeleteRequestsManager) DryRun(ctx context.Context, userID, query string, startTime, endTime model.Time) (DryRunResult, error) {
+	if d.tablesManager == nil {
+		return DryRunResult{}, errDryRunNotSupported
+	}
+
+	deleteRequest, err := newDeleteRequest(deletionproto.DeleteRequest{
+		UserID:    userID,
+		Query:     query,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}, nil)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+
+	estimator := newDryRunEstimator(deleteRequest)
+	uncompactedTables, err := d.tablesManager.ReadUserIndexes(ctx, userID, estimator.deletionInterval, func(_ string, index retention.SeriesIterator) error {
+		return index.ForEachSeries(ctx, func(series retention.Series) error {
+			estimator.AddSeries(series)
+			return nil
+		})
+	})
+	if err != nil {
+		return DryRunResult{}, err
+	}
+
+	result := estimator.Result()
+	result.UncompactedTables = uncompactedTables
+	return result, nil
+}
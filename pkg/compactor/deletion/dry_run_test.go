@@ -0,0 +1,80 @@
+package deletion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/compactor/deletion/deletionproto"
+	"github.com/grafana/loki/v3/pkg/compactor/retention"
+)
+
+func TestDryRunEstimator(t *testing.T) {
+	day := model.TimeFromUnix(int64(24 * time.Hour / time.Second))
+	at := func(d, h int) model.Time {
+		return day.Add(time.Duration(d)*24*time.Hour + time.Duration(h)*time.Hour)
+	}
+
+	deleteRequest, err := newDeleteRequest(deletionproto.DeleteRequest{
+		UserID:    user1,
+		Query:     `{foo="bar"}`,
+		StartTime: at(0, 12),
+		EndTime:   at(1, 12),
+	}, nil)
+	require.NoError(t, err)
+
+	spanningChunk := retention.Chunk{ChunkID: "spanning", From: at(0, 23), Through: at(1, 1), Entries: 40, KB: 4}
+	estimator := newDryRunEstimator(deleteRequest)
+	for _, series := range []*mockSeries{
+		// table of the first day
+		{
+			userID: user1,
+			labels: labels.FromStrings("foo", "bar"),
+			chunks: []retention.Chunk{
+				{ChunkID: "before", From: at(0, 0), Through: at(0, 1), Entries: 10, KB: 1},
+				{ChunkID: "partial", From: at(0, 11), Through: at(0, 13), Entries: 100, KB: 2},
+				spanningChunk,
+			},
+		},
+		{
+			userID: user1,
+			labels: labels.FromStrings("foo", "baz"),
+			chunks: []retention.Chunk{{ChunkID: "other", From: at(0, 13), Through: at(0, 14), Entries: 10, KB: 1}},
+		},
+		// table of the second day
+		{
+			userID: user1,
+			labels: labels.FromStrings("foo", "bar"),
+			chunks: []retention.Chunk{
+				spanningChunk,
+				{ChunkID: "second-day", From: at(1, 6), Through: at(1, 7), Entries: 10, KB: 1},
+			},
+		},
+		{
+			userID: user1,
+			labels: labels.FromStrings("foo", "bar", "x", "y"),
+			chunks: []retention.Chunk{{ChunkID: "other-stream", From: at(1, 10), Through: at(1, 11), Entries: 5, KB: 1}},
+		},
+		{
+			userID: user2,
+			labels: labels.FromStrings("foo", "bar"),
+			chunks: []retention.Chunk{{ChunkID: "other-user", From: at(1, 10), Through: at(1, 11), Entries: 5, KB: 1}},
+		},
+	} {
+		estimator.AddSeries(series)
+	}
+
+	require.Equal(t, DryRunResult{
+		Streams:        2,
+		Chunks:         4,
+		EstimatedLines: 105,
+		EstimatedBytes: 7 * 1024,
+		Days: []DryRunDay{
+			{Day: "1970-01-02", Streams: 1, Chunks: 2, EstimatedLines: 90, EstimatedBytes: 5 * 1024},
+			{Day: "1970-01-03", Streams: 2, Chunks: 2, EstimatedLines: 15, EstimatedBytes: 2 * 1024},
+		},
+	}, estimator.Result())
+}
@@ -72,8 +72,8 @@ func (jr *JobRunner) Run(ctx context.Context, job *grpc.Job) ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
-		if !req.logSelectorExpr.HasFilter() {
-			return nil, errors.New("deletion query does not contain filter")
+		if !processesEntries(req.logSelectorExpr, req.Mode) {
+			return nil, errors.New("deletion query does not contain filter and the request does not rewrite log entries")
 		}
 		deleteRequests = append(deleteRequests, req)
 	}
@@ -103,8 +103,10 @@ func (jr *JobRunner) Run(ctx context.Context, job *grpc.Job) ([]byte, error) {
 			return fmt.Errorf("expected 1 entry for chunk %s but found %d in storage", chunkID, len(chks))
 		}
 
-		// Build a chain of filters to apply on the chunk to remove data requested for deletion.
+		// Build a chain of filters to apply on the chunk to remove data requested for deletion,
+		// and a chain of rewrites to apply on the remaining data requested for rewriting.
 		var filterFuncs []filter.Func
+		var rewriteFuncs []filter.RewriteFunc
 		for _, req := range deleteRequests {
 			filterFunc, err := req.FilterFunction(chks[0].Metric)
 			if err != nil {
@@ -112,10 +114,29 @@ func (jr *JobRunner) Run(ctx context.Context, job *grpc.Job) ([]byte, error) {
 			}
 
 			filterFuncs = append(filterFuncs, filterFunc)
+
+			rewriteFunc, err := req.RewriteFunction(chks[0].Metric)
+			if err != nil {
+				return err
+			}
+
+			if rewriteFunc != nil {
+				rewriteFuncs = append(rewriteFuncs, rewriteFunc)
+			}
 		}
 
-		// rebuild the chunk and see if we excluded any of the lines from the existing chunk
+		// rebuild the chunk and see if we excluded or rewrote any of the lines from the existing chunk
 		var linesDeleted bool
+		var rewrite filter.RewriteFunc
+		if rewriteFunc := chainRewriteFuncs(rewriteFuncs); rewriteFunc != nil {
+			rewrite = func(ts time.Time, s string, structuredMetadata labels.Labels) (string, labels.Labels, bool) {
+				line, newStructuredMetadata, ok := rewriteFunc(ts, s, structuredMetadata)
+				if ok {
+					linesDeleted = true
+				}
+				return line, newStructuredMetadata, ok
+			}
+		}
 		newChunkData, err := chks[0].Data.Rewrite(func(ts time.Time, s string, structuredMetadata labels.Labels) bool {
 			for _, filterFunc := range filterFuncs {
 				if filterFunc(ts, s, structuredMetadata) {
@@ -125,7 +146,7 @@ func (jr *JobRunner) Run(ctx context.Context, job *grpc.Job) ([]byte, error) {
 			}
 
 			return false
-		})
+		}, rewrite)
 		if err != nil {
 			if errors.Is(err, storage_chunk.ErrRewriteNoDataLeft) {
 				level.Info(util_log.Logger).Log("msg", "Delete request filterFunc leaves an empty chunk", "chunk ref", chunkID)
@@ -137,7 +158,7 @@ func (jr *JobRunner) Run(ctx context.Context, job *grpc.Job) ([]byte, error) {
 			return err
 		}
 
-		// if no lines were deleted then there is nothing to do
+		// if no lines were deleted or rewritten then there is nothing to do
 		if !linesDeleted {
 			return nil
 		}
@@ -245,6 +245,41 @@ func TestJobRunner_Run(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "rewrite request dropping structured metadata",
+			deleteRequests: []deletionproto.DeleteRequest{
+				{
+					RequestID: "test-request-9",
+					// the structured metadata named like the stream label foo is referred to with the _extracted suffix
+					Query:     lblFoo.String() + ` | drop foo_extracted`,
+					Mode:      deletionproto.ModeRewrite,
+					StartTime: yesterdaysTableInterval.Start.Add(-7 * time.Hour),
+					EndTime:   yesterdaysTableInterval.Start.Add(7 * time.Hour),
+				},
+			},
+			expectedResult: &deletionproto.StorageUpdates{
+				RebuiltChunks: map[string]*deletionproto.Chunk{
+					chunkKey(chk): {
+						From:        yesterdaysTableInterval.Start.Add(-6 * time.Hour),
+						Through:     yesterdaysTableInterval.Start.Add(6 * time.Hour),
+						Fingerprint: labels.StableHash(lblFoo),
+					},
+				},
+			},
+		},
+		{
+			name: "rewrite request not changing the entries",
+			deleteRequests: []deletionproto.DeleteRequest{
+				{
+					RequestID: "test-request-10",
+					Query:     lblFoo.String() + ` | drop bar`,
+					Mode:      deletionproto.ModeRewrite,
+					StartTime: yesterdaysTableInterval.Start.Add(-7 * time.Hour),
+					EndTime:   yesterdaysTableInterval.Start.Add(7 * time.Hour),
+				},
+			},
+			expectedResult: &deletionproto.StorageUpdates{},
+		},
 		{
 			name: "old chunk to be de-indexed when new chunk wont belong to the current table",
 			deleteRequests: []deletionproto.DeleteRequest{
@@ -32,6 +32,8 @@ type DeleteRequestHandler struct {
 	maxInterval         time.Duration
 
 	deleteRequestCancelPeriod time.Duration
+
+	dryRunner DeleteRequestsDryRunner
 }
 
 // NewDeleteRequestHandler creates a DeleteRequestHandler
@@ -46,6 +48,11 @@ func NewDeleteRequestHandler(deleteStore DeleteRequestsStore, maxInterval, delet
 	return &deleteMgr
 }
 
+// SetDryRunner sets the DeleteRequestsDryRunner estimating the impact of the delete requests added with the dry_run parameter.
+func (dm *DeleteRequestHandler) SetDryRunner(dryRunner DeleteRequestsDryRunner) {
+	dm.dryRunner = dryRunner
+}
+
 // AddDeleteRequestHandler handles addition of a new delete request
 func (dm *DeleteRequestHandler) AddDeleteRequestHandler(w http.ResponseWriter, r *http.Request) {
 	if dm == nil {
@@ -66,6 +73,12 @@ func (dm *DeleteRequestHandler) AddDeleteRequestHandler(w http.ResponseWriter, r
 		return
 	}
 
+	mode, err := mode(params, parsedExpr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	startTime, err := startTime(params)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -79,8 +92,8 @@ func (dm *DeleteRequestHandler) AddDeleteRequestHandler(w http.ResponseWriter, r
 	}
 
 	var shardByInterval time.Duration
-	// shard delete requests only when there are line filters
-	if parsedExpr.HasFilter() {
+	// shard delete requests only when there are line filters or the log entries are rewritten
+	if processesEntries(parsedExpr, mode) {
 		var err error
 		shardByInterval, err = dm.interval(params, startTime, endTime)
 		if err != nil {
@@ -89,7 +102,12 @@ func (dm *DeleteRequestHandler) AddDeleteRequestHandler(w http.ResponseWriter, r
 		}
 	}
 
-	requestID, err := dm.deleteRequestsStore.AddDeleteRequest(ctx, userID, query, startTime, endTime, shardByInterval)
+	if params.Get(DryRunQueryParam) == "true" {
+		dm.dryRun(w, r, userID, query, startTime, endTime)
+		return
+	}
+
+	requestID, err := dm.deleteRequestsStore.AddDeleteRequest(ctx, userID, query, mode, startTime, endTime, shardByInterval)
 	if err != nil {
 		level.Error(util_log.Logger).Log("msg", "error adding delete request to the store", "err", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -101,6 +119,7 @@ func (dm *DeleteRequestHandler) AddDeleteRequestHandler(w http.ResponseWriter, r
 		"delete_request_id", requestID,
 		"user", userID,
 		"query", query,
+		"rewrite", mode == deletionproto.ModeRewrite,
 		"interval", shardByInterval.String(),
 	)
 
@@ -109,6 +128,35 @@ func (dm *DeleteRequestHandler) AddDeleteRequestHandler(w http.ResponseWriter, r
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// dryRun responds with the estimated impact of the delete request instead of adding it.
+func (dm *DeleteRequestHandler) dryRun(w http.ResponseWriter, r *http.Request, userID, query string, startTime, endTime model.Time) {
+	if dm.dryRunner == nil {
+		http.Error(w, errDryRunNotSupported.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := dm.dryRunner.DryRun(r.Context(), userID, query, startTime, endTime)
+	if err != nil {
+		level.Error(util_log.Logger).Log("msg", "error estimating the impact of the delete request", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	level.Info(util_log.Logger).Log(
+		"msg", "delete request dry run",
+		"user", userID,
+		"query", query,
+		"streams", result.Streams,
+		"chunks", result.Chunks,
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		level.Error(util_log.Logger).Log("msg", "error marshalling response", "err", err)
+		http.Error(w, fmt.Sprintf("Error marshalling response: %v", err), http.StatusInternalServerError)
+	}
+}
+
 func (dm *DeleteRequestHandler) interval(params url.Values, startTime, endTime model.Time) (time.Duration, error) {
 	qr := params.Get("max_interval")
 	if qr == "" {
@@ -332,6 +380,19 @@ func query(params url.Values) (string, syntax.LogSelectorExpr, error) {
 	return query, parsedExpr, nil
 }
 
+func mode(params url.Values, parsedExpr syntax.LogSelectorExpr) (deletionproto.DeleteRequestMode, error) {
+	mode, err := deletionproto.ParseDeleteRequestMode(params.Get("mode"))
+	if err != nil {
+		return mode, err
+	}
+
+	if mode == deletionproto.ModeRewrite && !rewritesEntries(parsedExpr) {
+		return mode, errors.New("query of rewrite request must have drop or line_format stages")
+	}
+
+	return mode, nil
+}
+
 func startTime(params url.Values) (model.Time, error) {
 	startParam := params.Get("start")
 	if startParam == "" {
@@ -385,7 +446,7 @@ func timeFromInt(in string) (int64, error) {
 	return util.ParseTime(in)
 }
 
-func buildRequests(shardByInterval time.Duration, query, userID string, startTime, endTime model.Time) []deletionproto.DeleteRequest {
+func buildRequests(shardByInterval time.Duration, query string, mode deletionproto.DeleteRequestMode, userID string, startTime, endTime model.Time) []deletionproto.DeleteRequest {
 	var deleteRequests []deletionproto.DeleteRequest
 
 	if shardByInterval == 0 || shardByInterval >= endTime.Sub(startTime) {
@@ -394,6 +455,7 @@ func buildRequests(shardByInterval time.Duration, query, userID string, startTim
 				StartTime: startTime,
 				EndTime:   endTime,
 				Query:     query,
+				Mode:      mode,
 				UserID:    userID,
 			},
 		}
@@ -406,6 +468,7 @@ func buildRequests(shardByInterval time.Duration, query, userID string, startTim
 				StartTime: model.Time(start.UnixMilli()),
 				EndTime:   model.Time(end.UnixMilli()),
 				Query:     query,
+				Mode:      mode,
 				UserID:    userID,
 			})
 		})
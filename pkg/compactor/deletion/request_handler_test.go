@@ -94,6 +94,49 @@ func TestAddDeleteRequestHandler(t *testing.T) {
 		require.Equal(t, time.Duration(0), store.addReq.shardByInterval)
 	})
 
+	t.Run("it adds rewrite requests with their mode and shards them", func(t *testing.T) {
+		store := &mockDeleteRequestsStore{}
+		h := NewDeleteRequestHandler(store, time.Hour, 0, nil)
+
+		now := model.Now()
+		from := model.TimeFromUnix(now.Add(-3 * time.Hour).Unix())
+		to := model.TimeFromUnix(now.Unix())
+
+		req := buildRequest("org-id", `{foo="bar"} | drop email`, unixString(from), unixString(to), false)
+		params := req.URL.Query()
+		params.Set("mode", "rewrite")
+		req.URL.RawQuery = params.Encode()
+
+		w := httptest.NewRecorder()
+		h.AddDeleteRequestHandler(w, req)
+
+		require.Equal(t, w.Code, http.StatusNoContent)
+		require.Equal(t, deletionproto.ModeRewrite, store.addReq.mode)
+		require.Equal(t, time.Hour, store.addReq.shardByInterval)
+	})
+
+	t.Run("it validates the mode", func(t *testing.T) {
+		h := NewDeleteRequestHandler(&mockDeleteRequestsStore{}, 0, 0, nil)
+
+		for _, tc := range []struct {
+			query, mode, error string
+		}{
+			{`{foo="bar"} | drop email`, "redact", "invalid mode \"redact\", must be one of delete or rewrite\n"},
+			{`{foo="bar"} |= "foo"`, "rewrite", "query of rewrite request must have drop or line_format stages\n"},
+		} {
+			req := buildRequest("org-id", tc.query, "0000000000", "0000000001", false)
+			params := req.URL.Query()
+			params.Set("mode", tc.mode)
+			req.URL.RawQuery = params.Encode()
+
+			w := httptest.NewRecorder()
+			h.AddDeleteRequestHandler(w, req)
+
+			require.Equal(t, w.Code, http.StatusBadRequest)
+			require.Equal(t, tc.error, w.Body.String())
+		}
+	})
+
 	t.Run("it works with RFC3339", func(t *testing.T) {
 		store := &mockDeleteRequestsStore{}
 		h := NewDeleteRequestHandler(store, 0, 0, nil)
@@ -139,6 +182,44 @@ func TestAddDeleteRequestHandler(t *testing.T) {
 		require.Equal(t, w.Code, http.StatusInternalServerError)
 	})
 
+	t.Run("it estimates the impact of the delete request without adding it on dry runs", func(t *testing.T) {
+		store := &mockDeleteRequestsStore{}
+		dryRunner := &mockDryRunner{result: DryRunResult{Streams: 1, Chunks: 2, EstimatedLines: 10, EstimatedBytes: 1024}}
+		h := NewDeleteRequestHandler(store, 0, 0, nil)
+		h.SetDryRunner(dryRunner)
+
+		req := buildRequest("org-id", `{foo="bar"}`, "0000000000", "0000000001", false)
+		params := req.URL.Query()
+		params.Set(DryRunQueryParam, "true")
+		req.URL.RawQuery = params.Encode()
+
+		w := httptest.NewRecorder()
+		h.AddDeleteRequestHandler(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var result DryRunResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Equal(t, dryRunner.result, result)
+		require.Equal(t, "org-id", dryRunner.userID)
+		require.Equal(t, `{foo="bar"}`, dryRunner.query)
+		require.Empty(t, store.addReq.userID)
+	})
+
+	t.Run("it returns 400 on dry runs when they are not supported", func(t *testing.T) {
+		store := &mockDeleteRequestsStore{}
+		h := NewDeleteRequestHandler(store, 0, 0, nil)
+
+		req := buildRequest("org-id", `{foo="bar"}`, "0000000000", "0000000001", false)
+		params := req.URL.Query()
+		params.Set(DryRunQueryParam, "true")
+		req.URL.RawQuery = params.Encode()
+
+		w := httptest.NewRecorder()
+		h.AddDeleteRequestHandler(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Empty(t, store.addReq.userID)
+	})
+
 	t.Run("Validation", func(t *testing.T) {
 		h := NewDeleteRequestHandler(&mockDeleteRequestsStore{}, time.Minute, 0, nil)
 
@@ -340,6 +421,28 @@ func TestGetAllDeleteRequestsHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("it returns the rewrite requests with their mode", func(t *testing.T) {
+		store := &mockDeleteRequestsStore{}
+		store.getAllResult = []deletionproto.DeleteRequest{
+			{RequestID: "test-request-1", Query: `{foo="bar"} |= "secret"`, Status: deletionproto.StatusReceived},
+			{RequestID: "test-request-2", Query: `{foo="bar"} |= "secret" | drop email`, Status: deletionproto.StatusReceived, Mode: deletionproto.ModeRewrite},
+		}
+		h := NewDeleteRequestHandler(store, 0, 0, nil)
+
+		for _, forQuerytimeFiltering := range []bool{false, true} {
+			req := buildRequest("org-id", ``, "", "", forQuerytimeFiltering)
+
+			w := httptest.NewRecorder()
+			h.GetAllDeleteRequestsHandler(w, req)
+
+			require.Equal(t, w.Code, http.StatusOK)
+
+			var result []deletionproto.DeleteRequest
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+			require.ElementsMatch(t, store.getAllResult, result)
+		}
+	})
+
 	t.Run("error getting from store", func(t *testing.T) {
 		store := &mockDeleteRequestsStore{}
 		store.getAllErr = errors.New("something bad")
@@ -434,3 +537,15 @@ func verifyRequestSplits(t *testing.T, from, to model.Time, shardInterval time.D
 		}
 	}
 }
+
+type mockDryRunner struct {
+	result     DryRunResult
+	userID     string
+	query      string
+	start, end model.Time
+}
+
+func (m *mockDryRunner) DryRun(_ context.Context, userID, query string, startTime, endTime model.Time) (DryRunResult, error) {
+	m.userID, m.query, m.start, m.end = userID, query, startTime, endTime
+	return m.result, nil
+}
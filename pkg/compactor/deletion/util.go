@@ -2,9 +2,14 @@ package deletion
 
 import (
 	"errors"
+	"time"
 
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/v3/pkg/compactor/deletion/deletionproto"
 	"github.com/grafana/loki/v3/pkg/compactor/deletionmode"
 	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/util/filter"
 )
 
 var (
@@ -21,6 +26,48 @@ func parseDeletionQuery(query string) (syntax.LogSelectorExpr, error) {
 	return logSelectorExpr, nil
 }
 
+// rewritesEntries tells whether the deletion query has drop or line_format stages, which rewrite the
+// log entries selected by the requests in rewrite mode.
+func rewritesEntries(expr syntax.LogSelectorExpr) bool {
+	pipelineExpr, ok := expr.(*syntax.PipelineExpr)
+	if !ok {
+		return false
+	}
+
+	for _, stage := range pipelineExpr.MultiStages {
+		switch stage.(type) {
+		case *syntax.DropLabelsExpr, *syntax.LineFmtExpr:
+			return true
+		}
+	}
+	return false
+}
+
+// processesEntries tells whether the log entries of the chunks selected by the deletion query
+// have to be processed, because it has line filters or the request rewrites the entries.
+func processesEntries(expr syntax.LogSelectorExpr, mode deletionproto.DeleteRequestMode) bool {
+	return expr.HasFilter() || mode == deletionproto.ModeRewrite
+}
+
+// chainRewriteFuncs returns a rewrite function applying the given rewrite functions in order,
+// or nil if there are none.
+func chainRewriteFuncs(rewriteFuncs []filter.RewriteFunc) filter.RewriteFunc {
+	if len(rewriteFuncs) == 0 {
+		return nil
+	}
+
+	return func(ts time.Time, s string, structuredMetadata labels.Labels) (string, labels.Labels, bool) {
+		rewritten := false
+		for _, rewriteFunc := range rewriteFuncs {
+			if line, newStructuredMetadata, ok := rewriteFunc(ts, s, structuredMetadata); ok {
+				s, structuredMetadata, rewritten = line, newStructuredMetadata, true
+			}
+		}
+
+		return s, structuredMetadata, rewritten
+	}
+}
+
 func validDeletionLimit(l Limits, userID string) (bool, error) {
 	mode, err := deleteModeFromLimits(l, userID)
 	if err != nil {
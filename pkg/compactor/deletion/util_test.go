@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/compactor/deletion/deletionproto"
 )
 
 func TestParseLogQLExpressionForDeletion(t *testing.T) {
@@ -37,3 +39,42 @@ func TestParseLogQLExpressionForDeletion(t *testing.T) {
 		require.ErrorIs(t, err, errInvalidQuery)
 	})
 }
+
+func TestRewritesEntries(t *testing.T) {
+	for _, tc := range []struct {
+		query           string
+		rewritesEntries bool
+	}{
+		{query: `{env="dev"}`},
+		{query: `{env="dev"} |= "secret"`},
+		{query: `{env="dev"} | json | msg="secret"`},
+		{query: `{env="dev"} | drop email`, rewritesEntries: true},
+		{query: `{env="dev"} |= "secret" | json | line_format "{{.msg}}"`, rewritesEntries: true},
+		{query: `{env="dev"} | drop email |= "secret"`, rewritesEntries: true},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			expr, err := parseDeletionQuery(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.rewritesEntries, rewritesEntries(expr))
+		})
+	}
+}
+
+func TestProcessesEntries(t *testing.T) {
+	for _, tc := range []struct {
+		query            string
+		mode             deletionproto.DeleteRequestMode
+		processesEntries bool
+	}{
+		{query: `{env="dev"}`, mode: deletionproto.ModeDelete},
+		{query: `{env="dev"} | drop email`, mode: deletionproto.ModeDelete},
+		{query: `{env="dev"} |= "secret"`, mode: deletionproto.ModeDelete, processesEntries: true},
+		{query: `{env="dev"} | drop email`, mode: deletionproto.ModeRewrite, processesEntries: true},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			expr, err := parseDeletionQuery(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.processesEntries, processesEntries(expr, tc.mode))
+		})
+	}
+}
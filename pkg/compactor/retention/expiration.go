@@ -34,6 +34,12 @@ type ExpirationChecker interface {
 	MarkSeriesAsProcessed(userID, seriesID []byte, lbls labels.Labels, tableName string) error
 }
 
+// EntryRewriter is optionally implemented by an ExpirationChecker rewriting the log entries
+// of the chunks it reports as expired with a filter.Func, instead of only removing them.
+type EntryRewriter interface {
+	RewriteFunc(userID []byte, chk Chunk, lbls labels.Labels, seriesID []byte, tableName string, now model.Time) filter.RewriteFunc
+}
+
 type expirationChecker struct {
 	tenantsRetention         *TenantsRetention
 	latestRetentionStartTime latestRetentionStartTime
@@ -37,6 +37,11 @@ type Chunk struct {
 	ChunkID string
 	From    model.Time
 	Through model.Time
+
+	// KB and Entries are the size of the chunk in KB and its number of entries,
+	// when known by the index.
+	KB      uint32
+	Entries uint32
 }
 
 func (c Chunk) String() string {
@@ -281,7 +286,11 @@ func markForDelete(
 					seriesHasLogsToRemoveWithFilter = true
 					wroteChunks := false
 					var err error
-					wroteChunks, linesDeleted, err = chunkRewriter.rewriteChunk(ctx, s.UserID(), c, tableInterval, filterFunc)
+					var rewriteFunc filter.RewriteFunc
+					if rewriter, ok := expiration.(EntryRewriter); ok {
+						rewriteFunc = rewriter.RewriteFunc(s.UserID(), c, s.Labels(), s.SeriesID(), tableName, now)
+					}
+					wroteChunks, linesDeleted, err = chunkRewriter.rewriteChunk(ctx, s.UserID(), c, tableInterval, filterFunc, rewriteFunc)
 					if err != nil {
 						return fmt.Errorf("failed to rewrite chunk %s with error %s", c.ChunkID, err)
 					}
@@ -479,13 +488,13 @@ func newChunkRewriter(chunkClient client.Client, tableName string, chunkIndexer
 	}
 }
 
-// rewriteChunk rewrites a chunk after filtering out logs using filterFunc.
-// It first builds a newChunk using filterFunc.
+// rewriteChunk rewrites a chunk after filtering out logs using filterFunc, and rewriting the remaining ones using rewriteFunc if set.
+// It first builds a newChunk using filterFunc and rewriteFunc.
 // If the newChunk is same as the original chunk then there is nothing to do here, wroteChunks and linesDeleted both would be false.
-// If the newChunk is different, linesDeleted would be true.
+// If the newChunk is different, linesDeleted would be true, even if the lines were only rewritten.
 // The newChunk is indexed and uploaded only if it belongs to the current index table being processed,
 // the status of which is set to wroteChunks.
-func (c *chunkRewriter) rewriteChunk(ctx context.Context, userID []byte, ce Chunk, tableInterval model.Interval, filterFunc filter.Func) (wroteChunks bool, linesDeleted bool, err error) {
+func (c *chunkRewriter) rewriteChunk(ctx context.Context, userID []byte, ce Chunk, tableInterval model.Interval, filterFunc filter.Func, rewriteFunc filter.RewriteFunc) (wroteChunks bool, linesDeleted bool, err error) {
 	userIDStr := unsafeGetString(userID)
 
 	chk, err := chunk.ParseExternalKey(userIDStr, ce.ChunkID)
@@ -502,6 +511,17 @@ func (c *chunkRewriter) rewriteChunk(ctx context.Context, userID []byte, ce Chun
 		return false, false, fmt.Errorf("expected 1 entry for chunk %s but found %d in storage", ce.ChunkID, len(chks))
 	}
 
+	var rewrite filter.RewriteFunc
+	if rewriteFunc != nil {
+		rewrite = func(ts time.Time, s string, structuredMetadata labels.Labels) (string, labels.Labels, bool) {
+			line, newStructuredMetadata, ok := rewriteFunc(ts, s, structuredMetadata)
+			if ok {
+				linesDeleted = true
+			}
+			return line, newStructuredMetadata, ok
+		}
+	}
+
 	newChunkData, err := chks[0].Data.Rewrite(func(ts time.Time, s string, structuredMetadata labels.Labels) bool {
 		if filterFunc(ts, s, structuredMetadata) {
 			linesDeleted = true
@@ -509,7 +529,7 @@ func (c *chunkRewriter) rewriteChunk(ctx context.Context, userID []byte, ce Chun
 		}
 
 		return false
-	})
+	}, rewrite)
 	if err != nil {
 		if errors.Is(err, chunk.ErrRewriteNoDataLeft) {
 			level.Info(util_log.Logger).Log("msg", "Delete request filterFunc leaves an empty chunk", "chunk ref", ce.ChunkID)
@@ -587,7 +587,7 @@ func TestChunkRewriter(t *testing.T) {
 					ChunkID: getChunkID(tt.chunk.ChunkRef),
 					From:    tt.chunk.From,
 					Through: tt.chunk.Through,
-				}, ExtractIntervalFromTableName(indexTable.name), tt.filterFunc)
+				}, ExtractIntervalFromTableName(indexTable.name), tt.filterFunc, nil)
 				require.NoError(t, err)
 				require.Equal(t, tt.expectedRespByTables[indexTable.name].mustDeleteLines, linesDeleted)
 				require.Equal(t, tt.expectedRespByTables[indexTable.name].mustRewriteChunk, wroteChunks)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"unsafe"
 
@@ -347,6 +348,49 @@ func (t *table) applyStorageUpdates(userID, labelsStr string, rebuiltChunks map[
 	return t.tableMarker.MarkChunksForDeletion(t.name, chunksToDelete)
 }
 
+// readUserIndex opens each index file of the user in turn, without compacting them nor modifying them in the storage.
+// It returns true if the table has common index files, which can only be read for a user once compacted.
+func (t *table) readUserIndex(userID string, callback func(retention.SeriesIterator) error) (bool, error) {
+	t.indexStorageClient.RefreshIndexTableCache(t.ctx, t.name)
+	commonIndexFiles, usersWithPerUserIndex, err := t.indexStorageClient.ListFiles(t.ctx, t.name, false)
+	if err != nil {
+		return false, err
+	}
+
+	if !slices.Contains(usersWithPerUserIndex, userID) {
+		return len(commonIndexFiles) > 0, nil
+	}
+
+	is, err := newUserIndexSet(t.ctx, t.name, userID, t.baseUserIndexSet, filepath.Join(t.workingDirectory, userID), t.logger)
+	if err != nil {
+		return false, err
+	}
+
+	for _, indexFile := range is.ListSourceFiles() {
+		if err := t.ctx.Err(); err != nil {
+			return false, err
+		}
+
+		downloadedAt, err := is.GetSourceFile(indexFile)
+		if err != nil {
+			return false, err
+		}
+
+		compactedIndex, err := t.indexCompactor.OpenCompactedIndexFile(t.ctx, downloadedAt, t.name, userID, filepath.Join(t.workingDirectory, userID), t.periodConfig, is.logger)
+		if err != nil {
+			return false, err
+		}
+
+		err = callback(compactedIndex)
+		compactedIndex.Cleanup()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return len(commonIndexFiles) > 0, nil
+}
+
 // cleanup takes care of cleaning up any local data on disk
 func (t *table) cleanup() {
 	for _, is := range t.indexSets {
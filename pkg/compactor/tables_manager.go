@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-kit/log/level"
 	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/loki/v3/pkg/compactor/deletion"
 	"github.com/grafana/loki/v3/pkg/compactor/retention"
@@ -20,6 +21,7 @@ type TablesManager interface {
 	CompactTable(ctx context.Context, tableName string, applyRetention bool) error
 	ApplyStorageUpdates(ctx context.Context, iterator deletion.StorageUpdatesIterator) error
 	IterateTables(ctx context.Context, callback func(string, deletion.Table) error) (err error)
+	ReadUserIndexes(ctx context.Context, userID string, interval model.Interval, callback func(string, retention.SeriesIterator) error) ([]string, error)
 }
 
 type tablesManager struct {
@@ -525,3 +527,58 @@ func (c *tablesManager) IterateTables(ctx context.Context, callback func(string,
 	}
 	return nil
 }
+
+// ReadUserIndexes reads the index of the user in the tables overlapping the interval, without compacting the tables
+// nor modifying their index in the storage. It returns the tables with index files waiting for compaction, which aren't read.
+func (c *tablesManager) ReadUserIndexes(ctx context.Context, userID string, interval model.Interval, callback func(string, retention.SeriesIterator) error) ([]string, error) {
+	tables, err := c.listTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var uncompactedTables []string
+	for _, tableName := range tables {
+		tableInterval := retention.ExtractIntervalFromTableName(tableName)
+		if tableInterval.Start > interval.End || interval.Start > tableInterval.End {
+			continue
+		}
+
+		uncompacted, err := func() (bool, error) {
+			for {
+				locked, lockWaiterChan := c.tableLocker.lockTable(tableName)
+				if locked {
+					break
+				}
+
+				select {
+				case <-lockWaiterChan:
+				case <-ctx.Done():
+					return false, ctx.Err()
+				}
+			}
+			defer c.tableLocker.unlockTable(tableName)
+
+			table, err := c.initTable(ctx, tableName)
+			if err != nil {
+				if errors.Is(err, errSchemaForTableNotFound) {
+					return false, nil
+				}
+				return false, err
+			}
+
+			defer table.cleanup()
+
+			return table.readUserIndex(userID, func(index retention.SeriesIterator) error {
+				return callback(tableName, index)
+			})
+		}()
+		if err != nil {
+			return nil, err
+		}
+		if uncompacted {
+			uncompactedTables = append(uncompactedTables, tableName)
+		}
+	}
+
+	return uncompactedTables, ctx.Err()
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/loki/v3/pkg/compactor/deletion"
+	"github.com/grafana/loki/v3/pkg/compactor/retention"
 	"github.com/grafana/loki/v3/pkg/storage/chunk/client"
 	"github.com/grafana/loki/v3/pkg/storage/chunk/client/local"
 	"github.com/grafana/loki/v3/pkg/storage/config"
@@ -301,6 +302,101 @@ func TestTablesManager_IterateTables(t *testing.T) {
 	}
 }
 
+func TestTablesManager_ReadUserIndexes(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tablesPath := filepath.Join(tempDir, "index")
+	daySeconds := int64(24 * time.Hour / time.Second)
+	tableNumEnd := time.Now().Unix() / daySeconds
+	tableNumStart := tableNumEnd - 5
+
+	periodConfigs := []config.PeriodConfig{
+		{
+			From:       config.DayTime{Time: model.Time(0)},
+			IndexType:  "dummy",
+			ObjectType: "fs_01",
+			IndexTables: config.IndexPeriodicTableConfig{
+				PathPrefix: "index/",
+				PeriodicTableConfig: config.PeriodicTableConfig{
+					Prefix: indexTablePrefix,
+					Period: config.ObjectStorageIndexRequiredPeriod,
+				}},
+		},
+	}
+
+	tableFiles := map[string][]string{}
+	for i := tableNumStart; i <= tableNumEnd; i++ {
+		tableName := fmt.Sprintf("%s%d", indexTablePrefix, i)
+		commonDBsConfig := IndexesConfig{}
+		if i == tableNumEnd {
+			// the index files uploaded since the last compaction
+			commonDBsConfig.NumUnCompactedFiles = 2
+		}
+		SetupTable(t, filepath.Join(tablesPath, tableName), commonDBsConfig, PerUserIndexesConfig{
+			IndexesConfig: IndexesConfig{NumCompactedFiles: 2},
+			NumUsers:      1,
+		})
+		tableFiles[tableName] = listTableFiles(t, filepath.Join(tablesPath, tableName))
+	}
+
+	var (
+		objectClients = map[config.DayTime]client.ObjectClient{}
+		err           error
+	)
+	objectClients[periodConfigs[0].From], err = local.NewFSObjectClient(local.FSConfig{Directory: tempDir})
+	require.NoError(t, err)
+
+	compactor := setupTestCompactor(t, objectClients, periodConfigs, tempDir)
+
+	// the interval of the 3 last tables
+	interval := model.Interval{
+		Start: model.TimeFromUnix((tableNumEnd - 2) * daySeconds),
+		End:   model.TimeFromUnix((tableNumEnd+1)*daySeconds - 1),
+	}
+	indexesRead := map[string]int{}
+	uncompactedTables, err := compactor.tablesManager.ReadUserIndexes(context.Background(), BuildUserID(0), interval, func(tableName string, _ retention.SeriesIterator) error {
+		// verify that table is locked while its index is read
+		require.True(t, compactor.tablesManager.tableLocker.isLocked(tableName))
+		indexesRead[tableName]++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{fmt.Sprintf("%s%d", indexTablePrefix, tableNumEnd)}, uncompactedTables)
+	require.Equal(t, map[string]int{
+		fmt.Sprintf("%s%d", indexTablePrefix, tableNumEnd-2): 2,
+		fmt.Sprintf("%s%d", indexTablePrefix, tableNumEnd-1): 2,
+		fmt.Sprintf("%s%d", indexTablePrefix, tableNumEnd):   2,
+	}, indexesRead)
+
+	// verify that the tables are unlocked and left untouched in the storage
+	for tableName, files := range tableFiles {
+		require.False(t, compactor.tablesManager.tableLocker.isLocked(tableName))
+		require.Equal(t, files, listTableFiles(t, filepath.Join(tablesPath, tableName)))
+	}
+
+	// verify that the walk stops when the context is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = compactor.tablesManager.ReadUserIndexes(ctx, BuildUserID(0), interval, func(_ string, _ retention.SeriesIterator) error {
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func listTableFiles(t *testing.T, path string) []string {
+	var files []string
+	require.NoError(t, filepath.WalkDir(path, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	}))
+	return files
+}
+
 func TestTablesManager_ApplyStorageUpdates(t *testing.T) {
 
 	daySeconds := int64(24 * time.Hour / time.Second)
@@ -107,6 +107,7 @@ type Config struct {
 	IngesterEnabled           bool `yaml:"ingester_writes_enabled"`
 	IngestLimitsEnabled       bool `yaml:"ingest_limits_enabled"`
 	IngestLimitsDryRunEnabled bool `yaml:"ingest_limits_dry_run_enabled"`
+	IngestLimitsRateLimits    bool `yaml:"ingest_limits_rate_limits_enabled"`
 
 	KafkaConfig kafka.Config `yaml:"-"`
 }
@@ -123,6 +124,7 @@ func (cfg *Config) RegisterFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&cfg.IngesterEnabled, "distributor.ingester-writes-enabled", true, "Enable writes to Ingesters during Push requests. Defaults to true.")
 	fs.BoolVar(&cfg.IngestLimitsEnabled, "distributor.ingest-limits-enabled", false, "Enable checking limits against the ingest-limits service. Defaults to false.")
 	fs.BoolVar(&cfg.IngestLimitsDryRunEnabled, "distributor.ingest-limits-dry-run-enabled", false, "Enable dry-run mode where limits are checked the ingest-limits service, but not enforced. Defaults to false.")
+	fs.BoolVar(&cfg.IngestLimitsRateLimits, "distributor.ingest-limits-rate-limits-enabled", false, "Enforce the ingestion rate limits in the ingest-limits service instead of the distributors. Requires the ingest-limits service to have rate limits enabled. Distributors still enforce the ingestion rate limit when the ingest-limits service cannot be reached. Has no effect in dry-run mode. Defaults to false.")
 }
 
 func (cfg *Config) Validate() error {
@@ -443,6 +445,7 @@ type KeyedStream struct {
 	HashKey        uint32
 	HashKeyNoShard uint64
 	Stream         logproto.Stream
+	Policy         string
 }
 
 // TODO taken from Cortex, see if we can refactor out an usable interface.
@@ -532,33 +535,38 @@ func (d *Distributor) PushWithResolver(ctx context.Context, req *logproto.PushRe
 	shouldDiscoverGenericFields := fieldDetector.shouldDiscoverGenericFields()
 
 	shardStreamsCfg := d.validator.ShardStreams(tenantID)
-	maybeShardByRate := func(stream logproto.Stream, pushSize int) {
+	maybeShardByRate := func(stream logproto.Stream, pushSize int, policy string) {
 		if shardStreamsCfg.Enabled {
-			streams = append(streams, d.shardStream(stream, pushSize, tenantID)...)
+			shards := d.shardStream(stream, pushSize, tenantID)
+			for i := range shards {
+				shards[i].Policy = policy
+			}
+			streams = append(streams, shards...)
 			return
 		}
 		streams = append(streams, KeyedStream{
 			HashKey:        lokiring.TokenFor(tenantID, stream.Labels),
 			HashKeyNoShard: stream.Hash,
 			Stream:         stream,
+			Policy:         policy,
 		})
 	}
 
-	maybeShardStreams := func(stream logproto.Stream, labels labels.Labels, pushSize int) {
+	maybeShardStreams := func(stream logproto.Stream, labels labels.Labels, pushSize int, policy string) {
 		if !shardStreamsCfg.TimeShardingEnabled {
-			maybeShardByRate(stream, pushSize)
+			maybeShardByRate(stream, pushSize, policy)
 			return
 		}
 
 		ignoreRecentFrom := now.Add(-shardStreamsCfg.TimeShardingIgnoreRecent)
 		streamsByTime, ok := shardStreamByTime(stream, labels, d.ingesterCfg.MaxChunkAge/2, ignoreRecentFrom)
 		if !ok {
-			maybeShardByRate(stream, pushSize)
+			maybeShardByRate(stream, pushSize, policy)
 			return
 		}
 
 		for _, ts := range streamsByTime {
-			maybeShardByRate(ts.Stream, ts.linesTotalLen)
+			maybeShardByRate(ts.Stream, ts.linesTotalLen, policy)
 		}
 	}
 
@@ -702,7 +710,7 @@ func (d *Distributor) PushWithResolver(ctx context.Context, req *logproto.PushRe
 				continue
 			}
 
-			maybeShardStreams(stream, lbs, pushSize)
+			maybeShardStreams(stream, lbs, pushSize, policy)
 		}
 		return nil
 	}()
@@ -723,23 +731,54 @@ func (d *Distributor) PushWithResolver(ctx context.Context, req *logproto.PushRe
 		return &logproto.PushResponse{}, validationErr
 	}
 
-	if !d.ingestionRateLimiter.AllowN(now, tenantID, validationContext.validationMetrics.aggregatedPushStats.lineSize) {
+	// The ingestion rate limit is enforced by the ingest-limits service when
+	// rate limits are delegated to it.
+	rateLimitedByIngestLimits := d.cfg.IngestLimitsEnabled && d.cfg.IngestLimitsRateLimits && !d.cfg.IngestLimitsDryRunEnabled
+	enforceIngestionRateLimit := func() error {
+		if d.ingestionRateLimiter.AllowN(now, tenantID, validationContext.validationMetrics.aggregatedPushStats.lineSize) {
+			return nil
+		}
 		d.trackDiscardedData(ctx, req, validationContext, tenantID, validationContext.validationMetrics, validation.RateLimited, streamResolver, format)
 
-		err = fmt.Errorf(validation.RateLimitedErrorMsg, tenantID, int(d.ingestionRateLimiter.Limit(now, tenantID)), validationContext.validationMetrics.aggregatedPushStats.lineCount, validationContext.validationMetrics.aggregatedPushStats.lineSize)
+		err := fmt.Errorf(validation.RateLimitedErrorMsg, tenantID, int(d.ingestionRateLimiter.Limit(now, tenantID)), validationContext.validationMetrics.aggregatedPushStats.lineCount, validationContext.validationMetrics.aggregatedPushStats.lineSize)
 		d.writeFailuresManager.Log(tenantID, err)
 		// Return a 429 to indicate to the client they are being rate limited
-		return nil, httpgrpc.Errorf(http.StatusTooManyRequests, "%s", err.Error())
+		return httpgrpc.Errorf(http.StatusTooManyRequests, "%s", err.Error())
+	}
+	if !rateLimitedByIngestLimits {
+		if err := enforceIngestionRateLimit(); err != nil {
+			return nil, err
+		}
 	}
 
 	// These limits are checked after the ingestion rate limit as this
 	// is how it works in ingesters.
 	if d.cfg.IngestLimitsEnabled {
-		accepted, err := d.ingestLimits.EnforceLimits(ctx, tenantID, streams)
-		if err == nil && !d.cfg.IngestLimitsDryRunEnabled {
+		accepted, failed, rejected, err := d.ingestLimits.EnforceLimits(ctx, tenantID, streams)
+		if rateLimitedByIngestLimits && len(failed) > 0 {
+			// The streams of the partitions that could not be checked fall
+			// back to the ingestion rate limit of the distributor, like all
+			// the streams when the ingest-limits service cannot be reached.
+			accepted, rejected = d.enforceIngestionRateLimitOnFailed(now, tenantID, accepted, failed, rejected)
+		}
+		switch {
+		case err != nil:
+			// The stream limits fail open when the ingest-limits service
+			// cannot be reached, as they cannot be checked without it. The
+			// ingestion rate limit falls back to the distributor instead, so
+			// that pushes are never accepted without a rate limit.
+			if rateLimitedByIngestLimits {
+				if err := enforceIngestionRateLimit(); err != nil {
+					return nil, err
+				}
+			}
+		case !d.cfg.IngestLimitsDryRunEnabled && len(rejected) > 0:
+			d.trackRejectedStreams(ctx, tenantID, rejected, streamResolver, format)
 			if len(accepted) == 0 {
 				// All streams were rejected, the request should be failed.
-				return nil, httpgrpc.Error(http.StatusTooManyRequests, "request exceeded limits")
+				err := fmt.Errorf("request exceeded limits: %s", rejectedReasons(rejected))
+				d.writeFailuresManager.Log(tenantID, err)
+				return nil, httpgrpc.Error(http.StatusTooManyRequests, err.Error())
 			}
 			streams = accepted
 		}
@@ -908,6 +947,26 @@ func (d *Distributor) trackDiscardedData(
 	}
 }
 
+// trackRejectedStreams records the streams rejected by the ingest-limits
+// service as discarded, with the discard reason matching their rejection
+// reason.
+func (d *Distributor) trackRejectedStreams(ctx context.Context, tenantID string, rejected []rejectedStream, streamResolver push.StreamResolver, format string) {
+	for _, s := range rejected {
+		reason := discardReasonForLimit(s.Reason)
+		lbs, err := syntax.ParseLabels(s.Stream.Labels)
+		if err != nil {
+			continue
+		}
+		retentionHours := streamResolver.RetentionHoursFor(lbs)
+		discardedBytes := util.EntriesTotalSize(s.Stream.Entries)
+		validation.DiscardedSamples.WithLabelValues(reason, tenantID, retentionHours, s.Policy, format).Add(float64(len(s.Stream.Entries)))
+		validation.DiscardedBytes.WithLabelValues(reason, tenantID, retentionHours, s.Policy, format).Add(float64(discardedBytes))
+		if d.usageTracker != nil {
+			d.usageTracker.DiscardedBytesAdd(ctx, tenantID, reason, lbs, float64(discardedBytes), format)
+		}
+	}
+}
+
 type streamWithTimeShard struct {
 	logproto.Stream
 	linesTotalLen int
@@ -2409,6 +2409,8 @@ func TestDistributor_PushIngestLimits(t *testing.T) {
 		name                      string
 		ingestLimitsEnabled       bool
 		ingestLimitsDryRunEnabled bool
+		ingestLimitsRateLimits    bool
+		ingestionRateExceeded     bool
 		tenant                    string
 		streams                   logproto.PushRequest
 		expectedLimitsCalls       uint64
@@ -2416,6 +2418,7 @@ func TestDistributor_PushIngestLimits(t *testing.T) {
 		limitsResponse            *limitsproto.ExceedsLimitsResponse
 		limitsResponseErr         error
 		expectedErr               string
+		expectedDiscardedReason   string
 	}{{
 		name:                "limits are not checked when disabled",
 		ingestLimitsEnabled: false,
@@ -2477,7 +2480,8 @@ func TestDistributor_PushIngestLimits(t *testing.T) {
 				Reason:     uint32(limits.ReasonMaxStreams),
 			}},
 		},
-		expectedErr: "rpc error: code = Code(429) desc = request exceeded limits",
+		expectedErr:             "rpc error: code = Code(429) desc = request exceeded limits: max streams",
+		expectedDiscardedReason: validation.StreamLimit,
 	}, {
 		name:                "one of two streams exceed max stream limit, request is accepted",
 		ingestLimitsEnabled: true,
@@ -2542,6 +2546,139 @@ func TestDistributor_PushIngestLimits(t *testing.T) {
 				Reason:     uint32(limits.ReasonMaxStreams),
 			}},
 		},
+	}, {
+		name:                  "ingestion rate is enforced by the distributor",
+		ingestLimitsEnabled:   true,
+		ingestionRateExceeded: true,
+		tenant:                "test",
+		streams: logproto.PushRequest{
+			Streams: []logproto.Stream{{
+				Labels: "{foo=\"bar\"}",
+				Entries: []logproto.Entry{{
+					Timestamp: time.Now(),
+					Line:      "baz",
+				}},
+			}},
+		},
+		expectedLimitsCalls:     0,
+		expectedErr:             "rpc error: code = Code(429) desc = ingestion rate limit exceeded for user test (limit: 0 bytes/sec) while attempting to ingest '1' lines totaling '3' bytes, reduce log volume or contact your Loki administrator to see if the limit can be increased",
+		expectedDiscardedReason: validation.RateLimited,
+	}, {
+		name:                   "ingestion rate is enforced by the ingest-limits service",
+		ingestLimitsEnabled:    true,
+		ingestLimitsRateLimits: true,
+		ingestionRateExceeded:  true,
+		tenant:                 "test",
+		streams: logproto.PushRequest{
+			Streams: []logproto.Stream{{
+				Labels: "{foo=\"bar\"}",
+				Entries: []logproto.Entry{{
+					Timestamp: time.Now(),
+					Line:      "baz",
+				}},
+			}},
+		},
+		expectedLimitsCalls: 1,
+		expectedLimitsRequest: &limitsproto.ExceedsLimitsRequest{
+			Tenant: "test",
+			Streams: []*limitsproto.StreamMetadata{{
+				StreamHash: 0x90eb45def17f924,
+				TotalSize:  0x3,
+			}},
+		},
+		limitsResponse: &limitsproto.ExceedsLimitsResponse{
+			Results: []*limitsproto.ExceedsLimitsResult{{
+				StreamHash: 0x90eb45def17f924,
+				Reason:     uint32(limits.ReasonRateLimited),
+			}},
+		},
+		expectedErr:             "rpc error: code = Code(429) desc = request exceeded limits: rate limited",
+		expectedDiscardedReason: validation.RateLimited,
+	}, {
+		name:                   "ingestion rate is enforced by the distributor when limits cannot be checked",
+		ingestLimitsEnabled:    true,
+		ingestLimitsRateLimits: true,
+		ingestionRateExceeded:  true,
+		tenant:                 "test",
+		streams: logproto.PushRequest{
+			Streams: []logproto.Stream{{
+				Labels: "{foo=\"bar\"}",
+				Entries: []logproto.Entry{{
+					Timestamp: time.Now(),
+					Line:      "baz",
+				}},
+			}},
+		},
+		expectedLimitsCalls: 1,
+		expectedLimitsRequest: &limitsproto.ExceedsLimitsRequest{
+			Tenant: "test",
+			Streams: []*limitsproto.StreamMetadata{{
+				StreamHash: 0x90eb45def17f924,
+				TotalSize:  0x3,
+			}},
+		},
+		limitsResponseErr:       errors.New("failed to check limits"),
+		expectedErr:             "rpc error: code = Code(429) desc = ingestion rate limit exceeded for user test (limit: 0 bytes/sec) while attempting to ingest '1' lines totaling '3' bytes, reduce log volume or contact your Loki administrator to see if the limit can be increased",
+		expectedDiscardedReason: validation.RateLimited,
+	}, {
+		name:                   "ingestion rate is enforced by the distributor for streams that could not be checked",
+		ingestLimitsEnabled:    true,
+		ingestLimitsRateLimits: true,
+		ingestionRateExceeded:  true,
+		tenant:                 "test",
+		streams: logproto.PushRequest{
+			Streams: []logproto.Stream{{
+				Labels: "{foo=\"bar\"}",
+				Entries: []logproto.Entry{{
+					Timestamp: time.Now(),
+					Line:      "baz",
+				}},
+			}},
+		},
+		expectedLimitsCalls: 1,
+		expectedLimitsRequest: &limitsproto.ExceedsLimitsRequest{
+			Tenant: "test",
+			Streams: []*limitsproto.StreamMetadata{{
+				StreamHash: 0x90eb45def17f924,
+				TotalSize:  0x3,
+			}},
+		},
+		limitsResponse: &limitsproto.ExceedsLimitsResponse{
+			Results: []*limitsproto.ExceedsLimitsResult{{
+				StreamHash: 0x90eb45def17f924,
+				Reason:     uint32(limits.ReasonFailed),
+			}},
+		},
+		expectedErr:             "rpc error: code = Code(429) desc = request exceeded limits: rate limited",
+		expectedDiscardedReason: validation.RateLimited,
+	}, {
+		name:                   "streams that could not be checked are accepted within the ingestion rate of the distributor",
+		ingestLimitsEnabled:    true,
+		ingestLimitsRateLimits: true,
+		tenant:                 "test",
+		streams: logproto.PushRequest{
+			Streams: []logproto.Stream{{
+				Labels: "{foo=\"bar\"}",
+				Entries: []logproto.Entry{{
+					Timestamp: time.Now(),
+					Line:      "baz",
+				}},
+			}},
+		},
+		expectedLimitsCalls: 1,
+		expectedLimitsRequest: &limitsproto.ExceedsLimitsRequest{
+			Tenant: "test",
+			Streams: []*limitsproto.StreamMetadata{{
+				StreamHash: 0x90eb45def17f924,
+				TotalSize:  0x3,
+			}},
+		},
+		limitsResponse: &limitsproto.ExceedsLimitsResponse{
+			Results: []*limitsproto.ExceedsLimitsResult{{
+				StreamHash: 0x90eb45def17f924,
+				Reason:     uint32(limits.ReasonFailed),
+			}},
+		},
 	}, {
 		name:                "error checking limits",
 		ingestLimitsEnabled: true,
@@ -2570,10 +2707,15 @@ func TestDistributor_PushIngestLimits(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			limits := &validation.Limits{}
 			flagext.DefaultValues(limits)
+			if test.ingestionRateExceeded {
+				limits.IngestionRateMB = 0
+				limits.IngestionBurstSizeMB = 0
+			}
 			distributors, _ := prepare(t, 1, 3, limits, nil)
 			d := distributors[0]
 			d.cfg.IngestLimitsEnabled = test.ingestLimitsEnabled
 			d.cfg.IngestLimitsDryRunEnabled = test.ingestLimitsDryRunEnabled
+			d.cfg.IngestLimitsRateLimits = test.ingestLimitsRateLimits
 
 			mockClient := mockIngestLimitsFrontendClient{
 				t:               t,
@@ -2584,6 +2726,7 @@ func TestDistributor_PushIngestLimits(t *testing.T) {
 			l := newIngestLimits(&mockClient, prometheus.NewRegistry())
 			d.ingestLimits = l
 
+			validation.DiscardedSamples.Reset()
 			ctx = user.InjectOrgID(context.Background(), test.tenant)
 			resp, err := d.Push(ctx, &test.streams)
 			if test.expectedErr != "" {
@@ -2594,6 +2737,10 @@ func TestDistributor_PushIngestLimits(t *testing.T) {
 				require.Equal(t, success, resp)
 			}
 			require.Equal(t, test.expectedLimitsCalls, mockClient.calls.Load())
+			if test.expectedDiscardedReason != "" {
+				retentionHours := d.tenantsRetention.RetentionHoursFor(test.tenant, labels.FromStrings("foo", "bar"))
+				require.Equal(t, float64(1), testutil.ToFloat64(validation.DiscardedSamples.WithLabelValues(test.expectedDiscardedReason, test.tenant, retentionHours, "", constants.Loki)))
+			}
 		})
 	}
 }
@@ -5,6 +5,9 @@ import (
 	"encoding/binary"
 	"fmt"
 	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/grafana/dskit/ring"
 	ring_client "github.com/grafana/dskit/ring/client"
@@ -14,6 +17,8 @@ import (
 	"github.com/grafana/loki/v3/pkg/limits"
 	limits_frontend_client "github.com/grafana/loki/v3/pkg/limits/frontend/client"
 	"github.com/grafana/loki/v3/pkg/limits/proto"
+	"github.com/grafana/loki/v3/pkg/util"
+	"github.com/grafana/loki/v3/pkg/validation"
 )
 
 // ingestLimitsFrontendClient is used for tests.
@@ -96,24 +101,36 @@ func newIngestLimits(client ingestLimitsFrontendClient, r prometheus.Registerer)
 	}
 }
 
+// rejectedStream is a stream that exceeded the per-tenant limits.
+type rejectedStream struct {
+	KeyedStream
+	Reason limits.Reason
+}
+
 // EnforceLimits checks all streams against the per-tenant limits and returns
 // a slice containing the streams that are accepted (within the per-tenant
-// limits). Any streams that could not have their limits checked are also
-// accepted.
-func (l *ingestLimits) EnforceLimits(ctx context.Context, tenant string, streams []KeyedStream) ([]KeyedStream, error) {
+// limits), a slice containing the accepted streams that could not have their
+// limits checked, and a slice containing the rejected streams with the reason
+// they were rejected. Any streams that could not have their limits checked are
+// also accepted.
+func (l *ingestLimits) EnforceLimits(ctx context.Context, tenant string, streams []KeyedStream) ([]KeyedStream, []KeyedStream, []rejectedStream, error) {
 	results, err := l.ExceedsLimits(ctx, tenant, streams)
 	if err != nil {
-		return streams, err
+		return streams, nil, nil, err
 	}
 	// Fast path. No results means all streams were accepted and there were
 	// no failures, so we can return the input streams.
 	if len(results) == 0 {
-		return streams, nil
+		return streams, nil, nil, nil
 	}
 	// We can do this without allocation if needed, but doing so will modify
 	// the original backing array. See "Filtering without allocation" from
 	// https://go.dev/wiki/SliceTricks.
 	accepted := make([]KeyedStream, 0, len(streams))
+	var (
+		failed   []KeyedStream
+		rejected []rejectedStream
+	)
 	for _, s := range streams {
 		// Check each stream to see if it failed.
 		// TODO(grobinson): We have an O(N*M) loop here. Need to benchmark if
@@ -131,9 +148,61 @@ func (l *ingestLimits) EnforceLimits(ctx context.Context, tenant string, streams
 		}
 		if !found || reason == uint32(limits.ReasonFailed) {
 			accepted = append(accepted, s)
+			if found {
+				failed = append(failed, s)
+			}
+			continue
 		}
+		rejected = append(rejected, rejectedStream{KeyedStream: s, Reason: limits.Reason(reason)})
+	}
+	return accepted, failed, rejected, nil
+}
+
+// enforceIngestionRateLimitOnFailed enforces the ingestion rate limit of the
+// distributor on the accepted streams that the ingest-limits service failed to
+// check, rejecting them as rate limited when the limit is exceeded.
+func (d *Distributor) enforceIngestionRateLimitOnFailed(now time.Time, tenantID string, accepted, failed []KeyedStream, rejected []rejectedStream) ([]KeyedStream, []rejectedStream) {
+	var size int
+	for _, s := range failed {
+		size += util.EntriesTotalSize(s.Stream.Entries)
+	}
+	if d.ingestionRateLimiter.AllowN(now, tenantID, size) {
+		return accepted, rejected
+	}
+	hashes := make(map[uint64]struct{}, len(failed))
+	for _, s := range failed {
+		hashes[s.HashKeyNoShard] = struct{}{}
+		rejected = append(rejected, rejectedStream{KeyedStream: s, Reason: limits.ReasonRateLimited})
+	}
+	accepted = slices.DeleteFunc(accepted, func(s KeyedStream) bool {
+		_, ok := hashes[s.HashKeyNoShard]
+		return ok
+	})
+	return accepted, rejected
+}
+
+// discardReasonForLimit returns the discard reason for streams rejected by
+// the ingest-limits service for the reason r.
+func discardReasonForLimit(r limits.Reason) string {
+	switch r {
+	case limits.ReasonRateLimited, limits.ReasonPolicyRateLimited:
+		return validation.RateLimited
+	case limits.ReasonStreamRateLimited:
+		return validation.StreamRateLimit
+	default:
+		return validation.StreamLimit
+	}
+}
+
+// rejectedReasons returns the distinct reasons the streams were rejected
+// for, sorted and separated by commas.
+func rejectedReasons(rejected []rejectedStream) string {
+	reasons := make([]string, 0, len(rejected))
+	for _, s := range rejected {
+		reasons = append(reasons, s.Reason.String())
 	}
-	return accepted, nil
+	slices.Sort(reasons)
+	return strings.Join(slices.Compact(reasons), ", ")
 }
 
 // ExceedsLimits checks all streams against the per-tenant limits. It returns
@@ -170,6 +239,7 @@ func newExceedsLimitsRequest(tenant string, streams []KeyedStream) (*proto.Excee
 		streamMetadata = append(streamMetadata, &proto.StreamMetadata{
 			StreamHash: stream.HashKeyNoShard,
 			TotalSize:  entriesSize + structuredMetadataSize,
+			Policy:     stream.Policy,
 		})
 	}
 	return &proto.ExceedsLimitsRequest{
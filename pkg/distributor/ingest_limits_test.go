@@ -42,14 +42,16 @@ func TestIngestLimits_EnforceLimits(t *testing.T) {
 	clock.Set(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
 
 	tests := []struct {
-		name            string
-		tenant          string
-		streams         []KeyedStream
-		expectedRequest *proto.ExceedsLimitsRequest
-		response        *proto.ExceedsLimitsResponse
-		responseErr     error
-		expectedStreams []KeyedStream
-		expectedErr     string
+		name             string
+		tenant           string
+		streams          []KeyedStream
+		expectedRequest  *proto.ExceedsLimitsRequest
+		response         *proto.ExceedsLimitsResponse
+		responseErr      error
+		expectedStreams  []KeyedStream
+		expectedFailed   []KeyedStream
+		expectedRejected []rejectedStream
+		expectedErr      string
 	}{{
 		// This test also asserts that streams are returned unmodified.
 		name:   "error should be returned if limits cannot be checked",
@@ -115,6 +117,13 @@ func TestIngestLimits_EnforceLimits(t *testing.T) {
 			}},
 		},
 		expectedStreams: []KeyedStream{},
+		expectedRejected: []rejectedStream{{
+			KeyedStream: KeyedStream{
+				HashKey:        1000, // Should not be used.
+				HashKeyNoShard: 1,
+			},
+			Reason: limits.ReasonMaxStreams,
+		}},
 	}, {
 		name:   "one of two streams exceeds limits",
 		tenant: "test",
@@ -136,13 +145,55 @@ func TestIngestLimits_EnforceLimits(t *testing.T) {
 		response: &proto.ExceedsLimitsResponse{
 			Results: []*proto.ExceedsLimitsResult{{
 				StreamHash: 1,
-				Reason:     uint32(limits.ReasonMaxStreams),
+				Reason:     uint32(limits.ReasonRateLimited),
+			}},
+		},
+		expectedStreams: []KeyedStream{{
+			HashKey:        2000, // Should not be used.
+			HashKeyNoShard: 2,
+		}},
+		expectedRejected: []rejectedStream{{
+			KeyedStream: KeyedStream{
+				HashKey:        1000, // Should not be used.
+				HashKeyNoShard: 1,
+			},
+			Reason: limits.ReasonRateLimited,
+		}},
+	}, {
+		name:   "one of two streams could not be checked",
+		tenant: "test",
+		streams: []KeyedStream{{
+			HashKey:        1000, // Should not be used.
+			HashKeyNoShard: 1,
+		}, {
+			HashKey:        2000, // Should not be used.
+			HashKeyNoShard: 2,
+		}},
+		expectedRequest: &proto.ExceedsLimitsRequest{
+			Tenant: "test",
+			Streams: []*proto.StreamMetadata{{
+				StreamHash: 1,
+			}, {
+				StreamHash: 2,
+			}},
+		},
+		response: &proto.ExceedsLimitsResponse{
+			Results: []*proto.ExceedsLimitsResult{{
+				StreamHash: 1,
+				Reason:     uint32(limits.ReasonFailed),
 			}},
 		},
 		expectedStreams: []KeyedStream{{
+			HashKey:        1000, // Should not be used.
+			HashKeyNoShard: 1,
+		}, {
 			HashKey:        2000, // Should not be used.
 			HashKeyNoShard: 2,
 		}},
+		expectedFailed: []KeyedStream{{
+			HashKey:        1000, // Should not be used.
+			HashKeyNoShard: 1,
+		}},
 	}, {
 		name:   "does not exceed limits",
 		tenant: "test",
@@ -184,14 +235,18 @@ func TestIngestLimits_EnforceLimits(t *testing.T) {
 			l := newIngestLimits(&mockClient, prometheus.NewRegistry())
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
-			accepted, err := l.EnforceLimits(ctx, test.tenant, test.streams)
+			accepted, failed, rejected, err := l.EnforceLimits(ctx, test.tenant, test.streams)
 			if test.expectedErr != "" {
 				require.EqualError(t, err, test.expectedErr)
 				// The streams should be returned unmodified.
 				require.Equal(t, test.streams, accepted)
+				require.Empty(t, failed)
+				require.Empty(t, rejected)
 			} else {
 				require.Nil(t, err)
 				require.Equal(t, test.expectedStreams, accepted)
+				require.Equal(t, test.expectedFailed, failed)
+				require.Equal(t, test.expectedRejected, rejected)
 			}
 		})
 	}
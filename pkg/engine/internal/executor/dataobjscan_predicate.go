@@ -98,6 +98,7 @@ var comparisonBinaryOps = map[types.BinaryOp]struct{}{
 	types.BinaryOpNotMatchRe:      {},
 	types.BinaryOpMatchPattern:    {},
 	types.BinaryOpNotMatchPattern: {},
+	types.BinaryOpMatchField:      {},
 }
 
 func buildLogsBinaryPredicate(expr physical.BinaryExpression, columns []*logs.Column) (logs.Predicate, error) {
@@ -217,6 +218,12 @@ func buildLogsComparison(expr *physical.BinaryExpr, columns []*logs.Column) (log
 			Right: logs.EqualPredicate{Column: col, Value: s},
 		}, nil
 
+	case types.BinaryOpMatchField:
+		if col == nil {
+			return buildMissingFieldMatch(s)
+		}
+		return buildLogsMatch(col, expr.Op, s)
+
 	case types.BinaryOpMatchSubstr, types.BinaryOpMatchRe, types.BinaryOpMatchPattern:
 		if col == nil {
 			return logs.FalsePredicate{}, nil // Match operations against a non-existent column will always fail.
@@ -350,6 +357,21 @@ func buildLogsMatch(col *logs.Column, op types.BinaryOp, value scalar.Scalar) (l
 				return !re.Match(getBytes(value))
 			},
 		}, nil
+
+	case types.BinaryOpMatchField:
+		filter, err := compileFieldFilter(string(find))
+		if err != nil {
+			return nil, err
+		}
+		return logs.FuncPredicate{
+			Column: col,
+			Keep: func(_ *logs.Column, value scalar.Scalar) bool {
+				if !value.IsValid() {
+					return filter.MatchesValue(nil, false)
+				}
+				return filter.Matches(getBytes(value))
+			},
+		}, nil
 	}
 
 	// NOTE(rfratto): [types.BinaryOpMatchPattern] and [types.BinaryOpNotMatchPattern]
@@ -357,6 +379,20 @@ func buildLogsMatch(col *logs.Column, op types.BinaryOp, value scalar.Scalar) (l
 	return nil, fmt.Errorf("unrecognized match operation %s", op)
 }
 
+// buildMissingFieldMatch builds the predicate of a [types.BinaryOpMatchField]
+// operation against a non-existent column, which is decided by the filter
+// alone: string filters match missing fields with an empty value.
+func buildMissingFieldMatch(value scalar.Scalar) (logs.Predicate, error) {
+	filter, err := compileFieldFilter(string(getBytes(value)))
+	if err != nil {
+		return nil, err
+	}
+	if filter.MatchesValue(nil, false) {
+		return logs.TruePredicate{}, nil
+	}
+	return logs.FalsePredicate{}, nil
+}
+
 func getBytes(value scalar.Scalar) []byte {
 	if !value.IsValid() {
 		return nil
@@ -298,6 +298,24 @@ func Test_buildLogsPredicate(t *testing.T) {
 			},
 			expect: logs.FalsePredicate{}, // NULL != NULL: always fails
 		},
+		{
+			name: "binary MATCH_FIELD (invalid column)",
+			expr: &physical.BinaryExpr{
+				Op:    types.BinaryOpMatchField,
+				Left:  columnRef(types.ColumnTypeMetadata, "nonexistent"),
+				Right: physical.NewLiteral(`metadata.nonexistent="abc"`),
+			},
+			expect: logs.FalsePredicate{}, // missing field compared with an empty value
+		},
+		{
+			name: "binary NOT MATCH_FIELD (invalid column)",
+			expr: &physical.BinaryExpr{
+				Op:    types.BinaryOpMatchField,
+				Left:  columnRef(types.ColumnTypeMetadata, "nonexistent"),
+				Right: physical.NewLiteral(`metadata.nonexistent!="abc"`),
+			},
+			expect: logs.TruePredicate{}, // missing field compared with an empty value
+		},
 		{
 			name: "binary GT (invalid column)",
 			expr: &physical.BinaryExpr{
@@ -440,6 +458,56 @@ func Test_buildLogsPredicate_FuncPredicates(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "binary MATCH_FIELD",
+			expr: &physical.BinaryExpr{
+				Op:    types.BinaryOpMatchField,
+				Left:  columnRef(types.ColumnTypeMetadata, "metadata"),
+				Right: physical.NewLiteral("json.response.status>=500"),
+			},
+			expectedColumn: metadataColumn,
+			keepTests: []keepTest{
+				{
+					input:    scalar.NewBinaryScalar(memory.NewBufferBytes([]byte(`{"response": {"status": 503}}`)), arrow.BinaryTypes.Binary),
+					expected: true,
+				},
+				{
+					input:    scalar.NewStringScalar(`{"response": {"status": 200}}`),
+					expected: false,
+				},
+				{
+					input:    scalar.NewStringScalar(`status=503`),
+					expected: false,
+				},
+				{
+					input:    scalar.MakeNullScalar(arrow.BinaryTypes.Binary),
+					expected: false,
+				},
+			},
+		},
+		{
+			name: "binary MATCH_FIELD structured metadata",
+			expr: &physical.BinaryExpr{
+				Op:    types.BinaryOpMatchField,
+				Left:  columnRef(types.ColumnTypeMetadata, "metadata"),
+				Right: physical.NewLiteral(`metadata.metadata!="abc"`),
+			},
+			expectedColumn: metadataColumn,
+			keepTests: []keepTest{
+				{
+					input:    scalar.NewStringScalar("abc"),
+					expected: false,
+				},
+				{
+					input:    scalar.NewStringScalar("def"),
+					expected: true,
+				},
+				{
+					input:    scalar.MakeNullScalar(arrow.BinaryTypes.Binary),
+					expected: true,
+				},
+			},
+		},
 		{
 			name: "binary NOT_MATCH_STR",
 			expr: &physical.BinaryExpr{
@@ -10,6 +10,8 @@ import (
 
 	"github.com/grafana/loki/v3/pkg/engine/internal/errors"
 	"github.com/grafana/loki/v3/pkg/engine/internal/types"
+	"github.com/grafana/loki/v3/pkg/logql/log"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
 )
 
 var (
@@ -78,6 +80,8 @@ func init() {
 		}
 		return !reg.Match([]byte(a)), nil
 	}})
+	// Functions for [types.BinaryOpMatchField]
+	binaryFunctions.register(types.BinaryOpMatchField, arrow.BinaryTypes.String, &fieldMatchFunction{})
 
 	// Cast functions
 	unaryFunctions.register(types.UnaryOpCastFloat, arrow.BinaryTypes.String, castFn(types.UnaryOpCastFloat))
@@ -228,6 +232,70 @@ func (f *genericFunction[E, T]) Evaluate(lhs ColumnVector, rhs ColumnVector) (Co
 	return &Array{array: builder.NewArray()}, nil
 }
 
+// fieldMatchFunction implements the [BinaryFunction] interface for
+// [types.BinaryOpMatchField]. The right-hand side holds the field filter, which
+// is only compiled again when it changes between rows.
+type fieldMatchFunction struct{}
+
+// Evaluate implements BinaryFunction.
+func (f *fieldMatchFunction) Evaluate(lhs ColumnVector, rhs ColumnVector) (ColumnVector, error) {
+	if lhs.Len() != rhs.Len() {
+		return nil, arrow.ErrIndex
+	}
+
+	lhsArr, ok := lhs.ToArray().(*array.String)
+	if !ok {
+		return nil, arrow.ErrType
+	}
+	defer lhsArr.Release()
+
+	rhsArr, ok := rhs.ToArray().(*array.String)
+	if !ok {
+		return nil, arrow.ErrType
+	}
+	defer rhsArr.Release()
+
+	mem := memory.NewGoAllocator()
+	builder := array.NewBooleanBuilder(mem)
+	defer builder.Release()
+
+	var (
+		filterStr string
+		filter    *log.FieldFilter
+	)
+	for i := range lhsArr.Len() {
+		if rhsArr.IsNull(i) {
+			builder.Append(false)
+			continue
+		}
+		if filter == nil || rhsArr.Value(i) != filterStr {
+			var err error
+			filterStr = rhsArr.Value(i)
+			if filter, err = compileFieldFilter(filterStr); err != nil {
+				return nil, err
+			}
+		}
+		if lhsArr.IsNull(i) {
+			// A null value is a missing field, e.g. a missing structured metadata label.
+			builder.Append(filter.MatchesValue(nil, false))
+			continue
+		}
+		builder.Append(filter.Matches([]byte(lhsArr.Value(i))))
+	}
+
+	return &Array{array: builder.NewArray()}, nil
+}
+
+// compileFieldFilter compiles the field filter of a [types.BinaryOpMatchField]
+// operation, such as `json.status>=500`.
+func compileFieldFilter(s string) (*log.FieldFilter, error) {
+	expr, err := syntax.ParseFieldFilter(s)
+	if err != nil {
+		return nil, err
+	}
+	return expr.FieldFilter()
+}
+
 // Compiler optimized version of converting boolean b into an integer of value 0 or 1
 // https://github.com/golang/go/issues/6011#issuecomment-323144578
 func boolToInt(b bool) int {
@@ -34,6 +34,25 @@ func createBoolArray(mem memory.Allocator, values []bool, nulls []bool) *Array {
 }
 
 // Helper function to create a string array
+func TestFieldMatchFunction_NullValues(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// Null values are missing fields: string filters compare them with an
+	// empty value, while conversion filters drop them.
+	lhsArray := createStringArray(mem, []string{"", "", "abc"}, []bool{true, true, false})
+	rhsArray := createStringArray(mem, []string{`metadata.trace_id!="abc"`, `metadata.took>1s`, `metadata.trace_id="abc"`}, nil)
+	defer lhsArray.array.Release()
+	defer rhsArray.array.Release()
+
+	fn, err := binaryFunctions.GetForSignature(types.BinaryOpMatchField, arrow.BinaryTypes.String)
+	require.NoError(t, err)
+
+	result, err := fn.Evaluate(lhsArray, rhsArray)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, extractBoolValues(result))
+}
+
 func createStringArray(mem memory.Allocator, values []string, nulls []bool) *Array {
 	builder := array.NewStringBuilder(mem)
 	defer builder.Release()
@@ -620,6 +639,20 @@ func TestStringMatchingFunctions(t *testing.T) {
 			rhs:      []string{"^hello\\d+$", "^\\d+", "^[a-z]+$", ".+"},
 			expected: []bool{false, true, false, true},
 		},
+		{
+			name:     "field match",
+			op:       types.BinaryOpMatchField,
+			lhs:      []string{`{"status": 503}`, `{"status": 200}`, `level=error`, `level=info`, ""},
+			rhs:      []string{"json.status>=500", "json.status>=500", `logfmt.level="error"`, `logfmt.level="error"`, "json.status>=500"},
+			expected: []bool{true, false, true, false, false},
+		},
+		{
+			name:     "structured metadata field match",
+			op:       types.BinaryOpMatchField,
+			lhs:      []string{"abc", "def", ""},
+			rhs:      []string{`metadata.trace_id="abc"`, `metadata.trace_id="abc"`, `metadata.trace_id!="abc"`},
+			expected: []bool{true, false, true},
+		},
 		{
 			name:     "case sensitive substring matching",
 			op:       types.BinaryOpMatchSubstr,
@@ -81,6 +81,11 @@ func buildPlanForLogQuery(
 			// We do not want to traverse the AST further down, because line filter expressions can be nested,
 			// which would lead to multiple predicates of the same expression.
 			return false // do not traverse children
+		case *syntax.FieldFilterExpr:
+			// Field filters only read the line or a structured metadata
+			// column, so they can be applied before any parser stage.
+			predicates = append(predicates, convertFieldFilterExpr(e))
+			return true
 		case *syntax.LogfmtParserExpr:
 			// TODO: support --strict and --keep-empty
 			if e.Strict {
@@ -99,7 +104,7 @@ func buildPlanForLogQuery(
 			case syntax.OpParserTypeJSON:
 				hasJSONParser = true
 				return true
-			case syntax.OpParserTypeRegexp, syntax.OpParserTypeUnpack, syntax.OpParserTypePattern:
+			case syntax.OpParserTypeRegexp, syntax.OpParserTypeUnpack, syntax.OpParserTypePattern, syntax.OpParserTypeXML:
 				// keeping these as a distinct cases so we remember to implement them later
 				err = errUnimplemented
 				return false
@@ -118,7 +123,7 @@ func buildPlanForLogQuery(
 				}
 			}
 			return true
-		case *syntax.LogfmtExpressionParserExpr, *syntax.JSONExpressionParserExpr:
+		case *syntax.LogfmtExpressionParserExpr, *syntax.JSONExpressionParserExpr, *syntax.XMLExpressionParserExpr, *syntax.CSVParserExpr:
 			err = errUnimplemented
 			return false // do not traverse children
 		case *syntax.LineFmtExpr:
@@ -130,6 +135,12 @@ func buildPlanForLogQuery(
 		case *syntax.KeepLabelsExpr:
 			err = unimplementedFeature("keep")
 			return false // do not traverse children
+		case *syntax.LookupExpr:
+			err = unimplementedFeature("lookup")
+			return false // do not traverse children
+		case *syntax.DedupExpr:
+			err = unimplementedFeature("dedup")
+			return false // do not traverse children
 		case *syntax.DropLabelsExpr:
 			if e.HasNamedMatchers() {
 				// Example: `| drop __error__=~"Unknown Error: .*"`
@@ -235,8 +246,8 @@ func buildPlanForSampleQuery(e syntax.SampleExpr, params logql.Params) (*Builder
 	e.Walk(func(e syntax.Expr) bool {
 		switch e := e.(type) {
 		case *syntax.RangeAggregationExpr:
-			// offsets are not yet supported.
-			if e.Left.Offset != 0 {
+			// offsets and @ modifiers are not yet supported.
+			if e.Left.Offset != 0 || e.Left.At != nil {
 				err = errUnimplemented
 				return false
 			}
@@ -402,6 +413,18 @@ func convertLineFilter(filter syntax.LineFilter) Value {
 	}
 }
 
+func convertFieldFilterExpr(expr *syntax.FieldFilterExpr) Value {
+	left := lineColumnRef()
+	if expr.Parser == syntax.OpFieldFilterMetadata {
+		left = NewColumnRef(expr.Path[0], types.ColumnTypeMetadata)
+	}
+	return &BinOp{
+		Left:  left,
+		Right: NewLiteral(expr.FilterString()),
+		Op:    types.BinaryOpMatchField,
+	}
+}
+
 func convertLineMatchType(op log.LineMatchType) types.BinaryOp {
 	switch op {
 	case log.LineMatchEqual:
@@ -122,6 +122,37 @@ RETURN %21
 	t.Logf("\n%s\n", sb.String())
 }
 
+func TestConvertAST_FieldFilter(t *testing.T) {
+	q := &query{
+		statement: `{cluster="prod"} | json.response.status >= 500 | logfmt.level = "error" | metadata.trace_id = "abc"`,
+		start:     3600,
+		end:       7200,
+		direction: logproto.BACKWARD,
+		limit:     1000,
+	}
+	logicalPlan, err := BuildPlan(q)
+	require.NoError(t, err)
+
+	expected := `%1 = EQ label.cluster "prod"
+%2 = MATCH_FIELD builtin.message "json.response.status>=500"
+%3 = MATCH_FIELD builtin.message "logfmt.level="error""
+%4 = MATCH_FIELD metadata.trace_id "metadata.trace_id="abc""
+%5 = MAKETABLE [selector=%1, predicates=[%2, %3, %4], shard=0_of_1]
+%6 = GTE builtin.timestamp 1970-01-01T01:00:00Z
+%7 = SELECT %5 [predicate=%6]
+%8 = LT builtin.timestamp 1970-01-01T02:00:00Z
+%9 = SELECT %7 [predicate=%8]
+%10 = SELECT %9 [predicate=%2]
+%11 = SELECT %10 [predicate=%3]
+%12 = SELECT %11 [predicate=%4]
+%13 = SORT %12 [column=builtin.timestamp, asc=false, nulls_first=false]
+%14 = LIMIT %13 [skip=0, fetch=1000]
+%15 = LOGQL_COMPAT %14
+RETURN %15
+`
+	require.Equal(t, expected, logicalPlan.String())
+}
+
 func TestConvertAST_MetricQuery_Success(t *testing.T) {
 	q := &query{
 		statement: `sum by (level) (count_over_time({cluster="prod", namespace=~"loki-.*"} |= "metric.go"[5m]))`,
@@ -246,6 +277,10 @@ func TestCanExecuteQuery(t *testing.T) {
 			// offset is not supported
 			statement: `sum by (level) (count_over_time({env="prod"}[1m] offset 5m))`,
 		},
+		{
+			// @ modifier is not supported
+			statement: `sum by (level) (count_over_time({env="prod"}[1m] @ 1609746000))`,
+		},
 		{
 			statement: `sum by (level) (sum_over_time({env="prod"} | unwrap size [1m]))`,
 			expected:  true,
@@ -69,6 +69,7 @@ const (
 	BinaryOpNotMatchRe      // Regular expression non-matching operation (!~). Used for regex match filter and label matcher.
 	BinaryOpMatchPattern    // Pattern matching operation (|>). Used for pattern match filter.
 	BinaryOpNotMatchPattern // Pattern non-matching operation (!>). Use for pattern match filter.
+	BinaryOpMatchField      // Field matching operation (| json.status >= 500). Used for field filter.
 )
 
 // String returns a human-readable representation of the binary operation kind.
@@ -116,6 +117,8 @@ func (t BinaryOp) String() string {
 		return "MATCH_PAT"
 	case BinaryOpNotMatchPattern:
 		return "NOT_MATCH_PAT" // convenience for NOT(MATCH_PAT(...))
+	case BinaryOpMatchField:
+		return "MATCH_FIELD"
 	default:
 		panic(fmt.Sprintf("unknown binary operator %d", t))
 	}
@@ -2,7 +2,7 @@ package goldfish
 
 // StorageConfig defines storage backend configuration
 type StorageConfig struct {
-	Type string `yaml:"type"` // "cloudsql", "rds", "mysql", or empty string for no storage
+	Type string `yaml:"type"` // "cloudsql", "rds", "mysql", "postgres", "sqlite", or empty string for no storage
 
 	// Direct MySQL connection
 	MySQLHost     string `yaml:"mysql_host"`
@@ -24,6 +24,17 @@ type StorageConfig struct {
 	RDSUser     string `yaml:"rds_user"`
 	// RDSPassword provided via GOLDFISH_DB_PASSWORD environment variable
 
+	// PostgreSQL connection
+	PostgresHost     string `yaml:"postgres_host"`
+	PostgresPort     int    `yaml:"postgres_port"`
+	PostgresDatabase string `yaml:"postgres_database"`
+	PostgresUser     string `yaml:"postgres_user"`
+	PostgresSSLMode  string `yaml:"postgres_ssl_mode"`
+	// PostgresPassword provided via GOLDFISH_DB_PASSWORD environment variable
+
+	// Embedded SQLite database file
+	SQLitePath string `yaml:"sqlite_path"`
+
 	// Common settings
 	MaxConnections int `yaml:"max_connections"`
 	MaxIdleTime    int `yaml:"max_idle_time_seconds"`
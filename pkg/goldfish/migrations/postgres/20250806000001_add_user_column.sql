@@ -0,0 +1,17 @@
+-- +goose Up
+-- Add user column to track who executed the query
+
+ALTER TABLE sampled_queries 
+ADD COLUMN "user" VARCHAR(255) DEFAULT '';
+
+-- Update existing rows to have empty string instead of NULL
+UPDATE sampled_queries SET "user" = '' WHERE "user" IS NULL;
+
+-- Add index for efficient filtering by user
+CREATE INDEX idx_sampled_queries_user ON sampled_queries("user");
+
+-- +goose Down
+-- Remove user column and index
+
+DROP INDEX idx_sampled_queries_user;
+ALTER TABLE sampled_queries DROP COLUMN "user";
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Add span ID columns to sampled_queries table for direct span navigation
+
+-- SQLite adds a single column per statement
+ALTER TABLE sampled_queries ADD COLUMN cell_a_span_id VARCHAR(255) DEFAULT NULL;
+ALTER TABLE sampled_queries ADD COLUMN cell_b_span_id VARCHAR(255) DEFAULT NULL;
+
+-- Add indexes for querying by span ID (useful for debugging specific spans)
+CREATE INDEX idx_sampled_queries_cell_a_span_id ON sampled_queries(cell_a_span_id);
+CREATE INDEX idx_sampled_queries_cell_b_span_id ON sampled_queries(cell_b_span_id);
+
+-- +goose Down
+-- Remove span ID columns and indexes
+
+DROP INDEX idx_sampled_queries_cell_b_span_id;
+DROP INDEX idx_sampled_queries_cell_a_span_id;
+
+ALTER TABLE sampled_queries DROP COLUMN cell_b_span_id;
+ALTER TABLE sampled_queries DROP COLUMN cell_a_span_id;
\ No newline at end of file
@@ -0,0 +1,57 @@
+package goldfish
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/log"
+	_ "github.com/jackc/pgx/v5/stdlib" // PostgreSQL driver
+)
+
+// PostgresStorage provides PostgreSQL storage implementation
+type PostgresStorage struct {
+	sqlStorage
+	config StorageConfig
+}
+
+// NewPostgresStorage creates a new PostgreSQL storage backend
+func NewPostgresStorage(config StorageConfig, logger log.Logger) (*PostgresStorage, error) {
+	if config.Type != "postgres" {
+		return nil, fmt.Errorf("unsupported storage type: %s", config.Type)
+	}
+
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(config.PostgresUser, os.Getenv("GOLDFISH_DB_PASSWORD")),
+		Host:   net.JoinHostPort(config.PostgresHost, strconv.Itoa(config.PostgresPort)),
+		Path:   "/" + config.PostgresDatabase,
+	}
+	if config.PostgresSSLMode != "" {
+		dsn.RawQuery = url.Values{"sslmode": []string{config.PostgresSSLMode}}.Encode()
+	}
+
+	db, err := sql.Open("pgx", dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Configure connection pool
+	db.SetMaxOpenConns(config.MaxConnections)
+	db.SetMaxIdleConns(config.MaxConnections / 2)
+	db.SetConnMaxIdleTime(time.Duration(config.MaxIdleTime) * time.Second)
+
+	storage, err := newSQLStorage(db, postgresDialect, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresStorage{
+		sqlStorage: storage,
+		config:     config,
+	}, nil
+}
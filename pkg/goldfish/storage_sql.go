@@ -0,0 +1,439 @@
+package goldfish
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pressly/goose/v3"
+)
+
+//go:embed migrations/*/*.sql
+var embedMigrations embed.FS
+
+// sqlDialect describes the differences between the SQL databases used as storage backends.
+type sqlDialect struct {
+	// name is the name of the directory of the migrations of the dialect
+	name string
+	// gooseDialect is the dialect used to run the migrations
+	gooseDialect goose.Dialect
+	// userColumn is the name of the user column, quoted when user is a reserved word
+	userColumn string
+	// trueValue and falseValue are the boolean literals
+	trueValue, falseValue string
+	// numberedParams is true when the query parameters are $1, $2... instead of ?
+	numberedParams bool
+	// upsertComparisonResult is the clause updating an existing comparison outcome
+	upsertComparisonResult string
+}
+
+var (
+	mysqlDialect = sqlDialect{
+		name:         "mysql",
+		gooseDialect: goose.DialectMySQL,
+		userColumn:   "user",
+		trueValue:    "1",
+		falseValue:   "0",
+		upsertComparisonResult: `ON DUPLICATE KEY UPDATE
+			comparison_status = VALUES(comparison_status),
+			difference_details = VALUES(difference_details),
+			performance_metrics = VALUES(performance_metrics),
+			compared_at = VALUES(compared_at)`,
+	}
+	postgresDialect = sqlDialect{
+		name:                   "postgres",
+		gooseDialect:           goose.DialectPostgres,
+		userColumn:             `"user"`,
+		trueValue:              "TRUE",
+		falseValue:             "FALSE",
+		numberedParams:         true,
+		upsertComparisonResult: upsertOnConflict,
+	}
+	sqliteDialect = sqlDialect{
+		name:                   "sqlite",
+		gooseDialect:           goose.DialectSQLite3,
+		userColumn:             `"user"`,
+		trueValue:              "1",
+		falseValue:             "0",
+		upsertComparisonResult: upsertOnConflict,
+	}
+)
+
+const upsertOnConflict = `ON CONFLICT (correlation_id) DO UPDATE SET
+			comparison_status = excluded.comparison_status,
+			difference_details = excluded.difference_details,
+			performance_metrics = excluded.performance_metrics,
+			compared_at = excluded.compared_at`
+
+// rebind replaces the ? parameters of the query with the parameters of the dialect
+func (d sqlDialect) rebind(query string) string {
+	if !d.numberedParams {
+		return query
+	}
+
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			sb.WriteRune(r)
+			continue
+		}
+		n++
+		sb.WriteString("$" + strconv.Itoa(n))
+	}
+	return sb.String()
+}
+
+// migrations returns the migrations of the dialect
+func (d sqlDialect) migrations() (fs.FS, error) {
+	return fs.Sub(embedMigrations, "migrations/"+d.name)
+}
+
+// sqlStorage implements the Storage interface on top of a SQL database
+type sqlStorage struct {
+	db      *sql.DB
+	dialect sqlDialect
+	logger  log.Logger
+}
+
+// newSQLStorage runs the migrations of the dialect on the database and returns the storage using it
+func newSQLStorage(db *sql.DB, dialect sqlDialect, logger log.Logger) (sqlStorage, error) {
+	// Verify connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return sqlStorage{}, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	// Run migrations
+	if err := runMigrations(db, dialect); err != nil {
+		db.Close()
+		return sqlStorage{}, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return sqlStorage{
+		db:      db,
+		dialect: dialect,
+		logger:  logger,
+	}, nil
+}
+
+// StoreQuerySample stores a sampled query with performance statistics
+func (s *sqlStorage) StoreQuerySample(ctx context.Context, sample *QuerySample) error {
+	query := `
+		INSERT INTO sampled_queries (
+			correlation_id, tenant_id, ` + s.dialect.userColumn + `, is_logs_drilldown, query, query_type,
+			start_time, end_time, step_duration,
+			cell_a_exec_time_ms, cell_b_exec_time_ms,
+			cell_a_queue_time_ms, cell_b_queue_time_ms,
+			cell_a_bytes_processed, cell_b_bytes_processed,
+			cell_a_lines_processed, cell_b_lines_processed,
+			cell_a_bytes_per_second, cell_b_bytes_per_second,
+			cell_a_lines_per_second, cell_b_lines_per_second,
+			cell_a_entries_returned, cell_b_entries_returned,
+			cell_a_splits, cell_b_splits,
+			cell_a_shards, cell_b_shards,
+			cell_a_response_hash, cell_b_response_hash,
+			cell_a_response_size, cell_b_response_size,
+			cell_a_status_code, cell_b_status_code,
+			cell_a_trace_id, cell_b_trace_id,
+			cell_a_span_id, cell_b_span_id,
+			cell_a_used_new_engine, cell_b_used_new_engine,
+			sampled_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	// Convert empty span IDs to NULL for database storage
+	var cellASpanID, cellBSpanID any
+	if sample.CellASpanID != "" {
+		cellASpanID = sample.CellASpanID
+	}
+	if sample.CellBSpanID != "" {
+		cellBSpanID = sample.CellBSpanID
+	}
+
+	_, err := s.db.ExecContext(ctx, s.dialect.rebind(query),
+		sample.CorrelationID,
+		sample.TenantID,
+		sample.User,
+		sample.IsLogsDrilldown,
+		sample.Query,
+		sample.QueryType,
+		sample.StartTime.UTC(),
+		sample.EndTime.UTC(),
+		sample.Step.Milliseconds(),
+		sample.CellAStats.ExecTimeMs,
+		sample.CellBStats.ExecTimeMs,
+		sample.CellAStats.QueueTimeMs,
+		sample.CellBStats.QueueTimeMs,
+		sample.CellAStats.BytesProcessed,
+		sample.CellBStats.BytesProcessed,
+		sample.CellAStats.LinesProcessed,
+		sample.CellBStats.LinesProcessed,
+		sample.CellAStats.BytesPerSecond,
+		sample.CellBStats.BytesPerSecond,
+		sample.CellAStats.LinesPerSecond,
+		sample.CellBStats.LinesPerSecond,
+		sample.CellAStats.TotalEntriesReturned,
+		sample.CellBStats.TotalEntriesReturned,
+		sample.CellAStats.Splits,
+		sample.CellBStats.Splits,
+		sample.CellAStats.Shards,
+		sample.CellBStats.Shards,
+		sample.CellAResponseHash,
+		sample.CellBResponseHash,
+		sample.CellAResponseSize,
+		sample.CellBResponseSize,
+		sample.CellAStatusCode,
+		sample.CellBStatusCode,
+		sample.CellATraceID,
+		sample.CellBTraceID,
+		cellASpanID,
+		cellBSpanID,
+		sample.CellAUsedNewEngine,
+		sample.CellBUsedNewEngine,
+		sample.SampledAt.UTC(),
+	)
+
+	return err
+}
+
+// StoreComparisonResult stores the outcome of comparing two responses
+func (s *sqlStorage) StoreComparisonResult(ctx context.Context, result *ComparisonResult) error {
+	differenceJSON, err := json.Marshal(result.DifferenceDetails)
+	if err != nil {
+		return fmt.Errorf("failed to marshal difference details: %w", err)
+	}
+
+	perfMetricsJSON, err := json.Marshal(result.PerformanceMetrics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal performance metrics: %w", err)
+	}
+
+	query := `
+		INSERT INTO comparison_outcomes (
+			correlation_id, comparison_status, 
+			difference_details, performance_metrics,
+			compared_at
+		) VALUES (?, ?, ?, ?, ?)
+	` + s.dialect.upsertComparisonResult
+
+	_, err = s.db.ExecContext(ctx, s.dialect.rebind(query),
+		result.CorrelationID,
+		result.ComparisonStatus,
+		string(differenceJSON),
+		string(perfMetricsJSON),
+		result.ComparedAt.UTC(),
+	)
+
+	return err
+}
+
+// GetSampledQueries retrieves sampled queries from the database with pagination and outcome filtering
+func (s *sqlStorage) GetSampledQueries(ctx context.Context, page, pageSize int, filter QueryFilter) (*APIResponse, error) {
+	// Validate and sanitize input parameters
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 1000 {
+		pageSize = 20 // Default page size
+	}
+
+	offset := (page - 1) * pageSize
+
+	// Build WHERE clause for tenant/user/engine/time filters
+	whereClause, whereArgs := buildWhereClause(filter, s.dialect)
+
+	query := `
+		SELECT
+			correlation_id, tenant_id, ` + s.dialect.userColumn + `, query, query_type, start_time, end_time, step_duration,
+			cell_a_exec_time_ms, cell_b_exec_time_ms, cell_a_queue_time_ms, cell_b_queue_time_ms,
+			cell_a_bytes_processed, cell_b_bytes_processed, cell_a_lines_processed, cell_b_lines_processed,
+			cell_a_bytes_per_second, cell_b_bytes_per_second, cell_a_lines_per_second, cell_b_lines_per_second,
+			cell_a_entries_returned, cell_b_entries_returned, cell_a_splits, cell_b_splits,
+			cell_a_shards, cell_b_shards, cell_a_response_hash, cell_b_response_hash,
+			cell_a_response_size, cell_b_response_size, cell_a_status_code, cell_b_status_code,
+			cell_a_trace_id, cell_b_trace_id,
+			cell_a_span_id, cell_b_span_id,
+			cell_a_used_new_engine, cell_b_used_new_engine,
+			sampled_at, created_at,
+			(SELECT difference_details FROM comparison_outcomes
+				WHERE comparison_outcomes.correlation_id = sampled_queries.correlation_id) AS difference_details
+		FROM sampled_queries
+		` + whereClause + `
+		ORDER BY sampled_at DESC 
+		LIMIT ? OFFSET ?
+	`
+
+	// Fetch one extra record to determine if there are more pages
+	// We'll fetch pageSize+1 records, then check if we got more than pageSize
+	queryArgs := append(whereArgs, pageSize+1, offset)
+	query = s.dialect.rebind(query)
+
+	// Debug logging
+	level.Debug(s.logger).Log("ui-component", "goldfish", "msg", "executing main query", "query", query, "args", queryArgs)
+
+	rows, err := s.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		level.Error(s.logger).Log("ui-component", "goldfish", "msg", "main query failed", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var queries []QuerySample
+	for rows.Next() {
+		var q QuerySample
+		var stepDurationMs int64
+		var createdAt time.Time
+		// Use sql.NullString for nullable span ID columns
+		var cellASpanID, cellBSpanID sql.NullString
+		var differenceDetails sql.NullString
+
+		err := rows.Scan(
+			&q.CorrelationID, &q.TenantID, &q.User, &q.Query, &q.QueryType, &q.StartTime, &q.EndTime, &stepDurationMs,
+			&q.CellAStats.ExecTimeMs, &q.CellBStats.ExecTimeMs, &q.CellAStats.QueueTimeMs, &q.CellBStats.QueueTimeMs,
+			&q.CellAStats.BytesProcessed, &q.CellBStats.BytesProcessed, &q.CellAStats.LinesProcessed, &q.CellBStats.LinesProcessed,
+			&q.CellAStats.BytesPerSecond, &q.CellBStats.BytesPerSecond, &q.CellAStats.LinesPerSecond, &q.CellBStats.LinesPerSecond,
+			&q.CellAStats.TotalEntriesReturned, &q.CellBStats.TotalEntriesReturned, &q.CellAStats.Splits, &q.CellBStats.Splits,
+			&q.CellAStats.Shards, &q.CellBStats.Shards, &q.CellAResponseHash, &q.CellBResponseHash,
+			&q.CellAResponseSize, &q.CellBResponseSize, &q.CellAStatusCode, &q.CellBStatusCode,
+			&q.CellATraceID, &q.CellBTraceID,
+			&cellASpanID, &cellBSpanID,
+			&q.CellAUsedNewEngine, &q.CellBUsedNewEngine,
+			&q.SampledAt, &createdAt,
+			&differenceDetails,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Convert nullable strings to regular strings (empty string if NULL)
+		if cellASpanID.Valid {
+			q.CellASpanID = cellASpanID.String
+		}
+		if cellBSpanID.Valid {
+			q.CellBSpanID = cellBSpanID.String
+		}
+
+		// Convert step duration from milliseconds to Duration
+		q.Step = time.Duration(stepDurationMs) * time.Millisecond
+
+		// Read the result diff of the comparison outcome, if any
+		if differenceDetails.Valid {
+			var details struct {
+				ResultDiff *ResultDiff `json:"result_diff"`
+			}
+			if err := json.Unmarshal([]byte(differenceDetails.String), &details); err != nil {
+				level.Warn(s.logger).Log("ui-component", "goldfish", "msg", "failed to parse difference details", "correlation_id", q.CorrelationID, "err", err)
+			} else {
+				q.ResultDiff = details.ResultDiff
+			}
+		}
+
+		queries = append(queries, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Check if we have more records than requested
+	hasMore := false
+	if len(queries) > pageSize {
+		hasMore = true
+		// Trim the result to the requested page size
+		queries = queries[:pageSize]
+	}
+
+	return &APIResponse{
+		Queries:  queries,
+		HasMore:  hasMore,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// Close closes the storage connection
+func (s *sqlStorage) Close() error {
+	return s.db.Close()
+}
+
+// buildWhereClause constructs a WHERE clause based on the filter parameters
+func buildWhereClause(filter QueryFilter, dialect sqlDialect) (string, []any) {
+	var conditions []string
+	var args []any
+
+	// Add time range filters
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "sampled_at >= ?")
+		args = append(args, filter.From.UTC())
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "sampled_at <= ?")
+		args = append(args, filter.To.UTC())
+	}
+
+	// Add tenant filter
+	if filter.Tenant != "" {
+		conditions = append(conditions, "tenant_id = ?")
+		args = append(args, filter.Tenant)
+	}
+
+	// Add user filter
+	if filter.User != "" {
+		conditions = append(conditions, dialect.userColumn+" = ?")
+		args = append(args, filter.User)
+	}
+
+	// Add logs drilldown filter
+	if filter.IsLogsDrilldown != nil {
+		conditions = append(conditions, "is_logs_drilldown = ?")
+		args = append(args, *filter.IsLogsDrilldown)
+	}
+
+	// Add new engine filter
+	if filter.UsedNewEngine != nil {
+		if *filter.UsedNewEngine {
+			// Either cell used new engine
+			conditions = append(conditions, "(cell_a_used_new_engine = "+dialect.trueValue+" OR cell_b_used_new_engine = "+dialect.trueValue+")")
+		} else {
+			// Neither cell used new engine
+			conditions = append(conditions, "cell_a_used_new_engine = "+dialect.falseValue+" AND cell_b_used_new_engine = "+dialect.falseValue)
+		}
+	}
+
+	// Combine conditions
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+	return whereClause, args
+}
+
+// runMigrations runs the database migrations of the dialect
+func runMigrations(db *sql.DB, dialect sqlDialect) error {
+	migrations, err := dialect.migrations()
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	provider, err := goose.NewProvider(dialect.gooseDialect, db, migrations, goose.WithDisableGlobalRegistry(true))
+	if err != nil {
+		return fmt.Errorf("failed to create migration provider: %w", err)
+	}
+
+	if _, err := provider.Up(context.Background()); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return nil
+}
@@ -1,10 +1,12 @@
 package goldfish
 
 import (
+	"io/fs"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestQueryFilter_BuildWhereClause(t *testing.T) {
@@ -140,13 +142,44 @@ func TestQueryFilter_BuildWhereClause(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			whereClause, args := buildWhereClause(tt.filter)
+			whereClause, args := buildWhereClause(tt.filter, mysqlDialect)
 			assert.Equal(t, tt.expectedWhere, whereClause)
 			assert.Equal(t, tt.expectedArgs, args)
 		})
 	}
 }
 
+func TestQueryFilter_BuildWhereClausePostgres(t *testing.T) {
+	from := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	whereClause, args := buildWhereClause(QueryFilter{
+		From:          from,
+		Tenant:        "tenant-b",
+		User:          "user123",
+		UsedNewEngine: boolPtr(false),
+	}, postgresDialect)
+
+	assert.Equal(t, `WHERE sampled_at >= ? AND tenant_id = ? AND "user" = ? AND cell_a_used_new_engine = FALSE AND cell_b_used_new_engine = FALSE`, whereClause)
+	assert.Equal(t, []any{from, "tenant-b", "user123"}, args)
+	assert.Equal(t, `WHERE sampled_at >= $1 AND tenant_id = $2 AND "user" = $3 AND cell_a_used_new_engine = FALSE AND cell_b_used_new_engine = FALSE LIMIT $4`, postgresDialect.rebind(whereClause+" LIMIT ?"))
+	assert.Equal(t, "LIMIT ?", mysqlDialect.rebind("LIMIT ?"))
+}
+
+func TestMigrationsPortedToEachDialect(t *testing.T) {
+	migrationFiles := func(dialect sqlDialect) []string {
+		migrations, err := dialect.migrations()
+		require.NoError(t, err)
+		files, err := fs.Glob(migrations, "*.sql")
+		require.NoError(t, err)
+		return files
+	}
+
+	expected := migrationFiles(mysqlDialect)
+	require.NotEmpty(t, expected)
+	for _, dialect := range []sqlDialect{postgresDialect, sqliteDialect} {
+		assert.Equal(t, expected, migrationFiles(dialect), dialect.name)
+	}
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
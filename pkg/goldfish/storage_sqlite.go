@@ -0,0 +1,46 @@
+package goldfish
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-kit/log"
+	_ "modernc.org/sqlite" // SQLite driver
+)
+
+// SQLiteStorage provides embedded SQLite storage implementation
+type SQLiteStorage struct {
+	sqlStorage
+	config StorageConfig
+}
+
+// NewSQLiteStorage creates a new SQLite storage backend, storing the data in the configured file
+func NewSQLiteStorage(config StorageConfig, logger log.Logger) (*SQLiteStorage, error) {
+	if config.Type != "sqlite" {
+		return nil, fmt.Errorf("unsupported storage type: %s", config.Type)
+	}
+	if config.SQLitePath == "" {
+		return nil, errors.New("SQLite database path must be specified")
+	}
+
+	// Store the timestamps in a format sortable as text, and enforce the foreign keys like the other backends
+	dsn := "file:" + config.SQLitePath + "?_time_format=sqlite&_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite allows a single writer at a time
+	db.SetMaxOpenConns(1)
+
+	storage, err := newSQLStorage(db, sqliteDialect, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLiteStorage{
+		sqlStorage: storage,
+		config:     config,
+	}, nil
+}
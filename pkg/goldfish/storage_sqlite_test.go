@@ -0,0 +1,148 @@
+package goldfish
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStorage(t *testing.T) {
+	storage, err := NewSQLiteStorage(StorageConfig{
+		Type:       "sqlite",
+		SQLitePath: filepath.Join(t.TempDir(), "goldfish.db"),
+	}, log.NewNopLogger())
+	require.NoError(t, err)
+	defer storage.Close()
+
+	ctx := context.Background()
+	sampledAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	samples := []QuerySample{
+		{
+			CorrelationID:      "query-1",
+			TenantID:           "tenant-a",
+			User:               "user-a",
+			Query:              `{job="app"}`,
+			QueryType:          "range",
+			StartTime:          sampledAt.Add(-time.Hour),
+			EndTime:            sampledAt,
+			Step:               time.Minute,
+			CellAStats:         QueryStats{ExecTimeMs: 100, BytesProcessed: 1024},
+			CellBStats:         QueryStats{ExecTimeMs: 200, BytesProcessed: 1024},
+			CellAResponseHash:  "hash-a",
+			CellBResponseHash:  "hash-a",
+			CellAStatusCode:    200,
+			CellBStatusCode:    200,
+			CellASpanID:        "span-a",
+			CellBUsedNewEngine: true,
+			SampledAt:          sampledAt,
+		},
+		{
+			CorrelationID:   "query-2",
+			TenantID:        "tenant-b",
+			User:            "user-b",
+			IsLogsDrilldown: true,
+			Query:           `{job="other"}`,
+			QueryType:       "instant",
+			StartTime:       sampledAt,
+			EndTime:         sampledAt,
+			CellAStatusCode: 200,
+			CellBStatusCode: 500,
+			SampledAt:       sampledAt.Add(time.Minute),
+		},
+	}
+	for i := range samples {
+		require.NoError(t, storage.StoreQuerySample(ctx, &samples[i]))
+	}
+
+	resultDiff := &ResultDiff{
+		ResultType:    "streams",
+		MissingSeries: []string{`{job="app"}`},
+		EntryDifferences: []EntryDiff{
+			{Stream: `{job="app", level="info"}`, MissingEntries: 2, FirstDifference: sampledAt},
+		},
+	}
+	result := &ComparisonResult{
+		CorrelationID:     "query-1",
+		ComparisonStatus:  ComparisonStatusMismatch,
+		DifferenceDetails: map[string]any{ResultDiffKey: resultDiff},
+		ComparedAt:        sampledAt,
+	}
+	require.NoError(t, storage.StoreComparisonResult(ctx, result))
+	result.ComparisonStatus = ComparisonStatusMatch
+	require.NoError(t, storage.StoreComparisonResult(ctx, result))
+
+	var status string
+	require.NoError(t, storage.db.QueryRowContext(ctx, "SELECT comparison_status FROM comparison_outcomes WHERE correlation_id = ?", "query-1").Scan(&status))
+	require.Equal(t, string(ComparisonStatusMatch), status)
+
+	// the sampled queries are read back with their result diff, without the logs drilldown flag, in UTC
+	expected := make([]QuerySample, len(samples))
+	copy(expected, samples)
+	expected[1].IsLogsDrilldown = false
+	expected[0].ResultDiff = resultDiff
+
+	resp, err := storage.GetSampledQueries(ctx, 1, 1, QueryFilter{})
+	require.NoError(t, err)
+	require.True(t, resp.HasMore)
+	require.Equal(t, []QuerySample{expected[1]}, inUTC(resp.Queries))
+
+	resp, err = storage.GetSampledQueries(ctx, 2, 1, QueryFilter{})
+	require.NoError(t, err)
+	require.False(t, resp.HasMore)
+	require.Equal(t, []QuerySample{expected[0]}, inUTC(resp.Queries))
+
+	for _, tc := range []struct {
+		name     string
+		filter   QueryFilter
+		expected []string
+	}{
+		{name: "tenant", filter: QueryFilter{Tenant: "tenant-b"}, expected: []string{"query-2"}},
+		{name: "user", filter: QueryFilter{User: "user-a"}, expected: []string{"query-1"}},
+		{name: "logs drilldown", filter: QueryFilter{IsLogsDrilldown: boolPtr(true)}, expected: []string{"query-2"}},
+		{name: "new engine", filter: QueryFilter{UsedNewEngine: boolPtr(true)}, expected: []string{"query-1"}},
+		{name: "old engine", filter: QueryFilter{UsedNewEngine: boolPtr(false)}, expected: []string{"query-2"}},
+		{name: "time range", filter: QueryFilter{From: sampledAt.Add(30 * time.Second), To: sampledAt.Add(time.Hour)}, expected: []string{"query-2"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := storage.GetSampledQueries(ctx, 1, 10, tc.filter)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, q := range resp.Queries {
+				ids = append(ids, q.CorrelationID)
+			}
+			require.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func inUTC(queries []QuerySample) []QuerySample {
+	for i := range queries {
+		queries[i].StartTime = queries[i].StartTime.UTC()
+		queries[i].EndTime = queries[i].EndTime.UTC()
+		queries[i].SampledAt = queries[i].SampledAt.UTC()
+	}
+	return queries
+}
+
+func TestSQLiteMigrationsDown(t *testing.T) {
+	storage, err := NewSQLiteStorage(StorageConfig{
+		Type:       "sqlite",
+		SQLitePath: filepath.Join(t.TempDir(), "goldfish.db"),
+	}, log.NewNopLogger())
+	require.NoError(t, err)
+	defer storage.Close()
+
+	migrations, err := sqliteDialect.migrations()
+	require.NoError(t, err)
+	provider, err := goose.NewProvider(sqliteDialect.gooseDialect, storage.db, migrations, goose.WithDisableGlobalRegistry(true))
+	require.NoError(t, err)
+
+	_, err = provider.DownTo(context.Background(), 0)
+	require.NoError(t, err)
+}
@@ -37,6 +37,9 @@ type QuerySample struct {
 	CellBUsedNewEngine bool `json:"cellBUsedNewEngine"`
 
 	SampledAt time.Time `json:"sampledAt"`
+
+	// Semantic diff of the results, read from the comparison outcome when the results differ
+	ResultDiff *ResultDiff `json:"resultDiff,omitempty"`
 }
 
 // QueryStats contains extracted performance statistics
@@ -61,6 +64,64 @@ type ComparisonResult struct {
 	ComparedAt         time.Time
 }
 
+// ResultDiffKey is the key of the semantic diff of the results in the difference details of a comparison result
+const ResultDiffKey = "result_diff"
+
+// ResultDiff is the semantic difference between the results returned by both cells.
+// Series are streams for log queries and metric series for metric queries, identified by their labels.
+// Log lines are never part of the diff, only the number of differing entries.
+type ResultDiff struct {
+	ResultType string `json:"resultType"`
+
+	// Series returned by cell A but not by cell B
+	MissingSeries []string `json:"missingSeries,omitempty"`
+	// Series returned by cell B but not by cell A
+	ExtraSeries []string `json:"extraSeries,omitempty"`
+	// Series returned by both cells with the same values but different labels
+	LabelDifferences []LabelSetDiff `json:"labelDifferences,omitempty"`
+	// Sample values of metric series differing beyond the tolerance, or returned by a single cell
+	ValueDifferences []ValueDiff `json:"valueDifferences,omitempty"`
+	// Entries of streams returned by a single cell
+	EntryDifferences []EntryDiff `json:"entryDifferences,omitempty"`
+
+	// SeriesReordered is set when both cells returned the matching series in a different order
+	SeriesReordered bool `json:"seriesReordered,omitempty"`
+	// Series returned by both cells with the same values in a different order
+	ReorderedSeries []string `json:"reorderedSeries,omitempty"`
+	// OrderingOnly is set when the results only differ by their ordering
+	OrderingOnly bool `json:"orderingOnly"`
+
+	// Truncated is set when differences were dropped to bound the size of the diff
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// LabelSetDiff is a series returned by both cells with different labels
+type LabelSetDiff struct {
+	CellA string `json:"cellA"`
+	CellB string `json:"cellB"`
+	// Labels, as name="value", only returned by one of the cells
+	CellAOnly []string `json:"cellAOnly,omitempty"`
+	CellBOnly []string `json:"cellBOnly,omitempty"`
+}
+
+// ValueDiff is a sample of a metric series differing between both cells.
+// Values are formatted like in query responses, and are empty when the sample wasn't returned by the cell.
+type ValueDiff struct {
+	Series    string    `json:"series"`
+	Timestamp time.Time `json:"timestamp"`
+	CellA     string    `json:"cellA,omitempty"`
+	CellB     string    `json:"cellB,omitempty"`
+	Delta     float64   `json:"delta,omitempty"` // cell B value minus cell A value, when both are finite
+}
+
+// EntryDiff is a stream for which both cells returned different entries
+type EntryDiff struct {
+	Stream          string    `json:"stream"`
+	MissingEntries  int       `json:"missingEntries"` // entries returned by cell A but not by cell B
+	ExtraEntries    int       `json:"extraEntries"`   // entries returned by cell B but not by cell A
+	FirstDifference time.Time `json:"firstDifference"`
+}
+
 // ComparisonStatus represents the outcome of a comparison
 type ComparisonStatus string
 
@@ -46,6 +46,7 @@ import (
 	"github.com/grafana/loki/v3/pkg/logproto"
 	"github.com/grafana/loki/v3/pkg/logql"
 	lokilog "github.com/grafana/loki/v3/pkg/logql/log"
+	"github.com/grafana/loki/v3/pkg/logql/lookup"
 	"github.com/grafana/loki/v3/pkg/logql/syntax"
 	"github.com/grafana/loki/v3/pkg/logqlmodel/metadata"
 	"github.com/grafana/loki/v3/pkg/logqlmodel/stats"
@@ -121,6 +122,7 @@ type Config struct {
 	ChunkFilterer          chunk.RequestChunkFilterer     `yaml:"-"`
 	PipelineWrapper        lokilog.PipelineWrapper        `yaml:"-"`
 	SampleExtractorWrapper lokilog.SampleExtractorWrapper `yaml:"-"`
+	LookupTables           lokilog.LookupTables           `yaml:"-"`
 
 	// Optional wrapper that can be used to modify the behaviour of the ingester
 	Wrapper Wrapper `yaml:"-"`
@@ -287,6 +289,7 @@ type Ingester struct {
 	chunkFilter      chunk.RequestChunkFilterer
 	extractorWrapper lokilog.SampleExtractorWrapper
 	pipelineWrapper  lokilog.PipelineWrapper
+	lookupTables     lokilog.LookupTables
 
 	streamRateCalculator *StreamRateCalculator
 
@@ -415,6 +418,10 @@ func New(cfg Config, clientConfig client.Config, store Store, limits Limits, con
 		i.SetExtractorWrapper(i.cfg.SampleExtractorWrapper)
 	}
 
+	if i.cfg.LookupTables != nil {
+		i.SetLookupTables(i.cfg.LookupTables)
+	}
+
 	var streamCountLimiter limiterRingStrategy
 	var ownedStreamsStrategy ownershipStrategy
 	var streamRateLimiter RateLimiterStrategy
@@ -449,6 +456,10 @@ func (i *Ingester) SetPipelineWrapper(wrapper lokilog.PipelineWrapper) {
 	i.pipelineWrapper = wrapper
 }
 
+func (i *Ingester) SetLookupTables(tables lokilog.LookupTables) {
+	i.lookupTables = tables
+}
+
 // setupAutoForget looks for ring status if `AutoForgetUnhealthy` is enabled
 // when enabled, unhealthy ingesters that reach `ring.kvstore.heartbeat_timeout` are removed from the ring every `HeartbeatPeriod`
 func (i *Ingester) setupAutoForget() {
@@ -1047,6 +1058,7 @@ func (i *Ingester) GetOrCreateInstance(instanceID string) (*instance, error) { /
 		if err != nil {
 			return nil, err
 		}
+		inst.lookupTables = i.lookupTables
 		i.instances[instanceID] = inst
 		activeTenantsStats.Set(int64(len(i.instances)))
 	}
@@ -1588,6 +1600,10 @@ func (i *Ingester) tail(req *logproto.TailRequest, queryServer logproto.Querier_
 		return fmt.Errorf("unsupported query expression: want (LogSelectorExpr), got (%T)", req.Plan.AST)
 	}
 
+	if err := lookup.ResolveTables(queryServer.Context(), expr, i.lookupTables); err != nil {
+		return err
+	}
+
 	tailer, err := newTailer(instanceID, expr, queryServer, i.cfg.MaxDroppedStreams)
 	if err != nil {
 		return err
@@ -36,6 +36,7 @@ import (
 	"github.com/grafana/loki/v3/pkg/logproto"
 	"github.com/grafana/loki/v3/pkg/logql"
 	"github.com/grafana/loki/v3/pkg/logql/log"
+	"github.com/grafana/loki/v3/pkg/logql/lookup"
 	"github.com/grafana/loki/v3/pkg/logql/syntax"
 	"github.com/grafana/loki/v3/pkg/logqlmodel/metadata"
 	"github.com/grafana/loki/v3/pkg/logqlmodel/stats"
@@ -121,6 +122,7 @@ type instance struct {
 	chunkFilter          chunk.RequestChunkFilterer
 	pipelineWrapper      log.PipelineWrapper
 	extractorWrapper     log.SampleExtractorWrapper
+	lookupTables         log.LookupTables
 	streamRateCalculator *StreamRateCalculator
 
 	writeFailures *writefailures.Manager
@@ -488,6 +490,10 @@ func (i *instance) query(ctx context.Context, req logql.SelectLogParams) (iter.E
 		return nil, err
 	}
 
+	if err := lookup.ResolveTables(ctx, expr, i.lookupTables); err != nil {
+		return nil, err
+	}
+
 	pipeline, err := expr.Pipeline()
 	if err != nil {
 		return nil, err
@@ -548,6 +554,10 @@ func (i *instance) querySample(ctx context.Context, req logql.SelectSampleParams
 		return nil, err
 	}
 
+	if err := lookup.ResolveTables(ctx, expr, i.lookupTables); err != nil {
+		return nil, err
+	}
+
 	extractors, err := expr.Extractors()
 	if err != nil {
 		return nil, err
@@ -43,6 +43,10 @@ type Config struct {
 	// EvictionInterval defines the interval at which old streams are evicted.
 	EvictionInterval time.Duration `yaml:"eviction_interval"`
 
+	// RateLimitsEnabled enables the enforcement of the per-tenant ingestion
+	// rate and burst, and of the per-stream rate limit.
+	RateLimitsEnabled bool `yaml:"rate_limits_enabled"`
+
 	// The number of partitions for the Kafka topic used to read and write stream metadata.
 	// It is fixed, not a maximum.
 	NumPartitions int `yaml:"num_partitions"`
@@ -86,6 +90,12 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 		DefaultEvictInterval,
 		"The interval at which old streams are evicted.",
 	)
+	f.BoolVar(
+		&cfg.RateLimitsEnabled,
+		"ingest-limits.rate-limits-enabled",
+		false,
+		"Enforce the per-tenant ingestion rate and burst, and the per-stream rate limit, in addition to the maximum number of streams.",
+	)
 	f.IntVar(
 		&cfg.NumPartitions,
 		"ingest-limits.num-partitions",
@@ -10,4 +10,8 @@ type limitsClient interface {
 	// ExceedsLimits checks if the streams in the request have exceeded their
 	// per-partition limits.
 	ExceedsLimits(context.Context, *proto.ExceedsLimitsRequest) ([]*proto.ExceedsLimitsResponse, error)
+
+	// GetTenantUsage returns the usage of the tenant from each limits
+	// instance that was queried.
+	GetTenantUsage(context.Context, *proto.GetTenantUsageRequest) ([]*proto.GetTenantUsageResponse, error)
 }
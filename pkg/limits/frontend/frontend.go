@@ -137,6 +137,54 @@ func (f *Frontend) ExceedsLimits(ctx context.Context, req *proto.ExceedsLimitsRe
 	return &proto.ExceedsLimitsResponse{Results: results}, nil
 }
 
+// GetTenantUsage implements proto.IngestLimitsFrontendClient.
+func (f *Frontend) GetTenantUsage(ctx context.Context, req *proto.GetTenantUsageRequest) (*proto.GetTenantUsageResponse, error) {
+	resps, err := f.limitsClient.GetTenantUsage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return mergeTenantUsage(resps, int(req.TopStreams)), nil
+}
+
+// mergeTenantUsage merges the usage returned from each limits instance.
+// As each partition is answered by a single instance, the same stream
+// cannot be returned twice.
+func mergeTenantUsage(resps []*proto.GetTenantUsageResponse, topStreams int) *proto.GetTenantUsageResponse {
+	var (
+		result = &proto.GetTenantUsageResponse{
+			ActiveStreamsByPolicy: make(map[string]uint64),
+		}
+		churn = make(map[int64]*proto.StreamChurn)
+	)
+	for _, resp := range resps {
+		result.ActiveStreams += resp.ActiveStreams
+		result.Rate += resp.Rate
+		for policy, n := range resp.ActiveStreamsByPolicy {
+			result.ActiveStreamsByPolicy[policy] += n
+		}
+		result.TopStreams = append(result.TopStreams, resp.TopStreams...)
+		for _, c := range resp.Churn {
+			merged, ok := churn[c.Timestamp]
+			if !ok {
+				merged = &proto.StreamChurn{Timestamp: c.Timestamp}
+				churn[c.Timestamp] = merged
+			}
+			merged.Created += c.Created
+			merged.Evicted += c.Evicted
+		}
+	}
+	limits.SortStreamUsage(result.TopStreams)
+	if len(result.TopStreams) > topStreams {
+		result.TopStreams = result.TopStreams[:topStreams]
+	}
+	result.Churn = make([]*proto.StreamChurn, 0, len(churn))
+	for _, c := range churn {
+		result.Churn = append(result.Churn, c)
+	}
+	limits.SortStreamChurn(result.Churn)
+	return result
+}
+
 func (f *Frontend) CheckReady(ctx context.Context) error {
 	if f.State() != services.Running {
 		return fmt.Errorf("service is not running: %v", f.State())
@@ -182,3 +182,64 @@ func TestFrontend_ExceedsLimits(t *testing.T) {
 		})
 	}
 }
+
+func TestFrontend_GetTenantUsage(t *testing.T) {
+	readRing, _ := newMockRingWithClientPool(t, "test", nil, nil)
+	f, err := New(Config{
+		LifecyclerConfig: ring.LifecyclerConfig{
+			RingConfig: ring.Config{
+				KVStore: kv.Config{
+					Store: "inmemory",
+				},
+			},
+		},
+	}, "test", readRing, log.NewNopLogger(), prometheus.NewRegistry())
+	require.NoError(t, err)
+	req := &proto.GetTenantUsageRequest{Tenant: "test", TopStreams: 2}
+	f.limitsClient = &mockLimitsClient{
+		t:                             t,
+		expectedGetTenantUsageRequest: req,
+		getTenantUsageResponses: []*proto.GetTenantUsageResponse{{
+			ActiveStreams:         2,
+			Rate:                  30,
+			ActiveStreamsByPolicy: map[string]uint64{"": 1, "policy1": 1},
+			TopStreams: []*proto.StreamUsage{
+				{StreamHash: 0x1, Rate: 20},
+				{StreamHash: 0x2, Policy: "policy1", Rate: 10},
+			},
+			Churn: []*proto.StreamChurn{
+				{Timestamp: 60, Created: 1},
+				{Timestamp: 120, Created: 1},
+			},
+		}, {
+			ActiveStreams:         1,
+			Rate:                  15,
+			ActiveStreamsByPolicy: map[string]uint64{"policy1": 1},
+			TopStreams: []*proto.StreamUsage{
+				{StreamHash: 0x3, Policy: "policy1", Rate: 15},
+			},
+			Churn: []*proto.StreamChurn{
+				{Timestamp: 0, Created: 1},
+				{Timestamp: 120, Created: 1, Evicted: 1},
+			},
+		}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	actual, err := f.GetTenantUsage(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, &proto.GetTenantUsageResponse{
+		ActiveStreams:         3,
+		Rate:                  45,
+		ActiveStreamsByPolicy: map[string]uint64{"": 1, "policy1": 2},
+		TopStreams: []*proto.StreamUsage{
+			{StreamHash: 0x1, Rate: 20},
+			{StreamHash: 0x3, Policy: "policy1", Rate: 15},
+		},
+		Churn: []*proto.StreamChurn{
+			{Timestamp: 0, Created: 1},
+			{Timestamp: 60, Created: 1},
+			{Timestamp: 120, Created: 2, Evicted: 1},
+		},
+	}, actual)
+}
@@ -3,8 +3,10 @@ package frontend
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/go-kit/log/level"
+	"github.com/gorilla/mux"
 	"github.com/grafana/dskit/user"
 
 	"github.com/grafana/loki/v3/pkg/limits/proto"
@@ -20,6 +22,36 @@ type httpExceedsLimitsResponse struct {
 	Results []*proto.ExceedsLimitsResult `json:"results,omitempty"`
 }
 
+const (
+	// The default and maximum number of top streams returned by the tenant
+	// usage endpoint.
+	defaultTopStreams = 10
+	maxTopStreams     = 1000
+)
+
+type httpTenantUsageResponse struct {
+	Tenant                string            `json:"tenant"`
+	ActiveStreams         uint64            `json:"activeStreams"`
+	Rate                  float64           `json:"rate"`
+	ActiveStreamsByPolicy map[string]uint64 `json:"activeStreamsByPolicy"`
+	TopStreams            []httpStreamUsage `json:"topStreams"`
+	Churn                 []httpStreamChurn `json:"churn"`
+}
+
+type httpStreamUsage struct {
+	// The hash is formatted as a string as it does not fit in a JSON number.
+	StreamHash string  `json:"streamHash"`
+	Policy     string  `json:"policy,omitempty"`
+	Rate       float64 `json:"rate"`
+	LastSeenAt int64   `json:"lastSeenAt"`
+}
+
+type httpStreamChurn struct {
+	Timestamp int64  `json:"timestamp"`
+	Created   uint64 `json:"created"`
+	Evicted   uint64 `json:"evicted"`
+}
+
 // ServeHTTP implements http.Handler.
 func (f *Frontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var req httpExceedsLimitsRequest
@@ -53,3 +85,67 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Results: resp.Results,
 	})
 }
+
+// TenantUsageHandler returns an http.Handler that returns the usage of the
+// tenant in the URL: its active streams, per policy and in total, its rate,
+// its top streams by rate and the stream churn over the active window.
+func (f *Frontend) TenantUsageHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := mux.Vars(r)["tenant"]
+		if tenant == "" {
+			http.Error(w, "tenant is required", http.StatusBadRequest)
+			return
+		}
+		topStreams := defaultTopStreams
+		if s := r.URL.Query().Get("top"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 0 || n > maxTopStreams {
+				http.Error(w, "top must be a number between 0 and "+strconv.Itoa(maxTopStreams), http.StatusBadRequest)
+				return
+			}
+			topStreams = n
+		}
+
+		ctx, err := user.InjectIntoGRPCRequest(user.InjectOrgID(r.Context(), tenant))
+		if err != nil {
+			http.Error(w, "failed to inject org ID", http.StatusInternalServerError)
+			return
+		}
+
+		resp, err := f.GetTenantUsage(ctx, &proto.GetTenantUsageRequest{
+			Tenant:     tenant,
+			TopStreams: uint32(topStreams),
+		})
+		if err != nil {
+			level.Error(f.logger).Log("msg", "failed to get tenant usage", "tenant", tenant, "err", err)
+			http.Error(w, "an unexpected error occurred while getting tenant usage", http.StatusInternalServerError)
+			return
+		}
+
+		topStreamsUsage := make([]httpStreamUsage, 0, len(resp.TopStreams))
+		for _, stream := range resp.TopStreams {
+			topStreamsUsage = append(topStreamsUsage, httpStreamUsage{
+				StreamHash: strconv.FormatUint(stream.StreamHash, 10),
+				Policy:     stream.Policy,
+				Rate:       stream.Rate,
+				LastSeenAt: stream.LastSeenAt,
+			})
+		}
+		churn := make([]httpStreamChurn, 0, len(resp.Churn))
+		for _, c := range resp.Churn {
+			churn = append(churn, httpStreamChurn{
+				Timestamp: c.Timestamp,
+				Created:   c.Created,
+				Evicted:   c.Evicted,
+			})
+		}
+		util.WriteJSONResponse(w, httpTenantUsageResponse{
+			Tenant:                tenant,
+			ActiveStreams:         resp.ActiveStreams,
+			Rate:                  resp.Rate,
+			ActiveStreamsByPolicy: resp.ActiveStreamsByPolicy,
+			TopStreams:            topStreamsUsage,
+			Churn:                 churn,
+		})
+	})
+}
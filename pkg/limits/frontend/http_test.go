@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/go-kit/log"
+	"github.com/gorilla/mux"
 	"github.com/grafana/dskit/kv"
 	"github.com/grafana/dskit/ring"
 	"github.com/prometheus/client_golang/prometheus"
@@ -111,3 +112,65 @@ func TestFrontend_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestFrontend_TenantUsageHandler(t *testing.T) {
+	readRing, _ := newMockRingWithClientPool(t, "test", nil, nil)
+	f, err := New(Config{
+		LifecyclerConfig: ring.LifecyclerConfig{
+			RingConfig: ring.Config{
+				KVStore: kv.Config{
+					Store: "inmemory",
+				},
+			},
+		},
+	}, "test", readRing, log.NewNopLogger(), prometheus.NewRegistry())
+	require.NoError(t, err)
+	f.limitsClient = &mockLimitsClient{
+		t: t,
+		expectedGetTenantUsageRequest: &proto.GetTenantUsageRequest{
+			Tenant:     "test",
+			TopStreams: 1,
+		},
+		getTenantUsageResponses: []*proto.GetTenantUsageResponse{{
+			ActiveStreams:         1,
+			Rate:                  10,
+			ActiveStreamsByPolicy: map[string]uint64{"policy1": 1},
+			TopStreams: []*proto.StreamUsage{{
+				StreamHash: 0xffffffffffffffff,
+				Policy:     "policy1",
+				Rate:       10,
+				LastSeenAt: 100,
+			}},
+			Churn: []*proto.StreamChurn{{Timestamp: 60, Created: 1}},
+		}},
+	}
+	router := mux.NewRouter()
+	router.Path("/usage/{tenant}").Handler(f.TenantUsageHandler())
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/usage/test?top=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var actual httpTenantUsageResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+	require.Equal(t, httpTenantUsageResponse{
+		Tenant:                "test",
+		ActiveStreams:         1,
+		Rate:                  10,
+		ActiveStreamsByPolicy: map[string]uint64{"policy1": 1},
+		TopStreams: []httpStreamUsage{{
+			StreamHash: "18446744073709551615",
+			Policy:     "policy1",
+			Rate:       10,
+			LastSeenAt: 100,
+		}},
+		Churn: []httpStreamChurn{{Timestamp: 60, Created: 1}},
+	}, actual)
+
+	resp, err = http.Get(ts.URL + "/usage/test?top=-1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
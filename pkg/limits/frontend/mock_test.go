@@ -22,9 +22,11 @@ import (
 type mockLimitsClient struct {
 	t *testing.T
 
-	expectedExceedsLimitsRequest *proto.ExceedsLimitsRequest
-	exceedsLimitsResponses       []*proto.ExceedsLimitsResponse
-	err                          error
+	expectedExceedsLimitsRequest  *proto.ExceedsLimitsRequest
+	exceedsLimitsResponses        []*proto.ExceedsLimitsResponse
+	expectedGetTenantUsageRequest *proto.GetTenantUsageRequest
+	getTenantUsageResponses       []*proto.GetTenantUsageResponse
+	err                           error
 }
 
 func (m *mockLimitsClient) ExceedsLimits(_ context.Context, req *proto.ExceedsLimitsRequest) ([]*proto.ExceedsLimitsResponse, error) {
@@ -34,6 +36,13 @@ func (m *mockLimitsClient) ExceedsLimits(_ context.Context, req *proto.ExceedsLi
 	return m.exceedsLimitsResponses, m.err
 }
 
+func (m *mockLimitsClient) GetTenantUsage(_ context.Context, req *proto.GetTenantUsageRequest) ([]*proto.GetTenantUsageResponse, error) {
+	if expected := m.expectedGetTenantUsageRequest; expected != nil {
+		require.Equal(m.t, expected, req)
+	}
+	return m.getTenantUsageResponses, m.err
+}
+
 // mockLimitsProtoClient mocks proto.IngestLimitsClient.
 type mockLimitsProtoClient struct {
 	proto.IngestLimitsClient
@@ -44,6 +53,9 @@ type mockLimitsProtoClient struct {
 	// has no fields. Instead, tests should check the number of requests
 	// received with [Finished].
 	expectedExceedsLimitsRequests []*proto.ExceedsLimitsRequest
+	// Requests for GetTenantUsage are not checked in order, as the
+	// partitions of each instance are queried concurrently.
+	expectedGetTenantUsageRequest *proto.GetTenantUsageRequest
 
 	// The complete set of mocked responses over the lifetime of the client.
 	// When a request is received, it consumes the next response (or error)
@@ -52,10 +64,13 @@ type mockLimitsProtoClient struct {
 	getAssignedPartitionsResponseErrs []error
 	exceedsLimitsResponses            []*proto.ExceedsLimitsResponse
 	exceedsLimitsResponseErrs         []error
+	getTenantUsageResponses           []*proto.GetTenantUsageResponse
+	getTenantUsageResponseErrs        []error
 
 	// The actual request counts.
 	numAssignedPartitionsRequests int
 	numExceedsLimitsRequests      int
+	numGetTenantUsageRequests     int
 }
 
 func (m *mockLimitsProtoClient) GetAssignedPartitions(_ context.Context, _ *proto.GetAssignedPartitionsRequest, _ ...grpc.CallOption) (*proto.GetAssignedPartitionsResponse, error) {
@@ -89,9 +104,27 @@ func (m *mockLimitsProtoClient) ExceedsLimits(_ context.Context, req *proto.Exce
 	return m.exceedsLimitsResponses[idx], nil
 }
 
+func (m *mockLimitsProtoClient) GetTenantUsage(_ context.Context, req *proto.GetTenantUsageRequest, _ ...grpc.CallOption) (*proto.GetTenantUsageResponse, error) {
+	idx := m.numGetTenantUsageRequests
+	// Check that we haven't received more requests than we have mocked
+	// responses.
+	if idx >= len(m.getTenantUsageResponses) {
+		return nil, errors.New("unexpected GetTenantUsageRequest")
+	}
+	m.numGetTenantUsageRequests++
+	if m.expectedGetTenantUsageRequest != nil {
+		require.Equal(m.t, m.expectedGetTenantUsageRequest, req)
+	}
+	if err := m.getTenantUsageResponseErrs[idx]; err != nil {
+		return nil, err
+	}
+	return m.getTenantUsageResponses[idx], nil
+}
+
 func (m *mockLimitsProtoClient) Finished() {
 	require.Equal(m.t, len(m.getAssignedPartitionsResponses), m.numAssignedPartitionsRequests)
 	require.Equal(m.t, len(m.exceedsLimitsResponses), m.numExceedsLimitsRequests)
+	require.Equal(m.t, len(m.getTenantUsageResponses), m.numGetTenantUsageRequests)
 }
 
 func (m *mockLimitsProtoClient) Close() error {
@@ -194,6 +194,101 @@ func (r *ringLimitsClient) doExceedsLimitsRPCs(ctx context.Context, tenant strin
 	return responses, answered, nil
 }
 
+// GetTenantUsage implements the [limitsClient] interface. It queries the
+// consumers of the requested partitions, or all partitions if none are
+// requested, and returns their responses. Each partition is queried once.
+func (r *ringLimitsClient) GetTenantUsage(ctx context.Context, req *proto.GetTenantUsageRequest) ([]*proto.GetTenantUsageResponse, error) {
+	rs, err := r.ring.GetAllHealthy(LimitsRead)
+	if err != nil {
+		return nil, err
+	}
+	zonesPartitions, err := r.getZoneAwarePartitionConsumers(ctx, rs.Instances)
+	if err != nil {
+		return nil, err
+	}
+	zonesToQuery := make([]string, 0, len(zonesPartitions))
+	for zone := range zonesPartitions {
+		zonesToQuery = append(zonesToQuery, zone)
+	}
+	slices.SortFunc(zonesToQuery, r.zoneCmp)
+	partitions := req.Partitions
+	if len(partitions) == 0 {
+		partitions = make([]int32, 0, r.numPartitions)
+		for i := 0; i < r.numPartitions; i++ {
+			partitions = append(partitions, int32(i))
+		}
+	}
+	// Query each zone as ordered in zonesToQuery for the partitions that
+	// have not been answered by a previous zone. As every zone consumes all
+	// partitions, the usage of a partition must only be counted once.
+	responses := make([]*proto.GetTenantUsageResponse, 0)
+	for _, zone := range zonesToQuery {
+		if len(partitions) == 0 {
+			break
+		}
+		resps, answered := r.doGetTenantUsageRPCs(ctx, req, partitions, zonesPartitions[zone], zone)
+		responses = append(responses, resps...)
+		partitions = slices.DeleteFunc(partitions, func(partition int32) bool {
+			return slices.Contains(answered, partition)
+		})
+	}
+	if len(partitions) > 0 {
+		level.Warn(r.logger).Log("msg", "failed to get tenant usage for all partitions", "tenant", req.Tenant, "missing", len(partitions))
+	}
+	return responses, nil
+}
+
+func (r *ringLimitsClient) doGetTenantUsageRPCs(ctx context.Context, req *proto.GetTenantUsageRequest, partitions []int32, consumers map[int32]string, zone string) ([]*proto.GetTenantUsageResponse, []int32) {
+	// For each partition, figure out which instance consumes it.
+	instancesForPartitions := make(map[string][]int32)
+	for _, partition := range partitions {
+		addr, ok := consumers[partition]
+		if !ok {
+			r.partitionsMissing.WithLabelValues(zone).Inc()
+			continue
+		}
+		instancesForPartitions[addr] = append(instancesForPartitions[addr], partition)
+	}
+	errg, ctx := errgroup.WithContext(ctx)
+	responseCh := make(chan *proto.GetTenantUsageResponse, len(instancesForPartitions))
+	answeredCh := make(chan int32, len(partitions))
+	for addr, partitions := range instancesForPartitions {
+		errg.Go(func() error {
+			client, err := r.pool.GetClientFor(addr)
+			if err != nil {
+				level.Error(r.logger).Log("msg", "failed to get client for instance", "instance", addr, "err", err.Error())
+				return nil
+			}
+			resp, err := client.(proto.IngestLimitsClient).GetTenantUsage(ctx, &proto.GetTenantUsageRequest{
+				Tenant:     req.Tenant,
+				TopStreams: req.TopStreams,
+				Partitions: partitions,
+			})
+			if err != nil {
+				level.Error(r.logger).Log("msg", "failed to get tenant usage for instance", "instance", addr, "err", err.Error())
+				return nil
+			}
+			responseCh <- resp
+			for _, partition := range partitions {
+				answeredCh <- partition
+			}
+			return nil
+		})
+	}
+	_ = errg.Wait()
+	close(responseCh)
+	close(answeredCh)
+	responses := make([]*proto.GetTenantUsageResponse, 0, len(instancesForPartitions))
+	for r := range responseCh {
+		responses = append(responses, r)
+	}
+	answered := make([]int32, 0, len(partitions))
+	for partition := range answeredCh {
+		answered = append(answered, partition)
+	}
+	return responses, answered
+}
+
 type zonePartitionConsumersResult struct {
 	zone       string
 	partitions map[int32]string
@@ -814,3 +814,135 @@ func TestRingStreamUsageGatherer_GetPartitionConsumers_Caching(t *testing.T) {
 	require.Equal(t, 2, client0.numAssignedPartitionsRequests)
 	require.Equal(t, 2, client1.numAssignedPartitionsRequests)
 }
+
+func TestRingGatherer_GetTenantUsage(t *testing.T) {
+	tests := []struct {
+		name                              string
+		request                           *proto.GetTenantUsageRequest
+		instances                         []ring.InstanceDesc
+		numPartitions                     int
+		getAssignedPartitionsResponses    [][]*proto.GetAssignedPartitionsResponse
+		getAssignedPartitionsResponseErrs [][]error
+		expectedGetTenantUsageRequests    []*proto.GetTenantUsageRequest
+		getTenantUsageResponses           [][]*proto.GetTenantUsageResponse
+		getTenantUsageResponseErrs        [][]error
+		expected                          []*proto.GetTenantUsageResponse
+	}{{
+		// When there are two instances, each instance is queried for the
+		// partitions it consumes.
+		name: "two instances",
+		request: &proto.GetTenantUsageRequest{
+			Tenant:     "test",
+			TopStreams: 10,
+		},
+		instances: []ring.InstanceDesc{{
+			Addr: "instance-0",
+		}, {
+			Addr: "instance-1",
+		}},
+		numPartitions: 2,
+		getAssignedPartitionsResponses: [][]*proto.GetAssignedPartitionsResponse{{{
+			AssignedPartitions: map[int32]int64{
+				0: time.Now().UnixNano(),
+			},
+		}}, {{
+			AssignedPartitions: map[int32]int64{
+				1: time.Now().UnixNano(),
+			},
+		}}},
+		getAssignedPartitionsResponseErrs: [][]error{{nil}, {nil}},
+		expectedGetTenantUsageRequests: []*proto.GetTenantUsageRequest{{
+			Tenant:     "test",
+			TopStreams: 10,
+			Partitions: []int32{0},
+		}, {
+			Tenant:     "test",
+			TopStreams: 10,
+			Partitions: []int32{1},
+		}},
+		getTenantUsageResponses: [][]*proto.GetTenantUsageResponse{{{
+			ActiveStreams: 1,
+		}}, {{
+			ActiveStreams: 2,
+		}}},
+		getTenantUsageResponseErrs: [][]error{{nil}, {nil}},
+		expected: []*proto.GetTenantUsageResponse{{
+			ActiveStreams: 1,
+		}, {
+			ActiveStreams: 2,
+		}},
+	}, {
+		// When the instance in the first zone fails, its partitions are
+		// queried in the next zone instead.
+		name: "two zones, first zone fails",
+		request: &proto.GetTenantUsageRequest{
+			Tenant: "test",
+		},
+		instances: []ring.InstanceDesc{{
+			Addr: "instance-a-0",
+			Zone: "a",
+		}, {
+			Addr: "instance-b-0",
+			Zone: "b",
+		}},
+		numPartitions: 2,
+		getAssignedPartitionsResponses: [][]*proto.GetAssignedPartitionsResponse{{{
+			AssignedPartitions: map[int32]int64{
+				0: time.Now().UnixNano(),
+				1: time.Now().UnixNano(),
+			},
+		}}, {{
+			AssignedPartitions: map[int32]int64{
+				0: time.Now().UnixNano(),
+				1: time.Now().UnixNano(),
+			},
+		}}},
+		getAssignedPartitionsResponseErrs: [][]error{{nil}, {nil}},
+		expectedGetTenantUsageRequests: []*proto.GetTenantUsageRequest{{
+			Tenant:     "test",
+			Partitions: []int32{0, 1},
+		}, {
+			Tenant:     "test",
+			Partitions: []int32{0, 1},
+		}},
+		getTenantUsageResponses: [][]*proto.GetTenantUsageResponse{{nil}, {{
+			ActiveStreams: 2,
+		}}},
+		getTenantUsageResponseErrs: [][]error{{
+			errors.New("an unexpected error occurred"),
+		}, {
+			nil,
+		}},
+		expected: []*proto.GetTenantUsageResponse{{
+			ActiveStreams: 2,
+		}},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClients := make([]*mockLimitsProtoClient, len(test.instances))
+			for i := 0; i < len(test.instances); i++ {
+				mockClients[i] = &mockLimitsProtoClient{
+					t:                                 t,
+					getAssignedPartitionsResponses:    test.getAssignedPartitionsResponses[i],
+					getAssignedPartitionsResponseErrs: test.getAssignedPartitionsResponseErrs[i],
+					expectedGetTenantUsageRequest:     test.expectedGetTenantUsageRequests[i],
+					getTenantUsageResponses:           test.getTenantUsageResponses[i],
+					getTenantUsageResponseErrs:        test.getTenantUsageResponseErrs[i],
+				}
+				t.Cleanup(mockClients[i].Finished)
+			}
+			readRing, clientPool := newMockRingWithClientPool(t, "test", mockClients, test.instances)
+			cache := newNopCache[string, *proto.GetAssignedPartitionsResponse]()
+			r := newRingLimitsClient(readRing, clientPool, test.numPartitions, cache, log.NewNopLogger(), prometheus.NewRegistry())
+
+			// Set a maximum upper bound on the test execution time.
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			actual, err := r.GetTenantUsage(ctx, test.request)
+			require.NoError(t, err)
+			require.ElementsMatch(t, test.expected, actual)
+		})
+	}
+}
@@ -30,7 +30,7 @@ func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			sumBuckets += bucket.size
 		}
 	})
-	rate := float64(sumBuckets) / s.cfg.ActiveWindow.Seconds()
+	rate := float64(sumBuckets) / s.cfg.RateWindow.Seconds()
 
 	// Log the calculated values for debugging
 	level.Debug(s.logger).Log(
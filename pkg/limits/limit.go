@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/grafana/loki/v3/pkg/limits/proto"
+	"github.com/grafana/loki/v3/pkg/validation"
 )
 
 // Limits contains all limits enforced by the limits frontend.
@@ -18,6 +19,9 @@ type Limits interface {
 	IngestionRateBytes(userID string) float64
 	IngestionBurstSizeBytes(userID string) int
 	MaxGlobalStreamsPerUser(userID string) int
+	PerStreamRateLimit(userID string) validation.RateLimit
+	PolicyIngestionRateBytes(userID, policy string) float64
+	PolicyIngestionBurstSizeBytes(userID, policy string) int
 }
 
 type limitsChecker struct {
@@ -91,13 +95,5 @@ func (c *limitsChecker) ExceedsLimits(ctx context.Context, req *proto.ExceedsLim
 	}
 	c.tenantIngestedBytesTotal.WithLabelValues(req.Tenant).Add(float64(ingestedBytes))
 
-	results := make([]*proto.ExceedsLimitsResult, 0, len(rejected))
-	for _, stream := range rejected {
-		results = append(results, &proto.ExceedsLimitsResult{
-			StreamHash: stream.StreamHash,
-			Reason:     uint32(ReasonMaxStreams),
-		})
-	}
-
-	return &proto.ExceedsLimitsResponse{Results: results}, nil
+	return &proto.ExceedsLimitsResponse{Results: rejected}, nil
 }
@@ -5,11 +5,15 @@ import (
 	"sync"
 
 	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/grafana/loki/v3/pkg/validation"
 )
 
 type mockLimits struct {
 	MaxGlobalStreams int
 	IngestionRate    float64
+	PerStreamRate    validation.RateLimit
+	PolicyRates      map[string]float64
 }
 
 func (m *mockLimits) MaxGlobalStreamsPerUser(_ string) int {
@@ -24,6 +28,18 @@ func (m *mockLimits) IngestionBurstSizeBytes(_ string) int {
 	return 1000
 }
 
+func (m *mockLimits) PerStreamRateLimit(_ string) validation.RateLimit {
+	return m.PerStreamRate
+}
+
+func (m *mockLimits) PolicyIngestionRateBytes(_, policy string) float64 {
+	return m.PolicyRates[policy]
+}
+
+func (m *mockLimits) PolicyIngestionBurstSizeBytes(_, _ string) int {
+	return 0
+}
+
 // mockKafka mocks a [kgo.Client]. The zero value is usable.
 type mockKafka struct {
 	fetches  []kgo.Fetches
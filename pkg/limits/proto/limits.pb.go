@@ -5,6 +5,7 @@ package proto
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
 	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
@@ -252,15 +253,283 @@ func (m *GetAssignedPartitionsResponse) GetAssignedPartitions() map[int32]int64
 	return nil
 }
 
+type GetTenantUsageRequest struct {
+	Tenant string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// The maximum number of streams to return, ordered by rate.
+	TopStreams uint32 `protobuf:"varint,2,opt,name=topStreams,proto3" json:"topStreams,omitempty"`
+	// The partitions to include. If empty, all partitions are included.
+	Partitions []int32 `protobuf:"varint,3,rep,packed,name=partitions,proto3" json:"partitions,omitempty"`
+}
+
+func (m *GetTenantUsageRequest) Reset()      { *m = GetTenantUsageRequest{} }
+func (*GetTenantUsageRequest) ProtoMessage() {}
+func (*GetTenantUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aaed9e7d5298ac0f, []int{5}
+}
+func (m *GetTenantUsageRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetTenantUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetTenantUsageRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetTenantUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTenantUsageRequest.Merge(m, src)
+}
+func (m *GetTenantUsageRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetTenantUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTenantUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTenantUsageRequest proto.InternalMessageInfo
+
+func (m *GetTenantUsageRequest) GetTenant() string {
+	if m != nil {
+		return m.Tenant
+	}
+	return ""
+}
+
+func (m *GetTenantUsageRequest) GetTopStreams() uint32 {
+	if m != nil {
+		return m.TopStreams
+	}
+	return 0
+}
+
+func (m *GetTenantUsageRequest) GetPartitions() []int32 {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+type GetTenantUsageResponse struct {
+	ActiveStreams uint64 `protobuf:"varint,1,opt,name=activeStreams,proto3" json:"activeStreams,omitempty"`
+	// The ingestion rate in bytes per second over the rate window.
+	Rate                  float64           `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	ActiveStreamsByPolicy map[string]uint64 `protobuf:"bytes,3,rep,name=activeStreamsByPolicy,proto3" json:"activeStreamsByPolicy,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	TopStreams            []*StreamUsage    `protobuf:"bytes,4,rep,name=topStreams,proto3" json:"topStreams,omitempty"`
+	Churn                 []*StreamChurn    `protobuf:"bytes,5,rep,name=churn,proto3" json:"churn,omitempty"`
+}
+
+func (m *GetTenantUsageResponse) Reset()      { *m = GetTenantUsageResponse{} }
+func (*GetTenantUsageResponse) ProtoMessage() {}
+func (*GetTenantUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aaed9e7d5298ac0f, []int{6}
+}
+func (m *GetTenantUsageResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetTenantUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetTenantUsageResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetTenantUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTenantUsageResponse.Merge(m, src)
+}
+func (m *GetTenantUsageResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetTenantUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTenantUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTenantUsageResponse proto.InternalMessageInfo
+
+func (m *GetTenantUsageResponse) GetActiveStreams() uint64 {
+	if m != nil {
+		return m.ActiveStreams
+	}
+	return 0
+}
+
+func (m *GetTenantUsageResponse) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *GetTenantUsageResponse) GetActiveStreamsByPolicy() map[string]uint64 {
+	if m != nil {
+		return m.ActiveStreamsByPolicy
+	}
+	return nil
+}
+
+func (m *GetTenantUsageResponse) GetTopStreams() []*StreamUsage {
+	if m != nil {
+		return m.TopStreams
+	}
+	return nil
+}
+
+func (m *GetTenantUsageResponse) GetChurn() []*StreamChurn {
+	if m != nil {
+		return m.Churn
+	}
+	return nil
+}
+
+type StreamUsage struct {
+	StreamHash uint64 `protobuf:"varint,1,opt,name=streamHash,proto3" json:"streamHash,omitempty"`
+	Policy     string `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	// The ingestion rate in bytes per second over the rate window.
+	Rate       float64 `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	LastSeenAt int64   `protobuf:"varint,4,opt,name=lastSeenAt,proto3" json:"lastSeenAt,omitempty"`
+}
+
+func (m *StreamUsage) Reset()      { *m = StreamUsage{} }
+func (*StreamUsage) ProtoMessage() {}
+func (*StreamUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aaed9e7d5298ac0f, []int{7}
+}
+func (m *StreamUsage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamUsage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamUsage.Merge(m, src)
+}
+func (m *StreamUsage) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamUsage proto.InternalMessageInfo
+
+func (m *StreamUsage) GetStreamHash() uint64 {
+	if m != nil {
+		return m.StreamHash
+	}
+	return 0
+}
+
+func (m *StreamUsage) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+func (m *StreamUsage) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *StreamUsage) GetLastSeenAt() int64 {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return 0
+}
+
+type StreamChurn struct {
+	// The start of the interval.
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The number of streams seen for the first time during the interval.
+	Created uint64 `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	// The number of streams evicted during the interval.
+	Evicted uint64 `protobuf:"varint,3,opt,name=evicted,proto3" json:"evicted,omitempty"`
+}
+
+func (m *StreamChurn) Reset()      { *m = StreamChurn{} }
+func (*StreamChurn) ProtoMessage() {}
+func (*StreamChurn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aaed9e7d5298ac0f, []int{8}
+}
+func (m *StreamChurn) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamChurn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamChurn.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamChurn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamChurn.Merge(m, src)
+}
+func (m *StreamChurn) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamChurn) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamChurn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamChurn proto.InternalMessageInfo
+
+func (m *StreamChurn) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *StreamChurn) GetCreated() uint64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *StreamChurn) GetEvicted() uint64 {
+	if m != nil {
+		return m.Evicted
+	}
+	return 0
+}
+
 type StreamMetadata struct {
 	StreamHash uint64 `protobuf:"varint,1,opt,name=streamHash,proto3" json:"streamHash,omitempty"`
 	TotalSize  uint64 `protobuf:"varint,2,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
+	Policy     string `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
 }
 
 func (m *StreamMetadata) Reset()      { *m = StreamMetadata{} }
 func (*StreamMetadata) ProtoMessage() {}
 func (*StreamMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aaed9e7d5298ac0f, []int{5}
+	return fileDescriptor_aaed9e7d5298ac0f, []int{9}
 }
 func (m *StreamMetadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -303,6 +572,13 @@ func (m *StreamMetadata) GetTotalSize() uint64 {
 	return 0
 }
 
+func (m *StreamMetadata) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
 type StreamMetadataRecord struct {
 	Zone     string          `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
 	Tenant   string          `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
@@ -312,7 +588,7 @@ type StreamMetadataRecord struct {
 func (m *StreamMetadataRecord) Reset()      { *m = StreamMetadataRecord{} }
 func (*StreamMetadataRecord) ProtoMessage() {}
 func (*StreamMetadataRecord) Descriptor() ([]byte, []int) {
-	return fileDescriptor_aaed9e7d5298ac0f, []int{6}
+	return fileDescriptor_aaed9e7d5298ac0f, []int{10}
 }
 func (m *StreamMetadataRecord) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -369,6 +645,11 @@ func init() {
 	proto.RegisterType((*GetAssignedPartitionsRequest)(nil), "proto.GetAssignedPartitionsRequest")
 	proto.RegisterType((*GetAssignedPartitionsResponse)(nil), "proto.GetAssignedPartitionsResponse")
 	proto.RegisterMapType((map[int32]int64)(nil), "proto.GetAssignedPartitionsResponse.AssignedPartitionsEntry")
+	proto.RegisterType((*GetTenantUsageRequest)(nil), "proto.GetTenantUsageRequest")
+	proto.RegisterType((*GetTenantUsageResponse)(nil), "proto.GetTenantUsageResponse")
+	proto.RegisterMapType((map[string]uint64)(nil), "proto.GetTenantUsageResponse.ActiveStreamsByPolicyEntry")
+	proto.RegisterType((*StreamUsage)(nil), "proto.StreamUsage")
+	proto.RegisterType((*StreamChurn)(nil), "proto.StreamChurn")
 	proto.RegisterType((*StreamMetadata)(nil), "proto.StreamMetadata")
 	proto.RegisterType((*StreamMetadataRecord)(nil), "proto.StreamMetadataRecord")
 }
@@ -376,38 +657,53 @@ func init() {
 func init() { proto.RegisterFile("pkg/limits/proto/limits.proto", fileDescriptor_aaed9e7d5298ac0f) }
 
 var fileDescriptor_aaed9e7d5298ac0f = []byte{
-	// 487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0xbf, 0x6e, 0x13, 0x41,
-	0x10, 0xc6, 0x6f, 0xed, 0x38, 0x21, 0x03, 0x41, 0x68, 0xb1, 0xe1, 0x64, 0x9c, 0x95, 0x75, 0x50,
-	0xb8, 0xb2, 0x85, 0x49, 0x81, 0x10, 0x0d, 0x48, 0xe6, 0x8f, 0x14, 0x23, 0xb4, 0x79, 0x00, 0xb4,
-	0xe4, 0x46, 0xe6, 0x94, 0xf3, 0x9e, 0xb9, 0x9d, 0x43, 0x24, 0x15, 0x8f, 0xc0, 0x63, 0xf0, 0x1c,
-	0x54, 0x94, 0x2e, 0x28, 0x52, 0xe2, 0x73, 0x43, 0x99, 0x47, 0x40, 0xd9, 0x5b, 0x83, 0xed, 0xd8,
-	0x81, 0x82, 0xca, 0x3b, 0x3b, 0x9f, 0xbf, 0xb9, 0x6f, 0xe7, 0x07, 0xbb, 0xa3, 0xa3, 0x41, 0x27,
-	0x8e, 0x86, 0x11, 0x99, 0xce, 0x28, 0x4d, 0x28, 0x71, 0x45, 0xdb, 0x16, 0xbc, 0x62, 0x7f, 0x82,
-	0x37, 0x50, 0xed, 0x7d, 0x3c, 0x44, 0x0c, 0xcd, 0xbe, 0xed, 0x4a, 0x7c, 0x9f, 0xa1, 0x21, 0x7e,
-	0x0b, 0x36, 0x09, 0xb5, 0xd2, 0xe4, 0xb3, 0x26, 0x6b, 0x6d, 0x4b, 0x57, 0xf1, 0x0e, 0x6c, 0x19,
-	0x4a, 0x51, 0x0d, 0x8d, 0x5f, 0x6a, 0x96, 0x5b, 0x57, 0xbb, 0xb5, 0xc2, 0xaf, 0x7d, 0x60, 0x6f,
-	0xfb, 0x48, 0x2a, 0x54, 0xa4, 0xe4, 0x4c, 0x15, 0xf4, 0xa1, 0xb6, 0x34, 0xc0, 0x8c, 0x12, 0x6d,
-	0x90, 0xef, 0xc1, 0x56, 0x8a, 0x26, 0x8b, 0xc9, 0xf8, 0xcc, 0x3a, 0xd5, 0x9d, 0xd3, 0xb2, 0x3c,
-	0x8b, 0x49, 0xce, 0xa4, 0x41, 0x1f, 0x6e, 0xae, 0xe8, 0x73, 0x01, 0x50, 0x0c, 0x7c, 0xa1, 0xcc,
-	0x3b, 0xfb, 0xc9, 0x1b, 0x72, 0xee, 0xe6, 0x3c, 0x4e, 0x8a, 0xca, 0x24, 0xda, 0x2f, 0x35, 0x59,
-	0x6b, 0x47, 0xba, 0x2a, 0x10, 0xd0, 0x78, 0x8e, 0xf4, 0xc4, 0x98, 0x68, 0xa0, 0x31, 0x7c, 0xad,
-	0x52, 0x8a, 0x28, 0x4a, 0xf4, 0xec, 0x19, 0x82, 0xef, 0x0c, 0x76, 0xd7, 0x08, 0x5c, 0x8c, 0x18,
-	0xb8, 0xba, 0xd0, 0x75, 0x89, 0x1e, 0xbb, 0x44, 0x97, 0x3a, 0xb4, 0x2f, 0xb6, 0x7a, 0x9a, 0xd2,
-	0x63, 0xb9, 0xc2, 0xb7, 0xde, 0x83, 0xdb, 0x6b, 0xe4, 0xfc, 0x06, 0x94, 0x8f, 0xf0, 0xd8, 0x66,
-	0xaf, 0xc8, 0xf3, 0x23, 0xaf, 0x42, 0xe5, 0x83, 0x8a, 0x33, 0xb4, 0x99, 0xcb, 0xb2, 0x28, 0x1e,
-	0x95, 0x1e, 0xb2, 0xe0, 0x15, 0x5c, 0x5f, 0xdc, 0xd7, 0x5f, 0x1f, 0xb0, 0x01, 0xdb, 0x94, 0x90,
-	0x8a, 0x0f, 0xa2, 0x93, 0xc2, 0x6f, 0x43, 0xfe, 0xb9, 0x08, 0x32, 0xa8, 0x2e, 0xed, 0x1f, 0x0f,
-	0x93, 0x34, 0xe4, 0x1c, 0x36, 0x4e, 0x12, 0x8d, 0x8e, 0x21, 0x7b, 0x9e, 0x23, 0xab, 0xb4, 0x40,
-	0xd6, 0x7d, 0xb8, 0x32, 0x74, 0xff, 0xf6, 0xcb, 0x4d, 0xb6, 0x1e, 0xad, 0xdf, 0xb2, 0x6e, 0x08,
-	0xd5, 0x97, 0x7a, 0x80, 0x86, 0x0a, 0x16, 0x9e, 0xa5, 0x89, 0x26, 0xd4, 0x21, 0xdf, 0x87, 0x9d,
-	0x05, 0x48, 0xf8, 0x9d, 0xd5, 0x68, 0xd9, 0x1d, 0xd7, 0x1b, 0x6b, 0xb8, 0xb3, 0xdb, 0x09, 0xbc,
-	0xee, 0x57, 0x06, 0xd7, 0xe6, 0xc7, 0xfc, 0x5f, 0x7b, 0x1e, 0x42, 0x6d, 0x25, 0x1f, 0xfc, 0xee,
-	0xe5, 0xf4, 0x14, 0xee, 0xf7, 0xfe, 0x05, 0xb1, 0xc0, 0x7b, 0xba, 0x37, 0x9e, 0x08, 0xef, 0x74,
-	0x22, 0xbc, 0xb3, 0x89, 0x60, 0x9f, 0x72, 0xc1, 0xbe, 0xe4, 0x82, 0x7d, 0xcb, 0x05, 0x1b, 0xe7,
-	0x82, 0xfd, 0xc8, 0x05, 0xfb, 0x99, 0x0b, 0xef, 0x2c, 0x17, 0xec, 0xf3, 0x54, 0x78, 0xe3, 0xa9,
-	0xf0, 0x4e, 0xa7, 0xc2, 0x7b, 0xbb, 0x69, 0xcd, 0x1f, 0xfc, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x36,
-	0x1b, 0xa0, 0x70, 0x4c, 0x04, 0x00, 0x00,
+	// 730 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0xbd, 0x4e, 0x1b, 0x4b,
+	0x14, 0xde, 0xf1, 0xda, 0x70, 0x39, 0xbe, 0xa0, 0xab, 0xb9, 0x36, 0xb1, 0x1c, 0x33, 0xb2, 0x36,
+	0x14, 0xae, 0x8c, 0xe2, 0x50, 0xa0, 0x28, 0x0d, 0x44, 0x04, 0x22, 0x81, 0x82, 0xd6, 0x49, 0x8d,
+	0x26, 0xf6, 0xc4, 0xac, 0x58, 0xcf, 0x3a, 0x3b, 0x63, 0x14, 0x53, 0xe5, 0x11, 0x22, 0xe5, 0x25,
+	0xf2, 0x02, 0x79, 0x87, 0x94, 0x14, 0x29, 0x28, 0xc3, 0xd2, 0x44, 0xa9, 0x78, 0x84, 0x68, 0x67,
+	0xd6, 0x78, 0xd6, 0xac, 0x81, 0x22, 0xa9, 0xbc, 0xe7, 0xc7, 0xdf, 0x39, 0xdf, 0x77, 0xe6, 0x1c,
+	0x58, 0x19, 0x1c, 0xf7, 0xd6, 0x7c, 0xaf, 0xef, 0x49, 0xb1, 0x36, 0x08, 0x03, 0x19, 0x24, 0x46,
+	0x53, 0x19, 0xb8, 0xa0, 0x7e, 0x9c, 0x43, 0x28, 0x6d, 0x7f, 0xe8, 0x30, 0xd6, 0x15, 0x7b, 0x2a,
+	0xea, 0xb2, 0xf7, 0x43, 0x26, 0x24, 0x5e, 0x86, 0x39, 0xc9, 0x38, 0xe5, 0xb2, 0x82, 0xea, 0xa8,
+	0xb1, 0xe0, 0x26, 0x16, 0x5e, 0x83, 0x79, 0x21, 0x43, 0x46, 0xfb, 0xa2, 0x92, 0xab, 0xdb, 0x8d,
+	0x62, 0xab, 0xac, 0xf1, 0x9a, 0x6d, 0xe5, 0xdd, 0x67, 0x92, 0x76, 0xa9, 0xa4, 0xee, 0x38, 0xcb,
+	0xd9, 0x87, 0xf2, 0x54, 0x01, 0x31, 0x08, 0xb8, 0x60, 0x78, 0x1d, 0xe6, 0x43, 0x26, 0x86, 0xbe,
+	0x14, 0x15, 0xa4, 0x90, 0xaa, 0x09, 0xd2, 0x74, 0xfa, 0xd0, 0x97, 0xee, 0x38, 0xd5, 0xd9, 0x87,
+	0xff, 0x33, 0xe2, 0x98, 0x00, 0xe8, 0x82, 0xbb, 0x54, 0x1c, 0xa9, 0x96, 0xf3, 0xae, 0xe1, 0x89,
+	0xe9, 0x84, 0x8c, 0x8a, 0x80, 0x57, 0x72, 0x75, 0xd4, 0x58, 0x74, 0x13, 0xcb, 0x21, 0x50, 0xdb,
+	0x61, 0x72, 0x53, 0x08, 0xaf, 0xc7, 0x59, 0xf7, 0x80, 0x86, 0xd2, 0x93, 0x5e, 0xc0, 0xc7, 0x32,
+	0x38, 0xdf, 0x11, 0xac, 0xcc, 0x48, 0x48, 0x68, 0xf8, 0x80, 0xe9, 0x8d, 0x68, 0xc2, 0xe8, 0x59,
+	0xc2, 0xe8, 0x56, 0x84, 0xe6, 0xcd, 0xd0, 0x36, 0x97, 0xe1, 0xc8, 0xcd, 0xc0, 0xad, 0x6e, 0xc3,
+	0x83, 0x19, 0xe9, 0xf8, 0x3f, 0xb0, 0x8f, 0xd9, 0x48, 0x71, 0x2f, 0xb8, 0xf1, 0x27, 0x2e, 0x41,
+	0xe1, 0x84, 0xfa, 0x43, 0xa6, 0x38, 0xdb, 0xae, 0x36, 0x9e, 0xe6, 0x36, 0x90, 0x13, 0x40, 0x79,
+	0x87, 0xc9, 0xd7, 0x6a, 0xa4, 0x6f, 0x04, 0xed, 0xb1, 0xbb, 0xc6, 0x4e, 0x00, 0x64, 0x30, 0x68,
+	0x5f, 0x4f, 0x3e, 0xd6, 0xd0, 0xf0, 0xc4, 0xf1, 0xc1, 0x84, 0xbd, 0x5d, 0xb7, 0x1b, 0x05, 0xd7,
+	0xf0, 0x38, 0xbf, 0x72, 0xb0, 0x3c, 0x5d, 0x31, 0x11, 0x70, 0x15, 0x16, 0x69, 0x47, 0x7a, 0x27,
+	0x6c, 0x8c, 0xae, 0xa7, 0x97, 0x76, 0x62, 0x0c, 0xf9, 0x90, 0x4a, 0x4d, 0x05, 0xb9, 0xea, 0x1b,
+	0x73, 0x28, 0xa7, 0x92, 0xb6, 0x46, 0x07, 0x81, 0xef, 0x75, 0x46, 0xaa, 0x7e, 0xb1, 0xb5, 0x31,
+	0x51, 0x3f, 0xa3, 0x6e, 0x73, 0x33, 0xeb, 0xaf, 0x5a, 0xf9, 0x6c, 0x58, 0xdc, 0x4a, 0x89, 0x90,
+	0x57, 0x45, 0x70, 0xea, 0xf9, 0xeb, 0x0a, 0xa6, 0x30, 0x0d, 0x28, 0x74, 0x8e, 0x86, 0x21, 0xaf,
+	0x14, 0x32, 0xd2, 0x9f, 0xc7, 0x11, 0x57, 0x27, 0x54, 0x77, 0xa1, 0x3a, 0xbb, 0x25, 0x73, 0xba,
+	0x0b, 0x19, 0xd3, 0xcd, 0x9b, 0xd3, 0x1d, 0x41, 0xd1, 0x68, 0xe7, 0x3e, 0xbb, 0x31, 0xd0, 0xba,
+	0xe5, 0xf4, 0xcc, 0xb5, 0x75, 0x2d, 0xb9, 0x6d, 0x48, 0x4e, 0x00, 0x7c, 0x2a, 0x64, 0x9b, 0x31,
+	0xbe, 0x29, 0x2b, 0x79, 0xf5, 0xae, 0x0c, 0x8f, 0x73, 0x08, 0x45, 0x83, 0x1a, 0xae, 0xc1, 0x82,
+	0xf4, 0xfa, 0x4c, 0x48, 0xda, 0x1f, 0xa8, 0xca, 0xb6, 0x3b, 0x71, 0xe0, 0x0a, 0xcc, 0x77, 0x42,
+	0x46, 0x25, 0xeb, 0x26, 0x1c, 0xc6, 0x66, 0x1c, 0x61, 0x27, 0x5e, 0x27, 0x8e, 0xd8, 0x3a, 0x92,
+	0x98, 0xce, 0x3b, 0x58, 0x4a, 0x5f, 0x9a, 0x3b, 0xe9, 0xc5, 0x3d, 0x04, 0x92, 0xfa, 0x6d, 0xef,
+	0x74, 0xac, 0xd5, 0xc4, 0x61, 0x90, 0xb7, 0x4d, 0xf2, 0xce, 0x10, 0x4a, 0x53, 0x17, 0x8d, 0x75,
+	0x82, 0xb0, 0x1b, 0x8b, 0x72, 0x1a, 0x70, 0x96, 0x0c, 0x42, 0x7d, 0x1b, 0x4b, 0x93, 0x4b, 0x2d,
+	0xcd, 0x63, 0xf8, 0xa7, 0x9f, 0xfc, 0x5b, 0xa1, 0xcf, 0x3c, 0x96, 0xd7, 0x69, 0xad, 0xaf, 0x08,
+	0x4a, 0x2f, 0x79, 0x8f, 0x09, 0xa9, 0xcf, 0xdb, 0x8b, 0x30, 0xe0, 0x92, 0xf1, 0x2e, 0xde, 0x83,
+	0xc5, 0xd4, 0xdd, 0xc3, 0x0f, 0xb3, 0xaf, 0xa5, 0x5a, 0xe3, 0x6a, 0x2d, 0x3b, 0xa8, 0x5f, 0xbe,
+	0x63, 0xe1, 0x57, 0xb0, 0x94, 0xde, 0x0a, 0x5c, 0x9b, 0xb1, 0x2c, 0x1a, 0x6f, 0xe5, 0xd6, 0x55,
+	0x72, 0xac, 0xd6, 0xe7, 0x1c, 0xfc, 0x6b, 0xf6, 0xfd, 0x87, 0xfb, 0xed, 0xaa, 0x7b, 0x75, 0xf3,
+	0xf2, 0xe1, 0x47, 0xb7, 0x5f, 0x58, 0x8d, 0xbe, 0x7a, 0x9f, 0x33, 0xfc, 0x17, 0x54, 0xd9, 0x5a,
+	0x3f, 0xbb, 0x20, 0xd6, 0xf9, 0x05, 0xb1, 0xae, 0x2e, 0x08, 0xfa, 0x18, 0x11, 0xf4, 0x25, 0x22,
+	0xe8, 0x5b, 0x44, 0xd0, 0x59, 0x44, 0xd0, 0x8f, 0x88, 0xa0, 0x9f, 0x11, 0xb1, 0xae, 0x22, 0x82,
+	0x3e, 0x5d, 0x12, 0xeb, 0xec, 0x92, 0x58, 0xe7, 0x97, 0xc4, 0x7a, 0x3b, 0xa7, 0x50, 0x9f, 0xfc,
+	0x1e, 0x00, 0x78, 0x41, 0xff, 0xd7, 0xc1, 0x07, 0x00, 0x00,
 }
 
 func (this *ExceedsLimitsRequest) Equal(that interface{}) bool {
@@ -548,14 +844,14 @@ func (this *GetAssignedPartitionsResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *StreamMetadata) Equal(that interface{}) bool {
+func (this *GetTenantUsageRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*StreamMetadata)
+	that1, ok := that.(*GetTenantUsageRequest)
 	if !ok {
-		that2, ok := that.(StreamMetadata)
+		that2, ok := that.(GetTenantUsageRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -567,22 +863,30 @@ func (this *StreamMetadata) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.StreamHash != that1.StreamHash {
+	if this.Tenant != that1.Tenant {
 		return false
 	}
-	if this.TotalSize != that1.TotalSize {
+	if this.TopStreams != that1.TopStreams {
+		return false
+	}
+	if len(this.Partitions) != len(that1.Partitions) {
 		return false
 	}
+	for i := range this.Partitions {
+		if this.Partitions[i] != that1.Partitions[i] {
+			return false
+		}
+	}
 	return true
 }
-func (this *StreamMetadataRecord) Equal(that interface{}) bool {
+func (this *GetTenantUsageResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*StreamMetadataRecord)
+	that1, ok := that.(*GetTenantUsageResponse)
 	if !ok {
-		that2, ok := that.(StreamMetadataRecord)
+		that2, ok := that.(GetTenantUsageResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -594,73 +898,217 @@ func (this *StreamMetadataRecord) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Zone != that1.Zone {
+	if this.ActiveStreams != that1.ActiveStreams {
 		return false
 	}
-	if this.Tenant != that1.Tenant {
+	if this.Rate != that1.Rate {
 		return false
 	}
-	if !this.Metadata.Equal(that1.Metadata) {
+	if len(this.ActiveStreamsByPolicy) != len(that1.ActiveStreamsByPolicy) {
 		return false
 	}
-	return true
-}
-func (this *ExceedsLimitsRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&proto.ExceedsLimitsRequest{")
-	s = append(s, "Tenant: "+fmt.Sprintf("%#v", this.Tenant)+",\n")
-	if this.Streams != nil {
-		s = append(s, "Streams: "+fmt.Sprintf("%#v", this.Streams)+",\n")
+	for i := range this.ActiveStreamsByPolicy {
+		if this.ActiveStreamsByPolicy[i] != that1.ActiveStreamsByPolicy[i] {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ExceedsLimitsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.TopStreams) != len(that1.TopStreams) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.ExceedsLimitsResponse{")
-	if this.Results != nil {
-		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
+	for i := range this.TopStreams {
+		if !this.TopStreams[i].Equal(that1.TopStreams[i]) {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ExceedsLimitsResult) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.Churn) != len(that1.Churn) {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&proto.ExceedsLimitsResult{")
-	s = append(s, "StreamHash: "+fmt.Sprintf("%#v", this.StreamHash)+",\n")
-	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GetAssignedPartitionsRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.Churn {
+		if !this.Churn[i].Equal(that1.Churn[i]) {
+			return false
+		}
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&proto.GetAssignedPartitionsRequest{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *GetAssignedPartitionsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *StreamUsage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.GetAssignedPartitionsResponse{")
-	keysForAssignedPartitions := make([]int32, 0, len(this.AssignedPartitions))
-	for k, _ := range this.AssignedPartitions {
-		keysForAssignedPartitions = append(keysForAssignedPartitions, k)
+
+	that1, ok := that.(*StreamUsage)
+	if !ok {
+		that2, ok := that.(StreamUsage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	github_com_gogo_protobuf_sortkeys.Int32s(keysForAssignedPartitions)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.StreamHash != that1.StreamHash {
+		return false
+	}
+	if this.Policy != that1.Policy {
+		return false
+	}
+	if this.Rate != that1.Rate {
+		return false
+	}
+	if this.LastSeenAt != that1.LastSeenAt {
+		return false
+	}
+	return true
+}
+func (this *StreamChurn) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*StreamChurn)
+	if !ok {
+		that2, ok := that.(StreamChurn)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Timestamp != that1.Timestamp {
+		return false
+	}
+	if this.Created != that1.Created {
+		return false
+	}
+	if this.Evicted != that1.Evicted {
+		return false
+	}
+	return true
+}
+func (this *StreamMetadata) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*StreamMetadata)
+	if !ok {
+		that2, ok := that.(StreamMetadata)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.StreamHash != that1.StreamHash {
+		return false
+	}
+	if this.TotalSize != that1.TotalSize {
+		return false
+	}
+	if this.Policy != that1.Policy {
+		return false
+	}
+	return true
+}
+func (this *StreamMetadataRecord) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*StreamMetadataRecord)
+	if !ok {
+		that2, ok := that.(StreamMetadataRecord)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Zone != that1.Zone {
+		return false
+	}
+	if this.Tenant != that1.Tenant {
+		return false
+	}
+	if !this.Metadata.Equal(that1.Metadata) {
+		return false
+	}
+	return true
+}
+func (this *ExceedsLimitsRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.ExceedsLimitsRequest{")
+	s = append(s, "Tenant: "+fmt.Sprintf("%#v", this.Tenant)+",\n")
+	if this.Streams != nil {
+		s = append(s, "Streams: "+fmt.Sprintf("%#v", this.Streams)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ExceedsLimitsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.ExceedsLimitsResponse{")
+	if this.Results != nil {
+		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ExceedsLimitsResult) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.ExceedsLimitsResult{")
+	s = append(s, "StreamHash: "+fmt.Sprintf("%#v", this.StreamHash)+",\n")
+	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetAssignedPartitionsRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&proto.GetAssignedPartitionsRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetAssignedPartitionsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.GetAssignedPartitionsResponse{")
+	keysForAssignedPartitions := make([]int32, 0, len(this.AssignedPartitions))
+	for k, _ := range this.AssignedPartitions {
+		keysForAssignedPartitions = append(keysForAssignedPartitions, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Int32s(keysForAssignedPartitions)
 	mapStringForAssignedPartitions := "map[int32]int64{"
 	for _, k := range keysForAssignedPartitions {
 		mapStringForAssignedPartitions += fmt.Sprintf("%#v: %#v,", k, this.AssignedPartitions[k])
@@ -672,14 +1120,82 @@ func (this *GetAssignedPartitionsResponse) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *GetTenantUsageRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.GetTenantUsageRequest{")
+	s = append(s, "Tenant: "+fmt.Sprintf("%#v", this.Tenant)+",\n")
+	s = append(s, "TopStreams: "+fmt.Sprintf("%#v", this.TopStreams)+",\n")
+	s = append(s, "Partitions: "+fmt.Sprintf("%#v", this.Partitions)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetTenantUsageResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&proto.GetTenantUsageResponse{")
+	s = append(s, "ActiveStreams: "+fmt.Sprintf("%#v", this.ActiveStreams)+",\n")
+	s = append(s, "Rate: "+fmt.Sprintf("%#v", this.Rate)+",\n")
+	keysForActiveStreamsByPolicy := make([]string, 0, len(this.ActiveStreamsByPolicy))
+	for k, _ := range this.ActiveStreamsByPolicy {
+		keysForActiveStreamsByPolicy = append(keysForActiveStreamsByPolicy, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForActiveStreamsByPolicy)
+	mapStringForActiveStreamsByPolicy := "map[string]uint64{"
+	for _, k := range keysForActiveStreamsByPolicy {
+		mapStringForActiveStreamsByPolicy += fmt.Sprintf("%#v: %#v,", k, this.ActiveStreamsByPolicy[k])
+	}
+	mapStringForActiveStreamsByPolicy += "}"
+	if this.ActiveStreamsByPolicy != nil {
+		s = append(s, "ActiveStreamsByPolicy: "+mapStringForActiveStreamsByPolicy+",\n")
+	}
+	if this.TopStreams != nil {
+		s = append(s, "TopStreams: "+fmt.Sprintf("%#v", this.TopStreams)+",\n")
+	}
+	if this.Churn != nil {
+		s = append(s, "Churn: "+fmt.Sprintf("%#v", this.Churn)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *StreamUsage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&proto.StreamUsage{")
+	s = append(s, "StreamHash: "+fmt.Sprintf("%#v", this.StreamHash)+",\n")
+	s = append(s, "Policy: "+fmt.Sprintf("%#v", this.Policy)+",\n")
+	s = append(s, "Rate: "+fmt.Sprintf("%#v", this.Rate)+",\n")
+	s = append(s, "LastSeenAt: "+fmt.Sprintf("%#v", this.LastSeenAt)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *StreamChurn) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.StreamChurn{")
+	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
+	s = append(s, "Created: "+fmt.Sprintf("%#v", this.Created)+",\n")
+	s = append(s, "Evicted: "+fmt.Sprintf("%#v", this.Evicted)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *StreamMetadata) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 7)
 	s = append(s, "&proto.StreamMetadata{")
 	s = append(s, "StreamHash: "+fmt.Sprintf("%#v", this.StreamHash)+",\n")
 	s = append(s, "TotalSize: "+fmt.Sprintf("%#v", this.TotalSize)+",\n")
+	s = append(s, "Policy: "+fmt.Sprintf("%#v", this.Policy)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -719,6 +1235,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type IngestLimitsFrontendClient interface {
 	ExceedsLimits(ctx context.Context, in *ExceedsLimitsRequest, opts ...grpc.CallOption) (*ExceedsLimitsResponse, error)
+	GetTenantUsage(ctx context.Context, in *GetTenantUsageRequest, opts ...grpc.CallOption) (*GetTenantUsageResponse, error)
 }
 
 type ingestLimitsFrontendClient struct {
@@ -738,9 +1255,19 @@ func (c *ingestLimitsFrontendClient) ExceedsLimits(ctx context.Context, in *Exce
 	return out, nil
 }
 
+func (c *ingestLimitsFrontendClient) GetTenantUsage(ctx context.Context, in *GetTenantUsageRequest, opts ...grpc.CallOption) (*GetTenantUsageResponse, error) {
+	out := new(GetTenantUsageResponse)
+	err := c.cc.Invoke(ctx, "/proto.IngestLimitsFrontend/GetTenantUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IngestLimitsFrontendServer is the server API for IngestLimitsFrontend service.
 type IngestLimitsFrontendServer interface {
 	ExceedsLimits(context.Context, *ExceedsLimitsRequest) (*ExceedsLimitsResponse, error)
+	GetTenantUsage(context.Context, *GetTenantUsageRequest) (*GetTenantUsageResponse, error)
 }
 
 // UnimplementedIngestLimitsFrontendServer can be embedded to have forward compatible implementations.
@@ -750,6 +1277,9 @@ type UnimplementedIngestLimitsFrontendServer struct {
 func (*UnimplementedIngestLimitsFrontendServer) ExceedsLimits(ctx context.Context, req *ExceedsLimitsRequest) (*ExceedsLimitsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExceedsLimits not implemented")
 }
+func (*UnimplementedIngestLimitsFrontendServer) GetTenantUsage(ctx context.Context, req *GetTenantUsageRequest) (*GetTenantUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenantUsage not implemented")
+}
 
 func RegisterIngestLimitsFrontendServer(s *grpc.Server, srv IngestLimitsFrontendServer) {
 	s.RegisterService(&_IngestLimitsFrontend_serviceDesc, srv)
@@ -773,6 +1303,24 @@ func _IngestLimitsFrontend_ExceedsLimits_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IngestLimitsFrontend_GetTenantUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestLimitsFrontendServer).GetTenantUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.IngestLimitsFrontend/GetTenantUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestLimitsFrontendServer).GetTenantUsage(ctx, req.(*GetTenantUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _IngestLimitsFrontend_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.IngestLimitsFrontend",
 	HandlerType: (*IngestLimitsFrontendServer)(nil),
@@ -781,6 +1329,10 @@ var _IngestLimitsFrontend_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExceedsLimits",
 			Handler:    _IngestLimitsFrontend_ExceedsLimits_Handler,
 		},
+		{
+			MethodName: "GetTenantUsage",
+			Handler:    _IngestLimitsFrontend_GetTenantUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/limits/proto/limits.proto",
@@ -792,6 +1344,7 @@ var _IngestLimitsFrontend_serviceDesc = grpc.ServiceDesc{
 type IngestLimitsClient interface {
 	ExceedsLimits(ctx context.Context, in *ExceedsLimitsRequest, opts ...grpc.CallOption) (*ExceedsLimitsResponse, error)
 	GetAssignedPartitions(ctx context.Context, in *GetAssignedPartitionsRequest, opts ...grpc.CallOption) (*GetAssignedPartitionsResponse, error)
+	GetTenantUsage(ctx context.Context, in *GetTenantUsageRequest, opts ...grpc.CallOption) (*GetTenantUsageResponse, error)
 }
 
 type ingestLimitsClient struct {
@@ -820,10 +1373,20 @@ func (c *ingestLimitsClient) GetAssignedPartitions(ctx context.Context, in *GetA
 	return out, nil
 }
 
+func (c *ingestLimitsClient) GetTenantUsage(ctx context.Context, in *GetTenantUsageRequest, opts ...grpc.CallOption) (*GetTenantUsageResponse, error) {
+	out := new(GetTenantUsageResponse)
+	err := c.cc.Invoke(ctx, "/proto.IngestLimits/GetTenantUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IngestLimitsServer is the server API for IngestLimits service.
 type IngestLimitsServer interface {
 	ExceedsLimits(context.Context, *ExceedsLimitsRequest) (*ExceedsLimitsResponse, error)
 	GetAssignedPartitions(context.Context, *GetAssignedPartitionsRequest) (*GetAssignedPartitionsResponse, error)
+	GetTenantUsage(context.Context, *GetTenantUsageRequest) (*GetTenantUsageResponse, error)
 }
 
 // UnimplementedIngestLimitsServer can be embedded to have forward compatible implementations.
@@ -836,6 +1399,9 @@ func (*UnimplementedIngestLimitsServer) ExceedsLimits(ctx context.Context, req *
 func (*UnimplementedIngestLimitsServer) GetAssignedPartitions(ctx context.Context, req *GetAssignedPartitionsRequest) (*GetAssignedPartitionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAssignedPartitions not implemented")
 }
+func (*UnimplementedIngestLimitsServer) GetTenantUsage(ctx context.Context, req *GetTenantUsageRequest) (*GetTenantUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenantUsage not implemented")
+}
 
 func RegisterIngestLimitsServer(s *grpc.Server, srv IngestLimitsServer) {
 	s.RegisterService(&_IngestLimits_serviceDesc, srv)
@@ -877,6 +1443,24 @@ func _IngestLimits_GetAssignedPartitions_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IngestLimits_GetTenantUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestLimitsServer).GetTenantUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.IngestLimits/GetTenantUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestLimitsServer).GetTenantUsage(ctx, req.(*GetTenantUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _IngestLimits_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.IngestLimits",
 	HandlerType: (*IngestLimitsServer)(nil),
@@ -889,6 +1473,10 @@ var _IngestLimits_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetAssignedPartitions",
 			Handler:    _IngestLimits_GetAssignedPartitions_Handler,
 		},
+		{
+			MethodName: "GetTenantUsage",
+			Handler:    _IngestLimits_GetTenantUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/limits/proto/limits.proto",
@@ -1069,7 +1657,7 @@ func (m *GetAssignedPartitionsResponse) MarshalToSizedBuffer(dAtA []byte) (int,
 	return len(dAtA) - i, nil
 }
 
-func (m *StreamMetadata) Marshal() (dAtA []byte, err error) {
+func (m *GetTenantUsageRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1079,30 +1667,51 @@ func (m *StreamMetadata) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StreamMetadata) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetTenantUsageRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *StreamMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetTenantUsageRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.TotalSize != 0 {
-		i = encodeVarintLimits(dAtA, i, uint64(m.TotalSize))
+	if len(m.Partitions) > 0 {
+		dAtA2 := make([]byte, len(m.Partitions)*10)
+		var j1 int
+		for _, num1 := range m.Partitions {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintLimits(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.TopStreams != 0 {
+		i = encodeVarintLimits(dAtA, i, uint64(m.TopStreams))
 		i--
 		dAtA[i] = 0x10
 	}
-	if m.StreamHash != 0 {
-		i = encodeVarintLimits(dAtA, i, uint64(m.StreamHash))
+	if len(m.Tenant) > 0 {
+		i -= len(m.Tenant)
+		copy(dAtA[i:], m.Tenant)
+		i = encodeVarintLimits(dAtA, i, uint64(len(m.Tenant)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *StreamMetadataRecord) Marshal() (dAtA []byte, err error) {
+func (m *GetTenantUsageResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1112,18 +1721,221 @@ func (m *StreamMetadataRecord) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StreamMetadataRecord) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetTenantUsageResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *StreamMetadataRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetTenantUsageResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Metadata != nil {
-		{
+	if len(m.Churn) > 0 {
+		for iNdEx := len(m.Churn) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Churn[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLimits(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.TopStreams) > 0 {
+		for iNdEx := len(m.TopStreams) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TopStreams[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLimits(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.ActiveStreamsByPolicy) > 0 {
+		for k := range m.ActiveStreamsByPolicy {
+			v := m.ActiveStreamsByPolicy[k]
+			baseI := i
+			i = encodeVarintLimits(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLimits(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLimits(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Rate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Rate))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.ActiveStreams != 0 {
+		i = encodeVarintLimits(dAtA, i, uint64(m.ActiveStreams))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastSeenAt != 0 {
+		i = encodeVarintLimits(dAtA, i, uint64(m.LastSeenAt))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Rate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Rate))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if len(m.Policy) > 0 {
+		i -= len(m.Policy)
+		copy(dAtA[i:], m.Policy)
+		i = encodeVarintLimits(dAtA, i, uint64(len(m.Policy)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StreamHash != 0 {
+		i = encodeVarintLimits(dAtA, i, uint64(m.StreamHash))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamChurn) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamChurn) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamChurn) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Evicted != 0 {
+		i = encodeVarintLimits(dAtA, i, uint64(m.Evicted))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Created != 0 {
+		i = encodeVarintLimits(dAtA, i, uint64(m.Created))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintLimits(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamMetadata) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Policy) > 0 {
+		i -= len(m.Policy)
+		copy(dAtA[i:], m.Policy)
+		i = encodeVarintLimits(dAtA, i, uint64(len(m.Policy)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.TotalSize != 0 {
+		i = encodeVarintLimits(dAtA, i, uint64(m.TotalSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StreamHash != 0 {
+		i = encodeVarintLimits(dAtA, i, uint64(m.StreamHash))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamMetadataRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamMetadataRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamMetadataRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		{
 			size, err := m.Metadata.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
@@ -1237,6 +2049,104 @@ func (m *GetAssignedPartitionsResponse) Size() (n int) {
 	return n
 }
 
+func (m *GetTenantUsageRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Tenant)
+	if l > 0 {
+		n += 1 + l + sovLimits(uint64(l))
+	}
+	if m.TopStreams != 0 {
+		n += 1 + sovLimits(uint64(m.TopStreams))
+	}
+	if len(m.Partitions) > 0 {
+		l = 0
+		for _, e := range m.Partitions {
+			l += sovLimits(uint64(e))
+		}
+		n += 1 + sovLimits(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *GetTenantUsageResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ActiveStreams != 0 {
+		n += 1 + sovLimits(uint64(m.ActiveStreams))
+	}
+	if m.Rate != 0 {
+		n += 9
+	}
+	if len(m.ActiveStreamsByPolicy) > 0 {
+		for k, v := range m.ActiveStreamsByPolicy {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovLimits(uint64(len(k))) + 1 + sovLimits(uint64(v))
+			n += mapEntrySize + 1 + sovLimits(uint64(mapEntrySize))
+		}
+	}
+	if len(m.TopStreams) > 0 {
+		for _, e := range m.TopStreams {
+			l = e.Size()
+			n += 1 + l + sovLimits(uint64(l))
+		}
+	}
+	if len(m.Churn) > 0 {
+		for _, e := range m.Churn {
+			l = e.Size()
+			n += 1 + l + sovLimits(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StreamUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StreamHash != 0 {
+		n += 1 + sovLimits(uint64(m.StreamHash))
+	}
+	l = len(m.Policy)
+	if l > 0 {
+		n += 1 + l + sovLimits(uint64(l))
+	}
+	if m.Rate != 0 {
+		n += 9
+	}
+	if m.LastSeenAt != 0 {
+		n += 1 + sovLimits(uint64(m.LastSeenAt))
+	}
+	return n
+}
+
+func (m *StreamChurn) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		n += 1 + sovLimits(uint64(m.Timestamp))
+	}
+	if m.Created != 0 {
+		n += 1 + sovLimits(uint64(m.Created))
+	}
+	if m.Evicted != 0 {
+		n += 1 + sovLimits(uint64(m.Evicted))
+	}
+	return n
+}
+
 func (m *StreamMetadata) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1249,6 +2159,10 @@ func (m *StreamMetadata) Size() (n int) {
 	if m.TotalSize != 0 {
 		n += 1 + sovLimits(uint64(m.TotalSize))
 	}
+	l = len(m.Policy)
+	if l > 0 {
+		n += 1 + l + sovLimits(uint64(l))
+	}
 	return n
 }
 
@@ -1350,6 +2264,77 @@ func (this *GetAssignedPartitionsResponse) String() string {
 	}, "")
 	return s
 }
+func (this *GetTenantUsageRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetTenantUsageRequest{`,
+		`Tenant:` + fmt.Sprintf("%v", this.Tenant) + `,`,
+		`TopStreams:` + fmt.Sprintf("%v", this.TopStreams) + `,`,
+		`Partitions:` + fmt.Sprintf("%v", this.Partitions) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetTenantUsageResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTopStreams := "[]*StreamUsage{"
+	for _, f := range this.TopStreams {
+		repeatedStringForTopStreams += strings.Replace(f.String(), "StreamUsage", "StreamUsage", 1) + ","
+	}
+	repeatedStringForTopStreams += "}"
+	repeatedStringForChurn := "[]*StreamChurn{"
+	for _, f := range this.Churn {
+		repeatedStringForChurn += strings.Replace(f.String(), "StreamChurn", "StreamChurn", 1) + ","
+	}
+	repeatedStringForChurn += "}"
+	keysForActiveStreamsByPolicy := make([]string, 0, len(this.ActiveStreamsByPolicy))
+	for k, _ := range this.ActiveStreamsByPolicy {
+		keysForActiveStreamsByPolicy = append(keysForActiveStreamsByPolicy, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForActiveStreamsByPolicy)
+	mapStringForActiveStreamsByPolicy := "map[string]uint64{"
+	for _, k := range keysForActiveStreamsByPolicy {
+		mapStringForActiveStreamsByPolicy += fmt.Sprintf("%v: %v,", k, this.ActiveStreamsByPolicy[k])
+	}
+	mapStringForActiveStreamsByPolicy += "}"
+	s := strings.Join([]string{`&GetTenantUsageResponse{`,
+		`ActiveStreams:` + fmt.Sprintf("%v", this.ActiveStreams) + `,`,
+		`Rate:` + fmt.Sprintf("%v", this.Rate) + `,`,
+		`ActiveStreamsByPolicy:` + mapStringForActiveStreamsByPolicy + `,`,
+		`TopStreams:` + repeatedStringForTopStreams + `,`,
+		`Churn:` + repeatedStringForChurn + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StreamUsage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StreamUsage{`,
+		`StreamHash:` + fmt.Sprintf("%v", this.StreamHash) + `,`,
+		`Policy:` + fmt.Sprintf("%v", this.Policy) + `,`,
+		`Rate:` + fmt.Sprintf("%v", this.Rate) + `,`,
+		`LastSeenAt:` + fmt.Sprintf("%v", this.LastSeenAt) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StreamChurn) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StreamChurn{`,
+		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
+		`Created:` + fmt.Sprintf("%v", this.Created) + `,`,
+		`Evicted:` + fmt.Sprintf("%v", this.Evicted) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *StreamMetadata) String() string {
 	if this == nil {
 		return "nil"
@@ -1357,6 +2342,7 @@ func (this *StreamMetadata) String() string {
 	s := strings.Join([]string{`&StreamMetadata{`,
 		`StreamHash:` + fmt.Sprintf("%v", this.StreamHash) + `,`,
 		`TotalSize:` + fmt.Sprintf("%v", this.TotalSize) + `,`,
+		`Policy:` + fmt.Sprintf("%v", this.Policy) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1883,7 +2869,7 @@ func (m *GetAssignedPartitionsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StreamMetadata) Unmarshal(dAtA []byte) error {
+func (m *GetTenantUsageRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1906,7 +2892,695 @@ func (m *StreamMetadata) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StreamMetadata: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetTenantUsageRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetTenantUsageRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tenant", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLimits
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tenant = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TopStreams", wireType)
+			}
+			m.TopStreams = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TopStreams |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLimits
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Partitions = append(m.Partitions, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLimits
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthLimits
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthLimits
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Partitions) == 0 {
+					m.Partitions = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLimits
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Partitions = append(m.Partitions, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partitions", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLimits(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetTenantUsageResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLimits
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetTenantUsageResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetTenantUsageResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveStreams", wireType)
+			}
+			m.ActiveStreams = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActiveStreams |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rate", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Rate = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveStreamsByPolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLimits
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActiveStreamsByPolicy == nil {
+				m.ActiveStreamsByPolicy = make(map[string]uint64)
+			}
+			var mapkey string
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLimits
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLimits
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthLimits
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthLimits
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLimits
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipLimits(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthLimits
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ActiveStreamsByPolicy[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TopStreams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLimits
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TopStreams = append(m.TopStreams, &StreamUsage{})
+			if err := m.TopStreams[len(m.TopStreams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Churn", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLimits
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Churn = append(m.Churn, &StreamChurn{})
+			if err := m.Churn[len(m.Churn)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLimits(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLimits
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamHash", wireType)
+			}
+			m.StreamHash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StreamHash |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLimits
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Policy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rate", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Rate = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSeenAt", wireType)
+			}
+			m.LastSeenAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSeenAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLimits(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamChurn) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLimits
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamChurn: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamChurn: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			m.Created = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Created |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evicted", wireType)
+			}
+			m.Evicted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Evicted |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLimits(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLimits
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamMetadata: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
 			return fmt.Errorf("proto: StreamMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
@@ -1950,6 +3624,38 @@ func (m *StreamMetadata) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLimits
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLimits
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLimits
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Policy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLimits(dAtA[iNdEx:])
@@ -11,6 +11,16 @@ const (
 	// ReasonMaxStreams is returned when a stream cannot be accepted because
 	// the tenant has either reached or exceeded their maximum stream limit.
 	ReasonMaxStreams
+	// ReasonRateLimited is returned when a stream cannot be accepted because
+	// the tenant has exceeded their ingestion rate limit.
+	ReasonRateLimited
+	// ReasonStreamRateLimited is returned when a stream cannot be accepted
+	// because it has exceeded the per-stream rate limit.
+	ReasonStreamRateLimited
+	// ReasonPolicyRateLimited is returned when a stream cannot be accepted
+	// because the tenant has exceeded the ingestion rate limit of the policy
+	// of the stream.
+	ReasonPolicyRateLimited
 )
 
 func (r Reason) String() string {
@@ -19,6 +29,12 @@ func (r Reason) String() string {
 		return "failed"
 	case ReasonMaxStreams:
 		return "max streams"
+	case ReasonRateLimited:
+		return "rate limited"
+	case ReasonStreamRateLimited:
+		return "per stream rate limited"
+	case ReasonPolicyRateLimited:
+		return "policy rate limited"
 	default:
 		return "unknown reason"
 	}
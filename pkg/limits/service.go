@@ -86,6 +86,7 @@ func New(cfg Config, limits Limits, logger log.Logger, reg prometheus.Registerer
 	if err != nil {
 		return nil, fmt.Errorf("failed to create usage store: %w", err)
 	}
+	s.usage.rateLimitsEnabled = cfg.RateLimitsEnabled
 	// Initialize lifecycler
 	s.lifecycler, err = ring.NewLifecycler(cfg.LifecyclerConfig, s, RingName, RingKey, true, logger, reg)
 	if err != nil {
@@ -180,6 +181,15 @@ func (s *Service) GetAssignedPartitions(
 	return &resp, nil
 }
 
+// GetTenantUsage implements the [proto.IngestLimitsServer] interface.
+// It returns the usage of the tenant for the requested partitions.
+func (s *Service) GetTenantUsage(
+	_ context.Context,
+	req *proto.GetTenantUsageRequest,
+) (*proto.GetTenantUsageResponse, error) {
+	return s.usage.TenantUsage(req.Tenant, req.Partitions, int(req.TopStreams)), nil
+}
+
 // ExceedsLimits implements the proto.IngestLimitsServer interface.
 func (s *Service) ExceedsLimits(
 	ctx context.Context,
@@ -1,16 +1,21 @@
 package limits
 
 import (
+	"cmp"
+	"container/heap"
 	"errors"
 	"fmt"
 	"hash/fnv"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/coder/quartz"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
 
 	"github.com/grafana/loki/v3/pkg/limits/proto"
+	"github.com/grafana/loki/v3/pkg/validation"
 )
 
 // The number of stripe locks.
@@ -48,6 +53,20 @@ type usageStore struct {
 	stripes       []map[string]tenantUsage
 	locks         []stripeLock
 
+	// churn contains the number of created and evicted streams per tenant
+	// and partition over the active window. It is guarded by the same stripe
+	// locks as stripes.
+	churn           []map[string]map[int32][]churnBucket
+	numChurnBuckets int
+
+	// rateLimitsEnabled enables the enforcement of the ingestion rate
+	// limits in UpdateCond.
+	rateLimitsEnabled bool
+	// tenantRates contains the per-partition ingestion rate limiters of
+	// each tenant, and of each policy with a rate limit. It is guarded by
+	// the same stripe locks as stripes.
+	tenantRates []map[string]map[rateLimiterKey]*rate.Limiter
+
 	// Used for tests.
 	clock quartz.Clock
 }
@@ -60,14 +79,13 @@ type tenantUsage map[int32]map[uint64]streamUsage
 // rate limits.
 type streamUsage struct {
 	hash       uint64
+	policy     string
 	lastSeenAt int64
 	// TODO(grobinson): This is a quick fix to allow us to keep testing
 	// correctness.
 	lastProducedAt int64
-	// TODO(grobinson): Rate buckets are not used as we have decided to defer
-	// implementing rate limits to a later date in the future.
-	totalSize   uint64
-	rateBuckets []rateBucket
+	totalSize      uint64
+	rateBuckets    []rateBucket
 }
 
 // RateBucket represents the bytes received during a specific time interval
@@ -77,6 +95,14 @@ type rateBucket struct {
 	size      uint64 // bytes received during this interval
 }
 
+// churnBucket represents the number of streams created and evicted during a
+// specific time interval.
+type churnBucket struct {
+	timestamp int64 // start of the interval
+	created   uint64
+	evicted   uint64
+}
+
 type stripeLock struct {
 	sync.RWMutex
 	// Padding to avoid multiple locks being on the same cache line.
@@ -93,10 +119,17 @@ func newUsageStore(activeWindow, rateWindow, bucketSize time.Duration, numPartit
 		numPartitions: numPartitions,
 		stripes:       make([]map[string]tenantUsage, numStripes),
 		locks:         make([]stripeLock, numStripes),
+		tenantRates:   make([]map[string]map[rateLimiterKey]*rate.Limiter, numStripes),
+		churn:         make([]map[string]map[int32][]churnBucket, numStripes),
 		clock:         quartz.NewReal(),
 	}
+	// The active window is inclusive, so one more bucket is needed to
+	// cover both its start and its end.
+	s.numChurnBuckets = int(activeWindow/bucketSize) + 1
 	for i := range s.stripes {
 		s.stripes[i] = make(map[string]tenantUsage)
+		s.tenantRates[i] = make(map[string]map[rateLimiterKey]*rate.Limiter)
+		s.churn[i] = make(map[string]map[int32][]churnBucket)
 	}
 	if err := reg.Register(s); err != nil {
 		return nil, fmt.Errorf("failed to register metrics: %w", err)
@@ -193,7 +226,10 @@ func (s *usageStore) Update(tenant string, metadata *proto.StreamMetadata, seenA
 	return nil
 }
 
-func (s *usageStore) UpdateCond(tenant string, metadata []*proto.StreamMetadata, seenAt time.Time, limits Limits) ([]*proto.StreamMetadata, []*proto.StreamMetadata, []*proto.StreamMetadata, error) {
+// UpdateCond updates the streams that do not exceed the limits, and returns
+// the streams to produce, the accepted streams and the results for the
+// rejected streams.
+func (s *usageStore) UpdateCond(tenant string, metadata []*proto.StreamMetadata, seenAt time.Time, limits Limits) ([]*proto.StreamMetadata, []*proto.StreamMetadata, []*proto.ExceedsLimitsResult, error) {
 	if !s.withinActiveWindow(seenAt.UnixNano()) {
 		return nil, nil, nil, errOutsideActiveWindow
 	}
@@ -201,9 +237,15 @@ func (s *usageStore) UpdateCond(tenant string, metadata []*proto.StreamMetadata,
 		now        = s.clock.Now()
 		toProduce  = make([]*proto.StreamMetadata, 0, len(metadata))
 		accepted   = make([]*proto.StreamMetadata, 0, len(metadata))
-		rejected   = make([]*proto.StreamMetadata, 0, len(metadata))
+		rejected   = make([]*proto.ExceedsLimitsResult, 0, len(metadata))
 		cutoff     = seenAt.Add(-s.activeWindow).UnixNano()
 		maxStreams = uint64(limits.MaxGlobalStreamsPerUser(tenant) / s.numPartitions)
+		// The tenant rate is shared between all partitions, as streams are
+		// evenly distributed over partitions. Like in the global ingestion
+		// rate strategy of distributors, the burst is not shared.
+		tenantRate  = rate.Limit(limits.IngestionRateBytes(tenant) / float64(s.numPartitions))
+		tenantBurst = limits.IngestionBurstSizeBytes(tenant)
+		streamRate  = limits.PerStreamRateLimit(tenant)
 	)
 	s.withLock(tenant, func(i int) {
 		for _, m := range metadata {
@@ -219,6 +261,7 @@ func (s *usageStore) UpdateCond(tenant string, metadata []*proto.StreamMetadata,
 					// The stream has expired, delete it so it doesn't count
 					// towards the active streams.
 					delete(streams, m.StreamHash)
+					s.recordChurn(i, tenant, partition, now, 0, 1)
 				}
 				// Get the total number of streams, including expired
 				// streams. While we would like to count just the number of
@@ -228,7 +271,49 @@ func (s *usageStore) UpdateCond(tenant string, metadata []*proto.StreamMetadata,
 				// limit until evicted.
 				numStreams := uint64(len(s.stripes[i][tenant][partition]))
 				if numStreams >= maxStreams {
-					rejected = append(rejected, m)
+					rejected = append(rejected, &proto.ExceedsLimitsResult{
+						StreamHash: m.StreamHash,
+						Reason:     uint32(ReasonMaxStreams),
+					})
+					continue
+				}
+			}
+			if s.rateLimitsEnabled {
+				if s.exceedsStreamRate(stream, m.TotalSize, seenAt, streamRate) {
+					rejected = append(rejected, &proto.ExceedsLimitsResult{
+						StreamHash: m.StreamHash,
+						Reason:     uint32(ReasonStreamRateLimited),
+					})
+					continue
+				}
+				// The policy rate limit is checked first, and its tokens are
+				// given back if the tenant rate limit is exceeded.
+				var policyReservation *rate.Reservation
+				if policyRate := limits.PolicyIngestionRateBytes(tenant, m.Policy); policyRate > 0 {
+					policyBurst := limits.PolicyIngestionBurstSizeBytes(tenant, m.Policy)
+					if policyBurst <= 0 {
+						policyBurst = tenantBurst
+					}
+					limiter := s.getRateLimiter(i, tenant, rateLimiterKey{partition: partition, policy: m.Policy}, rate.Limit(policyRate/float64(s.numPartitions)), policyBurst, now)
+					policyReservation = limiter.ReserveN(now, int(m.TotalSize))
+					if !policyReservation.OK() || policyReservation.DelayFrom(now) > 0 {
+						policyReservation.CancelAt(now)
+						rejected = append(rejected, &proto.ExceedsLimitsResult{
+							StreamHash: m.StreamHash,
+							Reason:     uint32(ReasonPolicyRateLimited),
+						})
+						continue
+					}
+				}
+				limiter := s.getRateLimiter(i, tenant, rateLimiterKey{partition: partition}, tenantRate, tenantBurst, now)
+				if !limiter.AllowN(now, int(m.TotalSize)) {
+					if policyReservation != nil {
+						policyReservation.CancelAt(now)
+					}
+					rejected = append(rejected, &proto.ExceedsLimitsResult{
+						StreamHash: m.StreamHash,
+						Reason:     uint32(ReasonRateLimited),
+					})
 					continue
 				}
 			}
@@ -247,17 +332,23 @@ func (s *usageStore) UpdateCond(tenant string, metadata []*proto.StreamMetadata,
 
 // Evict evicts all streams that have not been seen within the window.
 func (s *usageStore) Evict() map[string]int {
-	cutoff := s.clock.Now().Add(-s.activeWindow).UnixNano()
+	now := s.clock.Now()
+	cutoff := now.Add(-s.activeWindow).UnixNano()
 	evicted := make(map[string]int)
 	s.forEachLock(func(i int) {
 		for tenant, partitions := range s.stripes[i] {
 			for partition, streams := range partitions {
+				var n uint64
 				for streamHash, stream := range streams {
 					if stream.lastSeenAt < cutoff {
 						delete(s.stripes[i][tenant][partition], streamHash)
 						evicted[tenant]++
+						n++
 					}
 				}
+				if n > 0 {
+					s.recordChurn(i, tenant, partition, now, 0, n)
+				}
 			}
 		}
 	})
@@ -275,7 +366,109 @@ func (s *usageStore) EvictPartitions(partitionsToEvict []int32) {
 				delete(s.stripes[i], tenant)
 			}
 		}
+		for tenant, limiters := range s.tenantRates[i] {
+			for key := range limiters {
+				if slices.Contains(partitionsToEvict, key.partition) {
+					delete(limiters, key)
+				}
+			}
+			if len(limiters) == 0 {
+				delete(s.tenantRates[i], tenant)
+			}
+		}
+		for tenant, partitions := range s.churn[i] {
+			for _, partitionToEvict := range partitionsToEvict {
+				delete(partitions, partitionToEvict)
+			}
+			if len(partitions) == 0 {
+				delete(s.churn[i], tenant)
+			}
+		}
+	})
+}
+
+// TenantUsage returns the usage of the tenant for the partitions, or all
+// partitions if partitions is empty. The top streams contain at most
+// topStreams streams ordered by rate, highest first. Churn is returned for
+// the active window in chronological order.
+func (s *usageStore) TenantUsage(tenant string, partitions []int32, topStreams int) *proto.GetTenantUsageResponse {
+	var (
+		now                = s.clock.Now()
+		withinActiveWindow = s.newActiveWindowFunc(now)
+		withinRateWindow   = s.newRateWindowFunc(now)
+		includePartition   = func(int32) bool { return true }
+		top                = make(topStreamsHeap, 0, max(topStreams, 0))
+		churn              = make(map[int64]*proto.StreamChurn)
+		resp               = &proto.GetTenantUsageResponse{
+			ActiveStreamsByPolicy: make(map[string]uint64),
+		}
+	)
+	if len(partitions) > 0 {
+		includePartition = func(partition int32) bool {
+			return slices.Contains(partitions, partition)
+		}
+	}
+	s.withRLock(tenant, func(i int) {
+		for partition, streams := range s.stripes[i][tenant] {
+			if !includePartition(partition) {
+				continue
+			}
+			for _, stream := range streams {
+				if !withinActiveWindow(stream.lastSeenAt) {
+					continue
+				}
+				var size uint64
+				for _, bucket := range stream.rateBuckets {
+					if withinRateWindow(bucket.timestamp) {
+						size += bucket.size
+					}
+				}
+				streamRate := float64(size) / s.rateWindow.Seconds()
+				resp.ActiveStreams++
+				resp.ActiveStreamsByPolicy[stream.policy]++
+				resp.Rate += streamRate
+				if topStreams <= 0 {
+					continue
+				}
+				usage := &proto.StreamUsage{
+					StreamHash: stream.hash,
+					Policy:     stream.policy,
+					Rate:       streamRate,
+					LastSeenAt: stream.lastSeenAt,
+				}
+				if len(top) < topStreams {
+					heap.Push(&top, usage)
+				} else if top[0].Rate < streamRate {
+					top[0] = usage
+					heap.Fix(&top, 0)
+				}
+			}
+		}
+		for partition, buckets := range s.churn[i][tenant] {
+			if !includePartition(partition) {
+				continue
+			}
+			for _, bucket := range buckets {
+				if bucket.timestamp == 0 || !withinActiveWindow(bucket.timestamp) {
+					continue
+				}
+				c, ok := churn[bucket.timestamp]
+				if !ok {
+					c = &proto.StreamChurn{Timestamp: bucket.timestamp}
+					churn[bucket.timestamp] = c
+				}
+				c.Created += bucket.created
+				c.Evicted += bucket.evicted
+			}
+		}
 	})
+	resp.TopStreams = SortStreamUsage(top)
+	resp.Churn = make([]*proto.StreamChurn, 0, len(churn))
+	for _, c := range churn {
+		resp.Churn = append(resp.Churn, c)
+	}
+	SortStreamChurn(resp.Churn)
+	return resp
 }
 
 // Describe implements [prometheus.Collector].
@@ -338,7 +531,7 @@ func (s *usageStore) get(i int, tenant string, partition int32, streamHash uint6
 
 func (s *usageStore) update(i int, tenant string, partition int32, metadata *proto.StreamMetadata, seenAt time.Time) {
 	s.checkInitMap(i, tenant, partition)
-	streamHash, _ := metadata.StreamHash, metadata.TotalSize
+	streamHash, totalSize := metadata.StreamHash, metadata.TotalSize
 	// Get the stats for the stream.
 	stream, ok := s.stripes[i][tenant][partition][streamHash]
 	cutoff := seenAt.Add(-s.activeWindow).UnixNano()
@@ -346,33 +539,115 @@ func (s *usageStore) update(i int, tenant string, partition int32, metadata *pro
 	if !ok || stream.lastSeenAt < cutoff {
 		stream.hash = streamHash
 		stream.totalSize = 0
-		// stream.rateBuckets = make([]rateBucket, s.numBuckets)
+		stream.rateBuckets = newRateBuckets(s.rateWindow, s.bucketSize)
+		if ok {
+			s.recordChurn(i, tenant, partition, seenAt, 0, 1)
+		}
+		s.recordChurn(i, tenant, partition, seenAt, 1, 0)
+	}
+	if metadata.Policy != "" {
+		stream.policy = metadata.Policy
 	}
 	seenAtUnixNano := seenAt.UnixNano()
 	if stream.lastSeenAt <= seenAtUnixNano {
 		stream.lastSeenAt = seenAtUnixNano
 	}
-	// TODO(grobinson): As mentioned above, we will come back and implement
-	// rate limits at a later date in the future.
-	// stream.totalSize += totalSize
+	stream.totalSize += totalSize
 	// rate buckets are implemented as a circular list. To update a rate
 	// bucket we must first calculate the bucket index.
-	// bucketNum := seenAtUnixNano / int64(s.bucketSize)
-	// bucketIdx := int(bucketNum % int64(s.numBuckets))
-	// bucket := stream.rateBuckets[bucketIdx]
+	bucketNum := seenAtUnixNano / int64(s.bucketSize)
+	bucketIdx := int(bucketNum % int64(s.numBuckets))
+	bucket := stream.rateBuckets[bucketIdx]
 	// Once we have found the bucket, we then need to check if it is an old
 	// bucket outside the rate window. If it is, we must reset it before we
 	// can re-use it.
-	// bucketStart := seenAt.Truncate(s.bucketSize).UnixNano()
-	// if bucket.timestamp < bucketStart {
-	// bucket.timestamp = bucketStart
-	// bucket.size = 0
-	// }
-	// bucket.size += totalSize
-	// stream.rateBuckets[bucketIdx] = bucket
+	bucketStart := seenAt.Truncate(s.bucketSize).UnixNano()
+	if bucket.timestamp < bucketStart {
+		bucket.timestamp = bucketStart
+		bucket.size = 0
+	}
+	bucket.size += totalSize
+	stream.rateBuckets[bucketIdx] = bucket
 	s.stripes[i][tenant][partition][streamHash] = stream
 }
 
+// exceedsStreamRate returns true if accepting size more bytes for the stream
+// would exceed the per-stream rate limit. The rate is calculated over the
+// rate window, and the burst is allowed on top of it.
+func (s *usageStore) exceedsStreamRate(stream streamUsage, size uint64, seenAt time.Time, limit validation.RateLimit) bool {
+	if limit.Limit <= 0 || limit.Limit == rate.Inf {
+		return false
+	}
+	withinRateWindow := s.newRateWindowFunc(seenAt)
+	var windowSize uint64
+	for _, bucket := range stream.rateBuckets {
+		if withinRateWindow(bucket.timestamp) {
+			windowSize += bucket.size
+		}
+	}
+	allowed := float64(limit.Limit)*s.rateWindow.Seconds() + float64(limit.Burst)
+	return float64(windowSize+size) > allowed
+}
+
+// rateLimiterKey identifies the ingestion rate limiters of a tenant. The
+// policy is empty for the limiter of the tenant.
+type rateLimiterKey struct {
+	partition int32
+	policy    string
+}
+
+// getRateLimiter returns the rate limiter for the tenant and key, creating
+// it if needed. The limiter is updated if the limits have changed since it
+// was created. It must not be called without the stripe lock for i.
+func (s *usageStore) getRateLimiter(i int, tenant string, key rateLimiterKey, limit rate.Limit, burst int, now time.Time) *rate.Limiter {
+	limiters, ok := s.tenantRates[i][tenant]
+	if !ok {
+		limiters = make(map[rateLimiterKey]*rate.Limiter)
+		s.tenantRates[i][tenant] = limiters
+	}
+	limiter, ok := limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(limit, burst)
+		limiters[key] = limiter
+		return limiter
+	}
+	if limiter.Limit() != limit {
+		limiter.SetLimitAt(now, limit)
+	}
+	if limiter.Burst() != burst {
+		limiter.SetBurstAt(now, burst)
+	}
+	return limiter
+}
+
+// recordChurn adds the created and evicted streams to the churn bucket for t.
+// It must not be called without the stripe lock for i.
+func (s *usageStore) recordChurn(i int, tenant string, partition int32, t time.Time, created, evicted uint64) {
+	partitions, ok := s.churn[i][tenant]
+	if !ok {
+		partitions = make(map[int32][]churnBucket)
+		s.churn[i][tenant] = partitions
+	}
+	buckets, ok := partitions[partition]
+	if !ok {
+		buckets = make([]churnBucket, s.numChurnBuckets)
+		partitions[partition] = buckets
+	}
+	// Like rate buckets, churn buckets are implemented as a circular list.
+	bucketStart := t.Truncate(s.bucketSize).UnixNano()
+	bucketIdx := int((t.UnixNano() / int64(s.bucketSize)) % int64(s.numChurnBuckets))
+	bucket := buckets[bucketIdx]
+	if bucket.timestamp < bucketStart {
+		bucket = churnBucket{timestamp: bucketStart}
+	} else if bucket.timestamp > bucketStart {
+		// The bucket has been re-used for a more recent interval.
+		return
+	}
+	bucket.created += created
+	bucket.evicted += evicted
+	buckets[bucketIdx] = bucket
+}
+
 func (s *usageStore) setLastProducedAt(i int, tenant string, partition int32, streamHash uint64, now time.Time) {
 	stream := s.stripes[i][tenant][partition][streamHash]
 	stream.lastProducedAt = now.UnixNano()
@@ -481,6 +756,11 @@ func (s *usageStore) setForTests(tenant string, stream streamUsage) {
 	})
 }
 
+// newRateBuckets returns the rate buckets for a stream.
+func newRateBuckets(rateWindow, bucketSize time.Duration) []rateBucket {
+	return make([]rateBucket, int(rateWindow/bucketSize))
+}
+
 // getActiveRateBuckets returns the buckets within the active window.
 func getActiveRateBuckets(buckets []rateBucket, withinRateWindow func(int64) bool) []rateBucket {
 	result := make([]rateBucket, 0, len(buckets))
@@ -491,3 +771,42 @@ func getActiveRateBuckets(buckets []rateBucket, withinRateWindow func(int64) boo
 	}
 	return result
 }
+
+// SortStreamUsage sorts streams by rate, highest first. Streams with the
+// same rate are sorted by hash so the order is stable.
+func SortStreamUsage(streams []*proto.StreamUsage) []*proto.StreamUsage {
+	slices.SortFunc(streams, func(a, b *proto.StreamUsage) int {
+		if c := cmp.Compare(b.Rate, a.Rate); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.StreamHash, b.StreamHash)
+	})
+	return streams
+}
+
+// SortStreamChurn sorts churn in chronological order.
+func SortStreamChurn(churn []*proto.StreamChurn) {
+	slices.SortFunc(churn, func(a, b *proto.StreamChurn) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+}
+
+// topStreamsHeap is a min-heap of streams ordered by rate. It is used to
+// find the top streams without sorting all streams.
+type topStreamsHeap []*proto.StreamUsage
+
+func (h topStreamsHeap) Len() int           { return len(h) }
+func (h topStreamsHeap) Less(i, j int) bool { return h[i].Rate < h[j].Rate }
+func (h topStreamsHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *topStreamsHeap) Push(x any) {
+	*h = append(*h, x.(*proto.StreamUsage))
+}
+
+func (h *topStreamsHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/loki/v3/pkg/limits/proto"
+	"github.com/grafana/loki/v3/pkg/validation"
 )
 
 func TestUsageStore_Iter(t *testing.T) {
@@ -136,67 +137,7 @@ func TestUsageStore_Update(t *testing.T) {
 // This test asserts that we update the correct rate buckets, and as rate
 // buckets are implemented as a circular list, when we reach the end of
 // list the next bucket is the start of the list.
-// func TestUsageStore_UpdateRateBuckets(t *testing.T) {
-// 	s, err := newUsageStore(15*time.Minute, 5*time.Minute, time.Minute, 1, prometheus.NewRegistry())
-// 	require.NoError(t, err)
-// 	clock := quartz.NewMock(t)
-// 	s.clock = clock
-// 	metadata := &proto.StreamMetadata{
-// 		StreamHash: 0x1,
-// 		TotalSize:  100,
-// 	}
-// 	// Metadata at clock.Now() should update the first rate bucket because
-// 	// the mocked clock starts at 2024-01-01T00:00:00Z.
-// 	time1 := clock.Now()
-// 	require.NoError(t, s.Update("tenant", metadata, time1))
-// 	stream, ok := s.getForTests("tenant", 0x1)
-// 	require.True(t, ok)
-// 	expected := newRateBuckets(5*time.Minute, time.Minute)
-// 	expected[0].timestamp = time1.UnixNano()
-// 	expected[0].size = 100
-// 	require.Equal(t, expected, stream.rateBuckets)
-// 	// Update the first bucket with the same metadata but 1 second later.
-// 	clock.Advance(time.Second)
-// 	time2 := clock.Now()
-// 	require.NoError(t, s.Update("tenant", metadata, time2))
-// 	expected[0].size = 200
-// 	require.Equal(t, expected, stream.rateBuckets)
-// 	// Advance the clock forward to the next bucket. Should update the second
-// 	// bucket and leave the first bucket unmodified.
-// 	clock.Advance(time.Minute)
-// 	time3 := clock.Now()
-// 	require.NoError(t, s.Update("tenant", metadata, time3))
-// 	stream, ok = s.getForTests("tenant", 0x1)
-// 	require.True(t, ok)
-// 	// As the clock is now 1 second ahead of the bucket start time, we must
-// 	// truncate the expected time to the start of the bucket.
-// 	expected[1].timestamp = time3.Truncate(time.Minute).UnixNano()
-// 	expected[1].size = 100
-// 	require.Equal(t, expected, stream.rateBuckets)
-// 	// Advance the clock to the last bucket.
-// 	clock.Advance(3 * time.Minute)
-// 	time4 := clock.Now()
-// 	require.NoError(t, s.Update("tenant", metadata, time4))
-// 	stream, ok = s.getForTests("tenant", 0x1)
-// 	require.True(t, ok)
-// 	expected[4].timestamp = time4.Truncate(time.Minute).UnixNano()
-// 	expected[4].size = 100
-// 	require.Equal(t, expected, stream.rateBuckets)
-// 	// Advance the clock one last one. It should wrap around to the start of
-// 	// the list and replace the original bucket with time1.
-// 	clock.Advance(time.Minute)
-// 	time5 := clock.Now()
-// 	require.NoError(t, s.Update("tenant", metadata, time5))
-// 	stream, ok = s.getForTests("tenant", 0x1)
-// 	require.True(t, ok)
-// 	expected[0].timestamp = time5.Truncate(time.Minute).UnixNano()
-// 	expected[0].size = 100
-// 	require.Equal(t, expected, stream.rateBuckets)
-// }
-
-// This test asserts that rate buckets are not updated while the TODOs are
-// in place.
-func TestUsageStore_RateBucketsAreNotUsed(t *testing.T) {
+func TestUsageStore_UpdateRateBuckets(t *testing.T) {
 	s, err := newUsageStore(15*time.Minute, 5*time.Minute, time.Minute, 1, prometheus.NewRegistry())
 	require.NoError(t, err)
 	clock := quartz.NewMock(t)
@@ -205,12 +146,53 @@ func TestUsageStore_RateBucketsAreNotUsed(t *testing.T) {
 		StreamHash: 0x1,
 		TotalSize:  100,
 	}
-	require.NoError(t, s.Update("tenant", metadata, clock.Now()))
+	// Metadata at clock.Now() should update the first rate bucket because
+	// the mocked clock starts at 2024-01-01T00:00:00Z.
+	time1 := clock.Now()
+	require.NoError(t, s.Update("tenant", metadata, time1))
 	stream, ok := s.getForTests("tenant", 0x1)
 	require.True(t, ok)
-
-	require.Equal(t, uint64(0), stream.totalSize)
-	require.Nil(t, stream.rateBuckets)
+	expected := newRateBuckets(5*time.Minute, time.Minute)
+	expected[0].timestamp = time1.UnixNano()
+	expected[0].size = 100
+	require.Equal(t, expected, stream.rateBuckets)
+	// Update the first bucket with the same metadata but 1 second later.
+	clock.Advance(time.Second)
+	time2 := clock.Now()
+	require.NoError(t, s.Update("tenant", metadata, time2))
+	expected[0].size = 200
+	require.Equal(t, expected, stream.rateBuckets)
+	// Advance the clock forward to the next bucket. Should update the second
+	// bucket and leave the first bucket unmodified.
+	clock.Advance(time.Minute)
+	time3 := clock.Now()
+	require.NoError(t, s.Update("tenant", metadata, time3))
+	stream, ok = s.getForTests("tenant", 0x1)
+	require.True(t, ok)
+	// As the clock is now 1 second ahead of the bucket start time, we must
+	// truncate the expected time to the start of the bucket.
+	expected[1].timestamp = time3.Truncate(time.Minute).UnixNano()
+	expected[1].size = 100
+	require.Equal(t, expected, stream.rateBuckets)
+	// Advance the clock to the last bucket.
+	clock.Advance(3 * time.Minute)
+	time4 := clock.Now()
+	require.NoError(t, s.Update("tenant", metadata, time4))
+	stream, ok = s.getForTests("tenant", 0x1)
+	require.True(t, ok)
+	expected[4].timestamp = time4.Truncate(time.Minute).UnixNano()
+	expected[4].size = 100
+	require.Equal(t, expected, stream.rateBuckets)
+	// Advance the clock one last one. It should wrap around to the start of
+	// the list and replace the original bucket with time1.
+	clock.Advance(time.Minute)
+	time5 := clock.Now()
+	require.NoError(t, s.Update("tenant", metadata, time5))
+	stream, ok = s.getForTests("tenant", 0x1)
+	require.True(t, ok)
+	expected[0].timestamp = time5.Truncate(time.Minute).UnixNano()
+	expected[0].size = 100
+	require.Equal(t, expected, stream.rateBuckets)
 }
 
 func TestUsageStore_UpdateCond(t *testing.T) {
@@ -224,7 +206,7 @@ func TestUsageStore_UpdateCond(t *testing.T) {
 		streams           []*proto.StreamMetadata
 		expectedToProduce []*proto.StreamMetadata
 		expectedAccepted  []*proto.StreamMetadata
-		expectedRejected  []*proto.StreamMetadata
+		expectedRejected  []*proto.ExceedsLimitsResult
 	}{{
 		name:             "no streams",
 		numPartitions:    1,
@@ -259,8 +241,8 @@ func TestUsageStore_UpdateCond(t *testing.T) {
 		expectedAccepted: []*proto.StreamMetadata{
 			{StreamHash: 0x0, TotalSize: 1000},
 		},
-		expectedRejected: []*proto.StreamMetadata{
-			{StreamHash: 0x1, TotalSize: 1000},
+		expectedRejected: []*proto.ExceedsLimitsResult{
+			{StreamHash: 0x1, Reason: uint32(ReasonMaxStreams)},
 		},
 	}, {
 		name:             "one stream rejected in first partition",
@@ -280,9 +262,9 @@ func TestUsageStore_UpdateCond(t *testing.T) {
 			{StreamHash: 0x0, TotalSize: 1000},
 			{StreamHash: 0x1, TotalSize: 1000},
 		},
-		expectedRejected: []*proto.StreamMetadata{
-			{StreamHash: 0x3, TotalSize: 1000},
-			{StreamHash: 0x5, TotalSize: 1000},
+		expectedRejected: []*proto.ExceedsLimitsResult{
+			{StreamHash: 0x3, Reason: uint32(ReasonMaxStreams)},
+			{StreamHash: 0x5, Reason: uint32(ReasonMaxStreams)},
 		},
 	}, {
 		name:             "one stream rejected in all partitions",
@@ -302,9 +284,9 @@ func TestUsageStore_UpdateCond(t *testing.T) {
 			{StreamHash: 0x0, TotalSize: 1000},
 			{StreamHash: 0x1, TotalSize: 1000},
 		},
-		expectedRejected: []*proto.StreamMetadata{
-			{StreamHash: 0x2, TotalSize: 1000},
-			{StreamHash: 0x3, TotalSize: 1000},
+		expectedRejected: []*proto.ExceedsLimitsResult{
+			{StreamHash: 0x2, Reason: uint32(ReasonMaxStreams)},
+			{StreamHash: 0x3, Reason: uint32(ReasonMaxStreams)},
 		},
 	}, {
 		name:             "drops new streams but updates existing streams",
@@ -330,8 +312,8 @@ func TestUsageStore_UpdateCond(t *testing.T) {
 			{StreamHash: 0x1, TotalSize: 1000},
 			{StreamHash: 0x2, TotalSize: 1000},
 		},
-		expectedRejected: []*proto.StreamMetadata{
-			{StreamHash: 0x4, TotalSize: 1000},
+		expectedRejected: []*proto.ExceedsLimitsResult{
+			{StreamHash: 0x4, Reason: uint32(ReasonMaxStreams)},
 		},
 	}}
 
@@ -396,6 +378,130 @@ func TestUsageStore_UpdateCond_ToProduce(t *testing.T) {
 	require.Equal(t, metadata1, toProduce)
 }
 
+func TestUsageStore_UpdateCond_RateLimits(t *testing.T) {
+	t.Run("rejects streams exceeding the per-stream rate limit", func(t *testing.T) {
+		s, err := newUsageStore(15*time.Minute, 5*time.Minute, time.Minute, 1, prometheus.NewRegistry())
+		require.NoError(t, err)
+		clock := quartz.NewMock(t)
+		s.clock = clock
+		s.rateLimitsEnabled = true
+		// 1 byte per second over the 5 minute rate window plus the burst
+		// allows 400 bytes.
+		limits := mockLimits{
+			MaxGlobalStreams: 10,
+			IngestionRate:    1e9,
+			PerStreamRate:    validation.RateLimit{Limit: 1, Burst: 100},
+		}
+		metadata := []*proto.StreamMetadata{{StreamHash: 0x1, TotalSize: 300}}
+		_, accepted, rejected, err := s.UpdateCond("tenant", metadata, clock.Now(), &limits)
+		require.NoError(t, err)
+		require.Len(t, accepted, 1)
+		require.Empty(t, rejected)
+		_, accepted, rejected, err = s.UpdateCond("tenant", metadata, clock.Now(), &limits)
+		require.NoError(t, err)
+		require.Empty(t, accepted)
+		require.Equal(t, []*proto.ExceedsLimitsResult{{
+			StreamHash: 0x1,
+			Reason:     uint32(ReasonStreamRateLimited),
+		}}, rejected)
+		// Once the first update is outside the rate window, the stream is
+		// accepted again.
+		clock.Advance(5*time.Minute + time.Second)
+		_, accepted, rejected, err = s.UpdateCond("tenant", metadata, clock.Now(), &limits)
+		require.NoError(t, err)
+		require.Len(t, accepted, 1)
+		require.Empty(t, rejected)
+	})
+
+	t.Run("rejects streams exceeding the tenant rate limit", func(t *testing.T) {
+		s, err := newUsageStore(15*time.Minute, 5*time.Minute, time.Minute, 1, prometheus.NewRegistry())
+		require.NoError(t, err)
+		clock := quartz.NewMock(t)
+		s.clock = clock
+		s.rateLimitsEnabled = true
+		// 10 bytes per second with a burst of 1000 bytes.
+		limits := mockLimits{MaxGlobalStreams: 10, IngestionRate: 10}
+		metadata := []*proto.StreamMetadata{
+			{StreamHash: 0x1, TotalSize: 600},
+			{StreamHash: 0x2, TotalSize: 600},
+		}
+		_, accepted, rejected, err := s.UpdateCond("tenant", metadata, clock.Now(), &limits)
+		require.NoError(t, err)
+		require.Equal(t, metadata[:1], accepted)
+		require.Equal(t, []*proto.ExceedsLimitsResult{{
+			StreamHash: 0x2,
+			Reason:     uint32(ReasonRateLimited),
+		}}, rejected)
+		// After a minute, enough tokens are available for the second stream.
+		clock.Advance(time.Minute)
+		_, accepted, rejected, err = s.UpdateCond("tenant", metadata[1:], clock.Now(), &limits)
+		require.NoError(t, err)
+		require.Equal(t, metadata[1:], accepted)
+		require.Empty(t, rejected)
+	})
+
+	t.Run("rejects streams exceeding the policy rate limit", func(t *testing.T) {
+		s, err := newUsageStore(15*time.Minute, 5*time.Minute, time.Minute, 1, prometheus.NewRegistry())
+		require.NoError(t, err)
+		clock := quartz.NewMock(t)
+		s.clock = clock
+		s.rateLimitsEnabled = true
+		// The policy and the tenant both have a burst of 1000 bytes.
+		limits := mockLimits{
+			MaxGlobalStreams: 10,
+			IngestionRate:    10,
+			PolicyRates:      map[string]float64{"finance": 10},
+		}
+		metadata := []*proto.StreamMetadata{
+			{StreamHash: 0x1, TotalSize: 600, Policy: "finance"},
+			{StreamHash: 0x2, TotalSize: 600, Policy: "finance"},
+			{StreamHash: 0x3, TotalSize: 300},
+		}
+		_, accepted, rejected, err := s.UpdateCond("tenant", metadata, clock.Now(), &limits)
+		require.NoError(t, err)
+		require.Equal(t, []*proto.StreamMetadata{metadata[0], metadata[2]}, accepted)
+		require.Equal(t, []*proto.ExceedsLimitsResult{{
+			StreamHash: 0x2,
+			Reason:     uint32(ReasonPolicyRateLimited),
+		}}, rejected)
+		// The tenant has 100 bytes left, the tokens of the policy are given
+		// back when the tenant rate limit is exceeded.
+		_, _, rejected, err = s.UpdateCond("tenant", []*proto.StreamMetadata{{StreamHash: 0x4, TotalSize: 400, Policy: "finance"}}, clock.Now(), &limits)
+		require.NoError(t, err)
+		require.Equal(t, []*proto.ExceedsLimitsResult{{
+			StreamHash: 0x4,
+			Reason:     uint32(ReasonRateLimited),
+		}}, rejected)
+		// After 30 seconds, the tenant has 400 bytes again. The policy only
+		// has 400 bytes if its tokens were given back.
+		clock.Advance(30 * time.Second)
+		_, accepted, rejected, err = s.UpdateCond("tenant", []*proto.StreamMetadata{{StreamHash: 0x4, TotalSize: 400, Policy: "finance"}}, clock.Now(), &limits)
+		require.NoError(t, err)
+		require.Len(t, accepted, 1)
+		require.Empty(t, rejected)
+	})
+
+	t.Run("does not enforce rate limits when disabled", func(t *testing.T) {
+		s, err := newUsageStore(15*time.Minute, 5*time.Minute, time.Minute, 1, prometheus.NewRegistry())
+		require.NoError(t, err)
+		clock := quartz.NewMock(t)
+		s.clock = clock
+		limits := mockLimits{
+			MaxGlobalStreams: 10,
+			IngestionRate:    10,
+			PerStreamRate:    validation.RateLimit{Limit: 1, Burst: 100},
+		}
+		metadata := []*proto.StreamMetadata{
+			{StreamHash: 0x1, TotalSize: 600},
+			{StreamHash: 0x2, TotalSize: 600},
+		}
+		_, accepted, rejected, err := s.UpdateCond("tenant", metadata, clock.Now(), &limits)
+		require.NoError(t, err)
+		require.Equal(t, metadata, accepted)
+		require.Empty(t, rejected)
+	})
+}
+
 func TestUsageStore_Evict(t *testing.T) {
 	s, err := newUsageStore(15*time.Minute, 5*time.Minute, time.Minute, 1, prometheus.NewRegistry())
 	require.NoError(t, err)
@@ -450,6 +556,75 @@ func TestUsageStore_EvictPartitions(t *testing.T) {
 	require.ElementsMatch(t, expected, actual)
 }
 
-func newRateBuckets(rateWindow, bucketSize time.Duration) []rateBucket {
-	return make([]rateBucket, int(rateWindow/bucketSize))
+func TestUsageStore_TenantUsage(t *testing.T) {
+	s, err := newUsageStore(15*time.Minute, 5*time.Minute, time.Minute, 2, prometheus.NewRegistry())
+	require.NoError(t, err)
+	clock := quartz.NewMock(t)
+	clock.Set(time.Now().Truncate(time.Minute))
+	s.clock = clock
+	// 0x1 and 0x3 are assigned to partition 1, 0x2 to partition 0.
+	require.NoError(t, s.Update("tenant", &proto.StreamMetadata{StreamHash: 0x1, TotalSize: 600}, clock.Now()))
+	require.NoError(t, s.Update("tenant", &proto.StreamMetadata{StreamHash: 0x2, TotalSize: 300, Policy: "policy1"}, clock.Now()))
+	clock.Advance(time.Minute)
+	require.NoError(t, s.Update("tenant", &proto.StreamMetadata{StreamHash: 0x3, TotalSize: 1200, Policy: "policy1"}, clock.Now()))
+	require.NoError(t, s.Update("other", &proto.StreamMetadata{StreamHash: 0x1, TotalSize: 100}, clock.Now()))
+	start := clock.Now().Add(-time.Minute).UnixNano()
+
+	t.Run("all partitions", func(t *testing.T) {
+		actual := s.TenantUsage("tenant", nil, 2)
+		require.Equal(t, &proto.GetTenantUsageResponse{
+			ActiveStreams:         3,
+			Rate:                  7,
+			ActiveStreamsByPolicy: map[string]uint64{"": 1, "policy1": 2},
+			TopStreams: []*proto.StreamUsage{{
+				StreamHash: 0x3,
+				Policy:     "policy1",
+				Rate:       4,
+				LastSeenAt: clock.Now().UnixNano(),
+			}, {
+				StreamHash: 0x1,
+				Rate:       2,
+				LastSeenAt: start,
+			}},
+			Churn: []*proto.StreamChurn{
+				{Timestamp: start, Created: 2},
+				{Timestamp: clock.Now().UnixNano(), Created: 1},
+			},
+		}, actual)
+	})
+
+	t.Run("some partitions", func(t *testing.T) {
+		actual := s.TenantUsage("tenant", []int32{0}, 10)
+		require.Equal(t, &proto.GetTenantUsageResponse{
+			ActiveStreams:         1,
+			Rate:                  1,
+			ActiveStreamsByPolicy: map[string]uint64{"policy1": 1},
+			TopStreams: []*proto.StreamUsage{{
+				StreamHash: 0x2,
+				Policy:     "policy1",
+				Rate:       1,
+				LastSeenAt: start,
+			}},
+			Churn: []*proto.StreamChurn{{Timestamp: start, Created: 1}},
+		}, actual)
+	})
+
+	t.Run("evicted streams", func(t *testing.T) {
+		clock.Advance(15 * time.Minute)
+		s.Evict()
+		actual := s.TenantUsage("tenant", nil, 10)
+		require.Equal(t, &proto.GetTenantUsageResponse{
+			ActiveStreams:         1,
+			ActiveStreamsByPolicy: map[string]uint64{"policy1": 1},
+			TopStreams: []*proto.StreamUsage{{
+				StreamHash: 0x3,
+				Policy:     "policy1",
+				LastSeenAt: clock.Now().Add(-15 * time.Minute).UnixNano(),
+			}},
+			Churn: []*proto.StreamChurn{
+				{Timestamp: clock.Now().Add(-15 * time.Minute).UnixNano(), Created: 1},
+				{Timestamp: clock.Now().UnixNano(), Evicted: 2},
+			},
+		}, actual)
+	})
 }
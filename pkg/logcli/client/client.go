@@ -65,6 +65,7 @@ type Client interface {
 	GetVolumeRange(query *volume.Query) (*loghttp.QueryResponse, error)
 	GetDetectedFields(queryStr, fieldName string, fieldLimit, lineLimit int, start, end time.Time, step time.Duration, quiet bool) (*loghttp.DetectedFieldsResponse, error)
 	CreateDeleteRequest(params DeleteRequestParams, quiet bool) error
+	DryRunDeleteRequest(params DeleteRequestParams, quiet bool) (*DeleteRequestDryRun, error)
 	ListDeleteRequests(quiet bool) ([]DeleteRequest, error)
 	CancelDeleteRequest(requestID string, force bool, quiet bool) error
 }
@@ -283,8 +284,29 @@ func (c *DefaultClient) CreateDeleteRequest(params DeleteRequestParams, quiet bo
 	if params.MaxInterval != "" {
 		qsb.SetString("max_interval", params.MaxInterval)
 	}
+	if params.Mode != "" {
+		qsb.SetString("mode", params.Mode)
+	}
 
-	return c.doPostRequest(deletePath, qsb.Encode(), quiet)
+	return c.doPostRequest(deletePath, qsb.Encode(), quiet, nil)
+}
+
+func (c *DefaultClient) DryRunDeleteRequest(params DeleteRequestParams, quiet bool) (*DeleteRequestDryRun, error) {
+	qsb := util.NewQueryStringBuilder()
+	qsb.SetString("query", params.Query)
+	if params.Start != "" {
+		qsb.SetString("start", params.Start)
+	}
+	if params.End != "" {
+		qsb.SetString("end", params.End)
+	}
+	qsb.SetString("dry_run", "true")
+
+	var dryRun DeleteRequestDryRun
+	if err := c.doPostRequest(deletePath, qsb.Encode(), quiet, &dryRun); err != nil {
+		return nil, err
+	}
+	return &dryRun, nil
 }
 
 func (c *DefaultClient) ListDeleteRequests(quiet bool) ([]DeleteRequest, error) {
@@ -422,7 +444,7 @@ func (c *DefaultClient) doRequest(path, query string, quiet bool, out interface{
 	return json.NewDecoder(resp.Body).Decode(out)
 }
 
-func (c *DefaultClient) doPostRequest(path, query string, quiet bool) error {
+func (c *DefaultClient) doPostRequest(path, query string, quiet bool, out interface{}) error {
 	us, err := buildURL(c.Address, path, query)
 	if err != nil {
 		return err
@@ -518,7 +540,10 @@ func (c *DefaultClient) doPostRequest(path, query string, quiet bool) error {
 			log.Println("error closing body", err)
 		}
 	}()
-	return nil
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
 }
 
 func (c *DefaultClient) doDeleteRequest(path, query string, quiet bool) error {
@@ -741,6 +766,27 @@ type DeleteRequest struct {
 	EndTime   int64  `json:"end_time"`
 	Query     string `json:"query"`
 	Status    string `json:"status"`
+	Mode      string `json:"mode,omitempty"`
+}
+
+// DeleteRequestDryRun represents the estimated impact of a delete request
+type DeleteRequestDryRun struct {
+	Streams        int                      `json:"streams"`
+	Chunks         int                      `json:"chunks"`
+	EstimatedLines uint64                   `json:"estimated_lines"`
+	EstimatedBytes uint64                   `json:"estimated_bytes"`
+	Days           []DeleteRequestDryRunDay `json:"days"`
+	// UncompactedTables are the tables whose index is yet to be compacted and is not part of the estimate
+	UncompactedTables []string `json:"uncompacted_tables,omitempty"`
+}
+
+// DeleteRequestDryRunDay represents the estimated impact of a delete request on a day
+type DeleteRequestDryRunDay struct {
+	Day            string `json:"day"`
+	Streams        int    `json:"streams"`
+	Chunks         int    `json:"chunks"`
+	EstimatedLines uint64 `json:"estimated_lines"`
+	EstimatedBytes uint64 `json:"estimated_bytes"`
 }
 
 // DeleteRequestParams represents the parameters for creating a delete request
@@ -749,4 +795,5 @@ type DeleteRequestParams struct {
 	Start       string `json:"start,omitempty"`
 	End         string `json:"end,omitempty"`
 	MaxInterval string `json:"max_interval,omitempty"`
+	Mode        string `json:"mode,omitempty"`
 }
@@ -218,6 +218,10 @@ func (f *FileClient) CreateDeleteRequest(_ DeleteRequestParams, _ bool) error {
 	return ErrNotSupported
 }
 
+func (f *FileClient) DryRunDeleteRequest(_ DeleteRequestParams, _ bool) (*DeleteRequestDryRun, error) {
+	return nil, ErrNotSupported
+}
+
 func (f *FileClient) ListDeleteRequests(_ bool) ([]DeleteRequest, error) {
 	return nil, ErrNotSupported
 }
@@ -238,6 +242,14 @@ func (l *limiter) MaxQueryRange(_ context.Context, _ string) time.Duration {
 	return 0 * time.Second
 }
 
+func (l *limiter) MaxJoinEntries(_ context.Context, _ string) int {
+	return 0
+}
+
+func (l *limiter) MaxEntriesLimitPerQuery(_ context.Context, _ string) int {
+	return 0
+}
+
 func (l *limiter) QueryTimeout(_ context.Context, _ string) time.Duration {
 	return time.Minute * 5
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/grafana/loki/v3/pkg/logcli/client"
@@ -16,13 +17,65 @@ type Query struct {
 	Start       time.Time
 	End         time.Time
 	MaxInterval string
+	Mode        string
 	Quiet       bool
 	RequestID   string
 	Force       bool
+	DryRun      bool
 }
 
 // CreateQuery executes a delete request creation
 func (q *Query) CreateQuery(c client.Client) error {
+	err := c.CreateDeleteRequest(q.deleteRequestParams(), q.Quiet)
+	if err != nil {
+		return err
+	}
+
+	if !q.Quiet {
+		fmt.Println("Delete request created successfully")
+	}
+	return nil
+}
+
+// DryRunQuery estimates the impact of a delete request without creating it
+func (q *Query) DryRunQuery(c client.Client) error {
+	dryRun, err := c.DryRunDeleteRequest(q.deleteRequestParams(), q.Quiet)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Streams: %d\n", dryRun.Streams)
+	fmt.Printf("Chunks: %d\n", dryRun.Chunks)
+	fmt.Printf("Estimated Lines: %d\n", dryRun.EstimatedLines)
+	fmt.Printf("Estimated Bytes: %d\n", dryRun.EstimatedBytes)
+	for _, day := range dryRun.Days {
+		fmt.Println("---")
+		fmt.Printf("Day: %s\n", day.Day)
+		fmt.Printf("Streams: %d\n", day.Streams)
+		fmt.Printf("Chunks: %d\n", day.Chunks)
+		fmt.Printf("Estimated Lines: %d\n", day.EstimatedLines)
+		fmt.Printf("Estimated Bytes: %d\n", day.EstimatedBytes)
+	}
+	if len(dryRun.UncompactedTables) > 0 {
+		fmt.Println("---")
+		fmt.Printf("Uncompacted tables not included in the estimate: %s\n", strings.Join(dryRun.UncompactedTables, ", "))
+	}
+	return nil
+}
+
+// DryRunQueryJSON estimates the impact of a delete request without creating it, with JSON output
+func (q *Query) DryRunQueryJSON(c client.Client) error {
+	dryRun, err := c.DryRunDeleteRequest(q.deleteRequestParams(), q.Quiet)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(dryRun)
+}
+
+func (q *Query) deleteRequestParams() client.DeleteRequestParams {
 	params := client.DeleteRequestParams{
 		Query: q.QueryString,
 	}
@@ -37,16 +90,8 @@ func (q *Query) CreateQuery(c client.Client) error {
 	if q.MaxInterval != "" {
 		params.MaxInterval = q.MaxInterval
 	}
-
-	err := c.CreateDeleteRequest(params, q.Quiet)
-	if err != nil {
-		return err
-	}
-
-	if !q.Quiet {
-		fmt.Println("Delete request created successfully")
-	}
-	return nil
+	params.Mode = q.Mode
+	return params
 }
 
 // ListQuery executes a delete request listing
@@ -87,6 +132,9 @@ func (q *Query) printDeleteRequest(req client.DeleteRequest) {
 	fmt.Printf("Start Time: %s\n", time.Unix(req.StartTime, 0).Format(time.RFC3339))
 	fmt.Printf("End Time: %s\n", time.Unix(req.EndTime, 0).Format(time.RFC3339))
 	fmt.Printf("Status: %s\n", req.Status)
+	if req.Mode != "" {
+		fmt.Printf("Mode: %s\n", req.Mode)
+	}
 	fmt.Println("---")
 }
 
@@ -57,6 +57,24 @@ func TestDeleteCreateQuery(t *testing.T) {
 			},
 			expectOutput: "Delete request created successfully\n",
 		},
+		{
+			name: "create rewrite request",
+			query: Query{
+				QueryString: "{job=\"test\"} | drop email",
+				Start:       time.Unix(1000, 0),
+				End:         time.Unix(2000, 0),
+				Mode:        "rewrite",
+				Quiet:       false,
+			},
+			expectError: false,
+			expectParams: client.DeleteRequestParams{
+				Query: "{job=\"test\"} | drop email",
+				Start: "1000",
+				End:   "2000",
+				Mode:  "rewrite",
+			},
+			expectOutput: "Delete request created successfully\n",
+		},
 		{
 			name: "create request with zero times",
 			query: Query{
@@ -143,6 +161,57 @@ func TestDeleteCreateQuery(t *testing.T) {
 	}
 }
 
+func TestDeleteDryRunQuery(t *testing.T) {
+	mockClient := newMockDeleteClient()
+	mockClient.dryRun = &client.DeleteRequestDryRun{
+		Streams:        2,
+		Chunks:         3,
+		EstimatedLines: 100,
+		EstimatedBytes: 2048,
+		Days: []client.DeleteRequestDryRunDay{
+			{Day: "2023-01-01", Streams: 2, Chunks: 3, EstimatedLines: 100, EstimatedBytes: 2048},
+		},
+	}
+	q := Query{
+		QueryString: "{job=\"test\"}",
+		Start:       time.Unix(1000, 0),
+		End:         time.Unix(2000, 0),
+		DryRun:      true,
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := q.DryRunQuery(mockClient)
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `Streams: 2
+Chunks: 3
+Estimated Lines: 100
+Estimated Bytes: 2048
+---
+Day: 2023-01-01
+Streams: 2
+Chunks: 3
+Estimated Lines: 100
+Estimated Bytes: 2048
+`, buf.String())
+	assert.Equal(t, 0, mockClient.createDeleteRequestCalls)
+	assert.Equal(t, 1, mockClient.dryRunDeleteRequestCalls)
+	assert.Equal(t, client.DeleteRequestParams{
+		Query: "{job=\"test\"}",
+		Start: "1000",
+		End:   "2000",
+	}, mockClient.lastCreateParams)
+}
+
 func TestDeleteListQuery(t *testing.T) {
 	tests := []struct {
 		name            string
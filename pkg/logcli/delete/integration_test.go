@@ -268,6 +268,10 @@ func (m *workflowMockClient) CreateDeleteRequest(params client.DeleteRequestPara
 	return nil
 }
 
+func (m *workflowMockClient) DryRunDeleteRequest(_ client.DeleteRequestParams, _ bool) (*client.DeleteRequestDryRun, error) {
+	return &client.DeleteRequestDryRun{}, m.createError
+}
+
 func (m *workflowMockClient) ListDeleteRequests(_ bool) ([]client.DeleteRequest, error) {
 	if m.listError != nil {
 		return nil, m.listError
@@ -15,11 +15,13 @@ import (
 type mockDeleteClient struct {
 	// Call tracking
 	createDeleteRequestCalls int
+	dryRunDeleteRequestCalls int
 	listDeleteRequestsCalls  int
 	cancelDeleteRequestCalls int
 
 	// Response control
 	deleteRequests []client.DeleteRequest
+	dryRun         *client.DeleteRequestDryRun
 	createError    error
 	listError      error
 	cancelError    error
@@ -72,6 +74,15 @@ func (m *mockDeleteClient) CreateDeleteRequest(params client.DeleteRequestParams
 	return m.createError
 }
 
+func (m *mockDeleteClient) DryRunDeleteRequest(params client.DeleteRequestParams, _ bool) (*client.DeleteRequestDryRun, error) {
+	m.dryRunDeleteRequestCalls++
+	m.lastCreateParams = params
+	if m.createError != nil {
+		return nil, m.createError
+	}
+	return m.dryRun, nil
+}
+
 func (m *mockDeleteClient) ListDeleteRequests(quiet bool) ([]client.DeleteRequest, error) {
 	m.listDeleteRequestsCalls++
 	m.lastListQuiet = quiet
@@ -500,6 +500,10 @@ func (t *testQueryClient) CreateDeleteRequest(_ logcli_client.DeleteRequestParam
 	panic("not implemented")
 }
 
+func (t *testQueryClient) DryRunDeleteRequest(_ logcli_client.DeleteRequestParams, _ bool) (*logcli_client.DeleteRequestDryRun, error) {
+	panic("not implemented")
+}
+
 func (t *testQueryClient) ListDeleteRequests(_ bool) ([]logcli_client.DeleteRequest, error) {
 	panic("not implemented")
 }
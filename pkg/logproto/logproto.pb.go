@@ -542,6 +542,8 @@ type Delete struct {
 	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
 	Start    int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
 	End      int64  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// rewrite tells whether the matching entries are rewritten with the output of the selector's pipeline instead of being filtered out.
+	Rewrite bool `protobuf:"varint,4,opt,name=rewrite,proto3" json:"rewrite,omitempty"`
 }
 
 func (m *Delete) Reset()      { *m = Delete{} }
@@ -597,6 +599,13 @@ func (m *Delete) GetEnd() int64 {
 	return 0
 }
 
+func (m *Delete) GetRewrite() bool {
+	if m != nil {
+		return m.Rewrite
+	}
+	return false
+}
+
 type QueryResponse struct {
 	Streams  []github_com_grafana_loki_pkg_push.Stream `protobuf:"bytes,1,rep,name=streams,proto3,customtype=github.com/grafana/loki/pkg/push.Stream" json:"streams,omitempty"`
 	Stats    stats.Ingester                            `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats"`
@@ -3170,182 +3179,182 @@ func init() {
 func init() { proto.RegisterFile("pkg/logproto/logproto.proto", fileDescriptor_c28a5f14f1f4c79a) }
 
 var fileDescriptor_c28a5f14f1f4c79a = []byte{
-	// 2796 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x1a, 0xcf, 0x8f, 0x5b, 0x47,
-	0xd9, 0xcf, 0xbf, 0xd6, 0xfe, 0xec, 0xdd, 0x6c, 0x66, 0x9d, 0x8d, 0xb5, 0x49, 0xfd, 0xb6, 0x23,
-	0x68, 0xb7, 0x4d, 0xba, 0x4e, 0xb6, 0xb4, 0xb4, 0x29, 0xa5, 0xc4, 0xbb, 0x4d, 0x9a, 0x74, 0x9b,
-	0xa4, 0xb3, 0x69, 0x5a, 0x10, 0x55, 0xf5, 0x62, 0xcf, 0xda, 0x8f, 0xd8, 0xef, 0x39, 0xef, 0x8d,
-	0x9b, 0xfa, 0xc6, 0x3f, 0x80, 0xa8, 0x84, 0x10, 0x70, 0x41, 0x42, 0x42, 0x02, 0x21, 0xf5, 0x82,
-	0x38, 0x70, 0x40, 0x70, 0xe1, 0x50, 0x6e, 0x3d, 0x56, 0x3d, 0x18, 0xba, 0xbd, 0xa0, 0x95, 0x90,
-	0x7a, 0x02, 0xa9, 0x5c, 0xd0, 0xfc, 0x7a, 0x6f, 0xde, 0x5b, 0x2f, 0xa9, 0x43, 0x50, 0x9b, 0x8b,
-	0x3d, 0xf3, 0xcd, 0x37, 0xdf, 0xcc, 0xf7, 0x63, 0xbe, 0x5f, 0x36, 0x9c, 0x18, 0xde, 0xea, 0x36,
-	0xfb, 0x7e, 0x77, 0x18, 0xf8, 0xcc, 0x8f, 0x06, 0xeb, 0xe2, 0x13, 0x95, 0xf4, 0x7c, 0xa5, 0xd6,
-	0xf5, 0xbb, 0xbe, 0xc4, 0xe1, 0x23, 0xb9, 0xbe, 0x62, 0x77, 0x7d, 0xbf, 0xdb, 0xa7, 0x4d, 0x31,
-	0xbb, 0x39, 0xda, 0x6d, 0x32, 0x77, 0x40, 0x43, 0xe6, 0x0c, 0x86, 0x0a, 0x61, 0x55, 0x51, 0xbf,
-	0xdd, 0x1f, 0xf8, 0x1d, 0xda, 0x6f, 0x86, 0xcc, 0x61, 0xa1, 0xfc, 0x54, 0x18, 0x4b, 0x1c, 0x63,
-	0x38, 0x0a, 0x7b, 0xe2, 0x43, 0x01, 0xcf, 0x70, 0x60, 0xc8, 0xfc, 0xc0, 0xe9, 0xd2, 0x66, 0xbb,
-	0x37, 0xf2, 0x6e, 0x35, 0xdb, 0x4e, 0xbb, 0x47, 0x9b, 0x01, 0x0d, 0x47, 0x7d, 0x16, 0xca, 0x09,
-	0x1b, 0x0f, 0xa9, 0x22, 0x83, 0x7f, 0x67, 0xc1, 0xb1, 0x6d, 0xe7, 0x26, 0xed, 0x5f, 0xf7, 0x6f,
-	0x38, 0xfd, 0x11, 0x0d, 0x09, 0x0d, 0x87, 0xbe, 0x17, 0x52, 0xb4, 0x09, 0xc5, 0x3e, 0x5f, 0x08,
-	0xeb, 0xd6, 0x6a, 0x6e, 0xad, 0xb2, 0x71, 0x6a, 0x3d, 0x62, 0x72, 0xea, 0x06, 0x09, 0x0d, 0x5f,
-	0xf4, 0x58, 0x30, 0x26, 0x6a, 0xeb, 0xca, 0x0d, 0xa8, 0x18, 0x60, 0xb4, 0x08, 0xb9, 0x5b, 0x74,
-	0x5c, 0xb7, 0x56, 0xad, 0xb5, 0x32, 0xe1, 0x43, 0x74, 0x16, 0x0a, 0x6f, 0x73, 0x32, 0xf5, 0xec,
-	0xaa, 0xb5, 0x56, 0xd9, 0x38, 0x11, 0x1f, 0xf2, 0x9a, 0xe7, 0xde, 0x1e, 0x51, 0xb1, 0x5b, 0x1d,
-	0x24, 0x31, 0xcf, 0x65, 0x9f, 0xb1, 0xf0, 0x29, 0x38, 0x7a, 0x60, 0x1d, 0x2d, 0x43, 0x51, 0x60,
-	0xc8, 0x1b, 0x97, 0x89, 0x9a, 0xe1, 0x1a, 0xa0, 0x1d, 0x16, 0x50, 0x67, 0x40, 0x1c, 0xc6, 0xef,
-	0x7b, 0x7b, 0x44, 0x43, 0x86, 0x5f, 0x81, 0xa5, 0x04, 0x54, 0xb1, 0xfd, 0x34, 0x54, 0xc2, 0x18,
-	0xac, 0x78, 0xaf, 0xc5, 0xd7, 0x8a, 0xf7, 0x10, 0x13, 0x11, 0xff, 0xdc, 0x02, 0x88, 0xd7, 0x50,
-	0x03, 0x40, 0xae, 0xbe, 0xe4, 0x84, 0x3d, 0xc1, 0x70, 0x9e, 0x18, 0x10, 0x74, 0x1a, 0x8e, 0xc6,
-	0xb3, 0x2b, 0xfe, 0x4e, 0xcf, 0x09, 0x3a, 0x42, 0x06, 0x79, 0x72, 0x70, 0x01, 0x21, 0xc8, 0x07,
-	0x0e, 0xa3, 0xf5, 0xdc, 0xaa, 0xb5, 0x96, 0x23, 0x62, 0xcc, 0xb9, 0x65, 0xd4, 0x73, 0x3c, 0x56,
-	0xcf, 0x0b, 0x71, 0xaa, 0x19, 0x87, 0x73, 0x8b, 0xa0, 0x61, 0xbd, 0xb0, 0x6a, 0xad, 0xcd, 0x13,
-	0x35, 0xc3, 0xff, 0xcc, 0x41, 0xf5, 0xd5, 0x11, 0x0d, 0xc6, 0x4a, 0x00, 0xa8, 0x01, 0xa5, 0x90,
-	0xf6, 0x69, 0x9b, 0xf9, 0x81, 0xd4, 0x48, 0x2b, 0x5b, 0xb7, 0x48, 0x04, 0x43, 0x35, 0x28, 0xf4,
-	0xdd, 0x81, 0xcb, 0xc4, 0xb5, 0xe6, 0x89, 0x9c, 0xa0, 0x73, 0x50, 0x08, 0x99, 0x13, 0x30, 0x71,
-	0x97, 0xca, 0xc6, 0xca, 0xba, 0x34, 0xe5, 0x75, 0x6d, 0xca, 0xeb, 0xd7, 0xb5, 0x29, 0xb7, 0x4a,
-	0xef, 0x4f, 0xec, 0xcc, 0xbb, 0x7f, 0xb5, 0x2d, 0x22, 0xb7, 0xa0, 0xa7, 0x21, 0x47, 0xbd, 0x8e,
-	0xb8, 0xef, 0xe7, 0xdd, 0xc9, 0x37, 0xa0, 0xb3, 0x50, 0xee, 0xb8, 0x01, 0x6d, 0x33, 0xd7, 0xf7,
-	0x04, 0x57, 0x0b, 0x1b, 0x4b, 0xb1, 0x46, 0xb6, 0xf4, 0x12, 0x89, 0xb1, 0xd0, 0x69, 0x28, 0x86,
-	0x5c, 0x74, 0x61, 0x7d, 0x8e, 0xdb, 0x42, 0xab, 0xb6, 0x3f, 0xb1, 0x17, 0x25, 0xe4, 0xb4, 0x3f,
-	0x70, 0x19, 0x1d, 0x0c, 0xd9, 0x98, 0x28, 0x1c, 0xf4, 0x38, 0xcc, 0x75, 0x68, 0x9f, 0x72, 0x85,
-	0x97, 0x84, 0xc2, 0x17, 0x0d, 0xf2, 0x62, 0x81, 0x68, 0x04, 0xf4, 0x26, 0xe4, 0x87, 0x7d, 0xc7,
-	0xab, 0x97, 0x05, 0x17, 0x0b, 0x31, 0xe2, 0xb5, 0xbe, 0xe3, 0xb5, 0x9e, 0xfd, 0x68, 0x62, 0x3f,
-	0xd5, 0x75, 0x59, 0x6f, 0x74, 0x73, 0xbd, 0xed, 0x0f, 0x9a, 0xdd, 0xc0, 0xd9, 0x75, 0x3c, 0xa7,
-	0xd9, 0xf7, 0x6f, 0xb9, 0xcd, 0xb7, 0x9f, 0x6c, 0xf2, 0x07, 0x7a, 0x7b, 0x44, 0x03, 0x97, 0x06,
-	0x4d, 0x4e, 0x66, 0x5d, 0xa8, 0x84, 0x6f, 0x25, 0x82, 0x2c, 0xba, 0xcc, 0xed, 0xcf, 0x0f, 0xe8,
-	0x26, 0x7f, 0xbd, 0x61, 0x1d, 0xc4, 0x29, 0xc7, 0xe3, 0x53, 0x04, 0x9c, 0xd0, 0xdd, 0x8b, 0x81,
-	0x3f, 0x1a, 0xb6, 0x8e, 0xec, 0x4f, 0x6c, 0x13, 0x9f, 0x98, 0x93, 0xcb, 0xf9, 0x52, 0x71, 0x71,
-	0x0e, 0xbf, 0x97, 0x03, 0xb4, 0xe3, 0x0c, 0x86, 0x7d, 0x3a, 0x93, 0xfa, 0x23, 0x45, 0x67, 0xef,
-	0x59, 0xd1, 0xb9, 0x59, 0x15, 0x1d, 0x6b, 0x2d, 0x3f, 0x9b, 0xd6, 0x0a, 0x9f, 0x57, 0x6b, 0xc5,
-	0x2f, 0xbd, 0xd6, 0x70, 0x1d, 0xf2, 0x9c, 0x32, 0x77, 0x96, 0x81, 0x73, 0x47, 0xe8, 0xa6, 0x4a,
-	0xf8, 0x10, 0x6f, 0x43, 0x51, 0xf2, 0x85, 0x56, 0xd2, 0xca, 0x4b, 0xbe, 0xdb, 0x58, 0x71, 0x39,
-	0xad, 0x92, 0xc5, 0x58, 0x25, 0x39, 0x21, 0x6c, 0xfc, 0x07, 0x0b, 0xe6, 0x95, 0x45, 0x28, 0xdf,
-	0x77, 0x13, 0xe6, 0xa4, 0xef, 0xd1, 0x7e, 0xef, 0x78, 0xda, 0xef, 0x9d, 0xef, 0x38, 0x43, 0x46,
-	0x83, 0x56, 0xf3, 0xfd, 0x89, 0x6d, 0x7d, 0x34, 0xb1, 0x1f, 0x3d, 0x4c, 0x68, 0x3a, 0x3a, 0x69,
-	0x7f, 0xa9, 0x09, 0xa3, 0x53, 0xe2, 0x76, 0x2c, 0x54, 0x66, 0x75, 0x64, 0x5d, 0x06, 0xb5, 0x4b,
-	0x5e, 0x97, 0x86, 0x9c, 0x72, 0x9e, 0x5b, 0x04, 0x91, 0x38, 0x9c, 0xcd, 0x3b, 0x4e, 0xe0, 0xb9,
-	0x5e, 0x37, 0xac, 0xe7, 0x84, 0x4f, 0x8f, 0xe6, 0xf8, 0xa7, 0x16, 0x2c, 0x25, 0xcc, 0x5a, 0x31,
-	0xf1, 0x0c, 0x14, 0x43, 0xae, 0x29, 0xcd, 0x83, 0x61, 0x14, 0x3b, 0x02, 0xde, 0x5a, 0x50, 0x97,
-	0x2f, 0xca, 0x39, 0x51, 0xf8, 0xf7, 0xef, 0x6a, 0x7f, 0xb6, 0xa0, 0x2a, 0x02, 0x93, 0x7e, 0x6b,
-	0x08, 0xf2, 0x9e, 0x33, 0xa0, 0x4a, 0x55, 0x62, 0x6c, 0x44, 0x2b, 0x7e, 0x5c, 0x49, 0x47, 0xab,
-	0x59, 0x1d, 0xac, 0x75, 0xcf, 0x0e, 0xd6, 0x8a, 0xdf, 0x5d, 0x0d, 0x0a, 0xdc, 0xbc, 0xc7, 0xc2,
-	0xb9, 0x96, 0x89, 0x9c, 0xe0, 0x47, 0x61, 0x5e, 0x71, 0xa1, 0x44, 0x7b, 0x58, 0x80, 0x1d, 0x40,
-	0x51, 0x6a, 0x02, 0x7d, 0x05, 0xca, 0x51, 0x2a, 0x23, 0xb8, 0xcd, 0xb5, 0x8a, 0xfb, 0x13, 0x3b,
-	0xcb, 0x42, 0x12, 0x2f, 0x20, 0xdb, 0x0c, 0xfa, 0x56, 0xab, 0xbc, 0x3f, 0xb1, 0x25, 0x40, 0x85,
-	0x78, 0x74, 0x12, 0xf2, 0x3d, 0x1e, 0x37, 0xb9, 0x08, 0xf2, 0xad, 0xd2, 0xfe, 0xc4, 0x16, 0x73,
-	0x22, 0x3e, 0xf1, 0x45, 0xa8, 0x6e, 0xd3, 0xae, 0xd3, 0x1e, 0xab, 0x43, 0x6b, 0x9a, 0x1c, 0x3f,
-	0xd0, 0xd2, 0x34, 0x1e, 0x86, 0x6a, 0x74, 0xe2, 0x5b, 0x83, 0x50, 0xbd, 0x86, 0x4a, 0x04, 0x7b,
-	0x25, 0xc4, 0x3f, 0xb3, 0x40, 0xd9, 0x00, 0xc2, 0x46, 0xb6, 0xc3, 0x7d, 0x21, 0xec, 0x4f, 0x6c,
-	0x05, 0xd1, 0xc9, 0x0c, 0x7a, 0x0e, 0xe6, 0x42, 0x71, 0x22, 0x27, 0x96, 0x36, 0x2d, 0xb1, 0xd0,
-	0x3a, 0xc2, 0x4d, 0x64, 0x7f, 0x62, 0x6b, 0x44, 0xa2, 0x07, 0x68, 0x3d, 0x91, 0x10, 0x48, 0xc6,
-	0x16, 0xf6, 0x27, 0xb6, 0x01, 0x35, 0x13, 0x04, 0xfc, 0x99, 0x05, 0x95, 0xeb, 0x8e, 0x1b, 0x99,
-	0x50, 0x5d, 0xab, 0x28, 0xf6, 0xd5, 0x12, 0xc0, 0x2d, 0xb1, 0x43, 0xfb, 0xce, 0xf8, 0x82, 0x1f,
-	0x08, 0xba, 0xf3, 0x24, 0x9a, 0xc7, 0x31, 0x3c, 0x3f, 0x35, 0x86, 0x17, 0x66, 0x77, 0xed, 0xff,
-	0x5f, 0x47, 0x7a, 0x39, 0x5f, 0xca, 0x2e, 0xe6, 0xf0, 0x7b, 0x16, 0x54, 0x25, 0xf3, 0xca, 0xf2,
-	0xbe, 0x0b, 0x45, 0x29, 0x1b, 0xc1, 0xfe, 0x7f, 0x71, 0x4c, 0xa7, 0x66, 0x71, 0x4a, 0x8a, 0x26,
-	0x7a, 0x01, 0x16, 0x3a, 0x81, 0x3f, 0x1c, 0xd2, 0xce, 0x8e, 0x72, 0x7f, 0xd9, 0xb4, 0xfb, 0xdb,
-	0x32, 0xd7, 0x49, 0x0a, 0x1d, 0xff, 0xc5, 0x82, 0x79, 0xe5, 0x4c, 0x94, 0xba, 0x22, 0x11, 0x5b,
-	0xf7, 0x1c, 0x3d, 0xb3, 0xb3, 0x46, 0xcf, 0x65, 0x28, 0x76, 0x79, 0x7c, 0xd1, 0x0e, 0x49, 0xcd,
-	0x66, 0x8b, 0xaa, 0xf8, 0x32, 0x2c, 0x68, 0x56, 0x0e, 0xf1, 0xa8, 0x2b, 0x69, 0x8f, 0x7a, 0xa9,
-	0x43, 0x3d, 0xe6, 0xee, 0xba, 0x91, 0x8f, 0x54, 0xf8, 0xf8, 0x87, 0x16, 0x2c, 0xa6, 0x51, 0xd0,
-	0x56, 0xaa, 0xb0, 0x78, 0xe4, 0x70, 0x72, 0x66, 0x4d, 0xa1, 0x49, 0xab, 0xca, 0xe2, 0xa9, 0xbb,
-	0x55, 0x16, 0x35, 0xd3, 0xc9, 0x94, 0x95, 0x57, 0xc0, 0x3f, 0xb1, 0x60, 0x3e, 0xa1, 0x4b, 0xf4,
-	0x0c, 0xe4, 0x77, 0x03, 0x7f, 0x30, 0x93, 0xa2, 0xc4, 0x0e, 0xf4, 0x35, 0xc8, 0x32, 0x7f, 0x26,
-	0x35, 0x65, 0x99, 0xcf, 0xb5, 0xa4, 0xd8, 0xcf, 0xc9, 0xbc, 0x5d, 0xce, 0xf0, 0x53, 0x50, 0x16,
-	0x0c, 0x5d, 0x73, 0xdc, 0x60, 0x6a, 0xc0, 0x98, 0xce, 0xd0, 0x73, 0x70, 0x44, 0x3a, 0xc3, 0xe9,
-	0x9b, 0xab, 0xd3, 0x36, 0x57, 0xf5, 0xe6, 0x13, 0x50, 0x10, 0x49, 0x07, 0xdf, 0xd2, 0x71, 0x98,
-	0xa3, 0xb7, 0xf0, 0x31, 0x3e, 0x06, 0x4b, 0xfc, 0x0d, 0xd2, 0x20, 0xdc, 0xf4, 0x47, 0x1e, 0xd3,
-	0x75, 0xd3, 0x69, 0xa8, 0x25, 0xc1, 0xca, 0x4a, 0x6a, 0x50, 0x68, 0x73, 0x80, 0xa0, 0x31, 0x4f,
-	0xe4, 0x04, 0xff, 0xd2, 0x02, 0x74, 0x91, 0x32, 0x71, 0xca, 0xa5, 0xad, 0xe8, 0x79, 0xac, 0x40,
-	0x69, 0xe0, 0xb0, 0x76, 0x8f, 0x06, 0xa1, 0xce, 0x5f, 0xf4, 0xfc, 0x8b, 0x48, 0x3c, 0xf1, 0x59,
-	0x58, 0x4a, 0xdc, 0x52, 0xf1, 0xb4, 0x02, 0xa5, 0xb6, 0x82, 0xa9, 0x90, 0x17, 0xcd, 0xf1, 0x6f,
-	0xb3, 0x50, 0xd2, 0x69, 0x1d, 0x3a, 0x0b, 0x95, 0x5d, 0xd7, 0xeb, 0xd2, 0x60, 0x18, 0xb8, 0x4a,
-	0x04, 0x79, 0x99, 0xe6, 0x19, 0x60, 0x62, 0x4e, 0xd0, 0x13, 0x30, 0x37, 0x0a, 0x69, 0xf0, 0x96,
-	0x2b, 0x5f, 0x7a, 0xb9, 0x55, 0xdb, 0x9b, 0xd8, 0xc5, 0xd7, 0x42, 0x1a, 0x5c, 0xda, 0xe2, 0xc1,
-	0x67, 0x24, 0x46, 0x44, 0x7e, 0x77, 0xd0, 0xcb, 0xca, 0x4c, 0x45, 0x02, 0xd7, 0xfa, 0x3a, 0xbf,
-	0x7e, 0xca, 0xd5, 0x0d, 0x03, 0x7f, 0x40, 0x59, 0x8f, 0x8e, 0xc2, 0x66, 0xdb, 0x1f, 0x0c, 0x7c,
-	0xaf, 0x29, 0x7a, 0x07, 0x82, 0x69, 0x1e, 0x41, 0xf9, 0x76, 0x65, 0xb9, 0xd7, 0x61, 0x8e, 0xf5,
-	0x02, 0x7f, 0xd4, 0xed, 0x89, 0xc0, 0x90, 0x6b, 0x9d, 0x9b, 0x9d, 0x9e, 0xa6, 0x40, 0xf4, 0x00,
-	0x3d, 0xcc, 0xa5, 0x45, 0xdb, 0xb7, 0xc2, 0xd1, 0x40, 0xd6, 0x9e, 0xad, 0xc2, 0xfe, 0xc4, 0xb6,
-	0x9e, 0x20, 0x11, 0x18, 0x9f, 0x87, 0xf9, 0x44, 0x2a, 0x8c, 0xce, 0x40, 0x3e, 0xa0, 0xbb, 0xda,
-	0x15, 0xa0, 0x83, 0x19, 0xb3, 0x8c, 0xfe, 0x1c, 0x87, 0x88, 0x4f, 0xfc, 0x83, 0x2c, 0xd8, 0x46,
-	0xd5, 0x7f, 0xc1, 0x0f, 0x5e, 0xa1, 0x2c, 0x70, 0xdb, 0x57, 0x9c, 0x01, 0xd5, 0xe6, 0x65, 0x43,
-	0x65, 0x20, 0x80, 0x6f, 0x19, 0xaf, 0x08, 0x06, 0x11, 0x1e, 0x7a, 0x08, 0x40, 0x3c, 0x3b, 0xb9,
-	0x2e, 0x1f, 0x54, 0x59, 0x40, 0xc4, 0xf2, 0x66, 0x42, 0xd8, 0xcd, 0x19, 0x85, 0xa3, 0x84, 0x7c,
-	0x29, 0x2d, 0xe4, 0x99, 0xe9, 0x44, 0x92, 0x35, 0x9f, 0x4b, 0x21, 0xf9, 0x5c, 0xf0, 0x3f, 0x2c,
-	0x68, 0x6c, 0xeb, 0x9b, 0xdf, 0xa3, 0x38, 0x34, 0xbf, 0xd9, 0xfb, 0xc4, 0x6f, 0xee, 0x3e, 0xf2,
-	0x9b, 0x4f, 0xf1, 0xdb, 0x00, 0xd8, 0x76, 0x3d, 0x7a, 0xc1, 0xed, 0x33, 0x1a, 0x4c, 0x29, 0x92,
-	0x7e, 0x94, 0x8b, 0x3d, 0x0e, 0xa1, 0xbb, 0x5a, 0x06, 0x9b, 0x86, 0x9b, 0xbf, 0x1f, 0x2c, 0x66,
-	0xef, 0x23, 0x8b, 0xb9, 0x94, 0x07, 0xf4, 0x60, 0x6e, 0x57, 0xb0, 0x27, 0x23, 0x76, 0xa2, 0xff,
-	0x14, 0xf3, 0xde, 0xfa, 0xa6, 0x3a, 0xfc, 0xe9, 0xbb, 0x24, 0x5c, 0xa2, 0x8f, 0xd8, 0x0c, 0xc7,
-	0x1e, 0x73, 0xde, 0x31, 0xf6, 0x13, 0x7d, 0x08, 0x72, 0x54, 0x4e, 0x57, 0x98, 0x9a, 0xd3, 0x3d,
-	0xaf, 0x8e, 0xf9, 0x5f, 0xf2, 0x3a, 0xdc, 0x8d, 0x1d, 0xac, 0x50, 0x8a, 0x72, 0xb0, 0x8f, 0xdc,
-	0xed, 0xf9, 0xcb, 0x47, 0x8f, 0xd6, 0x92, 0xa5, 0x59, 0x35, 0x2a, 0xcd, 0x3a, 0xf4, 0x9d, 0x44,
-	0x5d, 0x86, 0xff, 0x68, 0xc1, 0xe2, 0x45, 0xca, 0x92, 0xd9, 0xd8, 0x03, 0xa4, 0x7c, 0xfc, 0x12,
-	0x1c, 0x35, 0xee, 0xaf, 0xe4, 0xf4, 0x64, 0x2a, 0x05, 0x3b, 0x16, 0x4b, 0x4a, 0xc8, 0x40, 0x55,
-	0xb6, 0xc9, 0xec, 0xeb, 0x1a, 0x54, 0x8c, 0x45, 0x74, 0x3e, 0x95, 0x77, 0x2d, 0xa5, 0x1a, 0xba,
-	0x3c, 0x77, 0x68, 0xd5, 0x14, 0x4f, 0xb2, 0x7e, 0x55, 0x59, 0x75, 0x94, 0xa3, 0xec, 0x00, 0x12,
-	0x8a, 0x15, 0x64, 0xcd, 0x28, 0x29, 0xa0, 0x2f, 0x47, 0x09, 0x58, 0x34, 0x47, 0x0f, 0x43, 0x3e,
-	0xf0, 0xef, 0xe8, 0x84, 0x7a, 0x3e, 0x3e, 0x92, 0xf8, 0x77, 0x88, 0x58, 0xc2, 0xcf, 0x41, 0x8e,
-	0xf8, 0x77, 0x50, 0x03, 0x20, 0x70, 0xbc, 0x2e, 0xbd, 0x11, 0x95, 0x72, 0x55, 0x62, 0x40, 0x0e,
-	0xc9, 0x60, 0x36, 0xe1, 0xa8, 0x79, 0x23, 0xa9, 0xee, 0x75, 0x98, 0x7b, 0x75, 0x64, 0x8a, 0xab,
-	0x96, 0x12, 0x97, 0xec, 0x18, 0x68, 0x24, 0x6e, 0x33, 0x10, 0xc3, 0xd1, 0x49, 0x28, 0x33, 0xe7,
-	0x66, 0x9f, 0x5e, 0x89, 0x9d, 0x65, 0x0c, 0xe0, 0xab, 0xbc, 0x0a, 0xbd, 0x61, 0xa4, 0x62, 0x31,
-	0x00, 0x3d, 0x0e, 0x8b, 0xf1, 0x9d, 0xaf, 0x05, 0x74, 0xd7, 0x7d, 0x47, 0x68, 0xb8, 0x4a, 0x0e,
-	0xc0, 0xd1, 0x1a, 0x1c, 0x89, 0x61, 0x3b, 0x22, 0xe5, 0xc9, 0x0b, 0xd4, 0x34, 0x98, 0xcb, 0x46,
-	0xb0, 0xfb, 0xe2, 0xed, 0x91, 0xd3, 0x17, 0xcf, 0xb4, 0x4a, 0x0c, 0x08, 0xfe, 0x93, 0x05, 0x47,
-	0xa5, 0xaa, 0xf9, 0x1b, 0x78, 0x10, 0xad, 0xfe, 0x57, 0x16, 0x20, 0x93, 0x03, 0x65, 0x5a, 0x5f,
-	0x35, 0x3b, 0x52, 0x3c, 0xa7, 0xaa, 0x88, 0xe2, 0x5a, 0x82, 0xe2, 0xa6, 0x12, 0x86, 0x62, 0x5b,
-	0x76, 0xde, 0x44, 0x0b, 0x5d, 0x56, 0xef, 0x12, 0x42, 0xd4, 0x37, 0xb2, 0xa1, 0x70, 0x73, 0xcc,
-	0x68, 0xa8, 0x6a, 0x6f, 0xd1, 0x74, 0x10, 0x00, 0x22, 0xbf, 0xf8, 0x59, 0xd4, 0x63, 0xc2, 0x6a,
-	0xf2, 0xf1, 0x59, 0x0a, 0x44, 0xf4, 0x00, 0xff, 0x2b, 0x0b, 0xf3, 0x37, 0xfc, 0xfe, 0x28, 0x0e,
-	0xaf, 0x0f, 0x52, 0x68, 0x49, 0x34, 0x04, 0x0a, 0xba, 0x21, 0x80, 0x20, 0x1f, 0x32, 0x3a, 0x14,
-	0x96, 0x95, 0x23, 0x62, 0x8c, 0x30, 0x54, 0x99, 0x13, 0x74, 0x29, 0x93, 0x65, 0x56, 0xbd, 0x28,
-	0xf2, 0xdf, 0x04, 0x0c, 0xad, 0x42, 0xc5, 0xe9, 0x76, 0x03, 0xda, 0x75, 0x18, 0x6d, 0x8d, 0xeb,
-	0x73, 0xe2, 0x30, 0x13, 0x84, 0x2e, 0xc3, 0x42, 0xdb, 0x69, 0xf7, 0x5c, 0xaf, 0x7b, 0x75, 0xc8,
-	0x5c, 0xdf, 0x0b, 0xeb, 0x25, 0xe1, 0xc1, 0x4f, 0xae, 0x9b, 0x3f, 0x49, 0xad, 0x6f, 0x26, 0x70,
-	0x94, 0x1f, 0x4b, 0xed, 0xc4, 0x6f, 0xc0, 0x82, 0x16, 0xbc, 0x32, 0x8f, 0x33, 0x30, 0xf7, 0xb6,
-	0x80, 0x4c, 0x69, 0xf6, 0x49, 0x54, 0x45, 0x4a, 0xa3, 0x25, 0x7f, 0xd4, 0xd0, 0xfc, 0xe3, 0xcb,
-	0x50, 0x94, 0xe8, 0xe8, 0xa4, 0x59, 0x78, 0xc9, 0xdc, 0x93, 0xcf, 0x55, 0x15, 0x85, 0xa1, 0x28,
-	0x09, 0x29, 0x23, 0x12, 0x76, 0x26, 0x21, 0x44, 0x7d, 0xe3, 0x1f, 0x67, 0xe1, 0xd8, 0x16, 0x65,
-	0xb4, 0xcd, 0x68, 0xe7, 0x82, 0x4b, 0xfb, 0x9d, 0x2f, 0xb4, 0x27, 0x10, 0x75, 0xf6, 0x72, 0x46,
-	0x67, 0x8f, 0xfb, 0xb0, 0xbe, 0xeb, 0xd1, 0x6d, 0xa3, 0x35, 0x14, 0x03, 0x62, 0x19, 0x15, 0xcc,
-	0xa6, 0x91, 0xb6, 0x91, 0xa2, 0x61, 0x23, 0x71, 0x43, 0x70, 0x2e, 0xd1, 0xc3, 0xd4, 0x15, 0x68,
-	0x29, 0x2e, 0x5f, 0xf1, 0xef, 0x2d, 0x58, 0x4e, 0xcb, 0x45, 0xa9, 0xf1, 0x45, 0x28, 0xee, 0x0a,
-	0xc8, 0xc1, 0xb6, 0x73, 0x62, 0x87, 0xec, 0x5c, 0x48, 0x54, 0xb3, 0x73, 0x21, 0x21, 0xe8, 0xb1,
-	0xc4, 0x0f, 0x56, 0xad, 0xa5, 0xfd, 0x89, 0x7d, 0x44, 0x00, 0x0c, 0x5c, 0xc5, 0xcc, 0xe9, 0xe8,
-	0xe2, 0xb9, 0xb8, 0x25, 0x22, 0x21, 0x26, 0x61, 0xd5, 0xdf, 0xfc, 0xb7, 0x05, 0xf3, 0x89, 0x8b,
-	0x08, 0x11, 0xf1, 0x27, 0xa0, 0xc2, 0x83, 0x9c, 0xa0, 0xc7, 0x20, 0xcf, 0xc6, 0x43, 0x15, 0x15,
-	0x5a, 0xc7, 0x3e, 0x9b, 0xd8, 0x47, 0x13, 0xdb, 0xae, 0x8f, 0x87, 0x94, 0x08, 0x14, 0xfe, 0x72,
-	0xda, 0x4e, 0xd0, 0x71, 0x3d, 0xa7, 0xef, 0x32, 0xa9, 0x9d, 0x3c, 0x31, 0x41, 0xdc, 0x1d, 0x0d,
-	0x9d, 0x20, 0xd4, 0x49, 0x60, 0x59, 0xba, 0x23, 0x05, 0x22, 0x7a, 0x20, 0x9a, 0x3b, 0xb7, 0x28,
-	0x6b, 0xf7, 0x64, 0x58, 0x50, 0xcd, 0x1d, 0x01, 0x49, 0x34, 0x77, 0x04, 0x04, 0x6d, 0x40, 0xe9,
-	0x7b, 0xa1, 0xef, 0x5d, 0x73, 0x58, 0x4f, 0x3e, 0xe8, 0xd6, 0xf2, 0xfe, 0xc4, 0x46, 0x1a, 0x66,
-	0xec, 0x88, 0xf0, 0xf0, 0x2f, 0xac, 0xd8, 0xa0, 0xe5, 0xbb, 0xff, 0xd2, 0x19, 0x34, 0xfe, 0x76,
-	0x6c, 0x5b, 0xfa, 0x8a, 0xca, 0xb6, 0x5e, 0x80, 0x85, 0x4e, 0x62, 0xe5, 0x70, 0x1b, 0x93, 0xcd,
-	0xee, 0x14, 0x3a, 0x1e, 0xc5, 0xba, 0x17, 0x90, 0x43, 0x74, 0x9f, 0x52, 0x68, 0xf6, 0xa0, 0x42,
-	0x63, 0x4d, 0xe5, 0xee, 0xae, 0xa9, 0xc7, 0x1f, 0x81, 0x72, 0xf4, 0xc3, 0x26, 0xaa, 0xc0, 0xdc,
-	0x85, 0xab, 0xe4, 0xf5, 0xf3, 0x64, 0x6b, 0x31, 0x83, 0xaa, 0x50, 0x6a, 0x9d, 0xdf, 0x7c, 0x59,
-	0xcc, 0xac, 0x8d, 0xdf, 0x14, 0x75, 0xb2, 0x13, 0xa0, 0x6f, 0x40, 0x41, 0x66, 0x30, 0xcb, 0x31,
-	0x73, 0xe6, 0x6f, 0x7e, 0x2b, 0xc7, 0x0f, 0xc0, 0xa5, 0x94, 0x70, 0xe6, 0x8c, 0x85, 0xae, 0x40,
-	0x45, 0x00, 0x55, 0x57, 0xfd, 0x64, 0xba, 0xb9, 0x9d, 0xa0, 0xf4, 0xd0, 0x21, 0xab, 0x06, 0xbd,
-	0x73, 0x50, 0x90, 0x02, 0x5b, 0x4e, 0x25, 0x9a, 0x53, 0x6e, 0x93, 0xf8, 0x9d, 0x01, 0x67, 0xd0,
-	0xb3, 0x90, 0xbf, 0xee, 0xb8, 0x7d, 0x64, 0xe4, 0xb9, 0x46, 0x33, 0x7c, 0x65, 0x39, 0x0d, 0x36,
-	0x8e, 0x7d, 0x3e, 0xea, 0xe9, 0x1f, 0x4f, 0x37, 0x16, 0xf5, 0xf6, 0xfa, 0xc1, 0x85, 0xe8, 0xe4,
-	0xab, 0xb2, 0xf3, 0xac, 0xdb, 0x5b, 0xe8, 0xa1, 0xe4, 0x51, 0xa9, 0x6e, 0xd8, 0x4a, 0xe3, 0xb0,
-	0xe5, 0x88, 0xe0, 0x36, 0x54, 0x8c, 0xd6, 0x92, 0x29, 0xd6, 0x83, 0x7d, 0x31, 0x53, 0xac, 0x53,
-	0xfa, 0x51, 0x38, 0x83, 0x2e, 0x42, 0x89, 0x57, 0x07, 0xe2, 0x27, 0xa8, 0x13, 0xe9, 0x22, 0xc0,
-	0x48, 0xfe, 0x56, 0x4e, 0x4e, 0x5f, 0x8c, 0x08, 0x7d, 0x0b, 0xca, 0x17, 0x29, 0x53, 0x51, 0xef,
-	0x78, 0x3a, 0x6c, 0x4e, 0x91, 0x54, 0x32, 0xf4, 0xe2, 0x0c, 0x7a, 0x43, 0x14, 0x2a, 0x49, 0x97,
-	0x8e, 0xec, 0x43, 0x5c, 0x77, 0x74, 0xaf, 0xd5, 0xc3, 0x11, 0x22, 0xca, 0xaf, 0x27, 0x28, 0xab,
-	0x5c, 0xc3, 0x3e, 0xe4, 0xc1, 0x46, 0x94, 0xed, 0xbb, 0xfc, 0x41, 0x05, 0x67, 0x36, 0xde, 0xd4,
-	0xff, 0xd1, 0xd8, 0x72, 0x98, 0x83, 0xae, 0xc2, 0x82, 0x90, 0x65, 0xf4, 0x27, 0x8e, 0x84, 0xcd,
-	0x1f, 0xf8, 0xc7, 0x48, 0xc2, 0xe6, 0x0f, 0xfe, 0x73, 0x04, 0x67, 0x5a, 0x6f, 0x7e, 0xf0, 0x71,
-	0x23, 0xf3, 0xe1, 0xc7, 0x8d, 0xcc, 0xa7, 0x1f, 0x37, 0xac, 0xef, 0xef, 0x35, 0xac, 0x5f, 0xef,
-	0x35, 0xac, 0xf7, 0xf7, 0x1a, 0xd6, 0x07, 0x7b, 0x0d, 0xeb, 0x6f, 0x7b, 0x0d, 0xeb, 0xef, 0x7b,
-	0x8d, 0xcc, 0xa7, 0x7b, 0x0d, 0xeb, 0xdd, 0x4f, 0x1a, 0x99, 0x0f, 0x3e, 0x69, 0x64, 0x3e, 0xfc,
-	0xa4, 0x91, 0xf9, 0xce, 0xa3, 0x77, 0x2f, 0xdf, 0xa5, 0x5b, 0x2c, 0x8a, 0xaf, 0x27, 0xff, 0x13,
-	0x00, 0x00, 0xff, 0xff, 0x6c, 0x7a, 0xae, 0xe7, 0x7b, 0x24, 0x00, 0x00,
+	// 2799 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0xcf, 0x6f, 0x1b, 0xc7,
+	0xf5, 0xd7, 0x92, 0xcb, 0x5f, 0x8f, 0x94, 0x2c, 0x8f, 0x68, 0x99, 0xa0, 0x6d, 0x52, 0x19, 0x7c,
+	0xbf, 0x89, 0x12, 0x3b, 0xa4, 0xad, 0x7c, 0x93, 0x6f, 0xe2, 0x34, 0x6d, 0x4d, 0x29, 0x76, 0xec,
+	0x38, 0xb6, 0x33, 0x72, 0x9c, 0xb4, 0x68, 0x10, 0xac, 0xc9, 0x11, 0xb9, 0x35, 0xb9, 0x4b, 0xef,
+	0x0e, 0xe3, 0xe8, 0xd6, 0x7f, 0xa0, 0x68, 0x80, 0xa2, 0x68, 0x7b, 0x29, 0x50, 0xa0, 0x40, 0x8b,
+	0x02, 0xb9, 0x14, 0x3d, 0xf4, 0x50, 0xb4, 0x97, 0x1e, 0xd2, 0x5b, 0x8e, 0x41, 0x0e, 0x6c, 0xa3,
+	0x5c, 0x0a, 0x01, 0x05, 0x72, 0x6a, 0x81, 0xf4, 0x52, 0xcc, 0xaf, 0xdd, 0xd9, 0x95, 0x58, 0x97,
+	0xae, 0x8b, 0x24, 0x17, 0xee, 0xce, 0x67, 0xde, 0xbc, 0x99, 0xf7, 0x63, 0xde, 0xbc, 0x79, 0x4b,
+	0x38, 0x31, 0xbe, 0xd3, 0x6f, 0x0f, 0xfd, 0xfe, 0x38, 0xf0, 0x99, 0x1f, 0xbd, 0xb4, 0xc4, 0x2f,
+	0x2a, 0xea, 0x76, 0xbd, 0xda, 0xf7, 0xfb, 0xbe, 0xa4, 0xe1, 0x6f, 0xb2, 0xbf, 0xde, 0xec, 0xfb,
+	0x7e, 0x7f, 0x48, 0xdb, 0xa2, 0x75, 0x7b, 0xb2, 0xd3, 0x66, 0xee, 0x88, 0x86, 0xcc, 0x19, 0x8d,
+	0x15, 0xc1, 0x9a, 0xe2, 0x7e, 0x77, 0x38, 0xf2, 0x7b, 0x74, 0xd8, 0x0e, 0x99, 0xc3, 0x42, 0xf9,
+	0xab, 0x28, 0x56, 0x38, 0xc5, 0x78, 0x12, 0x0e, 0xc4, 0x8f, 0x02, 0xcf, 0x72, 0x30, 0x64, 0x7e,
+	0xe0, 0xf4, 0x69, 0xbb, 0x3b, 0x98, 0x78, 0x77, 0xda, 0x5d, 0xa7, 0x3b, 0xa0, 0xed, 0x80, 0x86,
+	0x93, 0x21, 0x0b, 0x65, 0x83, 0xed, 0x8e, 0xa9, 0x62, 0x83, 0x7f, 0x6d, 0xc1, 0xb1, 0xab, 0xce,
+	0x6d, 0x3a, 0xbc, 0xe9, 0xdf, 0x72, 0x86, 0x13, 0x1a, 0x12, 0x1a, 0x8e, 0x7d, 0x2f, 0xa4, 0x68,
+	0x13, 0xf2, 0x43, 0xde, 0x11, 0xd6, 0xac, 0xb5, 0xec, 0x7a, 0x79, 0xe3, 0x74, 0x2b, 0x12, 0xf2,
+	0xd0, 0x01, 0x12, 0x0d, 0x5f, 0xf4, 0x58, 0xb0, 0x4b, 0xd4, 0xd0, 0xfa, 0x2d, 0x28, 0x1b, 0x30,
+	0x5a, 0x86, 0xec, 0x1d, 0xba, 0x5b, 0xb3, 0xd6, 0xac, 0xf5, 0x12, 0xe1, 0xaf, 0xe8, 0x1c, 0xe4,
+	0xde, 0xe6, 0x6c, 0x6a, 0x99, 0x35, 0x6b, 0xbd, 0xbc, 0x71, 0x22, 0x9e, 0xe4, 0x35, 0xcf, 0xbd,
+	0x3b, 0xa1, 0x62, 0xb4, 0x9a, 0x48, 0x52, 0x9e, 0xcf, 0x3c, 0x6b, 0xe1, 0xd3, 0x70, 0xf4, 0x40,
+	0x3f, 0x5a, 0x85, 0xbc, 0xa0, 0x90, 0x2b, 0x2e, 0x11, 0xd5, 0xc2, 0x55, 0x40, 0xdb, 0x2c, 0xa0,
+	0xce, 0x88, 0x38, 0x8c, 0xaf, 0xf7, 0xee, 0x84, 0x86, 0x0c, 0xbf, 0x02, 0x2b, 0x09, 0x54, 0x89,
+	0xfd, 0x0c, 0x94, 0xc3, 0x18, 0x56, 0xb2, 0x57, 0xe3, 0x65, 0xc5, 0x63, 0x88, 0x49, 0x88, 0x7f,
+	0x62, 0x01, 0xc4, 0x7d, 0xa8, 0x01, 0x20, 0x7b, 0x5f, 0x72, 0xc2, 0x81, 0x10, 0xd8, 0x26, 0x06,
+	0x82, 0xce, 0xc0, 0xd1, 0xb8, 0x75, 0xcd, 0xdf, 0x1e, 0x38, 0x41, 0x4f, 0xe8, 0xc0, 0x26, 0x07,
+	0x3b, 0x10, 0x02, 0x3b, 0x70, 0x18, 0xad, 0x65, 0xd7, 0xac, 0xf5, 0x2c, 0x11, 0xef, 0x5c, 0x5a,
+	0x46, 0x3d, 0xc7, 0x63, 0x35, 0x5b, 0xa8, 0x53, 0xb5, 0x38, 0xce, 0x3d, 0x82, 0x86, 0xb5, 0xdc,
+	0x9a, 0xb5, 0xbe, 0x48, 0x54, 0x0b, 0xff, 0x2d, 0x0b, 0x95, 0x57, 0x27, 0x34, 0xd8, 0x55, 0x0a,
+	0x40, 0x0d, 0x28, 0x86, 0x74, 0x48, 0xbb, 0xcc, 0x0f, 0xa4, 0x45, 0x3a, 0x99, 0x9a, 0x45, 0x22,
+	0x0c, 0x55, 0x21, 0x37, 0x74, 0x47, 0x2e, 0x13, 0xcb, 0x5a, 0x24, 0xb2, 0x81, 0xce, 0x43, 0x2e,
+	0x64, 0x4e, 0xc0, 0xc4, 0x5a, 0xca, 0x1b, 0xf5, 0x96, 0x74, 0xe5, 0x96, 0x76, 0xe5, 0xd6, 0x4d,
+	0xed, 0xca, 0x9d, 0xe2, 0xfb, 0xd3, 0xe6, 0xc2, 0xbb, 0x7f, 0x6a, 0x5a, 0x44, 0x0e, 0x41, 0xcf,
+	0x40, 0x96, 0x7a, 0xbd, 0x9a, 0x3d, 0xc7, 0x48, 0x3e, 0x00, 0x9d, 0x83, 0x52, 0xcf, 0x0d, 0x68,
+	0x97, 0xb9, 0xbe, 0x27, 0xa4, 0x5a, 0xda, 0x58, 0x89, 0x2d, 0xb2, 0xa5, 0xbb, 0x48, 0x4c, 0x85,
+	0xce, 0x40, 0x3e, 0xe4, 0xaa, 0x0b, 0x6b, 0x05, 0xee, 0x0b, 0x9d, 0xea, 0xfe, 0xb4, 0xb9, 0x2c,
+	0x91, 0x33, 0xfe, 0xc8, 0x65, 0x74, 0x34, 0x66, 0xbb, 0x44, 0xd1, 0xa0, 0x27, 0xa0, 0xd0, 0xa3,
+	0x43, 0xca, 0x0d, 0x5e, 0x14, 0x06, 0x5f, 0x36, 0xd8, 0x8b, 0x0e, 0xa2, 0x09, 0xd0, 0x9b, 0x60,
+	0x8f, 0x87, 0x8e, 0x57, 0x2b, 0x09, 0x29, 0x96, 0x62, 0xc2, 0x1b, 0x43, 0xc7, 0xeb, 0x3c, 0xf7,
+	0xd1, 0xb4, 0xf9, 0x74, 0xdf, 0x65, 0x83, 0xc9, 0xed, 0x56, 0xd7, 0x1f, 0xb5, 0xfb, 0x81, 0xb3,
+	0xe3, 0x78, 0x4e, 0x7b, 0xe8, 0xdf, 0x71, 0xdb, 0x6f, 0x3f, 0xd5, 0xe6, 0x1b, 0xf4, 0xee, 0x84,
+	0x06, 0x2e, 0x0d, 0xda, 0x9c, 0x4d, 0x4b, 0x98, 0x84, 0x0f, 0x25, 0x82, 0x2d, 0xba, 0xc2, 0xfd,
+	0xcf, 0x0f, 0xe8, 0x26, 0xdf, 0xbd, 0x61, 0x0d, 0xc4, 0x2c, 0xc7, 0xe3, 0x59, 0x04, 0x4e, 0xe8,
+	0xce, 0xa5, 0xc0, 0x9f, 0x8c, 0x3b, 0x47, 0xf6, 0xa7, 0x4d, 0x93, 0x9e, 0x98, 0x8d, 0x2b, 0x76,
+	0x31, 0xbf, 0x5c, 0xc0, 0xef, 0x65, 0x01, 0x6d, 0x3b, 0xa3, 0xf1, 0x90, 0xce, 0x65, 0xfe, 0xc8,
+	0xd0, 0x99, 0x07, 0x36, 0x74, 0x76, 0x5e, 0x43, 0xc7, 0x56, 0xb3, 0xe7, 0xb3, 0x5a, 0xee, 0xdf,
+	0xb5, 0x5a, 0xfe, 0x0b, 0x6f, 0x35, 0x5c, 0x03, 0x9b, 0x73, 0xe6, 0xc1, 0x32, 0x70, 0xee, 0x09,
+	0xdb, 0x54, 0x08, 0x7f, 0xc5, 0x3b, 0x90, 0x97, 0x72, 0xa1, 0x7a, 0xda, 0x78, 0xc9, 0x7d, 0x1b,
+	0x1b, 0x2e, 0xab, 0x4d, 0xb2, 0x1c, 0x9b, 0x24, 0x2b, 0x95, 0x5d, 0x83, 0x42, 0x40, 0xef, 0x05,
+	0x2e, 0xa3, 0x62, 0x47, 0x16, 0x89, 0x6e, 0xe2, 0xdf, 0x5a, 0xb0, 0xa8, 0x7c, 0x45, 0x45, 0xc5,
+	0xdb, 0x50, 0x90, 0x51, 0x49, 0x47, 0xc4, 0xe3, 0xe9, 0x88, 0x78, 0xa1, 0xe7, 0x8c, 0x19, 0x0d,
+	0x3a, 0xed, 0xf7, 0xa7, 0x4d, 0xeb, 0xa3, 0x69, 0xf3, 0xb1, 0x59, 0xea, 0xd4, 0xe7, 0x96, 0x1a,
+	0x47, 0x34, 0x63, 0x74, 0x5a, 0xac, 0x9b, 0x85, 0xca, 0xe1, 0x8e, 0xb4, 0x44, 0xab, 0x75, 0xd9,
+	0xeb, 0xd3, 0x90, 0x73, 0xb6, 0xb9, 0xaf, 0x10, 0x49, 0xc3, 0x15, 0x70, 0xcf, 0x09, 0x3c, 0xd7,
+	0xeb, 0x87, 0xb5, 0xac, 0x88, 0xf6, 0x51, 0x1b, 0xff, 0xc8, 0x82, 0x95, 0x84, 0xc3, 0x2b, 0x21,
+	0x9e, 0x85, 0x7c, 0xc8, 0x6d, 0xa8, 0x65, 0x30, 0xdc, 0x65, 0x5b, 0xe0, 0x9d, 0x25, 0xb5, 0xf8,
+	0xbc, 0x6c, 0x13, 0x45, 0xff, 0xf0, 0x96, 0xf6, 0x07, 0x0b, 0x2a, 0xe2, 0xc8, 0xd2, 0xbb, 0x10,
+	0x81, 0xed, 0x39, 0x23, 0xaa, 0x8c, 0x28, 0xde, 0x8d, 0x73, 0x2c, 0x23, 0xec, 0xa2, 0x5a, 0xf3,
+	0x86, 0x5e, 0xeb, 0x81, 0x43, 0xaf, 0x15, 0xef, 0xc8, 0x2a, 0xe4, 0xb8, 0xe3, 0xef, 0x8a, 0xb0,
+	0x5b, 0x22, 0xb2, 0x81, 0x1f, 0x83, 0x45, 0x25, 0x85, 0x52, 0xed, 0xac, 0xa3, 0x77, 0x04, 0x79,
+	0x69, 0x09, 0xf4, 0x3f, 0x50, 0x8a, 0x92, 0x1c, 0x21, 0x6d, 0xb6, 0x93, 0xdf, 0x9f, 0x36, 0x33,
+	0x2c, 0x24, 0x71, 0x07, 0x6a, 0x9a, 0xe9, 0x80, 0xd5, 0x29, 0xed, 0x4f, 0x9b, 0x12, 0x50, 0x87,
+	0x3f, 0x3a, 0x09, 0xf6, 0x80, 0x9f, 0xa8, 0x5c, 0x05, 0x76, 0xa7, 0xb8, 0x3f, 0x6d, 0x8a, 0x36,
+	0x11, 0xbf, 0xf8, 0x12, 0x54, 0xae, 0xd2, 0xbe, 0xd3, 0xdd, 0x55, 0x93, 0x56, 0x35, 0x3b, 0x3e,
+	0xa1, 0xa5, 0x79, 0x3c, 0x02, 0x95, 0x68, 0xc6, 0xb7, 0x46, 0xa1, 0xda, 0x27, 0xe5, 0x08, 0x7b,
+	0x25, 0xc4, 0x3f, 0xb6, 0x40, 0xf9, 0x00, 0xc2, 0x46, 0x1e, 0xc4, 0xa3, 0x24, 0xec, 0x4f, 0x9b,
+	0x0a, 0xd1, 0x69, 0x0e, 0x7a, 0x1e, 0x0a, 0xa1, 0x98, 0x91, 0x33, 0x4b, 0xbb, 0x96, 0xe8, 0xe8,
+	0x1c, 0xe1, 0x2e, 0xb2, 0x3f, 0x6d, 0x6a, 0x42, 0xa2, 0x5f, 0x50, 0x2b, 0x91, 0x2a, 0x48, 0xc1,
+	0x96, 0xf6, 0xa7, 0x4d, 0x03, 0x35, 0x53, 0x07, 0xfc, 0x99, 0x05, 0xe5, 0x9b, 0x8e, 0x1b, 0xb9,
+	0x50, 0x4d, 0x9b, 0x28, 0x8e, 0xe2, 0x12, 0xe0, 0x9e, 0xd8, 0xa3, 0x43, 0x67, 0xf7, 0xa2, 0x1f,
+	0x08, 0xbe, 0x8b, 0x24, 0x6a, 0xc7, 0xa7, 0xbb, 0x7d, 0xe8, 0xe9, 0x9e, 0x9b, 0x3f, 0xe8, 0xff,
+	0x77, 0x43, 0xec, 0x15, 0xbb, 0x98, 0x59, 0xce, 0xe2, 0xf7, 0x2c, 0xa8, 0x48, 0xe1, 0x95, 0xe7,
+	0x7d, 0x0b, 0xf2, 0x52, 0x37, 0x42, 0xfc, 0x7f, 0x11, 0x98, 0x4e, 0xcf, 0x13, 0x94, 0x14, 0x4f,
+	0xf4, 0x35, 0x58, 0xea, 0x05, 0xfe, 0x78, 0x4c, 0x7b, 0xdb, 0x2a, 0xfc, 0x65, 0xd2, 0xe1, 0x6f,
+	0xcb, 0xec, 0x27, 0x29, 0x72, 0xfc, 0x47, 0x0b, 0x16, 0x55, 0x30, 0x51, 0xe6, 0x8a, 0x54, 0x6c,
+	0x3d, 0xf0, 0xb9, 0x9a, 0x99, 0xf7, 0x5c, 0x5d, 0x85, 0x7c, 0x9f, 0x9f, 0x3c, 0x3a, 0x20, 0xa9,
+	0xd6, 0x7c, 0xe7, 0x2d, 0xbe, 0x02, 0x4b, 0x5a, 0x94, 0x19, 0x11, 0xb5, 0x9e, 0x8e, 0xa8, 0x97,
+	0x7b, 0xd4, 0x63, 0xee, 0x8e, 0x1b, 0xc5, 0x48, 0x45, 0x8f, 0xbf, 0x67, 0xc1, 0x72, 0x9a, 0x04,
+	0x6d, 0xa5, 0xae, 0x1c, 0x8f, 0xce, 0x66, 0x67, 0xde, 0x36, 0x34, 0x6b, 0x75, 0xe7, 0x78, 0xfa,
+	0x7e, 0x77, 0x8e, 0xaa, 0x19, 0x64, 0x4a, 0x2a, 0x2a, 0xe0, 0x1f, 0x5a, 0xb0, 0x98, 0xb0, 0x25,
+	0x7a, 0x16, 0xec, 0x9d, 0xc0, 0x1f, 0xcd, 0x65, 0x28, 0x31, 0x02, 0xfd, 0x1f, 0x64, 0x98, 0x3f,
+	0x97, 0x99, 0x32, 0xcc, 0xe7, 0x56, 0x52, 0xe2, 0x67, 0x65, 0x46, 0x2f, 0x5b, 0xf8, 0x69, 0x28,
+	0x09, 0x81, 0x6e, 0x38, 0x6e, 0x70, 0xe8, 0x81, 0x71, 0xb8, 0x40, 0xcf, 0xc3, 0x11, 0x19, 0x0c,
+	0x0f, 0x1f, 0x5c, 0x39, 0x6c, 0x70, 0x45, 0x0f, 0x3e, 0x01, 0x39, 0x91, 0x8e, 0xf0, 0x21, 0x3d,
+	0x87, 0x39, 0x7a, 0x08, 0x7f, 0xc7, 0xc7, 0x60, 0x85, 0xef, 0x41, 0x1a, 0x84, 0x9b, 0xfe, 0xc4,
+	0x63, 0xfa, 0x46, 0x75, 0x06, 0xaa, 0x49, 0x58, 0x79, 0x49, 0x15, 0x72, 0x5d, 0x0e, 0x08, 0x1e,
+	0x8b, 0x44, 0x36, 0xf0, 0xcf, 0x2c, 0x40, 0x97, 0x28, 0x13, 0xb3, 0x5c, 0xde, 0x8a, 0xb6, 0x47,
+	0x1d, 0x8a, 0x23, 0x87, 0x75, 0x07, 0x34, 0x08, 0x75, 0x66, 0xa3, 0xdb, 0x9f, 0x47, 0x4a, 0x8a,
+	0xcf, 0xc1, 0x4a, 0x62, 0x95, 0x4a, 0xa6, 0x3a, 0x14, 0xbb, 0x0a, 0x53, 0x47, 0x5e, 0xd4, 0xc6,
+	0xbf, 0xca, 0x40, 0x51, 0x27, 0x7c, 0xe8, 0x1c, 0x94, 0x77, 0x5c, 0xaf, 0x4f, 0x83, 0x71, 0xe0,
+	0x2a, 0x15, 0xd8, 0x32, 0x01, 0x34, 0x60, 0x62, 0x36, 0xd0, 0x93, 0x50, 0x98, 0x84, 0x34, 0x78,
+	0xcb, 0x95, 0x3b, 0xbd, 0xd4, 0xa9, 0xee, 0x4d, 0x9b, 0xf9, 0xd7, 0x42, 0x1a, 0x5c, 0xde, 0xe2,
+	0x87, 0xcf, 0x44, 0xbc, 0x11, 0xf9, 0xec, 0xa1, 0x97, 0x95, 0x9b, 0x8a, 0xd4, 0xae, 0xf3, 0xff,
+	0x7c, 0xf9, 0xa9, 0x50, 0x37, 0x0e, 0xfc, 0x11, 0x65, 0x03, 0x3a, 0x09, 0xdb, 0x5d, 0x7f, 0x34,
+	0xf2, 0xbd, 0xb6, 0xa8, 0x2a, 0x08, 0xa1, 0xf9, 0x09, 0xca, 0x87, 0x2b, 0xcf, 0xbd, 0x09, 0x05,
+	0x36, 0x08, 0xfc, 0x49, 0x7f, 0x20, 0x0e, 0x86, 0x6c, 0xe7, 0xfc, 0xfc, 0xfc, 0x34, 0x07, 0xa2,
+	0x5f, 0xd0, 0x23, 0x5c, 0x5b, 0xb4, 0x7b, 0x27, 0x9c, 0x8c, 0xe4, 0xad, 0xb4, 0x93, 0xdb, 0x9f,
+	0x36, 0xad, 0x27, 0x49, 0x04, 0xe3, 0x0b, 0xb0, 0x98, 0x48, 0x92, 0xd1, 0x59, 0xb0, 0x03, 0xba,
+	0xa3, 0x43, 0x01, 0x3a, 0x98, 0x4b, 0xcb, 0xd3, 0x9f, 0xd3, 0x10, 0xf1, 0x8b, 0xbf, 0x9b, 0x81,
+	0xa6, 0x51, 0x0f, 0xb8, 0xe8, 0x07, 0xaf, 0x50, 0x16, 0xb8, 0xdd, 0x6b, 0xce, 0x88, 0x6a, 0xf7,
+	0x6a, 0x42, 0x79, 0x24, 0xc0, 0xb7, 0x8c, 0x5d, 0x04, 0xa3, 0x88, 0x0e, 0x9d, 0x02, 0x10, 0xdb,
+	0x4e, 0xf6, 0xcb, 0x0d, 0x55, 0x12, 0x88, 0xe8, 0xde, 0x4c, 0x28, 0xbb, 0x3d, 0xa7, 0x72, 0x94,
+	0x92, 0x2f, 0xa7, 0x95, 0x3c, 0x37, 0x9f, 0x48, 0xb3, 0xe6, 0x76, 0xc9, 0x25, 0xb7, 0x0b, 0xfe,
+	0xab, 0x05, 0x8d, 0xab, 0x7a, 0xe5, 0x0f, 0xa8, 0x0e, 0x2d, 0x6f, 0xe6, 0x21, 0xc9, 0x9b, 0x7d,
+	0x88, 0xf2, 0xda, 0x29, 0x79, 0x1b, 0x00, 0x57, 0x5d, 0x8f, 0x5e, 0x74, 0x87, 0x8c, 0x06, 0x87,
+	0x5c, 0x9f, 0xbe, 0x9f, 0x8d, 0x23, 0x0e, 0xa1, 0x3b, 0x5a, 0x07, 0x9b, 0x46, 0x98, 0x7f, 0x18,
+	0x22, 0x66, 0x1e, 0xa2, 0x88, 0xd9, 0x54, 0x04, 0xf4, 0xa0, 0xb0, 0x23, 0xc4, 0x93, 0x27, 0x76,
+	0xa2, 0x32, 0x15, 0xcb, 0xde, 0xf9, 0xaa, 0x9a, 0xfc, 0x99, 0xfb, 0x24, 0x5c, 0xa2, 0xc2, 0xd8,
+	0x0e, 0x77, 0x3d, 0xe6, 0xbc, 0x63, 0x8c, 0x27, 0x7a, 0x12, 0xe4, 0xa8, 0x9c, 0x2e, 0x77, 0x68,
+	0x4e, 0xf7, 0x82, 0x9a, 0xe6, 0x3f, 0xc9, 0xeb, 0x70, 0x1f, 0x56, 0x12, 0x46, 0x51, 0x01, 0xf6,
+	0xd1, 0xfb, 0x6d, 0x7f, 0xb9, 0xe9, 0xd1, 0x7a, 0xf2, 0x6a, 0x56, 0x89, 0xae, 0x66, 0x3d, 0xfa,
+	0x4e, 0xe2, 0x5e, 0x86, 0x7f, 0x67, 0xc1, 0xf2, 0x25, 0xca, 0x92, 0xd9, 0xd8, 0x97, 0xc8, 0xf8,
+	0xf8, 0x25, 0x38, 0x6a, 0xac, 0x5f, 0xe9, 0xe9, 0xa9, 0x54, 0x0a, 0x76, 0x2c, 0xd6, 0x94, 0xd0,
+	0x81, 0xba, 0xd9, 0x26, 0xb3, 0xaf, 0x1b, 0x50, 0x36, 0x3a, 0xd1, 0x85, 0x54, 0xde, 0xb5, 0x92,
+	0x2a, 0xf5, 0xf2, 0xdc, 0xa1, 0x53, 0x55, 0x32, 0xc9, 0xfb, 0xab, 0xca, 0xaa, 0xa3, 0x1c, 0x65,
+	0x1b, 0x90, 0x30, 0xac, 0x60, 0x6b, 0x9e, 0x92, 0x02, 0x7d, 0x39, 0x4a, 0xc0, 0xa2, 0x36, 0x7a,
+	0x04, 0xec, 0xc0, 0xbf, 0xa7, 0x13, 0xea, 0xc5, 0x78, 0x4a, 0xe2, 0xdf, 0x23, 0xa2, 0x0b, 0x3f,
+	0x0f, 0x59, 0xe2, 0xdf, 0xe3, 0xb5, 0xd4, 0xc0, 0xf1, 0xfa, 0xf4, 0x56, 0x74, 0x95, 0xab, 0x10,
+	0x03, 0x99, 0x91, 0xc1, 0x6c, 0xc2, 0x51, 0x73, 0x45, 0xd2, 0xdc, 0x2d, 0x28, 0xbc, 0x3a, 0x31,
+	0xd5, 0x55, 0x4d, 0xa9, 0x4b, 0x0c, 0x21, 0x9a, 0x88, 0xfb, 0x0c, 0xc4, 0x38, 0x3a, 0x09, 0x25,
+	0xe6, 0xdc, 0x1e, 0xd2, 0x6b, 0x71, 0xb0, 0x8c, 0x01, 0xde, 0xcb, 0x6f, 0xa1, 0xb7, 0x8c, 0x54,
+	0x2c, 0x06, 0xd0, 0x13, 0xb0, 0x1c, 0xaf, 0xf9, 0x46, 0x40, 0x77, 0xdc, 0x77, 0x84, 0x85, 0x2b,
+	0xe4, 0x00, 0x8e, 0xd6, 0xe1, 0x48, 0x8c, 0x6d, 0x8b, 0x94, 0xc7, 0x16, 0xa4, 0x69, 0x98, 0xeb,
+	0x46, 0x88, 0xfb, 0xe2, 0xdd, 0x89, 0x33, 0x14, 0xdb, 0xb4, 0x42, 0x0c, 0x04, 0xff, 0xde, 0x82,
+	0xa3, 0xd2, 0xd4, 0x7c, 0x0f, 0x7c, 0x19, 0xbd, 0xfe, 0xe7, 0x16, 0x20, 0x53, 0x02, 0xe5, 0x5a,
+	0xff, 0x6b, 0x56, 0xa4, 0x78, 0x4e, 0x55, 0x16, 0x97, 0x6b, 0x09, 0xc5, 0x45, 0x25, 0x0c, 0xf9,
+	0xae, 0xac, 0xc9, 0x89, 0xe2, 0xba, 0xbc, 0xbd, 0x4b, 0x84, 0xa8, 0x27, 0x2f, 0x3a, 0xdc, 0xde,
+	0x65, 0x34, 0x54, 0x77, 0x6f, 0x51, 0x74, 0x10, 0x00, 0x91, 0x0f, 0x3e, 0x17, 0xf5, 0x98, 0xf0,
+	0x1a, 0x3b, 0x9e, 0x4b, 0x41, 0x44, 0xbf, 0xe0, 0xbf, 0x67, 0x60, 0xf1, 0x96, 0x3f, 0x9c, 0x8c,
+	0xe8, 0x97, 0x50, 0xcf, 0xc9, 0x82, 0x40, 0x4e, 0x17, 0x04, 0x10, 0xd8, 0x21, 0xa3, 0x63, 0xe1,
+	0x59, 0x59, 0x22, 0xde, 0x11, 0x86, 0x0a, 0x73, 0x82, 0x3e, 0x65, 0xf2, 0x9a, 0x55, 0xcb, 0x8b,
+	0xfc, 0x37, 0x81, 0xa1, 0x35, 0x28, 0x3b, 0xfd, 0x7e, 0x40, 0xfb, 0x0e, 0xa3, 0x9d, 0xdd, 0x5a,
+	0x41, 0x4c, 0x66, 0x42, 0xe8, 0x0a, 0x2c, 0xf1, 0xcf, 0x51, 0xae, 0xd7, 0xbf, 0x3e, 0xe6, 0x25,
+	0x7b, 0x5e, 0x7a, 0xe7, 0x11, 0xfc, 0x64, 0xcb, 0xfc, 0x58, 0xd5, 0xda, 0x4c, 0xd0, 0xa8, 0x38,
+	0x96, 0x1a, 0x89, 0xdf, 0x80, 0x25, 0xad, 0x78, 0xe5, 0x1e, 0x67, 0xa1, 0xf0, 0xb6, 0x40, 0x0e,
+	0x29, 0xf6, 0x49, 0x52, 0xc5, 0x4a, 0x93, 0x25, 0x3f, 0x77, 0x68, 0xf9, 0xf1, 0x15, 0xc8, 0x4b,
+	0x72, 0x5e, 0x79, 0x8a, 0x73, 0x24, 0x99, 0x7b, 0xf2, 0xb6, 0xba, 0x45, 0x61, 0xc8, 0x4b, 0x46,
+	0xb5, 0x6c, 0xec, 0x67, 0x12, 0x21, 0xea, 0x89, 0x7f, 0x90, 0x81, 0x63, 0x5b, 0x94, 0xd1, 0x2e,
+	0xa3, 0xbd, 0x8b, 0x2e, 0x1d, 0xf6, 0x3e, 0xd7, 0x9a, 0x40, 0x54, 0xd9, 0xcb, 0x1a, 0x95, 0x3d,
+	0x1e, 0xc3, 0x86, 0xae, 0x47, 0xaf, 0x1a, 0xa5, 0xa1, 0x18, 0x88, 0x75, 0x94, 0x33, 0x8b, 0x46,
+	0xda, 0x47, 0xf2, 0x86, 0x8f, 0xc4, 0x05, 0xc1, 0x42, 0xa2, 0x86, 0xa9, 0x6f, 0xa0, 0xc5, 0xf8,
+	0xfa, 0x8a, 0x7f, 0x63, 0xc1, 0x6a, 0x5a, 0x2f, 0xca, 0x8c, 0x2f, 0x42, 0x7e, 0x47, 0x20, 0x07,
+	0xcb, 0xce, 0x89, 0x11, 0xb2, 0x72, 0x21, 0x49, 0xcd, 0xca, 0x85, 0x44, 0xd0, 0xe3, 0x89, 0x4f,
+	0x59, 0x9d, 0x95, 0xfd, 0x69, 0xf3, 0x88, 0x00, 0x0c, 0x5a, 0x25, 0xcc, 0x99, 0x68, 0xe1, 0xd9,
+	0xb8, 0x24, 0x22, 0x11, 0x93, 0xb1, 0x44, 0xf0, 0x3f, 0x78, 0xd1, 0xc0, 0x5c, 0x88, 0x50, 0x11,
+	0xdf, 0x02, 0xea, 0x78, 0x90, 0x0d, 0xf4, 0x38, 0xd8, 0xfc, 0xab, 0xab, 0xba, 0xcf, 0x1d, 0xfb,
+	0x6c, 0xda, 0x3c, 0x9a, 0x18, 0x76, 0x73, 0x77, 0x4c, 0x89, 0x20, 0xe1, 0x3b, 0xa7, 0xeb, 0x04,
+	0x3d, 0xd7, 0x73, 0x86, 0x2e, 0x93, 0xd6, 0xb1, 0x89, 0x09, 0xf1, 0x70, 0x34, 0x76, 0x82, 0x50,
+	0x27, 0x81, 0x25, 0x19, 0x8e, 0x14, 0x44, 0xf4, 0x0b, 0x97, 0x24, 0xbc, 0x43, 0x59, 0x77, 0x20,
+	0x8f, 0x05, 0x29, 0x89, 0x44, 0x4c, 0x49, 0x24, 0x82, 0x36, 0xa0, 0xf8, 0xed, 0xd0, 0xf7, 0x6e,
+	0x38, 0x6c, 0x20, 0x37, 0x74, 0x67, 0x75, 0x7f, 0xda, 0x44, 0x1a, 0x33, 0x46, 0x44, 0x74, 0xf8,
+	0xa7, 0x56, 0xec, 0xd0, 0x72, 0xdf, 0x7f, 0xe1, 0x1c, 0x1a, 0x7f, 0x03, 0x56, 0xd3, 0x4b, 0x54,
+	0xbe, 0xc5, 0x6b, 0x7b, 0x89, 0x9e, 0xd9, 0x3e, 0x26, 0xfa, 0x49, 0x8a, 0x1c, 0x4f, 0x62, 0xdb,
+	0x0b, 0x64, 0x86, 0xed, 0x53, 0x06, 0xcd, 0x1c, 0x34, 0x68, 0x6c, 0xa9, 0xec, 0xfd, 0x2d, 0xf5,
+	0xc4, 0xa3, 0x50, 0x8a, 0x3e, 0x79, 0xa2, 0x32, 0x14, 0x2e, 0x5e, 0x27, 0xaf, 0x5f, 0x20, 0x5b,
+	0xcb, 0x0b, 0xa8, 0x02, 0xc5, 0xce, 0x85, 0xcd, 0x97, 0x45, 0xcb, 0xda, 0xf8, 0x65, 0x5e, 0x27,
+	0x3b, 0x01, 0xfa, 0x0a, 0xe4, 0x64, 0x06, 0xb3, 0x1a, 0x0b, 0x67, 0x7e, 0x0d, 0xac, 0x1f, 0x3f,
+	0x80, 0x4b, 0x2d, 0xe1, 0x85, 0xb3, 0x16, 0xba, 0x06, 0x65, 0x01, 0xaa, 0xaa, 0xfa, 0xc9, 0x74,
+	0x71, 0x3b, 0xc1, 0xe9, 0xd4, 0x8c, 0x5e, 0x83, 0xdf, 0x79, 0xc8, 0x49, 0x85, 0xad, 0xa6, 0x12,
+	0xcd, 0x43, 0x56, 0x93, 0xf8, 0xce, 0x80, 0x17, 0xd0, 0x73, 0x60, 0xf3, 0x22, 0x13, 0x32, 0xf2,
+	0x5c, 0xa3, 0x18, 0x5e, 0x5f, 0x4d, 0xc3, 0xc6, 0xb4, 0x2f, 0x44, 0x35, 0xfd, 0xe3, 0xe9, 0xc2,
+	0xa2, 0x1e, 0x5e, 0x3b, 0xd8, 0x11, 0xcd, 0x7c, 0x1d, 0x2a, 0x66, 0x79, 0x0b, 0x9d, 0x4a, 0x4e,
+	0x95, 0xaa, 0x86, 0xd5, 0x1b, 0xb3, 0xba, 0x23, 0x86, 0x57, 0xa1, 0x6c, 0x94, 0x96, 0x4c, 0xb5,
+	0x1e, 0xac, 0x8b, 0xd5, 0x4f, 0xcd, 0xe8, 0x8d, 0xb8, 0x5d, 0x82, 0x22, 0xbf, 0x1d, 0x88, 0x4f,
+	0x50, 0x27, 0xd2, 0x97, 0x00, 0x23, 0xf9, 0xab, 0x9f, 0x3c, 0xbc, 0x33, 0x62, 0xf4, 0x75, 0x28,
+	0x5d, 0xa2, 0x4c, 0x9d, 0x7a, 0xc7, 0xd3, 0xc7, 0xe6, 0x21, 0x9a, 0x4a, 0x1e, 0xbd, 0x78, 0x01,
+	0xbd, 0x21, 0x2e, 0x2a, 0xc9, 0x90, 0x8e, 0x9a, 0x33, 0x42, 0x77, 0xb4, 0xae, 0xb5, 0xd9, 0x04,
+	0x11, 0xe7, 0xd7, 0x13, 0x9c, 0x55, 0xae, 0xd1, 0x9c, 0xb1, 0x61, 0x23, 0xce, 0xcd, 0xfb, 0xfc,
+	0x75, 0x05, 0x2f, 0x6c, 0xbc, 0xa9, 0xff, 0xbd, 0xb1, 0xe5, 0x30, 0x07, 0x5d, 0x87, 0x25, 0xa1,
+	0xcb, 0xe8, 0xef, 0x1d, 0x09, 0x9f, 0x3f, 0xf0, 0x5f, 0x92, 0xfa, 0xa9, 0x19, 0xbd, 0x9a, 0x7d,
+	0xe7, 0xcd, 0x0f, 0x3e, 0x6e, 0x2c, 0x7c, 0xf8, 0x71, 0x63, 0xe1, 0xd3, 0x8f, 0x1b, 0xd6, 0x77,
+	0xf6, 0x1a, 0xd6, 0x2f, 0xf6, 0x1a, 0xd6, 0xfb, 0x7b, 0x0d, 0xeb, 0x83, 0xbd, 0x86, 0xf5, 0xe7,
+	0xbd, 0x86, 0xf5, 0x97, 0xbd, 0xc6, 0xc2, 0xa7, 0x7b, 0x0d, 0xeb, 0xdd, 0x4f, 0x1a, 0x0b, 0x1f,
+	0x7c, 0xd2, 0x58, 0xf8, 0xf0, 0x93, 0xc6, 0xc2, 0x37, 0x1f, 0xbb, 0xff, 0xf5, 0x5d, 0x86, 0xc5,
+	0xbc, 0x78, 0x3c, 0xf5, 0xcf, 0x01, 0x00, 0x79, 0x2b, 0x4d, 0x30, 0x95, 0x24, 0x00, 0x00,
 }
 
 func (x Direction) String() string {
@@ -3669,6 +3678,9 @@ func (this *Delete) Equal(that interface{}) bool {
 	if this.End != that1.End {
 		return false
 	}
+	if this.Rewrite != that1.Rewrite {
+		return false
+	}
 	return true
 }
 func (this *QueryResponse) Equal(that interface{}) bool {
@@ -5293,11 +5305,12 @@ func (this *Delete) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&logproto.Delete{")
 	s = append(s, "Selector: "+fmt.Sprintf("%#v", this.Selector)+",\n")
 	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
 	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
+	s = append(s, "Rewrite: "+fmt.Sprintf("%#v", this.Rewrite)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -6936,6 +6949,16 @@ func (m *Delete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Rewrite {
+		i--
+		if m.Rewrite {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.End != 0 {
 		i = encodeVarintLogproto(dAtA, i, uint64(m.End))
 		i--
@@ -9227,6 +9250,9 @@ func (m *Delete) Size() (n int) {
 	if m.End != 0 {
 		n += 1 + sovLogproto(uint64(m.End))
 	}
+	if m.Rewrite {
+		n += 2
+	}
 	return n
 }
 
@@ -10275,6 +10301,7 @@ func (this *Delete) String() string {
 		`Selector:` + fmt.Sprintf("%v", this.Selector) + `,`,
 		`Start:` + fmt.Sprintf("%v", this.Start) + `,`,
 		`End:` + fmt.Sprintf("%v", this.End) + `,`,
+		`Rewrite:` + fmt.Sprintf("%v", this.Rewrite) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -12260,6 +12287,26 @@ func (m *Delete) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rewrite", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Rewrite = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLogproto(dAtA[iNdEx:])
@@ -0,0 +1,128 @@
+package logql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/prometheus/promql"
+
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+)
+
+// pinnedParams overrides the time range of the outer query params, so that
+// an expression is evaluated once at the time of its `@` modifier.
+type pinnedParams struct {
+	Params
+	ts   time.Time
+	expr syntax.SampleExpr
+}
+
+func (p pinnedParams) Start() time.Time           { return p.ts }
+func (p pinnedParams) End() time.Time             { return p.ts }
+func (p pinnedParams) Step() time.Duration        { return 0 }
+func (p pinnedParams) QueryString() string        { return p.expr.String() }
+func (p pinnedParams) GetExpression() syntax.Expr { return p.expr }
+
+// withoutAtModifier returns a shallow copy of expr without its `@` modifier.
+func withoutAtModifier(expr syntax.SampleExpr) (syntax.SampleExpr, *syntax.AtModifier) {
+	switch e := expr.(type) {
+	case *syntax.RangeAggregationExpr:
+		left := *e.Left
+		left.At = nil
+		copied := *e
+		copied.Left = &left
+		return &copied, e.Left.At
+	case *syntax.SubqueryExpr:
+		copied := *e
+		copied.At = nil
+		return &copied, e.At
+	default:
+		return expr, nil
+	}
+}
+
+// newAtModifierEvaluator returns a step evaluator that evaluates expr once at
+// the time of its `@` modifier and returns the resulting samples at every
+// step of the outer query.
+func newAtModifierEvaluator(
+	ctx context.Context,
+	evFactory SampleEvaluatorFactory,
+	expr syntax.SampleExpr,
+	q Params,
+) (*AtModifierEvaluator, error) {
+	inner, at := withoutAtModifier(expr)
+	if at == nil {
+		return nil, fmt.Errorf("missing @ modifier in expression %s", expr)
+	}
+	if at.StartOrEnd != "" {
+		return nil, fmt.Errorf("unresolved @ %s() modifier in expression %s", at.StartOrEnd, expr)
+	}
+
+	nextEvaluator, err := evFactory.NewStepEvaluator(ctx, evFactory, inner, pinnedParams{
+		Params: q,
+		ts:     at.Time(),
+		expr:   inner,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stepMs := q.Step().Milliseconds()
+	if stepMs == 0 {
+		stepMs = 1
+	}
+	return &AtModifierEvaluator{
+		nextEvaluator: nextEvaluator,
+		at:            at,
+		stepMs:        stepMs,
+		endMs:         q.End().UnixMilli(),
+		currentMs:     q.Start().UnixMilli() - stepMs,
+	}, nil
+}
+
+type AtModifierEvaluator struct {
+	nextEvaluator StepEvaluator
+	at            *syntax.AtModifier
+
+	// vec holds the samples of the expression at the pinned time.
+	vec    promql.Vector
+	loaded bool
+
+	stepMs, endMs, currentMs int64
+}
+
+// load evaluates the single step of the pinned expression.
+func (e *AtModifierEvaluator) load() bool {
+	e.loaded = true
+	next, _, r := e.nextEvaluator.Next()
+	if next && r != nil {
+		e.vec = append(e.vec, r.SampleVector()...)
+	}
+	return e.nextEvaluator.Error() == nil
+}
+
+func (e *AtModifierEvaluator) Next() (bool, int64, StepResult) {
+	if !e.loaded && !e.load() {
+		return false, 0, SampleVector{}
+	}
+	e.currentMs += e.stepMs
+	if e.currentMs > e.endMs {
+		return false, 0, SampleVector{}
+	}
+
+	vec := make(promql.Vector, 0, len(e.vec))
+	for _, s := range e.vec {
+		s.T = e.currentMs
+		vec = append(vec, s)
+	}
+	return true, e.currentMs, SampleVector(vec)
+}
+
+func (e *AtModifierEvaluator) Close() error {
+	return e.nextEvaluator.Close()
+}
+
+func (e *AtModifierEvaluator) Error() error {
+	return e.nextEvaluator.Error()
+}
@@ -0,0 +1,108 @@
+package logql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/user"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+)
+
+func TestAtModifier(t *testing.T) {
+	// One line per second, two lines per second after the first minute.
+	entries := make([]logproto.Entry, 0, 180)
+	for i := 1; i <= 120; i++ {
+		ts := time.Unix(int64(i), 0)
+		entries = append(entries, logproto.Entry{Timestamp: ts, Line: fmt.Sprintf("%d", i)})
+		if i > 60 {
+			entries = append(entries, logproto.Entry{Timestamp: ts.Add(500 * time.Millisecond), Line: fmt.Sprintf("%d", i)})
+		}
+	}
+	streams := []logproto.Stream{{Labels: `{app="foo"}`, Entries: entries}}
+
+	for _, tc := range []struct {
+		query      string
+		start, end time.Time
+		step       time.Duration
+		expected   []float64
+	}{
+		{
+			query:    `count_over_time({app="foo"}[10s] @ 30)`,
+			start:    time.Unix(60, 0),
+			end:      time.Unix(120, 0),
+			step:     30 * time.Second,
+			expected: []float64{10, 10, 10},
+		},
+		{
+			// the pinned time may be after the evaluation time
+			query:    `count_over_time({app="foo"}[10s] @ 120)`,
+			start:    time.Unix(30, 0),
+			end:      time.Unix(30, 0),
+			expected: []float64{20},
+		},
+		{
+			query:    `count_over_time({app="foo"}[10s] @ 120 offset 60s)`,
+			start:    time.Unix(200, 0),
+			end:      time.Unix(200, 0),
+			expected: []float64{10},
+		},
+		{
+			query:    `count_over_time({app="foo"}[10s] @ start())`,
+			start:    time.Unix(60, 0),
+			end:      time.Unix(120, 0),
+			step:     30 * time.Second,
+			expected: []float64{10, 10, 10},
+		},
+		{
+			query:    `sum(count_over_time({app="foo"}[10s] @ end()))`,
+			start:    time.Unix(60, 0),
+			end:      time.Unix(120, 0),
+			step:     30 * time.Second,
+			expected: []float64{20, 20, 20},
+		},
+		{
+			query:    `count_over_time({app="foo"}[10s]) / count_over_time({app="foo"}[10s] @ 30)`,
+			start:    time.Unix(60, 0),
+			end:      time.Unix(120, 0),
+			step:     60 * time.Second,
+			expected: []float64{1, 2},
+		},
+		{
+			query:    `max_over_time(count_over_time({app="foo"}[10s])[60s:10s] @ 60)`,
+			start:    time.Unix(120, 0),
+			end:      time.Unix(120, 0),
+			expected: []float64{10},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			eng := NewEngine(EngineOpts{}, NewMockQuerier(0, streams), NoLimits, log.NewNopLogger())
+			params, err := NewLiteralParams(tc.query, tc.start, tc.end, tc.step, 0, logproto.FORWARD, 0, nil, nil)
+			require.NoError(t, err)
+
+			res, err := eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+			require.NoError(t, err)
+
+			var actual []float64
+			switch data := res.Data.(type) {
+			case promql.Vector:
+				for _, s := range data {
+					actual = append(actual, s.F)
+				}
+			case promql.Matrix:
+				require.Len(t, data, 1)
+				for _, p := range data[0].Floats {
+					actual = append(actual, p.F)
+				}
+			default:
+				t.Fatalf("unexpected result type %T", data)
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package logql
+
+import (
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/v3/pkg/iter"
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logql/log"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/util"
+)
+
+// StreamsDeduplicator applies the dedup stages of a log query to the streams
+// returned by its subqueries. Each subquery only deduplicates the lines it
+// reads, the duplicates of a line can still be returned by different
+// ingesters and store reads, or splits (when deduplicating within a window).
+type StreamsDeduplicator struct {
+	stages       []*streamsDedupStage
+	streamLabels map[string]labels.Labels
+}
+
+type streamsDedupStage struct {
+	by           []string
+	deduplicator *log.Deduplicator
+	values       []string
+}
+
+// NewStreamsDeduplicator returns a StreamsDeduplicator for the dedup stages of
+// the expression, or nil if it has none.
+func NewStreamsDeduplicator(expr syntax.Expr) *StreamsDeduplicator {
+	if expr == nil {
+		return nil
+	}
+
+	var stages []*streamsDedupStage
+	expr.Walk(func(e syntax.Expr) bool {
+		if d, ok := e.(*syntax.DedupExpr); ok {
+			stages = append(stages, &streamsDedupStage{
+				by:           d.By,
+				deduplicator: log.NewDeduplicator(d.Within),
+				values:       make([]string, max(len(d.By), 1)),
+			})
+		}
+		return true
+	})
+	if len(stages) == 0 {
+		return nil
+	}
+	return &StreamsDeduplicator{
+		stages:       stages,
+		streamLabels: map[string]labels.Labels{},
+	}
+}
+
+// Duplicate returns true if the entry is a duplicate of an entry seen before.
+func (d *StreamsDeduplicator) Duplicate(streamLabels string, e logproto.Entry) bool {
+	lbs, ok := d.streamLabels[streamLabels]
+	if !ok {
+		// the result labels are always valid.
+		lbs, _ = syntax.ParseLabels(streamLabels)
+		d.streamLabels[streamLabels] = lbs
+	}
+
+	for _, stage := range d.stages {
+		if len(stage.by) == 0 {
+			stage.values[0] = streamLabels
+		} else {
+			for i, name := range stage.by {
+				stage.values[i] = entryLabelValue(lbs, e, name)
+			}
+		}
+		if stage.deduplicator.Duplicate(e.Timestamp.UnixNano(), util.YoloBuf(e.Line), stage.values...) {
+			return true
+		}
+	}
+	return false
+}
+
+// DedupStreams removes the duplicate entries of the streams, going through the
+// entries of all streams in timestamp order, and returns the streams that
+// still have entries. The entries of each stream must be sorted in the
+// direction.
+func (d *StreamsDeduplicator) DedupStreams(streams []logproto.Stream, direction logproto.Direction) []logproto.Stream {
+	it := iter.NewStreamsIterator(streams, direction)
+	defer it.Close()
+
+	result := make([]logproto.Stream, 0, len(streams))
+	byLabels := make(map[string]int, len(streams))
+	for it.Next() {
+		lbs, e := it.Labels(), it.At()
+		if d.Duplicate(lbs, e) {
+			continue
+		}
+		i, ok := byLabels[lbs]
+		if !ok {
+			i = len(result)
+			byLabels[lbs] = i
+			result = append(result, logproto.Stream{Labels: lbs, Hash: it.StreamHash()})
+		}
+		result[i].Entries = append(result[i].Entries, e)
+	}
+	return result
+}
+
+// NewDedupIterator returns an iterator removing the duplicate entries of the
+// iterator for the dedup stages of the expression. The iterator is returned
+// as is if the expression has no dedup stages.
+func NewDedupIterator(it iter.EntryIterator, expr syntax.Expr) iter.EntryIterator {
+	dedup := NewStreamsDeduplicator(expr)
+	if dedup == nil {
+		return it
+	}
+	return &dedupIterator{
+		EntryIterator: it,
+		dedup:         dedup,
+	}
+}
+
+type dedupIterator struct {
+	iter.EntryIterator
+	dedup *StreamsDeduplicator
+}
+
+func (it *dedupIterator) Next() bool {
+	for it.EntryIterator.Next() {
+		if !it.dedup.Duplicate(it.Labels(), it.At()) {
+			return true
+		}
+	}
+	return false
+}
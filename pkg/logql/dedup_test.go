@@ -0,0 +1,112 @@
+package logql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/user"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
+)
+
+func TestStreamsDeduplicator(t *testing.T) {
+	expr, err := syntax.ParseExpr(`{app="foo"}`)
+	require.NoError(t, err)
+	require.Nil(t, NewStreamsDeduplicator(expr))
+
+	expr, err = syntax.ParseExpr(`{app="foo"} | dedup by (trace_id) within 5s`)
+	require.NoError(t, err)
+	d := NewStreamsDeduplicator(expr)
+	require.NotNil(t, d)
+
+	streams := d.DedupStreams([]logproto.Stream{
+		{
+			Labels: `{app="foo", pod="a", trace_id="1"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(0, 0), Line: "hello"},
+				{Timestamp: time.Unix(1, 0), Line: "hello"},
+				{Timestamp: time.Unix(10, 0), Line: "hello"},
+			},
+		},
+		{
+			Labels: `{app="foo", pod="b", trace_id="1"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(4, 0), Line: "hello"},
+			},
+		},
+		{
+			Labels: `{app="foo", pod="c"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(4, 0), Line: "hello", StructuredMetadata: logproto.FromLabelsToLabelAdapters(labels.FromStrings("trace_id", "2"))},
+				{Timestamp: time.Unix(4, 0), Line: "hello", Parsed: logproto.FromLabelsToLabelAdapters(labels.FromStrings("trace_id", "2"))},
+			},
+		},
+	}, logproto.FORWARD)
+	require.Equal(t, []logproto.Stream{
+		{
+			Labels: `{app="foo", pod="a", trace_id="1"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(0, 0), Line: "hello"},
+				{Timestamp: time.Unix(10, 0), Line: "hello"},
+			},
+		},
+		{
+			Labels: `{app="foo", pod="c"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(4, 0), Line: "hello", StructuredMetadata: logproto.FromLabelsToLabelAdapters(labels.FromStrings("trace_id", "2"))},
+			},
+		},
+	}, streams)
+}
+
+func TestEngine_Dedup(t *testing.T) {
+	querier := NewMockQuerier(0, []logproto.Stream{
+		{
+			Labels: `{app="foo", pod="a"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(1, 0), Line: "trace_id=1 msg=retry"},
+				{Timestamp: time.Unix(2, 0), Line: "trace_id=1 msg=retry"},
+				{Timestamp: time.Unix(3, 0), Line: "trace_id=1 msg=retry"},
+			},
+		},
+		{
+			Labels: `{app="foo", pod="b"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(1, 0), Line: "trace_id=1 msg=retry"},
+				{Timestamp: time.Unix(5, 0), Line: "trace_id=1 msg=retry"},
+			},
+		},
+	})
+	eng := NewEngine(EngineOpts{}, querier, &fakeLimits{}, log.NewNopLogger())
+
+	for _, test := range []struct {
+		qs       string
+		expected []int
+	}{
+		{`{app="foo"} | dedup`, []int{3, 2}},
+		{`{app="foo"} | dedup within 1s`, []int{2, 2}},
+		{`{app="foo"} | logfmt | dedup by (trace_id)`, []int{3, 1}},
+		{`{app="foo"} | logfmt | dedup by (trace_id) within 2s`, []int{1, 1}},
+	} {
+		t.Run(test.qs, func(t *testing.T) {
+			params, err := NewLiteralParams(test.qs, time.Unix(0, 0), time.Unix(10, 0), 0, 0, logproto.FORWARD, 100, nil, nil)
+			require.NoError(t, err)
+
+			res, err := eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+			require.NoError(t, err)
+
+			streams := res.Data.(logqlmodel.Streams)
+			counts := make([]int, 0, len(streams))
+			for _, s := range streams {
+				counts = append(counts, len(s.Entries))
+			}
+			require.Equal(t, test.expected, counts)
+		})
+	}
+}
@@ -510,7 +510,7 @@ func (errorQuerier) SelectSamples(_ context.Context, _ SelectSampleParams) (iter
 func NewDownstreamEvaluator(downstreamer Downstreamer) *DownstreamEvaluator {
 	return &DownstreamEvaluator{
 		Downstreamer:     downstreamer,
-		defaultEvaluator: NewDefaultEvaluator(&errorQuerier{}, 0, 0),
+		defaultEvaluator: NewDefaultEvaluator(&errorQuerier{}, NoLimits, 0, 0),
 	}
 }
 
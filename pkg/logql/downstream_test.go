@@ -82,6 +82,18 @@ func TestMappingEquivalence(t *testing.T) {
 		{`last_over_time({a=~".+"} | logfmt | unwrap value [1s]) by (a)`, false, []string{ShardLastOverTime}},
 		{`last_over_time({a=~".+"} | logfmt | unwrap value [1s] offset 2s) by (a)`, false, []string{ShardLastOverTime}},
 		{`last_over_time({a=~".+"} | logfmt | unwrap value [1s] offset -2s) by (a)`, false, []string{ShardLastOverTime}},
+		{`max_over_time(sum by (a) (rate({a=~".+"}[1s]))[3s:1s])`, false, nil},
+		{`sum(avg_over_time(rate({a=~".+"}[1s])[5s:2s] offset 1s))`, false, nil},
+		{`sum by (a) (rate({a=~".+"}[2s] @ 10))`, false, nil},
+		{`sum(rate({a=~".+"}[1s])) / sum(rate({a=~".+"}[1s] @ end()))`, false, nil},
+		{`clamp_max(sum by (a) (rate({a=~".+"}[1s])), 0.5)`, false, nil},
+		{`round(sum by (a) (count_over_time({a=~".+"}[2s])) / 3)`, false, nil},
+		{`sum by (a) (rate({a=~".+"}[1s])) / scalar(sum(rate({a=~".+"}[1s])))`, false, nil},
+		{`timestamp(sum by (a) (rate({a=~".+"}[1s])))`, false, nil},
+		{`count_values("value", count_over_time({a=~".+"}[2s]))`, false, nil},
+		{`count_values by (a) ("value", count_over_time({a=~".+"}[2s]))`, false, nil},
+		{`count_values("value", sum by (a) (count_over_time({a=~".+"}[2s])))`, false, nil},
+		{`label_join(sum by (a) (rate({a=~".+"}[1s])), "b", "-", "a", "a")`, false, nil},
 		// topk prefers already-seen values in tiebreakers. Since the test data generates
 		// the same log lines for each series & the resulting promql.Vectors aren't deterministically
 		// sorted by labels, we don't expect this to pass.
@@ -689,6 +701,20 @@ func TestRangeMappingEquivalence(t *testing.T) {
 		// label_replace
 		{`label_replace(sum by (a) (count_over_time({a=~".+"}[3s])), "", "", "", "")`, time.Second},
 		{`label_replace(sum by (a) (count_over_time({a=~".+"}[3s])), "foo", "$1", "a", "(.*)")`, time.Second},
+		{`label_join(sum by (a) (count_over_time({a=~".+"}[3s])), "foo", "-", "a", "a")`, time.Second},
+		{`count_values("value", sum by (a) (count_over_time({a=~".+"}[3s])))`, time.Second},
+
+		// subqueries
+		{`max_over_time(rate({a=~".+"}[1s])[4s:1s])`, time.Second},
+		{`min_over_time(sum by (a) (rate({a=~".+"}[2s]))[5s:1s])`, 2 * time.Second},
+		{`sum_over_time(count_over_time({a=~".+"}[1s])[4s:2s] offset 1s)`, time.Second},
+		{`sum by (a) (count_over_time(rate({a=~".+"}[1s])[3s:1s]))`, time.Second},
+		{`sum(count_over_time({a=~".+"}[4s] @ 15 offset 1s))`, time.Second},
+		{`max by (a) (max_over_time({a=~".+"} | logfmt | unwrap value [3s] @ end()))`, time.Second},
+
+		// functions
+		{`ceil(sum by (a) (rate({a=~".+"}[3s])))`, time.Second},
+		{`sum(count_over_time({a=~".+"}[3s])) / scalar(sum(count_over_time({a=~".+"}[3s])))`, time.Second},
 	} {
 		q := NewMockQuerier(
 			shards,
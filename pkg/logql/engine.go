@@ -192,7 +192,7 @@ func NewEngine(opts EngineOpts, q Querier, l Limits, logger log.Logger) *QueryEn
 	}
 	return &QueryEngine{
 		logger:           logger,
-		evaluatorFactory: NewDefaultEvaluator(q, opts.MaxLookBackPeriod, opts.MaxCountMinSketchHeapSize),
+		evaluatorFactory: NewDefaultEvaluator(q, l, opts.MaxLookBackPeriod, opts.MaxCountMinSketchHeapSize),
 		limits:           l,
 		opts:             opts,
 	}
@@ -342,6 +342,24 @@ func (q *query) Eval(ctx context.Context) (promql_parser.Value, error) {
 		if err != nil {
 			return nil, err
 		}
+		// the duplicates of a line can be returned by different ingesters,
+		// store reads or shards when deduplicating across streams.
+		itr = NewDedupIterator(itr, e)
+
+		encodingFlags := httpreq.ExtractEncodingFlagsFromCtx(ctx)
+		if encodingFlags.Has(httpreq.FlagCategorizeLabels) {
+			itr = iter.NewCategorizeLabelsIterator(itr)
+		}
+
+		defer util.LogErrorWithContext(ctx, "closing iterator", itr.Close)
+		streams, err := readStreams(itr, q.params.Limit(), q.params.Direction(), q.params.Interval())
+		return streams, err
+
+	case *syntax.JoinExpr:
+		itr, err := q.evalJoin(ctx, e)
+		if err != nil {
+			return nil, err
+		}
 
 		encodingFlags := httpreq.ExtractEncodingFlagsFromCtx(ctx)
 		if encodingFlags.Has(httpreq.FlagCategorizeLabels) {
@@ -396,6 +414,7 @@ func (q *query) evalSample(ctx context.Context, expr syntax.SampleExpr) (promql_
 	if err != nil {
 		return nil, err
 	}
+	syntax.ResolveAtModifiers(expr, q.params.Start(), q.params.End())
 
 	stepEvaluator, err := q.evaluator.NewStepEvaluator(ctx, q.evaluator, expr, q.params)
 	if err != nil {
@@ -477,6 +477,86 @@ func TestEngine_InstantQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			`label_join(
+				sum(count_over_time({app=~"foo|bar"} |~".+bar" [1m])) by (namespace,app),
+				"new",
+				"-",
+				"namespace",
+				"app"
+				)`, time.Unix(60, 0), logproto.FORWARD, 100,
+			[][]logproto.Series{
+				{
+					newSeries(testSize, factor(10, identity), `{app="foo", namespace="a"}`),
+					newSeries(testSize, factor(10, identity), `{app="bar", namespace="b"}`),
+				},
+			},
+			[]SelectSampleParams{
+				{&logproto.SampleQueryRequest{Start: time.Unix(0, 0), End: time.Unix(60, 0), Selector: `sum by (namespace,app) (count_over_time({app=~"foo|bar"} |~".+bar" [1m])) `}},
+			},
+			promql.Vector{
+				promql.Sample{
+					T: 60 * 1000, F: 6,
+					Metric: labels.FromStrings("app", "bar",
+						"namespace", "b",
+						"new", "b-bar",
+					),
+				},
+				promql.Sample{
+					T: 60 * 1000, F: 6,
+					Metric: labels.FromStrings("app", "foo",
+						"namespace", "a",
+						"new", "a-foo",
+					),
+				},
+			},
+		},
+		{
+			`count_values("count", sum(count_over_time({app=~"foo|bar|baz"} |~".+bar" [1m])) by (namespace,app))`, time.Unix(60, 0), logproto.FORWARD, 100,
+			[][]logproto.Series{
+				{
+					newSeries(testSize, factor(10, identity), `{app="foo", namespace="a"}`),
+					newSeries(testSize, factor(10, identity), `{app="bar", namespace="b"}`),
+					newSeries(testSize, factor(5, identity), `{app="baz", namespace="b"}`),
+				},
+			},
+			[]SelectSampleParams{
+				{&logproto.SampleQueryRequest{Start: time.Unix(0, 0), End: time.Unix(60, 0), Selector: `sum by (namespace,app) (count_over_time({app=~"foo|bar|baz"} |~".+bar" [1m])) `}},
+			},
+			promql.Vector{
+				promql.Sample{
+					T: 60 * 1000, F: 1,
+					Metric: labels.FromStrings("count", "12"),
+				},
+				promql.Sample{
+					T: 60 * 1000, F: 2,
+					Metric: labels.FromStrings("count", "6"),
+				},
+			},
+		},
+		{
+			`count_values by (namespace) ("count", sum(count_over_time({app=~"foo|bar|baz"} |~".+bar" [1m])) by (namespace,app))`, time.Unix(60, 0), logproto.FORWARD, 100,
+			[][]logproto.Series{
+				{
+					newSeries(testSize, factor(10, identity), `{app="foo", namespace="a"}`),
+					newSeries(testSize, factor(10, identity), `{app="bar", namespace="b"}`),
+					newSeries(testSize, factor(10, identity), `{app="baz", namespace="b"}`),
+				},
+			},
+			[]SelectSampleParams{
+				{&logproto.SampleQueryRequest{Start: time.Unix(0, 0), End: time.Unix(60, 0), Selector: `sum by (namespace,app) (count_over_time({app=~"foo|bar|baz"} |~".+bar" [1m])) `}},
+			},
+			promql.Vector{
+				promql.Sample{
+					T: 60 * 1000, F: 1,
+					Metric: labels.FromStrings("count", "6", "namespace", "a"),
+				},
+				promql.Sample{
+					T: 60 * 1000, F: 2,
+					Metric: labels.FromStrings("count", "6", "namespace", "b"),
+				},
+			},
+		},
 		{
 			`count(count_over_time({app=~"foo|bar"} |~".+bar" [1m])) without (app)`, time.Unix(60, 0), logproto.FORWARD, 100,
 			[][]logproto.Series{
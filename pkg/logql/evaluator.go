@@ -7,6 +7,7 @@ import (
 	"math"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -208,6 +209,18 @@ func (p ParamsWithShardsOverride) Shards() []string {
 	return p.ShardsOverride
 }
 
+// ParamsWithLimitOverride overrides the limit of entries returned by a log
+// query.
+type ParamsWithLimitOverride struct {
+	Params
+	LimitOverride uint32
+}
+
+// Limit returns the overwriting limit.
+func (p ParamsWithLimitOverride) Limit() uint32 {
+	return p.LimitOverride
+}
+
 type ParamsWithChunkOverrides struct {
 	Params
 	StoreChunksOverride *logproto.ChunkRefGroup
@@ -291,12 +304,14 @@ type DefaultEvaluator struct {
 	maxLookBackPeriod         time.Duration
 	maxCountMinSketchHeapSize int
 	querier                   Querier
+	limits                    Limits
 }
 
 // NewDefaultEvaluator constructs a DefaultEvaluator
-func NewDefaultEvaluator(querier Querier, maxLookBackPeriod time.Duration, maxCountMinSketchHeapSize int) *DefaultEvaluator {
+func NewDefaultEvaluator(querier Querier, limits Limits, maxLookBackPeriod time.Duration, maxCountMinSketchHeapSize int) *DefaultEvaluator {
 	return &DefaultEvaluator{
 		querier:                   querier,
+		limits:                    limits,
 		maxLookBackPeriod:         maxLookBackPeriod,
 		maxCountMinSketchHeapSize: maxCountMinSketchHeapSize,
 	}
@@ -333,7 +348,7 @@ func (ev *DefaultEvaluator) NewStepEvaluator(
 ) (StepEvaluator, error) {
 	switch e := expr.(type) {
 	case *syntax.VectorAggregationExpr:
-		if rangExpr, ok := e.Left.(*syntax.RangeAggregationExpr); ok && e.Operation == syntax.OpTypeSum {
+		if rangExpr, ok := e.Left.(*syntax.RangeAggregationExpr); ok && e.Operation == syntax.OpTypeSum && rangExpr.Operation != syntax.OpRangeTypePatternCount && rangExpr.Left.At == nil {
 			// if range expression is wrapped with a vector expression
 			// we should send the vector expression for allowing reducing labels at the source.
 			nextEvFactory = SampleEvaluatorFunc(func(ctx context.Context, _ SampleEvaluatorFactory, _ syntax.SampleExpr, _ Params) (StepEvaluator, error) {
@@ -362,6 +377,12 @@ func (ev *DefaultEvaluator) NewStepEvaluator(
 	case *CountMinSketchEvalExpr:
 		return NewCountMinSketchEvalStepEvaluator(ctx, nextEvFactory, e, q)
 	case *syntax.RangeAggregationExpr:
+		if e.Left.At != nil {
+			return newAtModifierEvaluator(ctx, nextEvFactory, e, q)
+		}
+		if e.Operation == syntax.OpRangeTypePatternCount {
+			return ev.newPatternCountEvaluator(ctx, e, q)
+		}
 		it, err := ev.querier.SelectSamples(ctx, SelectSampleParams{
 			&logproto.SampleQueryRequest{
 				// extend startTs backwards by step
@@ -385,6 +406,15 @@ func (ev *DefaultEvaluator) NewStepEvaluator(
 		return newBinOpStepEvaluator(ctx, nextEvFactory, e, q)
 	case *syntax.LabelReplaceExpr:
 		return newLabelReplaceEvaluator(ctx, nextEvFactory, e, q)
+	case *syntax.LabelJoinExpr:
+		return newLabelJoinEvaluator(ctx, nextEvFactory, e, q)
+	case *syntax.FunctionExpr:
+		return newFunctionEvaluator(ctx, nextEvFactory, e, q)
+	case *syntax.SubqueryExpr:
+		if e.At != nil {
+			return newAtModifierEvaluator(ctx, nextEvFactory, e, q)
+		}
+		return newSubqueryEvaluator(ctx, nextEvFactory, e, q, ev.limits)
 	case *syntax.VectorExpr:
 		val, err := e.Value()
 		if err != nil {
@@ -416,9 +446,18 @@ func newVectorAggEvaluator(
 		return newCountMinSketchVectorAggEvaluator(nextEvaluator, expr, maxCountMinSketchHeapSize)
 	}
 
+	grouping := expr.Grouping
+	if expr.Operation == syntax.OpTypeCountValues {
+		// count_values groups the samples by their values in addition to the
+		// grouping of the expression.
+		grouping = countValuesGrouping(expr)
+		sort.Strings(grouping.Groups)
+	}
+
 	return &VectorAggEvaluator{
 		nextEvaluator: nextEvaluator,
 		expr:          expr,
+		grouping:      grouping,
 		buf:           make([]byte, 0, 1024),
 		lb:            labels.NewBuilder(labels.EmptyLabels()),
 	}, nil
@@ -427,6 +466,7 @@ func newVectorAggEvaluator(
 type VectorAggEvaluator struct {
 	nextEvaluator StepEvaluator
 	expr          *syntax.VectorAggregationExpr
+	grouping      *syntax.Grouping
 	buf           []byte
 	lb            *labels.Builder
 }
@@ -439,6 +479,13 @@ func (e *VectorAggEvaluator) Next() (bool, int64, StepResult) {
 	}
 	vec := r.SampleVector()
 	result := map[uint64]*groupedAggregation{}
+	if e.expr.Operation == syntax.OpTypeCountValues {
+		for i, s := range vec {
+			e.lb.Reset(s.Metric)
+			e.lb.Set(e.expr.Label, strconv.FormatFloat(s.F, 'f', -1, 64))
+			vec[i].Metric = e.lb.Labels()
+		}
+	}
 	if e.expr.Operation == syntax.OpTypeTopK || e.expr.Operation == syntax.OpTypeBottomK {
 		if e.expr.Params < 1 {
 			return next, ts, SampleVector{}
@@ -448,25 +495,25 @@ func (e *VectorAggEvaluator) Next() (bool, int64, StepResult) {
 		metric := s.Metric
 
 		var groupingKey uint64
-		if e.expr.Grouping.Without {
-			groupingKey, e.buf = metric.HashWithoutLabels(e.buf, e.expr.Grouping.Groups...)
+		if e.grouping.Without {
+			groupingKey, e.buf = metric.HashWithoutLabels(e.buf, e.grouping.Groups...)
 		} else {
-			groupingKey, e.buf = metric.HashForLabels(e.buf, e.expr.Grouping.Groups...)
+			groupingKey, e.buf = metric.HashForLabels(e.buf, e.grouping.Groups...)
 		}
 		group, ok := result[groupingKey]
 		// Add a new group if it doesn't exist.
 		if !ok {
 			var m labels.Labels
 
-			if e.expr.Grouping.Without {
+			if e.grouping.Without {
 				e.lb.Reset(metric)
-				e.lb.Del(e.expr.Grouping.Groups...)
+				e.lb.Del(e.grouping.Groups...)
 				e.lb.Del(labels.MetricName)
 				m = e.lb.Labels()
 			} else {
-				b := labels.NewScratchBuilder(len(e.expr.Grouping.Groups))
+				b := labels.NewScratchBuilder(len(e.grouping.Groups))
 				metric.Range(func(l labels.Label) {
-					for _, n := range e.expr.Grouping.Groups {
+					for _, n := range e.grouping.Groups {
 						if l.Name == n {
 							b.Add(l.Name, l.Value)
 							break
@@ -535,7 +582,7 @@ func (e *VectorAggEvaluator) Next() (bool, int64, StepResult) {
 				group.value = s.F
 			}
 
-		case syntax.OpTypeCount:
+		case syntax.OpTypeCount, syntax.OpTypeCountValues:
 			group.groupCount++
 
 		case syntax.OpTypeStddev, syntax.OpTypeStdvar:
@@ -585,7 +632,7 @@ func (e *VectorAggEvaluator) Next() (bool, int64, StepResult) {
 		case syntax.OpTypeAvg:
 			aggr.value = aggr.mean
 
-		case syntax.OpTypeCount:
+		case syntax.OpTypeCount, syntax.OpTypeCountValues:
 			aggr.value = float64(aggr.groupCount)
 
 		case syntax.OpTypeStddev:
@@ -854,6 +901,14 @@ func newBinOpStepEvaluator(
 		return nil, err
 	}
 
+	// match the value of a scalar() leg with all labels in the other leg
+	if isScalarFunction(expr.SampleExpr) {
+		return newScalarStepEvaluator(expr.Op, lse, rse, false, expr.Opts.ReturnBool), nil
+	}
+	if isScalarFunction(expr.RHS) {
+		return newScalarStepEvaluator(expr.Op, rse, lse, true, expr.Opts.ReturnBool), nil
+	}
+
 	return &BinOpStepEvaluator{
 		rse:  rse,
 		lse:  lse,
@@ -1147,8 +1202,26 @@ func newLiteralStepEvaluator(
 	}, nil
 }
 
+// newScalarStepEvaluator merges the value of a scalar() leg at each step with a StepEvaluator.
+func newScalarStepEvaluator(
+	op string,
+	scalarEv StepEvaluator,
+	nextEv StepEvaluator,
+	inverted bool,
+	returnBool bool,
+) *LiteralStepEvaluator {
+	return &LiteralStepEvaluator{
+		nextEv:     nextEv,
+		scalarEv:   scalarEv,
+		inverted:   inverted,
+		op:         op,
+		returnBool: returnBool,
+	}
+}
+
 type LiteralStepEvaluator struct {
 	nextEv     StepEvaluator
+	scalarEv   StepEvaluator // optional, replaces val at each step
 	mergeErr   error
 	val        float64
 	inverted   bool
@@ -1161,6 +1234,14 @@ func (e *LiteralStepEvaluator) Next() (bool, int64, StepResult) {
 	if !ok {
 		return ok, ts, r
 	}
+	if e.scalarEv != nil {
+		// These should _always_ happen at the same step on each evaluator.
+		_, _, sr := e.scalarEv.Next()
+		e.val = math.NaN()
+		if sr != nil {
+			e.val = scalarValue(sr.SampleVector())
+		}
+	}
 	vec := r.SampleVector()
 	results := make(promql.Vector, 0, len(vec))
 	for _, sample := range vec {
@@ -1195,14 +1276,25 @@ func (e *LiteralStepEvaluator) Next() (bool, int64, StepResult) {
 	return ok, ts, SampleVector(results)
 }
 
-func (e *LiteralStepEvaluator) Close() error {
-	return e.nextEv.Close()
+func (e *LiteralStepEvaluator) Close() (lastError error) {
+	if e.scalarEv != nil {
+		lastError = e.scalarEv.Close()
+	}
+	if err := e.nextEv.Close(); err != nil {
+		lastError = err
+	}
+	return lastError
 }
 
 func (e *LiteralStepEvaluator) Error() error {
 	if e.mergeErr != nil {
 		return e.mergeErr
 	}
+	if e.scalarEv != nil {
+		if err := e.scalarEv.Error(); err != nil {
+			return err
+		}
+	}
 	return e.nextEv.Error()
 }
 
@@ -1315,6 +1407,72 @@ func (e *LabelReplaceEvaluator) Error() error {
 	return e.nextEvaluator.Error()
 }
 
+// newLabelJoinEvaluator sets the destination label of each series to the values of the source labels joined by the separator.
+// The destination label is removed when all the source labels are empty, like Prometheus does.
+func newLabelJoinEvaluator(
+	ctx context.Context,
+	evFactory SampleEvaluatorFactory,
+	expr *syntax.LabelJoinExpr,
+	q Params,
+) (*LabelJoinEvaluator, error) {
+	nextEvaluator, err := evFactory.NewStepEvaluator(ctx, evFactory, expr.Left, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabelJoinEvaluator{
+		nextEvaluator: nextEvaluator,
+		expr:          expr,
+		buf:           make([]byte, 0, 1024),
+	}, nil
+}
+
+type LabelJoinEvaluator struct {
+	nextEvaluator StepEvaluator
+	labelCache    map[uint64]labels.Labels
+	expr          *syntax.LabelJoinExpr
+	buf           []byte
+}
+
+func (e *LabelJoinEvaluator) Next() (bool, int64, StepResult) {
+	next, ts, r := e.nextEvaluator.Next()
+	if !next {
+		return false, 0, SampleVector{}
+	}
+	vec := r.SampleVector()
+	if e.labelCache == nil {
+		e.labelCache = make(map[uint64]labels.Labels, len(vec))
+	}
+	var hash uint64
+	values := make([]string, len(e.expr.Src))
+	for i, s := range vec {
+		hash, e.buf = s.Metric.HashWithoutLabels(e.buf)
+		if labels, ok := e.labelCache[hash]; ok {
+			vec[i].Metric = labels
+			continue
+		}
+		for j, src := range e.expr.Src {
+			values[j] = s.Metric.Get(src)
+		}
+		lb := labels.NewBuilder(s.Metric).Del(e.expr.Dst)
+		if joined := strings.Join(values, e.expr.Separator); joined != "" {
+			lb.Set(e.expr.Dst, joined)
+		}
+		outLbs := lb.Labels()
+		e.labelCache[hash] = outLbs
+		vec[i].Metric = outLbs
+	}
+	return next, ts, SampleVector(vec)
+}
+
+func (e *LabelJoinEvaluator) Close() error {
+	return e.nextEvaluator.Close()
+}
+
+func (e *LabelJoinEvaluator) Error() error {
+	return e.nextEvaluator.Error()
+}
+
 // This is to replace missing timeseries during absent_over_time aggregation.
 func absentLabels(expr syntax.SampleExpr) (labels.Labels, error) {
 	m := labels.Labels{}
@@ -1441,6 +1599,7 @@ func (ev *DefaultEvaluator) newVariantsEvaluator(
 					variantEvaluator = &VectorAggEvaluator{
 						nextEvaluator: rangeEvaluator,
 						expr:          e,
+						grouping:      e.Grouping,
 						buf:           make([]byte, 0, 1024),
 						lb:            labels.NewBuilder(labels.EmptyLabels()),
 					}
@@ -6,6 +6,9 @@ const MaxChildrenDisplay = 3
 
 func (e *LiteralStepEvaluator) Explain(parent Node) {
 	b := parent.Child("Literal")
+	if e.scalarEv != nil {
+		e.scalarEv.Explain(b)
+	}
 	e.nextEv.Explain(b)
 }
 
@@ -14,6 +17,26 @@ func (e *LabelReplaceEvaluator) Explain(parent Node) {
 	e.nextEvaluator.Explain(b)
 }
 
+func (e *LabelJoinEvaluator) Explain(parent Node) {
+	b := parent.Childf("%s LabelJoin", e.expr.Dst)
+	e.nextEvaluator.Explain(b)
+}
+
+func (e *FunctionEvaluator) Explain(parent Node) {
+	b := parent.Childf("%s Function", e.expr.Function)
+	e.nextEvaluator.Explain(b)
+}
+
+func (e *SubqueryEvaluator) Explain(parent Node) {
+	b := parent.Childf("[%s, %s] Subquery", e.expr.Operation, e.expr.Range)
+	e.nextEvaluator.Explain(b)
+}
+
+func (e *AtModifierEvaluator) Explain(parent Node) {
+	b := parent.Childf("[%s] AtModifier", e.at.Time())
+	e.nextEvaluator.Explain(b)
+}
+
 func (e *VectorAggEvaluator) Explain(parent Node) {
 	b := parent.Childf("[%s, %s] VectorAgg", e.expr.Operation, e.expr.Grouping)
 	e.nextEvaluator.Explain(b)
@@ -24,7 +24,7 @@ func TestExplain(t *testing.T) {
 
 	ctx := user.InjectOrgID(context.Background(), "fake")
 
-	defaultEv := NewDefaultEvaluator(querier, 30*time.Second, 10_000)
+	defaultEv := NewDefaultEvaluator(querier, NoLimits, 30*time.Second, 10_000)
 	downEv := &DownstreamEvaluator{Downstreamer: MockDownstreamer{regular}, defaultEvaluator: defaultEv}
 
 	strategy := NewPowerOfTwoStrategy(ConstantShards(4))
@@ -0,0 +1,213 @@
+package logql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+)
+
+// sampleFunction returns the new value of a sample.
+type sampleFunction func(v float64) float64
+
+func functionOf(expr *syntax.FunctionExpr) (sampleFunction, error) {
+	switch expr.Function {
+	case syntax.OpFuncAbs:
+		return math.Abs, nil
+	case syntax.OpFuncCeil:
+		return math.Ceil, nil
+	case syntax.OpFuncFloor:
+		return math.Floor, nil
+	case syntax.OpFuncSqrt:
+		return math.Sqrt, nil
+	case syntax.OpFuncExp:
+		return math.Exp, nil
+	case syntax.OpFuncLn:
+		return math.Log, nil
+	case syntax.OpFuncRound:
+		toNearest := 1.0
+		if expr.Param != nil {
+			toNearest = *expr.Param
+		}
+		// Invert as it seems to cause fewer floating point accuracy issues.
+		toNearestInverse := 1.0 / toNearest
+		return func(v float64) float64 {
+			return math.Floor(v*toNearestInverse+0.5) / toNearestInverse
+		}, nil
+	case syntax.OpFuncClampMin:
+		if expr.Param == nil {
+			return nil, fmt.Errorf("parameter required for function %s", expr.Function)
+		}
+		minVal := *expr.Param
+		return func(v float64) float64 { return math.Max(minVal, v) }, nil
+	case syntax.OpFuncClampMax:
+		if expr.Param == nil {
+			return nil, fmt.Errorf("parameter required for function %s", expr.Function)
+		}
+		maxVal := *expr.Param
+		return func(v float64) float64 { return math.Min(maxVal, v) }, nil
+	case syntax.OpFuncHour:
+		return dateFunction(func(t time.Time) float64 { return float64(t.Hour()) }), nil
+	case syntax.OpFuncDayOfWeek:
+		return dateFunction(func(t time.Time) float64 { return float64(t.Weekday()) }), nil
+	case syntax.OpFuncDayOfMonth:
+		return dateFunction(func(t time.Time) float64 { return float64(t.Day()) }), nil
+	default:
+		return nil, fmt.Errorf("unsupported function: %s", expr.Function)
+	}
+}
+
+// dateFunction interprets the sample value as seconds since the Unix epoch and
+// returns the date component in UTC.
+func dateFunction(f func(t time.Time) float64) sampleFunction {
+	return func(v float64) float64 {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return math.NaN()
+		}
+		return f(time.Unix(int64(v), 0).UTC())
+	}
+}
+
+// scalarValue returns the value of the single sample of vec or NaN if vec
+// does not contain exactly one sample.
+func scalarValue(vec promql.Vector) float64 {
+	if len(vec) != 1 {
+		return math.NaN()
+	}
+	return vec[0].F
+}
+
+// histogramQuantile groups the samples of vec by their labels without the
+// bucket label `le` and returns the quantile q of the histogram of every group,
+// interpolated like the PromQL function of the same name. Samples without a
+// valid bucket label are ignored.
+func histogramQuantile(q float64, ts int64, vec promql.Vector) promql.Vector {
+	type histogram struct {
+		metric  labels.Labels
+		buckets promql.Buckets
+	}
+
+	var (
+		byKey      = map[uint64]*histogram{}
+		histograms []*histogram
+		b          = labels.NewBuilder(labels.EmptyLabels())
+		buf        = make([]byte, 0, 1024)
+	)
+	for _, s := range vec {
+		le, err := strconv.ParseFloat(s.Metric.Get(labels.BucketLabel), 64)
+		if err != nil {
+			continue
+		}
+		var key uint64
+		key, buf = s.Metric.HashWithoutLabels(buf, labels.BucketLabel)
+		h, ok := byKey[key]
+		if !ok {
+			b.Reset(s.Metric)
+			b.Del(labels.BucketLabel)
+			h = &histogram{metric: b.Labels()}
+			byKey[key] = h
+			histograms = append(histograms, h)
+		}
+		h.buckets = append(h.buckets, promql.Bucket{UpperBound: le, Count: s.F})
+	}
+
+	result := make(promql.Vector, 0, len(histograms))
+	for _, h := range histograms {
+		v, _, _ := promql.BucketQuantile(q, h.buckets)
+		result = append(result, promql.Sample{T: ts, F: v, Metric: h.metric})
+	}
+	return result
+}
+
+// isScalarFunction returns true if expr is a call to scalar().
+func isScalarFunction(expr syntax.SampleExpr) bool {
+	fn, ok := expr.(*syntax.FunctionExpr)
+	return ok && fn.Function == syntax.OpFuncScalar
+}
+
+// newFunctionEvaluator returns a step evaluator that applies the function of
+// expr to every sample of its argument. Time functions without argument are
+// applied to the timestamp of every step.
+func newFunctionEvaluator(
+	ctx context.Context,
+	evFactory SampleEvaluatorFactory,
+	expr *syntax.FunctionExpr,
+	q Params,
+) (*FunctionEvaluator, error) {
+	var fn sampleFunction
+	switch expr.Function {
+	case syntax.OpFuncScalar, syntax.OpFuncTimestamp, syntax.OpFuncHistogramQuantile:
+		// applied to the whole vector of every step.
+	default:
+		var err error
+		if fn, err = functionOf(expr); err != nil {
+			return nil, err
+		}
+	}
+
+	var nextEvaluator StepEvaluator
+	if expr.Left == nil {
+		nextEvaluator = newVectorIterator(0, q.Step().Milliseconds(), q.Start().UnixMilli(), q.End().UnixMilli())
+	} else {
+		var err error
+		nextEvaluator, err = evFactory.NewStepEvaluator(ctx, evFactory, expr.Left, q)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &FunctionEvaluator{
+		nextEvaluator: nextEvaluator,
+		expr:          expr,
+		fn:            fn,
+	}, nil
+}
+
+type FunctionEvaluator struct {
+	nextEvaluator StepEvaluator
+	expr          *syntax.FunctionExpr
+	fn            sampleFunction
+}
+
+func (e *FunctionEvaluator) Next() (bool, int64, StepResult) {
+	next, ts, r := e.nextEvaluator.Next()
+	if !next {
+		return false, 0, SampleVector{}
+	}
+	vec := r.SampleVector()
+
+	switch e.expr.Function {
+	case syntax.OpFuncScalar:
+		return next, ts, SampleVector{{T: ts, F: scalarValue(vec), Metric: labels.EmptyLabels()}}
+	case syntax.OpFuncTimestamp:
+		for i, s := range vec {
+			vec[i].F = float64(s.T) / 1000
+		}
+		return next, ts, SampleVector(vec)
+	case syntax.OpFuncHistogramQuantile:
+		return next, ts, SampleVector(histogramQuantile(*e.expr.Param, ts, vec))
+	}
+
+	for i, s := range vec {
+		v := s.F
+		if e.expr.Left == nil {
+			v = float64(s.T) / 1000
+		}
+		vec[i].F = e.fn(v)
+	}
+	return next, ts, SampleVector(vec)
+}
+
+func (e *FunctionEvaluator) Close() error {
+	return e.nextEvaluator.Close()
+}
+
+func (e *FunctionEvaluator) Error() error {
+	return e.nextEvaluator.Error()
+}
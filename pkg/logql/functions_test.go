@@ -0,0 +1,202 @@
+package logql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/user"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+)
+
+func TestFunctions(t *testing.T) {
+	// One line per second for app foo and two lines per second for app bar.
+	var foo, bar []logproto.Entry
+	for i := 1; i <= 120; i++ {
+		ts := time.Unix(int64(i), 0)
+		foo = append(foo, logproto.Entry{Timestamp: ts, Line: fmt.Sprintf("%d", i)})
+		bar = append(bar,
+			logproto.Entry{Timestamp: ts, Line: fmt.Sprintf("%d", i)},
+			logproto.Entry{Timestamp: ts.Add(500 * time.Millisecond), Line: fmt.Sprintf("%d", i)},
+		)
+	}
+	streams := []logproto.Stream{
+		{Labels: `{app="bar"}`, Entries: bar},
+		{Labels: `{app="foo"}`, Entries: foo},
+	}
+
+	// Monday, 4 January 2021 13:00:00 UTC
+	monday := time.Date(2021, 1, 4, 13, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		query      string
+		start, end time.Time
+		step       time.Duration
+		expected   []float64
+	}{
+		{
+			query:    `abs(-1 * count_over_time({app="foo"}[10s]))`,
+			expected: []float64{10},
+		},
+		{
+			query:    `ceil(count_over_time({app="foo"}[10s]) / 3)`,
+			expected: []float64{4},
+		},
+		{
+			query:    `floor(count_over_time({app="foo"}[10s]) / 3)`,
+			expected: []float64{3},
+		},
+		{
+			query:    `round(count_over_time({app="foo"}[10s]) / 3)`,
+			expected: []float64{3},
+		},
+		{
+			query:    `round(count_over_time({app="foo"}[10s]) / 3, 0.5)`,
+			expected: []float64{3.5},
+		},
+		{
+			query:    `sqrt(count_over_time({app="foo"}[16s]))`,
+			expected: []float64{4},
+		},
+		{
+			query:    `exp(count_over_time({app="foo"}[2s]))`,
+			expected: []float64{math.Exp(2)},
+		},
+		{
+			query:    `ln(count_over_time({app="foo"}[10s]))`,
+			expected: []float64{math.Log(10)},
+		},
+		{
+			query:    `clamp_min(count_over_time({app="foo"}[10s]), 15)`,
+			expected: []float64{15},
+		},
+		{
+			query:    `clamp_max(count_over_time({app="foo"}[10s]), 5)`,
+			expected: []float64{5},
+		},
+		{
+			query:    `timestamp(count_over_time({app="foo"}[10s]))`,
+			expected: []float64{120},
+		},
+		{
+			query:    `scalar(count_over_time({app="foo"}[10s]))`,
+			expected: []float64{10},
+		},
+		{
+			// the scalar is applied to every series of the other leg
+			query:    `count_over_time({app=~".+"}[10s]) / scalar(sum(count_over_time({app=~".+"}[10s])))`,
+			expected: []float64{20.0 / 30, 10.0 / 30},
+		},
+		{
+			query:    `scalar(count_over_time({app="foo"}[10s])) - count_over_time({app="bar"}[10s])`,
+			expected: []float64{-10},
+		},
+		{
+			query:    `count_over_time({app="bar"}[10s]) > bool scalar(count_over_time({app="foo"}[10s]))`,
+			expected: []float64{1},
+		},
+		{
+			query:    `hour()`,
+			start:    monday,
+			end:      monday.Add(2 * time.Hour),
+			step:     time.Hour,
+			expected: []float64{13, 14, 15},
+		},
+		{
+			query:    `day_of_week()`,
+			start:    monday,
+			end:      monday,
+			expected: []float64{1},
+		},
+		{
+			query:    `day_of_month(vector(1609770000))`,
+			expected: []float64{4},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			start, end := tc.start, tc.end
+			if start.IsZero() {
+				start, end = time.Unix(120, 0), time.Unix(120, 0)
+			}
+			eng := NewEngine(EngineOpts{}, NewMockQuerier(0, streams), NoLimits, log.NewNopLogger())
+			params, err := NewLiteralParams(tc.query, start, end, tc.step, 0, logproto.FORWARD, 0, nil, nil)
+			require.NoError(t, err)
+
+			res, err := eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+			require.NoError(t, err)
+
+			var actual []float64
+			switch data := res.Data.(type) {
+			case promql.Vector:
+				for _, s := range data {
+					actual = append(actual, s.F)
+				}
+			case promql.Matrix:
+				require.Len(t, data, 1)
+				for _, p := range data[0].Floats {
+					actual = append(actual, p.F)
+				}
+			default:
+				t.Fatalf("unexpected result type %T", data)
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	// every stream is a bucket of the latency histogram of an app, logged as
+	// `count=<observations>` lines.
+	var streams []logproto.Stream
+	for _, b := range []struct {
+		app, le string
+		count   int
+	}{
+		{"api", "0.1", 10},
+		{"api", "0.5", 50},
+		{"api", "+Inf", 100},
+		{"db", "1", 0},
+		{"db", "+Inf", 20},
+	} {
+		streams = append(streams, logproto.Stream{
+			Labels:  fmt.Sprintf(`{app="%s", le="%s"}`, b.app, b.le),
+			Entries: []logproto.Entry{{Timestamp: time.Unix(100, 0), Line: fmt.Sprintf("count=%d", b.count)}},
+		})
+	}
+	// a stream without bucket label is ignored.
+	streams = append(streams, logproto.Stream{
+		Labels:  `{app="api"}`,
+		Entries: []logproto.Entry{{Timestamp: time.Unix(100, 0), Line: "count=1000"}},
+	})
+
+	eng := NewEngine(EngineOpts{}, NewMockQuerier(0, streams), NoLimits, log.NewNopLogger())
+	params, err := NewLiteralParams(
+		`histogram_quantile(0.5, sum by (app, le) (sum_over_time({app=~".+"} | logfmt | unwrap count [1m])))`,
+		time.Unix(120, 0), time.Unix(120, 0), 0, 0, logproto.FORWARD, 0, nil, nil,
+	)
+	require.NoError(t, err)
+
+	res, err := eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+	require.NoError(t, err)
+
+	actual := map[string]float64{}
+	for _, s := range res.Data.(promql.Vector) {
+		actual[s.Metric.String()] = s.F
+	}
+	require.Equal(t, map[string]float64{
+		`{app="api"}`: 0.5,
+		`{app="db"}`:  1,
+	}, actual)
+}
+
+func TestScalarValue(t *testing.T) {
+	require.Equal(t, 2.0, scalarValue(promql.Vector{{F: 2}}))
+	require.True(t, math.IsNaN(scalarValue(nil)))
+	require.True(t, math.IsNaN(scalarValue(promql.Vector{{F: 1}, {F: 2}})))
+}
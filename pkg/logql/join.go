@@ -0,0 +1,206 @@
+package logql
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/grafana/dskit/tenant"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/v3/pkg/iter"
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
+	"github.com/grafana/loki/v3/pkg/util"
+	"github.com/grafana/loki/v3/pkg/util/validation"
+)
+
+// joinEntry is a log entry read from one side of a join.
+type joinEntry struct {
+	labels string
+	entry  logproto.Entry
+	key    string
+}
+
+// evalJoin evaluates both sides of the join and returns an iterator over the
+// entries of the left side that have a matching entry on the right side, and
+// the matching entries of the right side. Entries match when they have the same
+// values for all the join labels and their timestamps are at most the join
+// window apart. For left joins all the entries of the left side are returned.
+//
+// The right side is buffered in memory and indexed by join key, the left side
+// is streamed and matched as it is read. The number of entries read from each
+// side is bounded by the tenant's MaxJoinEntries limit, the evaluation fails as
+// soon as either side exceeds it.
+func (q *query) evalJoin(ctx context.Context, expr *syntax.JoinExpr) (iter.EntryIterator, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	maxEntriesCapture := func(id string) int { return q.limits.MaxJoinEntries(ctx, id) }
+	maxEntries := validation.SmallestPositiveIntPerTenant(tenantIDs, maxEntriesCapture)
+
+	var right []joinEntry
+	if err := q.readJoinSide(ctx, expr.Right, expr.On, maxEntries, func(e joinEntry) {
+		right = append(right, e)
+	}); err != nil {
+		return nil, err
+	}
+
+	// index the right side by key, each key holding its entries sorted by time.
+	index := make(map[string][]int)
+	for i, e := range right {
+		if e.key == "" {
+			continue
+		}
+		index[e.key] = append(index[e.key], i)
+	}
+	for _, idx := range index {
+		sort.SliceStable(idx, func(i, j int) bool {
+			return right[idx[i]].entry.Timestamp.Before(right[idx[j]].entry.Timestamp)
+		})
+	}
+
+	var (
+		leftStreams  = map[string]*logproto.Stream{}
+		rightStreams = map[string]*logproto.Stream{}
+		rightMatched = make([]bool, len(right))
+	)
+	if err := q.readJoinSide(ctx, expr.Left, expr.On, maxEntries, func(e joinEntry) {
+		matched := false
+		if idx, ok := index[e.key]; ok && e.key != "" {
+			from := e.entry.Timestamp.Add(-expr.Window)
+			through := e.entry.Timestamp.Add(expr.Window)
+			start := sort.Search(len(idx), func(i int) bool {
+				return !right[idx[i]].entry.Timestamp.Before(from)
+			})
+			for _, i := range idx[start:] {
+				if right[i].entry.Timestamp.After(through) {
+					break
+				}
+				matched = true
+				if !rightMatched[i] {
+					rightMatched[i] = true
+					appendJoinEntry(rightStreams, right[i])
+				}
+			}
+		}
+		if matched || expr.Operation == syntax.OpLeftJoin {
+			appendJoinEntry(leftStreams, e)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	direction := q.params.Direction()
+	return iter.NewMergeEntryIterator(ctx, []iter.EntryIterator{
+		iter.NewStreamsIterator(joinStreams(leftStreams, direction), direction),
+		iter.NewStreamsIterator(joinStreams(rightStreams, direction), direction),
+	}, direction), nil
+}
+
+// readJoinSide reads the entries of one side of a join, computes their join
+// keys and passes them to fn. It returns an error as soon as the side has more
+// than maxEntries entries.
+func (q *query) readJoinSide(ctx context.Context, expr syntax.LogSelectorExpr, on []string, maxEntries int, fn func(joinEntry)) error {
+	var limit uint32
+	if maxEntries > 0 {
+		limit = uint32(maxEntries) + 1
+	}
+	itr, err := q.evaluator.NewIterator(ctx, expr, ParamsWithLimitOverride{
+		Params:        q.params,
+		LimitOverride: limit,
+	})
+	if err != nil {
+		return err
+	}
+	itr = NewDedupIterator(itr, expr)
+	defer util.LogErrorWithContext(ctx, "closing iterator", itr.Close)
+
+	var (
+		read        int
+		streamCache = map[string]labels.Labels{}
+		values      = make([]string, len(on))
+	)
+	for itr.Next() {
+		if maxEntries > 0 && read >= maxEntries {
+			return logqlmodel.NewJoinLimitError(maxEntries)
+		}
+		read++
+		streamLabels, entry := itr.Labels(), itr.At()
+		lbs, ok := streamCache[streamLabels]
+		if !ok {
+			lbs, err = syntax.ParseLabels(streamLabels)
+			if err != nil {
+				return err
+			}
+			streamCache[streamLabels] = lbs
+		}
+		fn(joinEntry{
+			labels: streamLabels,
+			entry:  entry,
+			key:    joinKey(on, lbs, entry, values),
+		})
+	}
+	return itr.Err()
+}
+
+// joinKey returns the key of the entry for the join labels. It returns an empty
+// key if any of the labels has no value, such entries never match.
+func joinKey(on []string, lbs labels.Labels, entry logproto.Entry, values []string) string {
+	for i, name := range on {
+		v := entryLabelValue(lbs, entry, name)
+		if v == "" {
+			return ""
+		}
+		values[i] = v
+	}
+	return strings.Join(values, "\xff")
+}
+
+// entryLabelValue returns the value of the label in the stream labels, the
+// structured metadata or the parsed labels of the entry.
+func entryLabelValue(lbs labels.Labels, entry logproto.Entry, name string) string {
+	if v := lbs.Get(name); v != "" {
+		return v
+	}
+	if v := labelAdapterValue(entry.StructuredMetadata, name); v != "" {
+		return v
+	}
+	return labelAdapterValue(entry.Parsed, name)
+}
+
+func labelAdapterValue(lbs []logproto.LabelAdapter, name string) string {
+	for _, l := range lbs {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func appendJoinEntry(streams map[string]*logproto.Stream, e joinEntry) {
+	stream, ok := streams[e.labels]
+	if !ok {
+		stream = &logproto.Stream{Labels: e.labels}
+		streams[e.labels] = stream
+	}
+	stream.Entries = append(stream.Entries, e.entry)
+}
+
+// joinStreams returns the streams with their entries sorted in the direction
+// of the query.
+func joinStreams(streams map[string]*logproto.Stream, direction logproto.Direction) []logproto.Stream {
+	result := make([]logproto.Stream, 0, len(streams))
+	for _, s := range streams {
+		sort.SliceStable(s.Entries, func(i, j int) bool {
+			if direction == logproto.BACKWARD {
+				return s.Entries[i].Timestamp.After(s.Entries[j].Timestamp)
+			}
+			return s.Entries[i].Timestamp.Before(s.Entries[j].Timestamp)
+		})
+		result = append(result, *s)
+	}
+	return result
+}
@@ -0,0 +1,191 @@
+package logql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/user"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
+)
+
+func TestEngine_Join(t *testing.T) {
+	querier := NewMockQuerier(0, []logproto.Stream{
+		{
+			Labels: `{app="api"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(10, 0), Line: "trace_id=a msg=request"},
+				{Timestamp: time.Unix(20, 0), Line: "trace_id=b msg=request"},
+				{Timestamp: time.Unix(30, 0), Line: "trace_id=c msg=request"},
+				{Timestamp: time.Unix(40, 0), Line: "msg=request"},
+			},
+		},
+		{
+			Labels: `{app="db"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(12, 0), Line: "trace_id=a msg=error"},
+				{Timestamp: time.Unix(15, 0), Line: "trace_id=a msg=error"},
+				{Timestamp: time.Unix(90, 0), Line: "trace_id=b msg=error"},
+				{Timestamp: time.Unix(95, 0), Line: "trace_id=d msg=error"},
+			},
+		},
+	})
+
+	for _, test := range []struct {
+		qs        string
+		direction logproto.Direction
+		limit     uint32
+		expected  logqlmodel.Streams
+	}{
+		{
+			qs:        `{app="api"} | logfmt join on (trace_id) [10s] {app="db"} | logfmt`,
+			direction: logproto.FORWARD,
+			limit:     100,
+			expected: logqlmodel.Streams{
+				{
+					Labels:  `{app="api", msg="request", trace_id="a"}`,
+					Entries: []logproto.Entry{{Timestamp: time.Unix(10, 0), Line: "trace_id=a msg=request"}},
+				},
+				{
+					Labels: `{app="db", msg="error", trace_id="a"}`,
+					Entries: []logproto.Entry{
+						{Timestamp: time.Unix(12, 0), Line: "trace_id=a msg=error"},
+						{Timestamp: time.Unix(15, 0), Line: "trace_id=a msg=error"},
+					},
+				},
+			},
+		},
+		{
+			qs:        `{app="api"} | logfmt join on (trace_id) [2s] {app="db"} | logfmt`,
+			direction: logproto.BACKWARD,
+			limit:     100,
+			expected: logqlmodel.Streams{
+				{
+					Labels:  `{app="api", msg="request", trace_id="a"}`,
+					Entries: []logproto.Entry{{Timestamp: time.Unix(10, 0), Line: "trace_id=a msg=request"}},
+				},
+				{
+					Labels:  `{app="db", msg="error", trace_id="a"}`,
+					Entries: []logproto.Entry{{Timestamp: time.Unix(12, 0), Line: "trace_id=a msg=error"}},
+				},
+			},
+		},
+		{
+			qs:        `{app="api"} | logfmt left_join on (trace_id) [10s] {app="db"} | logfmt`,
+			direction: logproto.BACKWARD,
+			limit:     100,
+			expected: logqlmodel.Streams{
+				{
+					Labels: `{app="api", msg="request", trace_id="a"}`,
+					Entries: []logproto.Entry{
+						{Timestamp: time.Unix(10, 0), Line: "trace_id=a msg=request"},
+					},
+				},
+				{
+					Labels: `{app="api", msg="request", trace_id="b"}`,
+					Entries: []logproto.Entry{
+						{Timestamp: time.Unix(20, 0), Line: "trace_id=b msg=request"},
+					},
+				},
+				{
+					Labels: `{app="api", msg="request", trace_id="c"}`,
+					Entries: []logproto.Entry{
+						{Timestamp: time.Unix(30, 0), Line: "trace_id=c msg=request"},
+					},
+				},
+				{
+					Labels: `{app="api", msg="request"}`,
+					Entries: []logproto.Entry{
+						{Timestamp: time.Unix(40, 0), Line: "msg=request"},
+					},
+				},
+				{
+					Labels: `{app="db", msg="error", trace_id="a"}`,
+					Entries: []logproto.Entry{
+						{Timestamp: time.Unix(15, 0), Line: "trace_id=a msg=error"},
+						{Timestamp: time.Unix(12, 0), Line: "trace_id=a msg=error"},
+					},
+				},
+			},
+		},
+		{
+			qs:        `{app="api"} | logfmt left_join on (trace_id) [10s] {app="db"} | logfmt`,
+			direction: logproto.FORWARD,
+			limit:     2,
+			expected: logqlmodel.Streams{
+				{
+					Labels:  `{app="api", msg="request", trace_id="a"}`,
+					Entries: []logproto.Entry{{Timestamp: time.Unix(10, 0), Line: "trace_id=a msg=request"}},
+				},
+				{
+					Labels:  `{app="db", msg="error", trace_id="a"}`,
+					Entries: []logproto.Entry{{Timestamp: time.Unix(12, 0), Line: "trace_id=a msg=error"}},
+				},
+			},
+		},
+		{
+			// the same entries on both sides are only returned once.
+			qs:        `{app="api"} | logfmt join on (trace_id) [1s] {app="api"} | logfmt`,
+			direction: logproto.FORWARD,
+			limit:     100,
+			expected: logqlmodel.Streams{
+				{
+					Labels:  `{app="api", msg="request", trace_id="a"}`,
+					Entries: []logproto.Entry{{Timestamp: time.Unix(10, 0), Line: "trace_id=a msg=request"}},
+				},
+				{
+					Labels:  `{app="api", msg="request", trace_id="b"}`,
+					Entries: []logproto.Entry{{Timestamp: time.Unix(20, 0), Line: "trace_id=b msg=request"}},
+				},
+				{
+					Labels:  `{app="api", msg="request", trace_id="c"}`,
+					Entries: []logproto.Entry{{Timestamp: time.Unix(30, 0), Line: "trace_id=c msg=request"}},
+				},
+			},
+		},
+	} {
+		t.Run(test.qs, func(t *testing.T) {
+			eng := NewEngine(EngineOpts{}, querier, &fakeLimits{maxJoinEntries: 100}, log.NewNopLogger())
+			params, err := NewLiteralParams(test.qs, time.Unix(0, 0), time.Unix(100, 0), 0, 0, test.direction, test.limit, nil, nil)
+			require.NoError(t, err)
+
+			res, err := eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res.Data)
+		})
+	}
+}
+
+func TestEngine_JoinLimit(t *testing.T) {
+	querier := NewMockQuerier(0, []logproto.Stream{
+		newStream(10, identity, `{app="api"}`),
+		newStream(10, identity, `{app="db"}`),
+		newStream(3, identity, `{app="web"}`),
+	})
+	for _, qs := range []string{
+		`{app="api"} join on (app) [1m] {app="db"}`,
+		// only the left side exceeds the limit.
+		`{app="api"} join on (app) [1m] {app="web"}`,
+		// only the right side exceeds the limit.
+		`{app="web"} join on (app) [1m] {app="db"}`,
+	} {
+		t.Run(qs, func(t *testing.T) {
+			params, err := NewLiteralParams(qs, time.Unix(0, 0), time.Unix(100, 0), 0, 0, logproto.FORWARD, 100, nil, nil)
+			require.NoError(t, err)
+
+			eng := NewEngine(EngineOpts{}, querier, &fakeLimits{maxJoinEntries: 5}, log.NewNopLogger())
+			_, err = eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+			require.True(t, errors.Is(err, logqlmodel.ErrLimit))
+			require.ErrorContains(t, err, "maximum number of join entries (5) reached")
+
+			eng = NewEngine(EngineOpts{}, querier, &fakeLimits{maxJoinEntries: 10}, log.NewNopLogger())
+			_, err = eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+			require.NoError(t, err)
+		})
+	}
+}
@@ -17,6 +17,8 @@ var NoLimits = &fakeLimits{
 // Limits allow the engine to fetch limits for a given users.
 type Limits interface {
 	MaxQuerySeries(context.Context, string) int
+	MaxJoinEntries(context.Context, string) int
+	MaxEntriesLimitPerQuery(context.Context, string) int
 	MaxQueryRange(ctx context.Context, userID string) time.Duration
 	QueryTimeout(context.Context, string) time.Duration
 	BlockedQueries(context.Context, string) []*validation.BlockedQuery
@@ -25,6 +27,8 @@ type Limits interface {
 
 type fakeLimits struct {
 	maxSeries               int
+	maxJoinEntries          int
+	maxEntries              int
 	timeout                 time.Duration
 	blockedQueries          []*validation.BlockedQuery
 	rangeLimit              time.Duration
@@ -36,6 +40,14 @@ func (f fakeLimits) MaxQuerySeries(_ context.Context, _ string) int {
 	return f.maxSeries
 }
 
+func (f fakeLimits) MaxJoinEntries(_ context.Context, _ string) int {
+	return f.maxJoinEntries
+}
+
+func (f fakeLimits) MaxEntriesLimitPerQuery(_ context.Context, _ string) int {
+	return f.maxEntries
+}
+
 func (f fakeLimits) MaxQueryRange(_ context.Context, _ string) time.Duration {
 	return f.rangeLimit
 }
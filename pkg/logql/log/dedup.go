@@ -0,0 +1,131 @@
+package log
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+var dedupSeparator = []byte{0xff}
+
+// Deduplicator reports the lines that are duplicates of a line seen before.
+// Two lines are duplicates when they have the same content, the same label
+// values and their timestamps are at most the dedup window apart. The first
+// line seen is kept, the following duplicates are dropped.
+//
+// Lines are expected in timestamp order, in either direction. The kept lines
+// are forgotten once a line more than the dedup window apart is seen, so that
+// only the lines within the window are held in memory.
+type Deduplicator struct {
+	within int64
+	digest *xxhash.Digest
+	// kept holds the sorted timestamps of the kept lines by key.
+	kept map[uint64][]int64
+	// order holds the kept lines in the order they were kept.
+	order []keptLine
+}
+
+type keptLine struct {
+	key uint64
+	ts  int64
+}
+
+// NewDeduplicator creates a Deduplicator. A zero window only considers lines
+// with the exact same timestamp as duplicates.
+func NewDeduplicator(within time.Duration) *Deduplicator {
+	return &Deduplicator{
+		within: within.Nanoseconds(),
+		digest: xxhash.New(),
+		kept:   map[uint64][]int64{},
+	}
+}
+
+// Duplicate returns true if the line is a duplicate of a line kept before.
+// Otherwise the line is recorded as kept.
+func (d *Deduplicator) Duplicate(ts int64, line []byte, values ...string) bool {
+	d.evict(ts)
+
+	d.digest.Reset()
+	for _, v := range values {
+		_, _ = d.digest.WriteString(v)
+		_, _ = d.digest.Write(dedupSeparator)
+	}
+	_, _ = d.digest.Write(line)
+	key := d.digest.Sum64()
+
+	kept := d.kept[key]
+	i := sort.Search(len(kept), func(i int) bool { return kept[i] >= ts-d.within })
+	if i < len(kept) && kept[i] <= ts+d.within {
+		return true
+	}
+	d.kept[key] = slices.Insert(kept, i, ts)
+	d.order = append(d.order, keptLine{key: key, ts: ts})
+	return false
+}
+
+// evict forgets the kept lines that are more than the dedup window apart
+// from ts, starting with the oldest kept line. They can't be duplicates of
+// the lines after ts when lines are in timestamp order.
+func (d *Deduplicator) evict(ts int64) {
+	n := 0
+	for _, l := range d.order {
+		if l.ts >= ts-d.within && l.ts <= ts+d.within {
+			break
+		}
+		n++
+
+		kept := d.kept[l.key]
+		if i, ok := slices.BinarySearch(kept, l.ts); ok {
+			kept = slices.Delete(kept, i, i+1)
+		}
+		if len(kept) == 0 {
+			delete(d.kept, l.key)
+		} else {
+			d.kept[l.key] = kept
+		}
+	}
+	if n > 0 {
+		d.order = slices.Delete(d.order, 0, n)
+	}
+}
+
+// DedupStage drops the lines that are duplicates of a line seen before in the
+// same stream. When labels are given, lines are deduplicated across streams
+// by the values of these labels instead.
+type DedupStage struct {
+	by []string
+
+	mtx          sync.Mutex
+	deduplicator *Deduplicator
+	values       []string
+}
+
+// NewDedupStage creates a DedupStage.
+func NewDedupStage(by []string, within time.Duration) *DedupStage {
+	return &DedupStage{
+		by:           by,
+		deduplicator: NewDeduplicator(within),
+		values:       make([]string, max(len(by), 1)),
+	}
+}
+
+func (d *DedupStage) Process(ts int64, line []byte, lbs *LabelsBuilder) ([]byte, bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if len(d.by) == 0 {
+		d.values[0] = lbs.currentResult.String()
+	} else {
+		for i, name := range d.by {
+			d.values[i], _ = lbs.Get(name)
+		}
+	}
+	return line, !d.deduplicator.Duplicate(ts, line, d.values...)
+}
+
+func (d *DedupStage) RequiredLabelNames() []string {
+	return d.by
+}
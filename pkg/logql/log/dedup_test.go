@@ -0,0 +1,126 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicator(t *testing.T) {
+	d := NewDeduplicator(0)
+	require.False(t, d.Duplicate(10, []byte("line")))
+	require.True(t, d.Duplicate(10, []byte("line")))
+	require.False(t, d.Duplicate(10, []byte("other")))
+	require.False(t, d.Duplicate(10, []byte("line"), "a"))
+	require.True(t, d.Duplicate(10, []byte("line"), "a"))
+	require.False(t, d.Duplicate(10, []byte("line"), "b"))
+	require.False(t, d.Duplicate(11, []byte("line")))
+	// the lines kept at 10 are forgotten.
+	require.Len(t, d.kept, 1)
+	require.Len(t, d.order, 1)
+
+	t.Run("forward", func(t *testing.T) {
+		d := NewDeduplicator(5 * time.Nanosecond)
+		require.False(t, d.Duplicate(20, []byte("line")))
+		require.True(t, d.Duplicate(25, []byte("line")))
+		require.False(t, d.Duplicate(26, []byte("line")))
+		// the line kept at 26 is within the window.
+		require.True(t, d.Duplicate(31, []byte("line")))
+		require.False(t, d.Duplicate(32, []byte("line")))
+		require.Equal(t, map[uint64][]int64{d.order[0].key: {32}}, d.kept)
+	})
+
+	t.Run("backward", func(t *testing.T) {
+		d := NewDeduplicator(5 * time.Nanosecond)
+		require.False(t, d.Duplicate(20, []byte("line")))
+		require.True(t, d.Duplicate(15, []byte("line")))
+		require.False(t, d.Duplicate(14, []byte("line")))
+		require.True(t, d.Duplicate(9, []byte("line")))
+		require.False(t, d.Duplicate(8, []byte("line")))
+		require.Equal(t, map[uint64][]int64{d.order[0].key: {8}}, d.kept)
+	})
+}
+
+func TestDedupStage(t *testing.T) {
+	type line struct {
+		ts   int64
+		line string
+		lbs  labels.Labels
+		keep bool
+	}
+
+	var (
+		foo = labels.FromStrings("app", "foo", "pod", "a")
+		bar = labels.FromStrings("app", "foo", "pod", "b")
+	)
+
+	tests := []struct {
+		name   string
+		by     []string
+		within time.Duration
+		lines  []line
+	}{
+		{
+			"stream",
+			nil,
+			0,
+			[]line{
+				{1, "hello", foo, true},
+				{1, "hello", foo, false},
+				{1, "hello", bar, true},
+				{2, "hello", foo, true},
+				{1, "world", foo, true},
+			},
+		},
+		{
+			"by",
+			[]string{"app"},
+			0,
+			[]line{
+				{1, "hello", foo, true},
+				{1, "hello", bar, false},
+				{1, "hello", labels.FromStrings("app", "bar"), true},
+				{1, "hello", labels.FromStrings("pod", "a"), true},
+				{1, "hello", labels.FromStrings("pod", "b"), false},
+			},
+		},
+		{
+			"within",
+			nil,
+			time.Second,
+			[]line{
+				{0, "hello", foo, true},
+				{int64(time.Second), "hello", foo, false},
+				{int64(2 * time.Second), "hello", foo, true},
+				{int64(time.Second), "hello", bar, true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPipeline([]Stage{NewDedupStage(tt.by, tt.within)})
+			for i, l := range tt.lines {
+				_, _, ok := p.ForStream(l.lbs).Process(l.ts, []byte(l.line), labels.EmptyLabels())
+				require.Equal(t, l.keep, ok, "line %d", i)
+			}
+		})
+	}
+}
+
+func TestDedupStage_ParsedLabel(t *testing.T) {
+	p := NewPipeline([]Stage{
+		NewLogfmtParser(false, false),
+		NewDedupStage([]string{"trace_id"}, 0),
+	})
+	foo := p.ForStream(labels.FromStrings("app", "foo"))
+	bar := p.ForStream(labels.FromStrings("app", "bar"))
+
+	_, _, ok := foo.Process(0, []byte("trace_id=1"), labels.EmptyLabels())
+	require.True(t, ok)
+	_, _, ok = bar.Process(0, []byte("trace_id=1"), labels.EmptyLabels())
+	require.False(t, ok)
+	_, _, ok = bar.Process(0, []byte("trace_id=2"), labels.EmptyLabels())
+	require.True(t, ok)
+}
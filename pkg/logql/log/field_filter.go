@@ -0,0 +1,159 @@
+package log
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"github.com/dustin/go-humanize"
+
+	"github.com/grafana/loki/v3/pkg/logql/log/logfmt"
+)
+
+// FieldFormat is the format of the lines read by a FieldFilter.
+type FieldFormat int
+
+const (
+	FieldFormatJSON FieldFormat = iota
+	FieldFormatLogfmt
+	// FieldFormatStructuredMetadata reads the field from the structured
+	// metadata of the lines instead of the lines themselves.
+	FieldFormatStructuredMetadata
+)
+
+// FieldFilter keeps the lines of which a single JSON or logfmt field, or a
+// single structured metadata label, matches a filter. Only the field is read
+// from the line: the line is neither fully parsed nor are labels extracted
+// from it, which makes the filter cheap enough to be applied while reading the
+// lines.
+//
+// Like the label filters, string filters compare a missing field with an empty
+// value, while conversion filters (numbers, durations and bytes) drop the
+// lines where the field is missing or cannot be converted.
+type FieldFilter struct {
+	format FieldFormat
+	path   []string
+	filter LabelFilterer
+}
+
+// NewFieldFilter creates a FieldFilter reading the field at path, which must
+// have a single element for logfmt lines and structured metadata. The filter
+// is applied to the value of the field regardless of its name.
+func NewFieldFilter(format FieldFormat, path []string, filter LabelFilterer) (*FieldFilter, error) {
+	if len(path) == 0 {
+		return nil, errors.New("field filter requires a field")
+	}
+	if format == FieldFormatLogfmt && len(path) > 1 {
+		return nil, fmt.Errorf("logfmt fields can not be nested: %v", path)
+	}
+	if format == FieldFormatStructuredMetadata && len(path) > 1 {
+		return nil, fmt.Errorf("structured metadata fields can not be nested: %v", path)
+	}
+	switch filter.(type) {
+	case *StringLabelFilter, *LineFilterLabelFilter, *NoopLabelFilter, *NumericLabelFilter, *DurationLabelFilter, *BytesLabelFilter:
+	default:
+		return nil, fmt.Errorf("unsupported field filter %s", filter)
+	}
+	return &FieldFilter{
+		format: format,
+		path:   path,
+		filter: filter,
+	}, nil
+}
+
+func (f *FieldFilter) Process(_ int64, line []byte, lbs *LabelsBuilder) ([]byte, bool) {
+	if f.format == FieldFormatStructuredMetadata {
+		v, category, ok := lbs.GetWithCategory(f.path[0])
+		if !ok || category != StructuredMetadataLabel {
+			return line, f.MatchesValue(nil, false)
+		}
+		return line, f.MatchesValue(unsafeGetBytes(v), true)
+	}
+	return line, f.Matches(line)
+}
+
+func (f *FieldFilter) RequiredLabelNames() []string { return nil }
+
+// Matches returns true if the field of the line matches the filter. For
+// structured metadata, the line is the value of the label.
+func (f *FieldFilter) Matches(line []byte) bool {
+	v, ok := f.value(line)
+	return f.MatchesValue(v, ok)
+}
+
+// MatchesValue returns true if the value of the field matches the filter, ok
+// being false if the field is missing.
+func (f *FieldFilter) MatchesValue(v []byte, ok bool) bool {
+	switch filter := f.filter.(type) {
+	case *StringLabelFilter:
+		return filter.Matches(unsafeGetString(v))
+	case *LineFilterLabelFilter:
+		return filter.Filter.Filter(v)
+	case *NoopLabelFilter:
+		return true
+	}
+
+	if !ok {
+		return false
+	}
+	switch filter := f.filter.(type) {
+	case *NumericLabelFilter:
+		value, err := strconv.ParseFloat(unsafeGetString(v), 64)
+		return err == nil && compareField(filter.Type, value, filter.Value)
+	case *DurationLabelFilter:
+		value, err := time.ParseDuration(unsafeGetString(v))
+		return err == nil && compareField(filter.Type, value, filter.Value)
+	case *BytesLabelFilter:
+		value, err := humanize.ParseBytes(unsafeGetString(v))
+		return err == nil && compareField(filter.Type, value, filter.Value)
+	}
+	return false
+}
+
+// value returns the value of the field and whether the line has the field.
+func (f *FieldFilter) value(line []byte) ([]byte, bool) {
+	switch f.format {
+	case FieldFormatJSON:
+		v, typ, _, err := jsonparser.Get(line, f.path...)
+		if err != nil || typ == jsonparser.Null {
+			return nil, false
+		}
+		if typ == jsonparser.String {
+			if v, err = jsonparser.Unescape(v, nil); err != nil {
+				return nil, false
+			}
+		}
+		return v, true
+	case FieldFormatLogfmt:
+		dec := logfmt.NewDecoder(line)
+		for dec.ScanKeyval() {
+			if string(dec.Key()) == f.path[0] {
+				return dec.Value(), true
+			}
+		}
+	case FieldFormatStructuredMetadata:
+		return line, true
+	}
+	return nil, false
+}
+
+func compareField[T cmp.Ordered](t LabelFilterType, value, filter T) bool {
+	switch t {
+	case LabelFilterEqual:
+		return value == filter
+	case LabelFilterNotEqual:
+		return value != filter
+	case LabelFilterGreaterThan:
+		return value > filter
+	case LabelFilterGreaterThanOrEqual:
+		return value >= filter
+	case LabelFilterLesserThan:
+		return value < filter
+	case LabelFilterLesserThanOrEqual:
+		return value <= filter
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldFilter(t *testing.T) {
+	var (
+		jsonLine   = []byte(`{"status": 503, "method": "GET", "msg": "a \"quoted\" value", "response": {"took": "1.5s", "size": "2KB", "error": null}}`)
+		logfmtLine = []byte(`level=error status=503 msg="a \"quoted\" value" took=1.5s`)
+	)
+
+	tests := []struct {
+		name   string
+		format FieldFormat
+		path   []string
+		filter LabelFilterer
+		line   []byte
+		want   bool
+	}{
+		{"json number", FieldFormatJSON, []string{"status"}, NewNumericLabelFilter(LabelFilterGreaterThanOrEqual, "status", 500), jsonLine, true},
+		{"json number not matching", FieldFormatJSON, []string{"status"}, NewNumericLabelFilter(LabelFilterLesserThan, "status", 500), jsonLine, false},
+		{"json string", FieldFormatJSON, []string{"method"}, NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "method", "GET")), jsonLine, true},
+		{"json escaped string", FieldFormatJSON, []string{"msg"}, NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "msg", `a "quoted" value`)), jsonLine, true},
+		{"json regexp", FieldFormatJSON, []string{"method"}, NewStringLabelFilter(labels.MustNewMatcher(labels.MatchRegexp, "method", "GET|POST")), jsonLine, true},
+		{"json nested duration", FieldFormatJSON, []string{"response", "took"}, NewDurationLabelFilter(LabelFilterGreaterThan, "took", time.Second), jsonLine, true},
+		{"json nested bytes", FieldFormatJSON, []string{"response", "size"}, NewBytesLabelFilter(LabelFilterLesserThanOrEqual, "size", 1000), jsonLine, false},
+		{"json missing field compared with empty string", FieldFormatJSON, []string{"user"}, NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "user", "")), jsonLine, true},
+		{"json null field", FieldFormatJSON, []string{"response", "error"}, NewStringLabelFilter(labels.MustNewMatcher(labels.MatchNotEqual, "error", "")), jsonLine, false},
+		{"json missing field with conversion", FieldFormatJSON, []string{"user"}, NewNumericLabelFilter(LabelFilterNotEqual, "user", 1), jsonLine, false},
+		{"json invalid conversion", FieldFormatJSON, []string{"method"}, NewNumericLabelFilter(LabelFilterNotEqual, "method", 1), jsonLine, false},
+		{"invalid json", FieldFormatJSON, []string{"status"}, NewNumericLabelFilter(LabelFilterGreaterThanOrEqual, "status", 500), logfmtLine, false},
+		{"logfmt number", FieldFormatLogfmt, []string{"status"}, NewNumericLabelFilter(LabelFilterEqual, "status", 503), logfmtLine, true},
+		{"logfmt string", FieldFormatLogfmt, []string{"level"}, NewStringLabelFilter(labels.MustNewMatcher(labels.MatchNotEqual, "level", "error")), logfmtLine, false},
+		{"logfmt escaped string", FieldFormatLogfmt, []string{"msg"}, NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "msg", `a "quoted" value`)), logfmtLine, true},
+		{"logfmt duration", FieldFormatLogfmt, []string{"took"}, NewDurationLabelFilter(LabelFilterLesserThan, "took", time.Second), logfmtLine, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFieldFilter(tt.format, tt.path, tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, f.Matches(tt.line))
+
+			_, _, ok := NewPipeline([]Stage{f}).ForStream(labels.EmptyLabels()).Process(0, tt.line, labels.EmptyLabels())
+			require.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestFieldFilter_StructuredMetadata(t *testing.T) {
+	metadata := labels.FromStrings("trace_id", "abc", "took", "1.5s")
+
+	tests := []struct {
+		name   string
+		filter LabelFilterer
+		want   bool
+	}{
+		{"string", NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "trace_id", "abc")), true},
+		{"duration", NewDurationLabelFilter(LabelFilterGreaterThan, "took", 2*time.Second), false},
+		{"missing label compared with empty string", NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "user", "")), true},
+		{"missing label with conversion", NewNumericLabelFilter(LabelFilterNotEqual, "user", 1), false},
+		{"stream label", NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "app", "foo")), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFieldFilter(FieldFormatStructuredMetadata, []string{tt.filter.RequiredLabelNames()[0]}, tt.filter)
+			require.NoError(t, err)
+
+			_, _, ok := NewPipeline([]Stage{f}).ForStream(labels.FromStrings("app", "foo")).Process(0, []byte("line"), metadata)
+			require.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestNewFieldFilter_Errors(t *testing.T) {
+	filter := NewNumericLabelFilter(LabelFilterEqual, "status", 1)
+
+	_, err := NewFieldFilter(FieldFormatJSON, nil, filter)
+	require.Error(t, err)
+	_, err = NewFieldFilter(FieldFormatLogfmt, []string{"response", "status"}, filter)
+	require.EqualError(t, err, "logfmt fields can not be nested: [response status]")
+	_, err = NewFieldFilter(FieldFormatStructuredMetadata, []string{"response", "status"}, filter)
+	require.EqualError(t, err, "structured metadata fields can not be nested: [response status]")
+	_, err = NewFieldFilter(FieldFormatJSON, []string{"status"}, NewAndLabelFilter(filter, filter))
+	require.Error(t, err)
+}
@@ -0,0 +1,182 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+const (
+	LookupTableFormatCSV  = "csv"
+	LookupTableFormatJSON = "json"
+)
+
+var _ Stage = &LookupStage{}
+
+// LookupTables resolves the lookup tables of a tenant by name.
+type LookupTables interface {
+	LookupTable(ctx context.Context, tenant, name string) (*LookupTable, error)
+}
+
+// LookupTable maps the values of a key to a set of labels.
+type LookupTable struct {
+	rows map[string]labels.Labels
+}
+
+// NewLookupTable creates a lookup table from the labels of each key.
+func NewLookupTable(rows map[string]labels.Labels) *LookupTable {
+	return &LookupTable{rows: rows}
+}
+
+// ParseLookupTable parses a lookup table in the given format.
+//
+// A csv table starts with a header row naming its columns. The first column
+// holds the keys and the other columns are the names of the labels added for
+// each key, empty cells are ignored.
+//
+// A json table is an object mapping each key to an object of label names and
+// string values, e.g. {"42": {"tier": "gold", "region": "eu"}}.
+func ParseLookupTable(format string, data []byte) (*LookupTable, error) {
+	switch format {
+	case LookupTableFormatCSV:
+		return parseCSVLookupTable(data)
+	case LookupTableFormatJSON:
+		return parseJSONLookupTable(data)
+	default:
+		return nil, fmt.Errorf("unsupported lookup table format %q", format)
+	}
+}
+
+func parseCSVLookupTable(data []byte) (*LookupTable, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.ReuseRecord = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(header) < 2 {
+		return nil, errors.New("at least one label column must be supplied")
+	}
+	names := make([]string, len(header)-1)
+	for i, name := range header[1:] {
+		if err := validateLookupLabelName(name); err != nil {
+			return nil, err
+		}
+		names[i] = name
+	}
+
+	rows := map[string]labels.Labels{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := rows[record[0]]; ok {
+			return nil, fmt.Errorf("duplicate key %q", record[0])
+		}
+
+		b := labels.NewScratchBuilder(len(names))
+		for i, value := range record[1:] {
+			if value != "" {
+				b.Add(names[i], value)
+			}
+		}
+		b.Sort()
+		rows[record[0]] = b.Labels()
+	}
+	return NewLookupTable(rows), nil
+}
+
+func parseJSONLookupTable(data []byte) (*LookupTable, error) {
+	var table map[string]map[string]string
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, err
+	}
+
+	rows := make(map[string]labels.Labels, len(table))
+	for key, row := range table {
+		for name := range row {
+			if err := validateLookupLabelName(name); err != nil {
+				return nil, err
+			}
+		}
+		rows[key] = labels.FromMap(row)
+	}
+	return NewLookupTable(rows), nil
+}
+
+func validateLookupLabelName(name string) error {
+	if name == "" || !model.LabelName(name).IsValid() {
+		return fmt.Errorf("invalid label name '%s'", name)
+	}
+	return nil
+}
+
+// Len returns the number of keys of the table.
+func (t *LookupTable) Len() int {
+	return len(t.rows)
+}
+
+// Lookup returns the labels of the key.
+func (t *LookupTable) Lookup(key string) (labels.Labels, bool) {
+	lbs, ok := t.rows[key]
+	return lbs, ok
+}
+
+// LookupStage is a log stage that adds the labels of a lookup table row to
+// the log line.
+type LookupStage struct {
+	table *LookupTable
+	label string
+}
+
+// NewLookupStage creates a log stage that looks up the value of label in
+// table and adds the labels of the matching row. Lines without the label or
+// with a value missing from the table are left untouched.
+func NewLookupStage(table *LookupTable, label string) (*LookupStage, error) {
+	if table == nil {
+		return nil, errors.New("lookup table must be supplied")
+	}
+	if err := validateLookupLabelName(label); err != nil {
+		return nil, err
+	}
+	return &LookupStage{
+		table: table,
+		label: label,
+	}, nil
+}
+
+func (l *LookupStage) Process(_ int64, line []byte, lbs *LabelsBuilder) ([]byte, bool) {
+	key, ok := lbs.Get(l.label)
+	if !ok {
+		return line, true
+	}
+	row, ok := l.table.Lookup(key)
+	if !ok {
+		return line, true
+	}
+	row.Range(func(lbl labels.Label) {
+		name := lbl.Name
+		if lbs.BaseHas(name) {
+			name = name + duplicateSuffix
+		}
+		lbs.Set(ParsedLabel, name, lbl.Value)
+	})
+	return line, true
+}
+
+func (l *LookupStage) RequiredLabelNames() []string { return []string{l.label} }
@@ -0,0 +1,137 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLookupTable(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   string
+		want   map[string]labels.Labels
+		err    string
+	}{
+		{
+			"csv",
+			LookupTableFormatCSV,
+			"customer,tier,region\n42,gold,eu\n43,silver,\n\"4,4\",bronze,us\n",
+			map[string]labels.Labels{
+				"42":  labels.FromStrings("tier", "gold", "region", "eu"),
+				"43":  labels.FromStrings("tier", "silver"),
+				"4,4": labels.FromStrings("tier", "bronze", "region", "us"),
+			},
+			"",
+		},
+		{
+			"json",
+			LookupTableFormatJSON,
+			`{"42": {"tier": "gold", "region": "eu"}, "43": {}}`,
+			map[string]labels.Labels{
+				"42": labels.FromStrings("tier", "gold", "region", "eu"),
+				"43": labels.EmptyLabels(),
+			},
+			"",
+		},
+		{"unsupported format", "yaml", "", nil, `unsupported lookup table format "yaml"`},
+		{"csv missing header", LookupTableFormatCSV, "", nil, "missing header row"},
+		{"csv no label column", LookupTableFormatCSV, "customer\n42\n", nil, "at least one label column must be supplied"},
+		{"csv invalid label name", LookupTableFormatCSV, "customer,\n42,gold\n", nil, "invalid label name ''"},
+		{"csv duplicate key", LookupTableFormatCSV, "customer,tier\n42,gold\n42,silver\n", nil, `duplicate key "42"`},
+		{"csv wrong number of fields", LookupTableFormatCSV, "customer,tier\n42,gold,eu\n", nil, "wrong number of fields"},
+		{"json not a string", LookupTableFormatJSON, `{"42": {"tier": 1}}`, nil, "cannot unmarshal number"},
+		{"json invalid label name", LookupTableFormatJSON, `{"42": {"": "gold"}}`, nil, "invalid label name ''"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := ParseLookupTable(tt.format, []byte(tt.data))
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, len(tt.want), table.Len())
+			for key, want := range tt.want {
+				got, ok := table.Lookup(key)
+				require.True(t, ok, key)
+				require.Equal(t, want, got)
+			}
+		})
+	}
+}
+
+func TestLookupStage(t *testing.T) {
+	table := NewLookupTable(map[string]labels.Labels{
+		"42": labels.FromStrings("tier", "gold", "region", "eu"),
+	})
+
+	tests := []struct {
+		name string
+		lbs  labels.Labels
+		want labels.Labels
+	}{
+		{
+			"match",
+			labels.FromStrings("customer", "42"),
+			labels.FromStrings("customer", "42", "tier", "gold", "region", "eu"),
+		},
+		{
+			"no match",
+			labels.FromStrings("customer", "43"),
+			labels.FromStrings("customer", "43"),
+		},
+		{
+			"missing label",
+			labels.FromStrings("app", "foo"),
+			labels.FromStrings("app", "foo"),
+		},
+		{
+			"duplicate",
+			labels.FromStrings("customer", "42", "tier", "free"),
+			labels.FromStrings("customer", "42", "tier", "free", "tier_extracted", "gold", "region", "eu"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewLookupStage(table, "customer")
+			require.NoError(t, err)
+			b := NewBaseLabelsBuilder().ForLabels(tt.lbs, labels.StableHash(tt.lbs))
+			b.Reset()
+			_, ok := s.Process(0, []byte("line"), b)
+			require.True(t, ok)
+			require.Equal(t, tt.want, b.LabelsResult().Labels())
+		})
+	}
+}
+
+func TestLookupStage_ParsedLabel(t *testing.T) {
+	table := NewLookupTable(map[string]labels.Labels{
+		"42": labels.FromStrings("tier", "gold"),
+	})
+	s, err := NewLookupStage(table, "customer")
+	require.NoError(t, err)
+
+	p := NewPipeline([]Stage{
+		NewLogfmtParser(false, false),
+		s,
+		NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "tier", "gold")),
+	}).ForStream(labels.FromStrings("app", "foo"))
+
+	_, lbs, ok := p.Process(0, []byte("customer=42 msg=hello"), labels.EmptyLabels())
+	require.True(t, ok)
+	require.Equal(t, labels.FromStrings("app", "foo", "customer", "42", "msg", "hello", "tier", "gold"), lbs.Labels())
+
+	_, _, ok = p.Process(0, []byte("customer=43 msg=hello"), labels.EmptyLabels())
+	require.False(t, ok)
+}
+
+func TestNewLookupStage(t *testing.T) {
+	_, err := NewLookupStage(nil, "customer")
+	require.EqualError(t, err, "lookup table must be supplied")
+
+	_, err = NewLookupStage(NewLookupTable(nil), "")
+	require.EqualError(t, err, "invalid label name ''")
+}
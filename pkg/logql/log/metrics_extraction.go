@@ -259,6 +259,7 @@ func (p *filteringSampleExtractor) ForStream(labels labels.Labels) StreamSampleE
 			streamFilters = append(streamFilters, streamFilter{
 				start:    f.Start,
 				end:      f.End,
+				rewrite:  f.Rewrite,
 				pipeline: f.Pipeline.ForStream(labels),
 			})
 		}
@@ -284,13 +285,10 @@ func (sp *filteringStreamExtractor) BaseLabels() LabelsResult {
 }
 
 func (sp *filteringStreamExtractor) Process(ts int64, line []byte, structuredMetadata labels.Labels) ([]ExtractedSample, bool) {
+	var filtered bool
 	for _, filter := range sp.filters {
-		if ts < filter.start || ts > filter.end {
-			continue
-		}
-
-		_, _, matches := filter.pipeline.Process(ts, line, structuredMetadata)
-		if matches { // When the filter matches, don't run the next step
+		line, structuredMetadata, filtered = filter.apply(ts, line, structuredMetadata)
+		if filtered { // When the filter matches, don't run the next step
 			return nil, false
 		}
 	}
@@ -299,13 +297,10 @@ func (sp *filteringStreamExtractor) Process(ts int64, line []byte, structuredMet
 }
 
 func (sp *filteringStreamExtractor) ProcessString(ts int64, line string, structuredMetadata labels.Labels) ([]ExtractedSample, bool) {
+	var filtered bool
 	for _, filter := range sp.filters {
-		if ts < filter.start || ts > filter.end {
-			continue
-		}
-
-		_, _, matches := filter.pipeline.ProcessString(ts, line, structuredMetadata)
-		if matches { // When the filter matches, don't run the next step
+		line, structuredMetadata, filtered = filter.applyString(ts, line, structuredMetadata)
+		if filtered { // When the filter matches, don't run the next step
 			return nil, false
 		}
 	}
@@ -0,0 +1,148 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	DefaultCSVDelimiter = ','
+	DefaultCSVQuote     = '"'
+)
+
+var (
+	_ Stage = &CSVParser{}
+
+	errCSVUnterminatedQuote = errors.New("missing closing quote in quoted field")
+	errCSVExtraneousQuote   = errors.New("extraneous character after quoted field")
+)
+
+// CSVParser is a log stage that parses a line of delimiter-separated values.
+type CSVParser struct {
+	columns   []string
+	delimiter rune
+	quote     rune
+	field     []byte // buffer used to unquote fields
+}
+
+// NewCSVParser creates a log stage that splits a log line into fields separated
+// by delimiter and adds every field as a label named after its column. Fields
+// may be enclosed in quote, a quote inside a quoted field is escaped by
+// doubling it. Fields without a column or with an empty column name are
+// ignored.
+func NewCSVParser(columns []string, delimiter, quote rune) (*CSVParser, error) {
+	var named bool
+	for _, c := range columns {
+		if c == "" {
+			continue
+		}
+		if !model.LabelName(c).IsValid() {
+			return nil, fmt.Errorf("invalid column name '%s'", c)
+		}
+		named = true
+	}
+	if !named {
+		return nil, errors.New("at least one column name must be supplied")
+	}
+	if !validCSVRune(delimiter) {
+		return nil, fmt.Errorf("invalid delimiter %q", delimiter)
+	}
+	if !validCSVRune(quote) {
+		return nil, fmt.Errorf("invalid quote %q", quote)
+	}
+	if delimiter == quote {
+		return nil, errors.New("delimiter and quote must be different")
+	}
+
+	return &CSVParser{
+		columns:   columns,
+		delimiter: delimiter,
+		quote:     quote,
+	}, nil
+}
+
+func validCSVRune(r rune) bool {
+	return r != 0 && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+func (c *CSVParser) Process(_ int64, line []byte, lbs *LabelsBuilder) ([]byte, bool) {
+	parserHints := lbs.ParserLabelHints()
+	if parserHints.NoLabels() {
+		return line, true
+	}
+
+	var (
+		field []byte
+		err   error
+	)
+	rest := line
+	for i := 0; i < len(c.columns) && rest != nil; i++ {
+		field, rest, err = c.next(rest)
+		if err != nil {
+			addErrLabel(errCSV, err, lbs)
+			return line, true
+		}
+
+		if c.columns[i] == "" {
+			continue
+		}
+		name, ok := parsedLabelName(c.columns[i], lbs)
+		if !ok {
+			continue
+		}
+
+		lbs.Set(ParsedLabel, name, string(field))
+		if !parserHints.ShouldContinueParsingLine(name, lbs) {
+			return line, false
+		}
+		if parserHints.AllRequiredExtracted() {
+			break
+		}
+	}
+	return line, true
+}
+
+// next returns the first field of the record and the rest of the record after
+// the delimiter, or nil if it was the last field.
+func (c *CSVParser) next(record []byte) ([]byte, []byte, error) {
+	r, size := utf8.DecodeRune(record)
+	if len(record) == 0 || r != c.quote {
+		i := bytes.IndexRune(record, c.delimiter)
+		if i < 0 {
+			return record, nil, nil
+		}
+		return record[:i], record[i+utf8.RuneLen(c.delimiter):], nil
+	}
+
+	c.field = c.field[:0]
+	record = record[size:]
+	for {
+		i := bytes.IndexRune(record, c.quote)
+		if i < 0 {
+			return nil, nil, errCSVUnterminatedQuote
+		}
+		c.field = append(c.field, record[:i]...)
+		record = record[i+size:]
+
+		// a doubled quote is an escaped quote.
+		if r, _ := utf8.DecodeRune(record); len(record) == 0 || r != c.quote {
+			break
+		}
+		c.field = utf8.AppendRune(c.field, c.quote)
+		record = record[size:]
+	}
+
+	if len(record) == 0 {
+		return c.field, nil, nil
+	}
+	if r, n := utf8.DecodeRune(record); r == c.delimiter {
+		return c.field, record[n:], nil
+	}
+	return nil, nil, errCSVExtraneousQuote
+}
+
+func (c *CSVParser) RequiredLabelNames() []string { return []string{} }
@@ -0,0 +1,163 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_csvParser_Parse(t *testing.T) {
+	tests := []struct {
+		name      string
+		columns   []string
+		delimiter rune
+		quote     rune
+		line      []byte
+		lbs       labels.Labels
+		want      labels.Labels
+		hints     ParserHint
+	}{
+		{
+			"simple",
+			[]string{"ts", "level", "msg"},
+			DefaultCSVDelimiter, DefaultCSVQuote,
+			[]byte(`2024-01-01T00:00:00Z,info,hello`),
+			labels.EmptyLabels(),
+			labels.FromStrings("ts", "2024-01-01T00:00:00Z",
+				"level", "info",
+				"msg", "hello",
+			),
+			NoParserHints(),
+		},
+		{
+			"quoted fields",
+			[]string{"level", "msg", "caller"},
+			DefaultCSVDelimiter, DefaultCSVQuote,
+			[]byte(`info,"hello, ""world""",`),
+			labels.EmptyLabels(),
+			labels.FromStrings("level", "info",
+				"msg", `hello, "world"`,
+				"caller", "",
+			),
+			NoParserHints(),
+		},
+		{
+			"skipped and missing columns",
+			[]string{"", "level", "msg", "caller"},
+			DefaultCSVDelimiter, DefaultCSVQuote,
+			[]byte(`1,info,hello`),
+			labels.EmptyLabels(),
+			labels.FromStrings("level", "info",
+				"msg", "hello",
+			),
+			NoParserHints(),
+		},
+		{
+			"extra fields",
+			[]string{"level"},
+			DefaultCSVDelimiter, DefaultCSVQuote,
+			[]byte(`info,hello`),
+			labels.EmptyLabels(),
+			labels.FromStrings("level", "info"),
+			NoParserHints(),
+		},
+		{
+			"custom delimiter and quote",
+			[]string{"level", "msg"},
+			';', '\'',
+			[]byte(`info;'it''s; fine'`),
+			labels.EmptyLabels(),
+			labels.FromStrings("level", "info",
+				"msg", "it's; fine",
+			),
+			NoParserHints(),
+		},
+		{
+			"unicode delimiter",
+			[]string{"level", "msg"},
+			'¦', DefaultCSVQuote,
+			[]byte(`info¦hello`),
+			labels.EmptyLabels(),
+			labels.FromStrings("level", "info",
+				"msg", "hello",
+			),
+			NoParserHints(),
+		},
+		{
+			"duplicate",
+			[]string{"app"},
+			DefaultCSVDelimiter, DefaultCSVQuote,
+			[]byte(`bar`),
+			labels.FromStrings("app", "foo"),
+			labels.FromStrings("app", "foo",
+				"app_extracted", "bar",
+			),
+			NoParserHints(),
+		},
+		{
+			"unterminated quote",
+			[]string{"level", "msg"},
+			DefaultCSVDelimiter, DefaultCSVQuote,
+			[]byte(`info,"hello`),
+			labels.EmptyLabels(),
+			labels.FromStrings("level", "info",
+				"__error__", "CSVParserErr",
+				"__error_details__", "missing closing quote in quoted field",
+			),
+			NoParserHints(),
+		},
+		{
+			"extraneous character after quote",
+			[]string{"level", "msg"},
+			DefaultCSVDelimiter, DefaultCSVQuote,
+			[]byte(`"info"x,hello`),
+			labels.EmptyLabels(),
+			labels.FromStrings("__error__", "CSVParserErr",
+				"__error_details__", "extraneous character after quoted field",
+			),
+			NoParserHints(),
+		},
+		{
+			"hints",
+			[]string{"ts", "level", "msg"},
+			DefaultCSVDelimiter, DefaultCSVQuote,
+			[]byte(`2024-01-01T00:00:00Z,info,hello`),
+			labels.EmptyLabels(),
+			labels.FromStrings("level", "info"),
+			NewParserHint([]string{"level"}, []string{"level"}, false, false, "", nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewCSVParser(tt.columns, tt.delimiter, tt.quote)
+			require.NoError(t, err)
+			b := NewBaseLabelsBuilderWithGrouping(nil, tt.hints, false, false).ForLabels(tt.lbs, labels.StableHash(tt.lbs))
+			b.Reset()
+			_, _ = p.Process(0, tt.line, b)
+			require.Equal(t, tt.want, b.LabelsResult().Labels())
+		})
+	}
+}
+
+func TestNewCSVParser(t *testing.T) {
+	tests := []struct {
+		name      string
+		columns   []string
+		delimiter rune
+		quote     rune
+		err       string
+	}{
+		{"no columns", nil, DefaultCSVDelimiter, DefaultCSVQuote, "at least one column name must be supplied"},
+		{"only empty columns", []string{"", ""}, DefaultCSVDelimiter, DefaultCSVQuote, "at least one column name must be supplied"},
+		{"invalid column", []string{""}, DefaultCSVDelimiter, DefaultCSVQuote, "at least one column name must be supplied"},
+		{"invalid delimiter", []string{"a"}, '\n', DefaultCSVQuote, `invalid delimiter '\n'`},
+		{"same delimiter and quote", []string{"a"}, ',', ',', "delimiter and quote must be different"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCSVParser(tt.columns, tt.delimiter, tt.quote)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
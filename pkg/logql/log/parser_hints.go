@@ -29,7 +29,7 @@ type ParserHint interface {
 	ShouldExtract(key string) bool
 
 	// Tells if there's any hint that start with the given prefix.
-	// This allows to speed up key searching in nested structured like json.
+	// This allows to speed up key searching in nested structured like json or xml.
 	ShouldExtractPrefix(prefix string) bool
 
 	// Tells if we should not extract any labels.
@@ -213,3 +213,17 @@ func appendLabelHints(dst []string, src ...string) []string {
 	}
 	return dst
 }
+
+// parsedLabelName returns the name under which a label parsed from the log
+// line is stored and whether it should be extracted at all according to the
+// parser hints. Labels colliding with a stream label get the `_extracted` suffix.
+func parsedLabelName(name string, lbs *LabelsBuilder) (string, bool) {
+	if lbs.BaseHas(name) {
+		name = name + duplicateSuffix
+	}
+	hints := lbs.ParserLabelHints()
+	if hints.Extracted(name) || !hints.ShouldExtract(name) {
+		return "", false
+	}
+	return name, true
+}
@@ -238,6 +238,8 @@ func TestKeyShortCircuit(t *testing.T) {
 	logfmtLine := []byte(`level=info ts=2020-12-14T21:25:20.947307459Z caller=metrics.go:83 org_id=29 traceID=c80e691e8db08e2 latency=fast query="sum by (object_name) (rate(({container=\"metrictank\", cluster=\"hm-us-east2\"} |= \"PANIC\")[5m]))" query_type=metric range_type=range length=5m0s step=15s duration=322.623724ms status=200 throughput=1.2GB total_bytes=375MB`)
 	nginxline := []byte(`10.1.0.88 - - [14/Dec/2020:22:56:24 +0000] "GET /static/img/about/bob.jpg HTTP/1.1" 200 60755 "https://grafana.com/go/observabilitycon/grafana-the-open-and-composable-observability-platform/?tech=ggl-o&pg=oss-graf&plcmt=hero-txt" "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.0.1 Safari/605.1.15" "123.123.123.123, 35.35.122.223" "TLSv1.3"`)
 	packedLike := []byte(`{"job":"123","pod":"someuid123","app":"foo","_entry":"10.1.0.88 - - [14/Dec/2020:22:56:24 +0000] GET /static/img/about/bob.jpg HTTP/1.1"}`)
+	xmlLine := []byte(`<event><level>info</level><msg>hello</msg></event>`)
+	csvLine := []byte(`info,"hello, world"`)
 
 	lbs := NewBaseLabelsBuilder().ForLabels(labels.EmptyLabels(), 0)
 	hints := newFakeParserHints()
@@ -257,6 +259,8 @@ func TestKeyShortCircuit(t *testing.T) {
 		{"logfmt", logfmtLine, NewLogfmtParser(false, false), labels.MustNewMatcher(labels.MatchEqual, "info", "nope")},
 		{"regex greedy", nginxline, mustStage(NewRegexpParser(`GET (?P<path>.*?)/\?`)), labels.MustNewMatcher(labels.MatchEqual, "path", "nope")},
 		{"pattern", nginxline, mustStage(NewPatternParser(`<_> "<method> <path> <_>"<_>`)), labels.MustNewMatcher(labels.MatchEqual, "method", "nope")},
+		{"xml", xmlLine, NewXMLParser(), labels.MustNewMatcher(labels.MatchEqual, "event_level", "nope")},
+		{"csv", csvLine, mustStage(NewCSVParser([]string{"level", "msg"}, DefaultCSVDelimiter, DefaultCSVQuote)), labels.MustNewMatcher(labels.MatchEqual, "level", "nope")},
 	} {
 		lbs.Reset()
 		t.Run(tt.name, func(t *testing.T) {
@@ -281,6 +285,8 @@ func TestLabelShortCircuit(t *testing.T) {
       "onMouseUp": "sun1.opacity = (sun1.opacity / 100) * 90;"
     }`)
 	logFmt := []byte(`data="ClickHere" size=36 style=bold name=text1 name=duplicate hOffset=250 vOffset=100 alignment=center onMouseUp="sun1.opacity = (sun1.opacity / 100) * 90;"`)
+	xmlFragment := []byte(`<data>Click Here</data><size>36</size><name>text1</name><name>duplicate</name><hOffset>250</hOffset>`)
+	csv := []byte(`ClickHere,36,bold,text1,duplicate,250`)
 
 	hints := newFakeParserHints()
 	hints.label = "name"
@@ -296,6 +302,8 @@ func TestLabelShortCircuit(t *testing.T) {
 		{"json", NewJSONParser(false), simpleJsn},
 		{"logfmt", NewLogfmtParser(false, false), logFmt},
 		{"logfmt-expression", mustStage(NewLogfmtExpressionParser([]LabelExtractionExpr{NewLabelExtractionExpr("name", "name")}, false)), logFmt},
+		{"xml", NewXMLParser(), xmlFragment},
+		{"csv", mustStage(NewCSVParser([]string{"data", "size", "style", "name", "name", "hOffset"}, DefaultCSVDelimiter, DefaultCSVQuote)), csv},
 	}
 	for _, tt := range tests {
 		lbs.Reset()
@@ -952,6 +960,8 @@ func BenchmarkKeyExtraction(b *testing.B) {
       "onMouseUp": "sun1.opacity = (sun1.opacity / 100) * 90;"
     }`)
 	logFmt := []byte(`data="Click Here" size=36 style=bold name=text1 hOffset=250 vOffset=100 alignment=center onMouseUp="sun1.opacity = (sun1.opacity / 100) * 90;"`)
+	xmlFragment := []byte(`<data>Click Here</data><size>36</size><style>bold</style><name>text1</name><hOffset>250</hOffset>`)
+	csv := []byte(`Click Here,36,bold,text1,250`)
 
 	lbs := NewBaseLabelsBuilder().ForLabels(labels.EmptyLabels(), 0)
 	lbs.parserKeyHints = NewParserHint([]string{"name"}, nil, false, true, "", nil)
@@ -964,6 +974,8 @@ func BenchmarkKeyExtraction(b *testing.B) {
 		{"json", NewJSONParser(false), simpleJsn},
 		{"logfmt", NewLogfmtParser(false, false), logFmt},
 		{"logfmt-expression", mustStage(NewLogfmtExpressionParser([]LabelExtractionExpr{NewLabelExtractionExpr("name", "name")}, false)), logFmt},
+		{"xml", NewXMLParser(), xmlFragment},
+		{"csv", mustStage(NewCSVParser([]string{"data", "size", "style", "name", "hOffset"}, DefaultCSVDelimiter, DefaultCSVQuote)), csv},
 	}
 	for _, bb := range benchmarks {
 		b.Run(bb.name, func(b *testing.B) {
@@ -0,0 +1,429 @@
+package log
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+)
+
+var (
+	_ Stage = &XMLParser{}
+	_ Stage = &XMLExpressionParser{}
+
+	errUnexpectedXMLElement = errors.New("expecting xml element, but it is not")
+)
+
+// xmlFrame is an element of the XMLParser stack.
+type xmlFrame struct {
+	prefixLen int  // length of the key buffer before this element was added
+	leaf      bool // whether the element has no child elements
+}
+
+// XMLParser is a log stage that parses XML documents or fragments.
+type XMLParser struct {
+	reader   *bytes.Reader
+	stack    []xmlFrame
+	key      []byte // buffer used to build label keys
+	text     []byte // text of the current element
+	keys     internedStringSet
+	prefixes internedStringSet
+}
+
+// NewXMLParser creates a log stage that parses an xml log line and adds the
+// text of the elements and their attributes as labels. Nested names are joined
+// with `_`, like nested keys with the json parser, so that
+// `<event level="info"><msg>hi</msg></event>` adds the labels
+// event_level="info" and event_msg="hi".
+func NewXMLParser() *XMLParser {
+	return &XMLParser{
+		reader:   bytes.NewReader(nil),
+		stack:    make([]xmlFrame, 0, 8),
+		key:      make([]byte, 0, 64),
+		keys:     internedStringSet{},
+		prefixes: internedStringSet{},
+	}
+}
+
+func (x *XMLParser) Process(_ int64, line []byte, lbs *LabelsBuilder) ([]byte, bool) {
+	parserHints := lbs.ParserLabelHints()
+	if parserHints.NoLabels() {
+		return line, true
+	}
+
+	if !isValidXMLStart(line) {
+		addErrLabel(errXML, errUnexpectedXMLElement, lbs)
+		return line, true
+	}
+
+	// reset the state.
+	x.reader.Reset(line)
+	x.stack = x.stack[:0]
+	x.key = x.key[:0]
+
+	if err := x.parse(xml.NewDecoder(x.reader), lbs); err != nil {
+		if errors.Is(err, errFoundAllLabels) {
+			// Short-circuited
+			return line, true
+		}
+
+		if errors.Is(err, errLabelDoesNotMatch) {
+			// one of the label matchers does not match. The whole line can be thrown away
+			return line, false
+		}
+
+		addErrLabel(errXML, err, lbs)
+	}
+	return line, true
+}
+
+// isValidXMLStart tells whether the line starts with an xml element, a
+// declaration or a comment.
+func isValidXMLStart(line []byte) bool {
+	line = bytes.TrimLeft(line, " \t\r\n")
+	return len(line) > 0 && line[0] == '<'
+}
+
+func (x *XMLParser) parse(dec *xml.Decoder, lbs *LabelsBuilder) error {
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(x.stack) > 0 {
+				x.stack[len(x.stack)-1].leaf = false
+			}
+			prefixLen := len(x.key)
+			x.appendKey(t.Name.Local)
+			if !x.shouldExtractPrefix(lbs) {
+				// none of the labels we need can be found below this element.
+				x.key = x.key[:prefixLen]
+				if err := dec.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			x.stack = append(x.stack, xmlFrame{prefixLen: prefixLen, leaf: true})
+			x.text = x.text[:0]
+
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+					continue
+				}
+				elemLen := len(x.key)
+				x.appendKey(attr.Name.Local)
+				err := x.set(lbs, []byte(attr.Value))
+				x.key = x.key[:elemLen]
+				if err != nil {
+					return err
+				}
+			}
+		case xml.CharData:
+			if len(x.stack) > 0 && x.stack[len(x.stack)-1].leaf {
+				x.text = append(x.text, t...)
+			}
+		case xml.EndElement:
+			if len(x.stack) == 0 {
+				continue
+			}
+			frame := x.stack[len(x.stack)-1]
+			x.stack = x.stack[:len(x.stack)-1]
+
+			var err error
+			if text := bytes.TrimSpace(x.text); frame.leaf && len(text) > 0 {
+				err = x.set(lbs, text)
+			}
+			x.key = x.key[:frame.prefixLen]
+			x.text = x.text[:0]
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// appendKey appends the sanitized name to the key buffer.
+func (x *XMLParser) appendKey(name string) {
+	if len(x.key) > 0 {
+		x.key = append(x.key, jsonSpacer)
+	}
+	x.key = appendSanitized(x.key, []byte(name))
+}
+
+// shouldExtractPrefix tells whether the labels below the current key should be
+// processed based on hints.
+func (x *XMLParser) shouldExtractPrefix(lbs *LabelsBuilder) bool {
+	_, ok := x.prefixes.Get(x.key, func() (string, bool) {
+		return "", lbs.ParserLabelHints().ShouldExtractPrefix(string(x.key))
+	})
+	return ok
+}
+
+func (x *XMLParser) set(lbs *LabelsBuilder, value []byte) error {
+	key, _ := x.keys.Get(x.key, func() (string, bool) {
+		return string(x.key), true
+	})
+	if len(key) == 0 {
+		return nil
+	}
+	name, ok := parsedLabelName(key, lbs)
+	if !ok {
+		return nil
+	}
+	lbs.Set(ParsedLabel, name, string(value))
+
+	parserHints := lbs.ParserLabelHints()
+	if !parserHints.ShouldContinueParsingLine(name, lbs) {
+		return errLabelDoesNotMatch
+	}
+	if parserHints.AllRequiredExtracted() {
+		return errFoundAllLabels
+	}
+	return nil
+}
+
+func (x *XMLParser) RequiredLabelNames() []string { return []string{} }
+
+// xmlPathStep is a step of an xml path expression.
+type xmlPathStep struct {
+	name  string // local name of the element or `*` for any element
+	index int    // 1-based position among the matching siblings, 0 for the first match
+}
+
+// xmlPath is a simplified XPath expression that selects an element or an
+// attribute starting at the root element, e.g. `event/user[2]/@id`.
+type xmlPath struct {
+	steps []xmlPathStep
+	attr  string
+}
+
+func parseXMLPath(expr string) (xmlPath, error) {
+	var path xmlPath
+	parts := strings.Split(strings.TrimPrefix(expr, "/"), "/")
+	for i, part := range parts {
+		if attr, ok := strings.CutPrefix(part, "@"); ok {
+			if i == 0 || i != len(parts)-1 {
+				return path, errors.New("an attribute must be the last step of the path")
+			}
+			if attr == "" {
+				return path, errors.New("empty attribute name")
+			}
+			path.attr = attr
+			continue
+		}
+
+		step := xmlPathStep{name: part}
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			if !strings.HasSuffix(part, "]") {
+				return path, fmt.Errorf("missing closing ']' in %q", part)
+			}
+			index, err := strconv.Atoi(part[open+1 : len(part)-1])
+			if err != nil || index < 1 {
+				return path, fmt.Errorf("invalid index in %q", part)
+			}
+			step.name, step.index = part[:open], index
+		}
+		if step.name == "" {
+			return path, errors.New("empty element name")
+		}
+		path.steps = append(path.steps, step)
+	}
+	return path, nil
+}
+
+// xmlExpressionFrame is an element of the XMLExpressionParser stack.
+type xmlExpressionFrame struct {
+	name     string
+	position int            // 1-based position among all siblings
+	named    int            // 1-based position among the siblings with the same name
+	children map[string]int // number of child elements by name
+	total    int            // number of child elements
+}
+
+// XMLExpressionParser is a log stage that extracts labels from an xml log
+// line using path expressions.
+type XMLExpressionParser struct {
+	ids   []string
+	paths []xmlPath
+
+	reader   *bytes.Reader
+	root     xmlExpressionFrame
+	stack    []xmlExpressionFrame
+	resolved []bool
+	capture  []bool
+	values   [][]byte
+}
+
+func NewXMLExpressionParser(expressions []LabelExtractionExpr) (*XMLExpressionParser, error) {
+	var ids []string
+	var paths []xmlPath
+	for _, exp := range expressions {
+		path, err := parseXMLPath(exp.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse expression [%s]: %w", exp.Expression, err)
+		}
+
+		if !model.LabelName(exp.Identifier).IsValid() {
+			return nil, fmt.Errorf("invalid extracted label name '%s'", exp.Identifier)
+		}
+
+		ids = append(ids, exp.Identifier)
+		paths = append(paths, path)
+	}
+
+	return &XMLExpressionParser{
+		ids:      ids,
+		paths:    paths,
+		reader:   bytes.NewReader(nil),
+		resolved: make([]bool, len(ids)),
+		capture:  make([]bool, len(ids)),
+		values:   make([][]byte, len(ids)),
+	}, nil
+}
+
+func (x *XMLExpressionParser) Process(_ int64, line []byte, lbs *LabelsBuilder) ([]byte, bool) {
+	if len(line) == 0 || lbs.ParserLabelHints().NoLabels() {
+		return line, true
+	}
+
+	if !isValidXMLStart(line) {
+		addErrLabel(errXML, errUnexpectedXMLElement, lbs)
+		return line, true
+	}
+
+	// reset the state.
+	x.reader.Reset(line)
+	x.root = xmlExpressionFrame{}
+	x.stack = x.stack[:0]
+	pending := 0
+	for i, id := range x.ids {
+		x.capture[i] = false
+		x.values[i] = x.values[i][:0]
+		// only look up the labels that are needed.
+		x.resolved[i] = !lbs.ParserLabelHints().ShouldExtract(id)
+		if !x.resolved[i] {
+			pending++
+		}
+	}
+
+	if err := x.parse(xml.NewDecoder(x.reader), lbs, pending); err != nil {
+		addErrLabel(errXML, err, lbs)
+	}
+
+	// Ensure there's a label for every value
+	for i, id := range x.ids {
+		if x.resolved[i] {
+			continue
+		}
+		if _, ok := lbs.Get(id); !ok {
+			lbs.Set(ParsedLabel, id, "")
+		}
+	}
+	return line, true
+}
+
+func (x *XMLExpressionParser) parse(dec *xml.Decoder, lbs *LabelsBuilder, pending int) error {
+	for pending > 0 {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			// the root frame counts the top level elements of fragments.
+			parent := &x.root
+			if len(x.stack) > 0 {
+				parent = &x.stack[len(x.stack)-1]
+			}
+			if parent.children == nil {
+				parent.children = make(map[string]int)
+			}
+			parent.total++
+			parent.children[t.Name.Local]++
+			x.stack = append(x.stack, xmlExpressionFrame{
+				name:     t.Name.Local,
+				position: parent.total,
+				named:    parent.children[t.Name.Local],
+			})
+
+			for i, path := range x.paths {
+				if x.resolved[i] || len(path.steps) != len(x.stack) || !x.matches(path) {
+					continue
+				}
+				if path.attr == "" {
+					x.capture[i] = true
+					continue
+				}
+				for _, attr := range t.Attr {
+					if attr.Name.Local == path.attr {
+						x.set(lbs, i, []byte(attr.Value))
+						pending--
+						break
+					}
+				}
+			}
+		case xml.CharData:
+			for i := range x.capture {
+				if x.capture[i] {
+					x.values[i] = append(x.values[i], t...)
+				}
+			}
+		case xml.EndElement:
+			if len(x.stack) == 0 {
+				continue
+			}
+			for i, path := range x.paths {
+				if x.capture[i] && len(path.steps) == len(x.stack) {
+					x.capture[i] = false
+					x.set(lbs, i, bytes.TrimSpace(x.values[i]))
+					pending--
+				}
+			}
+			x.stack = x.stack[:len(x.stack)-1]
+		}
+	}
+	return nil
+}
+
+// matches tells whether the path selects the current element.
+func (x *XMLExpressionParser) matches(path xmlPath) bool {
+	for i, step := range path.steps {
+		frame := x.stack[i]
+		if step.name == "*" {
+			if step.index != 0 && step.index != frame.position {
+				return false
+			}
+			continue
+		}
+		if step.name != frame.name || (step.index != 0 && step.index != frame.named) {
+			return false
+		}
+	}
+	return true
+}
+
+func (x *XMLExpressionParser) set(lbs *LabelsBuilder, i int, value []byte) {
+	x.resolved[i] = true
+	key := x.ids[i]
+	if lbs.BaseHas(key) {
+		key = key + duplicateSuffix
+	}
+	lbs.Set(ParsedLabel, key, string(value))
+}
+
+func (x *XMLExpressionParser) RequiredLabelNames() []string { return []string{} }
@@ -0,0 +1,247 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_xmlParser_Parse(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  []byte
+		lbs   labels.Labels
+		want  labels.Labels
+		hints ParserHint
+	}{
+		{
+			"multi depth",
+			[]byte(`<event level="info"><app>foo</app><pod uuid="foo"><deployment><ref>foobar</ref></deployment></pod></event>`),
+			labels.EmptyLabels(),
+			labels.FromStrings("event_level", "info",
+				"event_app", "foo",
+				"event_pod_uuid", "foo",
+				"event_pod_deployment_ref", "foobar",
+			),
+			NoParserHints(),
+		},
+		{
+			"fragment",
+			[]byte(`<level>info</level> <msg>hello &amp; bye</msg><data><![CDATA[<raw>]]></data>`),
+			labels.EmptyLabels(),
+			labels.FromStrings("level", "info",
+				"msg", "hello & bye",
+				"data", "<raw>",
+			),
+			NoParserHints(),
+		},
+		{
+			"declaration, namespaces and mixed content",
+			[]byte(`<?xml version="1.0"?><ns:event xmlns:ns="urn:x" ns:id="1">text<msg> hello </msg></ns:event>`),
+			labels.EmptyLabels(),
+			labels.FromStrings("event_id", "1",
+				"event_msg", "hello",
+			),
+			NoParserHints(),
+		},
+		{
+			"duplicate",
+			[]byte(`<app>bar</app>`),
+			labels.FromStrings("app", "foo"),
+			labels.FromStrings("app", "foo",
+				"app_extracted", "bar",
+			),
+			NoParserHints(),
+		},
+		{
+			"sanitized names",
+			[]byte(`<log-entry><remote.addr>1.2.3.4</remote.addr></log-entry>`),
+			labels.EmptyLabels(),
+			labels.FromStrings("log_entry_remote_addr", "1.2.3.4"),
+			NoParserHints(),
+		},
+		{
+			"not xml",
+			[]byte(`level=info`),
+			labels.FromStrings("foo", "bar"),
+			labels.FromStrings("foo", "bar",
+				"__error__", "XMLParserErr",
+				"__error_details__", "expecting xml element, but it is not",
+			),
+			NoParserHints(),
+		},
+		{
+			"invalid xml",
+			[]byte(`<event><msg>hello</event>`),
+			labels.FromStrings("foo", "bar"),
+			labels.FromStrings("foo", "bar",
+				"__error__", "XMLParserErr",
+				"__error_details__", "XML syntax error on line 1: element <msg> closed by </event>",
+			),
+			NoParserHints(),
+		},
+		{
+			"hints",
+			[]byte(`<event level="info"><app>foo</app><pod uuid="foo"><deployment><ref>foobar</ref></deployment></pod></event>`),
+			labels.EmptyLabels(),
+			labels.FromStrings("event_pod_deployment_ref", "foobar"),
+			NewParserHint([]string{"event_pod_deployment_ref"}, []string{"event_pod_deployment_ref"}, false, false, "", nil),
+		},
+	}
+	for _, tt := range tests {
+		j := NewXMLParser()
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBaseLabelsBuilderWithGrouping(nil, tt.hints, false, false).ForLabels(tt.lbs, labels.StableHash(tt.lbs))
+			b.Reset()
+			_, _ = j.Process(0, tt.line, b)
+			require.Equal(t, tt.want, b.LabelsResult().Labels())
+		})
+	}
+}
+
+func TestXMLExpressionParser(t *testing.T) {
+	testLine := []byte(`<event level="info"><app>foo</app><pod uuid="foo"><container>a</container><container name="b">b</container></pod><msg>hello <b>world</b></msg></event>`)
+
+	tests := []struct {
+		name        string
+		line        []byte
+		expressions []LabelExtractionExpr
+		lbs         labels.Labels
+		want        labels.Labels
+	}{
+		{
+			"single element",
+			testLine,
+			[]LabelExtractionExpr{
+				NewLabelExtractionExpr("app", "event/app"),
+			},
+			labels.EmptyLabels(),
+			labels.FromStrings("app", "foo"),
+		},
+		{
+			"attributes",
+			testLine,
+			[]LabelExtractionExpr{
+				NewLabelExtractionExpr("level", "/event/@level"),
+				NewLabelExtractionExpr("uuid", "event/pod/@uuid"),
+			},
+			labels.EmptyLabels(),
+			labels.FromStrings("level", "info",
+				"uuid", "foo",
+			),
+		},
+		{
+			"first match",
+			testLine,
+			[]LabelExtractionExpr{
+				NewLabelExtractionExpr("container", "event/pod/container"),
+			},
+			labels.EmptyLabels(),
+			labels.FromStrings("container", "a"),
+		},
+		{
+			"index",
+			testLine,
+			[]LabelExtractionExpr{
+				NewLabelExtractionExpr("container", "event/pod/container[2]"),
+				NewLabelExtractionExpr("name", "event/pod/container[2]/@name"),
+				NewLabelExtractionExpr("msg", "event/*[3]"),
+			},
+			labels.EmptyLabels(),
+			labels.FromStrings("container", "b",
+				"name", "b",
+				"msg", "hello world",
+			),
+		},
+		{
+			"missing",
+			testLine,
+			[]LabelExtractionExpr{
+				NewLabelExtractionExpr("app", "event/app/@missing"),
+				NewLabelExtractionExpr("missing", "event/missing"),
+			},
+			labels.EmptyLabels(),
+			labels.FromStrings("app", "",
+				"missing", "",
+			),
+		},
+		{
+			"duplicate",
+			testLine,
+			[]LabelExtractionExpr{
+				NewLabelExtractionExpr("app", "event/app"),
+			},
+			labels.FromStrings("app", "bar"),
+			labels.FromStrings("app", "bar",
+				"app_extracted", "foo",
+			),
+		},
+		{
+			"not xml",
+			[]byte(`{"app":"foo"}`),
+			[]LabelExtractionExpr{
+				NewLabelExtractionExpr("app", "app"),
+			},
+			labels.EmptyLabels(),
+			labels.FromStrings("__error__", "XMLParserErr",
+				"__error_details__", "expecting xml element, but it is not",
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := NewXMLExpressionParser(tt.expressions)
+			require.NoError(t, err, "cannot create XML expression parser")
+			b := NewBaseLabelsBuilderWithGrouping(nil, NoParserHints(), false, false).ForLabels(tt.lbs, labels.StableHash(tt.lbs))
+			b.Reset()
+			_, _ = x.Process(0, tt.line, b)
+			require.Equal(t, tt.want, b.LabelsResult().Labels())
+		})
+	}
+}
+
+func TestXMLExpressionParserFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression LabelExtractionExpr
+		error      string
+	}{
+		{
+			"empty path",
+			NewLabelExtractionExpr("app", ``),
+			"empty element name",
+		},
+		{
+			"attribute only",
+			NewLabelExtractionExpr("app", `@id`),
+			"an attribute must be the last step of the path",
+		},
+		{
+			"attribute not last",
+			NewLabelExtractionExpr("app", `event/@id/app`),
+			"an attribute must be the last step of the path",
+		},
+		{
+			"invalid index",
+			NewLabelExtractionExpr("app", `event/app[0]`),
+			`invalid index in "app[0]"`,
+		},
+		{
+			"missing closing square bracket",
+			NewLabelExtractionExpr("app", `event/app[1`),
+			`missing closing ']' in "app[1"`,
+		},
+		{
+			"invalid label name",
+			NewLabelExtractionExpr("", `event/app`),
+			"invalid extracted label name ''",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewXMLExpressionParser([]LabelExtractionExpr{tt.expression})
+			require.ErrorContains(t, err, tt.error)
+		})
+	}
+}
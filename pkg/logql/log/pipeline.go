@@ -1,12 +1,16 @@
 package log
 
 import (
+	"bytes"
 	"context"
+	"strings"
 
 	"github.com/prometheus/prometheus/model/labels"
 
 	"sync"
 	"unsafe"
+
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
 )
 
 // NoopStage is a stage that doesn't process a log line.
@@ -242,13 +246,15 @@ func (p *streamPipeline) ProcessString(ts int64, line string, structuredMetadata
 func (p *streamPipeline) BaseLabels() LabelsResult { return p.builder.currentResult }
 
 // PipelineFilter contains a set of matchers and a pipeline that, when matched,
-// causes an entry from a log stream to be skipped. Matching entries must also
-// fall between 'start' and 'end', inclusive
+// causes an entry from a log stream to be skipped, or to be rewritten with the
+// output of the pipeline if Rewrite is set. Matching entries must also fall
+// between 'start' and 'end', inclusive
 type PipelineFilter struct {
 	Start    int64
 	End      int64
 	Matchers []*labels.Matcher
 	Pipeline Pipeline
+	Rewrite  bool
 }
 
 // NewFilteringPipeline creates a pipeline where entries from the underlying
@@ -274,6 +280,7 @@ func (p *filteringPipeline) ForStream(labels labels.Labels) StreamPipeline {
 			streamFilters = append(streamFilters, streamFilter{
 				start:    f.Start,
 				end:      f.End,
+				rewrite:  f.Rewrite,
 				pipeline: f.Pipeline.ForStream(labels),
 			})
 		}
@@ -301,9 +308,65 @@ func allMatch(matchers []*labels.Matcher, labels labels.Labels) bool {
 type streamFilter struct {
 	start    int64
 	end      int64
+	rewrite  bool
 	pipeline StreamPipeline
 }
 
+// apply tells whether the entry is filtered out, and otherwise returns the entry
+// rewritten by the filter if it rewrites the entries it matches.
+func (f streamFilter) apply(ts int64, line []byte, structuredMetadata labels.Labels) ([]byte, labels.Labels, bool) {
+	if ts < f.start || ts > f.end {
+		return line, structuredMetadata, false
+	}
+
+	newLine, lbs, matches := f.pipeline.Process(ts, line, structuredMetadata)
+	if !matches {
+		return line, structuredMetadata, false
+	}
+	if !f.rewrite {
+		return nil, labels.EmptyLabels(), true
+	}
+	if lbs.Parsed().Has(logqlmodel.ErrorLabel) {
+		// the entries the pipeline fails for are not rewritten
+		return line, structuredMetadata, false
+	}
+
+	return bytes.Clone(newLine), KeptStructuredMetadata(structuredMetadata, lbs), false
+}
+
+func (f streamFilter) applyString(ts int64, line string, structuredMetadata labels.Labels) (string, labels.Labels, bool) {
+	if ts < f.start || ts > f.end {
+		return line, structuredMetadata, false
+	}
+
+	newLine, lbs, matches := f.pipeline.ProcessString(ts, line, structuredMetadata)
+	if !matches {
+		return line, structuredMetadata, false
+	}
+	if !f.rewrite {
+		return "", labels.EmptyLabels(), true
+	}
+	if lbs.Parsed().Has(logqlmodel.ErrorLabel) {
+		return line, structuredMetadata, false
+	}
+
+	return strings.Clone(newLine), KeptStructuredMetadata(structuredMetadata, lbs), false
+}
+
+// KeptStructuredMetadata returns the structured metadata of an entry which is kept in the result of
+// a pipeline, i.e. which was not dropped. The structured metadata named like a stream label is kept
+// under its own name, although the pipeline refers to it with the _extracted suffix.
+func KeptStructuredMetadata(structuredMetadata labels.Labels, result LabelsResult) labels.Labels {
+	kept := result.StructuredMetadata()
+	b := labels.NewScratchBuilder(structuredMetadata.Len())
+	structuredMetadata.Range(func(l labels.Label) {
+		if kept.Get(l.Name) == l.Value || kept.Get(l.Name+duplicateSuffix) == l.Value {
+			b.Add(l.Name, l.Value)
+		}
+	})
+	return b.Labels()
+}
+
 type filteringStreamPipeline struct {
 	filters  []streamFilter
 	pipeline StreamPipeline
@@ -318,13 +381,10 @@ func (sp *filteringStreamPipeline) BaseLabels() LabelsResult {
 }
 
 func (sp *filteringStreamPipeline) Process(ts int64, line []byte, structuredMetadata labels.Labels) ([]byte, LabelsResult, bool) {
+	var filtered bool
 	for _, filter := range sp.filters {
-		if ts < filter.start || ts > filter.end {
-			continue
-		}
-
-		_, _, matches := filter.pipeline.Process(ts, line, structuredMetadata)
-		if matches { // When the filter matches, don't run the next step
+		line, structuredMetadata, filtered = filter.apply(ts, line, structuredMetadata)
+		if filtered { // When the filter matches, don't run the next step
 			return nil, nil, false
 		}
 	}
@@ -333,13 +393,10 @@ func (sp *filteringStreamPipeline) Process(ts int64, line []byte, structuredMeta
 }
 
 func (sp *filteringStreamPipeline) ProcessString(ts int64, line string, structuredMetadata labels.Labels) (string, LabelsResult, bool) {
+	var filtered bool
 	for _, filter := range sp.filters {
-		if ts < filter.start || ts > filter.end {
-			continue
-		}
-
-		_, _, matches := filter.pipeline.ProcessString(ts, line, structuredMetadata)
-		if matches { // When the filter matches, don't run the next step
+		line, structuredMetadata, filtered = filter.applyString(ts, line, structuredMetadata)
+		if filtered { // When the filter matches, don't run the next step
 			return "", nil, false
 		}
 	}
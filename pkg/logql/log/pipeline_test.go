@@ -230,6 +230,87 @@ func TestFilteringPipeline(t *testing.T) {
 	}
 }
 
+func TestFilteringPipelineRewrite(t *testing.T) {
+	lineFormat, err := NewFormatter("{{.app}}: {{.msg}}")
+	require.NoError(t, err)
+	p := NewFilteringPipeline([]PipelineFilter{
+		{
+			Start:    2,
+			End:      4,
+			Matchers: []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "app", "checkout")},
+			Pipeline: NewPipeline([]Stage{
+				NewJSONParser(false),
+				lineFormat,
+				NewDropLabels([]NamedLabelMatcher{{Name: "email"}, {Name: "app_extracted"}}),
+			}),
+			Rewrite: true,
+		},
+		newPipelineFilter(2, 4, labels.FromStrings("app", "checkout"), labels.EmptyLabels(), "checkout: delete"),
+	}, NewNoopPipeline())
+
+	tt := []struct {
+		name                       string
+		ts                         int64
+		line                       string
+		structuredMetadata         labels.Labels
+		ok                         bool
+		expectedLine               string
+		expectedStructuredMetadata labels.Labels
+	}{
+		{
+			name:                       "it rewrites the entry",
+			ts:                         3,
+			line:                       `{"msg":"login","user":"alice"}`,
+			structuredMetadata:         labels.FromStrings("app", "checkout", "email", "alice@example.com", "trace_id", "1"),
+			ok:                         true,
+			expectedLine:               "checkout: login",
+			expectedStructuredMetadata: labels.FromStrings("trace_id", "1"),
+		},
+		{
+			name:                       "it keeps the entry the pipeline fails for",
+			ts:                         3,
+			line:                       "not json",
+			structuredMetadata:         labels.FromStrings("email", "alice@example.com"),
+			ok:                         true,
+			expectedLine:               "not json",
+			expectedStructuredMetadata: labels.FromStrings("email", "alice@example.com"),
+		},
+		{
+			name:                       "it is after the timerange",
+			ts:                         5,
+			line:                       `{"msg":"login"}`,
+			structuredMetadata:         labels.FromStrings("email", "alice@example.com"),
+			ok:                         true,
+			expectedLine:               `{"msg":"login"}`,
+			expectedStructuredMetadata: labels.FromStrings("email", "alice@example.com"),
+		},
+		{
+			name: "the next filters apply to the rewritten entry",
+			ts:   3,
+			line: `{"msg":"delete"}`,
+			ok:   false,
+		},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			line, lbs, ok := p.ForStream(labels.FromStrings("app", "checkout")).Process(test.ts, []byte(test.line), test.structuredMetadata)
+			require.Equal(t, test.ok, ok)
+			if ok {
+				require.Equal(t, test.expectedLine, string(line))
+				require.Equal(t, test.expectedStructuredMetadata, lbs.StructuredMetadata())
+			}
+
+			lineString, lbs, ok := p.ForStream(labels.FromStrings("app", "checkout")).ProcessString(test.ts, test.line, test.structuredMetadata)
+			require.Equal(t, test.ok, ok)
+			if ok {
+				require.Equal(t, test.expectedLine, lineString)
+				require.Equal(t, test.expectedStructuredMetadata, lbs.StructuredMetadata())
+			}
+		})
+	}
+}
+
 //nolint:unparam
 func newPipelineFilter(start, end int64, lbls, structuredMetadata labels.Labels, filter string) PipelineFilter {
 	var stages []Stage
@@ -246,7 +327,7 @@ func newPipelineFilter(start, end int64, lbls, structuredMetadata labels.Labels,
 
 	stages = append(stages, mustFilter(NewFilter(filter, LineMatchEqual)).ToStage())
 
-	return PipelineFilter{start, end, matchers, NewPipeline(stages)}
+	return PipelineFilter{Start: start, End: end, Matchers: matchers, Pipeline: NewPipeline(stages)}
 }
 
 func newStubPipeline() *stubPipeline {
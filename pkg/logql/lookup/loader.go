@@ -0,0 +1,148 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/tenant"
+
+	lokilog "github.com/grafana/loki/v3/pkg/logql/log"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/storage/chunk/client"
+	"github.com/grafana/loki/v3/pkg/util/validation"
+)
+
+// DefaultRefreshPeriod is the default period after which cached tables are
+// reloaded.
+const DefaultRefreshPeriod = time.Minute
+
+var (
+	_ lokilog.LookupTables = &Loader{}
+
+	ErrNotConfigured = errors.New("lookup tables are not configured")
+	ErrUnknownTable  = errors.New("unknown lookup table")
+)
+
+type Limits interface {
+	LookupTables(userID string) map[string]validation.LookupTable
+}
+
+// Loader loads the lookup tables configured in the tenant limits from object
+// storage. Tables are cached and reloaded once they are older than the
+// refresh period. If a reload fails the previous version of the table is used.
+type Loader struct {
+	client        client.ObjectClient
+	limits        Limits
+	refreshPeriod time.Duration
+	logger        log.Logger
+	now           func() time.Time
+
+	mtx    sync.Mutex
+	tables map[tableKey]*cachedTable
+}
+
+type tableKey struct {
+	tenant, name string
+}
+
+type cachedTable struct {
+	// mtx serializes the loads of a table.
+	mtx sync.Mutex
+
+	cfg      validation.LookupTable
+	table    *lokilog.LookupTable
+	loadedAt time.Time
+}
+
+func NewLoader(client client.ObjectClient, limits Limits, refreshPeriod time.Duration, logger log.Logger) *Loader {
+	return &Loader{
+		client:        client,
+		limits:        limits,
+		refreshPeriod: refreshPeriod,
+		logger:        logger,
+		now:           time.Now,
+		tables:        map[tableKey]*cachedTable{},
+	}
+}
+
+// LookupTable implements log.LookupTables.
+func (l *Loader) LookupTable(ctx context.Context, tenant, name string) (*lokilog.LookupTable, error) {
+	cfg, ok := l.limits.LookupTables(tenant)[name]
+	if !ok {
+		return nil, ErrUnknownTable
+	}
+
+	key := tableKey{tenant: tenant, name: name}
+	l.mtx.Lock()
+	cached, ok := l.tables[key]
+	if !ok {
+		cached = &cachedTable{}
+		l.tables[key] = cached
+	}
+	l.mtx.Unlock()
+
+	cached.mtx.Lock()
+	defer cached.mtx.Unlock()
+
+	if cached.table != nil && cached.cfg == cfg && l.now().Sub(cached.loadedAt) < l.refreshPeriod {
+		return cached.table, nil
+	}
+
+	table, err := l.load(ctx, cfg)
+	if err != nil {
+		if cached.table == nil || cached.cfg != cfg {
+			return nil, err
+		}
+		level.Warn(l.logger).Log("msg", "failed to reload lookup table, using previous version", "tenant", tenant, "table", name, "path", cfg.Path, "err", err)
+		return cached.table, nil
+	}
+
+	cached.cfg = cfg
+	cached.table = table
+	cached.loadedAt = l.now()
+	return table, nil
+}
+
+func (l *Loader) load(ctx context.Context, cfg validation.LookupTable) (*lokilog.LookupTable, error) {
+	reader, _, err := l.client.GetObject(ctx, cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", cfg.Path, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", cfg.Path, err)
+	}
+
+	table, err := lokilog.ParseLookupTable(cfg.Format, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", cfg.Path, err)
+	}
+	return table, nil
+}
+
+// ResolveTables resolves the lookup tables used by the expression for the
+// tenant of the context. The tenant is only required when the expression has
+// lookup stages.
+func ResolveTables(ctx context.Context, expr syntax.Expr, tables lokilog.LookupTables) error {
+	var userID string
+	return syntax.ResolveLookupTables(expr, func(name string) (*lokilog.LookupTable, error) {
+		if tables == nil {
+			return nil, ErrNotConfigured
+		}
+		if userID == "" {
+			var err error
+			if userID, err = tenant.TenantID(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return tables.LookupTable(ctx, userID, name)
+	})
+}
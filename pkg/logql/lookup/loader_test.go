@@ -0,0 +1,102 @@
+package lookup
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/user"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/storage/chunk/client/testutils"
+	"github.com/grafana/loki/v3/pkg/util/validation"
+)
+
+type fakeLimits map[string]map[string]validation.LookupTable
+
+func (f fakeLimits) LookupTables(userID string) map[string]validation.LookupTable {
+	return f[userID]
+}
+
+func TestLoader(t *testing.T) {
+	ctx := context.Background()
+	objectClient := testutils.NewInMemoryObjectClient()
+	require.NoError(t, objectClient.PutObject(ctx, "customers.csv", strings.NewReader("id,tier\n42,gold\n")))
+	require.NoError(t, objectClient.PutObject(ctx, "customers.json", strings.NewReader(`{"42": {"tier": "silver"}}`)))
+
+	limits := fakeLimits{
+		"tenant": {
+			"customers": {Path: "customers.csv", Format: "csv"},
+			"missing":   {Path: "missing.csv", Format: "csv"},
+		},
+	}
+	now := time.Unix(0, 0)
+	loader := NewLoader(objectClient, limits, time.Minute, log.NewNopLogger())
+	loader.now = func() time.Time { return now }
+
+	assertTier := func(tenant, name, want string) {
+		t.Helper()
+		table, err := loader.LookupTable(ctx, tenant, name)
+		require.NoError(t, err)
+		row, ok := table.Lookup("42")
+		require.True(t, ok)
+		require.Equal(t, want, row.Get("tier"))
+	}
+
+	assertTier("tenant", "customers", "gold")
+
+	_, err := loader.LookupTable(ctx, "tenant", "missing")
+	require.ErrorContains(t, err, "failed to get missing.csv")
+
+	_, err = loader.LookupTable(ctx, "other", "customers")
+	require.ErrorIs(t, err, ErrUnknownTable)
+
+	// the table is cached until the refresh period elapses.
+	require.NoError(t, objectClient.PutObject(ctx, "customers.csv", strings.NewReader("id,tier\n42,platinum\n")))
+	assertTier("tenant", "customers", "gold")
+	now = now.Add(time.Minute)
+	assertTier("tenant", "customers", "platinum")
+
+	// the previous version is used when the reload fails.
+	require.NoError(t, objectClient.PutObject(ctx, "customers.csv", strings.NewReader("id\n42\n")))
+	now = now.Add(time.Minute)
+	assertTier("tenant", "customers", "platinum")
+
+	// configuration changes are applied immediately.
+	limits["tenant"]["customers"] = validation.LookupTable{Path: "customers.json", Format: "json"}
+	assertTier("tenant", "customers", "silver")
+}
+
+func TestResolveTables(t *testing.T) {
+	expr, err := syntax.ParseLogSelector(`{app="foo"} | logfmt | lookup customers on id | tier="gold"`, true)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, ResolveTables(context.Background(), expr, nil), ErrNotConfigured)
+
+	objectClient := testutils.NewInMemoryObjectClient()
+	require.NoError(t, objectClient.PutObject(context.Background(), "customers.csv", strings.NewReader("id,tier\n42,gold\n")))
+	loader := NewLoader(objectClient, fakeLimits{
+		"tenant": {"customers": {Path: "customers.csv", Format: "csv"}},
+	}, time.Minute, log.NewNopLogger())
+
+	require.ErrorContains(t, ResolveTables(context.Background(), expr, loader), "no org id")
+	require.NoError(t, ResolveTables(user.InjectOrgID(context.Background(), "tenant"), expr, loader))
+
+	p, err := expr.Pipeline()
+	require.NoError(t, err)
+	sp := p.ForStream(labels.FromStrings("app", "foo"))
+	_, lbs, ok := sp.Process(0, []byte("id=42"), labels.EmptyLabels())
+	require.True(t, ok)
+	require.Equal(t, labels.FromStrings("app", "foo", "id", "42", "tier", "gold"), lbs.Labels())
+	_, _, ok = sp.Process(0, []byte("id=43"), labels.EmptyLabels())
+	require.False(t, ok)
+
+	// expressions without lookup stages don't require a tenant.
+	expr, err = syntax.ParseLogSelector(`{app="foo"} | logfmt`, true)
+	require.NoError(t, err)
+	require.NoError(t, ResolveTables(context.Background(), expr, nil))
+}
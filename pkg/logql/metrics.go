@@ -35,6 +35,7 @@ const (
 	QueryTypeStats   = "stats"
 	QueryTypeShards  = "shards"
 	QueryTypeVolume  = "volume"
+	QueryTypeJoin    = "join"
 
 	latencyTypeSlow = "slow"
 	latencyTypeFast = "fast"
@@ -558,6 +559,8 @@ func QueryType(expr syntax.Expr) (string, error) {
 			return QueryTypeFilter, nil
 		}
 		return QueryTypeLimited, nil
+	case *syntax.JoinExpr:
+		return QueryTypeJoin, nil
 	default:
 		return "", nil
 	}
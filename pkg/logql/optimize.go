@@ -97,6 +97,14 @@ func removeLineformat(expr syntax.SampleExpr) {
 					found = true
 					break
 				}
+				if _, ok := pipelineExpr.MultiStages[j].(*syntax.XMLExpressionParserExpr); ok {
+					found = true
+					break
+				}
+				if _, ok := pipelineExpr.MultiStages[j].(*syntax.CSVParserExpr); ok {
+					found = true
+					break
+				}
 			}
 			if found {
 				// we cannot remove safely the linefmtExpr.
@@ -0,0 +1,244 @@
+package logql
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/grafana/dskit/tenant"
+	"github.com/prometheus/prometheus/model/labels"
+	promql_parser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/loki/v3/pkg/iter"
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
+	"github.com/grafana/loki/v3/pkg/pattern/drain"
+	"github.com/grafana/loki/v3/pkg/querier/plan"
+	"github.com/grafana/loki/v3/pkg/util/constants"
+	"github.com/grafana/loki/v3/pkg/util/validation"
+)
+
+// newPatternCountEvaluator evaluates pattern_count_over_time.
+// Unlike other range aggregations the samples cannot be extracted at the source:
+// the pattern of a line depends on every other line of the query, so the lines
+// are fetched and clustered with Drain in the querier before being counted.
+// The number of lines fetched is bounded by the tenant's max entries limit.
+func (ev *DefaultEvaluator) newPatternCountEvaluator(
+	ctx context.Context,
+	expr *syntax.RangeAggregationExpr,
+	q Params,
+) (StepEvaluator, error) {
+	selector, err := expr.Selector()
+	if err != nil {
+		return nil, err
+	}
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	maxEntriesCapture := func(id string) int { return ev.limits.MaxEntriesLimitPerQuery(ctx, id) }
+	maxEntries := validation.SmallestPositiveIntPerTenant(tenantIDs, maxEntriesCapture)
+	limit := uint32(math.MaxInt32)
+	if maxEntries > 0 {
+		// fetch one more line to detect that the limit is exceeded.
+		limit = uint32(maxEntries) + 1
+	}
+
+	it, err := ev.querier.SelectLogs(ctx, SelectLogParams{
+		QueryRequest: &logproto.QueryRequest{
+			// extend startTs backwards by step
+			Start: q.Start().Add(-expr.Left.Interval).Add(-expr.Left.Offset),
+			// add leap nanosecond to endTs to include lines exactly at endTs. range iterators work on start exclusive, end inclusive ranges
+			End: q.End().Add(-expr.Left.Offset).Add(time.Nanosecond),
+			// every line is needed to cluster and count patterns.
+			Limit:     limit,
+			Direction: logproto.FORWARD,
+			Selector:  selector.String(),
+			Shards:    q.Shards(),
+			Plan: &plan.QueryPlan{
+				AST: selector,
+			},
+			StoreChunks: q.GetStoreChunks(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	samples, err := newPatternSampleIterator(
+		it, expr.Grouping, maxEntries,
+		q.Start().Add(-expr.Left.Offset).UnixNano(),
+		patternBucketInterval(expr.Left.Interval, q.Step()).Nanoseconds(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newRangeAggEvaluator(iter.NewPeekingSampleIterator(samples), expr, q, expr.Left.Offset)
+}
+
+// HasPatternCount returns true if the expression contains a pattern_count_over_time
+// range aggregation. Patterns are detected from all the lines of the query, such
+// expressions can be neither split by time nor sharded.
+func HasPatternCount(expr syntax.Expr) bool {
+	found := false
+	expr.Walk(func(e syntax.Expr) bool {
+		if r, ok := e.(*syntax.RangeAggregationExpr); ok && r.Operation == syntax.OpRangeTypePatternCount {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// patternBucketInterval returns the width of the buckets used to count lines per pattern.
+// Every range window boundary falls on a bucket boundary, so counting buckets is exact.
+func patternBucketInterval(selRange, step time.Duration) time.Duration {
+	if step <= 0 {
+		return selRange
+	}
+	a, b := selRange, step
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+type patternGroup struct {
+	labels  labels.Labels
+	drain   *drain.Drain
+	buckets map[*drain.LogCluster]map[int64]float64
+}
+
+// newPatternSampleIterator consumes all entries of it, clusters them with Drain and returns
+// a sample iterator with the number of lines per pattern and bucket.
+// Streams are clustered separately per grouping label set and every series is labelled with its
+// pattern using the detected_pattern label.
+// A line at ts is counted in the bucket ending at the first boundary (base + k*interval) >= ts,
+// which matches the start exclusive, end inclusive range windows.
+// It returns an error if it has more than maxEntries entries.
+func newPatternSampleIterator(it iter.EntryIterator, grouping *syntax.Grouping, maxEntries int, base, interval int64) (iter.SampleIterator, error) {
+	defer it.Close()
+
+	cfg := drain.DefaultConfig()
+	groups := map[string]*patternGroup{}
+	streams := map[string]*patternGroup{}
+	for read := 0; it.Next(); read++ {
+		if maxEntries > 0 && read >= maxEntries {
+			return nil, logqlmodel.NewPatternLimitError(maxEntries)
+		}
+		lbs := it.Labels()
+		group, ok := streams[lbs]
+		if !ok {
+			metric, err := promql_parser.ParseMetric(lbs)
+			if err != nil {
+				return nil, err
+			}
+			metric = groupPatternLabels(metric, grouping)
+			key := metric.String()
+			if group, ok = groups[key]; !ok {
+				group = &patternGroup{
+					labels:  metric,
+					buckets: map[*drain.LogCluster]map[int64]float64{},
+				}
+				groups[key] = group
+			}
+			streams[lbs] = group
+		}
+
+		entry := it.At()
+		if group.drain == nil {
+			group.drain = drain.New("", cfg, nil, drain.DetectLogFormat(entry.Line), nil)
+		}
+		cluster := group.drain.Train(entry.Line, entry.Timestamp.UnixNano())
+		if cluster == nil {
+			continue
+		}
+		buckets, ok := group.buckets[cluster]
+		if !ok {
+			buckets = map[int64]float64{}
+			group.buckets[cluster] = buckets
+		}
+		buckets[patternBucket(entry.Timestamp.UnixNano(), base, interval)]++
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	series := map[string]*logproto.Series{}
+	for _, group := range groups {
+		if group.drain == nil {
+			continue
+		}
+		lb := labels.NewBuilder(group.labels)
+		// Evicted clusters are dropped, like in the pattern ingester.
+		for _, cluster := range group.drain.Clusters() {
+			buckets := group.buckets[cluster]
+			if len(buckets) == 0 {
+				continue
+			}
+			lb.Set(constants.DetectedPatternLabel, cluster.String())
+			lbs := lb.Labels().String()
+			s, ok := series[lbs]
+			if !ok {
+				s = &logproto.Series{Labels: lbs}
+				series[lbs] = s
+			}
+			for ts, v := range buckets {
+				s.Samples = append(s.Samples, logproto.Sample{Timestamp: ts, Value: v})
+			}
+		}
+	}
+
+	result := make([]logproto.Series, 0, len(series))
+	for _, s := range series {
+		s.Samples = mergePatternSamples(s.Samples)
+		result = append(result, *s)
+	}
+	return iter.NewMultiSeriesIterator(result), nil
+}
+
+// groupPatternLabels reduces the stream labels according to the grouping of the range aggregation.
+// Without grouping all labels are kept.
+func groupPatternLabels(metric labels.Labels, grouping *syntax.Grouping) labels.Labels {
+	if grouping == nil {
+		return metric
+	}
+	if grouping.Singleton() {
+		return labels.EmptyLabels()
+	}
+	lb := labels.NewBuilder(metric)
+	if grouping.Without {
+		lb.Del(grouping.Groups...)
+	} else {
+		lb.Keep(grouping.Groups...)
+	}
+	return lb.Labels()
+}
+
+func patternBucket(ts, base, interval int64) int64 {
+	d := ts - base
+	k := d / interval
+	if d%interval > 0 {
+		k++
+	}
+	return base + k*interval
+}
+
+// mergePatternSamples sorts samples by timestamp and sums the ones sharing a timestamp,
+// which happens when clusters of different streams end up with the same pattern.
+func mergePatternSamples(samples []logproto.Sample) []logproto.Sample {
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp < samples[j].Timestamp
+	})
+	merged := samples[:0]
+	for _, s := range samples {
+		if len(merged) > 0 && merged[len(merged)-1].Timestamp == s.Timestamp {
+			merged[len(merged)-1].Value += s.Value
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
@@ -0,0 +1,137 @@
+package logql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/user"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
+)
+
+func TestPatternCountOverTime(t *testing.T) {
+	start := time.Unix(0, 0)
+	entries := make([]logproto.Entry, 0, 20)
+	for i := 0; i < 10; i++ {
+		entries = append(entries,
+			logproto.Entry{Timestamp: start.Add(time.Duration(i) * time.Second), Line: fmt.Sprintf("user %d logged in from host-%d", i, i)},
+			logproto.Entry{Timestamp: start.Add(time.Duration(i)*time.Second + time.Millisecond), Line: fmt.Sprintf("request %d failed with status 500 after %dms", i, i*10)},
+		)
+	}
+	streams := []logproto.Stream{
+		{Labels: `{app="foo"}`, Entries: entries[:10]},
+		{Labels: `{app="bar"}`, Entries: entries[10:]},
+	}
+
+	for _, tc := range []struct {
+		query    string
+		expected map[string][]float64
+	}{
+		{
+			query: `pattern_count_over_time({app=~".+"}[5s]) by ()`,
+			expected: map[string][]float64{
+				`{detected_pattern="user <_> logged in from <_>"}`:                  {5, 4},
+				`{detected_pattern="request <_> failed with status 500 after <_>"}`: {5, 5},
+			},
+		},
+		{
+			query: `sum by (app) (pattern_count_over_time({app=~".+"} |= "status" [10s]))`,
+			expected: map[string][]float64{
+				`{app="foo"}`: {5, 5},
+				`{app="bar"}`: {5},
+			},
+		},
+		{
+			query: `pattern_count_over_time({app="foo"}[10s])`,
+			expected: map[string][]float64{
+				`{app="foo", detected_pattern="user <_> logged in from <_>"}`:                  {5, 4},
+				`{app="foo", detected_pattern="request <_> failed with status 500 after <_>"}`: {5, 5},
+			},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			eng := NewEngine(EngineOpts{}, NewMockQuerier(0, streams), NoLimits, log.NewNopLogger())
+			params, err := NewLiteralParams(tc.query, start.Add(5*time.Second), start.Add(10*time.Second), 5*time.Second, 0, logproto.FORWARD, 0, nil, nil)
+			require.NoError(t, err)
+
+			res, err := eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+			require.NoError(t, err)
+
+			matrix, ok := res.Data.(promql.Matrix)
+			require.True(t, ok)
+			actual := make(map[string][]float64, len(matrix))
+			for _, s := range matrix {
+				for _, p := range s.Floats {
+					actual[s.Metric.String()] = append(actual[s.Metric.String()], p.F)
+				}
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestPatternCountOverTime_Limit(t *testing.T) {
+	querier := NewMockQuerier(0, []logproto.Stream{
+		newStream(10, identity, `{app="foo"}`),
+		newStream(10, identity, `{app="bar"}`),
+	})
+	params, err := NewLiteralParams(`pattern_count_over_time({app=~".+"}[1m])`, time.Unix(60, 0), time.Unix(60, 0), 0, 0, logproto.FORWARD, 0, nil, nil)
+	require.NoError(t, err)
+
+	eng := NewEngine(EngineOpts{}, querier, &fakeLimits{maxSeries: 100, maxEntries: 15}, log.NewNopLogger())
+	_, err = eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+	require.True(t, errors.Is(err, logqlmodel.ErrLimit))
+	require.ErrorContains(t, err, "maximum number of entries (15) reached")
+
+	eng = NewEngine(EngineOpts{}, querier, &fakeLimits{maxSeries: 100, maxEntries: 20}, log.NewNopLogger())
+	_, err = eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+	require.NoError(t, err)
+}
+
+func TestHasPatternCount(t *testing.T) {
+	for _, tc := range []struct {
+		query    string
+		expected bool
+	}{
+		{`{app="foo"}`, false},
+		{`count_over_time({app="foo"}[1m])`, false},
+		{`pattern_count_over_time({app="foo"}[1m])`, true},
+		{`sum by (detected_pattern) (pattern_count_over_time({app="foo"}[1m]))`, true},
+		{`max_over_time(pattern_count_over_time({app="foo"}[1m])[1h:1m])`, true},
+		{`count_over_time({app="foo"}[1m]) / pattern_count_over_time({app="foo"}[1m]) by ()`, true},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			require.Equal(t, tc.expected, HasPatternCount(syntax.MustParseExpr(tc.query)))
+		})
+	}
+}
+
+func TestPatternBucketInterval(t *testing.T) {
+	require.Equal(t, 5*time.Minute, patternBucketInterval(5*time.Minute, 0))
+	require.Equal(t, time.Minute, patternBucketInterval(5*time.Minute, time.Minute))
+	require.Equal(t, 10*time.Second, patternBucketInterval(time.Minute, 50*time.Second))
+}
+
+func TestPatternBucket(t *testing.T) {
+	for _, tc := range []struct {
+		ts, expected int64
+	}{
+		{ts: 10, expected: 10},
+		{ts: 11, expected: 20},
+		{ts: 19, expected: 20},
+		{ts: 0, expected: 0},
+		{ts: -1, expected: 0},
+		{ts: -10, expected: -10},
+		{ts: -11, expected: -10},
+	} {
+		require.Equal(t, tc.expected, patternBucket(tc.ts, 0, 10), "ts: %d", tc.ts)
+	}
+}
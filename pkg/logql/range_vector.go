@@ -230,7 +230,7 @@ func aggregator(r *syntax.RangeAggregationExpr) (BatchRangeVectorAggregator, err
 		return countOverTime, nil
 	case syntax.OpRangeTypeBytesRate:
 		return rateLogBytes(r.Left.Interval), nil
-	case syntax.OpRangeTypeBytes, syntax.OpRangeTypeSum:
+	case syntax.OpRangeTypeBytes, syntax.OpRangeTypeSum, syntax.OpRangeTypePatternCount:
 		return sumOverTime, nil
 	case syntax.OpRangeTypeAvg:
 		return avgOverTime, nil
@@ -607,7 +607,7 @@ func streamingAggregator(r *syntax.RangeAggregationExpr) (RangeStreamingAgg, err
 		return &CountOverTime{}, nil
 	case syntax.OpRangeTypeBytesRate:
 		return &RateLogBytesOverTime{selRange: r.Left.Interval}, nil
-	case syntax.OpRangeTypeBytes, syntax.OpRangeTypeSum:
+	case syntax.OpRangeTypeBytes, syntax.OpRangeTypeSum, syntax.OpRangeTypePatternCount:
 		return &SumOverTime{}, nil
 	case syntax.OpRangeTypeAvg:
 		return &AvgOverTime{}, nil
@@ -22,6 +22,8 @@ var splittableVectorOp = map[string]struct{}{
 	syntax.OpTypeTopK:     {},
 	syntax.OpTypeSort:     {},
 	syntax.OpTypeSortDesc: {},
+
+	syntax.OpTypeCountValues: {},
 }
 
 var splittableRangeVectorOp = map[string]struct{}{
@@ -34,6 +36,15 @@ var splittableRangeVectorOp = map[string]struct{}{
 	syntax.OpRangeTypeMin:       {},
 }
 
+// splittableSubqueryOp maps the range aggregation operations of subqueries that
+// can be split by range to the vector aggregation that merges the splits.
+var splittableSubqueryOp = map[string]string{
+	syntax.OpRangeTypeCount: syntax.OpTypeSum,
+	syntax.OpRangeTypeSum:   syntax.OpTypeSum,
+	syntax.OpRangeTypeMax:   syntax.OpTypeMax,
+	syntax.OpRangeTypeMin:   syntax.OpTypeMin,
+}
+
 // RangeMapper is used to rewrite LogQL sample expressions into multiple
 // downstream sample expressions with a smaller time range that can be executed
 // using the downstream engine.
@@ -53,6 +64,9 @@ var splittableRangeVectorOp = map[string]struct{}{
 //     either with or without grouping.
 //  5. Left and right-hand side of binary operations are split individually
 //     using the same rules as above.
+//  6. Subqueries are split into multiple downstream subqueries with a smaller
+//     subquery range that are merged with a vector aggregation using "without".
+//     The inner query of a subquery is never split.
 type RangeMapper struct {
 	splitByInterval time.Duration
 	metrics         *MapperMetrics
@@ -140,6 +154,8 @@ func (m RangeMapper) Map(expr syntax.SampleExpr, vectorAggrPushdown *syntax.Vect
 		return m.mapVectorAggregationExpr(e, recorder)
 	case *syntax.RangeAggregationExpr:
 		return m.mapRangeAggregationExpr(e, vectorAggrPushdown, recorder), nil
+	case *syntax.SubqueryExpr:
+		return m.mapSubqueryExpr(e, recorder), nil
 	case *syntax.BinOpExpr:
 		lhsMapped, err := m.Map(e.SampleExpr, vectorAggrPushdown, recorder)
 		if err != nil {
@@ -175,6 +191,25 @@ func (m RangeMapper) Map(expr syntax.SampleExpr, vectorAggrPushdown *syntax.Vect
 		}
 		e.Left = lhsMapped
 		return e, nil
+	case *syntax.LabelJoinExpr:
+		lhsMapped, err := m.Map(e.Left, vectorAggrPushdown, recorder)
+		if err != nil {
+			return nil, err
+		}
+		e.Left = lhsMapped
+		return e, nil
+	case *syntax.FunctionExpr:
+		if e.Left == nil {
+			return e, nil
+		}
+		// functions are not linear, so outer vector aggregations must not be
+		// pushed down through them.
+		lhsMapped, err := m.Map(e.Left, nil, recorder)
+		if err != nil {
+			return nil, err
+		}
+		e.Left = lhsMapped
+		return e, nil
 	case *syntax.LiteralExpr:
 		return e, nil
 	case *syntax.VectorExpr:
@@ -200,6 +235,9 @@ func getRangeInterval(expr syntax.SampleExpr) time.Duration {
 		switch concrete := e.(type) {
 		case *syntax.RangeAggregationExpr:
 			rangeInterval = concrete.Left.Interval
+		case *syntax.SubqueryExpr:
+			rangeInterval = concrete.Range
+			return false
 		}
 		return true
 	})
@@ -207,7 +245,7 @@ func getRangeInterval(expr syntax.SampleExpr) time.Duration {
 }
 
 // hasLabelExtractionStage returns true if an expression contains a stage for label extraction,
-// such as `| json` or `| logfmt`, that would result in an exploding amount of series in downstream queries.
+// such as `| json`, `| logfmt` or `| xml`, that would result in an exploding amount of series in downstream queries.
 func hasLabelExtractionStage(expr syntax.SampleExpr) bool {
 	found := false
 	expr.Walk(func(e syntax.Expr) bool {
@@ -217,7 +255,7 @@ func hasLabelExtractionStage(expr syntax.SampleExpr) bool {
 		case *syntax.LineParserExpr:
 			// It will **not** return true for `regexp`, `unpack` and `pattern`, since these label extraction
 			// stages can control how many labels, and therefore the resulting amount of series, are extracted.
-			if concrete.Op == syntax.OpParserTypeJSON {
+			if concrete.Op == syntax.OpParserTypeJSON || concrete.Op == syntax.OpParserTypeXML {
 				found = true
 			}
 		}
@@ -226,6 +264,19 @@ func hasLabelExtractionStage(expr syntax.SampleExpr) bool {
 	return found
 }
 
+// hasDedupStage returns true if an expression contains a dedup stage. The
+// duplicates of a line can be in different split ranges.
+func hasDedupStage(expr syntax.Expr) bool {
+	found := false
+	expr.Walk(func(e syntax.Expr) bool {
+		if _, ok := e.(*syntax.DedupExpr); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
 // sumOverFullRange returns an expression that sums up individual downstream queries (with preserving labels)
 // and dividing it by the full range in seconds to calculate a rate value.
 // The operation defines the range aggregation operation of the downstream queries.
@@ -306,6 +357,12 @@ func appendDownstream(downstreams *ConcatSampleExpr, expr syntax.SampleExpr, int
 			if offset != 0 {
 				concrete.Left.Offset = offset
 			}
+		case *syntax.SubqueryExpr:
+			concrete.Range = interval
+			if offset != 0 {
+				concrete.Offset = offset
+			}
+			return false
 		}
 		return true
 	})
@@ -326,6 +383,9 @@ func getOffsets(expr syntax.SampleExpr) []time.Duration {
 		switch concrete := e.(type) {
 		case *syntax.RangeAggregationExpr:
 			offsets = append(offsets, concrete.Left.Offset)
+		case *syntax.SubqueryExpr:
+			offsets = append(offsets, concrete.Offset)
+			return false
 		}
 		return true
 	})
@@ -462,10 +522,10 @@ func (m RangeMapper) mapVectorAggregationExpr(expr *syntax.VectorAggregationExpr
 
 	// In order to minimize the amount of streams on the downstream query,
 	// we can push down the outer vector aggregation to the downstream query.
-	// This does not work for `count()` and `topk()`, though.
+	// This does not work for `count()`, `count_values()` and `topk()`, though.
 	// We also do not want to push down, if the inner expression is a binary operation.
 	var vectorAggrPushdown *syntax.VectorAggregationExpr
-	if _, ok := expr.Left.(*syntax.BinOpExpr); !ok && expr.Operation != syntax.OpTypeCount && expr.Operation != syntax.OpTypeTopK && expr.Operation != syntax.OpTypeSort && expr.Operation != syntax.OpTypeSortDesc {
+	if _, ok := expr.Left.(*syntax.BinOpExpr); !ok && expr.Operation != syntax.OpTypeCount && expr.Operation != syntax.OpTypeCountValues && expr.Operation != syntax.OpTypeTopK && expr.Operation != syntax.OpTypeSort && expr.Operation != syntax.OpTypeSortDesc {
 		vectorAggrPushdown = expr
 	}
 
@@ -480,6 +540,7 @@ func (m RangeMapper) mapVectorAggregationExpr(expr *syntax.VectorAggregationExpr
 		Grouping:  expr.Grouping,
 		Params:    expr.Params,
 		Operation: expr.Operation,
+		Label:     expr.Label,
 	}, nil
 }
 
@@ -555,12 +616,41 @@ func (m RangeMapper) mapRangeAggregationExpr(expr *syntax.RangeAggregationExpr,
 	}
 }
 
+// mapSubqueryExpr maps expr into a new SampleExpr with multiple downstream
+// subqueries split by the subquery range.
+// Since the evaluation timestamps of subqueries are aligned to their step, the
+// downstream subqueries produce exactly the samples of the original subquery.
+// Example:
+// max_over_time(rate({app="foo"}[1m])[2h:1m])
+// => max without () (max_over_time(rate({app="foo"}[1m])[1h:1m]) ++ max_over_time(rate({app="foo"}[1m])[1h:1m] offset 1h))
+func (m RangeMapper) mapSubqueryExpr(expr *syntax.SubqueryExpr, recorder *downstreamRecorder) syntax.SampleExpr {
+	// in case the range is smaller than the configured split interval,
+	// don't split it.
+	if expr.Range <= m.splitByInterval {
+		return expr
+	}
+	op, ok := splittableSubqueryOp[expr.Operation]
+	if !ok {
+		return expr
+	}
+	return &syntax.VectorAggregationExpr{
+		Left: m.mapConcatSampleExpr(expr, expr.Range, recorder),
+		Grouping: &syntax.Grouping{
+			Without: true,
+			Groups:  []string{},
+		},
+		Operation: op,
+	}
+}
+
 // isSplittableByRange returns whether it is possible to optimize the given
 // sample expression.
 // A vector aggregation is splittable, if the aggregation operation is
 // supported and the inner expression is also splittable.
 // A range aggregation is splittable, if the aggregation operation is
-// supported.
+// supported and its pipeline has no dedup stage.
+// A subquery is splittable, if its aggregation operation is supported and
+// its pipelines have no dedup stage and no pattern_count_over_time.
 // A binary expression is splittable, if both the left and the right-hand side
 // are splittable.
 func isSplittableByRange(expr syntax.SampleExpr) bool {
@@ -570,7 +660,7 @@ func isSplittableByRange(expr syntax.SampleExpr) bool {
 		return ok && isSplittableByRange(e.Left)
 	case *syntax.RangeAggregationExpr:
 		_, ok := splittableRangeVectorOp[e.Operation]
-		return ok
+		return ok && !hasDedupStage(e)
 	case *syntax.BinOpExpr:
 		_, literalLHS := e.SampleExpr.(*syntax.LiteralExpr)
 		_, literalRHS := e.RHS.(*syntax.LiteralExpr)
@@ -579,6 +669,13 @@ func isSplittableByRange(expr syntax.SampleExpr) bool {
 		return isSplittableByRange(e.SampleExpr) || literalLHS && isSplittableByRange(e.RHS) || literalRHS
 	case *syntax.LabelReplaceExpr:
 		return isSplittableByRange(e.Left)
+	case *syntax.LabelJoinExpr:
+		return isSplittableByRange(e.Left)
+	case *syntax.FunctionExpr:
+		return e.Left != nil && isSplittableByRange(e.Left)
+	case *syntax.SubqueryExpr:
+		_, ok := splittableSubqueryOp[e.Operation]
+		return ok && !hasDedupStage(e) && !HasPatternCount(e)
 	case *syntax.VectorExpr:
 		return false
 	default:
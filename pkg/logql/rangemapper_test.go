@@ -74,6 +74,23 @@ func Test_SplitRangeInterval(t *testing.T) {
 			) / 4)`,
 			2,
 		},
+		// Subqueries are split by their range, their inner query is not split
+		{
+			`max_over_time(count_over_time({app="foo"}[3s])[4s:1s])`,
+			`max without () (
+				downstream<max_over_time(count_over_time({app="foo"}[3s])[2s:1s] offset 2s), shard=<nil>>
+				++ downstream<max_over_time(count_over_time({app="foo"}[3s])[2s:1s]), shard=<nil>>
+			)`,
+			2,
+		},
+		{
+			`sum(count_over_time(rate({app="foo"}[1s])[3s:1s] offset 1s))`,
+			`sum(sum without () (
+				downstream<count_over_time(rate({app="foo"}[1s])[1s:1s] offset 3s), shard=<nil>>
+				++ downstream<count_over_time(rate({app="foo"}[1s])[2s:1s] offset 1s), shard=<nil>>
+			))`,
+			2,
+		},
 	} {
 		t.Run(tc.expr, func(t *testing.T) {
 			t.Parallel()
@@ -195,6 +212,15 @@ func Test_SplitRangeVectorMapping(t *testing.T) {
 			)`,
 			3,
 		},
+		{
+			`count_over_time({app="foo"}[3m] @ 1609746000)`,
+			`sum without () (
+				downstream<count_over_time({app="foo"}[1m] @ 1609746000 offset 2m0s), shard=<nil>>
+				++ downstream<count_over_time({app="foo"}[1m] @ 1609746000 offset 1m0s), shard=<nil>>
+				++ downstream<count_over_time({app="foo"}[1m] @ 1609746000), shard=<nil>>
+			)`,
+			3,
+		},
 		{
 			`sum_over_time({app="foo"} | unwrap bar [3m])`,
 			`sum without () (
@@ -1809,6 +1835,38 @@ func Test_SplitRangeVectorMapping(t *testing.T) {
 			)`,
 			3,
 		},
+
+		// label_join
+		{
+			`label_join(sum by (baz) (count_over_time({app="foo"}[3m])), "x", "-", "baz", "baz")`,
+			`label_join(
+				sum by (baz) (
+					sum without () (
+						downstream<sum by (baz) (count_over_time({app="foo"} [1m] offset 2m0s)), shard=<nil>>
+						++ downstream<sum by (baz) (count_over_time({app="foo"} [1m] offset 1m0s)), shard=<nil>>
+						++ downstream<sum by (baz) (count_over_time({app="foo"} [1m])), shard=<nil>>
+					)
+				),
+				"x", "-", "baz", "baz"
+			)`,
+			3,
+		},
+
+		// functions
+		{
+			`sum(round(count_over_time({app="foo"}[3m]), 10))`,
+			`sum(
+				round(
+					sum without () (
+						downstream<count_over_time({app="foo"} [1m] offset 2m0s), shard=<nil>>
+						++ downstream<count_over_time({app="foo"} [1m] offset 1m0s), shard=<nil>>
+						++ downstream<count_over_time({app="foo"} [1m]), shard=<nil>>
+					),
+					10
+				)
+			)`,
+			3,
+		},
 	} {
 		t.Run(tc.expr, func(t *testing.T) {
 			t.Parallel()
@@ -1858,6 +1916,10 @@ func Test_SplitRangeVectorMapping_Noop(t *testing.T) {
 			`count_over_time({app="foo"} | json [3m])`,
 			`count_over_time({app="foo"} | json [3m])`,
 		},
+		{
+			`count_over_time({app="foo"} | xml [3m])`,
+			`count_over_time({app="foo"} | xml [3m])`,
+		},
 		{
 			`sum_over_time({app="foo"} | logfmt | unwrap bar [3m])`,
 			`sum_over_time({app="foo"} | logfmt | unwrap bar [3m])`,
@@ -1999,6 +2061,34 @@ func Test_SplitRangeVectorMapping_Noop(t *testing.T) {
 			`vector(0)`,
 			`vector(0.000000)`,
 		},
+		// should be noop if the subquery aggregation is not splittable
+		// or the subquery range is lower or equal to the split interval
+		{
+			`avg_over_time(rate({app="foo"}[5m])[1h:1m])`,
+			`avg_over_time(rate({app="foo"}[5m])[1h:1m])`,
+		},
+		{
+			`max_over_time(rate({app="foo"}[5m])[1m:10s])`,
+			`max_over_time(rate({app="foo"}[5m])[1m:10s])`,
+		},
+		// should be noop if the pipeline has a dedup stage
+		{
+			`sum by (app) (count_over_time({app="foo"} | dedup within 1m [5m]))`,
+			`sum by (app) (count_over_time({app="foo"} | dedup within 1m [5m]))`,
+		},
+		{
+			`max_over_time(sum(count_over_time({app="foo"} | dedup [5m]))[1h:1m])`,
+			`max_over_time(sum(count_over_time({app="foo"} | dedup [5m]))[1h:1m])`,
+		},
+		// should be noop if the query detects patterns
+		{
+			`sum by (detected_pattern) (pattern_count_over_time({app="foo"}[5m]))`,
+			`sum by (detected_pattern) (pattern_count_over_time({app="foo"}[5m]))`,
+		},
+		{
+			`max_over_time(sum by (detected_pattern) (pattern_count_over_time({app="foo"}[5m]))[1h:1m])`,
+			`max_over_time(sum by (detected_pattern) (pattern_count_over_time({app="foo"}[5m]))[1h:1m])`,
+		},
 	} {
 		t.Run(tc.expr, func(t *testing.T) {
 			t.Parallel()
@@ -99,8 +99,14 @@ func (m ShardMapper) Map(expr syntax.Expr, r *downstreamRecorder, topLevel bool)
 		return m.mapVectorAggregationExpr(e, r, topLevel)
 	case *syntax.LabelReplaceExpr:
 		return m.mapLabelReplaceExpr(e, r, topLevel)
+	case *syntax.LabelJoinExpr:
+		return m.mapLabelJoinExpr(e, r, topLevel)
+	case *syntax.FunctionExpr:
+		return m.mapFunctionExpr(e, r, topLevel)
 	case *syntax.RangeAggregationExpr:
 		return m.mapRangeAggregationExpr(e, r, topLevel)
+	case *syntax.SubqueryExpr:
+		return m.mapSubqueryExpr(e, r, topLevel)
 	case *syntax.BinOpExpr:
 		return m.mapBinOpExpr(e, r, topLevel)
 	default:
@@ -166,7 +172,7 @@ func (m ShardMapper) mapLogSelectorExpr(expr syntax.LogSelectorExpr, r *downstre
 	if err != nil {
 		return nil, 0, err
 	}
-	if len(shards) == 0 {
+	if len(shards) == 0 || !expr.Shardable(true) {
 		return &ConcatLogSelectorExpr{
 			DownstreamLogSelectorExpr: DownstreamLogSelectorExpr{
 				shard:           nil,
@@ -291,6 +297,22 @@ func (m ShardMapper) mapVectorAggregationExpr(expr *syntax.VectorAggregationExpr
 				Grouping:  expr.Grouping,
 				Operation: syntax.OpTypeSum,
 			}, bytesPerShard, nil
+		case syntax.OpTypeCountValues:
+			if syntax.ReducesLabels(expr.Left) {
+				// skip sharding optimizations at this level, see count.
+				break
+			}
+
+			// count_values("l", x) -> sum by (l) (count_values("l", x, shard=1) ++ count_values("l", x, shard=2)...)
+			sharded, bytesPerShard, err := m.mapSampleExpr(expr, r)
+			if err != nil {
+				return nil, 0, err
+			}
+			return &syntax.VectorAggregationExpr{
+				Left:      sharded,
+				Grouping:  countValuesGrouping(expr),
+				Operation: syntax.OpTypeSum,
+			}, bytesPerShard, nil
 		case syntax.OpTypeApproxTopK:
 			if !m.approxTopkSupport {
 				return nil, 0, fmt.Errorf("approx_topk is not enabled. See -limits.shard_aggregations")
@@ -339,9 +361,25 @@ func (m ShardMapper) mapVectorAggregationExpr(expr *syntax.VectorAggregationExpr
 		Grouping:  expr.Grouping,
 		Params:    expr.Params,
 		Operation: expr.Operation,
+		Label:     expr.Label,
 	}, bytesPerShard, nil
 }
 
+// countValuesGrouping returns the grouping of the samples returned by a
+// count_values aggregation, which always includes its value label.
+func countValuesGrouping(expr *syntax.VectorAggregationExpr) *syntax.Grouping {
+	grouping := &syntax.Grouping{Without: expr.Grouping.Without}
+	for _, g := range expr.Grouping.Groups {
+		if g != expr.Label {
+			grouping.Groups = append(grouping.Groups, g)
+		}
+	}
+	if !grouping.Without {
+		grouping.Groups = append(grouping.Groups, expr.Label)
+	}
+	return grouping
+}
+
 func (m ShardMapper) mapApproxTopk(expr *syntax.VectorAggregationExpr, forceNoShard bool) (*syntax.VectorAggregationExpr, uint64, error) {
 	// TODO(owen-d): integrate bounded sharding with approx_topk
 	// I'm not doing this now because it uses a separate code path and may not handle
@@ -415,6 +453,47 @@ func (m ShardMapper) mapLabelReplaceExpr(expr *syntax.LabelReplaceExpr, r *downs
 	return &cpy, bytesPerShard, nil
 }
 
+func (m ShardMapper) mapLabelJoinExpr(expr *syntax.LabelJoinExpr, r *downstreamRecorder, topLevel bool) (syntax.SampleExpr, uint64, error) {
+	subMapped, bytesPerShard, err := m.Map(expr.Left, r, topLevel)
+	if err != nil {
+		return nil, 0, err
+	}
+	cpy := *expr
+	cpy.Left = subMapped.(syntax.SampleExpr)
+	return &cpy, bytesPerShard, nil
+}
+
+// mapFunctionExpr shards the argument of a function. The function itself is
+// evaluated on the merged result of the shards.
+func (m ShardMapper) mapFunctionExpr(expr *syntax.FunctionExpr, r *downstreamRecorder, topLevel bool) (syntax.SampleExpr, uint64, error) {
+	if expr.Left == nil {
+		return expr, 0, nil
+	}
+	subMapped, bytesPerShard, err := m.Map(expr.Left, r, topLevel)
+	if err != nil {
+		return nil, 0, err
+	}
+	cpy := *expr
+	cpy.Left = subMapped.(syntax.SampleExpr)
+	return &cpy, bytesPerShard, nil
+}
+
+// mapSubqueryExpr shards the inner query of a subquery. The aggregation over
+// time of the subquery itself is evaluated on the merged result of the shards.
+// If the inner query cannot be sharded, the subquery is left as is.
+func (m ShardMapper) mapSubqueryExpr(expr *syntax.SubqueryExpr, r *downstreamRecorder, topLevel bool) (syntax.SampleExpr, uint64, error) {
+	subMapped, bytesPerShard, err := m.Map(expr.Left, r, topLevel)
+	if err != nil {
+		return nil, 0, err
+	}
+	if isNoOp(expr.Left, subMapped) {
+		return noOp[syntax.SampleExpr](expr, m.shards.Resolver())
+	}
+	cpy := *expr
+	cpy.Left = subMapped.(syntax.SampleExpr)
+	return &cpy, bytesPerShard, nil
+}
+
 // These functions require a different merge strategy than the default
 // concatenation.
 // This is because the same label sets may exist on multiple shards when label-reducing parsing is applied or when
@@ -463,6 +463,11 @@ func TestMappingStrings(t *testing.T) {
 			in:  `sum(count_over_time({a=~".+"}[1s]) * ignoring () count_over_time({a=~".+"}[1s]))`,
 			out: `sum(downstream<sum((count_over_time({a=~".+"}[1s])*count_over_time({a=~".+"}[1s]))),shard=0_of_2>++downstream<sum((count_over_time({a=~".+"}[1s])*count_over_time({a=~".+"}[1s]))),shard=1_of_2>)`,
 		},
+		{
+			// patterns are clustered per query, so they can't be merged across shards
+			in:  `sum by (detected_pattern) (pattern_count_over_time({job="bar"}[1m]))`,
+			out: `sumby(detected_pattern)(pattern_count_over_time({job="bar"}[1m]))`,
+		},
 		{
 			// shard the count since there is no label reduction in children
 			in:  `count by (foo) (rate({job="bar"}[1m]))`,
@@ -473,6 +478,80 @@ func TestMappingStrings(t *testing.T) {
 			in:  `count by (foo) (sum by (foo, bar) (rate({job="bar"}[1m])))`,
 			out: `countby(foo)(sumby(foo,bar)(downstream<sumby(foo,bar)(rate({job="bar"}[1m])),shard=0_of_2>++downstream<sumby(foo,bar)(rate({job="bar"}[1m])),shard=1_of_2>))`,
 		},
+		{
+			// the inner query of a subquery is sharded
+			in:  `max_over_time(sum by (foo) (rate({job="bar"}[1m]))[1h:1m])`,
+			out: `max_over_time(sumby(foo)(downstream<sumby(foo)(rate({job="bar"}[1m])),shard=0_of_2>++downstream<sumby(foo)(rate({job="bar"}[1m])),shard=1_of_2>)[1h:1m])`,
+		},
+		{
+			// subqueries are not merged across shards
+			in:  `sum(max_over_time(rate({job="bar"}[1m])[1h:1m]))`,
+			out: `sum(max_over_time(downstream<rate({job="bar"}[1m]),shard=0_of_2>++downstream<rate({job="bar"}[1m]),shard=1_of_2>[1h:1m]))`,
+		},
+		{
+			// subqueries with an unshardable inner query are not sharded
+			in:  `max_over_time(stddev_over_time({job="bar"} | unwrap foo [1m])[1h:1m])`,
+			out: `max_over_time(stddev_over_time({job="bar"}|unwrapfoo[1m])[1h:1m])`,
+		},
+		{
+			in:  `sum(rate({job="bar"}[1m] @ 1609746000))`,
+			out: `sum(downstream<sum(rate({job="bar"}[1m]@1609746000)),shard=0_of_2>++downstream<sum(rate({job="bar"}[1m]@1609746000)),shard=1_of_2>)`,
+		},
+		{
+			// sketches are merged by the timestamps of the samples, which are
+			// not the pinned time of the `@` modifier
+			in:  `quantile_over_time(0.99, {job="bar"} | unwrap foo [1m] @ 1609746000) by (cluster)`,
+			out: `quantile_over_time(0.99,{job="bar"}|unwrapfoo[1m]@1609746000)by(cluster)`,
+		},
+		{
+			// functions are evaluated on the merged result of the shards
+			in:  `clamp_max(sum by (foo) (rate({job="bar"}[1m])), 10)`,
+			out: `clamp_max(sumby(foo)(downstream<sumby(foo)(rate({job="bar"}[1m])),shard=0_of_2>++downstream<sumby(foo)(rate({job="bar"}[1m])),shard=1_of_2>),10)`,
+		},
+		{
+			in:  `histogram_quantile(0.99, sum by (le) (rate({job="bar"}[1m])))`,
+			out: `histogram_quantile(0.99,sumby(le)(downstream<sumby(le)(rate({job="bar"}[1m])),shard=0_of_2>++downstream<sumby(le)(rate({job="bar"}[1m])),shard=1_of_2>))`,
+		},
+		{
+			in:  `sum(rate({job="bar"}[1m])) / scalar(sum(rate({job="bar"}[1m])))`,
+			out: `(sum(downstream<sum(rate({job="bar"}[1m])),shard=0_of_2>++downstream<sum(rate({job="bar"}[1m])),shard=1_of_2>)/scalar(sum(downstream<sum(rate({job="bar"}[1m])),shard=0_of_2>++downstream<sum(rate({job="bar"}[1m])),shard=1_of_2>)))`,
+		},
+		{
+			in:  `hour()`,
+			out: `hour()`,
+		},
+		{
+			// count_values is merged by summing the counts of the shards
+			in:  `count_values("value", rate({job="bar"}[1m]))`,
+			out: `sumby(value)(downstream<count_values("value",rate({job="bar"}[1m])),shard=0_of_2>++downstream<count_values("value",rate({job="bar"}[1m])),shard=1_of_2>)`,
+		},
+		{
+			in:  `count_values without (foo) ("value", rate({job="bar"}[1m]))`,
+			out: `sumwithout(foo)(downstream<count_valueswithout(foo)("value",rate({job="bar"}[1m])),shard=0_of_2>++downstream<count_valueswithout(foo)("value",rate({job="bar"}[1m])),shard=1_of_2>)`,
+		},
+		{
+			// the inner aggregation must be merged across shards before counting values
+			in:  `count_values("value", max by (pod) (rate({job="bar"}[1m])))`,
+			out: `count_values("value",maxby(pod)(downstream<maxby(pod)(rate({job="bar"}[1m])),shard=0_of_2>++downstream<maxby(pod)(rate({job="bar"}[1m])),shard=1_of_2>))`,
+		},
+		{
+			in:  `label_join(sum by (foo, bar) (rate({job="bar"}[1m])), "foobar", "-", "foo", "bar")`,
+			out: `label_join(sumby(foo,bar)(downstream<sumby(foo,bar)(rate({job="bar"}[1m])),shard=0_of_2>++downstream<sumby(foo,bar)(rate({job="bar"}[1m])),shard=1_of_2>),"foobar","-","foo","bar")`,
+		},
+		{
+			// the duplicates of a line can be in different chunks of a stream
+			in:  `sum(count_over_time({job="bar"} | dedup within 1m [5m]))`,
+			out: `sum(count_over_time({job="bar"}|dedupwithin1m[5m]))`,
+		},
+		{
+			// duplicates across streams can belong to different shards
+			in:  `sum(count_over_time({job="bar"} | logfmt | dedup by (trace_id) [5m]))`,
+			out: `sum(count_over_time({job="bar"}|logfmt|dedupby(trace_id)[5m]))`,
+		},
+		{
+			in:  `{job="bar"} | logfmt | dedup by (trace_id)`,
+			out: `downstream<{job="bar"}|logfmt|dedupby(trace_id),shard=<nil>>`,
+		},
 	} {
 		t.Run(tc.in, func(t *testing.T) {
 			ast, err := syntax.ParseExpr(tc.in)
@@ -0,0 +1,246 @@
+package logql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/grafana/dskit/tenant"
+	"github.com/prometheus/prometheus/promql"
+
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
+	"github.com/grafana/loki/v3/pkg/util/validation"
+)
+
+const (
+	// defaultSubqueryStep is the resolution of subqueries without an explicit step
+	// when the outer query is an instant query.
+	defaultSubqueryStep = time.Minute
+
+	// maxSubqueryPoints is the maximum number of steps of the inner query of a
+	// subquery, the same resolution limit as for range queries.
+	maxSubqueryPoints = 11000
+)
+
+// subqueryParams overrides the time range and the step of the outer query
+// params for the evaluation of the inner query of a subquery.
+type subqueryParams struct {
+	Params
+	start, end time.Time
+	step       time.Duration
+	expr       syntax.SampleExpr
+}
+
+func (p subqueryParams) Start() time.Time           { return p.start }
+func (p subqueryParams) End() time.Time             { return p.end }
+func (p subqueryParams) Step() time.Duration        { return p.step }
+func (p subqueryParams) QueryString() string        { return p.expr.String() }
+func (p subqueryParams) GetExpression() syntax.Expr { return p.expr }
+
+// subqueryStep returns the resolution at which the inner query of expr is evaluated.
+func subqueryStep(expr *syntax.SubqueryExpr, q Params) time.Duration {
+	step := expr.Step
+	if step == 0 {
+		step = q.Step()
+	}
+	if step == 0 {
+		step = defaultSubqueryStep
+	}
+	if step < time.Millisecond {
+		step = time.Millisecond
+	}
+	return step
+}
+
+// newSubqueryParams returns the params for the inner query of expr.
+// The evaluation timestamps of the inner query are aligned to multiples of the
+// step, so the same samples are produced independently of the start of the
+// outer query. This also allows splitting subqueries by range.
+// It returns an error if the inner query has more than maxSubqueryPoints steps.
+func newSubqueryParams(expr *syntax.SubqueryExpr, q Params) (subqueryParams, error) {
+	step := subqueryStep(expr, q)
+	stepMs := step.Milliseconds()
+
+	// The range of the subquery is start exclusive and end inclusive.
+	startMs := q.Start().Add(-expr.Offset).Add(-expr.Range).UnixMilli()
+	startMs = startMs - startMs%stepMs + stepMs
+	endMs := q.End().Add(-expr.Offset).UnixMilli()
+	endMs -= endMs % stepMs
+	if endMs < startMs {
+		endMs = startMs
+	}
+	if (endMs-startMs)/stepMs > maxSubqueryPoints {
+		return subqueryParams{}, logqlmodel.NewSubqueryResolutionLimitError(maxSubqueryPoints)
+	}
+
+	return subqueryParams{
+		Params: q,
+		start:  time.UnixMilli(startMs),
+		end:    time.UnixMilli(endMs),
+		step:   step,
+		expr:   expr.Left,
+	}, nil
+}
+
+func subqueryAggregator(expr *syntax.SubqueryExpr) (BatchRangeVectorAggregator, error) {
+	switch expr.Operation {
+	case syntax.OpRangeTypeCount:
+		return countOverTime, nil
+	case syntax.OpRangeTypeSum:
+		return sumOverTime, nil
+	case syntax.OpRangeTypeAvg:
+		return avgOverTime, nil
+	case syntax.OpRangeTypeMax:
+		return maxOverTime, nil
+	case syntax.OpRangeTypeMin:
+		return minOverTime, nil
+	case syntax.OpRangeTypeStddev:
+		return stddevOverTime, nil
+	case syntax.OpRangeTypeStdvar:
+		return stdvarOverTime, nil
+	case syntax.OpRangeTypeQuantile:
+		if expr.Params == nil {
+			return nil, fmt.Errorf("parameter required for operation %s", expr.Operation)
+		}
+		return quantileOverTime(*expr.Params), nil
+	case syntax.OpRangeTypeFirst:
+		return first, nil
+	case syntax.OpRangeTypeLast:
+		return last, nil
+	default:
+		return nil, fmt.Errorf(syntax.UnsupportedErr, expr.Operation)
+	}
+}
+
+// newSubqueryEvaluator returns a step evaluator that evaluates the inner query
+// of expr at the resolution of the subquery and aggregates the resulting
+// samples over the subquery range at every step of the outer query.
+// The number of series of the inner query is bounded by the tenant's max query
+// series limit.
+func newSubqueryEvaluator(
+	ctx context.Context,
+	evFactory SampleEvaluatorFactory,
+	expr *syntax.SubqueryExpr,
+	q Params,
+	limits Limits,
+) (*SubqueryEvaluator, error) {
+	agg, err := subqueryAggregator(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	maxSeriesCapture := func(id string) int { return limits.MaxQuerySeries(ctx, id) }
+	maxSeries := validation.SmallestPositiveIntPerTenant(tenantIDs, maxSeriesCapture)
+
+	params, err := newSubqueryParams(expr, q)
+	if err != nil {
+		return nil, err
+	}
+	nextEvaluator, err := evFactory.NewStepEvaluator(ctx, evFactory, expr.Left, params)
+	if err != nil {
+		return nil, err
+	}
+
+	stepMs := q.Step().Milliseconds()
+	if stepMs == 0 {
+		stepMs = 1
+	}
+	return &SubqueryEvaluator{
+		nextEvaluator: nextEvaluator,
+		expr:          expr,
+		agg:           agg,
+		maxSeries:     maxSeries,
+		rangeMs:       expr.Range.Milliseconds(),
+		offsetMs:      expr.Offset.Milliseconds(),
+		stepMs:        stepMs,
+		endMs:         q.End().UnixMilli(),
+		currentMs:     q.Start().UnixMilli() - stepMs,
+	}, nil
+}
+
+type SubqueryEvaluator struct {
+	nextEvaluator StepEvaluator
+	expr          *syntax.SubqueryExpr
+	agg           BatchRangeVectorAggregator
+
+	// series holds all samples of the inner query ordered by timestamp.
+	series    []*promql.Series
+	maxSeries int
+	loaded    bool
+	err       error
+
+	rangeMs, offsetMs        int64
+	stepMs, endMs, currentMs int64
+}
+
+// load evaluates all steps of the inner query. It fails if the inner query
+// returns more than maxSeries series.
+func (e *SubqueryEvaluator) load() bool {
+	e.loaded = true
+	index := map[uint64]*promql.Series{}
+	for {
+		next, ts, r := e.nextEvaluator.Next()
+		if !next {
+			break
+		}
+		for _, s := range r.SampleVector() {
+			hash := s.Metric.Hash()
+			series, ok := index[hash]
+			if !ok {
+				if e.maxSeries > 0 && len(e.series) >= e.maxSeries {
+					e.err = logqlmodel.NewSeriesLimitError(e.maxSeries)
+					return false
+				}
+				series = &promql.Series{Metric: s.Metric}
+				index[hash] = series
+				e.series = append(e.series, series)
+			}
+			series.Floats = append(series.Floats, promql.FPoint{T: ts, F: s.F})
+		}
+	}
+	return e.nextEvaluator.Error() == nil
+}
+
+func (e *SubqueryEvaluator) Next() (bool, int64, StepResult) {
+	if !e.loaded && !e.load() {
+		return false, 0, SampleVector{}
+	}
+	e.currentMs += e.stepMs
+	if e.currentMs > e.endMs {
+		return false, 0, SampleVector{}
+	}
+
+	maxT := e.currentMs - e.offsetMs
+	minT := maxT - e.rangeMs
+	vec := make(promql.Vector, 0, len(e.series))
+	for _, s := range e.series {
+		lo := sort.Search(len(s.Floats), func(i int) bool { return s.Floats[i].T > minT })
+		hi := sort.Search(len(s.Floats), func(i int) bool { return s.Floats[i].T > maxT })
+		if lo == hi {
+			continue
+		}
+		vec = append(vec, promql.Sample{
+			T:      e.currentMs,
+			F:      e.agg(s.Floats[lo:hi]),
+			Metric: s.Metric,
+		})
+	}
+	return true, e.currentMs, SampleVector(vec)
+}
+
+func (e *SubqueryEvaluator) Close() error {
+	return e.nextEvaluator.Close()
+}
+
+func (e *SubqueryEvaluator) Error() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.nextEvaluator.Error()
+}
@@ -0,0 +1,143 @@
+package logql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/user"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
+)
+
+func TestSubquery(t *testing.T) {
+	// One line per second, two lines per second after the first minute.
+	entries := make([]logproto.Entry, 0, 180)
+	for i := 1; i <= 120; i++ {
+		ts := time.Unix(int64(i), 0)
+		entries = append(entries, logproto.Entry{Timestamp: ts, Line: fmt.Sprintf("%d", i)})
+		if i > 60 {
+			entries = append(entries, logproto.Entry{Timestamp: ts.Add(500 * time.Millisecond), Line: fmt.Sprintf("%d", i)})
+		}
+	}
+	streams := []logproto.Stream{{Labels: `{app="foo"}`, Entries: entries}}
+
+	for _, tc := range []struct {
+		query      string
+		start, end time.Time
+		step       time.Duration
+		expected   []float64
+	}{
+		{
+			query:    `max_over_time(count_over_time({app="foo"}[10s])[60s:10s])`,
+			start:    time.Unix(120, 0),
+			end:      time.Unix(120, 0),
+			expected: []float64{20},
+		},
+		{
+			query:    `min_over_time(count_over_time({app="foo"}[10s])[90s:10s])`,
+			start:    time.Unix(120, 0),
+			end:      time.Unix(120, 0),
+			expected: []float64{10},
+		},
+		{
+			query:    `count_over_time(count_over_time({app="foo"}[10s])[60s:10s])`,
+			start:    time.Unix(120, 0),
+			end:      time.Unix(120, 0),
+			expected: []float64{6},
+		},
+		{
+			// the evaluation timestamps of the subquery are aligned to its step
+			query:    `count_over_time(count_over_time({app="foo"}[10s])[60s:10s])`,
+			start:    time.Unix(125, 0),
+			end:      time.Unix(125, 0),
+			expected: []float64{6},
+		},
+		{
+			query:    `max_over_time(count_over_time({app="foo"}[10s])[20s:10s] offset 60s)`,
+			start:    time.Unix(120, 0),
+			end:      time.Unix(120, 0),
+			expected: []float64{10},
+		},
+		{
+			query:    `avg_over_time(sum(count_over_time({app="foo"}[10s]))[40s:10s])`,
+			start:    time.Unix(60, 0),
+			end:      time.Unix(100, 0),
+			step:     20 * time.Second,
+			expected: []float64{10, 14.75, 19.75},
+		},
+		{
+			// without step, the subquery is evaluated at the step of the outer query
+			query:    `max_over_time(count_over_time({app="foo"}[10s])[20s:])`,
+			start:    time.Unix(60, 0),
+			end:      time.Unix(80, 0),
+			step:     10 * time.Second,
+			expected: []float64{10, 19, 20},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			eng := NewEngine(EngineOpts{}, NewMockQuerier(0, streams), NoLimits, log.NewNopLogger())
+			params, err := NewLiteralParams(tc.query, tc.start, tc.end, tc.step, 0, logproto.FORWARD, 0, nil, nil)
+			require.NoError(t, err)
+
+			res, err := eng.Query(params).Exec(user.InjectOrgID(context.Background(), "fake"))
+			require.NoError(t, err)
+
+			var actual []float64
+			switch data := res.Data.(type) {
+			case promql.Vector:
+				for _, s := range data {
+					actual = append(actual, s.F)
+				}
+			case promql.Matrix:
+				require.Len(t, data, 1)
+				for _, p := range data[0].Floats {
+					actual = append(actual, p.F)
+				}
+			default:
+				t.Fatalf("unexpected result type %T", data)
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSubquery_Limits(t *testing.T) {
+	querier := NewMockQuerier(0, []logproto.Stream{
+		newStream(10, identity, `{app="foo"}`),
+		newStream(10, identity, `{app="bar"}`),
+		newStream(10, identity, `{app="baz"}`),
+	})
+	ctx := user.InjectOrgID(context.Background(), "fake")
+
+	for _, tc := range []struct {
+		query string
+		err   string
+	}{
+		{
+			// the inner query has more series than the outer query.
+			query: `sum(max_over_time(count_over_time({app=~".+"}[10s])[60s:10s]))`,
+			err:   "maximum number of series (2) reached for a single query",
+		},
+		{
+			query: `max_over_time(count_over_time({app="foo"}[10s])[1d:1s])`,
+			err:   "maximum resolution of 11000 points per time series reached for a subquery",
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			params, err := NewLiteralParams(tc.query, time.Unix(60, 0), time.Unix(60, 0), 0, 0, logproto.FORWARD, 0, nil, nil)
+			require.NoError(t, err)
+
+			eng := NewEngine(EngineOpts{}, querier, &fakeLimits{maxSeries: 2}, log.NewNopLogger())
+			_, err = eng.Query(params).Exec(ctx)
+			require.True(t, errors.Is(err, logqlmodel.ErrLimit))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
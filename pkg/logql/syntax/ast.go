@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/grafana/loki/v3/pkg/util"
 
@@ -52,17 +53,25 @@ func (VectorAggregationExpr) isExpr()      {}
 func (LiteralExpr) isExpr()                {}
 func (VectorExpr) isExpr()                 {}
 func (LabelReplaceExpr) isExpr()           {}
+func (LabelJoinExpr) isExpr()              {}
+func (FunctionExpr) isExpr()               {}
+func (SubqueryExpr) isExpr()               {}
 func (LineParserExpr) isExpr()             {}
 func (LogfmtParserExpr) isExpr()           {}
 func (LineFilterExpr) isExpr()             {}
 func (LabelFilterExpr) isExpr()            {}
 func (DecolorizeExpr) isExpr()             {}
+func (LookupExpr) isExpr()                 {}
+func (DedupExpr) isExpr()                  {}
+func (FieldFilterExpr) isExpr()            {}
 func (DropLabelsExpr) isExpr()             {}
 func (KeepLabelsExpr) isExpr()             {}
 func (LineFmtExpr) isExpr()                {}
 func (LabelFmtExpr) isExpr()               {}
 func (JSONExpressionParserExpr) isExpr()   {}
 func (LogfmtExpressionParserExpr) isExpr() {}
+func (XMLExpressionParserExpr) isExpr()    {}
+func (CSVParserExpr) isExpr()              {}
 func (LogRangeExpr) isExpr()               {}
 func (OffsetExpr) isExpr()                 {}
 func (UnwrapExpr) isExpr()                 {}
@@ -101,6 +110,9 @@ func (VectorAggregationExpr) isSampleExpr() {}
 func (LiteralExpr) isSampleExpr()           {}
 func (VectorExpr) isSampleExpr()            {}
 func (LabelReplaceExpr) isSampleExpr()      {}
+func (LabelJoinExpr) isSampleExpr()         {}
+func (FunctionExpr) isSampleExpr()          {}
+func (SubqueryExpr) isSampleExpr()          {}
 func (MultiVariantExpr) isSampleExpr()      {}
 
 // StageExpr is an expression defining a single step into a log pipeline
@@ -117,12 +129,17 @@ func (LogfmtParserExpr) isStageExpr()           {}
 func (LineFilterExpr) isStageExpr()             {}
 func (LabelFilterExpr) isStageExpr()            {}
 func (DecolorizeExpr) isStageExpr()             {}
+func (LookupExpr) isStageExpr()                 {}
+func (DedupExpr) isStageExpr()                  {}
+func (FieldFilterExpr) isStageExpr()            {}
 func (DropLabelsExpr) isStageExpr()             {}
 func (KeepLabelsExpr) isStageExpr()             {}
 func (LineFmtExpr) isStageExpr()                {}
 func (LabelFmtExpr) isStageExpr()               {}
 func (JSONExpressionParserExpr) isStageExpr()   {}
 func (LogfmtExpressionParserExpr) isStageExpr() {}
+func (XMLExpressionParserExpr) isStageExpr()    {}
+func (CSVParserExpr) isStageExpr()              {}
 
 func Clone[T Expr](e T) (T, error) {
 	var empty T
@@ -187,7 +204,11 @@ func ExtractLabelFiltersBeforeParser(e Expr) []*LabelFilterExpr {
 		VisitLabelParserFn:            func(_ RootVisitor, _ *LineParserExpr) { foundParseStage = true },
 		VisitJSONExpressionParserFn:   func(_ RootVisitor, _ *JSONExpressionParserExpr) { foundParseStage = true },
 		VisitLogfmtExpressionParserFn: func(_ RootVisitor, _ *LogfmtExpressionParserExpr) { foundParseStage = true },
+		VisitXMLExpressionParserFn:    func(_ RootVisitor, _ *XMLExpressionParserExpr) { foundParseStage = true },
+		VisitCSVParserFn:              func(_ RootVisitor, _ *CSVParserExpr) { foundParseStage = true },
 		VisitLabelFmtFn:               func(_ RootVisitor, _ *LabelFmtExpr) { foundParseStage = true },
+		VisitLookupFn:                 func(_ RootVisitor, _ *LookupExpr) { foundParseStage = true },
+		VisitDedupFn:                  func(_ RootVisitor, _ *DedupExpr) { foundParseStage = true },
 		VisitKeepLabelFn:              func(_ RootVisitor, _ *KeepLabelsExpr) { foundParseStage = true },
 		VisitDropLabelsFn:             func(_ RootVisitor, _ *DropLabelsExpr) { foundParseStage = true },
 	}
@@ -762,6 +783,8 @@ func (e *LineParserExpr) Stage() (log.Stage, error) {
 		return log.NewUnpackParser(), nil
 	case OpParserTypePattern:
 		return log.NewPatternParser(e.Param)
+	case OpParserTypeXML:
+		return log.NewXMLParser(), nil
 	default:
 		return nil, fmt.Errorf("unknown parser operator: %s", e.Op)
 	}
@@ -842,6 +865,185 @@ func (e *DecolorizeExpr) Walk(f WalkFn) { f(e) }
 
 func (e *DecolorizeExpr) Accept(v RootVisitor) { v.VisitDecolorize(e) }
 
+// LookupExpr adds the labels of the rows of a tenant lookup table matching
+// the value of a label.
+type LookupExpr struct {
+	Table string
+	Label string
+
+	table *log.LookupTable // resolved by ResolveLookupTables
+}
+
+func newLookupExpr(table, label string) *LookupExpr {
+	return &LookupExpr{
+		Table: table,
+		Label: label,
+	}
+}
+
+func (e *LookupExpr) Shardable(_ bool) bool { return true }
+
+func (e *LookupExpr) Stage() (log.Stage, error) {
+	if e.table == nil {
+		return nil, fmt.Errorf("lookup table %q is not available", e.Table)
+	}
+	return log.NewLookupStage(e.table, e.Label)
+}
+
+func (e *LookupExpr) String() string {
+	return fmt.Sprintf("%s %s %s %s %s", OpPipe, OpLookup, e.Table, OpOn, e.Label)
+}
+
+func (e *LookupExpr) Walk(f WalkFn) { f(e) }
+
+func (e *LookupExpr) Accept(v RootVisitor) { v.VisitLookup(e) }
+
+// DedupExpr drops the log lines that are duplicates of an earlier line of the
+// same stream, or of any stream with the same values for the By labels.
+type DedupExpr struct {
+	By     []string
+	Within time.Duration
+}
+
+func newDedupExpr(by []string, within time.Duration) *DedupExpr {
+	if within < 0 {
+		panic(logqlmodel.NewParseError(fmt.Sprintf("invalid dedup window %s: the window must not be negative", model.Duration(within)), 0, 0))
+	}
+	return &DedupExpr{
+		By:     by,
+		Within: within,
+	}
+}
+
+// Shardable returns false, since the duplicates of a line can belong to
+// different shards: shards split the streams when deduplicating across
+// streams, and the chunks of a stream with bounded shards.
+func (e *DedupExpr) Shardable(_ bool) bool { return false }
+
+func (e *DedupExpr) Stage() (log.Stage, error) {
+	return log.NewDedupStage(e.By, e.Within), nil
+}
+
+func (e *DedupExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(OpPipe)
+	sb.WriteString(" ")
+	sb.WriteString(OpDedup)
+	if len(e.By) > 0 {
+		sb.WriteString(" by (")
+		sb.WriteString(strings.Join(e.By, ", "))
+		sb.WriteString(")")
+	}
+	if e.Within > 0 {
+		sb.WriteString(" ")
+		sb.WriteString(OpWithin)
+		sb.WriteString(" ")
+		sb.WriteString(model.Duration(e.Within).String())
+	}
+	return sb.String()
+}
+
+func (e *DedupExpr) Walk(f WalkFn) { f(e) }
+
+func (e *DedupExpr) Accept(v RootVisitor) { v.VisitDedup(e) }
+
+// FieldFilterExpr filters the log lines by the value of a single JSON or
+// logfmt field, without parsing the lines into labels, e.g.
+// `| json.response.status >= 500`, or by the value of a single structured
+// metadata label, e.g. `| metadata.trace_id="abc"`. The name of the filter is
+// the last element of the path.
+type FieldFilterExpr struct {
+	Parser string
+	Path   []string
+	Filter log.LabelFilterer
+}
+
+func newFieldFilterExpr(parser string, path []string, filter log.LabelFilterer) *FieldFilterExpr {
+	e := &FieldFilterExpr{
+		Parser: parser,
+		Path:   append(path, fieldFilterName(filter)),
+		Filter: filter,
+	}
+	if _, err := e.Stage(); err != nil {
+		panic(logqlmodel.NewParseError(err.Error(), 0, 0))
+	}
+	return e
+}
+
+func fieldFilterName(filter log.LabelFilterer) string {
+	if noop, ok := filter.(*log.NoopLabelFilter); ok {
+		if noop.Matcher == nil {
+			return ""
+		}
+		return noop.Name
+	}
+	if names := filter.RequiredLabelNames(); len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+func (e *FieldFilterExpr) Shardable(_ bool) bool { return true }
+
+func (e *FieldFilterExpr) Stage() (log.Stage, error) {
+	return e.FieldFilter()
+}
+
+// FieldFilter returns the filter of the expression.
+func (e *FieldFilterExpr) FieldFilter() (*log.FieldFilter, error) {
+	format := log.FieldFormatJSON
+	switch e.Parser {
+	case OpParserTypeLogfmt:
+		format = log.FieldFormatLogfmt
+	case OpFieldFilterMetadata:
+		format = log.FieldFormatStructuredMetadata
+	}
+	return log.NewFieldFilter(format, e.Path, e.Filter)
+}
+
+func (e *FieldFilterExpr) String() string {
+	return OpPipe + " " + e.FilterString()
+}
+
+// FilterString returns the filter without the leading pipe, e.g.
+// `json.status>=500`, which can be parsed with ParseFieldFilter.
+func (e *FieldFilterExpr) FilterString() string {
+	var sb strings.Builder
+	sb.WriteString(e.Parser)
+	sb.WriteString(".")
+	for _, p := range e.Path[:len(e.Path)-1] {
+		sb.WriteString(p)
+		sb.WriteString(".")
+	}
+	sb.WriteString(e.Filter.String())
+	return sb.String()
+}
+
+func (e *FieldFilterExpr) Walk(f WalkFn) { f(e) }
+
+func (e *FieldFilterExpr) Accept(v RootVisitor) { v.VisitFieldFilter(e) }
+
+// ResolveLookupTables loads the lookup tables used by the expression with the
+// lookup function. It must be called before building the pipeline of an
+// expression with lookup stages.
+func ResolveLookupTables(e Expr, lookup func(name string) (*log.LookupTable, error)) error {
+	var err error
+	e.Accept(&DepthFirstTraversal{
+		VisitLookupFn: func(_ RootVisitor, e *LookupExpr) {
+			if err != nil {
+				return
+			}
+			var table *log.LookupTable
+			if table, err = lookup(e.Table); err != nil {
+				err = fmt.Errorf("lookup table %q: %w", e.Table, err)
+				return
+			}
+			e.table = table
+		},
+	})
+	return err
+}
+
 type DropLabelsExpr struct {
 	dropLabels []log.NamedLabelMatcher
 }
@@ -1099,6 +1301,108 @@ func (l *LogfmtExpressionParserExpr) String() string {
 	return sb.String()
 }
 
+type XMLExpressionParserExpr struct {
+	Expressions []log.LabelExtractionExpr
+}
+
+func newXMLExpressionParser(expressions []log.LabelExtractionExpr) *XMLExpressionParserExpr {
+	return &XMLExpressionParserExpr{
+		Expressions: expressions,
+	}
+}
+
+func (x *XMLExpressionParserExpr) Shardable(_ bool) bool { return true }
+
+func (x *XMLExpressionParserExpr) Walk(f WalkFn) { f(x) }
+
+func (x *XMLExpressionParserExpr) Accept(v RootVisitor) { v.VisitXMLExpressionParser(x) }
+
+func (x *XMLExpressionParserExpr) Stage() (log.Stage, error) {
+	return log.NewXMLExpressionParser(x.Expressions)
+}
+
+func (x *XMLExpressionParserExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s ", OpPipe, OpParserTypeXML))
+	for i, exp := range x.Expressions {
+		sb.WriteString(exp.Identifier)
+		sb.WriteString("=")
+		sb.WriteString(strconv.Quote(exp.Expression))
+
+		if i+1 != len(x.Expressions) {
+			sb.WriteString(",")
+		}
+	}
+	return sb.String()
+}
+
+// CSVParserExpr parses delimiter-separated values into the comma-separated
+// list of Columns, e.g. `| csv "ts,level,msg" delimiter=";"`.
+type CSVParserExpr struct {
+	Columns   []string
+	Delimiter rune
+	Quote     rune
+}
+
+func newCSVParserExpr(columns string, options []log.LabelExtractionExpr) *CSVParserExpr {
+	e := &CSVParserExpr{
+		Columns:   strings.Split(columns, ","),
+		Delimiter: log.DefaultCSVDelimiter,
+		Quote:     log.DefaultCSVQuote,
+	}
+	for i, c := range e.Columns {
+		e.Columns[i] = strings.TrimSpace(c)
+	}
+
+	for _, opt := range options {
+		if opt.Identifier != OpCSVDelimiter && opt.Identifier != OpCSVQuote {
+			panic(logqlmodel.NewParseError(fmt.Sprintf("unknown csv parser option: %s", opt.Identifier), 0, 0))
+		}
+		r, size := utf8.DecodeRuneInString(opt.Expression)
+		if size == 0 || size != len(opt.Expression) {
+			panic(logqlmodel.NewParseError(fmt.Sprintf("invalid csv parser option %s: a single character is required", opt.Identifier), 0, 0))
+		}
+		if opt.Identifier == OpCSVDelimiter {
+			e.Delimiter = r
+		} else {
+			e.Quote = r
+		}
+	}
+
+	if _, err := e.Stage(); err != nil {
+		panic(logqlmodel.NewParseError(fmt.Sprintf("invalid csv parser: %s", err.Error()), 0, 0))
+	}
+	return e
+}
+
+func (e *CSVParserExpr) Shardable(_ bool) bool { return true }
+
+func (e *CSVParserExpr) Walk(f WalkFn) { f(e) }
+
+func (e *CSVParserExpr) Accept(v RootVisitor) { v.VisitCSVParser(e) }
+
+func (e *CSVParserExpr) Stage() (log.Stage, error) {
+	return log.NewCSVParser(e.Columns, e.Delimiter, e.Quote)
+}
+
+func (e *CSVParserExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s ", OpPipe, OpParserTypeCSV))
+	sb.WriteString(strconv.Quote(strings.Join(e.Columns, ",")))
+	var options []string
+	if e.Delimiter != log.DefaultCSVDelimiter {
+		options = append(options, OpCSVDelimiter+"="+strconv.Quote(string(e.Delimiter)))
+	}
+	if e.Quote != log.DefaultCSVQuote {
+		options = append(options, OpCSVQuote+"="+strconv.Quote(string(e.Quote)))
+	}
+	if len(options) > 0 {
+		sb.WriteString(" ")
+		sb.WriteString(strings.Join(options, ","))
+	}
+	return sb.String()
+}
+
 func mustNewMatcher(t labels.MatchType, n, v string) *labels.Matcher {
 	m, err := labels.NewMatcher(t, n, v)
 	if err != nil {
@@ -1164,6 +1468,7 @@ type LogRangeExpr struct {
 	Left     LogSelectorExpr
 	Interval time.Duration
 	Offset   time.Duration
+	At       *AtModifier
 	Unwrap   *UnwrapExpr
 }
 
@@ -1175,8 +1480,8 @@ func (r LogRangeExpr) String() string {
 		sb.WriteString(r.Unwrap.String())
 	}
 	sb.WriteString(fmt.Sprintf("[%v]", model.Duration(r.Interval)))
-	if r.Offset != 0 {
-		offsetExpr := OffsetExpr{Offset: r.Offset}
+	if r.Offset != 0 || r.At != nil {
+		offsetExpr := OffsetExpr{Offset: r.Offset, At: r.At}
 		sb.WriteString(offsetExpr.String())
 	}
 	return sb.String()
@@ -1207,32 +1512,129 @@ func (r *LogRangeExpr) WithoutUnwrap() (*LogRangeExpr, error) {
 		Left:     left,
 		Interval: r.Interval,
 		Offset:   r.Offset,
+		At:       r.At.clone(),
 	}, nil
 }
 
 func newLogRange(left LogSelectorExpr, interval time.Duration, u *UnwrapExpr, o *OffsetExpr) *LogRangeExpr {
-	var offset time.Duration
+	var (
+		offset time.Duration
+		at     *AtModifier
+	)
 	if o != nil {
 		offset = o.Offset
+		at = o.At
 	}
 	return &LogRangeExpr{
 		Left:     left,
 		Interval: interval,
 		Unwrap:   u,
 		Offset:   offset,
+		At:       at,
 	}
 }
 
+// OffsetExpr holds the time modifiers of a range, i.e. `offset <duration>`
+// and `@ <timestamp>`.
 type OffsetExpr struct {
 	Offset time.Duration
+	At     *AtModifier
 }
 
 func (o *OffsetExpr) String() string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf(" %s %s", OpOffset, o.Offset.String()))
+	if o.At != nil {
+		sb.WriteString(o.At.String())
+	}
+	if o.Offset != 0 || o.At == nil {
+		sb.WriteString(fmt.Sprintf(" %s %s", OpOffset, o.Offset.String()))
+	}
 	return sb.String()
 }
 
+// AtModifier pins the evaluation time of a range aggregation or a subquery
+// to a fixed timestamp, or to the start or the end of the query, e.g.
+// `count_over_time({app="foo"}[5m] @ 1609746000)`.
+type AtModifier struct {
+	// Timestamp is the pinned evaluation time in milliseconds since epoch.
+	// It is only valid if StartOrEnd is empty.
+	Timestamp int64
+	// StartOrEnd is either OpStart or OpEnd for `@ start()` and `@ end()`
+	// until the modifier is resolved against the time range of the query.
+	StartOrEnd string
+}
+
+func mustNewAtModifier(s string) *AtModifier {
+	ts, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		panic(logqlmodel.NewParseError(fmt.Sprintf("unable to parse @ timestamp: %s", err.Error()), 0, 0))
+	}
+	if math.IsInf(ts, 0) || math.IsNaN(ts) || ts*1000 > math.MaxInt64 {
+		panic(logqlmodel.NewParseError(fmt.Sprintf("invalid @ timestamp: %s", s), 0, 0))
+	}
+	return &AtModifier{Timestamp: int64(math.Round(ts * 1000))}
+}
+
+// Time returns the pinned evaluation time.
+func (a *AtModifier) Time() time.Time {
+	return time.UnixMilli(a.Timestamp)
+}
+
+func (a *AtModifier) String() string {
+	switch a.StartOrEnd {
+	case OpStart, OpEnd:
+		return fmt.Sprintf(" %s %s()", OpAt, a.StartOrEnd)
+	}
+	return fmt.Sprintf(" %s %s", OpAt, strconv.FormatFloat(float64(a.Timestamp)/1000, 'f', -1, 64))
+}
+
+func (a *AtModifier) clone() *AtModifier {
+	if a == nil {
+		return nil
+	}
+	copied := *a
+	return &copied
+}
+
+// AtModifiers returns all `@` modifiers of the given expression.
+func AtModifiers(expr Expr) []*AtModifier {
+	var res []*AtModifier
+	expr.Walk(func(e Expr) bool {
+		switch e := e.(type) {
+		case *LogRangeExpr:
+			if e.At != nil {
+				res = append(res, e.At)
+			}
+		case *SubqueryExpr:
+			if e.At != nil {
+				res = append(res, e.At)
+			}
+		}
+		return true
+	})
+	return res
+}
+
+// ResolveAtModifiers replaces `@ start()` and `@ end()` modifiers of the
+// given expression in place with the given start and end timestamps.
+// It returns true if any modifier was resolved.
+func ResolveAtModifiers(expr Expr, start, end time.Time) bool {
+	var resolved bool
+	for _, at := range AtModifiers(expr) {
+		switch at.StartOrEnd {
+		case OpStart:
+			at.Timestamp = start.UnixMilli()
+		case OpEnd:
+			at.Timestamp = end.UnixMilli()
+		default:
+			continue
+		}
+		at.StartOrEnd = ""
+		resolved = true
+	}
+	return resolved
+}
+
 func newOffsetExpr(offset time.Duration) *OffsetExpr {
 	return &OffsetExpr{
 		Offset: offset,
@@ -1253,6 +1655,9 @@ const (
 	OpTypeSort     = "sort"
 	OpTypeSortDesc = "sort_desc"
 
+	// OpTypeCountValues counts the series with the same sample value.
+	OpTypeCountValues = "count_values"
+
 	// range vector ops
 	OpRangeTypeCount       = "count_over_time"
 	OpRangeTypeRate        = "rate"
@@ -1270,6 +1675,9 @@ const (
 	OpRangeTypeLast        = "last_over_time"
 	OpRangeTypeAbsent      = "absent_over_time"
 
+	// OpRangeTypePatternCount counts log lines per Drain pattern detected at query time.
+	OpRangeTypePatternCount = "pattern_count_over_time"
+
 	// vector
 	OpTypeVector = "vector"
 
@@ -1300,18 +1708,37 @@ const (
 	OpParserTypeRegexp  = "regexp"
 	OpParserTypeUnpack  = "unpack"
 	OpParserTypePattern = "pattern"
+	OpParserTypeXML     = "xml"
+	OpParserTypeCSV     = "csv"
+
+	// field filters
+	OpFieldFilterMetadata = "metadata"
+
+	// csv parser options
+	OpCSVDelimiter = "delimiter"
+	OpCSVQuote     = "quote"
 
 	OpFmtLine    = "line_format"
 	OpFmtLabel   = "label_format"
 	OpDecolorize = "decolorize"
+	OpLookup     = "lookup"
+	OpDedup      = "dedup"
+	OpWithin     = "within"
 
 	OpPipe   = "|"
 	OpUnwrap = "unwrap"
 	OpOffset = "offset"
+	OpAt     = "@"
+	OpStart  = "start"
+	OpEnd    = "end"
 
 	OpOn       = "on"
 	OpIgnoring = "ignoring"
 
+	// joins
+	OpJoin     = "join"
+	OpLeftJoin = "left_join"
+
 	OpGroupLeft  = "group_left"
 	OpGroupRight = "group_right"
 
@@ -1321,6 +1748,25 @@ const (
 	OpConvDurationSeconds = "duration_seconds"
 
 	OpLabelReplace = "label_replace"
+	OpLabelJoin    = "label_join"
+
+	// functions
+	OpFuncAbs        = "abs"
+	OpFuncCeil       = "ceil"
+	OpFuncFloor      = "floor"
+	OpFuncRound      = "round"
+	OpFuncSqrt       = "sqrt"
+	OpFuncExp        = "exp"
+	OpFuncLn         = "ln"
+	OpFuncClampMin   = "clamp_min"
+	OpFuncClampMax   = "clamp_max"
+	OpFuncScalar     = "scalar"
+	OpFuncTimestamp  = "timestamp"
+	OpFuncHour       = "hour"
+	OpFuncDayOfWeek  = "day_of_week"
+	OpFuncDayOfMonth = "day_of_month"
+
+	OpFuncHistogramQuantile = "histogram_quantile"
 
 	// function filters
 	OpFilterIP = "ip"
@@ -1441,7 +1887,8 @@ func (e RangeAggregationExpr) validate() error {
 		switch e.Operation {
 		case OpRangeTypeAvg, OpRangeTypeStddev, OpRangeTypeStdvar, OpRangeTypeQuantile,
 			OpRangeTypeQuantileSketch, OpRangeTypeMax, OpRangeTypeMin, OpRangeTypeFirst,
-			OpRangeTypeLast, OpRangeTypeFirstWithTimestamp, OpRangeTypeLastWithTimestamp:
+			OpRangeTypeLast, OpRangeTypeFirstWithTimestamp, OpRangeTypeLastWithTimestamp,
+			OpRangeTypePatternCount:
 		default:
 			return fmt.Errorf("grouping not allowed for %s aggregation", e.Operation)
 		}
@@ -1458,7 +1905,8 @@ func (e RangeAggregationExpr) validate() error {
 		}
 	}
 	switch e.Operation {
-	case OpRangeTypeBytes, OpRangeTypeBytesRate, OpRangeTypeCount, OpRangeTypeRate, OpRangeTypeAbsent:
+	case OpRangeTypeBytes, OpRangeTypeBytesRate, OpRangeTypeCount, OpRangeTypeRate, OpRangeTypeAbsent,
+		OpRangeTypePatternCount:
 		return nil
 	default:
 		return fmt.Errorf("invalid aggregation %s without unwrap", e.Operation)
@@ -1495,6 +1943,20 @@ func (e *RangeAggregationExpr) Shardable(topLevel bool) bool {
 	if e.Operation == OpRangeTypeQuantile && !topLevel {
 		return false
 	}
+	// Patterns are detected from all the lines of the query, the patterns of
+	// different shards can't be merged.
+	if e.Operation == OpRangeTypePatternCount {
+		return false
+	}
+	// Sharded quantile, first and last aggregations are merged by the timestamps
+	// of their samples, which are replaced by the steps of the outer query when
+	// the evaluation time is pinned with an `@` modifier.
+	if e.Left.At != nil {
+		switch e.Operation {
+		case OpRangeTypeQuantile, OpRangeTypeFirst, OpRangeTypeLast:
+			return false
+		}
+	}
 	return shardableOps[e.Operation] && e.Left.Shardable(topLevel)
 }
 
@@ -1509,6 +1971,147 @@ func (e *RangeAggregationExpr) Walk(f WalkFn) {
 
 func (e *RangeAggregationExpr) Accept(v RootVisitor) { v.VisitRangeAggregation(e) }
 
+// subqueryRange is the `[<range>:<step>]` part of a subquery as produced by the lexer.
+type subqueryRange struct {
+	rng  time.Duration
+	step time.Duration
+}
+
+// SubqueryExpr applies a range vector aggregation to the samples of an inner
+// metric query that is evaluated at a fixed resolution over the range, e.g.
+// max_over_time(rate({app="api"}[1m])[1h:1m]).
+// A zero Step means that the inner query is evaluated at the step of the outer query.
+type SubqueryExpr struct {
+	Left      SampleExpr
+	Operation string
+	Range     time.Duration
+	Step      time.Duration
+	Offset    time.Duration
+	At        *AtModifier
+
+	Params *float64
+	err    error
+}
+
+func newSubqueryExpr(left SampleExpr, operation string, r subqueryRange, o *OffsetExpr, stringParams *string) SampleExpr {
+	e := &SubqueryExpr{
+		Left:      left,
+		Operation: operation,
+		Range:     r.rng,
+		Step:      r.step,
+	}
+	if o != nil {
+		e.Offset = o.Offset
+		e.At = o.At
+	}
+	if stringParams != nil {
+		if operation != OpRangeTypeQuantile {
+			return &SubqueryExpr{err: logqlmodel.NewParseError(fmt.Sprintf("parameter %s not supported for operation %s", *stringParams, operation), 0, 0)}
+		}
+		params, err := strconv.ParseFloat(*stringParams, 64)
+		if err != nil {
+			return &SubqueryExpr{err: logqlmodel.NewParseError(fmt.Sprintf("invalid parameter for operation %s: %s", operation, err), 0, 0)}
+		}
+		e.Params = &params
+	} else if operation == OpRangeTypeQuantile {
+		return &SubqueryExpr{err: logqlmodel.NewParseError(fmt.Sprintf("parameter required for operation %s", operation), 0, 0)}
+	}
+	if err := e.validate(); err != nil {
+		return &SubqueryExpr{err: logqlmodel.NewParseError(err.Error(), 0, 0)}
+	}
+	return e
+}
+
+func (e *SubqueryExpr) validate() error {
+	switch e.Operation {
+	case OpRangeTypeCount, OpRangeTypeAvg, OpRangeTypeSum, OpRangeTypeMax, OpRangeTypeMin,
+		OpRangeTypeStddev, OpRangeTypeStdvar, OpRangeTypeQuantile, OpRangeTypeFirst, OpRangeTypeLast:
+	default:
+		return fmt.Errorf("invalid aggregation %s with subquery", e.Operation)
+	}
+	if e.Range <= 0 {
+		return fmt.Errorf("subquery range must be greater than zero: %s", e.Range)
+	}
+	if e.Step < 0 {
+		return fmt.Errorf("subquery step must not be negative: %s", e.Step)
+	}
+	return nil
+}
+
+func (e *SubqueryExpr) Selector() (LogSelectorExpr, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.Left.Selector()
+}
+
+// MatcherGroups returns the matcher groups of the inner query, extended by the
+// range and offset of the subquery.
+func (e *SubqueryExpr) MatcherGroups() ([]MatcherRange, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	groups, err := e.Left.MatcherGroups()
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		groups[i].Interval += e.Range
+		groups[i].Offset += e.Offset
+	}
+	return groups, nil
+}
+
+func (e *SubqueryExpr) Extractors() ([]SampleExtractor, error) {
+	if e.err != nil {
+		return []SampleExtractor{}, e.err
+	}
+	return e.Left.Extractors()
+}
+
+// Shardable returns false, because the aggregation over time of a subquery
+// cannot be merged from shards. Its inner query may be sharded though.
+func (e *SubqueryExpr) Shardable(_ bool) bool {
+	return false
+}
+
+func (e *SubqueryExpr) Walk(f WalkFn) {
+	if !f(e) {
+		return
+	}
+	if e.Left != nil {
+		e.Left.Walk(f)
+	}
+}
+
+func (e *SubqueryExpr) Accept(v RootVisitor) { v.VisitSubquery(e) }
+
+// impls Stringer
+func (e *SubqueryExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Operation)
+	sb.WriteString("(")
+	if e.Params != nil {
+		sb.WriteString(strconv.FormatFloat(*e.Params, 'f', -1, 64))
+		sb.WriteString(",")
+	}
+	sb.WriteString(e.Left.String())
+	sb.WriteString(e.rangeString())
+	if e.Offset != 0 || e.At != nil {
+		offsetExpr := OffsetExpr{Offset: e.Offset, At: e.At}
+		sb.WriteString(offsetExpr.String())
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func (e *SubqueryExpr) rangeString() string {
+	if e.Step == 0 {
+		return fmt.Sprintf("[%v:]", model.Duration(e.Range))
+	}
+	return fmt.Sprintf("[%v:%v]", model.Duration(e.Range), model.Duration(e.Step))
+}
+
 // Grouping struct represents the grouping by/without label(s) for vector aggregators and range vector aggregators.
 // The representation is as follows:
 //   - No Grouping (labels dismissed): <operation> (<expr>) => Grouping{Without: false, Groups: nil}
@@ -1557,7 +2160,9 @@ type VectorAggregationExpr struct {
 	Grouping  *Grouping `json:"grouping,omitempty"`
 	Params    int       `json:"params"`
 	Operation string    `json:"operation"`
-	err       error
+	// Label is the name of the label holding the sample values of count_values.
+	Label string `json:"label,omitempty"`
+	err   error
 }
 
 func mustNewVectorAggregationExpr(left SampleExpr, operation string, gr *Grouping, params *string) SampleExpr {
@@ -1595,6 +2200,21 @@ func mustNewVectorAggregationExpr(left SampleExpr, operation string, gr *Groupin
 	}
 }
 
+func mustNewCountValuesExpr(left SampleExpr, label string, gr *Grouping) SampleExpr {
+	if !model.LabelName(label).IsValid() {
+		return &VectorAggregationExpr{err: logqlmodel.NewParseError(fmt.Sprintf("invalid label name in %s: %q", OpTypeCountValues, label), 0, 0)}
+	}
+	if gr == nil {
+		gr = &Grouping{}
+	}
+	return &VectorAggregationExpr{
+		Left:      left,
+		Operation: OpTypeCountValues,
+		Grouping:  gr,
+		Label:     label,
+	}
+}
+
 func (e *VectorAggregationExpr) MatcherGroups() ([]MatcherRange, error) {
 	if e.err != nil {
 		return nil, e.err
@@ -1647,6 +2267,8 @@ func (e *VectorAggregationExpr) String() string {
 	// bottomK and topk can have first parameter as 0
 	case OpTypeBottomK, OpTypeTopK, OpTypeApproxTopK:
 		params = []string{fmt.Sprintf("%d", e.Params), e.Left.String()}
+	case OpTypeCountValues:
+		params = []string{strconv.Quote(e.Label), e.Left.String()}
 	default:
 		if e.Params != 0 {
 			params = []string{fmt.Sprintf("%d", e.Params), e.Left.String()}
@@ -1665,11 +2287,12 @@ func (e *VectorAggregationExpr) Shardable(topLevel bool) bool {
 
 	switch e.Operation {
 
-	case OpTypeCount, OpTypeAvg:
+	case OpTypeCount, OpTypeAvg, OpTypeCountValues:
 		// count is shardable if labels are not mutated
 		// otherwise distinct values can be present in multiple shards and
 		// counted twice.
 		// avg is similar since it's remapped to sum/count.
+		// count_values is a count grouped by the sample values.
 		// TODO(owen-d): this is hard to figure out; we should refactor to
 		// make these relationships clearer, safer, and more extensible.
 		shardable := !ReducesLabels(e.Left)
@@ -2292,6 +2915,218 @@ func (e *LabelReplaceExpr) String() string {
 	return sb.String()
 }
 
+// LabelJoinExpr joins the values of the Src labels with Separator and stores
+// the result in the Dst label of every sample returned by Left.
+type LabelJoinExpr struct {
+	Left      SampleExpr
+	Dst       string
+	Separator string
+	Src       []string
+	err       error
+}
+
+func mustNewLabelJoinExpr(left SampleExpr, dst, separator string, src []string) *LabelJoinExpr {
+	for _, name := range append([]string{dst}, src...) {
+		if !model.LabelName(name).IsValid() {
+			return &LabelJoinExpr{
+				err: logqlmodel.NewParseError(fmt.Sprintf("invalid label name in %s: %q", OpLabelJoin, name), 0, 0),
+			}
+		}
+	}
+	return &LabelJoinExpr{
+		Left:      left,
+		Dst:       dst,
+		Separator: separator,
+		Src:       src,
+	}
+}
+
+func (e *LabelJoinExpr) Selector() (LogSelectorExpr, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.Left.Selector()
+}
+
+func (e *LabelJoinExpr) MatcherGroups() ([]MatcherRange, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.Left.MatcherGroups()
+}
+
+func (e *LabelJoinExpr) Extractors() ([]SampleExtractor, error) {
+	if e.err != nil {
+		return []SampleExtractor{}, e.err
+	}
+	return e.Left.Extractors()
+}
+
+func (e *LabelJoinExpr) Shardable(_ bool) bool {
+	return false
+}
+
+func (e *LabelJoinExpr) Walk(f WalkFn) {
+	if !f(e) {
+		return
+	}
+	if e.Left != nil {
+		e.Left.Walk(f)
+	}
+}
+
+func (e *LabelJoinExpr) Accept(v RootVisitor) { v.VisitLabelJoin(e) }
+
+func (e *LabelJoinExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(OpLabelJoin)
+	sb.WriteString("(")
+	sb.WriteString(e.Left.String())
+	sb.WriteString(",")
+	sb.WriteString(strconv.Quote(e.Dst))
+	sb.WriteString(",")
+	sb.WriteString(strconv.Quote(e.Separator))
+	for _, src := range e.Src {
+		sb.WriteString(",")
+		sb.WriteString(strconv.Quote(src))
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+// FunctionExpr applies a function to every sample of the instant vector
+// returned by Left. Left is nil for time functions called without argument,
+// which are then evaluated against the timestamp of each step.
+// histogram_quantile takes its parameter as first argument and reduces the
+// bucket samples of Left to one sample per histogram.
+type FunctionExpr struct {
+	Left     SampleExpr
+	Function string
+	Param    *float64
+	err      error
+}
+
+func mustNewFunctionExpr(fn string, left SampleExpr, param *LiteralExpr) *FunctionExpr {
+	e := &FunctionExpr{
+		Left:     left,
+		Function: fn,
+	}
+	if param != nil {
+		val, err := param.Value()
+		if err != nil {
+			e.err = err
+			return e
+		}
+		e.Param = &val
+	}
+	if err := e.validate(); err != nil {
+		e.err = logqlmodel.NewParseError(err.Error(), 0, 0)
+	}
+	return e
+}
+
+// validate checks the number of arguments of the function.
+func (e *FunctionExpr) validate() error {
+	switch e.Function {
+	case OpFuncAbs, OpFuncCeil, OpFuncFloor, OpFuncSqrt, OpFuncExp, OpFuncLn, OpFuncScalar, OpFuncTimestamp:
+		if e.Left == nil || e.Param != nil {
+			return fmt.Errorf("function %s expects exactly one argument", e.Function)
+		}
+	case OpFuncClampMin, OpFuncClampMax:
+		if e.Left == nil || e.Param == nil {
+			return fmt.Errorf("function %s expects exactly two arguments", e.Function)
+		}
+	case OpFuncRound:
+		if e.Left == nil {
+			return fmt.Errorf("function %s expects one or two arguments", e.Function)
+		}
+	case OpFuncHour, OpFuncDayOfWeek, OpFuncDayOfMonth:
+		if e.Param != nil {
+			return fmt.Errorf("function %s expects at most one argument", e.Function)
+		}
+	case OpFuncHistogramQuantile:
+		if e.Left == nil || e.Param == nil {
+			return fmt.Errorf("function %s expects exactly two arguments", e.Function)
+		}
+	default:
+		return fmt.Errorf("unsupported function: %s", e.Function)
+	}
+	return nil
+}
+
+func (e *FunctionExpr) Err() error {
+	return e.err
+}
+
+func (e *FunctionExpr) Selector() (LogSelectorExpr, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	if e.Left == nil {
+		return &VectorExpr{}, nil
+	}
+	return e.Left.Selector()
+}
+
+func (e *FunctionExpr) MatcherGroups() ([]MatcherRange, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	if e.Left == nil {
+		return nil, nil
+	}
+	return e.Left.MatcherGroups()
+}
+
+func (e *FunctionExpr) Extractors() ([]SampleExtractor, error) {
+	if e.err != nil {
+		return []SampleExtractor{}, e.err
+	}
+	if e.Left == nil {
+		return []SampleExtractor{}, nil
+	}
+	return e.Left.Extractors()
+}
+
+// Shardable returns false since functions are evaluated on the merged
+// result of the shards of their argument.
+func (e *FunctionExpr) Shardable(_ bool) bool {
+	return false
+}
+
+func (e *FunctionExpr) Walk(f WalkFn) {
+	if !f(e) {
+		return
+	}
+	if e.Left != nil {
+		e.Left.Walk(f)
+	}
+}
+
+func (e *FunctionExpr) Accept(v RootVisitor) { v.VisitFunction(e) }
+
+func (e *FunctionExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Function)
+	sb.WriteString("(")
+	if e.Function == OpFuncHistogramQuantile && e.Param != nil && e.Left != nil {
+		sb.WriteString(strconv.FormatFloat(*e.Param, 'f', -1, 64))
+		sb.WriteString(",")
+		sb.WriteString(e.Left.String())
+		sb.WriteString(")")
+		return sb.String()
+	}
+	if e.Left != nil {
+		sb.WriteString(e.Left.String())
+	}
+	if e.Param != nil {
+		sb.WriteString(",")
+		sb.WriteString(strconv.FormatFloat(*e.Param, 'f', -1, 64))
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
 // shardableOps lists the operations which may be sharded, but are not
 // guaranteed to be. See the `Shardable()` implementations
 // on the respective expr types for more details.
@@ -2319,6 +3154,8 @@ var shardableOps = map[string]bool{
 	OpTypeCount: true,
 	OpTypeMax:   true,
 	OpTypeMin:   true,
+	// count_values is remapped into a sum of the counts of the shards.
+	OpTypeCountValues: true,
 
 	OpTypeApproxTopK: true,
 
@@ -2638,3 +3475,58 @@ func newVariantsExpr(variants []SampleExpr, logRange *LogRangeExpr) VariantsExpr
 		logRange: logRange,
 	}
 }
+
+// JoinExpr correlates the entries of two log queries sharing the values of
+// labels within a time window.
+type JoinExpr struct {
+	Left  LogSelectorExpr
+	Right LogSelectorExpr
+
+	Operation string   // OpJoin or OpLeftJoin
+	On        []string // labels the entries are joined on
+	Window    time.Duration
+}
+
+func newJoinExpr(left LogSelectorExpr, operation string, on []string, window time.Duration, right LogSelectorExpr) *JoinExpr {
+	if window <= 0 {
+		panic(logqlmodel.NewParseError(fmt.Sprintf("invalid %s window %s: the window must be positive", operation, window), 0, 0))
+	}
+	return &JoinExpr{
+		Left:      left,
+		Right:     right,
+		Operation: operation,
+		On:        on,
+		Window:    window,
+	}
+}
+
+func (JoinExpr) isExpr() {}
+
+// Shardable returns false as matching entries can belong to different shards.
+func (e *JoinExpr) Shardable(_ bool) bool { return false }
+
+func (e *JoinExpr) Walk(f WalkFn) {
+	if !f(e) {
+		return
+	}
+	e.Left.Walk(f)
+	e.Right.Walk(f)
+}
+
+func (e *JoinExpr) Accept(v RootVisitor) { v.VisitJoin(e) }
+
+func (e *JoinExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Left.String())
+	sb.WriteString(" ")
+	sb.WriteString(e.joinString())
+	sb.WriteString(" ")
+	sb.WriteString(e.Right.String())
+	return sb.String()
+}
+
+// joinString returns the join operation with its modifiers, e.g.
+// `join on (trace_id) [5m]`.
+func (e *JoinExpr) joinString() string {
+	return fmt.Sprintf("%s %s (%s) [%s]", e.Operation, OpOn, strings.Join(e.On, ", "), model.Duration(e.Window))
+}
@@ -1,6 +1,7 @@
 package syntax
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -51,6 +52,11 @@ func Test_logSelectorExpr_String(t *testing.T) {
 		{`{foo="bar"} |= "baz" |~ "blip" != "flip" !~ "flap" | regexp "(?P<foo>foo|bar)"`, true},
 		{`{foo="bar"} |= "baz" |~ "blip" != "flip" !~ "flap" | regexp "(?P<foo>foo|bar)" | ( ( foo<5.01 , bar>20ms ) or foo="bar" ) | line_format "blip{{.boop}}bap" | label_format foo=bar,bar="blip{{.blop}}"`, true},
 		{`{foo="bar"} | logfmt | counter>-1 | counter>=-1 | counter<-1 | counter<=-1 | counter!=-1 | counter==-1`, true},
+		{`{foo="bar"} |= "baz" | xml | event_level="error"`, true},
+		{`{foo="bar"} |= "baz" | xml level="event/@level",msg="event/msg" | level="error"`, true},
+		{`{foo="bar"} |= "baz" | csv "ts,level,,msg" | level="error"`, true},
+		{`{foo="bar"} |= "baz" | csv "ts,level,msg" delimiter=";",quote="'" | level="error"`, true},
+		{`{foo="bar"} | json.status>=500 | json.request.method=~"GET|POST" | logfmt.took>1s | logfmt.level!="debug"`, true},
 	}
 
 	for _, tt := range tests {
@@ -88,6 +94,8 @@ func Test_SampleExpr_String(t *testing.T) {
 		`sum by(a) (rate( ( {job="mysql"} |="error" !="timeout" ) [10s] ) )`,
 		`sum(count_over_time({job="mysql"}[5m]))`,
 		`sum(count_over_time({job="mysql"}[5m] offset 10m))`,
+		`sum(count_over_time({job="mysql"}[5m] @ 1609746000 offset 10m))`,
+		`sum(count_over_time({job="mysql"}[5m] @ end())) / sum(count_over_time({job="mysql"}[5m] @ start()))`,
 		`sum(count_over_time({job="mysql"} | json [5m]))`,
 		`sum(count_over_time({job="mysql"} | json [5m] offset 10m))`,
 		`sum(count_over_time({job="mysql"} | logfmt [5m]))`,
@@ -884,6 +892,7 @@ func Test_parserExpr_Parser(t *testing.T) {
 		{"pattern err", OpParserTypePattern, "bar", nil, true, true},
 		{"regexp", OpParserTypeRegexp, "(?P<foo>foo)", mustNewRegexParser("(?P<foo>foo)"), false, false},
 		{"regexp err ", OpParserTypeRegexp, "foo", nil, true, true},
+		{"xml", OpParserTypeXML, "", log.NewXMLParser(), false, false},
 		{"unknown op", "DummyOp", "", nil, true, false},
 	}
 	for _, tt := range tests {
@@ -920,6 +929,7 @@ func Test_parserExpr_String(t *testing.T) {
 		{"valid pattern", OpParserTypePattern, "buzz", `| pattern "buzz"`},
 		{"empty pattern", OpParserTypePattern, "", `| pattern ""`},
 		{"valid json", OpParserTypeJSON, "", `| json`},
+		{"valid xml", OpParserTypeXML, "", `| xml`},
 	}
 
 	for _, tt := range tests {
@@ -1100,6 +1110,50 @@ func TestLogSelectorExprHasFilter(t *testing.T) {
 	}
 }
 
+func TestResolveAtModifiers(t *testing.T) {
+	expr, err := ParseSampleExpr(`sum(count_over_time({job="mysql"}[5m] @ start())) / sum(count_over_time({job="mysql"}[5m] @ end())) / sum(count_over_time({job="mysql"}[5m] @ 1609746000))`)
+	require.NoError(t, err)
+	require.Len(t, AtModifiers(expr), 3)
+
+	start, end := time.Unix(1609740000, 0), time.Unix(1609750000, 0)
+	require.True(t, ResolveAtModifiers(expr, start, end))
+	require.Equal(t, `((sum(count_over_time({job="mysql"}[5m] @ 1609740000)) / sum(count_over_time({job="mysql"}[5m] @ 1609750000))) / sum(count_over_time({job="mysql"}[5m] @ 1609746000)))`, expr.String())
+
+	// resolving twice is a no-op
+	require.False(t, ResolveAtModifiers(expr, start.Add(time.Hour), end.Add(time.Hour)))
+}
+
+func TestResolveLookupTables(t *testing.T) {
+	expr, err := ParseSampleExpr(`sum by (tier) (count_over_time({app="foo"} | logfmt | lookup customers on customer_id [5m]))`)
+	require.NoError(t, err)
+
+	_, err = expr.Extractors()
+	require.ErrorContains(t, err, `lookup table "customers" is not available`)
+
+	err = ResolveLookupTables(expr, func(name string) (*log.LookupTable, error) {
+		return nil, errors.New("not found")
+	})
+	require.EqualError(t, err, `lookup table "customers": not found`)
+
+	table := log.NewLookupTable(map[string]labels.Labels{
+		"42": labels.FromStrings("tier", "gold"),
+	})
+	err = ResolveLookupTables(expr, func(name string) (*log.LookupTable, error) {
+		require.Equal(t, "customers", name)
+		return table, nil
+	})
+	require.NoError(t, err)
+
+	extractors, err := expr.Extractors()
+	require.NoError(t, err)
+	require.Len(t, extractors, 1)
+	samples, ok := extractors[0].ForStream(labels.FromStrings("app", "foo")).Process(0, []byte("customer_id=42"), labels.EmptyLabels())
+	require.True(t, ok)
+	require.Len(t, samples, 1)
+	require.Equal(t, labels.FromStrings("tier", "gold"), samples[0].Labels.Labels())
+	require.Equal(t, `sum by (tier)(count_over_time({app="foo"} | logfmt | lookup customers on customer_id[5m]))`, expr.String())
+}
+
 func TestGroupingString(t *testing.T) {
 	g := Grouping{
 		Groups:  []string{"a", "b"},
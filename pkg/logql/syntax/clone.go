@@ -1,6 +1,8 @@
 package syntax
 
 import (
+	"slices"
+
 	"github.com/prometheus/prometheus/model/labels"
 
 	"github.com/grafana/loki/v3/pkg/logql/log"
@@ -55,6 +57,7 @@ func (v *cloneVisitor) VisitVectorAggregation(e *VectorAggregationExpr) {
 		Left:      MustClone[SampleExpr](e.Left),
 		Params:    e.Params,
 		Operation: e.Operation,
+		Label:     e.Label,
 	}
 
 	if e.Grouping != nil {
@@ -87,6 +90,44 @@ func (v *cloneVisitor) VisitLabelReplace(e *LabelReplaceExpr) {
 	v.cloned = mustNewLabelReplaceExpr(left, e.Dst, e.Replacement, e.Src, e.Regex)
 }
 
+func (v *cloneVisitor) VisitLabelJoin(e *LabelJoinExpr) {
+	left := MustClone[SampleExpr](e.Left)
+	v.cloned = mustNewLabelJoinExpr(left, e.Dst, e.Separator, slices.Clone(e.Src))
+}
+
+func (v *cloneVisitor) VisitFunction(e *FunctionExpr) {
+	copied := &FunctionExpr{
+		Function: e.Function,
+		err:      e.err,
+	}
+	if e.Left != nil {
+		copied.Left = MustClone[SampleExpr](e.Left)
+	}
+	if e.Param != nil {
+		tmp := *e.Param
+		copied.Param = &tmp
+	}
+	v.cloned = copied
+}
+
+func (v *cloneVisitor) VisitSubquery(e *SubqueryExpr) {
+	copied := &SubqueryExpr{
+		Left:      MustClone[SampleExpr](e.Left),
+		Operation: e.Operation,
+		Range:     e.Range,
+		Step:      e.Step,
+		Offset:    e.Offset,
+		At:        e.At.clone(),
+	}
+
+	if e.Params != nil {
+		tmp := *e.Params
+		copied.Params = &tmp
+	}
+
+	v.cloned = copied
+}
+
 func (v *cloneVisitor) VisitLiteral(e *LiteralExpr) {
 	v.cloned = &LiteralExpr{Val: e.Val}
 }
@@ -95,11 +136,26 @@ func (v *cloneVisitor) VisitVector(e *VectorExpr) {
 	v.cloned = &VectorExpr{Val: e.Val}
 }
 
+func (v *cloneVisitor) VisitJoin(e *JoinExpr) {
+	copied := &JoinExpr{
+		Left:      MustClone[LogSelectorExpr](e.Left),
+		Right:     MustClone[LogSelectorExpr](e.Right),
+		Operation: e.Operation,
+		Window:    e.Window,
+	}
+	if e.On != nil {
+		copied.On = make([]string, len(e.On))
+		copy(copied.On, e.On)
+	}
+	v.cloned = copied
+}
+
 func (v *cloneVisitor) VisitLogRange(e *LogRangeExpr) {
 	copied := &LogRangeExpr{
 		Left:     MustClone[LogSelectorExpr](e.Left),
 		Interval: e.Interval,
 		Offset:   e.Offset,
+		At:       e.At.clone(),
 	}
 	if e.Unwrap != nil {
 		copied.Unwrap = &UnwrapExpr{
@@ -144,6 +200,29 @@ func (v *cloneVisitor) VisitDecolorize(*DecolorizeExpr) {
 	v.cloned = &DecolorizeExpr{}
 }
 
+func (v *cloneVisitor) VisitLookup(e *LookupExpr) {
+	v.cloned = &LookupExpr{
+		Table: e.Table,
+		Label: e.Label,
+		table: e.table,
+	}
+}
+
+func (v *cloneVisitor) VisitDedup(e *DedupExpr) {
+	v.cloned = &DedupExpr{
+		By:     slices.Clone(e.By),
+		Within: e.Within,
+	}
+}
+
+func (v *cloneVisitor) VisitFieldFilter(e *FieldFilterExpr) {
+	v.cloned = &FieldFilterExpr{
+		Parser: e.Parser,
+		Path:   slices.Clone(e.Path),
+		Filter: cloneLabelFilterer(e.Filter),
+	}
+}
+
 func (v *cloneVisitor) VisitDropLabels(e *DropLabelsExpr) {
 	copied := &DropLabelsExpr{
 		dropLabels: make([]log.NamedLabelMatcher, len(e.dropLabels)),
@@ -294,6 +373,23 @@ func (v *cloneVisitor) VisitLogfmtExpressionParser(e *LogfmtExpressionParserExpr
 	v.cloned = copied
 }
 
+func (v *cloneVisitor) VisitXMLExpressionParser(e *XMLExpressionParserExpr) {
+	copied := &XMLExpressionParserExpr{
+		Expressions: make([]log.LabelExtractionExpr, len(e.Expressions)),
+	}
+	copy(copied.Expressions, e.Expressions)
+
+	v.cloned = copied
+}
+
+func (v *cloneVisitor) VisitCSVParser(e *CSVParserExpr) {
+	v.cloned = &CSVParserExpr{
+		Columns:   slices.Clone(e.Columns),
+		Delimiter: e.Delimiter,
+		Quote:     e.Quote,
+	}
+}
+
 func (v *cloneVisitor) VisitLogfmtParser(e *LogfmtParserExpr) {
 	v.cloned = &LogfmtParserExpr{
 		Strict:    e.Strict,
@@ -37,7 +37,9 @@ var tokens = map[string]int{
 	"[":            OPEN_BRACKET,
 	"]":            CLOSE_BRACKET,
 	OpLabelReplace: LABEL_REPLACE,
+	OpLabelJoin:    LABEL_JOIN,
 	OpOffset:       OFFSET,
+	OpAt:           AT,
 	OpOn:           ON,
 	OpIgnoring:     IGNORING,
 	OpGroupLeft:    GROUP_LEFT,
@@ -75,6 +77,13 @@ var tokens = map[string]int{
 	OpFilterIP:   IP,
 	OpDecolorize: DECOLORIZE,
 
+	// lookup tables
+	OpLookup: LOOKUP,
+
+	// dedup
+	OpDedup:  DEDUP,
+	OpWithin: WITHIN,
+
 	// drop labels
 	OpDrop: DROP,
 
@@ -84,6 +93,10 @@ var tokens = map[string]int{
 	// variants
 	OpVariants: VARIANTS,
 	VariantsOf: OF,
+
+	// joins
+	OpJoin:     JOIN,
+	OpLeftJoin: LEFT_JOIN,
 }
 
 var parserFlags = map[string]struct{}{
@@ -91,25 +104,39 @@ var parserFlags = map[string]struct{}{
 	OpKeepEmpty: {},
 }
 
+// pipelineStageTokens are tokens only recognized at the start of a pipeline stage,
+// so that they remain valid label names everywhere else, including in label filters.
+var pipelineStageTokens = map[string]int{
+	OpParserTypeXML: XML,
+	OpParserTypeCSV: CSV,
+}
+
+// fieldFilterTokens are tokens only recognized at the start of a field filter,
+// e.g. `metadata.trace_id`, so that they remain valid label names everywhere else.
+var fieldFilterTokens = map[string]int{
+	OpFieldFilterMetadata: METADATA,
+}
+
 // functionTokens are tokens that needs to be suffixes with parenthesis
 var functionTokens = map[string]int{
 	// range vec ops
-	OpRangeTypeRate:        RATE,
-	OpRangeTypeRateCounter: RATE_COUNTER,
-	OpRangeTypeCount:       COUNT_OVER_TIME,
-	OpRangeTypeBytesRate:   BYTES_RATE,
-	OpRangeTypeBytes:       BYTES_OVER_TIME,
-	OpRangeTypeAvg:         AVG_OVER_TIME,
-	OpRangeTypeSum:         SUM_OVER_TIME,
-	OpRangeTypeMin:         MIN_OVER_TIME,
-	OpRangeTypeMax:         MAX_OVER_TIME,
-	OpRangeTypeStdvar:      STDVAR_OVER_TIME,
-	OpRangeTypeStddev:      STDDEV_OVER_TIME,
-	OpRangeTypeQuantile:    QUANTILE_OVER_TIME,
-	OpRangeTypeFirst:       FIRST_OVER_TIME,
-	OpRangeTypeLast:        LAST_OVER_TIME,
-	OpRangeTypeAbsent:      ABSENT_OVER_TIME,
-	OpTypeVector:           VECTOR,
+	OpRangeTypeRate:         RATE,
+	OpRangeTypeRateCounter:  RATE_COUNTER,
+	OpRangeTypeCount:        COUNT_OVER_TIME,
+	OpRangeTypeBytesRate:    BYTES_RATE,
+	OpRangeTypeBytes:        BYTES_OVER_TIME,
+	OpRangeTypeAvg:          AVG_OVER_TIME,
+	OpRangeTypeSum:          SUM_OVER_TIME,
+	OpRangeTypeMin:          MIN_OVER_TIME,
+	OpRangeTypeMax:          MAX_OVER_TIME,
+	OpRangeTypeStdvar:       STDVAR_OVER_TIME,
+	OpRangeTypeStddev:       STDDEV_OVER_TIME,
+	OpRangeTypeQuantile:     QUANTILE_OVER_TIME,
+	OpRangeTypeFirst:        FIRST_OVER_TIME,
+	OpRangeTypeLast:         LAST_OVER_TIME,
+	OpRangeTypeAbsent:       ABSENT_OVER_TIME,
+	OpRangeTypePatternCount: PATTERN_COUNT_OVER_TIME,
+	OpTypeVector:            VECTOR,
 
 	// vec ops
 	OpTypeSum:      SUM,
@@ -124,8 +151,10 @@ var functionTokens = map[string]int{
 	OpTypeSort:     SORT,
 	OpTypeSortDesc: SORT_DESC,
 	OpLabelReplace: LABEL_REPLACE,
+	OpLabelJoin:    LABEL_JOIN,
 
-	OpTypeApproxTopK: APPROX_TOPK,
+	OpTypeApproxTopK:  APPROX_TOPK,
+	OpTypeCountValues: COUNT_VALUES,
 
 	// conversion Op
 	OpConvBytes:           BYTES_CONV,
@@ -134,15 +163,53 @@ var functionTokens = map[string]int{
 
 	// filterOp
 	OpFilterIP: IP,
+
+	// at modifier
+	OpStart: START,
+	OpEnd:   END,
+
+	// functions
+	OpFuncAbs:        ABS,
+	OpFuncCeil:       CEIL,
+	OpFuncFloor:      FLOOR,
+	OpFuncRound:      ROUND,
+	OpFuncSqrt:       SQRT,
+	OpFuncExp:        EXP,
+	OpFuncLn:         LN,
+	OpFuncClampMin:   CLAMP_MIN,
+	OpFuncClampMax:   CLAMP_MAX,
+	OpFuncScalar:     SCALAR,
+	OpFuncTimestamp:  TIMESTAMP,
+	OpFuncHour:       HOUR,
+	OpFuncDayOfWeek:  DAY_OF_WEEK,
+	OpFuncDayOfMonth: DAY_OF_MONTH,
+
+	OpFuncHistogramQuantile: HISTOGRAM_QUANTILE,
 }
 
 type lexer struct {
 	Scanner
 	errs    []logqlmodel.ParseError
 	builder strings.Builder
+	// prev is the last token returned
+	prev int
+	// start is the start token returned before scanning the input, selecting
+	// the grammar rule to parse, or 0 to parse an expression.
+	start int
 }
 
 func (l *lexer) Lex(lval *syntaxSymType) int {
+	tok := l.start
+	if tok != 0 {
+		l.start = 0
+	} else {
+		tok = l.lex(lval)
+	}
+	l.prev = tok
+	return tok
+}
+
+func (l *lexer) lex(lval *syntaxSymType) int {
 	r := l.Scan()
 
 	switch r {
@@ -152,7 +219,7 @@ func (l *lexer) Lex(lval *syntaxSymType) int {
 		for next := l.Peek(); next != '\n' && next != scanner.EOF; next = l.Next() {
 		}
 
-		return l.Lex(lval)
+		return l.lex(lval)
 
 	case scanner.EOF:
 		return 0
@@ -208,6 +275,15 @@ func (l *lexer) Lex(lval *syntaxSymType) int {
 		l.builder.Reset()
 		for r := l.Next(); r != scanner.EOF; r = l.Next() {
 			if r == ']' {
+				if rng, step, ok := strings.Cut(l.builder.String(), ":"); ok {
+					sr, err := parseSubqueryRange(rng, step)
+					if err != nil {
+						l.Error(err.Error())
+						return 0
+					}
+					lval.subqueryRange = sr
+					return SUBQUERY_RANGE
+				}
 				i, err := model.ParseDuration(l.builder.String())
 				if err != nil {
 					l.Error(err.Error())
@@ -244,6 +320,14 @@ func (l *lexer) Lex(lval *syntaxSymType) int {
 		return tok
 	}
 
+	if tok, ok := pipelineStageTokens[tokenTextLower]; ok && l.prev == PIPE && !isLabelFilter(l.Scanner) {
+		return tok
+	}
+
+	if tok, ok := fieldFilterTokens[tokenTextLower]; ok && (l.prev == PIPE || l.prev == START_FIELD_FILTER) && l.Peek() == '.' {
+		return tok
+	}
+
 	if tok, ok := tokens[tokenNext]; ok {
 		l.Next()
 		return tok
@@ -257,6 +341,25 @@ func (l *lexer) Lex(lval *syntaxSymType) int {
 	return IDENTIFIER
 }
 
+// parseSubqueryRange parses the range and the optional step of a subquery,
+// e.g. `1h:1m` or `1h:`.
+func parseSubqueryRange(rng, step string) (subqueryRange, error) {
+	var sr subqueryRange
+	d, err := model.ParseDuration(rng)
+	if err != nil {
+		return sr, err
+	}
+	sr.rng = time.Duration(d)
+	if step != "" {
+		d, err = model.ParseDuration(step)
+		if err != nil {
+			return sr, err
+		}
+		sr.step = time.Duration(d)
+	}
+	return sr, nil
+}
+
 func (l *lexer) Error(msg string) {
 	l.errs = append(l.errs, logqlmodel.NewParseError(msg, l.Line, l.Column))
 }
@@ -411,6 +514,17 @@ func isFunction(sc Scanner) bool {
 	return false
 }
 
+// isLabelFilter checks if the next rune is a comparison operator,
+// which means the current token is the label name of a label filter.
+func isLabelFilter(sc Scanner) bool {
+	sc = trimSpace(sc)
+	switch sc.Peek() {
+	case '=', '!', '>', '<':
+		return true
+	}
+	return false
+}
+
 func trimSpace(l Scanner) Scanner {
 	for n := l.Peek(); n != scanner.EOF; n = l.Peek() {
 		if unicode.IsSpace(n) {
@@ -46,6 +46,12 @@ func init() {
 	for str, tok := range tokens {
 		syntaxToknames[tok-syntaxPrivate+1] = str
 	}
+	for str, tok := range pipelineStageTokens {
+		syntaxToknames[tok-syntaxPrivate+1] = str
+	}
+	for str, tok := range fieldFilterTokens {
+		syntaxToknames[tok-syntaxPrivate+1] = str
+	}
 }
 
 type parser struct {
@@ -57,6 +63,7 @@ type parser struct {
 
 func (p *parser) Parse() (Expr, error) {
 	p.errs = p.errs[:0]
+	p.prev = 0
 	p.Scanner.Error = func(_ *Scanner, msg string) {
 		p.Error(msg)
 	}
@@ -79,7 +86,13 @@ func ParseExpr(input string) (Expr, error) {
 	return expr, nil
 }
 
-func ParseExprWithoutValidation(input string) (expr Expr, err error) {
+func ParseExprWithoutValidation(input string) (Expr, error) {
+	return parse(input, 0)
+}
+
+// parse parses the input from the grammar rule selected by the start token,
+// or as an expression if start is 0.
+func parse(input string, start int) (expr Expr, err error) {
 	if len(input) >= maxInputSize {
 		return nil, logqlmodel.NewParseError(fmt.Sprintf("input size too long (%d > %d)", len(input), maxInputSize), 0, 0)
 	}
@@ -101,6 +114,7 @@ func ParseExprWithoutValidation(input string) (expr Expr, err error) {
 
 	p.Reset(input)
 	p.Init(p.Reader)
+	p.start = start
 	return p.Parse()
 }
 
@@ -120,11 +134,20 @@ func validateExpr(expr Expr) error {
 		return validateLogSelectorExpression(e)
 	case VariantsExpr:
 		return validateVariantsExpr(e)
+	case *JoinExpr:
+		return validateJoinExpr(e)
 	default:
 		return logqlmodel.NewParseError(fmt.Sprintf("unexpected expression type: %v", e), 0, 0)
 	}
 }
 
+func validateJoinExpr(e *JoinExpr) error {
+	if err := validateLogSelectorExpression(e.Left); err != nil {
+		return err
+	}
+	return validateLogSelectorExpression(e.Right)
+}
+
 func validateVariantsExpr(e VariantsExpr) error {
 	err := validateLogSelectorExpression(e.LogRange().Left)
 	if err != nil {
@@ -227,6 +250,24 @@ func validateSampleExpr(expr SampleExpr) error {
 			return e.err
 		}
 		return validateSampleExpr(e.Left)
+	case *SubqueryExpr:
+		if e.err != nil {
+			return e.err
+		}
+		return validateSampleExpr(e.Left)
+	case *LabelJoinExpr:
+		if e.err != nil {
+			return e.err
+		}
+		return validateSampleExpr(e.Left)
+	case *FunctionExpr:
+		if e.err != nil {
+			return e.err
+		}
+		if e.Left == nil {
+			return nil
+		}
+		return validateSampleExpr(e.Left)
 	default:
 		selector, err := e.Selector()
 		if err != nil {
@@ -272,6 +313,16 @@ func ParseLogSelector(input string, validate bool) (LogSelectorExpr, error) {
 	return logSelector, nil
 }
 
+// ParseFieldFilter parses a field filter without its leading pipe, as
+// returned by FieldFilterExpr.FilterString.
+func ParseFieldFilter(input string) (*FieldFilterExpr, error) {
+	expr, err := parse(input, START_FIELD_FILTER)
+	if err != nil {
+		return nil, err
+	}
+	return expr.(*FieldFilterExpr), nil
+}
+
 // ParseLabels parses labels from a string using logql parser.
 func ParseLabels(lbs string) (labels.Labels, error) {
 	ls, err := promql_parser.ParseMetric(lbs)
@@ -215,6 +215,26 @@ var ParseTestCases = []struct {
 			Operation: OpRangeTypeAbsent,
 		},
 	},
+	{
+		in: `pattern_count_over_time({ foo = "bar" } |= "error" [5m]) by ()`,
+		exp: &RangeAggregationExpr{
+			Left: &LogRangeExpr{
+				Left: newPipelineExpr(
+					newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}),
+					MultiStageExpr{
+						newLineFilterExpr(log.LineMatchEqual, "", "error"),
+					},
+				),
+				Interval: 5 * time.Minute,
+			},
+			Operation: OpRangeTypePatternCount,
+			Grouping:  &Grouping{},
+		},
+	},
+	{
+		in:  `pattern_count_over_time({ foo = "bar" } | unwrap bar [5m])`,
+		err: logqlmodel.NewParseError("invalid aggregation pattern_count_over_time with unwrap", 0, 0),
+	},
 	{
 		in: `sum(rate({ foo = "bar" }[5h]))`,
 		exp: mustNewVectorAggregationExpr(&RangeAggregationExpr{
@@ -380,6 +400,14 @@ var ParseTestCases = []struct {
 		in:  `label_replace(rate({ foo = "bar" }[5m]),"foo","$1","bar","^^^^x43\\q")`,
 		err: logqlmodel.NewParseError("invalid regex in label_replace: error parsing regexp: invalid escape sequence: `\\q`", 0, 0),
 	},
+	{
+		in:  `count_values("", rate({ foo = "bar" }[5m]))`,
+		err: logqlmodel.NewParseError(`invalid label name in count_values: ""`, 0, 0),
+	},
+	{
+		in:  `label_join(rate({ foo = "bar" }[5m]),"",",","bar")`,
+		err: logqlmodel.NewParseError(`invalid label name in label_join: ""`, 0, 0),
+	},
 	{
 		in:  `rate({ foo = "bar" }[5)`,
 		err: logqlmodel.NewParseError("missing closing ']' in duration", 0, 21),
@@ -388,6 +416,168 @@ var ParseTestCases = []struct {
 		in:  `min({ foo = "bar" }[5m])`,
 		err: logqlmodel.NewParseError("syntax error: unexpected RANGE", 0, 20),
 	},
+	{
+		in: `max_over_time(rate({ foo = "bar" }[1m])[1h:1m])`,
+		exp: &SubqueryExpr{
+			Left:      newRangeAggregationExpr(newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), time.Minute, nil, nil), OpRangeTypeRate, nil, nil),
+			Operation: OpRangeTypeMax,
+			Range:     time.Hour,
+			Step:      time.Minute,
+		},
+	},
+	{
+		in: `avg_over_time(sum by (foo) (rate({ foo = "bar" }[1m]))[1h:] offset 5m)`,
+		exp: &SubqueryExpr{
+			Left: mustNewVectorAggregationExpr(
+				newRangeAggregationExpr(newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), time.Minute, nil, nil), OpRangeTypeRate, nil, nil),
+				OpTypeSum, &Grouping{Groups: []string{"foo"}}, nil,
+			),
+			Operation: OpRangeTypeAvg,
+			Range:     time.Hour,
+			Offset:    5 * time.Minute,
+		},
+	},
+	{
+		in: `quantile_over_time(0.99, rate({ foo = "bar" }[1m])[30m:30s])`,
+		exp: newSubqueryExpr(
+			newRangeAggregationExpr(newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), time.Minute, nil, nil), OpRangeTypeRate, nil, nil),
+			OpRangeTypeQuantile, subqueryRange{rng: 30 * time.Minute, step: 30 * time.Second}, nil, NewStringLabelFilter("0.99"),
+		),
+	},
+	{
+		in:  `rate(sum(rate({ foo = "bar" }[1m]))[1h:1m])`,
+		err: logqlmodel.NewParseError("invalid aggregation rate with subquery", 0, 0),
+	},
+	{
+		in:  `quantile_over_time(rate({ foo = "bar" }[1m])[1h:1m])`,
+		err: logqlmodel.NewParseError("syntax error: unexpected RATE, expecting NUMBER or { or (", 1, 20),
+	},
+	{
+		in:  `max_over_time(rate({ foo = "bar" }[1m])[1h:1x])`,
+		err: logqlmodel.NewParseError(`unknown unit "x" in duration "1x"`, 0, 40),
+	},
+	{
+		in:  `max_over_time({ foo = "bar" }[1h:1m])`,
+		err: logqlmodel.NewParseError("syntax error: unexpected SUBQUERY_RANGE", 0, 30),
+	},
+	{
+		in: `count_over_time({ foo = "bar" }[5m] @ 1609746000)`,
+		exp: newRangeAggregationExpr(
+			newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, &OffsetExpr{At: &AtModifier{Timestamp: 1609746000000}}),
+			OpRangeTypeCount, nil, nil),
+	},
+	{
+		in: `count_over_time({ foo = "bar" }[5m] @ 1609746000.5 offset 1h)`,
+		exp: newRangeAggregationExpr(
+			newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, &OffsetExpr{Offset: time.Hour, At: &AtModifier{Timestamp: 1609746000500}}),
+			OpRangeTypeCount, nil, nil),
+	},
+	{
+		in: `count_over_time({ foo = "bar" }[5m] offset 1h @ start())`,
+		exp: newRangeAggregationExpr(
+			newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, &OffsetExpr{Offset: time.Hour, At: &AtModifier{StartOrEnd: OpStart}}),
+			OpRangeTypeCount, nil, nil),
+	},
+	{
+		in: `sum by (end) (rate({ foo = "bar" } | json | unwrap end [5m] @ end()))`,
+		exp: mustNewVectorAggregationExpr(
+			newRangeAggregationExpr(
+				newLogRange(
+					newPipelineExpr(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), MultiStageExpr{newLabelParserExpr(OpParserTypeJSON, "")}),
+					5*time.Minute, newUnwrapExpr("end", ""), &OffsetExpr{At: &AtModifier{StartOrEnd: OpEnd}}),
+				OpRangeTypeRate, nil, nil),
+			OpTypeSum, &Grouping{Groups: []string{"end"}}, nil,
+		),
+	},
+	{
+		in: `max_over_time(rate({ foo = "bar" }[1m])[1h:1m] @ end())`,
+		exp: &SubqueryExpr{
+			Left:      newRangeAggregationExpr(newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), time.Minute, nil, nil), OpRangeTypeRate, nil, nil),
+			Operation: OpRangeTypeMax,
+			Range:     time.Hour,
+			Step:      time.Minute,
+			At:        &AtModifier{StartOrEnd: OpEnd},
+		},
+	},
+	{
+		in: `round(rate({ foo = "bar" }[5m]), 0.5)`,
+		exp: mustNewFunctionExpr(OpFuncRound,
+			newRangeAggregationExpr(newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, nil), OpRangeTypeRate, nil, nil),
+			mustNewLiteralExpr("0.5", false),
+		),
+	},
+	{
+		in: `clamp_min(sum(rate({ foo = "bar" }[5m])), -1)`,
+		exp: mustNewFunctionExpr(OpFuncClampMin,
+			mustNewVectorAggregationExpr(
+				newRangeAggregationExpr(newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, nil), OpRangeTypeRate, nil, nil),
+				OpTypeSum, nil, nil,
+			),
+			mustNewLiteralExpr("1", true),
+		),
+	},
+	{
+		in: `sum(rate({ foo = "bar" }[5m])) / scalar(sum(rate({ foo = "bar" }[5m])))`,
+		exp: mustNewBinOpExpr(OpTypeDiv, &BinOpOptions{VectorMatching: &VectorMatching{Card: CardOneToOne}},
+			mustNewVectorAggregationExpr(
+				newRangeAggregationExpr(newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, nil), OpRangeTypeRate, nil, nil),
+				OpTypeSum, nil, nil,
+			),
+			mustNewFunctionExpr(OpFuncScalar,
+				mustNewVectorAggregationExpr(
+					newRangeAggregationExpr(newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, nil), OpRangeTypeRate, nil, nil),
+					OpTypeSum, nil, nil,
+				),
+				nil,
+			),
+		),
+	},
+	{
+		in:  `hour()`,
+		exp: mustNewFunctionExpr(OpFuncHour, nil, nil),
+	},
+	{
+		in: `histogram_quantile(0.99, sum by (le) (sum_over_time({ foo = "bar" } | unwrap count [5m])))`,
+		exp: mustNewFunctionExpr(OpFuncHistogramQuantile,
+			mustNewVectorAggregationExpr(
+				newRangeAggregationExpr(
+					newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, newUnwrapExpr("count", ""), nil),
+					OpRangeTypeSum, nil, nil),
+				OpTypeSum, &Grouping{Groups: []string{"le"}}, nil,
+			),
+			mustNewLiteralExpr("0.99", false),
+		),
+	},
+	{
+		in:  `histogram_quantile(sum by (le) (rate({ foo = "bar" }[5m])), 0.99)`,
+		err: logqlmodel.NewParseError("syntax error: unexpected SUM, expecting NUMBER or + or -", 1, 20),
+	},
+	{
+		in: `sum by (hour) (count_over_time({ foo = "bar" } | json | hour > 5 [5m]))`,
+		exp: mustNewVectorAggregationExpr(
+			newRangeAggregationExpr(
+				newLogRange(
+					newPipelineExpr(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), MultiStageExpr{
+						newLabelParserExpr(OpParserTypeJSON, ""),
+						&LabelFilterExpr{LabelFilterer: log.NewNumericLabelFilter(log.LabelFilterGreaterThan, "hour", 5)},
+					}),
+					5*time.Minute, nil, nil),
+				OpRangeTypeCount, nil, nil),
+			OpTypeSum, &Grouping{Groups: []string{"hour"}}, nil,
+		),
+	},
+	{
+		in:  `clamp_max(rate({ foo = "bar" }[5m]))`,
+		err: logqlmodel.NewParseError("function clamp_max expects exactly two arguments", 0, 0),
+	},
+	{
+		in:  `abs(rate({ foo = "bar" }[5m]), 1)`,
+		err: logqlmodel.NewParseError("function abs expects exactly one argument", 0, 0),
+	},
+	{
+		in:  `count_over_time({ foo = "bar" }[5m] @ foo())`,
+		err: logqlmodel.NewParseError("syntax error: unexpected IDENTIFIER, expecting NUMBER or START or END", 1, 39),
+	},
 	{
 		in: `avg(
 					label_replace(
@@ -2931,6 +3121,28 @@ var ParseTestCases = []struct {
 		in:  `label_replace(vector(0), "foo", "bar", "", "")`,
 		exp: mustNewLabelReplaceExpr(&VectorExpr{Val: 0, err: nil}, "foo", "bar", "", ""),
 	},
+	{
+		in:  `label_join(vector(0), "foo", ",", "bar", "baz")`,
+		exp: mustNewLabelJoinExpr(&VectorExpr{Val: 0, err: nil}, "foo", ",", []string{"bar", "baz"}),
+	},
+	{
+		in: `count_values("value", vector(0))`,
+		exp: &VectorAggregationExpr{
+			Left:      &VectorExpr{Val: 0, err: nil},
+			Grouping:  &Grouping{},
+			Operation: OpTypeCountValues,
+			Label:     "value",
+		},
+	},
+	{
+		in: `count_values by (foo) ("value", vector(0))`,
+		exp: &VectorAggregationExpr{
+			Left:      &VectorExpr{Val: 0, err: nil},
+			Grouping:  &Grouping{Groups: []string{"foo"}},
+			Operation: OpTypeCountValues,
+			Label:     "value",
+		},
+	},
 	{
 		in: `sum(vector(0))`,
 		exp: &VectorAggregationExpr{
@@ -3052,6 +3264,215 @@ var ParseTestCases = []struct {
 			},
 		},
 	},
+	{
+		in: `{app="foo"} | xml`,
+		exp: &PipelineExpr{
+			Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+			MultiStages: MultiStageExpr{
+				newLabelParserExpr(OpParserTypeXML, ""),
+			},
+		},
+	},
+	{
+		in: `{app="foo"} | xml level="event/@level", msg="/event/msg[1]"`,
+		exp: &PipelineExpr{
+			Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+			MultiStages: MultiStageExpr{
+				newXMLExpressionParser([]log.LabelExtractionExpr{
+					log.NewLabelExtractionExpr("level", `event/@level`),
+					log.NewLabelExtractionExpr("msg", `/event/msg[1]`),
+				}),
+			},
+		},
+	},
+	{
+		in: `{app="foo"} | csv "ts, level,,msg"`,
+		exp: &PipelineExpr{
+			Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+			MultiStages: MultiStageExpr{
+				&CSVParserExpr{Columns: []string{"ts", "level", "", "msg"}, Delimiter: ',', Quote: '"'},
+			},
+		},
+	},
+	{
+		in: `{app="foo"} | csv "ts,level,msg" delimiter=";", quote="'" | level="error"`,
+		exp: &PipelineExpr{
+			Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+			MultiStages: MultiStageExpr{
+				&CSVParserExpr{Columns: []string{"ts", "level", "msg"}, Delimiter: ';', Quote: '\''},
+				&LabelFilterExpr{
+					LabelFilterer: log.NewStringLabelFilter(mustNewMatcher(labels.MatchEqual, "level", "error")),
+				},
+			},
+		},
+	},
+	{
+		// xml and csv are only keywords at the start of a pipeline stage
+		in: `{xml="foo"} | json csv="bar" | xml="baz"`,
+		exp: &PipelineExpr{
+			Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "xml", Value: "foo"}}),
+			MultiStages: MultiStageExpr{
+				newJSONExpressionParser([]log.LabelExtractionExpr{
+					log.NewLabelExtractionExpr("csv", `bar`),
+				}),
+				&LabelFilterExpr{
+					LabelFilterer: log.NewStringLabelFilter(mustNewMatcher(labels.MatchEqual, "xml", "baz")),
+				},
+			},
+		},
+	},
+	{
+		in: `{app="foo"} | logfmt | lookup customers on customer_id | tier="gold"`,
+		exp: &PipelineExpr{
+			Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+			MultiStages: MultiStageExpr{
+				newLogfmtParserExpr(nil),
+				newLookupExpr("customers", "customer_id"),
+				&LabelFilterExpr{
+					LabelFilterer: log.NewStringLabelFilter(mustNewMatcher(labels.MatchEqual, "tier", "gold")),
+				},
+			},
+		},
+	},
+	{
+		in: `{app="foo"} | dedup`,
+		exp: &PipelineExpr{
+			Left:        newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+			MultiStages: MultiStageExpr{newDedupExpr(nil, 0)},
+		},
+	},
+	{
+		in: `{app="foo"} | logfmt | dedup by (trace_id, span_id) within 5s | level="error"`,
+		exp: &PipelineExpr{
+			Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+			MultiStages: MultiStageExpr{
+				newLogfmtParserExpr(nil),
+				newDedupExpr([]string{"trace_id", "span_id"}, 5*time.Second),
+				&LabelFilterExpr{
+					LabelFilterer: log.NewStringLabelFilter(mustNewMatcher(labels.MatchEqual, "level", "error")),
+				},
+			},
+		},
+	},
+	{
+		in: `count_over_time({app="foo"} | dedup within 1m [5m])`,
+		exp: newRangeAggregationExpr(
+			newLogRange(newPipelineExpr(
+				newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+				MultiStageExpr{newDedupExpr(nil, time.Minute)},
+			), 5*time.Minute, nil, nil),
+			OpRangeTypeCount, nil, nil,
+		),
+	},
+	{
+		in:  `{app="foo"} | dedup within -5s`,
+		err: logqlmodel.NewParseError("invalid dedup window -5s: the window must not be negative", 0, 0),
+	},
+	{
+		in:  `{app="foo"} | dedup by ()`,
+		err: logqlmodel.NewParseError("syntax error: unexpected ), expecting IDENTIFIER", 1, 25),
+	},
+	{
+		in: `{app="foo"} | json.status >= 500 | logfmt.level = "error" | json`,
+		exp: &PipelineExpr{
+			Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+			MultiStages: MultiStageExpr{
+				newFieldFilterExpr(OpParserTypeJSON, nil, log.NewNumericLabelFilter(log.LabelFilterGreaterThanOrEqual, "status", 500)),
+				newFieldFilterExpr(OpParserTypeLogfmt, nil, log.NewStringLabelFilter(mustNewMatcher(labels.MatchEqual, "level", "error"))),
+				newLabelParserExpr(OpParserTypeJSON, ""),
+			},
+		},
+	},
+	{
+		in: `count_over_time({app="foo"} | json.response.took > 1s [5m])`,
+		exp: newRangeAggregationExpr(
+			newLogRange(newPipelineExpr(
+				newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+				MultiStageExpr{newFieldFilterExpr(OpParserTypeJSON, []string{"response"}, log.NewDurationLabelFilter(log.LabelFilterGreaterThan, "took", time.Second))},
+			), 5*time.Minute, nil, nil),
+			OpRangeTypeCount, nil, nil,
+		),
+	},
+	{
+		in:  `{app="foo"} | logfmt.response.status >= 500`,
+		err: logqlmodel.NewParseError("logfmt fields can not be nested: [response status]", 0, 0),
+	},
+	{
+		in: `{app="foo"} | metadata.trace_id = "abc" | metadata != "bar"`,
+		exp: &PipelineExpr{
+			Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+			MultiStages: MultiStageExpr{
+				newFieldFilterExpr(OpFieldFilterMetadata, nil, log.NewStringLabelFilter(mustNewMatcher(labels.MatchEqual, "trace_id", "abc"))),
+				newLabelFilterExpr(log.NewStringLabelFilter(mustNewMatcher(labels.MatchNotEqual, "metadata", "bar"))),
+			},
+		},
+	},
+	{
+		in:  `{app="foo"} | metadata.trace.id = "abc"`,
+		err: logqlmodel.NewParseError("structured metadata fields can not be nested: [trace id]", 0, 0),
+	},
+	{
+		in:  `{app="foo"} | json.status >= 500 or json.status < 200`,
+		err: logqlmodel.NewParseError("syntax error: unexpected json", 1, 37),
+	},
+	{
+		in:  `{app="foo"} | lookup "customers" on customer_id`,
+		err: logqlmodel.NewParseError("syntax error: unexpected STRING, expecting IDENTIFIER", 1, 22),
+	},
+	{
+		in: `{app="api"} | logfmt join on (trace_id) [5m] {app="db"} |= "error"`,
+		exp: &JoinExpr{
+			Left: &PipelineExpr{
+				Left:        newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "api"}}),
+				MultiStages: MultiStageExpr{newLogfmtParserExpr(nil)},
+			},
+			Operation: OpJoin,
+			On:        []string{"trace_id"},
+			Window:    5 * time.Minute,
+			Right: newPipelineExpr(
+				newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "db"}}),
+				MultiStageExpr{newLineFilterExpr(log.LineMatchEqual, "", "error")},
+			),
+		},
+	},
+	{
+		in: `{app="api"} left_join on (trace_id, span_id) [30s] {app="db"}`,
+		exp: &JoinExpr{
+			Left:      newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "api"}}),
+			Operation: OpLeftJoin,
+			On:        []string{"trace_id", "span_id"},
+			Window:    30 * time.Second,
+			Right:     newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "db"}}),
+		},
+	},
+	{
+		in:  `{app="api"} join on (trace_id) [0s] {app="db"}`,
+		err: logqlmodel.NewParseError("invalid join window 0s: the window must be positive", 0, 0),
+	},
+	{
+		in:  `{app="api"} join on () [5m] {app="db"}`,
+		err: logqlmodel.NewParseError("syntax error: unexpected ), expecting IDENTIFIER", 1, 22),
+	},
+	{
+		in:  `count_over_time({app="api"} join on (trace_id) [5m] {app="db"} [5m])`,
+		err: logqlmodel.NewParseError("syntax error: unexpected RANGE", 0, 64),
+	},
+	{
+		in:  `{app="foo"} | csv "ts,level" separator=";"`,
+		err: logqlmodel.NewParseError("unknown csv parser option: separator", 0, 0),
+	},
+	{
+		in:  `{app="foo"} | csv "ts,level" delimiter=";;"`,
+		err: logqlmodel.NewParseError("invalid csv parser option delimiter: a single character is required", 0, 0),
+	},
+	{
+		in:  `{app="foo"} | csv "ts,level" quote=","`,
+		err: logqlmodel.NewParseError("invalid csv parser: delimiter and quote must be different", 0, 0),
+	},
+	{
+		in:  `{app="foo"} | csv ""`,
+		err: logqlmodel.NewParseError("invalid csv parser: at least one column name must be supplied", 0, 0),
+	},
 	{
 		in: `count_over_time({ foo ="bar" } | json layer7_something_specific="layer7_something_specific" [12m])`,
 		exp: &RangeAggregationExpr{
@@ -3760,3 +4181,28 @@ func TestParseSampleExpr_String(t *testing.T) {
 		require.Equal(t, "{cluster=\"beep\", namespace=\"boop\"} | msg=~`\\w.*`", expr.String())
 	})
 }
+
+func TestParseFieldFilter(t *testing.T) {
+	for _, in := range []string{
+		`json.response.status>=500`,
+		`logfmt.level="error"`,
+		`metadata.trace_id=~"abc.*"`,
+	} {
+		t.Run(in, func(t *testing.T) {
+			expr, err := ParseFieldFilter(in)
+			require.NoError(t, err)
+			require.Equal(t, in, expr.FilterString())
+		})
+	}
+
+	for _, in := range []string{
+		`status>=500`,
+		`json.status>=500 | json`,
+		`{app="foo"} | json.status>=500`,
+	} {
+		t.Run(in, func(t *testing.T) {
+			_, err := ParseFieldFilter(in)
+			require.Error(t, err)
+		})
+	}
+}
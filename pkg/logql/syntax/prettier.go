@@ -126,6 +126,11 @@ func (e *LabelFilterExpr) Pretty(level int) string {
 	return commonPrefixIndent(level, e)
 }
 
+// e.g: | json.status>=500
+func (e *FieldFilterExpr) Pretty(level int) string {
+	return commonPrefixIndent(level, e)
+}
+
 // e.g: | line_format "{{ .label }}"
 func (e *LineFmtExpr) Pretty(level int) string {
 	return commonPrefixIndent(level, e)
@@ -136,6 +141,16 @@ func (e *DecolorizeExpr) Pretty(_ int) string {
 	return e.String()
 }
 
+// e.g: | lookup customers on customer_id
+func (e *LookupExpr) Pretty(_ int) string {
+	return e.String()
+}
+
+// e.g: | dedup by (trace_id) within 5s
+func (e *DedupExpr) Pretty(_ int) string {
+	return e.String()
+}
+
 // e.g: | label_format dst="{{ .src }}"
 func (e *LabelFmtExpr) Pretty(level int) string {
 	return commonPrefixIndent(level, e)
@@ -151,6 +166,16 @@ func (e *LogfmtExpressionParserExpr) Pretty(level int) string {
 	return commonPrefixIndent(level, e)
 }
 
+// e.g: | xml label="expression", another="expression"
+func (e *XMLExpressionParserExpr) Pretty(level int) string {
+	return commonPrefixIndent(level, e)
+}
+
+// e.g: | csv "ts,level,msg" delimiter=";"
+func (e *CSVParserExpr) Pretty(level int) string {
+	return commonPrefixIndent(level, e)
+}
+
 // e.g: sum_over_time({foo="bar"} | logfmt | unwrap bytes_processed [5m])
 func (e *UnwrapExpr) Pretty(level int) string {
 	s := Indent(level)
@@ -180,8 +205,8 @@ func (e *LogRangeExpr) Pretty(level int) string {
 	// TODO: this will put [1m] on the same line, not in new line as people used to now.
 	s = fmt.Sprintf("%s [%s]", s, model.Duration(e.Interval))
 
-	if e.Offset != 0 {
-		oe := OffsetExpr{Offset: e.Offset}
+	if e.Offset != 0 || e.At != nil {
+		oe := OffsetExpr{Offset: e.Offset, At: e.At}
 		s += oe.Pretty(level)
 	}
 
@@ -192,10 +217,17 @@ func (e *LogRangeExpr) Pretty(level int) string {
 // TODO(kavi): why does offset not work in log queries? e.g: `{foo="bar"} offset 1h`? is it bug? or anything else?
 // NOTE: Also offset expression never to be indented. It always goes with its parent expression (usually RangeExpr).
 func (e *OffsetExpr) Pretty(_ int) string {
+	var s string
+	if e.At != nil {
+		s = e.At.String()
+	}
+	if e.Offset == 0 && e.At != nil {
+		return s
+	}
 	// using `model.Duration` as it can format ignoring zero units.
 	// e.g: time.Duration(2 * Hour) -> "2h0m0s"
 	// but model.Duration(2 * Hour) -> "2h"
-	return fmt.Sprintf(" %s %s", OpOffset, model.Duration(e.Offset))
+	return s + fmt.Sprintf(" %s %s", OpOffset, model.Duration(e.Offset))
 }
 
 // e.g: count_over_time({foo="bar"}[5m])
@@ -226,6 +258,34 @@ func (e *RangeAggregationExpr) Pretty(level int) string {
 	return s
 }
 
+// e.g: max_over_time(rate({foo="bar"}[5m])[1h:1m])
+func (e *SubqueryExpr) Pretty(level int) string {
+	s := Indent(level)
+	if !NeedSplit(e) {
+		return s + e.String()
+	}
+
+	s += e.Operation
+
+	s += "(\n"
+
+	if e.Params != nil {
+		s = fmt.Sprintf("%s%s%s,", s, Indent(level+1), fmt.Sprint(*e.Params))
+		s += "\n"
+	}
+
+	s += e.Left.Pretty(level + 1)
+	s += e.rangeString()
+	if e.Offset != 0 || e.At != nil {
+		oe := OffsetExpr{Offset: e.Offset, At: e.At}
+		s += oe.Pretty(level)
+	}
+
+	s += "\n" + Indent(level) + ")"
+
+	return s
+}
+
 // e.g:
 // sum(count_over_time({foo="bar"}[5m])) by (container)
 // topk(10, count_over_time({foo="bar"}[5m])) by (container)
@@ -251,6 +311,9 @@ func (e *VectorAggregationExpr) Pretty(level int) string {
 	case OpTypeBottomK, OpTypeTopK:
 		params = []string{fmt.Sprintf("%s%d", Indent(level+1), e.Params), left}
 
+	case OpTypeCountValues:
+		params = []string{Indent(level+1) + strconv.Quote(e.Label), left}
+
 	default:
 		if e.Params != 0 {
 			params = []string{fmt.Sprintf("%s%d", Indent(level+1), e.Params), left}
@@ -338,6 +401,65 @@ func (e *LabelReplaceExpr) Pretty(level int) string {
 	return s
 }
 
+// e.g: label_join(rate({job="api-server"}[5m]), "foo", ",", "cluster", "namespace")
+func (e *LabelJoinExpr) Pretty(level int) string {
+	s := Indent(level)
+
+	if !NeedSplit(e) {
+		return s + e.String()
+	}
+
+	s += OpLabelJoin
+
+	s += "(\n"
+
+	params := []string{
+		e.Left.Pretty(level + 1),
+		Indent(level+1) + strconv.Quote(e.Dst),
+		Indent(level+1) + strconv.Quote(e.Separator),
+	}
+	for _, src := range e.Src {
+		params = append(params, Indent(level+1)+strconv.Quote(src))
+	}
+
+	for i, v := range params {
+		s += v
+		// LogQL doesn't allow `,` at the end of last argument.
+		if i < len(params)-1 {
+			s += ","
+		}
+		s += "\n"
+	}
+
+	s += Indent(level) + ")"
+
+	return s
+}
+
+// e.g: clamp_min(sum by (cluster) (rate({job="api-server"}[5m])), 1)
+func (e *FunctionExpr) Pretty(level int) string {
+	s := Indent(level)
+
+	if e.Left == nil || !NeedSplit(e) {
+		return s + e.String()
+	}
+
+	s += e.Function + "(\n"
+	if e.Function == OpFuncHistogramQuantile && e.Param != nil {
+		s += Indent(level+1) + strconv.FormatFloat(*e.Param, 'f', -1, 64) + ",\n"
+		s += e.Left.Pretty(level + 1)
+		s += "\n" + Indent(level) + ")"
+		return s
+	}
+	s += e.Left.Pretty(level + 1)
+	if e.Param != nil {
+		s += ",\n" + Indent(level+1) + strconv.FormatFloat(*e.Param, 'f', -1, 64)
+	}
+	s += "\n" + Indent(level) + ")"
+
+	return s
+}
+
 // e.g: vector(5)
 func (e *VectorExpr) Pretty(level int) string {
 	return commonPrefixIndent(level, e)
@@ -420,3 +542,13 @@ func formatBinaryOp(op string, opts *BinOpOptions) string {
 	}
 	return op
 }
+
+// e.g: {app="api"} | logfmt
+// join on (trace_id) [5m]
+// {app="db"} | logfmt
+func (e *JoinExpr) Pretty(level int) string {
+	s := e.Left.Pretty(level)
+	s += "\n" + Indent(level) + e.joinString() + "\n"
+	s += e.Right.Pretty(level)
+	return s
+}
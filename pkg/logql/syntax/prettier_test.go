@@ -64,6 +64,14 @@ func TestFormat(t *testing.T) {
 			exp: `count_over_time(
   {job="loki", instance="localhost"}
     |= "error" [5m] offset 20m
+)`,
+		},
+		{
+			name: "aggregation_with_at_modifier",
+			in:   `count_over_time({job="loki", instance="localhost"}|= "error"[5m] @ 1609746000 offset 20m)`,
+			exp: `count_over_time(
+  {job="loki", instance="localhost"}
+    |= "error" [5m] @ 1609746000 offset 20m
 )`,
 		},
 		{
@@ -200,6 +208,183 @@ func TestFormat_LabelReplace(t *testing.T) {
 	}
 }
 
+func TestFormat_Subquery(t *testing.T) {
+	MaxCharsPerLine = 20
+
+	cases := []struct {
+		name string
+		in   string
+		exp  string
+	}{
+		{
+			name: "subquery",
+			in:   `max_over_time(rate({job="api-server",service="a:c"}|= "err" [5m])[1h:1m] offset 5m)`,
+			exp: `max_over_time(
+  rate(
+    {job="api-server", service="a:c"}
+      |= "err" [5m]
+  )[1h:1m] offset 5m
+)`,
+		},
+		{
+			name: "subquery_with_param",
+			in:   `quantile_over_time(0.9, sum(rate({job="api-server"}[5m]))[1h:])`,
+			exp: `quantile_over_time(
+  0.9,
+  sum(
+    rate(
+      {job="api-server"} [5m]
+    )
+  )[1h:]
+)`,
+		},
+		{
+			name: "subquery_with_at_modifier",
+			in:   `max_over_time(rate({job="api-server"}[5m])[1h:1m] @ end() offset 5m)`,
+			exp: `max_over_time(
+  rate(
+    {job="api-server"} [5m]
+  )[1h:1m] @ end() offset 5m
+)`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExpr(c.in)
+			require.NoError(t, err)
+			got := Prettify(expr)
+			assert.Equal(t, c.exp, got)
+		})
+	}
+}
+
+func TestFormat_Function(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		exp  string
+	}{
+		{
+			name: "function",
+			in:   `clamp_min(sum by (cluster) (rate({job="api-server"}[5m])), -1)`,
+			exp: `clamp_min(
+  sum by (cluster)(
+    rate(
+      {job="api-server"} [5m]
+    )
+  ),
+  -1
+)`,
+		},
+		{
+			name: "function_without_argument",
+			in:   `hour()`,
+			exp:  `hour()`,
+		},
+		{
+			name: "histogram_quantile",
+			in:   `histogram_quantile(0.99, sum by (le) (rate({job="api-server"} | unwrap count [5m])))`,
+			exp: `histogram_quantile(
+  0.99,
+  sum by (le)(
+    rate(
+      {job="api-server"}
+        | unwrap count [5m]
+    )
+  )
+)`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExpr(c.in)
+			require.NoError(t, err)
+			got := Prettify(expr)
+			assert.Equal(t, c.exp, got)
+		})
+	}
+}
+
+func TestFormat_LabelJoin(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		exp  string
+	}{
+		{
+			name: "label_join",
+			in:   `label_join(rate({job="api-server",service="a:c"}|= "err" [5m]), "foo", ",", "job", "service")`,
+			exp: `label_join(
+  rate(
+    {job="api-server", service="a:c"}
+      |= "err" [5m]
+  ),
+  "foo",
+  ",",
+  "job",
+  "service"
+)`,
+		},
+		{
+			name: "count_values",
+			in:   `count_values("value", sum by (cluster) (rate({job="api-server",service="a:c"}|= "err" [5m])))`,
+			exp: `count_values(
+  "value",
+  sum by (cluster)(
+    rate(
+      {job="api-server", service="a:c"}
+        |= "err" [5m]
+    )
+  )
+)`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExpr(c.in)
+			require.NoError(t, err)
+			got := Prettify(expr)
+			assert.Equal(t, c.exp, got)
+		})
+	}
+}
+
+func TestFormat_Join(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		exp  string
+	}{
+		{
+			name: "join",
+			in:   `{app="api"} | logfmt join on (trace_id) [5m] {app="db"} |= "error"`,
+			exp: `{app="api"} | logfmt
+join on (trace_id) [5m]
+{app="db"}
+  |= "error"`,
+		},
+		{
+			name: "left_join",
+			in:   `{app="api"} left_join on (trace_id, span_id) [30s] {app="db"}`,
+			exp: `{app="api"}
+left_join on (trace_id, span_id) [30s]
+{app="db"}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExpr(c.in)
+			require.NoError(t, err)
+			got := Prettify(expr)
+			assert.Equal(t, c.exp, got)
+		})
+	}
+}
+
 func TestFormat_BinOp(t *testing.T) {
 	MaxCharsPerLine = 20
 
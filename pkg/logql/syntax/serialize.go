@@ -35,9 +35,11 @@ const (
 	Binary              = "binary"
 	Bytes               = "bytes"
 	And                 = "and"
+	At                  = "at"
 	Card                = "cardinality"
 	Dst                 = "dst"
 	Duration            = "duration"
+	Function            = "function"
 	Groups              = "groups"
 	GroupingField       = "grouping"
 	Include             = "include"
@@ -45,7 +47,9 @@ const (
 	Inner               = "inner"
 	IntervalNanos       = "interval_nanos"
 	IPField             = "ip"
+	Join                = "join"
 	Label               = "label"
+	LabelJoin           = "label_join"
 	LabelReplace        = "label_replace"
 	LHS                 = "lhs"
 	Literal             = "literal"
@@ -65,10 +69,15 @@ const (
 	Raw                 = "raw"
 	RegexField          = "regex"
 	Replacement         = "replacement"
+	Separator           = "separator"
 	ReturnBool          = "return_bool"
 	RHS                 = "rhs"
 	Src                 = "src"
+	StartOrEnd          = "start_or_end"
+	StepNanos           = "step_nanos"
 	StringField         = "string"
+	Subquery            = "subquery"
+	TimestampMs         = "timestamp_ms"
 	NoopField           = "noop"
 	Type                = "type"
 	Unwrap              = "unwrap"
@@ -79,6 +88,7 @@ const (
 	Without             = "without"
 	Variants            = "variants"
 	Of                  = "of"
+	WindowNanos         = "window_nanos"
 )
 
 func DecodeJSON(raw string) (Expr, error) {
@@ -98,10 +108,18 @@ func DecodeJSON(raw string) (Expr, error) {
 		return decodeVector(iter)
 	case LabelReplace:
 		return decodeLabelReplace(iter)
+	case LabelJoin:
+		return decodeLabelJoin(iter)
+	case Function:
+		return decodeFunction(iter)
+	case Subquery:
+		return decodeSubquery(iter)
 	case LogSelector:
 		return decodeLogSelector(iter)
 	case Variants:
 		return decodeVariants(iter)
+	case Join:
+		return decodeJoin(iter)
 	default:
 		return nil, fmt.Errorf("unknown expression type: %s", key)
 	}
@@ -163,6 +181,12 @@ func (v *JSONSerializer) VisitVectorAggregation(e *VectorAggregationExpr) {
 	v.WriteObjectField(Op)
 	v.WriteString(e.Operation)
 
+	if e.Label != "" {
+		v.WriteMore()
+		v.WriteObjectField(Label)
+		v.WriteString(e.Label)
+	}
+
 	if e.Grouping != nil {
 		v.WriteMore()
 		v.WriteObjectField(GroupingField)
@@ -208,6 +232,44 @@ func (v *JSONSerializer) VisitRangeAggregation(e *RangeAggregationExpr) {
 	v.Flush()
 }
 
+func (v *JSONSerializer) VisitJoin(e *JoinExpr) {
+	v.WriteObjectStart()
+
+	v.WriteObjectField(Join)
+	v.WriteObjectStart()
+
+	v.WriteObjectField(Op)
+	v.WriteString(e.Operation)
+
+	v.WriteMore()
+	v.WriteObjectField(On)
+	v.WriteArrayStart()
+	for i, label := range e.On {
+		if i > 0 {
+			v.WriteMore()
+		}
+		v.WriteString(label)
+	}
+	v.WriteArrayEnd()
+
+	v.WriteMore()
+	v.WriteObjectField(WindowNanos)
+	v.WriteInt64(int64(e.Window))
+
+	// Serialize both log selectors as string.
+	v.WriteMore()
+	v.WriteObjectField(LHS)
+	encodeLogSelector(v.Stream, e.Left)
+
+	v.WriteMore()
+	v.WriteObjectField(RHS)
+	encodeLogSelector(v.Stream, e.Right)
+
+	v.WriteObjectEnd()
+	v.WriteObjectEnd()
+	v.Flush()
+}
+
 func (v *JSONSerializer) VisitLogRange(e *LogRangeExpr) {
 	v.WriteObjectStart()
 
@@ -217,6 +279,12 @@ func (v *JSONSerializer) VisitLogRange(e *LogRangeExpr) {
 	v.WriteObjectField(OffsetNanos)
 	v.WriteInt64(int64(e.Offset))
 
+	if e.At != nil {
+		v.WriteMore()
+		v.WriteObjectField(At)
+		encodeAtModifier(v.Stream, e.At)
+	}
+
 	// Serialize log selector pipeline as string.
 	v.WriteMore()
 	v.WriteObjectField(LogSelector)
@@ -232,6 +300,48 @@ func (v *JSONSerializer) VisitLogRange(e *LogRangeExpr) {
 	v.Flush()
 }
 
+func (v *JSONSerializer) VisitSubquery(e *SubqueryExpr) {
+	v.WriteObjectStart()
+
+	v.WriteObjectField(Subquery)
+	v.WriteObjectStart()
+
+	v.WriteObjectField(Op)
+	v.WriteString(e.Operation)
+
+	if e.Params != nil {
+		v.WriteMore()
+		v.WriteObjectField(Params)
+		v.WriteFloat64(*e.Params)
+	}
+
+	v.WriteMore()
+	v.WriteObjectField(IntervalNanos)
+	v.WriteInt64(int64(e.Range))
+
+	v.WriteMore()
+	v.WriteObjectField(StepNanos)
+	v.WriteInt64(int64(e.Step))
+
+	v.WriteMore()
+	v.WriteObjectField(OffsetNanos)
+	v.WriteInt64(int64(e.Offset))
+
+	if e.At != nil {
+		v.WriteMore()
+		v.WriteObjectField(At)
+		encodeAtModifier(v.Stream, e.At)
+	}
+
+	v.WriteMore()
+	v.WriteObjectField(Inner)
+	e.Left.Accept(v)
+
+	v.WriteObjectEnd()
+	v.WriteObjectEnd()
+	v.Flush()
+}
+
 func (v *JSONSerializer) VisitLabelReplace(e *LabelReplaceExpr) {
 	v.WriteObjectStart()
 
@@ -262,6 +372,65 @@ func (v *JSONSerializer) VisitLabelReplace(e *LabelReplaceExpr) {
 	v.Flush()
 }
 
+func (v *JSONSerializer) VisitLabelJoin(e *LabelJoinExpr) {
+	v.WriteObjectStart()
+
+	v.WriteObjectField(LabelJoin)
+	v.WriteObjectStart()
+
+	v.WriteObjectField(Inner)
+	e.Left.Accept(v)
+
+	v.WriteMore()
+	v.WriteObjectField(Dst)
+	v.WriteString(e.Dst)
+
+	v.WriteMore()
+	v.WriteObjectField(Separator)
+	v.WriteString(e.Separator)
+
+	v.WriteMore()
+	v.WriteObjectField(Src)
+	v.WriteArrayStart()
+	for i, src := range e.Src {
+		if i > 0 {
+			v.WriteMore()
+		}
+		v.WriteString(src)
+	}
+	v.WriteArrayEnd()
+
+	v.WriteObjectEnd()
+	v.WriteObjectEnd()
+	v.Flush()
+}
+
+func (v *JSONSerializer) VisitFunction(e *FunctionExpr) {
+	v.WriteObjectStart()
+
+	v.WriteObjectField(Function)
+	v.WriteObjectStart()
+
+	v.WriteObjectField(Op)
+	v.WriteString(e.Function)
+
+	if e.Param != nil {
+		v.WriteMore()
+		v.WriteObjectField(Params)
+		v.WriteFloat64(*e.Param)
+	}
+
+	if e.Left != nil {
+		v.WriteMore()
+		v.WriteObjectField(Inner)
+		e.Left.Accept(v)
+	}
+
+	v.WriteObjectEnd()
+	v.WriteObjectEnd()
+	v.Flush()
+}
+
 func (v *JSONSerializer) VisitLiteral(e *LiteralExpr) {
 	v.WriteObjectStart()
 
@@ -338,6 +507,9 @@ func (v *JSONSerializer) VisitVariants(e *MultiVariantExpr) {
 // Below are StageExpr visitors that we are skipping since a pipeline is
 // serialized as a string.
 func (*JSONSerializer) VisitDecolorize(*DecolorizeExpr)                         {}
+func (*JSONSerializer) VisitLookup(*LookupExpr)                                 {}
+func (*JSONSerializer) VisitDedup(*DedupExpr)                                   {}
+func (*JSONSerializer) VisitFieldFilter(*FieldFilterExpr)                       {}
 func (*JSONSerializer) VisitDropLabels(*DropLabelsExpr)                         {}
 func (*JSONSerializer) VisitJSONExpressionParser(*JSONExpressionParserExpr)     {}
 func (*JSONSerializer) VisitKeepLabel(*KeepLabelsExpr)                          {}
@@ -348,6 +520,8 @@ func (*JSONSerializer) VisitLineFilter(*LineFilterExpr)
 func (*JSONSerializer) VisitLineFmt(*LineFmtExpr)                               {}
 func (*JSONSerializer) VisitLogfmtExpressionParser(*LogfmtExpressionParserExpr) {}
 func (*JSONSerializer) VisitLogfmtParser(*LogfmtParserExpr)                     {}
+func (*JSONSerializer) VisitXMLExpressionParser(*XMLExpressionParserExpr)       {}
+func (*JSONSerializer) VisitCSVParser(*CSVParserExpr)                           {}
 
 func encodeGrouping(s *jsoniter.Stream, g *Grouping) {
 	s.WriteObjectStart()
@@ -407,6 +581,34 @@ func encodeUnwrap(s *jsoniter.Stream, u *UnwrapExpr) {
 	s.WriteObjectEnd()
 }
 
+func encodeAtModifier(s *jsoniter.Stream, a *AtModifier) {
+	s.WriteObjectStart()
+	s.WriteObjectField(TimestampMs)
+	s.WriteInt64(a.Timestamp)
+
+	if a.StartOrEnd != "" {
+		s.WriteMore()
+		s.WriteObjectField(StartOrEnd)
+		s.WriteString(a.StartOrEnd)
+	}
+
+	s.WriteObjectEnd()
+}
+
+func decodeAtModifier(iter *jsoniter.Iterator) *AtModifier {
+	a := &AtModifier{}
+	for f := iter.ReadObject(); f != ""; f = iter.ReadObject() {
+		switch f {
+		case TimestampMs:
+			a.Timestamp = iter.ReadInt64()
+		case StartOrEnd:
+			a.StartOrEnd = iter.ReadString()
+		}
+	}
+
+	return a
+}
+
 func decodeUnwrap(iter *jsoniter.Iterator) *UnwrapExpr {
 	e := &UnwrapExpr{}
 	for f := iter.ReadObject(); f != ""; f = iter.ReadObject() {
@@ -731,6 +933,36 @@ func decodeLogSelector(iter *jsoniter.Iterator) (LogSelectorExpr, error) {
 	return e, nil
 }
 
+func decodeJoin(iter *jsoniter.Iterator) (*JoinExpr, error) {
+	var err error
+	e := &JoinExpr{}
+
+	for f := iter.ReadObject(); f != ""; f = iter.ReadObject() {
+		switch f {
+		case Op:
+			e.Operation = iter.ReadString()
+		case On:
+			for iter.ReadArray() {
+				e.On = append(e.On, iter.ReadString())
+			}
+		case WindowNanos:
+			e.Window = time.Duration(iter.ReadInt64())
+		case LHS:
+			e.Left, err = decodeLogSelector(iter)
+		case RHS:
+			e.Right, err = decodeLogSelector(iter)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if e.Left == nil || e.Right == nil {
+		return nil, fmt.Errorf("missing log selector in %s", Join)
+	}
+	return e, nil
+}
+
 func decodeSample(iter *jsoniter.Iterator) (SampleExpr, error) {
 	var expr SampleExpr
 	var err error
@@ -748,6 +980,12 @@ func decodeSample(iter *jsoniter.Iterator) (SampleExpr, error) {
 			expr, err = decodeVector(iter)
 		case LabelReplace:
 			expr, err = decodeLabelReplace(iter)
+		case LabelJoin:
+			expr, err = decodeLabelJoin(iter)
+		case Function:
+			expr, err = decodeFunction(iter)
+		case Subquery:
+			expr, err = decodeSubquery(iter)
 		default:
 			return nil, fmt.Errorf("unknown sample expression type: %s", key)
 		}
@@ -858,6 +1096,8 @@ func decodeVectorAgg(iter *jsoniter.Iterator) (*VectorAggregationExpr, error) {
 			expr.Operation = iter.ReadString()
 		case Params:
 			expr.Params = iter.ReadInt()
+		case Label:
+			expr.Label = iter.ReadString()
 		case GroupingField:
 			expr.Grouping, err = decodeGrouping(iter)
 		case Inner:
@@ -901,6 +1141,8 @@ func decodeLogRange(iter *jsoniter.Iterator) (*LogRangeExpr, error) {
 			expr.Interval = time.Duration(iter.ReadInt64())
 		case OffsetNanos:
 			expr.Offset = time.Duration(iter.ReadInt64())
+		case At:
+			expr.At = decodeAtModifier(iter)
 		case Unwrap:
 			expr.Unwrap = decodeUnwrap(iter)
 		}
@@ -935,6 +1177,85 @@ func decodeLabelReplace(iter *jsoniter.Iterator) (*LabelReplaceExpr, error) {
 	return mustNewLabelReplaceExpr(left, dst, replacement, src, regex), nil
 }
 
+func decodeLabelJoin(iter *jsoniter.Iterator) (*LabelJoinExpr, error) {
+	var err error
+	var left SampleExpr
+	var dst, separator string
+	var src []string
+
+	for f := iter.ReadObject(); f != ""; f = iter.ReadObject() {
+		switch f {
+		case Inner:
+			left, err = decodeSample(iter)
+			if err != nil {
+				return nil, err
+			}
+		case Dst:
+			dst = iter.ReadString()
+		case Separator:
+			separator = iter.ReadString()
+		case Src:
+			for iter.ReadArray() {
+				src = append(src, iter.ReadString())
+			}
+		}
+	}
+
+	expr := mustNewLabelJoinExpr(left, dst, separator, src)
+	return expr, expr.err
+}
+
+func decodeFunction(iter *jsoniter.Iterator) (*FunctionExpr, error) {
+	var err error
+	var left SampleExpr
+	var fn string
+	var param *LiteralExpr
+
+	for f := iter.ReadObject(); f != ""; f = iter.ReadObject() {
+		switch f {
+		case Op:
+			fn = iter.ReadString()
+		case Params:
+			param = &LiteralExpr{Val: iter.ReadFloat64()}
+		case Inner:
+			left, err = decodeSample(iter)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	expr := mustNewFunctionExpr(fn, left, param)
+	return expr, expr.err
+}
+
+func decodeSubquery(iter *jsoniter.Iterator) (*SubqueryExpr, error) {
+	expr := &SubqueryExpr{}
+	var err error
+
+	for f := iter.ReadObject(); f != ""; f = iter.ReadObject() {
+		switch f {
+		case Op:
+			expr.Operation = iter.ReadString()
+		case Params:
+			tmp := iter.ReadFloat64()
+			expr.Params = &tmp
+		case IntervalNanos:
+			expr.Range = time.Duration(iter.ReadInt64())
+		case StepNanos:
+			expr.Step = time.Duration(iter.ReadInt64())
+		case OffsetNanos:
+			expr.Offset = time.Duration(iter.ReadInt64())
+		case At:
+			expr.At = decodeAtModifier(iter)
+		case Inner:
+			expr.Left, err = decodeSample(iter)
+		}
+	}
+
+	return expr, err
+}
+
 func decodeLiteral(iter *jsoniter.Iterator) (*LiteralExpr, error) {
 	expr := &LiteralExpr{}
 
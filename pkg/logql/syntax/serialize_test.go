@@ -15,6 +15,9 @@ func TestJSONSerializationRoundTrip(t *testing.T) {
 		"simple matchers": {
 			query: `{env="prod", app=~"loki.*"}`,
 		},
+		"join": {
+			query: `{app="api"} | logfmt left_join on (trace_id, span_id) [5m] {app="db"} |= "error"`,
+		},
 		"simple aggregation": {
 			query: `count_over_time({env="prod", app=~"loki.*"}[5m])`,
 		},
@@ -42,6 +45,15 @@ func TestJSONSerializationRoundTrip(t *testing.T) {
 		"label replace": {
 			query: `label_replace(vector(0.000000),"foo","bar","","")`,
 		},
+		"label join": {
+			query: `label_join(vector(0.000000),"foo",",","bar","baz")`,
+		},
+		"count values": {
+			query: `count_values by (cluster)("value",sum by (cluster)(rate({foo="bar"}[5m])))`,
+		},
+		"xml and csv parsers": {
+			query: `{app="foo"} | xml | xml level="event/@level" | csv "ts,level,,msg" delimiter=";",quote="'"`,
+		},
 		"filters with bytes": {
 			query: `{app="foo"} |= "bar" | json | ( status_code <500 or ( status_code>200 , size>=2.5KiB ) )`,
 		},
@@ -59,6 +71,27 @@ func TestJSONSerializationRoundTrip(t *testing.T) {
 		"empty label filter string": {
 			query: `rate({app="foo"} |= "bar" | json | unwrap latency | path!="" [5m])`,
 		},
+		"subquery": {
+			query: `quantile_over_time(0.99, sum by (app) (rate({foo="bar"}[1m]))[1h:1m] offset 5m)`,
+		},
+		"at modifier": {
+			query: `sum(rate({foo="bar"}[5m] @ 1609746000 offset 1h)) / sum(rate({foo="bar"}[5m] @ end()))`,
+		},
+		"subquery with at modifier": {
+			query: `max_over_time(rate({foo="bar"}[1m])[1h:1m] @ start())`,
+		},
+		"functions": {
+			query: `clamp_max(sum(rate({foo="bar"}[5m])), 10) / scalar(sum(rate({foo="bar"}[5m])))`,
+		},
+		"function without argument": {
+			query: `day_of_week()`,
+		},
+		"field filters": {
+			query: `sum by (level) (count_over_time({foo="bar"} | json.response.status >= 500 | logfmt.level = "error" [5m]))`,
+		},
+		"histogram_quantile": {
+			query: `histogram_quantile(0.99, sum by (le) (sum_over_time({foo="bar"} | unwrap count [5m])))`,
+		},
 		"multiple variants": {
 			query: `variants(bytes_over_time({foo="bar"}[5m]), count_over_time({foo="bar"}[5m])) of ({foo="bar"}[5m])`,
 		},
@@ -43,24 +43,26 @@ import (
   labelExtractionExpressionList []log.LabelExtractionExpr
   unwrapExpr *UnwrapExpr
   offsetExpr *OffsetExpr
+  atModifier *AtModifier
+  subqueryRange subqueryRange
 }
 
 %start root
 
-%type <expr> expr
+%type <expr> expr joinExpr
 %type <logExpr> logExpr
-%type <metricExpr> metricExpr rangeAggregationExpr vectorAggregationExpr binOpExpr labelReplaceExpr vectorExpr
+%type <metricExpr> metricExpr rangeAggregationExpr vectorAggregationExpr binOpExpr labelReplaceExpr labelJoinExpr vectorExpr subqueryExpr functionExpr
 %type <variantsExpr> variantsExpr
-%type <stage> pipelineStage logfmtParser labelParser jsonExpressionParser logfmtExpressionParser lineFormatExpr decolorizeExpr labelFormatExpr dropLabelsExpr keepLabelsExpr
+%type <stage> pipelineStage logfmtParser labelParser jsonExpressionParser logfmtExpressionParser xmlExpressionParser csvParser lineFormatExpr decolorizeExpr lookupExpr dedupExpr labelFormatExpr dropLabelsExpr keepLabelsExpr fieldFilterExpr
 %type <stages> pipelineExpr
 %type <lineFilterExpr> lineFilter lineFilters orFilter
-%type <op> rangeOp convOp vectorOp filterOp
-%type <filterer> bytesFilter numberFilter durationFilter labelFilter unitFilter ipLabelFilter
+%type <op> rangeOp quantileOp convOp vectorOp filterOp functionOp fieldFilterParser
+%type <filterer> bytesFilter numberFilter durationFilter labelFilter unitFilter ipLabelFilter fieldFilter
 %type <filter> filter
 %type <matcher> matcher
 %type <matchers> matchers selector
 %type <str> vector
-%type <strs> labels parserFlags
+%type <strs> labels parserFlags labelJoinSources fieldPath
 %type <binOpts> binOpModifier boolModifier onOrIgnoringModifier
 %type <namedMatcher> namedMatcher
 %type <namedMatchers> namedMatchers
@@ -73,18 +75,25 @@ import (
 %type <labelExtractionExpressionList> labelExtractionExpressionList
 %type <unwrapExpr> unwrapExpr
 %type <offsetExpr> offsetExpr
+%type <atModifier> atModifier
 %type <metricExprs> metricExprs
 
 %token <bytes> BYTES
 %token <str> IDENTIFIER STRING NUMBER FUNCTION_FLAG
 %token <dur> DURATION RANGE
+%token <subqueryRange> SUBQUERY_RANGE
 %token <val> MATCHERS LABELS EQ RE NRE NPA OPEN_BRACE CLOSE_BRACE OPEN_BRACKET CLOSE_BRACKET COMMA DOT PIPE_MATCH PIPE_EXACT PIPE_PATTERN
              OPEN_PARENTHESIS CLOSE_PARENTHESIS BY WITHOUT COUNT_OVER_TIME RATE RATE_COUNTER SUM SORT SORT_DESC AVG
              MAX MIN COUNT STDDEV STDVAR BOTTOMK TOPK APPROX_TOPK
              BYTES_OVER_TIME BYTES_RATE BOOL JSON REGEXP LOGFMT PIPE LINE_FMT LABEL_FMT UNWRAP AVG_OVER_TIME SUM_OVER_TIME MIN_OVER_TIME
              MAX_OVER_TIME STDVAR_OVER_TIME STDDEV_OVER_TIME QUANTILE_OVER_TIME BYTES_CONV DURATION_CONV DURATION_SECONDS_CONV
-             FIRST_OVER_TIME LAST_OVER_TIME ABSENT_OVER_TIME VECTOR LABEL_REPLACE UNPACK OFFSET PATTERN IP ON IGNORING GROUP_LEFT GROUP_RIGHT
-             DECOLORIZE DROP KEEP VARIANTS OF
+             FIRST_OVER_TIME LAST_OVER_TIME ABSENT_OVER_TIME PATTERN_COUNT_OVER_TIME VECTOR LABEL_REPLACE UNPACK OFFSET PATTERN IP ON IGNORING GROUP_LEFT GROUP_RIGHT
+             DECOLORIZE DROP KEEP VARIANTS OF AT START END ABS CEIL FLOOR ROUND SQRT EXP LN CLAMP_MIN CLAMP_MAX SCALAR
+             TIMESTAMP HOUR DAY_OF_WEEK DAY_OF_MONTH COUNT_VALUES LABEL_JOIN XML CSV LOOKUP JOIN LEFT_JOIN DEDUP WITHIN
+             HISTOGRAM_QUANTILE METADATA
+
+// START_FIELD_FILTER parses a field filter from the root instead of an expression, see lexer.start.
+%token <val> START_FIELD_FILTER
 
 // Operators are listed with increasing precedence.
 %left <binOp> OR
@@ -97,12 +106,15 @@ import (
 %%
 
 root:
-    expr { syntaxlex.(*parser).expr = $1 };
+      expr                                 { syntaxlex.(*parser).expr = $1 }
+    | START_FIELD_FILTER fieldFilterExpr   { syntaxlex.(*parser).expr = $2 }
+    ;
 
 expr:
       logExpr { $$ = $1 }
     | metricExpr { $$ = $1 }
     | variantsExpr { $$ = $1 }
+    | joinExpr { $$ = $1 }
     ;
 
 logExpr:
@@ -111,13 +123,21 @@ logExpr:
     | OPEN_PARENTHESIS logExpr CLOSE_PARENTHESIS { $$ = $2 }
     ;
 
+joinExpr:
+      logExpr JOIN ON OPEN_PARENTHESIS labels CLOSE_PARENTHESIS RANGE logExpr      { $$ = newJoinExpr($1, OpJoin, $5, $7, $8) }
+    | logExpr LEFT_JOIN ON OPEN_PARENTHESIS labels CLOSE_PARENTHESIS RANGE logExpr { $$ = newJoinExpr($1, OpLeftJoin, $5, $7, $8) }
+    ;
+
 metricExpr:
       rangeAggregationExpr                          { $$ = $1 }
     | vectorAggregationExpr                         { $$ = $1 }
     | binOpExpr                                     { $$ = $1 }
     | literalExpr                                   { $$ = $1 }
     | labelReplaceExpr                              { $$ = $1 }
+    | labelJoinExpr                                 { $$ = $1 }
     | vectorExpr                                    { $$ = $1 }
+    | subqueryExpr                                  { $$ = $1 }
+    | functionExpr                                  { $$ = $1 }
     | OPEN_PARENTHESIS metricExpr CLOSE_PARENTHESIS { $$ = $2 }
     ;
 
@@ -171,6 +191,21 @@ rangeAggregationExpr:
     | rangeOp OPEN_PARENTHESIS NUMBER COMMA logRangeExpr CLOSE_PARENTHESIS           { $$ = newRangeAggregationExpr($5, $1, nil, &$3) }
     | rangeOp OPEN_PARENTHESIS logRangeExpr CLOSE_PARENTHESIS grouping               { $$ = newRangeAggregationExpr($3, $1, $5, nil) }
     | rangeOp OPEN_PARENTHESIS NUMBER COMMA logRangeExpr CLOSE_PARENTHESIS grouping  { $$ = newRangeAggregationExpr($5, $1, $7, &$3) }
+    | quantileOp OPEN_PARENTHESIS logRangeExpr CLOSE_PARENTHESIS                       { $$ = newRangeAggregationExpr($3, $1, nil, nil) }
+    | quantileOp OPEN_PARENTHESIS NUMBER COMMA logRangeExpr CLOSE_PARENTHESIS          { $$ = newRangeAggregationExpr($5, $1, nil, &$3) }
+    | quantileOp OPEN_PARENTHESIS logRangeExpr CLOSE_PARENTHESIS grouping              { $$ = newRangeAggregationExpr($3, $1, $5, nil) }
+    | quantileOp OPEN_PARENTHESIS NUMBER COMMA logRangeExpr CLOSE_PARENTHESIS grouping { $$ = newRangeAggregationExpr($5, $1, $7, &$3) }
+    ;
+
+subqueryExpr:
+      rangeOp OPEN_PARENTHESIS metricExpr SUBQUERY_RANGE CLOSE_PARENTHESIS                              { $$ = newSubqueryExpr($3, $1, $4, nil, nil) }
+    | rangeOp OPEN_PARENTHESIS metricExpr SUBQUERY_RANGE offsetExpr CLOSE_PARENTHESIS                   { $$ = newSubqueryExpr($3, $1, $4, $5, nil) }
+    | rangeOp OPEN_PARENTHESIS NUMBER COMMA metricExpr SUBQUERY_RANGE CLOSE_PARENTHESIS                 { $$ = newSubqueryExpr($5, $1, $6, nil, &$3) }
+    | rangeOp OPEN_PARENTHESIS NUMBER COMMA metricExpr SUBQUERY_RANGE offsetExpr CLOSE_PARENTHESIS      { $$ = newSubqueryExpr($5, $1, $6, $7, &$3) }
+    // The parameter of quantile_over_time is required by the grammar, so the inner query of a
+    // subquery doesn't make the parser accept any metric expression after the parenthesis.
+    | quantileOp OPEN_PARENTHESIS NUMBER COMMA metricExpr SUBQUERY_RANGE CLOSE_PARENTHESIS              { $$ = newSubqueryExpr($5, $1, $6, nil, &$3) }
+    | quantileOp OPEN_PARENTHESIS NUMBER COMMA metricExpr SUBQUERY_RANGE offsetExpr CLOSE_PARENTHESIS   { $$ = newSubqueryExpr($5, $1, $6, $7, &$3) }
     ;
 
 vectorAggregationExpr:
@@ -182,6 +217,10 @@ vectorAggregationExpr:
     | vectorOp OPEN_PARENTHESIS NUMBER COMMA metricExpr CLOSE_PARENTHESIS                 { $$ = mustNewVectorAggregationExpr($5, $1, nil, &$3) }
     | vectorOp OPEN_PARENTHESIS NUMBER COMMA metricExpr CLOSE_PARENTHESIS grouping        { $$ = mustNewVectorAggregationExpr($5, $1, $7, &$3) }
     | vectorOp grouping OPEN_PARENTHESIS NUMBER COMMA metricExpr CLOSE_PARENTHESIS        { $$ = mustNewVectorAggregationExpr($6, $1, $2, &$4) }
+    // count_values takes the name of the output label as first argument.
+    | COUNT_VALUES OPEN_PARENTHESIS STRING COMMA metricExpr CLOSE_PARENTHESIS             { $$ = mustNewCountValuesExpr($5, $3, nil) }
+    | COUNT_VALUES OPEN_PARENTHESIS STRING COMMA metricExpr CLOSE_PARENTHESIS grouping    { $$ = mustNewCountValuesExpr($5, $3, $7) }
+    | COUNT_VALUES grouping OPEN_PARENTHESIS STRING COMMA metricExpr CLOSE_PARENTHESIS    { $$ = mustNewCountValuesExpr($6, $4, $2) }
     ;
 
 labelReplaceExpr:
@@ -189,6 +228,23 @@ labelReplaceExpr:
       { $$ = mustNewLabelReplaceExpr($3, $5, $7, $9, $11)}
     ;
 
+labelJoinExpr:
+      LABEL_JOIN OPEN_PARENTHESIS metricExpr COMMA STRING COMMA STRING CLOSE_PARENTHESIS                        { $$ = mustNewLabelJoinExpr($3, $5, $7, nil) }
+    | LABEL_JOIN OPEN_PARENTHESIS metricExpr COMMA STRING COMMA STRING COMMA labelJoinSources CLOSE_PARENTHESIS { $$ = mustNewLabelJoinExpr($3, $5, $7, $9) }
+    ;
+
+labelJoinSources:
+      STRING                          { $$ = []string{ $1 } }
+    | labelJoinSources COMMA STRING   { $$ = append($1, $3) }
+    ;
+
+functionExpr:
+      functionOp OPEN_PARENTHESIS CLOSE_PARENTHESIS                                    { $$ = mustNewFunctionExpr($1, nil, nil) }
+    | functionOp OPEN_PARENTHESIS metricExpr CLOSE_PARENTHESIS                         { $$ = mustNewFunctionExpr($1, $3, nil) }
+    | functionOp OPEN_PARENTHESIS metricExpr COMMA literalExpr CLOSE_PARENTHESIS       { $$ = mustNewFunctionExpr($1, $3, $5) }
+    | HISTOGRAM_QUANTILE OPEN_PARENTHESIS literalExpr COMMA metricExpr CLOSE_PARENTHESIS { $$ = mustNewFunctionExpr(OpFuncHistogramQuantile, $5, $3) }
+    ;
+
 selector:
       OPEN_BRACE matchers CLOSE_BRACE  { $$ = $2 }
     | OPEN_BRACE matchers error        { $$ = $2 }
@@ -218,9 +274,14 @@ pipelineStage:
   | PIPE labelParser             { $$ = $2 }
   | PIPE jsonExpressionParser    { $$ = $2 }
   | PIPE logfmtExpressionParser  { $$ = $2 }
+  | PIPE xmlExpressionParser     { $$ = $2 }
+  | PIPE csvParser               { $$ = $2 }
   | PIPE labelFilter             { $$ = &LabelFilterExpr{LabelFilterer: $2 }}
+  | PIPE fieldFilterExpr         { $$ = $2 }
   | PIPE lineFormatExpr          { $$ = $2 }
   | PIPE decolorizeExpr          { $$ = $2 }
+  | PIPE lookupExpr              { $$ = $2 }
+  | PIPE dedupExpr               { $$ = $2 }
   | PIPE labelFormatExpr         { $$ = $2 }
   | PIPE dropLabelsExpr          { $$ = $2 }
   | PIPE keepLabelsExpr          { $$ = $2 }
@@ -271,6 +332,7 @@ labelParser:
   | REGEXP STRING       { $$ = newLabelParserExpr(OpParserTypeRegexp, $2) }
   | UNPACK              { $$ = newLabelParserExpr(OpParserTypeUnpack, "") }
   | PATTERN STRING      { $$ = newLabelParserExpr(OpParserTypePattern, $2) }
+  | XML                 { $$ = newLabelParserExpr(OpParserTypeXML, "") }
   ;
 
 jsonExpressionParser:
@@ -281,10 +343,28 @@ logfmtExpressionParser:
   | LOGFMT labelExtractionExpressionList              { $$ = newLogfmtExpressionParser($2, nil)}
   ;
 
+xmlExpressionParser:
+    XML labelExtractionExpressionList { $$ = newXMLExpressionParser($2) }
+  ;
+
+csvParser:
+    CSV STRING                               { $$ = newCSVParserExpr($2, nil) }
+  | CSV STRING labelExtractionExpressionList { $$ = newCSVParserExpr($2, $3) }
+  ;
+
 lineFormatExpr: LINE_FMT STRING { $$ = newLineFmtExpr($2) };
 
 decolorizeExpr: DECOLORIZE { $$ = newDecolorizeExpr() };
 
+lookupExpr: LOOKUP IDENTIFIER ON IDENTIFIER { $$ = newLookupExpr($2, $4) };
+
+dedupExpr:
+    DEDUP                                                               { $$ = newDedupExpr(nil, 0) }
+  | DEDUP BY OPEN_PARENTHESIS labels CLOSE_PARENTHESIS                  { $$ = newDedupExpr($4, 0) }
+  | DEDUP WITHIN DURATION                                               { $$ = newDedupExpr(nil, $3) }
+  | DEDUP BY OPEN_PARENTHESIS labels CLOSE_PARENTHESIS WITHIN DURATION  { $$ = newDedupExpr($4, $7) }
+  ;
+
 labelFormat:
      IDENTIFIER EQ IDENTIFIER { $$ = log.NewRenameLabelFmt($1, $3)}
   |  IDENTIFIER EQ STRING     { $$ = log.NewTemplateLabelFmt($1, $3)}
@@ -311,6 +391,28 @@ labelFilter:
     | labelFilter OR labelFilter                     { $$ = log.NewOrLabelFilter($1, $3 ) }
     ;
 
+fieldFilterExpr:
+      fieldFilterParser DOT fieldFilter                { $$ = newFieldFilterExpr($1, nil, $3) }
+    | fieldFilterParser DOT fieldPath DOT fieldFilter  { $$ = newFieldFilterExpr($1, $3, $5) }
+    ;
+
+fieldFilterParser:
+      JSON     { $$ = OpParserTypeJSON }
+    | LOGFMT   { $$ = OpParserTypeLogfmt }
+    | METADATA { $$ = OpFieldFilterMetadata }
+    ;
+
+fieldPath:
+      IDENTIFIER                { $$ = []string{ $1 } }
+    | fieldPath DOT IDENTIFIER  { $$ = append($1, $3) }
+    ;
+
+fieldFilter:
+      matcher       { $$ = log.NewStringLabelFilter($1) }
+    | unitFilter    { $$ = $1 }
+    | numberFilter  { $$ = $1 }
+    ;
+
 labelExtractionExpression:
     IDENTIFIER EQ STRING { $$ = log.NewLabelExtractionExpr($1, $3) }
   | IDENTIFIER           { $$ = log.NewLabelExtractionExpr($1, $1) }
@@ -489,6 +591,23 @@ vectorOp:
       | APPROX_TOPK  { $$ = OpTypeApproxTopK }
       ;
 
+functionOp:
+      ABS          { $$ = OpFuncAbs }
+    | CEIL         { $$ = OpFuncCeil }
+    | FLOOR        { $$ = OpFuncFloor }
+    | ROUND        { $$ = OpFuncRound }
+    | SQRT         { $$ = OpFuncSqrt }
+    | EXP          { $$ = OpFuncExp }
+    | LN           { $$ = OpFuncLn }
+    | CLAMP_MIN    { $$ = OpFuncClampMin }
+    | CLAMP_MAX    { $$ = OpFuncClampMax }
+    | SCALAR       { $$ = OpFuncScalar }
+    | TIMESTAMP    { $$ = OpFuncTimestamp }
+    | HOUR         { $$ = OpFuncHour }
+    | DAY_OF_WEEK  { $$ = OpFuncDayOfWeek }
+    | DAY_OF_MONTH { $$ = OpFuncDayOfMonth }
+    ;
+
 rangeOp:
       COUNT_OVER_TIME    { $$ = OpRangeTypeCount }
     | RATE               { $$ = OpRangeTypeRate }
@@ -501,14 +620,28 @@ rangeOp:
     | MAX_OVER_TIME      { $$ = OpRangeTypeMax }
     | STDVAR_OVER_TIME   { $$ = OpRangeTypeStdvar }
     | STDDEV_OVER_TIME   { $$ = OpRangeTypeStddev }
-    | QUANTILE_OVER_TIME { $$ = OpRangeTypeQuantile }
     | FIRST_OVER_TIME    { $$ = OpRangeTypeFirst }
     | LAST_OVER_TIME     { $$ = OpRangeTypeLast }
     | ABSENT_OVER_TIME   { $$ = OpRangeTypeAbsent }
+    | PATTERN_COUNT_OVER_TIME { $$ = OpRangeTypePatternCount }
+    ;
+
+quantileOp:
+      QUANTILE_OVER_TIME { $$ = OpRangeTypeQuantile }
     ;
 
 offsetExpr:
-    OFFSET DURATION { $$ = newOffsetExpr( $2 ) }
+      OFFSET DURATION                 { $$ = newOffsetExpr( $2 ) }
+    | atModifier                      { $$ = &OffsetExpr{ At: $1 } }
+    | OFFSET DURATION atModifier      { $$ = &OffsetExpr{ Offset: $2, At: $3 } }
+    | atModifier OFFSET DURATION      { $$ = &OffsetExpr{ Offset: $3, At: $1 } }
+    ;
+
+atModifier:
+      AT NUMBER                                       { $$ = mustNewAtModifier( $2 ) }
+    | AT START OPEN_PARENTHESIS CLOSE_PARENTHESIS     { $$ = &AtModifier{ StartOrEnd: OpStart } }
+    | AT END OPEN_PARENTHESIS CLOSE_PARENTHESIS       { $$ = &AtModifier{ StartOrEnd: OpEnd } }
+    ;
 
 labels:
       IDENTIFIER                 { $$ = []string{ $1 } }
@@ -45,6 +45,8 @@ type syntaxSymType struct {
 	labelExtractionExpressionList []log.LabelExtractionExpr
 	unwrapExpr                    *UnwrapExpr
 	offsetExpr                    *OffsetExpr
+	atModifier                    *AtModifier
+	subqueryRange                 subqueryRange
 }
 
 const BYTES = 57346
@@ -54,93 +56,124 @@ const NUMBER = 57349
 const FUNCTION_FLAG = 57350
 const DURATION = 57351
 const RANGE = 57352
-const MATCHERS = 57353
-const LABELS = 57354
-const EQ = 57355
-const RE = 57356
-const NRE = 57357
-const NPA = 57358
-const OPEN_BRACE = 57359
-const CLOSE_BRACE = 57360
-const OPEN_BRACKET = 57361
-const CLOSE_BRACKET = 57362
-const COMMA = 57363
-const DOT = 57364
-const PIPE_MATCH = 57365
-const PIPE_EXACT = 57366
-const PIPE_PATTERN = 57367
-const OPEN_PARENTHESIS = 57368
-const CLOSE_PARENTHESIS = 57369
-const BY = 57370
-const WITHOUT = 57371
-const COUNT_OVER_TIME = 57372
-const RATE = 57373
-const RATE_COUNTER = 57374
-const SUM = 57375
-const SORT = 57376
-const SORT_DESC = 57377
-const AVG = 57378
-const MAX = 57379
-const MIN = 57380
-const COUNT = 57381
-const STDDEV = 57382
-const STDVAR = 57383
-const BOTTOMK = 57384
-const TOPK = 57385
-const APPROX_TOPK = 57386
-const BYTES_OVER_TIME = 57387
-const BYTES_RATE = 57388
-const BOOL = 57389
-const JSON = 57390
-const REGEXP = 57391
-const LOGFMT = 57392
-const PIPE = 57393
-const LINE_FMT = 57394
-const LABEL_FMT = 57395
-const UNWRAP = 57396
-const AVG_OVER_TIME = 57397
-const SUM_OVER_TIME = 57398
-const MIN_OVER_TIME = 57399
-const MAX_OVER_TIME = 57400
-const STDVAR_OVER_TIME = 57401
-const STDDEV_OVER_TIME = 57402
-const QUANTILE_OVER_TIME = 57403
-const BYTES_CONV = 57404
-const DURATION_CONV = 57405
-const DURATION_SECONDS_CONV = 57406
-const FIRST_OVER_TIME = 57407
-const LAST_OVER_TIME = 57408
-const ABSENT_OVER_TIME = 57409
-const VECTOR = 57410
-const LABEL_REPLACE = 57411
-const UNPACK = 57412
-const OFFSET = 57413
-const PATTERN = 57414
-const IP = 57415
-const ON = 57416
-const IGNORING = 57417
-const GROUP_LEFT = 57418
-const GROUP_RIGHT = 57419
-const DECOLORIZE = 57420
-const DROP = 57421
-const KEEP = 57422
-const VARIANTS = 57423
-const OF = 57424
-const OR = 57425
-const AND = 57426
-const UNLESS = 57427
-const CMP_EQ = 57428
-const NEQ = 57429
-const LT = 57430
-const LTE = 57431
-const GT = 57432
-const GTE = 57433
-const ADD = 57434
-const SUB = 57435
-const MUL = 57436
-const DIV = 57437
-const MOD = 57438
-const POW = 57439
+const SUBQUERY_RANGE = 57353
+const MATCHERS = 57354
+const LABELS = 57355
+const EQ = 57356
+const RE = 57357
+const NRE = 57358
+const NPA = 57359
+const OPEN_BRACE = 57360
+const CLOSE_BRACE = 57361
+const OPEN_BRACKET = 57362
+const CLOSE_BRACKET = 57363
+const COMMA = 57364
+const DOT = 57365
+const PIPE_MATCH = 57366
+const PIPE_EXACT = 57367
+const PIPE_PATTERN = 57368
+const OPEN_PARENTHESIS = 57369
+const CLOSE_PARENTHESIS = 57370
+const BY = 57371
+const WITHOUT = 57372
+const COUNT_OVER_TIME = 57373
+const RATE = 57374
+const RATE_COUNTER = 57375
+const SUM = 57376
+const SORT = 57377
+const SORT_DESC = 57378
+const AVG = 57379
+const MAX = 57380
+const MIN = 57381
+const COUNT = 57382
+const STDDEV = 57383
+const STDVAR = 57384
+const BOTTOMK = 57385
+const TOPK = 57386
+const APPROX_TOPK = 57387
+const BYTES_OVER_TIME = 57388
+const BYTES_RATE = 57389
+const BOOL = 57390
+const JSON = 57391
+const REGEXP = 57392
+const LOGFMT = 57393
+const PIPE = 57394
+const LINE_FMT = 57395
+const LABEL_FMT = 57396
+const UNWRAP = 57397
+const AVG_OVER_TIME = 57398
+const SUM_OVER_TIME = 57399
+const MIN_OVER_TIME = 57400
+const MAX_OVER_TIME = 57401
+const STDVAR_OVER_TIME = 57402
+const STDDEV_OVER_TIME = 57403
+const QUANTILE_OVER_TIME = 57404
+const BYTES_CONV = 57405
+const DURATION_CONV = 57406
+const DURATION_SECONDS_CONV = 57407
+const FIRST_OVER_TIME = 57408
+const LAST_OVER_TIME = 57409
+const ABSENT_OVER_TIME = 57410
+const PATTERN_COUNT_OVER_TIME = 57411
+const VECTOR = 57412
+const LABEL_REPLACE = 57413
+const UNPACK = 57414
+const OFFSET = 57415
+const PATTERN = 57416
+const IP = 57417
+const ON = 57418
+const IGNORING = 57419
+const GROUP_LEFT = 57420
+const GROUP_RIGHT = 57421
+const DECOLORIZE = 57422
+const DROP = 57423
+const KEEP = 57424
+const VARIANTS = 57425
+const OF = 57426
+const AT = 57427
+const START = 57428
+const END = 57429
+const ABS = 57430
+const CEIL = 57431
+const FLOOR = 57432
+const ROUND = 57433
+const SQRT = 57434
+const EXP = 57435
+const LN = 57436
+const CLAMP_MIN = 57437
+const CLAMP_MAX = 57438
+const SCALAR = 57439
+const TIMESTAMP = 57440
+const HOUR = 57441
+const DAY_OF_WEEK = 57442
+const DAY_OF_MONTH = 57443
+const COUNT_VALUES = 57444
+const LABEL_JOIN = 57445
+const XML = 57446
+const CSV = 57447
+const LOOKUP = 57448
+const JOIN = 57449
+const LEFT_JOIN = 57450
+const DEDUP = 57451
+const WITHIN = 57452
+const HISTOGRAM_QUANTILE = 57453
+const METADATA = 57454
+const START_FIELD_FILTER = 57455
+const OR = 57456
+const AND = 57457
+const UNLESS = 57458
+const CMP_EQ = 57459
+const NEQ = 57460
+const LT = 57461
+const LTE = 57462
+const GT = 57463
+const GTE = 57464
+const ADD = 57465
+const SUB = 57466
+const MUL = 57467
+const DIV = 57468
+const MOD = 57469
+const POW = 57470
 
 var syntaxToknames = [...]string{
 	"$end",
@@ -153,6 +186,7 @@ var syntaxToknames = [...]string{
 	"FUNCTION_FLAG",
 	"DURATION",
 	"RANGE",
+	"SUBQUERY_RANGE",
 	"MATCHERS",
 	"LABELS",
 	"EQ",
@@ -210,6 +244,7 @@ var syntaxToknames = [...]string{
 	"FIRST_OVER_TIME",
 	"LAST_OVER_TIME",
 	"ABSENT_OVER_TIME",
+	"PATTERN_COUNT_OVER_TIME",
 	"VECTOR",
 	"LABEL_REPLACE",
 	"UNPACK",
@@ -225,6 +260,35 @@ var syntaxToknames = [...]string{
 	"KEEP",
 	"VARIANTS",
 	"OF",
+	"AT",
+	"START",
+	"END",
+	"ABS",
+	"CEIL",
+	"FLOOR",
+	"ROUND",
+	"SQRT",
+	"EXP",
+	"LN",
+	"CLAMP_MIN",
+	"CLAMP_MAX",
+	"SCALAR",
+	"TIMESTAMP",
+	"HOUR",
+	"DAY_OF_WEEK",
+	"DAY_OF_MONTH",
+	"COUNT_VALUES",
+	"LABEL_JOIN",
+	"XML",
+	"CSV",
+	"LOOKUP",
+	"JOIN",
+	"LEFT_JOIN",
+	"DEDUP",
+	"WITHIN",
+	"HISTOGRAM_QUANTILE",
+	"METADATA",
+	"START_FIELD_FILTER",
 	"OR",
 	"AND",
 	"UNLESS",
@@ -241,294 +305,433 @@ var syntaxToknames = [...]string{
 	"MOD",
 	"POW",
 }
+
 var syntaxStatenames = [...]string{}
 
 const syntaxEofCode = 1
 const syntaxErrCode = 2
 const syntaxInitialStackSize = 16
 
-var syntaxExca = [...]int{
+var syntaxExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 150,
-	21, 227,
-	27, 227,
-	-2, 3,
-	-1, 290,
-	21, 228,
-	27, 228,
-	-2, 3,
+	-1, 171,
+	23, 169,
+	-2, 131,
+	-1, 172,
+	23, 168,
+	-2, 133,
+	-1, 200,
+	22, 300,
+	28, 300,
+	-2, 4,
+	-1, 384,
+	22, 301,
+	28, 301,
+	-2, 4,
 }
 
 const syntaxPrivate = 57344
 
-const syntaxLast = 642
-
-var syntaxAct = [...]int{
-
-	293, 232, 88, 4, 217, 67, 130, 6, 188, 206,
-	158, 79, 203, 195, 66, 241, 205, 193, 80, 2,
-	59, 286, 84, 51, 52, 53, 60, 61, 64, 65,
-	62, 63, 54, 55, 56, 57, 58, 59, 143, 218,
-	11, 52, 53, 60, 61, 64, 65, 62, 63, 54,
-	55, 56, 57, 58, 59, 60, 61, 64, 65, 62,
-	63, 54, 55, 56, 57, 58, 59, 56, 57, 58,
-	59, 289, 113, 144, 210, 156, 157, 296, 119, 54,
-	55, 56, 57, 58, 59, 150, 301, 154, 156, 157,
-	269, 162, 225, 18, 160, 268, 265, 167, 224, 18,
-	284, 264, 219, 18, 281, 283, 146, 18, 278, 280,
-	98, 18, 70, 277, 169, 172, 173, 298, 174, 175,
-	176, 177, 178, 179, 180, 181, 182, 183, 184, 185,
-	186, 187, 275, 170, 171, 18, 272, 274, 371, 18,
-	146, 271, 371, 200, 197, 208, 208, 216, 211, 214,
-	215, 212, 213, 343, 297, 140, 209, 392, 296, 267,
-	223, 155, 228, 343, 387, 263, 297, 310, 239, 235,
-	140, 190, 236, 360, 145, 233, 134, 228, 19, 20,
-	374, 114, 244, 350, 19, 20, 190, 380, 19, 20,
-	379, 134, 19, 20, 298, 298, 19, 20, 252, 253,
-	254, 344, 335, 299, 298, 75, 77, 298, 75, 77,
-	256, 89, 90, 72, 73, 74, 72, 73, 74, 378,
-	19, 20, 228, 243, 19, 20, 290, 87, 368, 89,
-	90, 376, 291, 294, 189, 300, 228, 303, 160, 113,
-	306, 292, 307, 119, 234, 320, 295, 305, 191, 189,
-	304, 266, 270, 273, 276, 279, 282, 285, 346, 347,
-	348, 229, 310, 363, 314, 316, 319, 321, 359, 208,
-	299, 322, 328, 324, 353, 75, 77, 76, 140, 333,
-	76, 243, 308, 72, 73, 74, 310, 351, 140, 247,
-	310, 331, 358, 243, 190, 336, 357, 338, 340, 134,
-	342, 113, 243, 318, 140, 243, 352, 341, 337, 134,
-	113, 234, 310, 354, 237, 317, 310, 222, 312, 243,
-	190, 148, 311, 221, 315, 134, 259, 245, 147, 334,
-	159, 126, 127, 125, 330, 135, 137, 301, 365, 366,
-	15, 242, 160, 113, 367, 364, 140, 76, 15, 161,
-	369, 370, 329, 128, 287, 129, 375, 161, 251, 250,
-	249, 136, 138, 139, 231, 18, 248, 134, 220, 75,
-	77, 382, 166, 383, 384, 15, 165, 72, 73, 74,
-	261, 302, 191, 189, 7, 164, 388, 94, 23, 24,
-	25, 38, 47, 48, 39, 41, 42, 40, 43, 44,
-	45, 46, 49, 26, 27, 234, 93, 86, 81, 390,
-	386, 356, 152, 28, 29, 30, 31, 32, 33, 34,
-	257, 309, 262, 35, 36, 37, 50, 21, 151, 231,
-	240, 153, 260, 246, 75, 77, 238, 75, 77, 14,
-	15, 76, 72, 73, 74, 72, 73, 74, 230, 7,
-	19, 20, 258, 23, 24, 25, 38, 47, 48, 39,
-	41, 42, 40, 43, 44, 45, 46, 49, 26, 27,
-	234, 385, 85, 234, 373, 372, 349, 339, 28, 29,
-	30, 31, 32, 33, 34, 83, 326, 327, 35, 36,
-	37, 50, 21, 296, 196, 163, 196, 255, 391, 194,
-	3, 168, 75, 77, 14, 15, 76, 92, 78, 76,
-	72, 73, 74, 91, 7, 19, 20, 389, 23, 24,
-	25, 38, 47, 48, 39, 41, 42, 40, 43, 44,
-	45, 46, 49, 26, 27, 377, 362, 361, 234, 332,
-	323, 149, 313, 28, 29, 30, 31, 32, 33, 34,
-	75, 77, 140, 35, 36, 37, 50, 21, 72, 73,
-	74, 325, 288, 227, 204, 202, 226, 225, 224, 14,
-	201, 199, 198, 134, 76, 95, 381, 355, 207, 196,
-	19, 20, 85, 204, 97, 96, 69, 192, 22, 82,
-	71, 131, 132, 141, 133, 126, 127, 125, 142, 135,
-	137, 17, 345, 16, 68, 124, 123, 122, 121, 120,
-	118, 117, 116, 115, 5, 13, 12, 128, 10, 129,
-	9, 8, 76, 1, 0, 136, 138, 139, 99, 100,
-	101, 102, 103, 104, 105, 106, 107, 108, 109, 110,
-	111, 112,
+const syntaxLast = 1315
+
+var syntaxAct = [...]int16{
+	389, 4, 308, 121, 99, 391, 291, 8, 280, 5,
+	320, 110, 178, 277, 257, 180, 264, 311, 181, 111,
+	249, 98, 262, 279, 81, 82, 83, 84, 125, 84,
+	25, 380, 193, 116, 112, 2, 76, 77, 78, 85,
+	86, 89, 90, 87, 88, 79, 80, 81, 82, 83,
+	84, 77, 78, 85, 86, 89, 90, 87, 88, 79,
+	80, 81, 82, 83, 84, 85, 86, 89, 90, 87,
+	88, 79, 80, 81, 82, 83, 84, 79, 80, 81,
+	82, 83, 84, 338, 206, 207, 13, 363, 523, 299,
+	25, 359, 362, 298, 25, 102, 358, 96, 97, 204,
+	206, 207, 390, 154, 293, 392, 363, 351, 299, 25,
+	383, 362, 153, 274, 392, 359, 162, 298, 25, 378,
+	358, 152, 25, 200, 377, 197, 211, 215, 210, 93,
+	217, 94, 233, 234, 190, 453, 208, 213, 224, 225,
+	399, 228, 284, 206, 207, 375, 26, 27, 25, 372,
+	374, 259, 25, 400, 371, 512, 182, 350, 361, 509,
+	369, 454, 357, 25, 251, 368, 366, 252, 230, 25,
+	253, 365, 235, 236, 237, 238, 239, 240, 241, 242,
+	243, 244, 245, 246, 247, 248, 290, 339, 288, 289,
+	286, 287, 95, 551, 275, 266, 155, 271, 464, 269,
+	390, 282, 282, 205, 96, 97, 26, 27, 195, 509,
+	26, 27, 392, 283, 110, 136, 297, 458, 197, 229,
+	310, 398, 111, 541, 315, 26, 27, 231, 232, 318,
+	390, 292, 323, 306, 26, 27, 194, 314, 26, 27,
+	455, 456, 392, 260, 258, 290, 285, 288, 289, 286,
+	287, 535, 107, 109, 458, 302, 466, 467, 468, 399,
+	104, 105, 106, 399, 26, 27, 340, 341, 26, 27,
+	190, 214, 506, 342, 343, 344, 122, 123, 529, 26,
+	27, 526, 20, 393, 346, 26, 27, 259, 393, 107,
+	109, 216, 182, 349, 107, 109, 399, 104, 105, 106,
+	196, 469, 104, 105, 106, 196, 384, 385, 395, 394,
+	396, 154, 211, 403, 387, 528, 405, 394, 403, 154,
+	409, 211, 386, 407, 162, 309, 190, 410, 548, 397,
+	309, 406, 401, 496, 547, 416, 388, 408, 124, 322,
+	122, 123, 421, 259, 422, 424, 427, 429, 182, 120,
+	251, 122, 123, 252, 108, 538, 253, 527, 430, 133,
+	525, 537, 428, 437, 434, 442, 398, 524, 438, 282,
+	517, 360, 364, 367, 370, 373, 376, 379, 390, 260,
+	258, 390, 413, 413, 472, 190, 413, 445, 490, 487,
+	392, 108, 486, 392, 457, 413, 108, 413, 459, 462,
+	461, 485, 154, 484, 470, 322, 154, 182, 399, 413,
+	413, 470, 20, 154, 463, 433, 432, 460, 420, 515,
+	493, 521, 476, 20, 483, 360, 364, 479, 426, 172,
+	173, 171, 216, 183, 187, 400, 258, 137, 138, 139,
+	140, 141, 142, 143, 144, 145, 146, 147, 148, 149,
+	150, 497, 174, 495, 175, 302, 215, 302, 498, 502,
+	184, 188, 189, 503, 322, 154, 494, 482, 478, 302,
+	413, 190, 507, 322, 508, 513, 415, 511, 322, 514,
+	475, 473, 516, 449, 176, 177, 185, 425, 259, 186,
+	520, 522, 95, 182, 413, 404, 423, 331, 322, 296,
+	414, 324, 190, 330, 302, 295, 531, 451, 447, 411,
+	533, 534, 329, 307, 25, 316, 198, 504, 501, 107,
+	109, 321, 500, 539, 182, 20, 448, 104, 105, 106,
+	312, 402, 302, 542, 9, 227, 444, 443, 33, 34,
+	35, 49, 58, 59, 50, 52, 53, 51, 54, 55,
+	56, 57, 60, 36, 37, 309, 381, 474, 303, 352,
+	336, 335, 334, 38, 39, 40, 41, 42, 43, 48,
+	333, 294, 256, 44, 45, 46, 47, 61, 28, 107,
+	109, 255, 223, 107, 109, 221, 220, 104, 105, 106,
+	19, 104, 105, 106, 337, 62, 63, 64, 65, 66,
+	67, 68, 69, 70, 71, 72, 73, 74, 75, 24,
+	29, 219, 25, 132, 131, 309, 130, 129, 32, 101,
+	128, 108, 119, 20, 118, 113, 151, 450, 546, 536,
+	26, 27, 9, 481, 480, 347, 33, 34, 35, 49,
+	58, 59, 50, 52, 53, 51, 54, 55, 56, 57,
+	60, 36, 37, 417, 412, 356, 354, 332, 328, 327,
+	202, 38, 39, 40, 41, 42, 43, 48, 325, 317,
+	313, 44, 45, 46, 47, 61, 28, 201, 304, 355,
+	203, 108, 348, 305, 532, 108, 510, 505, 19, 489,
+	488, 471, 540, 62, 63, 64, 65, 66, 67, 68,
+	69, 70, 71, 72, 73, 74, 75, 24, 29, 117,
+	25, 499, 440, 441, 452, 353, 32, 265, 3, 265,
+	345, 20, 263, 115, 226, 127, 126, 550, 26, 27,
+	9, 549, 545, 543, 33, 34, 35, 49, 58, 59,
+	50, 52, 53, 51, 54, 55, 56, 57, 60, 36,
+	37, 519, 518, 492, 491, 446, 435, 419, 418, 38,
+	39, 40, 41, 42, 43, 48, 382, 326, 301, 44,
+	45, 46, 47, 61, 28, 439, 300, 299, 278, 199,
+	298, 272, 270, 268, 267, 222, 19, 530, 477, 281,
+	322, 62, 63, 64, 65, 66, 67, 68, 69, 70,
+	71, 72, 73, 74, 75, 24, 29, 436, 25, 265,
+	431, 117, 278, 273, 32, 254, 163, 276, 135, 20,
+	91, 134, 250, 544, 261, 30, 26, 27, 212, 114,
+	103, 179, 33, 34, 35, 49, 58, 59, 50, 52,
+	53, 51, 54, 55, 56, 57, 60, 36, 37, 191,
+	192, 92, 31, 23, 465, 22, 21, 38, 39, 40,
+	41, 42, 43, 48, 100, 170, 169, 44, 45, 46,
+	47, 61, 28, 168, 167, 166, 165, 164, 161, 160,
+	159, 158, 157, 156, 19, 6, 18, 17, 16, 62,
+	63, 64, 65, 66, 67, 68, 69, 70, 71, 72,
+	73, 74, 75, 24, 29, 15, 319, 14, 12, 11,
+	10, 7, 32, 1, 0, 0, 0, 20, 0, 0,
+	0, 0, 0, 0, 26, 27, 9, 0, 0, 0,
+	33, 34, 35, 49, 58, 59, 50, 52, 53, 51,
+	54, 55, 56, 57, 60, 36, 37, 0, 0, 0,
+	0, 0, 0, 0, 0, 38, 39, 40, 41, 42,
+	43, 48, 0, 0, 0, 44, 45, 46, 47, 61,
+	28, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 19, 0, 0, 0, 0, 62, 63, 64,
+	65, 66, 67, 68, 69, 70, 71, 72, 73, 74,
+	75, 24, 29, 0, 218, 0, 0, 0, 0, 0,
+	32, 0, 0, 0, 0, 20, 0, 0, 0, 0,
+	0, 0, 26, 27, 9, 0, 0, 0, 33, 34,
+	35, 49, 58, 59, 50, 52, 53, 51, 54, 55,
+	56, 57, 60, 36, 37, 0, 0, 0, 0, 0,
+	0, 0, 0, 38, 39, 40, 41, 42, 43, 48,
+	0, 0, 0, 44, 45, 46, 47, 61, 28, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	19, 0, 0, 0, 0, 62, 63, 64, 65, 66,
+	67, 68, 69, 70, 71, 72, 73, 74, 75, 24,
+	29, 0, 209, 307, 0, 0, 0, 0, 32, 107,
+	109, 0, 0, 20, 0, 0, 0, 104, 105, 106,
+	26, 27, 212, 0, 0, 0, 33, 34, 35, 49,
+	58, 59, 50, 52, 53, 51, 54, 55, 56, 57,
+	60, 36, 37, 0, 0, 309, 0, 0, 0, 0,
+	0, 38, 39, 40, 41, 42, 43, 48, 0, 190,
+	0, 44, 45, 46, 47, 61, 28, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 19, 0,
+	0, 182, 0, 62, 63, 64, 65, 66, 67, 68,
+	69, 70, 71, 72, 73, 74, 75, 24, 29, 0,
+	0, 0, 0, 172, 173, 171, 32, 183, 187, 0,
+	0, 108, 107, 109, 0, 0, 0, 0, 26, 27,
+	104, 105, 106, 0, 0, 0, 174, 0, 175, 0,
+	0, 0, 0, 0, 184, 188, 189, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 309, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 176, 177,
+	185, 0, 0, 186, 0, 0, 95, 0, 0, 390,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 392, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 108,
 }
-var syntaxPact = [...]int{
 
-	358, -1000, -60, -1000, -1000, -1000, 535, 358, -1000, -1000,
-	-1000, -1000, -1000, -1000, 382, 467, 381, 201, -1000, 506,
-	500, 380, 361, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+var syntaxPact = [...]int16{
+	605, -1000, -78, 80, -10, -1000, -1000, -1000, 567, 703,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 598,
+	704, 597, 595, 322, 311, -1000, 719, 718, 593, 590,
+	589, 587, 586, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, 63, 63, 63, 63, 63, 63, 63, 63, 63,
-	63, 63, 63, 63, 63, 63, 535, -1000, 190, 547,
-	-45, 67, -1000, -1000, -1000, -1000, -1000, -1000, 301, 294,
-	-60, 358, 410, -1000, -1000, 74, 323, 488, 359, 350,
-	346, -1000, -1000, 358, 494, 358, 59, 39, -1000, 358,
-	358, 358, 358, 358, 358, 358, 358, 358, 358, 358,
-	358, 358, 358, -1000, -45, -1000, -1000, -1000, -1000, 165,
-	-1000, -1000, -1000, -1000, -1000, 491, 574, 566, -1000, 565,
-	-1000, -1000, -1000, -1000, 341, 564, -1000, 578, 573, 573,
-	61, -1000, -1000, 33, -1000, 342, -1000, -1000, -1000, 296,
-	-1000, -1000, -1000, 577, 562, 561, 560, 557, 234, 427,
-	419, 331, 287, 415, 423, 314, 300, 412, 262, -43,
-	340, 334, 333, 332, -31, -31, -27, -27, -77, -77,
-	-77, -77, -13, -13, -13, -13, -13, -13, 165, 341,
-	341, 341, 489, 399, -1000, -1000, 439, 399, -1000, -1000,
-	299, -1000, 411, -1000, 367, 401, -1000, 74, -1000, 401,
-	92, 86, 132, 128, 104, 100, 96, -1000, -62, 328,
-	556, -11, 358, -1000, -1000, -1000, -1000, -1000, -1000, 183,
-	331, 422, 144, 193, 283, 354, 220, 183, 358, 255,
-	400, 295, -1000, -1000, 291, -1000, 536, -1000, 297, 288,
-	276, 218, 273, 165, 150, -1000, 399, 574, 534, -1000,
-	559, 481, 573, 326, -1000, -1000, -1000, 308, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, 167, 167, 167, 167,
+	167, 167, 167, 167, 167, 167, 167, 167, 167, 167,
+	167, -1000, 603, -1000, -1000, -1000, 45, 36, 567, -1000,
+	236, 1154, -82, 230, -1000, -1000, -1000, -1000, -1000, -1000,
+	97, 488, -78, 703, 658, -1000, -1000, 85, 1095, 264,
+	997, 584, 559, 558, 779, 555, -1000, -1000, 703, 703,
+	717, 507, 23, 703, 151, 54, -1000, 703, 703, 703,
+	703, 703, 703, 703, 703, 703, 703, 703, 703, 703,
+	703, 810, 554, 545, -1000, -82, -1000, -1000, -1000, -1000,
+	-1000, -1000, 265, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 714, 804, 778, -1000, 777, 804, 776, -1000, -1000,
+	-1000, -1000, 497, 775, -1000, 808, 84, 807, 784, 784,
+	128, -1000, -1000, 225, -1000, 544, -1000, -1000, -1000, 477,
+	-1000, -1000, -1000, 806, 774, 771, 770, 762, 530, 656,
+	672, 1093, 801, 502, 648, 1093, 405, 487, 647, 899,
+	493, 473, 646, 761, 637, 636, 484, -1000, 475, 635,
+	-64, 543, 535, 534, 533, -52, -52, -101, -101, -99,
+	-99, -99, -99, -46, -46, -46, -46, -46, -46, -1000,
+	571, -1000, -1000, -1000, 69, 785, 785, 265, 497, 497,
+	497, 712, 613, -1000, -1000, 668, 613, -1000, -1000, 613,
+	804, 129, -1000, 31, 532, 706, 634, -1000, 665, 633,
+	-1000, 85, -1000, 633, 87, 83, 162, 156, 145, 141,
+	115, -1000, -83, 529, 760, 26, 703, -1000, -1000, -1000,
+	-1000, -1000, -1000, 247, 801, 308, 278, 1196, 211, 380,
+	503, 467, 247, 801, 278, 503, 247, 703, 481, 632,
+	472, -1000, -1000, 448, -1000, 703, 631, 752, 751, -1000,
+	-1000, 23, 703, 468, 459, 400, 334, 805, 111, 102,
+	388, 387, 466, 265, 321, -1000, 613, 804, 750, 613,
+	-1000, 802, 785, -1000, 773, 707, 784, 510, -1000, -1000,
+	-1000, 509, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, 33, 533, 252, 303,
-	-1000, -1000, 175, 487, 66, 487, 468, 6, 341, 6,
-	143, 196, 466, 156, 260, -1000, -1000, 247, -1000, 358,
-	572, -1000, -1000, 390, 269, -1000, 265, -1000, -1000, 241,
-	-1000, 146, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 531,
-	530, -1000, 236, -1000, 331, 183, 66, 487, 66, -1000,
-	-1000, 165, -1000, 6, -1000, 202, -1000, -1000, -1000, 87,
-	465, 464, 153, 183, 204, -1000, 529, -1000, -1000, -1000,
-	-1000, 192, 163, -1000, 160, -1000, 66, -1000, 571, 91,
-	66, 32, 6, 6, 461, -1000, -1000, 389, -1000, -1000,
-	-1000, 137, 66, -1000, -1000, 6, 511, -1000, -1000, 388,
-	492, 130, -1000,
+	225, 749, 480, 499, -1000, -1000, 455, 616, -1000, 479,
+	705, 62, 154, 29, 207, 563, 88, 563, 29, 497,
+	193, 273, 681, 356, -1000, -1000, 453, 546, 273, -1000,
+	452, -1000, 703, 783, -1000, -1000, 440, 703, 612, 611,
+	439, 396, 375, -1000, 373, -1000, -1000, 364, -1000, 361,
+	-1000, 69, 680, 679, -1000, -1000, -1000, 360, -1000, -1000,
+	-1000, -1000, -1000, 748, 747, -1000, 392, -1000, 405, 247,
+	305, -1000, 20, 702, -1000, 495, 491, -1000, 29, 88,
+	563, 88, -1000, 265, -1000, 490, -1000, -1000, -1000, 677,
+	244, 157, 676, 247, 127, 247, 391, -1000, 247, 342,
+	746, 745, -1000, -1000, -1000, -1000, -1000, -1000, 394, 394,
+	-22, 339, 332, -1000, 253, -1000, -1000, 329, -1000, -1000,
+	287, 250, -1000, 88, 782, 29, 674, 107, 88, 98,
+	29, -1000, -1000, 223, -1000, -1000, -1000, -1000, 607, 333,
+	-1000, 394, -1000, 683, -1000, -1000, -1000, -1000, -1000, -1000,
+	195, -1000, 29, 88, -1000, -1000, 727, -1000, 726, 190,
+	-1000, -1000, -1000, 606, 306, -1000, 725, -1000, 721, 165,
+	-1000, -1000,
 }
-var syntaxPgo = [...]int{
-
-	0, 623, 18, 500, 3, 621, 620, 618, 616, 615,
-	614, 5, 613, 612, 611, 610, 609, 608, 607, 606,
-	605, 14, 112, 604, 4, 603, 602, 601, 102, 598,
-	594, 593, 8, 592, 591, 590, 6, 589, 7, 588,
-	15, 587, 575, 585, 584, 9, 16, 12, 565, 2,
-	10, 40, 13, 17, 1, 0, 541,
+
+var syntaxPgo = [...]int16{
+	0, 913, 34, 911, 1, 9, 910, 909, 908, 907,
+	905, 888, 887, 886, 885, 4, 883, 882, 881, 880,
+	879, 878, 877, 876, 875, 874, 873, 866, 865, 816,
+	21, 95, 864, 6, 856, 855, 854, 853, 104, 852,
+	851, 850, 18, 849, 14, 15, 831, 20, 830, 12,
+	829, 7, 825, 10, 824, 823, 822, 359, 821, 818,
+	8, 23, 13, 817, 3, 17, 86, 16, 22, 2,
+	0, 5, 779,
 }
-var syntaxR1 = [...]int{
-
-	0, 1, 2, 2, 2, 3, 3, 3, 4, 4,
-	4, 4, 4, 4, 4, 10, 50, 50, 50, 50,
-	50, 50, 50, 50, 50, 50, 50, 50, 50, 50,
-	50, 50, 50, 50, 50, 50, 50, 50, 50, 50,
-	50, 50, 54, 54, 54, 26, 26, 26, 5, 5,
-	5, 5, 6, 6, 6, 6, 6, 6, 8, 38,
-	38, 38, 37, 37, 36, 36, 36, 36, 21, 21,
-	11, 11, 11, 11, 11, 11, 11, 11, 11, 11,
-	11, 35, 35, 35, 35, 35, 35, 28, 24, 24,
-	24, 22, 22, 22, 23, 23, 41, 41, 12, 12,
-	13, 13, 13, 13, 14, 15, 15, 16, 17, 47,
-	47, 48, 48, 48, 18, 32, 32, 32, 32, 32,
-	32, 32, 32, 32, 52, 52, 53, 53, 34, 34,
-	33, 33, 31, 31, 31, 31, 31, 31, 31, 29,
-	29, 29, 29, 29, 29, 29, 30, 30, 30, 30,
-	30, 30, 30, 45, 45, 46, 46, 19, 20, 7,
-	7, 7, 7, 7, 7, 7, 7, 7, 7, 7,
-	7, 7, 7, 7, 43, 43, 44, 44, 44, 44,
-	42, 42, 42, 42, 42, 42, 42, 42, 51, 51,
-	51, 9, 39, 27, 27, 27, 27, 27, 27, 27,
-	27, 27, 27, 27, 27, 25, 25, 25, 25, 25,
-	25, 25, 25, 25, 25, 25, 25, 25, 25, 25,
-	55, 40, 40, 49, 49, 49, 49, 56, 56,
+
+var syntaxR1 = [...]int8{
+	0, 1, 1, 2, 2, 2, 2, 4, 4, 4,
+	3, 3, 5, 5, 5, 5, 5, 5, 5, 5,
+	5, 5, 14, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 65, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 65, 65, 65, 65, 65, 65, 65, 69,
+	69, 69, 36, 36, 36, 6, 6, 6, 6, 6,
+	6, 6, 6, 12, 12, 12, 12, 12, 12, 7,
+	7, 7, 7, 7, 7, 7, 7, 7, 9, 10,
+	10, 55, 55, 13, 13, 13, 13, 51, 51, 51,
+	50, 50, 49, 49, 49, 49, 30, 30, 15, 15,
+	15, 15, 15, 15, 15, 15, 15, 15, 15, 15,
+	15, 15, 15, 15, 48, 48, 48, 48, 48, 48,
+	38, 33, 33, 33, 31, 31, 31, 32, 32, 54,
+	54, 16, 16, 17, 17, 17, 17, 17, 18, 19,
+	19, 20, 21, 21, 22, 23, 24, 25, 25, 25,
+	25, 62, 62, 63, 63, 63, 26, 44, 44, 44,
+	44, 44, 44, 44, 44, 44, 29, 29, 40, 40,
+	40, 56, 56, 47, 47, 47, 67, 67, 68, 68,
+	46, 46, 45, 45, 43, 43, 43, 43, 43, 43,
+	43, 41, 41, 41, 41, 41, 41, 41, 42, 42,
+	42, 42, 42, 42, 42, 60, 60, 61, 61, 27,
+	28, 8, 8, 8, 8, 8, 8, 8, 8, 8,
+	8, 8, 8, 8, 8, 8, 58, 58, 59, 59,
+	59, 59, 57, 57, 57, 57, 57, 57, 57, 57,
+	66, 66, 66, 11, 52, 37, 37, 37, 37, 37,
+	37, 37, 37, 37, 37, 37, 37, 39, 39, 39,
+	39, 39, 39, 39, 39, 39, 39, 39, 39, 39,
+	39, 34, 34, 34, 34, 34, 34, 34, 34, 34,
+	34, 34, 34, 34, 34, 34, 35, 70, 70, 70,
+	70, 71, 71, 71, 53, 53, 64, 64, 64, 64,
+	72, 72,
 }
-var syntaxR2 = [...]int{
-
-	0, 1, 1, 1, 1, 1, 2, 3, 1, 1,
-	1, 1, 1, 1, 3, 8, 2, 3, 4, 5,
-	3, 4, 5, 6, 3, 4, 5, 6, 3, 4,
-	5, 6, 4, 5, 6, 7, 3, 4, 4, 5,
-	3, 2, 3, 6, 3, 1, 1, 1, 4, 6,
-	5, 7, 4, 5, 5, 6, 7, 7, 12, 3,
-	3, 2, 1, 3, 3, 3, 3, 3, 1, 2,
-	1, 2, 2, 2, 2, 2, 2, 2, 2, 2,
-	2, 1, 1, 1, 1, 1, 1, 1, 1, 3,
-	4, 2, 5, 3, 1, 2, 1, 2, 1, 2,
-	1, 2, 1, 2, 2, 3, 2, 2, 1, 3,
-	3, 1, 3, 3, 2, 1, 1, 1, 1, 3,
-	2, 3, 3, 3, 3, 1, 1, 3, 6, 6,
-	1, 1, 3, 3, 3, 3, 3, 3, 3, 3,
+
+var syntaxR2 = [...]int8{
+	0, 1, 2, 1, 1, 1, 1, 1, 2, 3,
+	8, 8, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 3, 8, 2, 3, 4, 5, 3, 4, 5,
+	6, 3, 4, 5, 6, 3, 4, 5, 6, 4,
+	5, 6, 7, 3, 4, 4, 5, 3, 2, 3,
+	6, 3, 1, 1, 1, 4, 6, 5, 7, 4,
+	6, 5, 7, 5, 6, 7, 8, 7, 8, 4,
+	5, 5, 6, 7, 7, 6, 7, 7, 12, 8,
+	10, 1, 3, 3, 4, 6, 6, 3, 3, 2,
+	1, 3, 3, 3, 3, 3, 1, 2, 1, 2,
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 1, 1, 1, 1, 1, 1,
+	1, 1, 3, 4, 2, 5, 3, 1, 2, 1,
+	2, 1, 2, 1, 2, 1, 2, 1, 2, 3,
+	2, 2, 2, 3, 2, 1, 4, 1, 5, 3,
+	7, 3, 3, 1, 3, 3, 2, 1, 1, 1,
+	1, 3, 2, 3, 3, 3, 3, 5, 1, 1,
+	1, 1, 3, 1, 1, 1, 3, 1, 1, 3,
+	6, 6, 1, 1, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 1, 1, 1, 3, 2, 2, 4,
-	4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
-	4, 4, 4, 4, 0, 1, 5, 4, 5, 4,
-	1, 1, 2, 4, 5, 2, 4, 5, 1, 2,
-	2, 4, 1, 1, 1, 1, 1, 1, 1, 1,
+	3, 3, 3, 3, 3, 1, 1, 1, 3, 2,
+	2, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+	4, 4, 4, 4, 4, 4, 0, 1, 5, 4,
+	5, 4, 1, 1, 2, 4, 5, 2, 4, 5,
+	1, 2, 2, 4, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	2, 1, 3, 4, 4, 3, 3, 1, 3,
+	1, 1, 1, 1, 1, 1, 1, 2, 1, 3,
+	3, 2, 4, 4, 1, 3, 4, 4, 3, 3,
+	1, 3,
 }
-var syntaxChk = [...]int{
-
-	-1000, -1, -2, -3, -4, -10, -38, 26, -5, -6,
-	-7, -51, -8, -9, 81, 17, -25, -27, 7, 92,
-	93, 69, -39, 30, 31, 32, 45, 46, 55, 56,
-	57, 58, 59, 60, 61, 65, 66, 67, 33, 36,
-	39, 37, 38, 40, 41, 42, 43, 34, 35, 44,
-	68, 83, 84, 85, 92, 93, 94, 95, 96, 97,
-	86, 87, 90, 91, 88, 89, -21, -11, -23, 51,
-	-22, -35, 23, 24, 25, 15, 87, 16, -3, -4,
-	-2, 26, -37, 18, -36, 5, 26, 26, -49, 28,
-	29, 7, 7, 26, 26, -42, -43, -44, 47, -42,
-	-42, -42, -42, -42, -42, -42, -42, -42, -42, -42,
-	-42, -42, -42, -11, -22, -12, -13, -14, -15, -32,
-	-16, -17, -18, -19, -20, 50, 48, 49, 70, 72,
-	-36, -34, -33, -30, 26, 52, 78, 53, 79, 80,
-	5, -31, -29, 83, 6, -28, 73, 27, 27, -56,
-	-4, 18, 2, 21, 13, 87, 14, 15, -50, 7,
-	-38, 26, -4, 7, 26, 26, 26, -4, 7, -2,
-	74, 75, 76, 77, -2, -2, -2, -2, -2, -2,
-	-2, -2, -2, -2, -2, -2, -2, -2, -32, 84,
-	21, 83, -41, -53, 8, -52, 5, -53, 6, 6,
-	-32, 6, -48, -47, 5, -46, -45, 5, -36, -46,
-	13, 87, 90, 91, 88, 89, 86, -24, 6, -28,
-	26, 27, 21, -36, 6, 6, 6, 6, 2, 27,
-	21, 10, -54, -21, 51, -38, -50, 27, 21, -4,
-	7, -40, 27, 5, -40, 27, 21, 27, 26, 26,
-	26, 26, -32, -32, -32, 8, -53, 21, 13, 27,
-	21, 13, 21, 73, 9, 4, -51, 73, 9, 4,
-	-51, 9, 4, -51, 9, 4, -51, 9, 4, -51,
-	9, 4, -51, 9, 4, -51, 83, 26, 6, 82,
-	-4, -49, -50, -55, -54, -21, 71, 10, 51, 10,
-	-54, 54, 27, -54, -21, 27, -49, -4, 27, 21,
-	21, 27, 27, 6, -40, 27, -40, 27, 27, -40,
-	27, -40, -52, 6, -47, 2, 5, 6, -45, 26,
-	26, -24, 6, 27, 26, 27, -54, -21, -54, 9,
-	-55, -32, -55, 10, 5, -26, 62, 63, 64, 10,
-	27, 27, -54, 27, -4, 5, 21, 27, 27, 27,
-	27, 6, 6, 27, -50, -49, -54, -55, 26, -55,
-	-54, 51, 10, 10, 27, -49, 27, 6, 27, 27,
-	27, 5, -54, -55, -55, 10, 21, 27, -55, 6,
-	21, 6, 27,
+
+var syntaxChk = [...]int16{
+	-1000, -1, -2, 113, -4, -5, -14, -3, -51, 27,
+	-6, -7, -8, -66, -9, -10, -11, -12, -13, 83,
+	18, -34, -35, -37, 102, 7, 123, 124, 71, 103,
+	-52, -39, 111, 31, 32, 33, 46, 47, 56, 57,
+	58, 59, 60, 61, 66, 67, 68, 69, 62, 34,
+	37, 40, 38, 39, 41, 42, 43, 44, 35, 36,
+	45, 70, 88, 89, 90, 91, 92, 93, 94, 95,
+	96, 97, 98, 99, 100, 101, 114, 115, 116, 123,
+	124, 125, 126, 127, 128, 117, 118, 121, 122, 119,
+	120, -29, -40, 49, 51, 112, 107, 108, -30, -15,
+	-32, 52, -31, -48, 24, 25, 26, 16, 118, 17,
+	-4, -5, -2, 27, -50, 19, -49, 5, 27, 27,
+	27, -64, 29, 30, 27, -64, 7, 7, 27, 27,
+	27, 27, 27, -57, -58, -59, 48, -57, -57, -57,
+	-57, -57, -57, -57, -57, -57, -57, -57, -57, -57,
+	-57, 23, 76, 76, -15, -31, -16, -17, -18, -19,
+	-20, -21, -44, -29, -22, -23, -24, -25, -26, -27,
+	-28, 51, 49, 50, 72, 74, 104, 105, -49, -46,
+	-45, -42, 27, 53, 80, 106, 109, 54, 81, 82,
+	5, -43, -41, 114, 6, -38, 75, 28, 28, -72,
+	-5, 19, 2, 22, 14, 118, 15, 16, -65, 7,
+	-5, -51, 27, -65, 7, -51, 27, -5, 7, 27,
+	27, 27, 6, 27, -5, -5, 7, 28, -5, -66,
+	-2, 76, 77, 78, 79, -2, -2, -2, -2, -2,
+	-2, -2, -2, -2, -2, -2, -2, -2, -2, -47,
+	-56, -49, -45, -42, 5, 27, 27, -44, 115, 22,
+	114, -54, -68, 8, -67, 5, -68, 6, 6, -68,
+	6, -44, 6, 5, 29, 110, -63, -62, 5, -61,
+	-60, 5, -49, -61, 14, 118, 121, 122, 119, 120,
+	117, -33, 6, -38, 27, 28, 22, -49, 6, 6,
+	6, 6, 2, 28, 22, 11, -30, 10, -69, 52,
+	-51, -65, 28, 22, -30, -51, 28, 22, -5, 7,
+	-53, 28, 5, -53, 28, 22, 6, 22, 22, 28,
+	28, 22, 22, 27, 27, 27, 27, 23, 14, 118,
+	-53, -53, -44, -44, -44, 8, -68, 22, 14, -68,
+	28, 76, 27, 9, 22, 14, 22, 75, 9, 4,
+	-66, 75, 9, 4, -66, 9, 4, -66, 9, 4,
+	-66, 9, 4, -66, 9, 4, -66, 9, 4, -66,
+	114, 27, 6, 84, -5, -64, -65, -5, 28, -70,
+	73, -71, 85, 10, -69, -70, -69, -30, 10, 52,
+	55, -30, 28, -69, 28, -64, -65, -5, -30, -64,
+	-5, 28, 22, 22, 28, 28, -5, 22, 6, 6,
+	-66, -5, -53, 28, -53, 28, 28, -53, 28, -53,
+	-47, 5, 28, 28, -67, 6, 5, -53, -62, 2,
+	5, 6, -60, 27, 27, -33, 6, 28, 27, 28,
+	11, 28, 9, 73, 7, 86, 87, -70, 10, -69,
+	-30, -69, -70, -44, 5, -36, 63, 64, 65, 28,
+	-69, 10, 28, 28, 11, 28, -5, 5, 28, -5,
+	22, 22, 28, 28, 28, 28, 28, 28, 10, 10,
+	28, 6, 6, 28, -65, -64, 28, -70, -71, 9,
+	27, 27, -70, -69, 27, 10, 28, -70, -69, 52,
+	10, -64, 28, -70, -64, 28, -64, 28, 6, 6,
+	-4, 27, -4, 110, 28, 28, 28, 28, 28, 28,
+	5, -70, 10, -69, -70, 28, 22, 28, 22, -4,
+	9, 28, -70, 6, -55, 6, 22, 28, 22, 6,
+	6, 28,
 }
-var syntaxDef = [...]int{
-
-	0, -2, 1, 2, 3, 4, 5, 0, 8, 9,
-	10, 11, 12, 13, 0, 0, 0, 0, 188, 0,
-	0, 0, 0, 205, 206, 207, 208, 209, 210, 211,
-	212, 213, 214, 215, 216, 217, 218, 219, 193, 194,
-	195, 196, 197, 198, 199, 200, 201, 202, 203, 204,
-	192, 174, 174, 174, 174, 174, 174, 174, 174, 174,
-	174, 174, 174, 174, 174, 174, 6, 68, 70, 0,
-	94, 0, 81, 82, 83, 84, 85, 86, 2, 3,
-	0, 0, 0, 61, 62, 0, 0, 0, 0, 0,
-	0, 189, 190, 0, 0, 0, 180, 181, 175, 0,
+
+var syntaxDef = [...]int16{
+	0, -2, 1, 0, 3, 4, 5, 6, 7, 0,
+	12, 13, 14, 15, 16, 17, 18, 19, 20, 0,
+	0, 0, 0, 0, 0, 240, 0, 0, 0, 0,
+	0, 0, 0, 271, 272, 273, 274, 275, 276, 277,
+	278, 279, 280, 281, 282, 283, 284, 285, 286, 245,
+	246, 247, 248, 249, 250, 251, 252, 253, 254, 255,
+	256, 244, 257, 258, 259, 260, 261, 262, 263, 264,
+	265, 266, 267, 268, 269, 270, 226, 226, 226, 226,
+	226, 226, 226, 226, 226, 226, 226, 226, 226, 226,
+	226, 2, 0, 168, 169, 170, 0, 0, 8, 96,
+	98, 0, 127, 0, 114, 115, 116, 117, 118, 119,
+	3, 4, 0, 0, 0, 89, 90, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 241, 242, 0, 0,
+	0, 0, 0, 0, 232, 233, 227, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 69, 95, 71, 72, 73, 74, 75,
-	76, 77, 78, 79, 80, 98, 100, 0, 102, 0,
-	115, 116, 117, 118, 0, 0, 108, 0, 0, 0,
-	0, 130, 131, 0, 91, 0, 87, 7, 14, 0,
-	-2, 59, 60, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 3, 188, 0, 0, 0, 3, 0, 159,
-	0, 0, 182, 185, 160, 161, 162, 163, 164, 165,
-	166, 167, 168, 169, 170, 171, 172, 173, 120, 0,
-	0, 0, 99, 106, 96, 126, 125, 104, 101, 103,
-	0, 107, 114, 111, 0, 157, 155, 153, 154, 158,
-	0, 0, 0, 0, 0, 0, 0, 93, 88, 0,
-	0, 0, 0, 63, 64, 65, 66, 67, 41, 48,
-	0, 16, 0, 0, 0, 0, 0, 52, 0, 3,
-	188, 0, 225, 221, 0, 226, 0, 191, 0, 0,
-	0, 0, 121, 122, 123, 97, 105, 0, 0, 119,
-	0, 0, 0, 0, 137, 144, 151, 0, 136, 143,
-	150, 132, 139, 146, 133, 140, 147, 134, 141, 148,
-	135, 142, 149, 138, 145, 152, 0, 0, 0, 0,
-	-2, 50, 0, 17, 20, 36, 0, 24, 0, 28,
-	0, 0, 0, 0, 0, 40, 54, 3, 53, 0,
-	0, 223, 224, 0, 0, 177, 0, 179, 183, 0,
-	186, 0, 127, 124, 112, 113, 109, 110, 156, 0,
-	0, 89, 0, 92, 0, 49, 21, 37, 38, 220,
-	25, 44, 29, 32, 42, 0, 45, 46, 47, 18,
-	0, 0, 0, 55, 3, 222, 0, 176, 178, 184,
-	187, 0, 0, 90, 0, 51, 39, 33, 0, 19,
-	22, 0, 26, 30, 0, 56, 57, 0, 128, 129,
-	15, 0, 23, 27, 31, 34, 0, 43, 35, 0,
-	0, 0, 58,
+	0, 0, 0, 0, 97, 128, 99, 100, 101, 102,
+	103, 104, 105, 106, 107, 108, 109, 110, 111, 112,
+	113, -2, -2, 0, 135, 0, 137, 0, 157, 158,
+	159, 160, 0, 0, 145, 0, 147, 0, 0, 0,
+	0, 182, 183, 0, 124, 0, 120, 9, 21, 0,
+	-2, 87, 88, 0, 0, 0, 0, 0, 0, 240,
+	4, 7, 0, 0, 0, 0, 0, 4, 240, 0,
+	0, 0, 0, 0, 4, 4, 0, 83, 4, 0,
+	211, 0, 0, 234, 237, 212, 213, 214, 215, 216,
+	217, 218, 219, 220, 221, 222, 223, 224, 225, 166,
+	0, 173, 174, 175, 171, 0, 0, 162, 0, 0,
+	0, 132, 140, 129, 178, 177, 138, 134, 136, 141,
+	142, 0, 144, 0, 0, 0, 156, 153, 0, 209,
+	207, 205, 206, 210, 0, 0, 0, 0, 0, 0,
+	0, 126, 121, 0, 0, 0, 0, 91, 92, 93,
+	94, 95, 48, 55, 0, 0, 8, 23, 0, 0,
+	7, 0, 59, 0, 0, 0, 69, 0, 4, 240,
+	0, 298, 294, 0, 299, 0, 0, 0, 0, 243,
+	84, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 163, 164, 165, 130, 139, 0, 0, 143,
+	161, 0, 0, 149, 0, 0, 0, 0, 189, 196,
+	203, 0, 188, 195, 202, 184, 191, 198, 185, 192,
+	199, 186, 193, 200, 187, 194, 201, 190, 197, 204,
+	0, 0, 0, 0, -2, 57, 0, 4, 63, 0,
+	0, 288, 0, 35, 0, 24, 27, 43, 31, 0,
+	0, 8, 0, 0, 47, 61, 0, 4, 0, 71,
+	4, 70, 0, 0, 296, 297, 4, 0, 0, 0,
+	0, 4, 0, 229, 0, 231, 235, 0, 238, 0,
+	167, 172, 0, 0, 179, 176, 146, 0, 154, 155,
+	151, 152, 208, 0, 0, 122, 0, 125, 0, 56,
+	0, 64, 287, 0, 291, 0, 0, 36, 39, 28,
+	44, 45, 32, 51, 49, 0, 52, 53, 54, 0,
+	0, 25, 0, 60, 0, 72, 4, 295, 75, 4,
+	0, 0, 85, 86, 228, 230, 236, 239, 0, 0,
+	148, 0, 0, 123, 0, 58, 65, 0, 289, 290,
+	0, 0, 40, 46, 0, 37, 0, 26, 29, 0,
+	33, 62, 67, 0, 73, 74, 76, 77, 0, 0,
+	10, 0, 11, 0, 180, 181, 22, 66, 292, 293,
+	0, 38, 41, 30, 34, 68, 0, 79, 0, 0,
+	150, 50, 42, 0, 0, 81, 0, 80, 0, 0,
+	82, 78,
 }
-var syntaxTok1 = [...]int{
 
+var syntaxTok1 = [...]int8{
 	1,
 }
-var syntaxTok2 = [...]int{
 
+var syntaxTok2 = [...]uint8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
@@ -538,9 +741,13 @@ var syntaxTok2 = [...]int{
 	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
 	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
 	82, 83, 84, 85, 86, 87, 88, 89, 90, 91,
-	92, 93, 94, 95, 96, 97,
+	92, 93, 94, 95, 96, 97, 98, 99, 100, 101,
+	102, 103, 104, 105, 106, 107, 108, 109, 110, 111,
+	112, 113, 114, 115, 116, 117, 118, 119, 120, 121,
+	122, 123, 124, 125, 126, 127, 128,
 }
-var syntaxTok3 = [...]int{
+
+var syntaxTok3 = [...]int8{
 	0,
 }
 
@@ -620,9 +827,9 @@ func syntaxErrorMessage(state, lookAhead int) string {
 	expected := make([]int, 0, 4)
 
 	// Look for shiftable tokens.
-	base := syntaxPact[state]
+	base := int(syntaxPact[state])
 	for tok := TOKSTART; tok-1 < len(syntaxToknames); tok++ {
-		if n := base + tok; n >= 0 && n < syntaxLast && syntaxChk[syntaxAct[n]] == tok {
+		if n := base + tok; n >= 0 && n < syntaxLast && int(syntaxChk[int(syntaxAct[n])]) == tok {
 			if len(expected) == cap(expected) {
 				return res
 			}
@@ -632,13 +839,13 @@ func syntaxErrorMessage(state, lookAhead int) string {
 
 	if syntaxDef[state] == -2 {
 		i := 0
-		for syntaxExca[i] != -1 || syntaxExca[i+1] != state {
+		for syntaxExca[i] != -1 || int(syntaxExca[i+1]) != state {
 			i += 2
 		}
 
 		// Look for tokens that we accept or reduce.
 		for i += 2; syntaxExca[i] >= 0; i += 2 {
-			tok := syntaxExca[i]
+			tok := int(syntaxExca[i])
 			if tok < TOKSTART || syntaxExca[i+1] == 0 {
 				continue
 			}
@@ -669,30 +876,30 @@ func syntaxlex1(lex syntaxLexer, lval *syntaxSymType) (char, token int) {
 	token = 0
 	char = lex.Lex(lval)
 	if char <= 0 {
-		token = syntaxTok1[0]
+		token = int(syntaxTok1[0])
 		goto out
 	}
 	if char < len(syntaxTok1) {
-		token = syntaxTok1[char]
+		token = int(syntaxTok1[char])
 		goto out
 	}
 	if char >= syntaxPrivate {
 		if char < syntaxPrivate+len(syntaxTok2) {
-			token = syntaxTok2[char-syntaxPrivate]
+			token = int(syntaxTok2[char-syntaxPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(syntaxTok3); i += 2 {
-		token = syntaxTok3[i+0]
+		token = int(syntaxTok3[i+0])
 		if token == char {
-			token = syntaxTok3[i+1]
+			token = int(syntaxTok3[i+1])
 			goto out
 		}
 	}
 
 out:
 	if token == 0 {
-		token = syntaxTok2[1] /* unknown char */
+		token = int(syntaxTok2[1]) /* unknown char */
 	}
 	if syntaxDebug >= 3 {
 		__yyfmt__.Printf("lex %s(%d)\n", syntaxTokname(token), uint(char))
@@ -747,7 +954,7 @@ syntaxstack:
 	syntaxS[syntaxp].yys = syntaxstate
 
 syntaxnewstate:
-	syntaxn = syntaxPact[syntaxstate]
+	syntaxn = int(syntaxPact[syntaxstate])
 	if syntaxn <= syntaxFlag {
 		goto syntaxdefault /* simple state */
 	}
@@ -758,8 +965,8 @@ syntaxnewstate:
 	if syntaxn < 0 || syntaxn >= syntaxLast {
 		goto syntaxdefault
 	}
-	syntaxn = syntaxAct[syntaxn]
-	if syntaxChk[syntaxn] == syntaxtoken { /* valid shift */
+	syntaxn = int(syntaxAct[syntaxn])
+	if int(syntaxChk[syntaxn]) == syntaxtoken { /* valid shift */
 		syntaxrcvr.char = -1
 		syntaxtoken = -1
 		syntaxVAL = syntaxrcvr.lval
@@ -772,7 +979,7 @@ syntaxnewstate:
 
 syntaxdefault:
 	/* default state action */
-	syntaxn = syntaxDef[syntaxstate]
+	syntaxn = int(syntaxDef[syntaxstate])
 	if syntaxn == -2 {
 		if syntaxrcvr.char < 0 {
 			syntaxrcvr.char, syntaxtoken = syntaxlex1(syntaxlex, &syntaxrcvr.lval)
@@ -781,18 +988,18 @@ syntaxdefault:
 		/* look through exception table */
 		xi := 0
 		for {
-			if syntaxExca[xi+0] == -1 && syntaxExca[xi+1] == syntaxstate {
+			if syntaxExca[xi+0] == -1 && int(syntaxExca[xi+1]) == syntaxstate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			syntaxn = syntaxExca[xi+0]
+			syntaxn = int(syntaxExca[xi+0])
 			if syntaxn < 0 || syntaxn == syntaxtoken {
 				break
 			}
 		}
-		syntaxn = syntaxExca[xi+1]
+		syntaxn = int(syntaxExca[xi+1])
 		if syntaxn < 0 {
 			goto ret0
 		}
@@ -814,10 +1021,10 @@ syntaxdefault:
 
 			/* find a state where "error" is a legal shift action */
 			for syntaxp >= 0 {
-				syntaxn = syntaxPact[syntaxS[syntaxp].yys] + syntaxErrCode
+				syntaxn = int(syntaxPact[syntaxS[syntaxp].yys]) + syntaxErrCode
 				if syntaxn >= 0 && syntaxn < syntaxLast {
-					syntaxstate = syntaxAct[syntaxn] /* simulate a shift of "error" */
-					if syntaxChk[syntaxstate] == syntaxErrCode {
+					syntaxstate = int(syntaxAct[syntaxn]) /* simulate a shift of "error" */
+					if int(syntaxChk[syntaxstate]) == syntaxErrCode {
 						goto syntaxstack
 					}
 				}
@@ -853,7 +1060,7 @@ syntaxdefault:
 	syntaxpt := syntaxp
 	_ = syntaxpt // guard against "declared and not used"
 
-	syntaxp -= syntaxR2[syntaxn]
+	syntaxp -= int(syntaxR2[syntaxn])
 	// syntaxp is now the index of $0. Perform the default action. Iff the
 	// reduced production is ε, $1 is possibly out of range.
 	if syntaxp+1 >= len(syntaxS) {
@@ -864,16 +1071,16 @@ syntaxdefault:
 	syntaxVAL = syntaxS[syntaxp+1]
 
 	/* consult goto table to find next state */
-	syntaxn = syntaxR1[syntaxn]
-	syntaxg := syntaxPgo[syntaxn]
+	syntaxn = int(syntaxR1[syntaxn])
+	syntaxg := int(syntaxPgo[syntaxn])
 	syntaxj := syntaxg + syntaxS[syntaxp].yys + 1
 
 	if syntaxj >= syntaxLast {
-		syntaxstate = syntaxAct[syntaxg]
+		syntaxstate = int(syntaxAct[syntaxg])
 	} else {
-		syntaxstate = syntaxAct[syntaxj]
-		if syntaxChk[syntaxstate] != -syntaxn {
-			syntaxstate = syntaxAct[syntaxg]
+		syntaxstate = int(syntaxAct[syntaxj])
+		if int(syntaxChk[syntaxstate]) != -syntaxn {
+			syntaxstate = int(syntaxAct[syntaxg])
 		}
 	}
 	// dummy call; replaced with literal code
@@ -885,1137 +1092,1502 @@ syntaxdefault:
 			syntaxlex.(*parser).expr = syntaxDollar[1].expr
 		}
 	case 2:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxlex.(*parser).expr = syntaxDollar[2].stage
+		}
+	case 3:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.expr = syntaxDollar[1].logExpr
 		}
-	case 3:
+	case 4:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.expr = syntaxDollar[1].metricExpr
 		}
-	case 4:
+	case 5:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.expr = syntaxDollar[1].variantsExpr
 		}
-	case 5:
+	case 6:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.expr = syntaxDollar[1].expr
+		}
+	case 7:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.logExpr = newMatcherExpr(syntaxDollar[1].matchers)
 		}
-	case 6:
+	case 8:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.logExpr = newPipelineExpr(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[2].stages)
 		}
-	case 7:
+	case 9:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.logExpr = syntaxDollar[2].logExpr
 		}
-	case 8:
+	case 10:
+		syntaxDollar = syntaxS[syntaxpt-8 : syntaxpt+1]
+		{
+			syntaxVAL.expr = newJoinExpr(syntaxDollar[1].logExpr, OpJoin, syntaxDollar[5].strs, syntaxDollar[7].dur, syntaxDollar[8].logExpr)
+		}
+	case 11:
+		syntaxDollar = syntaxS[syntaxpt-8 : syntaxpt+1]
+		{
+			syntaxVAL.expr = newJoinExpr(syntaxDollar[1].logExpr, OpLeftJoin, syntaxDollar[5].strs, syntaxDollar[7].dur, syntaxDollar[8].logExpr)
+		}
+	case 12:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = syntaxDollar[1].metricExpr
 		}
-	case 9:
+	case 13:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = syntaxDollar[1].metricExpr
 		}
-	case 10:
+	case 14:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = syntaxDollar[1].metricExpr
 		}
-	case 11:
+	case 15:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = syntaxDollar[1].literalExpr
 		}
-	case 12:
+	case 16:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = syntaxDollar[1].metricExpr
 		}
-	case 13:
+	case 17:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = syntaxDollar[1].metricExpr
 		}
-	case 14:
+	case 18:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.metricExpr = syntaxDollar[1].metricExpr
+		}
+	case 19:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.metricExpr = syntaxDollar[1].metricExpr
+		}
+	case 20:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.metricExpr = syntaxDollar[1].metricExpr
+		}
+	case 21:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = syntaxDollar[2].metricExpr
 		}
-	case 15:
+	case 22:
 		syntaxDollar = syntaxS[syntaxpt-8 : syntaxpt+1]
 		{
 			syntaxVAL.variantsExpr = newVariantsExpr(syntaxDollar[3].metricExprs, syntaxDollar[7].logRangeExpr)
 		}
-	case 16:
+	case 23:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[2].dur, nil, nil)
 		}
-	case 17:
+	case 24:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[2].dur, nil, syntaxDollar[3].offsetExpr)
 		}
-	case 18:
+	case 25:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[4].dur, nil, nil)
 		}
-	case 19:
+	case 26:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[4].dur, nil, syntaxDollar[5].offsetExpr)
 		}
-	case 20:
+	case 27:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[2].dur, syntaxDollar[3].unwrapExpr, nil)
 		}
-	case 21:
+	case 28:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[2].dur, syntaxDollar[4].unwrapExpr, syntaxDollar[3].offsetExpr)
 		}
-	case 22:
+	case 29:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[4].dur, syntaxDollar[5].unwrapExpr, nil)
 		}
-	case 23:
+	case 30:
 		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[4].dur, syntaxDollar[6].unwrapExpr, syntaxDollar[5].offsetExpr)
 		}
-	case 24:
+	case 31:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[3].dur, syntaxDollar[2].unwrapExpr, nil)
 		}
-	case 25:
+	case 32:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[3].dur, syntaxDollar[2].unwrapExpr, syntaxDollar[4].offsetExpr)
 		}
-	case 26:
+	case 33:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[5].dur, syntaxDollar[3].unwrapExpr, nil)
 		}
-	case 27:
+	case 34:
 		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[5].dur, syntaxDollar[3].unwrapExpr, syntaxDollar[6].offsetExpr)
 		}
-	case 28:
+	case 35:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[2].stages), syntaxDollar[3].dur, nil, nil)
 		}
-	case 29:
+	case 36:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[2].stages), syntaxDollar[3].dur, nil, syntaxDollar[4].offsetExpr)
 		}
-	case 30:
+	case 37:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[3].stages), syntaxDollar[5].dur, nil, nil)
 		}
-	case 31:
+	case 38:
 		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[3].stages), syntaxDollar[5].dur, nil, syntaxDollar[6].offsetExpr)
 		}
-	case 32:
+	case 39:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[2].stages), syntaxDollar[4].dur, syntaxDollar[3].unwrapExpr, nil)
 		}
-	case 33:
+	case 40:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[2].stages), syntaxDollar[4].dur, syntaxDollar[3].unwrapExpr, syntaxDollar[5].offsetExpr)
 		}
-	case 34:
+	case 41:
 		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[3].stages), syntaxDollar[6].dur, syntaxDollar[4].unwrapExpr, nil)
 		}
-	case 35:
+	case 42:
 		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[2].matchers), syntaxDollar[3].stages), syntaxDollar[6].dur, syntaxDollar[4].unwrapExpr, syntaxDollar[7].offsetExpr)
 		}
-	case 36:
+	case 43:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[3].stages), syntaxDollar[2].dur, nil, nil)
 		}
-	case 37:
+	case 44:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[4].stages), syntaxDollar[2].dur, nil, syntaxDollar[3].offsetExpr)
 		}
-	case 38:
+	case 45:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[3].stages), syntaxDollar[2].dur, syntaxDollar[4].unwrapExpr, nil)
 		}
-	case 39:
+	case 46:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = newLogRange(newPipelineExpr(newMatcherExpr(syntaxDollar[1].matchers), syntaxDollar[4].stages), syntaxDollar[2].dur, syntaxDollar[5].unwrapExpr, syntaxDollar[3].offsetExpr)
 		}
-	case 40:
+	case 47:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.logRangeExpr = syntaxDollar[2].logRangeExpr
 		}
-	case 42:
+	case 49:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.unwrapExpr = newUnwrapExpr(syntaxDollar[3].str, "")
 		}
-	case 43:
+	case 50:
 		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
 			syntaxVAL.unwrapExpr = newUnwrapExpr(syntaxDollar[5].str, syntaxDollar[3].op)
 		}
-	case 44:
+	case 51:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.unwrapExpr = syntaxDollar[1].unwrapExpr.addPostFilter(syntaxDollar[3].filterer)
 		}
-	case 45:
+	case 52:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpConvBytes
 		}
-	case 46:
+	case 53:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpConvDuration
 		}
-	case 47:
+	case 54:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpConvDurationSeconds
 		}
-	case 48:
+	case 55:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = newRangeAggregationExpr(syntaxDollar[3].logRangeExpr, syntaxDollar[1].op, nil, nil)
 		}
-	case 49:
+	case 56:
 		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = newRangeAggregationExpr(syntaxDollar[5].logRangeExpr, syntaxDollar[1].op, nil, &syntaxDollar[3].str)
 		}
-	case 50:
+	case 57:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = newRangeAggregationExpr(syntaxDollar[3].logRangeExpr, syntaxDollar[1].op, syntaxDollar[5].grouping, nil)
 		}
-	case 51:
+	case 58:
 		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = newRangeAggregationExpr(syntaxDollar[5].logRangeExpr, syntaxDollar[1].op, syntaxDollar[7].grouping, &syntaxDollar[3].str)
 		}
-	case 52:
-		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
-		{
-			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[3].metricExpr, syntaxDollar[1].op, nil, nil)
-		}
-	case 53:
-		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
-		{
-			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[4].metricExpr, syntaxDollar[1].op, syntaxDollar[2].grouping, nil)
-		}
-	case 54:
-		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
-		{
-			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[3].metricExpr, syntaxDollar[1].op, syntaxDollar[5].grouping, nil)
-		}
-	case 55:
-		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
-		{
-			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[5].metricExpr, syntaxDollar[1].op, nil, &syntaxDollar[3].str)
-		}
-	case 56:
-		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
-		{
-			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[5].metricExpr, syntaxDollar[1].op, syntaxDollar[7].grouping, &syntaxDollar[3].str)
-		}
-	case 57:
-		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
-		{
-			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[6].metricExpr, syntaxDollar[1].op, syntaxDollar[2].grouping, &syntaxDollar[4].str)
-		}
-	case 58:
-		syntaxDollar = syntaxS[syntaxpt-12 : syntaxpt+1]
-		{
-			syntaxVAL.metricExpr = mustNewLabelReplaceExpr(syntaxDollar[3].metricExpr, syntaxDollar[5].str, syntaxDollar[7].str, syntaxDollar[9].str, syntaxDollar[11].str)
-		}
 	case 59:
-		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
-			syntaxVAL.matchers = syntaxDollar[2].matchers
+			syntaxVAL.metricExpr = newRangeAggregationExpr(syntaxDollar[3].logRangeExpr, syntaxDollar[1].op, nil, nil)
 		}
 	case 60:
-		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
-			syntaxVAL.matchers = syntaxDollar[2].matchers
+			syntaxVAL.metricExpr = newRangeAggregationExpr(syntaxDollar[5].logRangeExpr, syntaxDollar[1].op, nil, &syntaxDollar[3].str)
 		}
 	case 61:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
+			syntaxVAL.metricExpr = newRangeAggregationExpr(syntaxDollar[3].logRangeExpr, syntaxDollar[1].op, syntaxDollar[5].grouping, nil)
 		}
 	case 62:
-		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
 		{
-			syntaxVAL.matchers = []*labels.Matcher{syntaxDollar[1].matcher}
+			syntaxVAL.metricExpr = newRangeAggregationExpr(syntaxDollar[5].logRangeExpr, syntaxDollar[1].op, syntaxDollar[7].grouping, &syntaxDollar[3].str)
 		}
 	case 63:
-		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
-			syntaxVAL.matchers = append(syntaxDollar[1].matchers, syntaxDollar[3].matcher)
+			syntaxVAL.metricExpr = newSubqueryExpr(syntaxDollar[3].metricExpr, syntaxDollar[1].op, syntaxDollar[4].subqueryRange, nil, nil)
 		}
 	case 64:
-		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
-			syntaxVAL.matcher = mustNewMatcher(labels.MatchEqual, syntaxDollar[1].str, syntaxDollar[3].str)
+			syntaxVAL.metricExpr = newSubqueryExpr(syntaxDollar[3].metricExpr, syntaxDollar[1].op, syntaxDollar[4].subqueryRange, syntaxDollar[5].offsetExpr, nil)
 		}
 	case 65:
-		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
 		{
-			syntaxVAL.matcher = mustNewMatcher(labels.MatchNotEqual, syntaxDollar[1].str, syntaxDollar[3].str)
+			syntaxVAL.metricExpr = newSubqueryExpr(syntaxDollar[5].metricExpr, syntaxDollar[1].op, syntaxDollar[6].subqueryRange, nil, &syntaxDollar[3].str)
 		}
 	case 66:
-		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-8 : syntaxpt+1]
 		{
-			syntaxVAL.matcher = mustNewMatcher(labels.MatchRegexp, syntaxDollar[1].str, syntaxDollar[3].str)
+			syntaxVAL.metricExpr = newSubqueryExpr(syntaxDollar[5].metricExpr, syntaxDollar[1].op, syntaxDollar[6].subqueryRange, syntaxDollar[7].offsetExpr, &syntaxDollar[3].str)
 		}
 	case 67:
-		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
 		{
-			syntaxVAL.matcher = mustNewMatcher(labels.MatchNotRegexp, syntaxDollar[1].str, syntaxDollar[3].str)
+			syntaxVAL.metricExpr = newSubqueryExpr(syntaxDollar[5].metricExpr, syntaxDollar[1].op, syntaxDollar[6].subqueryRange, nil, &syntaxDollar[3].str)
 		}
 	case 68:
-		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-8 : syntaxpt+1]
 		{
-			syntaxVAL.stages = MultiStageExpr{syntaxDollar[1].stage}
+			syntaxVAL.metricExpr = newSubqueryExpr(syntaxDollar[5].metricExpr, syntaxDollar[1].op, syntaxDollar[6].subqueryRange, syntaxDollar[7].offsetExpr, &syntaxDollar[3].str)
 		}
 	case 69:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
-			syntaxVAL.stages = append(syntaxDollar[1].stages, syntaxDollar[2].stage)
+			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[3].metricExpr, syntaxDollar[1].op, nil, nil)
 		}
 	case 70:
-		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[1].lineFilterExpr
+			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[4].metricExpr, syntaxDollar[1].op, syntaxDollar[2].grouping, nil)
 		}
 	case 71:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[2].stage
+			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[3].metricExpr, syntaxDollar[1].op, syntaxDollar[5].grouping, nil)
 		}
 	case 72:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[2].stage
+			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[5].metricExpr, syntaxDollar[1].op, nil, &syntaxDollar[3].str)
 		}
 	case 73:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[2].stage
+			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[5].metricExpr, syntaxDollar[1].op, syntaxDollar[7].grouping, &syntaxDollar[3].str)
 		}
 	case 74:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[2].stage
+			syntaxVAL.metricExpr = mustNewVectorAggregationExpr(syntaxDollar[6].metricExpr, syntaxDollar[1].op, syntaxDollar[2].grouping, &syntaxDollar[4].str)
 		}
 	case 75:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
-			syntaxVAL.stage = &LabelFilterExpr{LabelFilterer: syntaxDollar[2].filterer}
+			syntaxVAL.metricExpr = mustNewCountValuesExpr(syntaxDollar[5].metricExpr, syntaxDollar[3].str, nil)
 		}
 	case 76:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[2].stage
+			syntaxVAL.metricExpr = mustNewCountValuesExpr(syntaxDollar[5].metricExpr, syntaxDollar[3].str, syntaxDollar[7].grouping)
 		}
 	case 77:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[2].stage
+			syntaxVAL.metricExpr = mustNewCountValuesExpr(syntaxDollar[6].metricExpr, syntaxDollar[4].str, syntaxDollar[2].grouping)
 		}
 	case 78:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-12 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[2].stage
+			syntaxVAL.metricExpr = mustNewLabelReplaceExpr(syntaxDollar[3].metricExpr, syntaxDollar[5].str, syntaxDollar[7].str, syntaxDollar[9].str, syntaxDollar[11].str)
 		}
 	case 79:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-8 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[2].stage
+			syntaxVAL.metricExpr = mustNewLabelJoinExpr(syntaxDollar[3].metricExpr, syntaxDollar[5].str, syntaxDollar[7].str, nil)
 		}
 	case 80:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-10 : syntaxpt+1]
 		{
-			syntaxVAL.stage = syntaxDollar[2].stage
+			syntaxVAL.metricExpr = mustNewLabelJoinExpr(syntaxDollar[3].metricExpr, syntaxDollar[5].str, syntaxDollar[7].str, syntaxDollar[9].strs)
 		}
 	case 81:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
-			syntaxVAL.filter = log.LineMatchRegexp
+			syntaxVAL.strs = []string{syntaxDollar[1].str}
 		}
 	case 82:
-		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
-			syntaxVAL.filter = log.LineMatchEqual
+			syntaxVAL.strs = append(syntaxDollar[1].strs, syntaxDollar[3].str)
 		}
 	case 83:
-		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
-			syntaxVAL.filter = log.LineMatchPattern
+			syntaxVAL.metricExpr = mustNewFunctionExpr(syntaxDollar[1].op, nil, nil)
 		}
 	case 84:
+		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
+		{
+			syntaxVAL.metricExpr = mustNewFunctionExpr(syntaxDollar[1].op, syntaxDollar[3].metricExpr, nil)
+		}
+	case 85:
+		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
+		{
+			syntaxVAL.metricExpr = mustNewFunctionExpr(syntaxDollar[1].op, syntaxDollar[3].metricExpr, syntaxDollar[5].literalExpr)
+		}
+	case 86:
+		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
+		{
+			syntaxVAL.metricExpr = mustNewFunctionExpr(OpFuncHistogramQuantile, syntaxDollar[5].metricExpr, syntaxDollar[3].literalExpr)
+		}
+	case 87:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.matchers = syntaxDollar[2].matchers
+		}
+	case 88:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.matchers = syntaxDollar[2].matchers
+		}
+	case 89:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+		}
+	case 90:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.matchers = []*labels.Matcher{syntaxDollar[1].matcher}
+		}
+	case 91:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.matchers = append(syntaxDollar[1].matchers, syntaxDollar[3].matcher)
+		}
+	case 92:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.matcher = mustNewMatcher(labels.MatchEqual, syntaxDollar[1].str, syntaxDollar[3].str)
+		}
+	case 93:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.matcher = mustNewMatcher(labels.MatchNotEqual, syntaxDollar[1].str, syntaxDollar[3].str)
+		}
+	case 94:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.matcher = mustNewMatcher(labels.MatchRegexp, syntaxDollar[1].str, syntaxDollar[3].str)
+		}
+	case 95:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.matcher = mustNewMatcher(labels.MatchNotRegexp, syntaxDollar[1].str, syntaxDollar[3].str)
+		}
+	case 96:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.stages = MultiStageExpr{syntaxDollar[1].stage}
+		}
+	case 97:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stages = append(syntaxDollar[1].stages, syntaxDollar[2].stage)
+		}
+	case 98:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[1].lineFilterExpr
+		}
+	case 99:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 100:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 101:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 102:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 103:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 104:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 105:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = &LabelFilterExpr{LabelFilterer: syntaxDollar[2].filterer}
+		}
+	case 106:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 107:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 108:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 109:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 110:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 111:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 112:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 113:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = syntaxDollar[2].stage
+		}
+	case 114:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.filter = log.LineMatchRegexp
+		}
+	case 115:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.filter = log.LineMatchEqual
+		}
+	case 116:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.filter = log.LineMatchPattern
+		}
+	case 117:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.filter = log.LineMatchNotRegexp
 		}
-	case 85:
+	case 118:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.filter = log.LineMatchNotEqual
 		}
-	case 86:
+	case 119:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.filter = log.LineMatchNotPattern
 		}
-	case 87:
+	case 120:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpFilterIP
 		}
-	case 88:
+	case 121:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.lineFilterExpr = newLineFilterExpr(log.LineMatchEqual, "", syntaxDollar[1].str)
 		}
-	case 89:
+	case 122:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.lineFilterExpr = newOrLineFilterExpr(newLineFilterExpr(log.LineMatchEqual, "", syntaxDollar[1].str), syntaxDollar[3].lineFilterExpr)
 		}
-	case 90:
+	case 123:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.lineFilterExpr = newLineFilterExpr(log.LineMatchEqual, syntaxDollar[1].op, syntaxDollar[3].str)
 		}
-	case 91:
+	case 124:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.lineFilterExpr = newLineFilterExpr(syntaxDollar[1].filter, "", syntaxDollar[2].str)
 		}
-	case 92:
+	case 125:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.lineFilterExpr = newLineFilterExpr(syntaxDollar[1].filter, syntaxDollar[2].op, syntaxDollar[4].str)
 		}
-	case 93:
+	case 126:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.lineFilterExpr = newOrLineFilterExpr(syntaxDollar[1].lineFilterExpr, syntaxDollar[3].lineFilterExpr)
 		}
-	case 94:
+	case 127:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.lineFilterExpr = syntaxDollar[1].lineFilterExpr
 		}
-	case 95:
+	case 128:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.lineFilterExpr = newNestedLineFilterExpr(syntaxDollar[1].lineFilterExpr, syntaxDollar[2].lineFilterExpr)
 		}
-	case 96:
+	case 129:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.strs = []string{syntaxDollar[1].str}
 		}
-	case 97:
+	case 130:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.strs = append(syntaxDollar[1].strs, syntaxDollar[2].str)
 		}
-	case 98:
+	case 131:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLogfmtParserExpr(nil)
 		}
-	case 99:
+	case 132:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLogfmtParserExpr(syntaxDollar[2].strs)
 		}
-	case 100:
+	case 133:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLabelParserExpr(OpParserTypeJSON, "")
 		}
-	case 101:
+	case 134:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLabelParserExpr(OpParserTypeRegexp, syntaxDollar[2].str)
 		}
-	case 102:
+	case 135:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLabelParserExpr(OpParserTypeUnpack, "")
 		}
-	case 103:
+	case 136:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLabelParserExpr(OpParserTypePattern, syntaxDollar[2].str)
 		}
-	case 104:
+	case 137:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newLabelParserExpr(OpParserTypeXML, "")
+		}
+	case 138:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newJSONExpressionParser(syntaxDollar[2].labelExtractionExpressionList)
 		}
-	case 105:
+	case 139:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLogfmtExpressionParser(syntaxDollar[3].labelExtractionExpressionList, syntaxDollar[2].strs)
 		}
-	case 106:
+	case 140:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLogfmtExpressionParser(syntaxDollar[2].labelExtractionExpressionList, nil)
 		}
-	case 107:
+	case 141:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newXMLExpressionParser(syntaxDollar[2].labelExtractionExpressionList)
+		}
+	case 142:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newCSVParserExpr(syntaxDollar[2].str, nil)
+		}
+	case 143:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newCSVParserExpr(syntaxDollar[2].str, syntaxDollar[3].labelExtractionExpressionList)
+		}
+	case 144:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLineFmtExpr(syntaxDollar[2].str)
 		}
-	case 108:
+	case 145:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newDecolorizeExpr()
 		}
-	case 109:
+	case 146:
+		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newLookupExpr(syntaxDollar[2].str, syntaxDollar[4].str)
+		}
+	case 147:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newDedupExpr(nil, 0)
+		}
+	case 148:
+		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newDedupExpr(syntaxDollar[4].strs, 0)
+		}
+	case 149:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newDedupExpr(nil, syntaxDollar[3].dur)
+		}
+	case 150:
+		syntaxDollar = syntaxS[syntaxpt-7 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newDedupExpr(syntaxDollar[4].strs, syntaxDollar[7].dur)
+		}
+	case 151:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.labelFormat = log.NewRenameLabelFmt(syntaxDollar[1].str, syntaxDollar[3].str)
 		}
-	case 110:
+	case 152:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.labelFormat = log.NewTemplateLabelFmt(syntaxDollar[1].str, syntaxDollar[3].str)
 		}
-	case 111:
+	case 153:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.labelsFormat = []log.LabelFmt{syntaxDollar[1].labelFormat}
 		}
-	case 112:
+	case 154:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.labelsFormat = append(syntaxDollar[1].labelsFormat, syntaxDollar[3].labelFormat)
 		}
-	case 114:
+	case 156:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newLabelFmtExpr(syntaxDollar[2].labelsFormat)
 		}
-	case 115:
+	case 157:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewStringLabelFilter(syntaxDollar[1].matcher)
 		}
-	case 116:
+	case 158:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = syntaxDollar[1].filterer
 		}
-	case 117:
+	case 159:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = syntaxDollar[1].filterer
 		}
-	case 118:
+	case 160:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = syntaxDollar[1].filterer
 		}
-	case 119:
+	case 161:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.filterer = syntaxDollar[2].filterer
+		}
+	case 162:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.filterer = log.NewAndLabelFilter(syntaxDollar[1].filterer, syntaxDollar[2].filterer)
+		}
+	case 163:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.filterer = log.NewAndLabelFilter(syntaxDollar[1].filterer, syntaxDollar[3].filterer)
+		}
+	case 164:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.filterer = log.NewAndLabelFilter(syntaxDollar[1].filterer, syntaxDollar[3].filterer)
+		}
+	case 165:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.filterer = log.NewOrLabelFilter(syntaxDollar[1].filterer, syntaxDollar[3].filterer)
+		}
+	case 166:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
-			syntaxVAL.filterer = syntaxDollar[2].filterer
+			syntaxVAL.stage = newFieldFilterExpr(syntaxDollar[1].op, nil, syntaxDollar[3].filterer)
+		}
+	case 167:
+		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
+		{
+			syntaxVAL.stage = newFieldFilterExpr(syntaxDollar[1].op, syntaxDollar[3].strs, syntaxDollar[5].filterer)
+		}
+	case 168:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpParserTypeJSON
+		}
+	case 169:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpParserTypeLogfmt
+		}
+	case 170:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFieldFilterMetadata
 		}
-	case 120:
-		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+	case 171:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
-			syntaxVAL.filterer = log.NewAndLabelFilter(syntaxDollar[1].filterer, syntaxDollar[2].filterer)
+			syntaxVAL.strs = []string{syntaxDollar[1].str}
 		}
-	case 121:
+	case 172:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
-			syntaxVAL.filterer = log.NewAndLabelFilter(syntaxDollar[1].filterer, syntaxDollar[3].filterer)
+			syntaxVAL.strs = append(syntaxDollar[1].strs, syntaxDollar[3].str)
 		}
-	case 122:
-		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+	case 173:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
-			syntaxVAL.filterer = log.NewAndLabelFilter(syntaxDollar[1].filterer, syntaxDollar[3].filterer)
+			syntaxVAL.filterer = log.NewStringLabelFilter(syntaxDollar[1].matcher)
 		}
-	case 123:
-		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+	case 174:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
-			syntaxVAL.filterer = log.NewOrLabelFilter(syntaxDollar[1].filterer, syntaxDollar[3].filterer)
+			syntaxVAL.filterer = syntaxDollar[1].filterer
 		}
-	case 124:
+	case 175:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.filterer = syntaxDollar[1].filterer
+		}
+	case 176:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.labelExtractionExpression = log.NewLabelExtractionExpr(syntaxDollar[1].str, syntaxDollar[3].str)
 		}
-	case 125:
+	case 177:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.labelExtractionExpression = log.NewLabelExtractionExpr(syntaxDollar[1].str, syntaxDollar[1].str)
 		}
-	case 126:
+	case 178:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.labelExtractionExpressionList = []log.LabelExtractionExpr{syntaxDollar[1].labelExtractionExpression}
 		}
-	case 127:
+	case 179:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.labelExtractionExpressionList = append(syntaxDollar[1].labelExtractionExpressionList, syntaxDollar[3].labelExtractionExpression)
 		}
-	case 128:
+	case 180:
 		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewIPLabelFilter(syntaxDollar[5].str, syntaxDollar[1].str, log.LabelFilterEqual)
 		}
-	case 129:
+	case 181:
 		syntaxDollar = syntaxS[syntaxpt-6 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewIPLabelFilter(syntaxDollar[5].str, syntaxDollar[1].str, log.LabelFilterNotEqual)
 		}
-	case 130:
+	case 182:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = syntaxDollar[1].filterer
 		}
-	case 131:
+	case 183:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = syntaxDollar[1].filterer
 		}
-	case 132:
+	case 184:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewDurationLabelFilter(log.LabelFilterGreaterThan, syntaxDollar[1].str, syntaxDollar[3].dur)
 		}
-	case 133:
+	case 185:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewDurationLabelFilter(log.LabelFilterGreaterThanOrEqual, syntaxDollar[1].str, syntaxDollar[3].dur)
 		}
-	case 134:
+	case 186:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewDurationLabelFilter(log.LabelFilterLesserThan, syntaxDollar[1].str, syntaxDollar[3].dur)
 		}
-	case 135:
+	case 187:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewDurationLabelFilter(log.LabelFilterLesserThanOrEqual, syntaxDollar[1].str, syntaxDollar[3].dur)
 		}
-	case 136:
+	case 188:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewDurationLabelFilter(log.LabelFilterNotEqual, syntaxDollar[1].str, syntaxDollar[3].dur)
 		}
-	case 137:
+	case 189:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewDurationLabelFilter(log.LabelFilterEqual, syntaxDollar[1].str, syntaxDollar[3].dur)
 		}
-	case 138:
+	case 190:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewDurationLabelFilter(log.LabelFilterEqual, syntaxDollar[1].str, syntaxDollar[3].dur)
 		}
-	case 139:
+	case 191:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewBytesLabelFilter(log.LabelFilterGreaterThan, syntaxDollar[1].str, syntaxDollar[3].bytes)
 		}
-	case 140:
+	case 192:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewBytesLabelFilter(log.LabelFilterGreaterThanOrEqual, syntaxDollar[1].str, syntaxDollar[3].bytes)
 		}
-	case 141:
+	case 193:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewBytesLabelFilter(log.LabelFilterLesserThan, syntaxDollar[1].str, syntaxDollar[3].bytes)
 		}
-	case 142:
+	case 194:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewBytesLabelFilter(log.LabelFilterLesserThanOrEqual, syntaxDollar[1].str, syntaxDollar[3].bytes)
 		}
-	case 143:
+	case 195:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewBytesLabelFilter(log.LabelFilterNotEqual, syntaxDollar[1].str, syntaxDollar[3].bytes)
 		}
-	case 144:
+	case 196:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewBytesLabelFilter(log.LabelFilterEqual, syntaxDollar[1].str, syntaxDollar[3].bytes)
 		}
-	case 145:
+	case 197:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewBytesLabelFilter(log.LabelFilterEqual, syntaxDollar[1].str, syntaxDollar[3].bytes)
 		}
-	case 146:
+	case 198:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewNumericLabelFilter(log.LabelFilterGreaterThan, syntaxDollar[1].str, syntaxDollar[3].literalExpr.Val)
 		}
-	case 147:
+	case 199:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewNumericLabelFilter(log.LabelFilterGreaterThanOrEqual, syntaxDollar[1].str, syntaxDollar[3].literalExpr.Val)
 		}
-	case 148:
+	case 200:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewNumericLabelFilter(log.LabelFilterLesserThan, syntaxDollar[1].str, syntaxDollar[3].literalExpr.Val)
 		}
-	case 149:
+	case 201:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewNumericLabelFilter(log.LabelFilterLesserThanOrEqual, syntaxDollar[1].str, syntaxDollar[3].literalExpr.Val)
 		}
-	case 150:
+	case 202:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewNumericLabelFilter(log.LabelFilterNotEqual, syntaxDollar[1].str, syntaxDollar[3].literalExpr.Val)
 		}
-	case 151:
+	case 203:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewNumericLabelFilter(log.LabelFilterEqual, syntaxDollar[1].str, syntaxDollar[3].literalExpr.Val)
 		}
-	case 152:
+	case 204:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.filterer = log.NewNumericLabelFilter(log.LabelFilterEqual, syntaxDollar[1].str, syntaxDollar[3].literalExpr.Val)
 		}
-	case 153:
+	case 205:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.namedMatcher = log.NewNamedLabelMatcher(nil, syntaxDollar[1].str)
 		}
-	case 154:
+	case 206:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.namedMatcher = log.NewNamedLabelMatcher(syntaxDollar[1].matcher, "")
 		}
-	case 155:
+	case 207:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.namedMatchers = []log.NamedLabelMatcher{syntaxDollar[1].namedMatcher}
 		}
-	case 156:
+	case 208:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.namedMatchers = append(syntaxDollar[1].namedMatchers, syntaxDollar[3].namedMatcher)
 		}
-	case 157:
+	case 209:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newDropLabelsExpr(syntaxDollar[2].namedMatchers)
 		}
-	case 158:
+	case 210:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.stage = newKeepLabelsExpr(syntaxDollar[2].namedMatchers)
 		}
-	case 159:
+	case 211:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("or", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 160:
+	case 212:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("and", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 161:
+	case 213:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("unless", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 162:
+	case 214:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("+", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 163:
+	case 215:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("-", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 164:
+	case 216:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("*", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 165:
+	case 217:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("/", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 166:
+	case 218:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("%", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 167:
+	case 219:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("^", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 168:
+	case 220:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("==", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 169:
+	case 221:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("!=", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 170:
+	case 222:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr(">", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 171:
+	case 223:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr(">=", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 172:
+	case 224:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("<", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 173:
+	case 225:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = mustNewBinOpExpr("<=", syntaxDollar[3].binOpts, syntaxDollar[1].expr, syntaxDollar[4].expr)
 		}
-	case 174:
+	case 226:
 		syntaxDollar = syntaxS[syntaxpt-0 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = &BinOpOptions{VectorMatching: &VectorMatching{Card: CardOneToOne}}
 		}
-	case 175:
+	case 227:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = &BinOpOptions{VectorMatching: &VectorMatching{Card: CardOneToOne}, ReturnBool: true}
 		}
-	case 176:
+	case 228:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 			syntaxVAL.binOpts.VectorMatching.On = true
 			syntaxVAL.binOpts.VectorMatching.MatchingLabels = syntaxDollar[4].strs
 		}
-	case 177:
+	case 229:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 			syntaxVAL.binOpts.VectorMatching.On = true
 		}
-	case 178:
+	case 230:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 			syntaxVAL.binOpts.VectorMatching.MatchingLabels = syntaxDollar[4].strs
 		}
-	case 179:
+	case 231:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 		}
-	case 180:
+	case 232:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 		}
-	case 181:
+	case 233:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 		}
-	case 182:
+	case 234:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 			syntaxVAL.binOpts.VectorMatching.Card = CardManyToOne
 		}
-	case 183:
+	case 235:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 			syntaxVAL.binOpts.VectorMatching.Card = CardManyToOne
 		}
-	case 184:
+	case 236:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 			syntaxVAL.binOpts.VectorMatching.Card = CardManyToOne
 			syntaxVAL.binOpts.VectorMatching.Include = syntaxDollar[4].strs
 		}
-	case 185:
+	case 237:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 			syntaxVAL.binOpts.VectorMatching.Card = CardOneToMany
 		}
-	case 186:
+	case 238:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 			syntaxVAL.binOpts.VectorMatching.Card = CardOneToMany
 		}
-	case 187:
+	case 239:
 		syntaxDollar = syntaxS[syntaxpt-5 : syntaxpt+1]
 		{
 			syntaxVAL.binOpts = syntaxDollar[1].binOpts
 			syntaxVAL.binOpts.VectorMatching.Card = CardOneToMany
 			syntaxVAL.binOpts.VectorMatching.Include = syntaxDollar[4].strs
 		}
-	case 188:
+	case 240:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.literalExpr = mustNewLiteralExpr(syntaxDollar[1].str, false)
 		}
-	case 189:
+	case 241:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.literalExpr = mustNewLiteralExpr(syntaxDollar[2].str, false)
 		}
-	case 190:
+	case 242:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.literalExpr = mustNewLiteralExpr(syntaxDollar[2].str, true)
 		}
-	case 191:
+	case 243:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.metricExpr = NewVectorExpr(syntaxDollar[3].str)
 		}
-	case 192:
+	case 244:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.str = OpTypeVector
 		}
-	case 193:
+	case 245:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeSum
 		}
-	case 194:
+	case 246:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeAvg
 		}
-	case 195:
+	case 247:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeCount
 		}
-	case 196:
+	case 248:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeMax
 		}
-	case 197:
+	case 249:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeMin
 		}
-	case 198:
+	case 250:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeStddev
 		}
-	case 199:
+	case 251:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeStdvar
 		}
-	case 200:
+	case 252:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeBottomK
 		}
-	case 201:
+	case 253:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeTopK
 		}
-	case 202:
+	case 254:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeSort
 		}
-	case 203:
+	case 255:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeSortDesc
 		}
-	case 204:
+	case 256:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpTypeApproxTopK
 		}
-	case 205:
+	case 257:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncAbs
+		}
+	case 258:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncCeil
+		}
+	case 259:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncFloor
+		}
+	case 260:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncRound
+		}
+	case 261:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncSqrt
+		}
+	case 262:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncExp
+		}
+	case 263:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncLn
+		}
+	case 264:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncClampMin
+		}
+	case 265:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncClampMax
+		}
+	case 266:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncScalar
+		}
+	case 267:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncTimestamp
+		}
+	case 268:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncHour
+		}
+	case 269:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncDayOfWeek
+		}
+	case 270:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpFuncDayOfMonth
+		}
+	case 271:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeCount
 		}
-	case 206:
+	case 272:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeRate
 		}
-	case 207:
+	case 273:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeRateCounter
 		}
-	case 208:
+	case 274:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeBytes
 		}
-	case 209:
+	case 275:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeBytesRate
 		}
-	case 210:
+	case 276:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeAvg
 		}
-	case 211:
+	case 277:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeSum
 		}
-	case 212:
+	case 278:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeMin
 		}
-	case 213:
+	case 279:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeMax
 		}
-	case 214:
+	case 280:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeStdvar
 		}
-	case 215:
+	case 281:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeStddev
 		}
-	case 216:
-		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
-		{
-			syntaxVAL.op = OpRangeTypeQuantile
-		}
-	case 217:
+	case 282:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeFirst
 		}
-	case 218:
+	case 283:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeLast
 		}
-	case 219:
+	case 284:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.op = OpRangeTypeAbsent
 		}
-	case 220:
+	case 285:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpRangeTypePatternCount
+		}
+	case 286:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.op = OpRangeTypeQuantile
+		}
+	case 287:
 		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
 		{
 			syntaxVAL.offsetExpr = newOffsetExpr(syntaxDollar[2].dur)
 		}
-	case 221:
+	case 288:
+		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
+		{
+			syntaxVAL.offsetExpr = &OffsetExpr{At: syntaxDollar[1].atModifier}
+		}
+	case 289:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.offsetExpr = &OffsetExpr{Offset: syntaxDollar[2].dur, At: syntaxDollar[3].atModifier}
+		}
+	case 290:
+		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
+		{
+			syntaxVAL.offsetExpr = &OffsetExpr{Offset: syntaxDollar[3].dur, At: syntaxDollar[1].atModifier}
+		}
+	case 291:
+		syntaxDollar = syntaxS[syntaxpt-2 : syntaxpt+1]
+		{
+			syntaxVAL.atModifier = mustNewAtModifier(syntaxDollar[2].str)
+		}
+	case 292:
+		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
+		{
+			syntaxVAL.atModifier = &AtModifier{StartOrEnd: OpStart}
+		}
+	case 293:
+		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
+		{
+			syntaxVAL.atModifier = &AtModifier{StartOrEnd: OpEnd}
+		}
+	case 294:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.strs = []string{syntaxDollar[1].str}
 		}
-	case 222:
+	case 295:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.strs = append(syntaxDollar[1].strs, syntaxDollar[3].str)
 		}
-	case 223:
+	case 296:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.grouping = &Grouping{Without: false, Groups: syntaxDollar[3].strs}
 		}
-	case 224:
+	case 297:
 		syntaxDollar = syntaxS[syntaxpt-4 : syntaxpt+1]
 		{
 			syntaxVAL.grouping = &Grouping{Without: true, Groups: syntaxDollar[3].strs}
 		}
-	case 225:
+	case 298:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.grouping = &Grouping{Without: false, Groups: nil}
 		}
-	case 226:
+	case 299:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.grouping = &Grouping{Without: true, Groups: nil}
 		}
-	case 227:
+	case 300:
 		syntaxDollar = syntaxS[syntaxpt-1 : syntaxpt+1]
 		{
 			syntaxVAL.metricExprs = []SampleExpr{syntaxDollar[1].metricExpr}
 		}
-	case 228:
+	case 301:
 		syntaxDollar = syntaxS[syntaxpt-3 : syntaxpt+1]
 		{
 			syntaxVAL.metricExprs = append(syntaxDollar[1].metricExprs, syntaxDollar[3].metricExpr)
@@ -11,6 +11,7 @@ type RootVisitor interface {
 	VariantsExprVisitor
 
 	VisitLogRange(*LogRangeExpr)
+	VisitJoin(*JoinExpr)
 }
 
 type SampleExprVisitor interface {
@@ -18,6 +19,9 @@ type SampleExprVisitor interface {
 	VisitVectorAggregation(*VectorAggregationExpr)
 	VisitRangeAggregation(*RangeAggregationExpr)
 	VisitLabelReplace(*LabelReplaceExpr)
+	VisitLabelJoin(*LabelJoinExpr)
+	VisitFunction(*FunctionExpr)
+	VisitSubquery(*SubqueryExpr)
 	VisitLiteral(*LiteralExpr)
 	VisitVector(*VectorExpr)
 }
@@ -31,6 +35,9 @@ type LogSelectorExprVisitor interface {
 
 type StageExprVisitor interface {
 	VisitDecolorize(*DecolorizeExpr)
+	VisitLookup(*LookupExpr)
+	VisitDedup(*DedupExpr)
+	VisitFieldFilter(*FieldFilterExpr)
 	VisitDropLabels(*DropLabelsExpr)
 	VisitJSONExpressionParser(*JSONExpressionParserExpr)
 	VisitKeepLabel(*KeepLabelsExpr)
@@ -41,6 +48,8 @@ type StageExprVisitor interface {
 	VisitLineFmt(*LineFmtExpr)
 	VisitLogfmtExpressionParser(*LogfmtExpressionParserExpr)
 	VisitLogfmtParser(*LogfmtParserExpr)
+	VisitXMLExpressionParser(*XMLExpressionParserExpr)
+	VisitCSVParser(*CSVParserExpr)
 }
 
 type VariantsExprVisitor interface {
@@ -51,13 +60,19 @@ var _ RootVisitor = &DepthFirstTraversal{}
 
 type DepthFirstTraversal struct {
 	VisitBinOpFn                  func(v RootVisitor, e *BinOpExpr)
+	VisitCSVParserFn              func(v RootVisitor, e *CSVParserExpr)
 	VisitDecolorizeFn             func(v RootVisitor, e *DecolorizeExpr)
+	VisitDedupFn                  func(v RootVisitor, e *DedupExpr)
 	VisitDropLabelsFn             func(v RootVisitor, e *DropLabelsExpr)
+	VisitFieldFilterFn            func(v RootVisitor, e *FieldFilterExpr)
+	VisitFunctionFn               func(v RootVisitor, e *FunctionExpr)
 	VisitJSONExpressionParserFn   func(v RootVisitor, e *JSONExpressionParserExpr)
+	VisitJoinFn                   func(v RootVisitor, e *JoinExpr)
 	VisitKeepLabelFn              func(v RootVisitor, e *KeepLabelsExpr)
 	VisitLabelFilterFn            func(v RootVisitor, e *LabelFilterExpr)
 	VisitLabelFmtFn               func(v RootVisitor, e *LabelFmtExpr)
 	VisitLabelParserFn            func(v RootVisitor, e *LineParserExpr)
+	VisitLabelJoinFn              func(v RootVisitor, e *LabelJoinExpr)
 	VisitLabelReplaceFn           func(v RootVisitor, e *LabelReplaceExpr)
 	VisitLineFilterFn             func(v RootVisitor, e *LineFilterExpr)
 	VisitLineFmtFn                func(v RootVisitor, e *LineFmtExpr)
@@ -65,12 +80,15 @@ type DepthFirstTraversal struct {
 	VisitLogRangeFn               func(v RootVisitor, e *LogRangeExpr)
 	VisitLogfmtExpressionParserFn func(v RootVisitor, e *LogfmtExpressionParserExpr)
 	VisitLogfmtParserFn           func(v RootVisitor, e *LogfmtParserExpr)
+	VisitLookupFn                 func(v RootVisitor, e *LookupExpr)
 	VisitMatchersFn               func(v RootVisitor, e *MatchersExpr)
 	VisitPipelineFn               func(v RootVisitor, e *PipelineExpr)
 	VisitRangeAggregationFn       func(v RootVisitor, e *RangeAggregationExpr)
+	VisitSubqueryFn               func(v RootVisitor, e *SubqueryExpr)
 	VisitVectorFn                 func(v RootVisitor, e *VectorExpr)
 	VisitVectorAggregationFn      func(v RootVisitor, e *VectorAggregationExpr)
 	VisitVariantsFn               func(v RootVisitor, e *MultiVariantExpr)
+	VisitXMLExpressionParserFn    func(v RootVisitor, e *XMLExpressionParserExpr)
 }
 
 // VisitBinOp implements RootVisitor.
@@ -96,6 +114,36 @@ func (v *DepthFirstTraversal) VisitDecolorize(e *DecolorizeExpr) {
 	}
 }
 
+// VisitLookup implements RootVisitor.
+func (v *DepthFirstTraversal) VisitLookup(e *LookupExpr) {
+	if e == nil {
+		return
+	}
+	if v.VisitLookupFn != nil {
+		v.VisitLookupFn(v, e)
+	}
+}
+
+// VisitDedup implements RootVisitor.
+func (v *DepthFirstTraversal) VisitDedup(e *DedupExpr) {
+	if e == nil {
+		return
+	}
+	if v.VisitDedupFn != nil {
+		v.VisitDedupFn(v, e)
+	}
+}
+
+// VisitFieldFilter implements RootVisitor.
+func (v *DepthFirstTraversal) VisitFieldFilter(e *FieldFilterExpr) {
+	if e == nil {
+		return
+	}
+	if v.VisitFieldFilterFn != nil {
+		v.VisitFieldFilterFn(v, e)
+	}
+}
+
 // VisitDropLabels implements RootVisitor.
 func (v *DepthFirstTraversal) VisitDropLabels(e *DropLabelsExpr) {
 	if e == nil {
@@ -106,6 +154,18 @@ func (v *DepthFirstTraversal) VisitDropLabels(e *DropLabelsExpr) {
 	}
 }
 
+// VisitFunction implements RootVisitor.
+func (v *DepthFirstTraversal) VisitFunction(e *FunctionExpr) {
+	if e == nil {
+		return
+	}
+	if v.VisitFunctionFn != nil {
+		v.VisitFunctionFn(v, e)
+	} else if e.Left != nil {
+		e.Left.Accept(v)
+	}
+}
+
 // VisitJSONExpressionParser implements RootVisitor.
 func (v *DepthFirstTraversal) VisitJSONExpressionParser(e *JSONExpressionParserExpr) {
 	if e == nil {
@@ -156,6 +216,16 @@ func (v *DepthFirstTraversal) VisitLabelParser(e *LineParserExpr) {
 	}
 }
 
+// VisitLabelJoin implements RootVisitor.
+func (v *DepthFirstTraversal) VisitLabelJoin(e *LabelJoinExpr) {
+	if e == nil {
+		return
+	}
+	if v.VisitLabelJoinFn != nil {
+		v.VisitLabelJoinFn(v, e)
+	}
+}
+
 // VisitLabelReplace implements RootVisitor.
 func (v *DepthFirstTraversal) VisitLabelReplace(e *LabelReplaceExpr) {
 	if e == nil {
@@ -215,6 +285,19 @@ func (v *DepthFirstTraversal) VisitLogRange(e *LogRangeExpr) {
 	}
 }
 
+// VisitJoin implements RootVisitor.
+func (v *DepthFirstTraversal) VisitJoin(e *JoinExpr) {
+	if e == nil {
+		return
+	}
+	if v.VisitJoinFn != nil {
+		v.VisitJoinFn(v, e)
+	} else {
+		e.Left.Accept(v)
+		e.Right.Accept(v)
+	}
+}
+
 // VisitLogfmtExpressionParser implements RootVisitor.
 func (v *DepthFirstTraversal) VisitLogfmtExpressionParser(e *LogfmtExpressionParserExpr) {
 	if e == nil {
@@ -225,6 +308,26 @@ func (v *DepthFirstTraversal) VisitLogfmtExpressionParser(e *LogfmtExpressionPar
 	}
 }
 
+// VisitXMLExpressionParser implements RootVisitor.
+func (v *DepthFirstTraversal) VisitXMLExpressionParser(e *XMLExpressionParserExpr) {
+	if e == nil {
+		return
+	}
+	if v.VisitXMLExpressionParserFn != nil {
+		v.VisitXMLExpressionParserFn(v, e)
+	}
+}
+
+// VisitCSVParser implements RootVisitor.
+func (v *DepthFirstTraversal) VisitCSVParser(e *CSVParserExpr) {
+	if e == nil {
+		return
+	}
+	if v.VisitCSVParserFn != nil {
+		v.VisitCSVParserFn(v, e)
+	}
+}
+
 // VisitLogfmtParser implements RootVisitor.
 func (v *DepthFirstTraversal) VisitLogfmtParser(e *LogfmtParserExpr) {
 	if e == nil {
@@ -272,6 +375,18 @@ func (v *DepthFirstTraversal) VisitRangeAggregation(e *RangeAggregationExpr) {
 	}
 }
 
+// VisitSubquery implements RootVisitor.
+func (v *DepthFirstTraversal) VisitSubquery(e *SubqueryExpr) {
+	if e == nil {
+		return
+	}
+	if v.VisitSubqueryFn != nil {
+		v.VisitSubqueryFn(v, e)
+	} else {
+		e.Left.Accept(v)
+	}
+}
+
 // VisitVector implements RootVisitor.
 func (v *DepthFirstTraversal) VisitVector(e *VectorExpr) {
 	if e == nil {
@@ -97,6 +97,24 @@ func NewSeriesLimitError(limit int) *LimitError {
 	}
 }
 
+func NewJoinLimitError(limit int) *LimitError {
+	return &LimitError{
+		error: fmt.Errorf("maximum number of join entries (%d) reached for a single query; consider reducing the number of entries on each side of the join by adding more specific stream selectors, filters, or reducing the time range", limit),
+	}
+}
+
+func NewSubqueryResolutionLimitError(limit int) *LimitError {
+	return &LimitError{
+		error: fmt.Errorf("maximum resolution of %d points per time series reached for a subquery; consider increasing the step of the subquery or reducing its range", limit),
+	}
+}
+
+func NewPatternLimitError(limit int) *LimitError {
+	return &LimitError{
+		error: fmt.Errorf("maximum number of entries (%d) reached while detecting patterns for a single query; consider adding more specific stream selectors, filters, or reducing the time range", limit),
+	}
+}
+
 // Is allows to use errors.Is(err,ErrLimit) on this error.
 func (e LimitError) Is(target error) bool {
 	return target == ErrLimit
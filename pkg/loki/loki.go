@@ -47,6 +47,7 @@ import (
 	limits_frontend "github.com/grafana/loki/v3/pkg/limits/frontend"
 	limits_frontend_client "github.com/grafana/loki/v3/pkg/limits/frontend/client"
 	"github.com/grafana/loki/v3/pkg/loghttp/push"
+	"github.com/grafana/loki/v3/pkg/logql/lookup"
 	"github.com/grafana/loki/v3/pkg/loki/common"
 	"github.com/grafana/loki/v3/pkg/lokifrontend"
 	"github.com/grafana/loki/v3/pkg/lokifrontend/frontend/transport"
@@ -438,6 +439,7 @@ type Loki struct {
 	queryScheduler            *scheduler.Scheduler
 	querySchedulerRingManager *lokiring.RingManager
 	usageReport               *analytics.Reporter
+	lookupTables              *lookup.Loader
 	indexGatewayRingManager   *lokiring.RingManager
 	PartitionRingWatcher      *ring.PartitionRingWatcher
 	partitionRing             *ring.PartitionInstanceRing
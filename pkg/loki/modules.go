@@ -58,6 +58,7 @@ import (
 	limitsproto "github.com/grafana/loki/v3/pkg/limits/proto"
 	"github.com/grafana/loki/v3/pkg/logproto"
 	"github.com/grafana/loki/v3/pkg/logql"
+	"github.com/grafana/loki/v3/pkg/logql/lookup"
 	"github.com/grafana/loki/v3/pkg/logqlmodel/stats"
 	"github.com/grafana/loki/v3/pkg/lokifrontend/frontend"
 	"github.com/grafana/loki/v3/pkg/lokifrontend/frontend/transport"
@@ -513,6 +514,10 @@ func (t *Loki) initIngestLimitsFrontend() (services.Service, error) {
 	// streams are rejected.
 	t.Server.HTTP.Path("/ingest-limits/exceeds-limits").Methods("POST").Handler(ingestLimitsFrontend)
 
+	// Register HTTP handler for the usage of a tenant across all limits
+	// instances, used to explore which streams count towards its limits.
+	t.Server.HTTP.Path("/ingest-limits-frontend/usage/{tenant}").Methods("GET").Handler(ingestLimitsFrontend.TenantUsageHandler())
+
 	return ingestLimitsFrontend, nil
 }
 
@@ -733,6 +738,9 @@ func (t *Loki) initQuerier() (services.Service, error) {
 func (t *Loki) initIngester() (_ services.Service, err error) {
 	logger := log.With(util_log.Logger, "component", "ingester")
 	t.Cfg.Ingester.LifecyclerConfig.ListenPort = t.Cfg.Server.GRPCListenPort
+	if t.lookupTables != nil {
+		t.Cfg.Ingester.LookupTables = t.lookupTables
+	}
 	t.Cfg.Ingester.KafkaIngestion.KafkaConfig = t.Cfg.KafkaConfig
 
 	if t.Cfg.Ingester.ShutdownMarkerPath == "" && t.Cfg.Common.PathPrefix != "" {
@@ -906,6 +914,17 @@ func (t *Loki) initStore() (services.Service, error) {
 
 	t.Store = store
 
+	// lookup tables are read from the object store of the active schema period.
+	if period, err := t.Cfg.SchemaConfig.SchemaForTime(model.Now()); err == nil {
+		objectClient, err := storage.NewObjectClient(period.ObjectType, "lookup-tables", t.Cfg.StorageConfig, t.ClientMetrics)
+		if err != nil {
+			level.Warn(util_log.Logger).Log("msg", "failed to initialize lookup tables", "err", err)
+		} else {
+			t.lookupTables = lookup.NewLoader(objectClient, t.Overrides, lookup.DefaultRefreshPeriod, log.With(util_log.Logger, "component", "lookup-tables"))
+			t.Store.SetLookupTables(t.lookupTables)
+		}
+	}
+
 	return services.NewIdleService(nil, func(_ error) error {
 		t.Store.Stop()
 		return nil
@@ -1480,6 +1499,13 @@ func (t *Loki) initRuler() (_ services.Service, err error) {
 		t.Server.HTTP.Path("/loki/api/v1/rules/{namespace}").Methods("DELETE").Handler(t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.rulerAPI.DeleteNamespace)))
 		t.Server.HTTP.Path("/loki/api/v1/rules/{namespace}/{groupName}").Methods("GET").Handler(t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.rulerAPI.GetRuleGroup)))
 		t.Server.HTTP.Path("/loki/api/v1/rules/{namespace}/{groupName}").Methods("DELETE").Handler(t.HTTPAuthMiddleware.Wrap(http.HandlerFunc(t.rulerAPI.DeleteRuleGroup)))
+
+		// Ruler Backfill API Routes
+		backfiller := ruler.NewBackfiller(t.Cfg.Ruler, t.ruleEvaluator, t.RulerStorage, t.Overrides, util_log.Logger)
+		t.Server.HTTP.Path("/api/prom/rules/{namespace}/{groupName}/backfill").Methods("GET", "POST").Handler(t.HTTPAuthMiddleware.Wrap(backfiller))
+		t.Server.HTTP.Path("/loki/api/v1/rules/{namespace}/{groupName}/backfill").Methods("GET", "POST").Handler(t.HTTPAuthMiddleware.Wrap(backfiller))
+		// The running backfills are canceled when the ruler stops.
+		t.ruler.AddListener(services.NewListener(nil, nil, func(_ services.State) { backfiller.Stop() }, nil, nil))
 	}
 
 	deleteStore, err := t.deleteRequestsClient("ruler", t.Overrides)
@@ -421,6 +421,9 @@ func minimalWorkingConfig(t *testing.T, dir, target string, cfgTransformers ...f
 		},
 	}
 
+	// Write the ingester WAL to the test directory rather than to the working directory
+	cfg.Ingester.WAL.Dir = filepath.Join(dir, "wal")
+
 	// Disable some caches otherwise we'll get errors if we don't configure them
 	cfg.QueryRange.CacheLabelResults = false
 	cfg.QueryRange.CacheSeriesResults = false
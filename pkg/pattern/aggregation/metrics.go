@@ -32,6 +32,7 @@ type Metrics struct {
 	PatternBytesWrittenTotal *prometheus.CounterVec
 	PatternWritesTotal       *prometheus.CounterVec
 	PatternsActive           *prometheus.GaugeVec
+	PatternAnomaliesTotal    *prometheus.CounterVec
 
 	// Aggregated metrics writing metrics
 	AggregatedMetricBytesWrittenTotal *prometheus.CounterVec
@@ -104,6 +105,13 @@ func NewMetrics(r prometheus.Registerer) *Metrics {
 				Help:      "Number of active patterns currently tracked in memory.",
 			}, []string{"tenant_id"}),
 
+			PatternAnomaliesTotal: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+				Namespace: constants.Loki,
+				Subsystem: "pattern_ingester",
+				Name:      "pattern_anomalies_total",
+				Help:      "Total number of pattern anomalies detected, by type of anomaly.",
+			}, []string{"tenant_id", "type"}),
+
 			// Aggregated metrics writing metrics
 			AggregatedMetricBytesWrittenTotal: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
 				Namespace: constants.Loki,
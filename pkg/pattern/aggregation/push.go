@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"sync"
 	"time"
 
@@ -405,6 +406,28 @@ func PatternEntry(
 	return internalEntry(base, lbls)
 }
 
+// PatternAnomalyEntry is a PatternEntry flagged as anomalous by the pattern ingester.
+// The baseline is the average count of the pattern per bucket before the anomaly, it is 0 for new patterns.
+func PatternAnomalyEntry(
+	ts time.Time,
+	count int64,
+	pattern string,
+	anomaly string,
+	baseline float64,
+	lbls labels.Labels,
+) string {
+	base := fmt.Sprintf(
+		`ts=%d count=%d detected_pattern="%s" anomaly="%s" baseline=%s`,
+		ts.UnixNano(),
+		count,
+		url.QueryEscape(pattern),
+		anomaly,
+		strconv.FormatFloat(baseline, 'f', -1, 64),
+	)
+
+	return internalEntry(base, lbls)
+}
+
 func internalEntry(
 	base string,
 	lbls labels.Labels,
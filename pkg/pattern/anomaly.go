@@ -0,0 +1,148 @@
+package pattern
+
+import (
+	"slices"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/pattern/drain"
+)
+
+const (
+	// AnomalyNew marks the first bucket of a pattern that was not seen since the stream was created.
+	AnomalyNew = "new"
+	// AnomalySpike marks a bucket whose count is above the pattern baseline by more than the configured ratio.
+	AnomalySpike = "spike"
+	// AnomalyDrop marks a bucket whose count is below the pattern baseline by more than the configured ratio.
+	AnomalyDrop = "drop"
+
+	// baselineAlpha is the weight of the latest bucket in the moving average of a pattern baseline.
+	baselineAlpha = 0.3
+	// minBaselineBuckets is the number of buckets required before a baseline is used to detect spikes and drops.
+	minBaselineBuckets = 3
+)
+
+type patternAnomaly struct {
+	kind     string
+	count    int64
+	baseline float64
+}
+
+// patternBaseline tracks the volume of a pattern per persisted bucket.
+type patternBaseline struct {
+	lastBucket model.Time
+	buckets    int
+	mean       float64
+	// silent is true once a drop of the pattern to zero has been flagged, until it shows up again.
+	silent bool
+}
+
+// detectAnomalies updates the baseline of cluster with the given buckets and returns the anomalous ones.
+// Buckets must be processed in chronological order, so the baseline only ever reflects the past.
+// The buckets without samples since the last bucket of the baseline count as zero, so that patterns
+// that stop appearing are flagged as dropped and their baseline decays.
+func (s *stream) detectAnomalies(cluster *drain.LogCluster, samples []*logproto.PatternSample) map[model.Time]*patternAnomaly {
+	if !s.anomalyDetectionEnabled {
+		return nil
+	}
+
+	counts := make(map[model.Time]int64)
+	for ts, bucket := range s.bucketSamples(samples) {
+		for _, sample := range bucket {
+			counts[ts] += sample.Value
+		}
+	}
+	times := make([]model.Time, 0, len(counts))
+	for ts := range counts {
+		times = append(times, ts)
+	}
+	slices.Sort(times)
+
+	var from model.Time
+	if baseline, ok := s.baselines[cluster]; ok {
+		from = baseline.lastBucket
+	} else if len(times) > 0 {
+		from = times[0]
+	} else {
+		return nil
+	}
+	step := model.Time(s.persistenceGranularity.Milliseconds())
+	for ts := from + step; ts <= s.silentThrough(cluster); ts += step {
+		if _, ok := counts[ts]; !ok {
+			counts[ts] = 0
+			times = append(times, ts)
+		}
+	}
+	slices.Sort(times)
+
+	var anomalies map[model.Time]*patternAnomaly
+	add := func(ts model.Time, a *patternAnomaly) {
+		if anomalies == nil {
+			anomalies = make(map[model.Time]*patternAnomaly)
+		}
+		anomalies[ts] = a
+	}
+
+	for _, ts := range times {
+		count := counts[ts]
+
+		baseline, ok := s.baselines[cluster]
+		if !ok {
+			if count == 0 {
+				continue
+			}
+			baseline = &patternBaseline{mean: float64(count)}
+			s.baselines[cluster] = baseline
+			// Patterns seen in the first bucket of the stream are part of the initial baseline.
+			if ts > s.firstBucket {
+				add(ts, &patternAnomaly{kind: AnomalyNew, count: count})
+			}
+		} else {
+			// A bucket split across flushes only contributes to the baseline once.
+			if ts <= baseline.lastBucket {
+				continue
+			}
+			if baseline.buckets >= minBaselineBuckets {
+				switch {
+				case float64(count) > baseline.mean*s.anomalyDeviationRatio:
+					add(ts, &patternAnomaly{kind: AnomalySpike, count: count, baseline: baseline.mean})
+				case count == 0 && baseline.silent:
+					// A silent pattern is only flagged in the first bucket it is missing from.
+				case float64(count)*s.anomalyDeviationRatio < baseline.mean:
+					add(ts, &patternAnomaly{kind: AnomalyDrop, count: count, baseline: baseline.mean})
+					baseline.silent = count == 0
+				}
+			}
+			if count > 0 {
+				baseline.silent = false
+			}
+			baseline.mean = baselineAlpha*float64(count) + (1-baselineAlpha)*baseline.mean
+		}
+		baseline.lastBucket = ts
+		baseline.buckets++
+	}
+
+	if s.aggregationMetrics != nil {
+		for _, a := range anomalies {
+			s.aggregationMetrics.PatternAnomaliesTotal.WithLabelValues(s.instanceID, a.kind).Inc()
+		}
+	}
+
+	return anomalies
+}
+
+// silentThrough returns the last bucket up to which a pattern without samples is silent: the last
+// complete bucket of the stream, before the first bucket the cluster still has samples in.
+func (s *stream) silentThrough(cluster *drain.LogCluster) model.Time {
+	step := model.Time(s.persistenceGranularity.Milliseconds())
+	through := s.bucketOf(time.Unix(0, s.lastTS)) - step
+	for _, chunk := range cluster.Chunks {
+		if len(chunk.Samples) > 0 {
+			through = min(through, s.bucketOf(chunk.Samples[0].Timestamp.Time())-step)
+			break
+		}
+	}
+	return through
+}
@@ -47,12 +47,21 @@ type Config struct {
 	MaxAllowedLineLength int
 	MaxChunkAge          time.Duration
 	SampleInterval       time.Duration
+	// Masks are the names of the masks applied to tokens before clustering.
+	Masks []string
+	// Tokenizer is the name of the tokenizer to use, the tokenizer is chosen from the log format when empty.
+	Tokenizer string
 }
 
 type Limits interface {
 	PatternIngesterTokenizableJSONFields(userID string) []string
+	PatternIngesterMasks(userID string) []string
+	PatternIngesterTokenizer(userID string) string
 }
 
+// DefaultTokenizableJSONFields are the JSON fields tokenized when no Limits are given to New.
+var DefaultTokenizableJSONFields = []string{"log", "message", "msg", "msg_", "_msg", "content"}
+
 func createLogClusterCache(maxSize int, onEvict func(int, *LogCluster)) *LogClusterCache {
 	if maxSize == 0 {
 		maxSize = math.MaxInt
@@ -158,16 +167,36 @@ func New(tenantID string, config *Config, limits Limits, format string, metrics
 
 	limiter := newLimiter(config.MaxEvictionRatio)
 
+	// Per-tenant limits take precedence over the config.
+	masks, tokenizerName := config.Masks, config.Tokenizer
+	if limits != nil {
+		if tenantMasks := limits.PatternIngesterMasks(tenantID); len(tenantMasks) > 0 {
+			masks = tenantMasks
+		}
+		if tenantTokenizer := limits.PatternIngesterTokenizer(tenantID); tenantTokenizer != "" {
+			tokenizerName = tenantTokenizer
+		}
+	}
+	if tokenizerName == "" {
+		tokenizerName = format
+	}
+
 	var tokenizer LineTokenizer
-	switch format {
-	case FormatJSON:
-		fieldsToTokenize := limits.PatternIngesterTokenizableJSONFields(tenantID)
+	switch tokenizerName {
+	case TokenizerJSON:
+		fieldsToTokenize := DefaultTokenizableJSONFields
+		if limits != nil {
+			fieldsToTokenize = limits.PatternIngesterTokenizableJSONFields(tenantID)
+		}
 		tokenizer = newJSONTokenizer(config.ParamString, config.MaxAllowedLineLength, fieldsToTokenize)
-	case FormatLogfmt:
+	case TokenizerLogfmt:
 		tokenizer = newLogfmtTokenizer(config.ParamString, config.MaxAllowedLineLength)
+	case TokenizerSplitting:
+		tokenizer = splittingTokenizer{}
 	default:
 		tokenizer = newPunctuationTokenizer(config.MaxAllowedLineLength)
 	}
+	tokenizer = newMaskingTokenizer(tokenizer, masks)
 
 	d.idToCluster = createLogClusterCache(config.MaxClusters, func(int, *LogCluster) {
 		if metrics != nil {
@@ -725,6 +725,14 @@ func (f *fakeLimits) PatternIngesterTokenizableJSONFields(_ string) []string {
 	return []string{"log", "message", "msg", "msg_", "_msg", "content"}
 }
 
+func (f *fakeLimits) PatternIngesterMasks(_ string) []string {
+	return nil
+}
+
+func (f *fakeLimits) PatternIngesterTokenizer(_ string) string {
+	return ""
+}
+
 func TestDrainDefaultConfig(t *testing.T) {
 	t.Run("should set default ChunkDuration to 1 hour", func(t *testing.T) {
 		cfg := DefaultConfig()
@@ -0,0 +1,199 @@
+package drain
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Built-in masks. A token fully matching a mask is replaced by the mask placeholder before
+// clustering, so that lines only differing by such values end up in the same cluster.
+const (
+	MaskUUID     = "uuid"
+	MaskIP       = "ip"
+	MaskHex      = "hex"
+	MaskDuration = "duration"
+)
+
+// Tokenizers that can be configured instead of the one chosen from the detected log format.
+const (
+	TokenizerPunctuation = "punctuation"
+	TokenizerSplitting   = "splitting"
+	TokenizerLogfmt      = FormatLogfmt
+	TokenizerJSON        = FormatJSON
+)
+
+type mask struct {
+	placeholder string
+	match       func(token string) bool
+}
+
+var masks = map[string]mask{
+	MaskUUID:     {placeholder: "<uuid>", match: isUUID},
+	MaskIP:       {placeholder: "<ip>", match: isIP},
+	MaskHex:      {placeholder: "<hex>", match: isHex},
+	MaskDuration: {placeholder: "<duration>", match: isDuration},
+}
+
+// ValidateMasks returns an error if one of the names is not a known mask.
+func ValidateMasks(names []string) error {
+	for _, name := range names {
+		if _, ok := masks[name]; !ok {
+			return fmt.Errorf("unknown pattern mask %q, supported masks are %s, %s, %s and %s", name, MaskUUID, MaskIP, MaskHex, MaskDuration)
+		}
+	}
+	return nil
+}
+
+// ValidateTokenizer returns an error if name is neither empty nor a known tokenizer.
+func ValidateTokenizer(name string) error {
+	switch name {
+	case "", TokenizerPunctuation, TokenizerSplitting, TokenizerLogfmt, TokenizerJSON:
+		return nil
+	}
+	return fmt.Errorf("unknown pattern tokenizer %q, supported tokenizers are %s, %s, %s and %s", name, TokenizerPunctuation, TokenizerSplitting, TokenizerLogfmt, TokenizerJSON)
+}
+
+// maskingTokenizer replaces the tokens matching one of its masks by the mask placeholder.
+type maskingTokenizer struct {
+	LineTokenizer
+	masks []mask
+}
+
+func newMaskingTokenizer(tokenizer LineTokenizer, names []string) LineTokenizer {
+	m := &maskingTokenizer{LineTokenizer: tokenizer}
+	for _, name := range names {
+		if mask, ok := masks[name]; ok {
+			m.masks = append(m.masks, mask)
+		}
+	}
+	if len(m.masks) == 0 {
+		return tokenizer
+	}
+	return m
+}
+
+func (m *maskingTokenizer) Tokenize(
+	line string,
+	tokens []string,
+	state interface{},
+	linesDropped *prometheus.CounterVec,
+) ([]string, interface{}) {
+	tokens, state = m.LineTokenizer.Tokenize(line, tokens, state, linesDropped)
+	for i, token := range tokens {
+		for _, mask := range m.masks {
+			if mask.match(token) {
+				tokens[i] = mask.placeholder
+				break
+			}
+		}
+	}
+	return tokens, state
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// isUUID matches the canonical 8-4-4-4-12 textual form of UUIDs.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isIP matches IPv4 and IPv6 addresses, with or without a port.
+func isIP(s string) bool {
+	// Cheap checks first, parsing failures allocate.
+	if len(s) < 2 || !strings.ContainsAny(s, ".:") || !isHexDigit(s[0]) && s[0] != '[' && s[0] != ':' {
+		return false
+	}
+	if _, err := netip.ParseAddr(s); err == nil {
+		return true
+	}
+	_, err := netip.ParseAddrPort(s)
+	return err == nil
+}
+
+// isHex matches 0x prefixed hexadecimal numbers and hexadecimal identifiers of at least 8 characters
+// mixing digits and letters, so that regular words and numbers are left alone.
+func isHex(s string) bool {
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		for i := 2; i < len(s); i++ {
+			if !isHexDigit(s[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if len(s) < 8 {
+		return false
+	}
+	hasDigit, hasLetter := false, false
+	for i := 0; i < len(s); i++ {
+		if !isHexDigit(s[i]) {
+			return false
+		}
+		if '0' <= s[i] && s[i] <= '9' {
+			hasDigit = true
+		} else {
+			hasLetter = true
+		}
+	}
+	return hasDigit && hasLetter
+}
+
+// isDuration matches durations as formatted by Go, e.g. 150ms, 1.5s or 1h2m3s.
+func isDuration(s string) bool {
+	if s == "" {
+		return false
+	}
+	for s != "" {
+		// number
+		i := 0
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		if i < len(s) && s[i] == '.' {
+			i++
+			j := i
+			for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+				i++
+			}
+			if i == j {
+				return false
+			}
+		}
+		if i == 0 {
+			return false
+		}
+		s = s[i:]
+		// unit
+		switch {
+		case strings.HasPrefix(s, "ns"), strings.HasPrefix(s, "us"), strings.HasPrefix(s, "ms"):
+			s = s[2:]
+		case strings.HasPrefix(s, "µs"):
+			s = s[len("µs"):]
+		case strings.HasPrefix(s, "s"), strings.HasPrefix(s, "m"), strings.HasPrefix(s, "h"):
+			s = s[1:]
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package drain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMasks(t *testing.T) {
+	for _, tc := range []struct {
+		mask    string
+		matches []string
+		ignores []string
+	}{
+		{
+			mask:    MaskUUID,
+			matches: []string{"123e4567-e89b-12d3-a456-426614174000", "123E4567-E89B-12D3-A456-426614174000"},
+			ignores: []string{"123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400g", "uuid"},
+		},
+		{
+			mask:    MaskIP,
+			matches: []string{"10.0.0.1", "10.0.0.1:8080", "::1", "fe80::1", "[fe80::1]:443"},
+			ignores: []string{"10.0.0", "1.5", "host:8080", "10.0.0.256", "localhost"},
+		},
+		{
+			mask:    MaskHex,
+			matches: []string{"0x1f", "0XDEADBEEF", "deadbeef01", "7f3a9c0b12e4"},
+			ignores: []string{"0x", "0xzz", "deadbeef", "12345678", "abc123", "committed"},
+		},
+		{
+			mask:    MaskDuration,
+			matches: []string{"150ms", "1.5s", "1h2m3s", "10us", "10µs", "3m"},
+			ignores: []string{"ms", "1.s", "10min", "10", "1h2", "s1"},
+		},
+	} {
+		t.Run(tc.mask, func(t *testing.T) {
+			m := masks[tc.mask]
+			for _, s := range tc.matches {
+				require.True(t, m.match(s), s)
+			}
+			for _, s := range tc.ignores {
+				require.False(t, m.match(s), s)
+			}
+		})
+	}
+}
+
+func TestValidateMasks(t *testing.T) {
+	require.NoError(t, ValidateMasks(nil))
+	require.NoError(t, ValidateMasks([]string{MaskUUID, MaskIP, MaskHex, MaskDuration}))
+	require.Error(t, ValidateMasks([]string{MaskUUID, "email"}))
+}
+
+func TestValidateTokenizer(t *testing.T) {
+	for _, name := range []string{"", TokenizerPunctuation, TokenizerSplitting, TokenizerLogfmt, TokenizerJSON} {
+		require.NoError(t, ValidateTokenizer(name))
+	}
+	require.Error(t, ValidateTokenizer("spaces"))
+}
+
+func TestDrainMasks(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		format   string
+		masks    []string
+		line     func(i int) string
+		expected []string
+	}{
+		{
+			name:   "without masks",
+			format: FormatUnknown,
+			line: func(i int) string {
+				return fmt.Sprintf("request 123e4567-e89b-12d3-a456-42661417400%d from 10.0.0.%d took %dms", i, i, i)
+			},
+			expected: []string{"request <_> from <_> took <_>"},
+		},
+		{
+			name:   "punctuation",
+			format: FormatUnknown,
+			masks:  []string{MaskUUID, MaskIP, MaskDuration},
+			line: func(i int) string {
+				return fmt.Sprintf("request 123e4567-e89b-12d3-a456-42661417400%d from 10.0.0.%d took %dms", i, i, i)
+			},
+			expected: []string{"request <uuid> from <ip> took <duration>"},
+		},
+		{
+			name:   "logfmt",
+			format: FormatLogfmt,
+			masks:  []string{MaskUUID, MaskIP, MaskDuration},
+			line: func(i int) string {
+				return fmt.Sprintf("msg=done id=123e4567-e89b-12d3-a456-42661417400%d client=10.0.0.%d:8080 duration=%ds", i, i, i)
+			},
+			expected: []string{"msg=done id=<uuid> client=<ip> duration=<duration>"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Masks = tc.masks
+			drain := New("", cfg, nil, tc.format, nil)
+			for i := range 5 {
+				drain.Train(tc.line(i), int64(i))
+			}
+
+			var patterns []string
+			for _, cluster := range drain.Clusters() {
+				patterns = append(patterns, cluster.String())
+			}
+			require.Equal(t, tc.expected, patterns)
+		})
+	}
+}
+
+func TestDrainTokenizerOverride(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Tokenizer = TokenizerSplitting
+	drain := New("", cfg, nil, FormatUnknown, nil)
+	for i := range 5 {
+		drain.Train(fmt.Sprintf("level=info caller=main.go:%d msg=started", i), int64(i))
+	}
+
+	require.Len(t, drain.Clusters(), 1)
+	require.Equal(t, "level=info caller=<_> msg=started", drain.Clusters()[0].String())
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/flagext"
 	"github.com/grafana/dskit/ring"
 	"github.com/grafana/dskit/services"
 	"github.com/grafana/dskit/tenant"
@@ -33,22 +34,24 @@ import (
 const readBatchSize = 1024
 
 type Config struct {
-	Enabled               bool                  `yaml:"enabled,omitempty" doc:"description=Whether the pattern ingester is enabled."`
-	LifecyclerConfig      ring.LifecyclerConfig `yaml:"lifecycler,omitempty" doc:"description=Configures how the lifecycle of the pattern ingester will operate and where it will register for discovery."`
-	ClientConfig          clientpool.Config     `yaml:"client_config,omitempty" doc:"description=Configures how the pattern ingester will connect to the ingesters."`
-	ConcurrentFlushes     int                   `yaml:"concurrent_flushes"`
-	FlushCheckPeriod      time.Duration         `yaml:"flush_check_period"`
-	MaxClusters           int                   `yaml:"max_clusters,omitempty" doc:"description=The maximum number of detected pattern clusters that can be created by streams."`
-	MaxEvictionRatio      float64               `yaml:"max_eviction_ratio,omitempty" doc:"description=The maximum eviction ratio of patterns per stream. Once that ratio is reached, the stream will throttled pattern detection."`
-	MetricAggregation     aggregation.Config    `yaml:"metric_aggregation,omitempty" doc:"description=Configures the metric aggregation and storage behavior of the pattern ingester."`
-	PatternPersistence    PersistenceConfig     `yaml:"pattern_persistence,omitempty" doc:"description=Configures how detected patterns are pushed back to Loki for persistence."`
-	TeeConfig             TeeConfig             `yaml:"tee_config,omitempty" doc:"description=Configures the pattern tee which forwards requests to the pattern ingester."`
-	ConnectionTimeout     time.Duration         `yaml:"connection_timeout"`
-	MaxAllowedLineLength  int                   `yaml:"max_allowed_line_length,omitempty" doc:"description=The maximum length of log lines that can be used for pattern detection."`
-	RetainFor             time.Duration         `yaml:"retain_for,omitempty" doc:"description=How long to retain patterns in the pattern ingester after they are pushed."`
-	MaxChunkAge           time.Duration         `yaml:"max_chunk_age,omitempty" doc:"description=The maximum time span for a single pattern chunk."`
-	PatternSampleInterval time.Duration         `yaml:"pattern_sample_interval,omitempty" doc:"description=The time resolution for pattern samples within chunks."`
-	VolumeThreshold       float64               `yaml:"volume_threshold,omitempty" doc:"description=The threshold for filtering patterns by volume. Only patterns representing the top X% of log volume will be persisted (0-1)."`
+	Enabled               bool                   `yaml:"enabled,omitempty" doc:"description=Whether the pattern ingester is enabled."`
+	LifecyclerConfig      ring.LifecyclerConfig  `yaml:"lifecycler,omitempty" doc:"description=Configures how the lifecycle of the pattern ingester will operate and where it will register for discovery."`
+	ClientConfig          clientpool.Config      `yaml:"client_config,omitempty" doc:"description=Configures how the pattern ingester will connect to the ingesters."`
+	ConcurrentFlushes     int                    `yaml:"concurrent_flushes"`
+	FlushCheckPeriod      time.Duration          `yaml:"flush_check_period"`
+	MaxClusters           int                    `yaml:"max_clusters,omitempty" doc:"description=The maximum number of detected pattern clusters that can be created by streams."`
+	MaxEvictionRatio      float64                `yaml:"max_eviction_ratio,omitempty" doc:"description=The maximum eviction ratio of patterns per stream. Once that ratio is reached, the stream will throttled pattern detection."`
+	MetricAggregation     aggregation.Config     `yaml:"metric_aggregation,omitempty" doc:"description=Configures the metric aggregation and storage behavior of the pattern ingester."`
+	PatternPersistence    PersistenceConfig      `yaml:"pattern_persistence,omitempty" doc:"description=Configures how detected patterns are pushed back to Loki for persistence."`
+	TeeConfig             TeeConfig              `yaml:"tee_config,omitempty" doc:"description=Configures the pattern tee which forwards requests to the pattern ingester."`
+	ConnectionTimeout     time.Duration          `yaml:"connection_timeout"`
+	MaxAllowedLineLength  int                    `yaml:"max_allowed_line_length,omitempty" doc:"description=The maximum length of log lines that can be used for pattern detection."`
+	RetainFor             time.Duration          `yaml:"retain_for,omitempty" doc:"description=How long to retain patterns in the pattern ingester after they are pushed."`
+	MaxChunkAge           time.Duration          `yaml:"max_chunk_age,omitempty" doc:"description=The maximum time span for a single pattern chunk."`
+	PatternSampleInterval time.Duration          `yaml:"pattern_sample_interval,omitempty" doc:"description=The time resolution for pattern samples within chunks."`
+	VolumeThreshold       float64                `yaml:"volume_threshold,omitempty" doc:"description=The threshold for filtering patterns by volume. Only patterns representing the top X% of log volume will be persisted (0-1)."`
+	Masks                 flagext.StringSliceCSV `yaml:"masks,omitempty" doc:"description=List of masks applied to tokens before pattern detection. Tokens matching a mask are replaced by a typed placeholder such as <uuid>. Supported masks: uuid, ip, hex, duration."`
+	Tokenizer             string                 `yaml:"tokenizer,omitempty" doc:"description=Tokenizer used for pattern detection. Supported tokenizers: punctuation, splitting, logfmt, json. When empty, the tokenizer is chosen from the log format detected for each stream."`
 
 	// For testing.
 	factory ring_client.PoolFactory `yaml:"-"`
@@ -128,6 +131,17 @@ func (cfg *Config) RegisterFlags(fs *flag.FlagSet) {
 		0.99,
 		"The threshold for filtering patterns by volume. Only patterns representing the top X% of log volume will be persisted (0-1).",
 	)
+	fs.Var(
+		&cfg.Masks,
+		"pattern-ingester.masks",
+		"List of masks applied to tokens before pattern detection. Tokens matching a mask are replaced by a typed placeholder such as <uuid>. Supported masks: uuid, ip, hex, duration.",
+	)
+	fs.StringVar(
+		&cfg.Tokenizer,
+		"pattern-ingester.tokenizer",
+		"",
+		"Tokenizer used for pattern detection. Supported tokenizers: punctuation, splitting, logfmt, json. When empty, the tokenizer is chosen from the log format detected for each stream.",
+	)
 }
 
 type TeeConfig struct {
@@ -191,6 +205,14 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("volume_threshold (%v) must be between 0 and 1", cfg.VolumeThreshold)
 	}
 
+	if err := drain.ValidateMasks(cfg.Masks); err != nil {
+		return err
+	}
+
+	if err := drain.ValidateTokenizer(cfg.Tokenizer); err != nil {
+		return err
+	}
+
 	return cfg.LifecyclerConfig.Validate()
 }
 
@@ -200,6 +222,8 @@ type Limits interface {
 	PatternPersistenceEnabled(userID string) bool
 	PersistenceGranularity(userID string) time.Duration
 	PatternRateThreshold(userID string) float64
+	PatternAnomalyDetectionEnabled(userID string) bool
+	PatternAnomalyDeviationRatio(userID string) float64
 }
 
 type Ingester struct {
@@ -244,6 +268,8 @@ func New(
 	drainCfg.MaxEvictionRatio = cfg.MaxEvictionRatio
 	drainCfg.MaxChunkAge = cfg.MaxChunkAge
 	drainCfg.SampleInterval = cfg.PatternSampleInterval
+	drainCfg.Masks = cfg.Masks
+	drainCfg.Tokenizer = cfg.Tokenizer
 
 	i := &Ingester{
 		cfg:         cfg,
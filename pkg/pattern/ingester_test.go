@@ -401,6 +401,8 @@ type fakeLimits struct {
 	patternPersistenceEnabled bool
 	persistenceGranularity    time.Duration
 	patternRateThreshold      float64
+	anomalyDetectionEnabled   bool
+	anomalyDeviationRatio     float64
 }
 
 var _ drain.Limits = &fakeLimits{}
@@ -410,6 +412,14 @@ func (f *fakeLimits) PatternIngesterTokenizableJSONFields(_ string) []string {
 	return []string{"log", "message", "msg", "msg_", "_msg", "content"}
 }
 
+func (f *fakeLimits) PatternIngesterMasks(_ string) []string {
+	return nil
+}
+
+func (f *fakeLimits) PatternIngesterTokenizer(_ string) string {
+	return ""
+}
+
 func (f *fakeLimits) MetricAggregationEnabled(_ string) bool {
 	return f.metricAggregationEnabled
 }
@@ -426,6 +436,14 @@ func (f *fakeLimits) PatternRateThreshold(_ string) float64 {
 	return f.patternRateThreshold
 }
 
+func (f *fakeLimits) PatternAnomalyDetectionEnabled(_ string) bool {
+	return f.anomalyDetectionEnabled
+}
+
+func (f *fakeLimits) PatternAnomalyDeviationRatio(_ string) float64 {
+	return f.anomalyDeviationRatio
+}
+
 func TestIngesterShutdownFlush(t *testing.T) {
 	lbs := labels.FromStrings("test", "test", "service_name", "test_service")
 	now := model.Now()
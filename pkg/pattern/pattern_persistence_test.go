@@ -173,6 +173,14 @@ func (c *configurableLimits) PatternIngesterTokenizableJSONFields(_ string) []st
 	return []string{"log", "message", "msg", "msg_", "_msg", "content"}
 }
 
+func (c *configurableLimits) PatternIngesterMasks(_ string) []string {
+	return nil
+}
+
+func (c *configurableLimits) PatternIngesterTokenizer(_ string) string {
+	return ""
+}
+
 func (c *configurableLimits) PatternPersistenceEnabled(_ string) bool {
 	return c.patternPersistenceEnabled
 }
@@ -189,6 +197,14 @@ func (c *configurableLimits) PatternRateThreshold(_ string) float64 {
 	return 1.0 // Default value for tests
 }
 
+func (c *configurableLimits) PatternAnomalyDetectionEnabled(_ string) bool {
+	return false
+}
+
+func (c *configurableLimits) PatternAnomalyDeviationRatio(_ string) float64 {
+	return 3.0 // Default value for tests
+}
+
 func testIngesterConfig(t testing.TB) Config {
 	kvClient, err := kv.NewClient(kv.Config{Store: "inmemory"}, ring.GetCodec(), nil, log.NewNopLogger())
 	require.NoError(t, err)
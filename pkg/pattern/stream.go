@@ -38,6 +38,12 @@ type stream struct {
 	sampleInterval         time.Duration
 	patternRateThreshold   float64
 	volumeThreshold        float64
+
+	anomalyDetectionEnabled bool
+	anomalyDeviationRatio   float64
+	// firstBucket is the bucket of the first line pushed to the stream, patterns first seen after it are new.
+	firstBucket model.Time
+	baselines   map[*drain.LogCluster]*patternBaseline
 }
 
 func newStream(
@@ -90,6 +96,10 @@ func newStream(
 		sampleInterval:         drainCfg.SampleInterval,
 		patternRateThreshold:   limits.PatternRateThreshold(instanceID),
 		volumeThreshold:        volumeThreshold,
+
+		anomalyDetectionEnabled: limits.PatternAnomalyDetectionEnabled(instanceID),
+		anomalyDeviationRatio:   limits.PatternAnomalyDeviationRatio(instanceID),
+		baselines:               make(map[*drain.LogCluster]*patternBaseline),
 	}, nil
 }
 
@@ -111,6 +121,9 @@ func (s *stream) Push(
 			lvl = strings.ToLower(metadata.Get(constants.LevelLabel))
 		}
 		s.lastTS = entry.Timestamp.UnixNano()
+		if s.anomalyDetectionEnabled && s.firstBucket == 0 {
+			s.firstBucket = s.bucketOf(entry.Timestamp)
+		}
 
 		//TODO(twhitney): Can we reduce lock contention by locking by level rather than for the entire stream?
 		if pattern, ok := s.patterns[lvl]; ok {
@@ -149,6 +162,7 @@ type clusterWithMeta struct {
 	level         string
 	drainInstance *drain.Drain
 	prunedSamples []*logproto.PatternSample
+	anomalies     map[model.Time]*patternAnomaly
 }
 
 func (s *stream) prune(olderThan time.Duration) bool {
@@ -156,6 +170,10 @@ func (s *stream) prune(olderThan time.Duration) bool {
 	defer s.mtx.Unlock()
 
 	var allClusters []clusterWithMeta
+	var liveClusters map[*drain.LogCluster]struct{}
+	if s.anomalyDetectionEnabled {
+		liveClusters = make(map[*drain.LogCluster]struct{}, len(s.baselines))
+	}
 
 	// First pass: collect all clusters and prune samples
 	totalClusters := 0
@@ -169,10 +187,18 @@ func (s *stream) prune(olderThan time.Duration) bool {
 					level:         lvl,
 					drainInstance: pattern,
 					prunedSamples: prunedSamples,
+					anomalies:     s.detectAnomalies(cluster, prunedSamples),
 				})
+			} else {
+				// Patterns without samples to write can still have dropped to zero.
+				for bucketTime, anomaly := range s.detectAnomalies(cluster, nil) {
+					s.writePattern(bucketTime, s.labels, cluster.String(), anomaly.count, lvl, anomaly)
+				}
 			}
 			if cluster.Size == 0 {
 				pattern.Delete(cluster)
+			} else if liveClusters != nil {
+				liveClusters[cluster] = struct{}{}
 			}
 			// Clear empty branches and track total clusters
 			pattern.Prune()
@@ -183,9 +209,9 @@ func (s *stream) prune(olderThan time.Duration) bool {
 	// Filter clusters by volume if volumeThreshold is set (< 1.0)
 	var clustersToWrite []clusterWithMeta
 	if s.volumeThreshold > 0 && s.volumeThreshold < 1.0 && len(allClusters) > 0 {
-		// Sort clusters by volume, and keep only the top threshold of clusters by volume
-		// To optimize memory, filterClustersByVolume will mutate the input slice, the slice we get
-		// in rerturn uses the same underlying array as the input slice.
+		// Sort clusters by volume, and keep only the top threshold of clusters by volume.
+		// The slice we get in return is a prefix of allClusters, so the clusters filtered out are the rest of it.
+		sortClustersByVolume(allClusters)
 		clustersToWrite = filterClustersByVolume(allClusters, s.volumeThreshold)
 	} else {
 		// No filtering, write all clusters
@@ -194,7 +220,21 @@ func (s *stream) prune(olderThan time.Duration) bool {
 
 	// Write patterns for filtered clusters
 	for _, cm := range clustersToWrite {
-		s.writePatternsBucketed(cm.prunedSamples, s.labels, cm.cluster.String(), cm.level)
+		s.writePatternsBucketed(cm.prunedSamples, s.labels, cm.cluster.String(), cm.level, cm.anomalies)
+	}
+
+	// Anomalies are always written, even for clusters filtered out by volume
+	for _, cm := range allClusters[len(clustersToWrite):] {
+		for bucketTime, anomaly := range cm.anomalies {
+			s.writePattern(bucketTime, s.labels, cm.cluster.String(), anomaly.count, cm.level, anomaly)
+		}
+	}
+
+	// Forget the baselines of deleted and evicted clusters
+	for cluster := range s.baselines {
+		if _, ok := liveClusters[cluster]; !ok {
+			delete(s.baselines, cluster)
+		}
 	}
 
 	// Update active patterns gauge
@@ -229,6 +269,7 @@ func (s *stream) writePattern(
 	pattern string,
 	count int64,
 	lvl string,
+	anomaly *patternAnomaly,
 ) {
 	service := streamLbls.Get(push.LabelServiceName)
 	if service == "" {
@@ -242,7 +283,12 @@ func (s *stream) writePattern(
 	}
 
 	if s.patternWriter != nil {
-		patternEntry := aggregation.PatternEntry(ts.Time(), count, pattern, streamLbls)
+		var patternEntry string
+		if anomaly != nil {
+			patternEntry = aggregation.PatternAnomalyEntry(ts.Time(), count, pattern, anomaly.kind, anomaly.baseline, streamLbls)
+		} else {
+			patternEntry = aggregation.PatternEntry(ts.Time(), count, pattern, streamLbls)
+		}
 
 		// Record metrics
 		if s.aggregationMetrics != nil {
@@ -268,22 +314,13 @@ func (s *stream) writePatternsBucketed(
 	streamLbls labels.Labels,
 	pattern string,
 	lvl string,
+	anomalies map[model.Time]*patternAnomaly,
 ) {
 	if len(prunedSamples) == 0 {
 		return
 	}
 
-	// Calculate bucket size
-	bucketSize := s.persistenceGranularity
-
-	// Process samples into buckets
-	buckets := make(map[model.Time][]*logproto.PatternSample)
-
-	for _, sample := range prunedSamples {
-		// Calculate which bucket this sample belongs to
-		sampleBucket := model.Time(sample.Timestamp.UnixNano() / bucketSize.Nanoseconds() * bucketSize.Nanoseconds() / 1e6)
-		buckets[sampleBucket] = append(buckets[sampleBucket], sample)
-	}
+	buckets := s.bucketSamples(prunedSamples)
 
 	// Write pattern entries for each bucket (apply rate threshold per bucket)
 	for bucketTime, bucketSamples := range buckets {
@@ -292,8 +329,10 @@ func (s *stream) writePatternsBucketed(
 		}
 
 		// Check if pattern rate meets threshold,
-		// threshold of 0 means no rate threshold
-		if s.patternRateThreshold > 0 {
+		// threshold of 0 means no rate threshold.
+		// Anomalous buckets are always written.
+		anomaly := anomalies[bucketTime]
+		if s.patternRateThreshold > 0 && anomaly == nil {
 			rate := s.calculatePatternRate(bucketSamples)
 			if rate < s.patternRateThreshold {
 				continue
@@ -307,11 +346,36 @@ func (s *stream) writePatternsBucketed(
 		}
 
 		if totalValue > 0 {
-			s.writePattern(bucketTime, streamLbls, pattern, totalValue, lvl)
+			s.writePattern(bucketTime, streamLbls, pattern, totalValue, lvl, anomaly)
+		}
+	}
+
+	// Drops to zero are flagged in buckets without samples
+	for bucketTime, anomaly := range anomalies {
+		if _, ok := buckets[bucketTime]; !ok {
+			s.writePattern(bucketTime, streamLbls, pattern, anomaly.count, lvl, anomaly)
 		}
 	}
 }
 
+// bucketSamples groups samples into buckets of the persistence granularity.
+func (s *stream) bucketSamples(samples []*logproto.PatternSample) map[model.Time][]*logproto.PatternSample {
+	buckets := make(map[model.Time][]*logproto.PatternSample)
+
+	for _, sample := range samples {
+		sampleBucket := s.bucketOf(sample.Timestamp.Time())
+		buckets[sampleBucket] = append(buckets[sampleBucket], sample)
+	}
+
+	return buckets
+}
+
+// bucketOf returns the start of the persistence bucket ts belongs to.
+func (s *stream) bucketOf(ts time.Time) model.Time {
+	bucketSize := s.persistenceGranularity.Nanoseconds()
+	return model.Time(ts.UnixNano() / bucketSize * bucketSize / 1e6)
+}
+
 // calculatePatternRate calculates a per second rate of samples in a bucket.
 func (s *stream) calculatePatternRate(samples []*logproto.PatternSample) float64 {
 	if len(samples) == 0 {
@@ -352,6 +416,19 @@ func (s *stream) calculatePatternRate(samples []*logproto.PatternSample) float64
 	return float64(totalCount) / timeSpanSeconds
 }
 
+// sortClustersByVolume sorts clusters by volume in descending order (in-place)
+func sortClustersByVolume(clusters []clusterWithMeta) {
+	slices.SortFunc(clusters, func(i, j clusterWithMeta) int {
+		if i.cluster.Volume > j.cluster.Volume {
+			return -1 // Higher volume first
+		}
+		if i.cluster.Volume < j.cluster.Volume {
+			return 1 // Lower volume last
+		}
+		return 0
+	})
+}
+
 // filterClustersByVolume sorts clusters in-place by volume and returns the number of clusters
 // to keep to represent the top X% of total volume. This mutates the input slice, and returns
 // a filtered slice that utilizes the same underlying array as the input slice.
@@ -370,16 +447,7 @@ func filterClustersByVolume(clusters []clusterWithMeta, threshold float64) []clu
 		totalVolume += cluster.cluster.Volume
 	}
 
-	// Sort clusters by volume in descending order (in-place)
-	slices.SortFunc(clusters, func(i, j clusterWithMeta) int {
-		if i.cluster.Volume > j.cluster.Volume {
-			return -1 // Higher volume first
-		}
-		if i.cluster.Volume < j.cluster.Volume {
-			return 1 // Lower volume last
-		}
-		return 0
-	})
+	sortClustersByVolume(clusters)
 
 	if totalVolume == 0 {
 		return []clusterWithMeta{}
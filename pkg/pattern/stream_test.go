@@ -3,6 +3,7 @@ package pattern
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -782,7 +783,7 @@ func TestStreamPatternRateThresholdGating(t *testing.T) {
 			},
 		).Once()
 
-		stream.writePatternsBucketed(samples, lbs, "test pattern", constants.LogLevelUnknown)
+		stream.writePatternsBucketed(samples, lbs, "test pattern", constants.LogLevelUnknown, nil)
 
 		mockWriter.AssertExpectations(t)
 	})
@@ -818,7 +819,7 @@ func TestStreamPatternRateThresholdGating(t *testing.T) {
 		// Should NOT persist the pattern
 		mockWriter.AssertNotCalled(t, "WriteEntry")
 
-		stream.writePatternsBucketed(samples, lbs, "test pattern", constants.LogLevelUnknown)
+		stream.writePatternsBucketed(samples, lbs, "test pattern", constants.LogLevelUnknown, nil)
 
 		mockWriter.AssertExpectations(t)
 	})
@@ -861,7 +862,7 @@ func TestStreamPatternRateThresholdGating(t *testing.T) {
 			},
 		).Once()
 
-		stream.writePatternsBucketed(samples, lbs, "test pattern", constants.LogLevelUnknown)
+		stream.writePatternsBucketed(samples, lbs, "test pattern", constants.LogLevelUnknown, nil)
 
 		mockWriter.AssertExpectations(t)
 	})
@@ -995,3 +996,141 @@ func TestFilterClustersByVolumeEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestStreamPatternAnomalies(t *testing.T) {
+	lbs := labels.New(
+		labels.Label{Name: "test", Value: "test"},
+		labels.Label{Name: "service_name", Value: "test_service"},
+	)
+
+	for _, tc := range []struct {
+		name      string
+		enabled   bool
+		anomalies []string
+	}{
+		{
+			name:    "should flag new patterns, spikes and drops",
+			enabled: true,
+			anomalies: []string{
+				`ts=3780000000000 count=10 detected_pattern="disk+%3C_%3E+is+full+on+node+%3C_%3E" anomaly="new" baseline=0`,
+				`ts=3900000000000 count=100 detected_pattern="user+%3C_%3E+logged+in" anomaly="spike" baseline=10`,
+				`ts=3960000000000 count=2 detected_pattern="user+%3C_%3E+logged+in" anomaly="drop" baseline=37`,
+			},
+		},
+		{
+			name:    "should not flag anything when disabled",
+			enabled: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var anomalies []string
+			mockWriter := &mockEntryWriter{}
+			mockWriter.On("WriteEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					entry := args.Get(1).(string)
+					if strings.Contains(entry, "anomaly=") {
+						anomalies = append(anomalies, strings.TrimSuffix(entry, ` service_name="test_service" test="test"`))
+					}
+				})
+
+			stream, err := newStream(
+				model.Fingerprint(labels.StableHash(lbs)),
+				lbs,
+				newIngesterMetrics(nil, "test"),
+				log.NewNopLogger(),
+				drain.FormatUnknown,
+				"123",
+				drain.DefaultConfig(),
+				&fakeLimits{
+					persistenceGranularity:  time.Minute,
+					anomalyDetectionEnabled: tc.enabled,
+					anomalyDeviationRatio:   3,
+				},
+				mockWriter,
+				aggregation.NewMetrics(nil),
+				0.99,
+			)
+			require.NoError(t, err)
+
+			// 10 lines per minute for 5 minutes, then 100 lines in the 6th minute and 2 lines in the 7th minute.
+			// A new pattern shows up in the 4th minute.
+			for minute := range 7 {
+				lines := 10
+				switch minute {
+				case 5:
+					lines = 100
+				case 6:
+					lines = 2
+				}
+				for i := range lines {
+					ts := time.Unix(int64(3600+minute*60), 0).Add(time.Duration(i) * 500 * time.Millisecond)
+					entries := []push.Entry{{Timestamp: ts, Line: fmt.Sprintf("user %d logged in", i)}}
+					if minute >= 3 && minute < 5 && i < 10 {
+						entries = append(entries, push.Entry{Timestamp: ts, Line: fmt.Sprintf("disk sda%d is full on node %d", i, i)})
+					}
+					require.NoError(t, stream.Push(context.Background(), entries))
+				}
+			}
+
+			stream.prune(0)
+
+			sort.Strings(anomalies)
+			require.Equal(t, tc.anomalies, anomalies)
+		})
+	}
+}
+
+func TestStreamPatternAnomalies_SilentPattern(t *testing.T) {
+	lbs := labels.New(
+		labels.Label{Name: "test", Value: "test"},
+		labels.Label{Name: "service_name", Value: "test_service"},
+	)
+
+	var anomalies []string
+	mockWriter := &mockEntryWriter{}
+	mockWriter.On("WriteEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			entry := args.Get(1).(string)
+			if strings.Contains(entry, "anomaly=") {
+				anomalies = append(anomalies, strings.TrimSuffix(entry, ` service_name="test_service" test="test"`))
+			}
+		})
+
+	stream, err := newStream(
+		model.Fingerprint(labels.StableHash(lbs)),
+		lbs,
+		newIngesterMetrics(nil, "test"),
+		log.NewNopLogger(),
+		drain.FormatUnknown,
+		"123",
+		drain.DefaultConfig(),
+		&fakeLimits{
+			persistenceGranularity:  time.Minute,
+			anomalyDetectionEnabled: true,
+			anomalyDeviationRatio:   3,
+		},
+		mockWriter,
+		aggregation.NewMetrics(nil),
+		0.99,
+	)
+	require.NoError(t, err)
+
+	// 10 lines per minute for 8 minutes, the disk pattern stops appearing after the 4th minute.
+	for minute := range 8 {
+		for i := range 10 {
+			ts := time.Unix(int64(3600+minute*60), 0).Add(time.Duration(i) * 500 * time.Millisecond)
+			entries := []push.Entry{{Timestamp: ts, Line: fmt.Sprintf("user %d logged in", i)}}
+			if minute < 4 {
+				entries = append(entries, push.Entry{Timestamp: ts, Line: fmt.Sprintf("disk sda%d is full on node %d", i, i)})
+			}
+			require.NoError(t, stream.Push(context.Background(), entries))
+		}
+	}
+
+	stream.prune(0)
+
+	// The drop is only flagged in the first minute the pattern is missing from.
+	require.Equal(t, []string{
+		`ts=3840000000000 count=0 detected_pattern="disk+%3C_%3E+is+full+on+node+%3C_%3E" anomaly="drop" baseline=10`,
+	}, anomalies)
+}
@@ -36,6 +36,7 @@ func DeletesForUserQuery(ctx context.Context, startT, endT time.Time, g DeleteGe
 				Selector: del.Query,
 				Start:    del.StartTime.UnixNano(),
 				End:      del.EndTime.UnixNano(),
+				Rewrite:  del.Mode == deletionproto.ModeRewrite,
 			})
 		}
 	}
@@ -114,6 +114,11 @@ func withoutOffset(query logql.DownstreamQuery) (string, time.Time, time.Time) {
 		newStart = query.Params.Start()
 		newEnd   = query.Params.End()
 	)
+	// offsets are relative to the pinned evaluation time of `@` modifiers,
+	// so they cannot be moved into the time range of the query.
+	if len(syntax.AtModifiers(expr)) > 0 {
+		return expr.String(), newStart, newEnd
+	}
 	expr.Walk(func(e syntax.Expr) bool {
 		switch rng := e.(type) {
 		case *syntax.RangeAggregationExpr:
@@ -127,6 +132,15 @@ func withoutOffset(query logql.DownstreamQuery) (string, time.Time, time.Time) {
 				newStart = newStart.Add(-off)
 
 			}
+		case *syntax.SubqueryExpr:
+			if rng.Offset != 0 {
+				newEnd = newEnd.Add(-rng.Offset)
+				newStart = newStart.Add(-rng.Offset)
+				rng.Offset = 0
+			}
+			// offsets of the inner query are relative to the evaluation
+			// timestamps of the subquery and must not be removed.
+			return false
 		}
 		return true
 	})
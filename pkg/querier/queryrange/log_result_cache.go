@@ -80,6 +80,26 @@ type logResultCache struct {
 	logger  log.Logger
 }
 
+// pinnedToFreshData returns true if the query of the request is pinned by an
+// `@` modifier to a time within the max cache freshness, for which results may
+// still change.
+func pinnedToFreshData(ctx context.Context, limits Limits, r *LokiRequest) bool {
+	if r.Plan == nil || r.Plan.AST == nil {
+		return false
+	}
+	ts, ok := maxAtModifierTime(r.Plan.AST)
+	if !ok {
+		return false
+	}
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return true
+	}
+	cacheFreshnessCapture := func(id string) time.Duration { return limits.MaxCacheFreshness(ctx, id) }
+	maxCacheFreshness := validation.MaxDurationPerTenant(tenantIDs, cacheFreshnessCapture)
+	return ts.After(model.Now().Add(-maxCacheFreshness).Time())
+}
+
 func (l *logResultCache) Do(ctx context.Context, req queryrangebase.Request) (queryrangebase.Response, error) {
 	ctx, sp := tracer.Start(ctx, "logResultCache.Do")
 	defer sp.End()
@@ -104,6 +124,9 @@ func (l *logResultCache) Do(ctx context.Context, req queryrangebase.Request) (qu
 	if !ok {
 		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "invalid request type %T", req)
 	}
+	if pinnedToFreshData(ctx, l.limits, lokiReq) {
+		return l.next.Do(ctx, req)
+	}
 
 	interval := validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, l.limits.QuerySplitDuration)
 	// skip caching by if interval is unset
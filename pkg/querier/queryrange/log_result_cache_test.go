@@ -15,7 +15,9 @@ import (
 
 	"github.com/grafana/loki/v3/pkg/loghttp"
 	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
 	"github.com/grafana/loki/v3/pkg/logqlmodel/stats"
+	"github.com/grafana/loki/v3/pkg/querier/plan"
 	"github.com/grafana/loki/v3/pkg/querier/queryrange/queryrangebase"
 	"github.com/grafana/loki/v3/pkg/storage/chunk/cache"
 )
@@ -628,6 +630,30 @@ func Test_LogResultCacheDifferentLimit(t *testing.T) {
 	fake.AssertExpectations(t)
 }
 
+func Test_pinnedToFreshData(t *testing.T) {
+	ctx := user.InjectOrgID(context.Background(), "foo")
+	now := time.Now()
+
+	for _, tc := range []struct {
+		query    string
+		expected bool
+	}{
+		{`{foo="bar"}`, false},
+		{`sum(rate({foo="bar"}[1m]))`, false},
+		{fmt.Sprintf(`sum(rate({foo="bar"}[1m] @ %d))`, now.Add(-time.Hour).Unix()), false},
+		{fmt.Sprintf(`sum(rate({foo="bar"}[1m] @ %d))`, now.Unix()), true},
+		{fmt.Sprintf(`sum(rate({foo="bar"}[1m] @ %d)) / sum(rate({foo="bar"}[1m] @ %d))`, now.Add(-time.Hour).Unix(), now.Add(time.Hour).Unix()), true},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			req := &LokiRequest{
+				Query: tc.query,
+				Plan:  &plan.QueryPlan{AST: syntax.MustParseExpr(tc.query)},
+			}
+			require.Equal(t, tc.expected, pinnedToFreshData(ctx, fakeLimits{}, req))
+		})
+	}
+}
+
 func TestExtractLokiResponse(t *testing.T) {
 	for _, tc := range []struct {
 		name           string
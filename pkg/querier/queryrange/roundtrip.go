@@ -213,6 +213,11 @@ func NewMiddleware(
 		return nil, nil, err
 	}
 
+	joinTripperware, err := NewJoinTripperware(cfg, log, limits, schema, metrics, metricsNamespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// NOTE: When we would start caching response from non-metric queries we would have to consider cache gen headers as well in
 	// MergeResponse implementation for Loki codecs same as it is done in Cortex at https://github.com/cortexproject/cortex/blob/21bad57b346c730d684d6d0205efef133422ab28/pkg/querier/queryrange/query_range.go#L170
 	logFilterTripperware, err := NewLogFilterTripperware(cfg, v1EngineOpts, v2EngineOpts, log, limits, schema, codec, iqo, resultsCache, metrics, indexStatsTripperware, metricsNamespace)
@@ -304,6 +309,7 @@ func NewMiddleware(
 			detectedFieldsRT = detectedFieldsTripperware.Wrap(next)
 			detectedLabelsRT = detectedLabelsTripperware.Wrap(next)
 			patternRT        = patternTripperware.Wrap(next)
+			joinRT           = joinTripperware.Wrap(next)
 		)
 
 		return newRoundTripper(
@@ -320,11 +326,32 @@ func NewMiddleware(
 			detectedFieldsRT,
 			detectedLabelsRT,
 			patternRT,
+			joinRT,
 			limits,
 		)
 	}), StopperWrapper{resultsCache, statsCache, volumeCache}, nil
 }
 
+// NewJoinTripperware creates a new frontend tripperware responsible for handling join queries.
+// Joins are evaluated by a single querier, they are neither split nor sharded.
+func NewJoinTripperware(cfg Config, logger log.Logger, limits Limits, schema config.SchemaConfig, metrics *Metrics, namespace string) (base.Middleware, error) {
+	return base.MiddlewareFunc(func(next base.Handler) base.Handler {
+		queryRangeMiddleware := []base.Middleware{
+			StatsCollectorMiddleware(),
+			NewLimitsMiddleware(limits),
+		}
+
+		if cfg.MaxRetries > 0 {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware, base.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+				base.NewRetryMiddleware(logger, cfg.MaxRetries, metrics.RetryMiddlewareMetrics, namespace),
+			)
+		}
+
+		return NewLimitedRoundTripper(next, limits, schema.Configs, queryRangeMiddleware...)
+	}), nil
+}
+
 func NewDetectedLabelsTripperware(cfg Config, logger log.Logger, l Limits, schema config.SchemaConfig, metrics *Metrics, namespace string, merger base.Merger, limits Limits, iqo util.IngesterQueryOptions) (base.Middleware, error) {
 	return base.MiddlewareFunc(func(next base.Handler) base.Handler {
 		splitter := newDefaultSplitter(limits, iqo)
@@ -375,7 +402,7 @@ func NewDetectedLabelsCardinalityFilter(rt base.Handler) base.Handler {
 type roundTripper struct {
 	logger log.Logger
 
-	next, limited, log, metric, series, labels, instantMetric, indexStats, seriesVolume, detectedFields, detectedLabels, pattern base.Handler
+	next, limited, log, metric, series, labels, instantMetric, indexStats, seriesVolume, detectedFields, detectedLabels, pattern, join base.Handler
 
 	limits Limits
 }
@@ -383,7 +410,7 @@ type roundTripper struct {
 // newRoundTripper creates a new queryrange roundtripper
 func newRoundTripper(
 	logger log.Logger,
-	next, limited, log, metric, series, labels, instantMetric, indexStats, seriesVolume, detectedFields, detectedLabels, pattern base.Handler,
+	next, limited, log, metric, series, labels, instantMetric, indexStats, seriesVolume, detectedFields, detectedLabels, pattern, join base.Handler,
 	limits Limits,
 ) roundTripper {
 	return roundTripper{
@@ -400,6 +427,7 @@ func newRoundTripper(
 		detectedFields: detectedFields,
 		detectedLabels: detectedLabels,
 		pattern:        pattern,
+		join:           join,
 		next:           next,
 	}
 }
@@ -491,6 +519,18 @@ func (r roundTripper) Do(ctx context.Context, req base.Request) (base.Response,
 				return r.limited.Do(ctx, req)
 			}
 			return r.log.Do(ctx, req)
+		case *syntax.JoinExpr:
+			if err := validateMaxEntriesLimits(ctx, op.Limit, r.limits); err != nil {
+				return nil, httpgrpc.Errorf(http.StatusBadRequest, "%s", err.Error())
+			}
+
+			for _, side := range []syntax.LogSelectorExpr{e.Left, e.Right} {
+				if err := validateMatchers(ctx, r.limits, side.Matchers()); err != nil {
+					return nil, httpgrpc.Errorf(http.StatusBadRequest, "%s", err.Error())
+				}
+			}
+
+			return r.join.Do(ctx, req)
 		default:
 			return r.next.Do(ctx, req)
 		}
@@ -985,8 +1025,13 @@ func NewMetricTripperware(cfg Config, engineOpts logql.EngineOpts, v2EngineCfg e
 			merger,
 			extractor,
 			cacheGenNumLoader,
-			func(_ context.Context, r base.Request) bool {
-				return !r.GetCachingOptions().Disabled
+			func(ctx context.Context, r base.Request) bool {
+				if r.GetCachingOptions().Disabled {
+					return false
+				}
+				lokiReq, ok := r.(*LokiRequest)
+				// the cached extents of queries that can't be split by time can't be merged.
+				return !ok || (!pinnedToFreshData(ctx, limits, lokiReq) && splittableByTime(lokiReq))
 			},
 			func(ctx context.Context, tenantIDs []string, r base.Request) int {
 				return MinWeightedParallelism(
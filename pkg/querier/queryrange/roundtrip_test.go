@@ -967,6 +967,41 @@ func TestLogNoFilter(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestJoinTripperware(t *testing.T) {
+	tpw, stopper, err := NewMiddleware(testConfig, testEngineOpts, engine.Config{}, nil, util_log.Logger, fakeLimits{maxQueryLength: 2 * time.Hour, maxQueryParallelism: 1}, config.SchemaConfig{Configs: testSchemas}, nil, false, nil, constants.Loki)
+	if stopper != nil {
+		defer stopper.Stop()
+	}
+	require.NoError(t, err)
+
+	query := `{app="foo"} | logfmt join on (trace_id) [10s] {app="bar"} | logfmt`
+	lreq := &LokiRequest{
+		Query:     query,
+		Limit:     1000,
+		StartTs:   testTime.Add(-time.Hour),
+		EndTs:     testTime,
+		Direction: logproto.FORWARD,
+		Path:      "/loki/api/v1/query_range",
+		Plan: &plan.QueryPlan{
+			AST: syntax.MustParseExpr(query),
+		},
+	}
+
+	ctx := user.InjectOrgID(context.Background(), "1")
+
+	// Joins are neither split nor sharded.
+	count, h := promqlResult(streams)
+	_, err = tpw.Wrap(h).Do(ctx, lreq)
+	require.NoError(t, err)
+	require.Equal(t, 1, *count)
+
+	// Joins are subject to the query limits.
+	lreq.StartTs = testTime.Add(-3 * time.Hour)
+	_, err = tpw.Wrap(h).Do(ctx, lreq)
+	require.ErrorContains(t, err, "the query time range exceeds the limit")
+	require.Equal(t, 1, *count)
+}
+
 func TestPostQueries(t *testing.T) {
 	lreq := &LokiRequest{
 		Query: `{app="foo"} |~ "foo"`,
@@ -995,6 +1030,7 @@ func TestPostQueries(t *testing.T) {
 		handler,
 		handler,
 		handler,
+		handler,
 		fakeLimits{},
 	).Do(ctx, lreq)
 	require.NoError(t, err)
@@ -1474,6 +1510,10 @@ func (f fakeLimits) MaxQuerySeries(context.Context, string) int {
 	return f.maxSeries
 }
 
+func (f fakeLimits) MaxJoinEntries(context.Context, string) int {
+	return 0
+}
+
 func (f fakeLimits) MaxCacheFreshness(context.Context, string) time.Duration {
 	return 1 * time.Minute
 }
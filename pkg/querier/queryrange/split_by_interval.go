@@ -15,7 +15,9 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logql"
 	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/querier/plan"
 	"github.com/grafana/loki/v3/pkg/querier/queryrange/queryrangebase"
 	"github.com/grafana/loki/v3/pkg/storage/config"
 	"github.com/grafana/loki/v3/pkg/util/constants"
@@ -98,6 +100,7 @@ func (h *splitByInterval) Process(
 	threshold int64,
 	input []*lokiResult,
 	maxSeries int,
+	dedup *logql.StreamsDeduplicator,
 ) ([]queryrangebase.Response, error) {
 	var responses []queryrangebase.Response
 	ctx, cancel := context.WithCancelCause(ctx)
@@ -133,6 +136,13 @@ func (h *splitByInterval) Process(
 				return nil, data.err
 			}
 
+			// The duplicates of a line can be returned by different splits
+			// when lines are deduplicated within a window. They are removed
+			// before the entries count towards the limit.
+			if casted, ok := data.resp.(*LokiResponse); ok && dedup != nil {
+				casted.Data.Result = dedup.DedupStreams(casted.Data.Result, x.req.(*LokiRequest).Direction)
+			}
+
 			responses = append(responses, data.resp)
 
 			// see if we can exit early if a limit has been reached
@@ -175,12 +185,31 @@ func (h *splitByInterval) loop(ctx context.Context, ch <-chan *lokiResult, next
 	}
 }
 
+// splittableByTime returns false for queries whose result depends on all the
+// lines of their time range, like pattern_count_over_time.
+func splittableByTime(r queryrangebase.Request) bool {
+	req, ok := r.(*LokiRequest)
+	if !ok || req.Plan == nil {
+		return true
+	}
+	return !logql.HasPatternCount(req.Plan.AST)
+}
+
 func (h *splitByInterval) Do(ctx context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
 	tenantIDs, err := tenant.TenantIDs(ctx)
 	if err != nil {
 		return nil, httpgrpc.Errorf(http.StatusBadRequest, "%s", err.Error())
 	}
 
+	// `@ start()` and `@ end()` refer to the time range of the original
+	// request, so they need to be pinned before the request is split.
+	if req, ok := r.(*LokiRequest); ok {
+		r, err = resolveAtModifiers(req)
+		if err != nil {
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, "%s", err.Error())
+		}
+	}
+
 	var interval time.Duration
 	switch r.(type) {
 	case *LokiSeriesRequest, *LabelRequest:
@@ -189,8 +218,8 @@ func (h *splitByInterval) Do(ctx context.Context, r queryrangebase.Request) (que
 		interval = validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, h.limits.QuerySplitDuration)
 	}
 
-	// skip split by if unset
-	if interval == 0 {
+	// skip split by if unset or the query can't be split by time
+	if interval == 0 || !splittableByTime(r) {
 		return h.next.Do(ctx, r)
 	}
 
@@ -209,10 +238,16 @@ func (h *splitByInterval) Do(ctx context.Context, r queryrangebase.Request) (que
 		return h.next.Do(ctx, intervals[0])
 	}
 
-	var limit int64
+	var (
+		limit int64
+		dedup *logql.StreamsDeduplicator
+	)
 	switch req := r.(type) {
 	case *LokiRequest:
 		limit = int64(req.Limit)
+		if req.Plan != nil {
+			dedup = logql.NewStreamsDeduplicator(req.Plan.AST)
+		}
 		if req.Direction == logproto.BACKWARD {
 			for i, j := 0, len(intervals)-1; i < j; i, j = i+1, j-1 {
 				intervals[i], intervals[j] = intervals[j], intervals[i]
@@ -241,7 +276,7 @@ func (h *splitByInterval) Do(ctx context.Context, r queryrangebase.Request) (que
 	maxSeriesCapture := func(id string) int { return h.limits.MaxQuerySeries(ctx, id) }
 	maxSeries := validation.SmallestPositiveIntPerTenant(tenantIDs, maxSeriesCapture)
 	maxParallelism := MinWeightedParallelism(ctx, tenantIDs, h.configs, h.limits, model.Time(r.GetStart().UnixMilli()), model.Time(r.GetEnd().UnixMilli()))
-	resps, err := h.Process(ctx, maxParallelism, limit, input, maxSeries)
+	resps, err := h.Process(ctx, maxParallelism, limit, input, maxSeries, dedup)
 	if err != nil {
 		return nil, err
 	}
@@ -278,3 +313,49 @@ func maxRangeVectorAndOffsetDuration(expr syntax.Expr) (time.Duration, time.Dura
 	})
 	return maxRVDuration, maxOffset
 }
+
+// resolveAtModifiers returns a copy of the request in which `@ start()` and
+// `@ end()` modifiers of the query are replaced by the start and end time of
+// the request. The request is returned as is if there is nothing to resolve.
+func resolveAtModifiers(r *LokiRequest) (*LokiRequest, error) {
+	if r.Plan == nil || r.Plan.AST == nil {
+		return r, nil
+	}
+	unresolved := false
+	for _, at := range syntax.AtModifiers(r.Plan.AST) {
+		if at.StartOrEnd != "" {
+			unresolved = true
+		}
+	}
+	if !unresolved {
+		return r, nil
+	}
+
+	expr, err := syntax.Clone(r.Plan.AST)
+	if err != nil {
+		return nil, err
+	}
+	syntax.ResolveAtModifiers(expr, r.StartTs, r.EndTs)
+
+	resolved := *r
+	resolved.Query = expr.String()
+	resolved.Plan = &plan.QueryPlan{AST: expr}
+	return &resolved, nil
+}
+
+// maxAtModifierTime returns the latest evaluation time pinned by an `@` modifier within a LogQL query.
+func maxAtModifierTime(expr syntax.Expr) (time.Time, bool) {
+	var (
+		maxTs time.Time
+		found bool
+	)
+	for _, at := range syntax.AtModifiers(expr) {
+		if at.StartOrEnd != "" {
+			continue
+		}
+		if ts := at.Time(); !found || ts.After(maxTs) {
+			maxTs, found = ts, true
+		}
+	}
+	return maxTs, found
+}
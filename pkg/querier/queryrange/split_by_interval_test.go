@@ -1545,6 +1545,221 @@ func Test_splitByInterval_Do(t *testing.T) {
 	}
 }
 
+func Test_splitByInterval_Dedup(t *testing.T) {
+	ctx := user.InjectOrgID(context.Background(), "1")
+	next := queryrangebase.HandlerFunc(func(_ context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+		req := r.(*LokiRequest)
+		// each split returns a line at its start and at its end.
+		return &LokiResponse{
+			Status:    loghttp.QueryStatusSuccess,
+			Direction: req.Direction,
+			Limit:     req.Limit,
+			Version:   uint32(loghttp.VersionV1),
+			Data: LokiData{
+				ResultType: loghttp.ResultTypeStream,
+				Result: []logproto.Stream{
+					{
+						Labels: `{foo="bar"}`,
+						Entries: []logproto.Entry{
+							{Timestamp: req.StartTs, Line: "retry"},
+							{Timestamp: req.EndTs.Add(-time.Second), Line: "retry"},
+						},
+					},
+				},
+			},
+		}, nil
+	})
+
+	split := SplitByIntervalMiddleware(
+		testSchemas,
+		WithSplitByLimits(fakeLimits{maxQueryParallelism: 1}, time.Hour),
+		DefaultCodec,
+		newDefaultSplitter(fakeLimits{}, nil),
+		nilMetrics,
+	).Wrap(next)
+
+	query := `{foo="bar"} | dedup within 5s`
+	res, err := split.Do(ctx, &LokiRequest{
+		StartTs:   time.Unix(0, 0),
+		EndTs:     time.Unix(0, (2 * time.Hour).Nanoseconds()),
+		Query:     query,
+		Limit:     1000,
+		Direction: logproto.FORWARD,
+		Path:      "/loki/api/v1/query_range",
+		Plan: &plan.QueryPlan{
+			AST: syntax.MustParseExpr(query),
+		},
+	})
+	require.NoError(t, err)
+
+	// the lines at the end of the first split and at the start of the second
+	// split are duplicates.
+	require.Equal(t, []logproto.Stream{
+		{
+			Labels: `{foo="bar"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(0, 0), Line: "retry"},
+				{Timestamp: time.Unix(3599, 0), Line: "retry"},
+				{Timestamp: time.Unix(7199, 0), Line: "retry"},
+			},
+		},
+	}, res.(*LokiResponse).Data.Result)
+}
+
+func Test_splitByInterval_DedupLimit(t *testing.T) {
+	ctx := user.InjectOrgID(context.Background(), "1")
+	next := queryrangebase.HandlerFunc(func(_ context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+		req := r.(*LokiRequest)
+		// each split returns a line, its duplicate and another line.
+		return &LokiResponse{
+			Status:    loghttp.QueryStatusSuccess,
+			Direction: req.Direction,
+			Limit:     req.Limit,
+			Version:   uint32(loghttp.VersionV1),
+			Data: LokiData{
+				ResultType: loghttp.ResultTypeStream,
+				Result: []logproto.Stream{
+					{
+						Labels: `{foo="bar"}`,
+						Entries: []logproto.Entry{
+							{Timestamp: req.StartTs, Line: "retry"},
+							{Timestamp: req.StartTs.Add(time.Second), Line: "retry"},
+							{Timestamp: req.StartTs.Add(10 * time.Second), Line: "retry"},
+						},
+					},
+				},
+			},
+		}, nil
+	})
+
+	split := SplitByIntervalMiddleware(
+		testSchemas,
+		WithSplitByLimits(fakeLimits{maxQueryParallelism: 1}, time.Hour),
+		DefaultCodec,
+		newDefaultSplitter(fakeLimits{}, nil),
+		nilMetrics,
+	).Wrap(next)
+
+	query := `{foo="bar"} | dedup within 5s`
+	res, err := split.Do(ctx, &LokiRequest{
+		StartTs:   time.Unix(0, 0),
+		EndTs:     time.Unix(0, (2 * time.Hour).Nanoseconds()),
+		Query:     query,
+		Limit:     4,
+		Direction: logproto.FORWARD,
+		Path:      "/loki/api/v1/query_range",
+		Plan: &plan.QueryPlan{
+			AST: syntax.MustParseExpr(query),
+		},
+	})
+	require.NoError(t, err)
+
+	// the duplicates are removed before the response is limited.
+	require.Equal(t, []logproto.Stream{
+		{
+			Labels: `{foo="bar"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(0, 0), Line: "retry"},
+				{Timestamp: time.Unix(10, 0), Line: "retry"},
+				{Timestamp: time.Unix(3600, 0), Line: "retry"},
+				{Timestamp: time.Unix(3610, 0), Line: "retry"},
+			},
+		},
+	}, res.(*LokiResponse).Data.Result)
+}
+
+func Test_splitByInterval_AtModifiers(t *testing.T) {
+	ctx := user.InjectOrgID(context.Background(), "1")
+
+	var queries []string
+	next := queryrangebase.HandlerFunc(func(_ context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+		req := r.(*LokiRequest)
+		require.Equal(t, req.Query, req.Plan.AST.String())
+		queries = append(queries, req.Query)
+		return &LokiPromResponse{
+			Response: &queryrangebase.PrometheusResponse{
+				Status: loghttp.QueryStatusSuccess,
+				Data: queryrangebase.PrometheusData{
+					ResultType: loghttp.ResultTypeMatrix,
+				},
+			},
+		}, nil
+	})
+
+	split := SplitByIntervalMiddleware(
+		testSchemas,
+		WithSplitByLimits(fakeLimits{maxQueryParallelism: 1}, time.Hour),
+		DefaultCodec,
+		newMetricQuerySplitter(fakeLimits{}, nil),
+		nilMetrics,
+	).Wrap(next)
+
+	query := `sum(rate({app="foo"}[1m])) / sum(rate({app="foo"}[1m] @ start())) / sum(rate({app="foo"}[1m] @ end()))`
+	req := &LokiRequest{
+		StartTs: time.Unix(0, 0),
+		EndTs:   time.Unix(3*3600, 0),
+		Query:   query,
+		Step:    60000,
+		Path:    "/loki/api/v1/query_range",
+		Plan: &plan.QueryPlan{
+			AST: syntax.MustParseExpr(query),
+		},
+	}
+
+	_, err := split.Do(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, queries, 3)
+	for _, q := range queries {
+		// all splits are pinned to the time range of the original request
+		require.Equal(t, `((sum(rate({app="foo"}[1m])) / sum(rate({app="foo"}[1m] @ 0))) / sum(rate({app="foo"}[1m] @ 10800)))`, q)
+	}
+	// the original request is not modified
+	require.Equal(t, query, req.Query)
+	require.Equal(t, syntax.MustParseExpr(query).String(), req.Plan.AST.String())
+}
+
+func Test_splitByInterval_PatternCount(t *testing.T) {
+	ctx := user.InjectOrgID(context.Background(), "1")
+
+	var requests []*LokiRequest
+	next := queryrangebase.HandlerFunc(func(_ context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+		requests = append(requests, r.(*LokiRequest))
+		return &LokiPromResponse{
+			Response: &queryrangebase.PrometheusResponse{
+				Status: loghttp.QueryStatusSuccess,
+				Data: queryrangebase.PrometheusData{
+					ResultType: loghttp.ResultTypeMatrix,
+				},
+			},
+		}, nil
+	})
+
+	split := SplitByIntervalMiddleware(
+		testSchemas,
+		WithSplitByLimits(fakeLimits{maxQueryParallelism: 1}, time.Hour),
+		DefaultCodec,
+		newMetricQuerySplitter(fakeLimits{}, nil),
+		nilMetrics,
+	).Wrap(next)
+
+	query := `sum by (detected_pattern) (pattern_count_over_time({app="foo"}[1m]))`
+	req := &LokiRequest{
+		StartTs: time.Unix(0, 0),
+		EndTs:   time.Unix(3*3600, 0),
+		Query:   query,
+		Step:    60000,
+		Path:    "/loki/api/v1/query_range",
+		Plan: &plan.QueryPlan{
+			AST: syntax.MustParseExpr(query),
+		},
+	}
+
+	_, err := split.Do(ctx, req)
+	require.NoError(t, err)
+	// patterns are detected from all the lines of the query, it is not split.
+	require.Equal(t, []*LokiRequest{req}, requests)
+}
+
 func Test_series_splitByInterval_Do(t *testing.T) {
 	ctx := user.InjectOrgID(context.Background(), "1")
 	next := queryrangebase.HandlerFunc(func(_ context.Context, _ queryrangebase.Request) (queryrangebase.Response, error) {
@@ -14,6 +14,7 @@ type MockLimits struct {
 	MaxQueryTimeoutVal            time.Duration
 	MaxQueryRangeVal              time.Duration
 	MaxQuerySeriesVal             int
+	MaxJoinEntriesVal             int
 	MaxConcurrentTailRequestsVal  int
 	MaxEntriesLimitPerQueryVal    int
 	MaxStreamsMatchersPerQueryVal int
@@ -48,6 +49,10 @@ func (m *MockLimits) MaxQuerySeries(_ context.Context, _ string) int {
 	return m.MaxQuerySeriesVal
 }
 
+func (m *MockLimits) MaxJoinEntries(_ context.Context, _ string) int {
+	return m.MaxJoinEntriesVal
+}
+
 func (m *MockLimits) MaxConcurrentTailRequests(_ context.Context, _ string) int {
 	return m.MaxConcurrentTailRequestsVal
 }
@@ -73,6 +78,16 @@ func (m *MockLimits) PatternIngesterTokenizableJSONFields(_ string) []string {
 	return []string{}
 }
 
+// PatternIngesterMasks implements pattern.drain.Limits interface
+func (m *MockLimits) PatternIngesterMasks(_ string) []string {
+	return nil
+}
+
+// PatternIngesterTokenizer implements pattern.drain.Limits interface
+func (m *MockLimits) PatternIngesterTokenizer(_ string) string {
+	return ""
+}
+
 // PatternPersistenceEnabled implements pattern.Limits interface
 func (m *MockLimits) PatternPersistenceEnabled(_ string) bool {
 	return m.PatternPersistenceEnabledVal
@@ -87,3 +102,13 @@ func (m *MockLimits) PatternRateThreshold(_ string) float64 {
 func (m *MockLimits) PersistenceGranularity(_ string) time.Duration {
 	return m.PersistenceGranularityVal
 }
+
+// PatternAnomalyDetectionEnabled implements pattern.Limits interface
+func (m *MockLimits) PatternAnomalyDetectionEnabled(_ string) bool {
+	return false
+}
+
+// PatternAnomalyDeviationRatio implements pattern.Limits interface
+func (m *MockLimits) PatternAnomalyDeviationRatio(_ string) float64 {
+	return 0
+}
@@ -0,0 +1,427 @@
+package ruler
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/util/annotations"
+
+	"github.com/grafana/loki/v3/pkg/querier/series"
+	"github.com/grafana/loki/v3/pkg/ruler/rulestore"
+	"github.com/grafana/loki/v3/pkg/util"
+)
+
+// AlertStateConfig configures the persistence of the alert states in the rule storage.
+type AlertStateConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	UpdatePeriod time.Duration `yaml:"update_period"`
+}
+
+func (cfg *AlertStateConfig) RegisterFlags(f *flag.FlagSet) {
+	f.BoolVar(&cfg.Enabled, "ruler.alert-state.enabled", false, "Persist the active-since state of the pending and firing alerts of each rule group to the rule storage, and restore it when a ruler starts evaluating the group, instead of re-evaluating the alerting rules over the past. Requires the ruler_storage object storage, used when storage_config.use_thanos_objstore is enabled.")
+	f.DurationVar(&cfg.UpdatePeriod, "ruler.alert-state.update-period", time.Minute, "How often the alert state of a rule group is persisted when its active alerts didn't change. The state is always persisted when the active alerts change. Restored alerts may be considered down since the last update.")
+}
+
+var errAlertStateStore = errors.New("ruler alert state persistence requires a rule storage supporting it: use ruler_storage with storage_config.use_thanos_objstore enabled")
+
+// alertStateRuleStore is a rule store persisting the alert states of its rule groups.
+type alertStateRuleStore interface {
+	rulestore.RuleStore
+	rulestore.AlertStateStore
+}
+
+// alertStateStore returns the rule store as an alert state store, if enabled.
+func alertStateStore(cfg AlertStateConfig, ruleStore rulestore.RuleStore) (alertStateRuleStore, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	store, ok := ruleStore.(alertStateRuleStore)
+	if !ok {
+		return nil, errAlertStateStore
+	}
+	return store, nil
+}
+
+// alertStatePersister persists the active alerts of the rule groups of a tenant
+// after their evaluations, and serves them as the ALERTS_FOR_STATE series queried
+// by the rules manager to restore the `for` state of the alerts. The restoration
+// falls back to the given queryable for the alerts without persisted state.
+type alertStatePersister struct {
+	userID   string
+	cfg      AlertStateConfig
+	store    alertStateRuleStore
+	fallback storage.Queryable
+	logger   log.Logger
+
+	// groups returns the rule groups evaluated by the rules manager of the tenant.
+	groups func() []*rules.Group
+
+	mtx sync.Mutex
+	// persisted alert states, by group key
+	persisted map[string]persistedAlertState
+	// alert states loaded to restore alerts
+	loaded   []*rulestore.AlertStateDesc
+	loadedAt time.Time
+}
+
+type persistedAlertState struct {
+	signature   string
+	evaluatedAt time.Time
+}
+
+func newAlertStatePersister(userID string, cfg AlertStateConfig, store alertStateRuleStore, fallback storage.Queryable, logger log.Logger) *alertStatePersister {
+	return &alertStatePersister{
+		userID:    userID,
+		cfg:       cfg,
+		store:     store,
+		fallback:  fallback,
+		logger:    log.With(logger, "component", "alert-state"),
+		persisted: make(map[string]persistedAlertState),
+	}
+}
+
+// evalIterationFunc returns a group evaluation function persisting the alert
+// state of the group after evaluating it with the given function.
+func (p *alertStatePersister) evalIterationFunc(next rules.GroupEvalIterationFunc) rules.GroupEvalIterationFunc {
+	if next == nil {
+		next = rules.DefaultEvalIterationFunc
+	}
+	return func(ctx context.Context, g *rules.Group, evalTimestamp time.Time) {
+		next(ctx, g, evalTimestamp)
+		p.persist(ctx, g, evalTimestamp)
+	}
+}
+
+func (p *alertStatePersister) persist(ctx context.Context, g *rules.Group, ts time.Time) {
+	// the group is evaluated before its alerts are restored: until then, their
+	// active-since time is the time of the first evaluation, and persisting it
+	// would overwrite the state left by the previous ruler before restoring it
+	if !groupRestored(g) {
+		return
+	}
+
+	namespace, err := ruleGroupNamespace(g.File())
+	if err != nil {
+		level.Warn(p.logger).Log("msg", "failed to get rule group namespace, not persisting alert state", "user", p.userID, "file", g.File(), "err", err)
+		return
+	}
+
+	state := &rulestore.AlertStateDesc{Namespace: namespace, Group: g.Name(), EvaluatedAt: ts.UTC()}
+	for _, r := range g.Rules() {
+		ar, ok := r.(*rules.AlertingRule)
+		if !ok {
+			continue
+		}
+		for _, a := range ar.ActiveAlerts() {
+			state.Alerts = append(state.Alerts, rulestore.ActiveAlertDesc{
+				Labels:   a.Labels.Map(),
+				ActiveAt: a.ActiveAt.UTC(),
+			})
+		}
+	}
+	signature := alertStateSignature(state)
+
+	key := rules.GroupKey(g.File(), g.Name())
+	p.mtx.Lock()
+	prev, ok := p.persisted[key]
+	p.mtx.Unlock()
+
+	switch {
+	case len(state.Alerts) == 0:
+		// the state left by a previous evaluation of the group, possibly by
+		// another ruler, is deleted once
+		if ok && prev.signature == signature {
+			return
+		}
+		err = p.store.DeleteAlertState(ctx, p.userID, namespace, g.Name())
+		if errors.Is(err, rulestore.ErrAlertStateNotFound) {
+			err = nil
+		}
+	case ok && prev.signature == signature && ts.Sub(prev.evaluatedAt) < p.cfg.UpdatePeriod:
+		return
+	default:
+		err = p.store.SetAlertState(ctx, p.userID, state)
+	}
+	if err != nil {
+		level.Warn(p.logger).Log("msg", "failed to persist alert state", "user", p.userID, "namespace", namespace, "group", g.Name(), "err", err)
+		return
+	}
+
+	p.mtx.Lock()
+	p.persisted[key] = persistedAlertState{signature: signature, evaluatedAt: ts}
+	p.mtx.Unlock()
+}
+
+// groupRestored returns true if the for state of all the alerting rules of the
+// group has been restored, or didn't need to be.
+func groupRestored(g *rules.Group) bool {
+	for _, r := range g.Rules() {
+		if ar, ok := r.(*rules.AlertingRule); ok && !ar.Restored() {
+			return false
+		}
+	}
+	return true
+}
+
+// deleteRemovedGroups deletes the alert state of the groups that are not
+// evaluated by the rules manager anymore and were deleted from the rule store.
+// The state of the groups now evaluated by another ruler is kept.
+func (p *alertStatePersister) deleteRemovedGroups(ctx context.Context, before, after []*rules.Group) {
+	current := make(map[string]struct{}, len(after))
+	for _, g := range after {
+		current[rules.GroupKey(g.File(), g.Name())] = struct{}{}
+	}
+	for _, g := range before {
+		key := rules.GroupKey(g.File(), g.Name())
+		if _, ok := current[key]; ok {
+			continue
+		}
+		p.mtx.Lock()
+		delete(p.persisted, key)
+		p.mtx.Unlock()
+
+		namespace, err := ruleGroupNamespace(g.File())
+		if err != nil {
+			continue
+		}
+		if _, err := p.store.GetRuleGroup(ctx, p.userID, namespace, g.Name()); !errors.Is(err, rulestore.ErrGroupNotFound) {
+			continue
+		}
+		err = p.store.DeleteAlertState(ctx, p.userID, namespace, g.Name())
+		if err != nil && !errors.Is(err, rulestore.ErrAlertStateNotFound) {
+			level.Warn(p.logger).Log("msg", "failed to delete alert state of deleted rule group", "user", p.userID, "namespace", namespace, "group", g.Name(), "err", err)
+		}
+	}
+}
+
+func alertStateSignature(state *rulestore.AlertStateDesc) string {
+	alerts := make([]string, 0, len(state.Alerts))
+	for _, a := range state.Alerts {
+		alerts = append(alerts, fmt.Sprintf("%s@%d", labels.FromMap(a.Labels), a.ActiveAt.Unix()))
+	}
+	sort.Strings(alerts)
+	return strings.Join(alerts, ",")
+}
+
+// states returns the persisted alert states of the tenant. They are loaded
+// once per update period, as all the groups are restored at the same time.
+func (p *alertStatePersister) states(ctx context.Context) ([]*rulestore.AlertStateDesc, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.loaded != nil && time.Since(p.loadedAt) < p.cfg.UpdatePeriod {
+		return p.loaded, nil
+	}
+	states, err := p.store.ListAlertStates(ctx, p.userID)
+	if err != nil {
+		return nil, err
+	}
+	p.loaded, p.loadedAt = states, time.Now()
+	return states, nil
+}
+
+// restoredAlerts returns the alerts of an alerting rule in the persisted alert
+// state of its group, by labels. The state of an alert is derived from the
+// time it has been active for when persisted: it was firing if active for the
+// `for` duration of its rule, and pending otherwise.
+func (p *alertStatePersister) restoredAlerts(ctx context.Context, namespace, group, alertname string) map[string]alertInstance {
+	var rule *rules.AlertingRule
+	if p.groups != nil {
+		for _, g := range p.groups() {
+			if g.Name() != group {
+				continue
+			}
+			if ns, err := ruleGroupNamespace(g.File()); err != nil || ns != namespace {
+				continue
+			}
+			for _, r := range g.Rules() {
+				if ar, ok := r.(*rules.AlertingRule); ok && ar.Name() == alertname {
+					rule = ar
+				}
+			}
+		}
+	}
+	if rule == nil {
+		return nil
+	}
+
+	states, err := p.states(ctx)
+	if err != nil {
+		level.Warn(p.logger).Log("msg", "failed to load alert states, not restoring the previous alert states", "user", p.userID, "alert", alertname, "err", err)
+		return nil
+	}
+
+	res := make(map[string]alertInstance)
+	for _, state := range states {
+		if state.Namespace != namespace || state.Group != group {
+			continue
+		}
+		for _, a := range state.Alerts {
+			if a.Labels[labels.AlertName] != alertname {
+				continue
+			}
+			alert := alertInstance{state: rules.StatePending, labels: withoutLogOutputLabel(labels.FromMap(a.Labels))}
+			if !state.EvaluatedAt.Before(a.ActiveAt.Add(rule.HoldDuration())) {
+				alert.state = rules.StateFiring
+			}
+			res[alert.labels.String()] = alert
+		}
+	}
+	return res
+}
+
+// Querier implements storage.Queryable. It is only called to restore the for
+// state of the alerts, with the outage tolerance as mint.
+func (p *alertStatePersister) Querier(mint, maxt int64) (storage.Querier, error) {
+	return &alertStateQuerier{persister: p, mint: mint, maxt: maxt}, nil
+}
+
+type alertStateQuerier struct {
+	persister  *alertStatePersister
+	mint, maxt int64
+}
+
+// Select returns the ALERTS_FOR_STATE series of the persisted alerts matching
+// the matchers, which are the alertname and the labels of the alerting rule.
+// Only the persisted state of the group of the restored alerting rule is used.
+func (q *alertStateQuerier) Select(ctx context.Context, sortSeries bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	p := q.persister
+
+	var alertname string
+	for _, m := range matchers {
+		if m.Name == labels.AlertName && m.Type == labels.MatchEqual {
+			alertname = m.Value
+		}
+	}
+
+	namespace, group, ok := p.restoringGroup(alertname, matchers)
+	if !ok {
+		level.Debug(p.logger).Log("msg", "no single rule group restoring the alert, restoring for state via evaluation", "user", p.userID, "alert", alertname)
+		return q.fallback(ctx, sortSeries, hints, matchers...)
+	}
+
+	states, err := p.states(ctx)
+	if err != nil {
+		level.Warn(p.logger).Log("msg", "failed to load alert states, restoring for state via evaluation", "user", p.userID, "alert", alertname, "err", err)
+		return q.fallback(ctx, sortSeries, hints, matchers...)
+	}
+
+	var (
+		found bool
+		res   []storage.Series
+	)
+	for _, state := range states {
+		if state.Namespace != namespace || state.Group != group {
+			continue
+		}
+		t := util.TimeToMillis(state.EvaluatedAt)
+		if t < q.mint || t > q.maxt {
+			continue
+		}
+	outer:
+		for _, a := range state.Alerts {
+			if a.Labels[labels.AlertName] != alertname {
+				continue
+			}
+			found = true
+
+			lbls := ForStateMetric(labels.FromMap(a.Labels), alertname)
+			for _, m := range matchers {
+				if !m.Matches(lbls.Get(m.Name)) {
+					continue outer
+				}
+			}
+			res = append(res, series.NewConcreteSeries(lbls, []model.SamplePair{
+				{Timestamp: model.Time(t), Value: model.SampleValue(a.ActiveAt.Unix())},
+			}))
+		}
+	}
+	if !found {
+		return q.fallback(ctx, sortSeries, hints, matchers...)
+	}
+
+	level.Debug(p.logger).Log("msg", "restoring for state from persisted alert state", "user", p.userID, "alert", alertname, "series", len(res))
+	return series.NewConcreteSeriesSet(res)
+}
+
+// restoringGroup returns the namespace and the name of the group of the alerting
+// rule whose for state is being restored, identified by the matchers of its
+// ALERTS_FOR_STATE series. It returns false if no group or several groups have
+// such an alerting rule that isn't restored yet.
+func (p *alertStatePersister) restoringGroup(alertname string, matchers []*labels.Matcher) (string, string, bool) {
+	if p.groups == nil {
+		return "", "", false
+	}
+
+	var found *rules.Group
+	for _, g := range p.groups() {
+		for _, r := range g.Rules() {
+			ar, ok := r.(*rules.AlertingRule)
+			if !ok || ar.Restored() || ar.Name() != alertname || !matchesForState(ar, matchers) {
+				continue
+			}
+			if found != nil && found != g {
+				return "", "", false
+			}
+			found = g
+		}
+	}
+	if found == nil {
+		return "", "", false
+	}
+	namespace, err := ruleGroupNamespace(found.File())
+	if err != nil {
+		return "", "", false
+	}
+	return namespace, found.Name(), true
+}
+
+// matchesForState returns true if the matchers select exactly the ALERTS_FOR_STATE
+// series of the alerting rule.
+func matchesForState(ar *rules.AlertingRule, matchers []*labels.Matcher) bool {
+	lbls := ForStateMetric(ar.Labels(), ar.Name())
+	if lbls.Len() != len(matchers) {
+		return false
+	}
+	for _, m := range matchers {
+		if m.Type != labels.MatchEqual || lbls.Get(m.Name) != m.Value {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *alertStateQuerier) fallback(ctx context.Context, sortSeries bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	if q.persister.fallback == nil {
+		return storage.EmptySeriesSet()
+	}
+	querier, err := q.persister.fallback.Querier(q.mint, q.maxt)
+	if err != nil {
+		return storage.ErrSeriesSet(err)
+	}
+	return querier.Select(ctx, sortSeries, hints, matchers...)
+}
+
+// LabelValues returns all potential values for a label name.
+func (*alertStateQuerier) LabelValues(_ context.Context, _ string, _ *storage.LabelHints, _ ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	return nil, nil, errors.New("unimplemented")
+}
+
+// LabelNames returns all the unique label names present in the block in sorted order.
+func (*alertStateQuerier) LabelNames(_ context.Context, _ *storage.LabelHints, _ ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	return nil, nil, errors.New("unimplemented")
+}
+
+func (*alertStateQuerier) Close() error { return nil }
@@ -0,0 +1,296 @@
+package ruler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+
+	"github.com/grafana/loki/v3/pkg/ruler/rulespb"
+	"github.com/grafana/loki/v3/pkg/ruler/rulestore"
+	"github.com/grafana/loki/v3/pkg/ruler/rulestore/bucketclient"
+)
+
+type countingAlertStateStore struct {
+	*bucketclient.BucketRuleStore
+	sets, deletes int
+}
+
+func (s *countingAlertStateStore) SetAlertState(ctx context.Context, userID string, state *rulestore.AlertStateDesc) error {
+	s.sets++
+	return s.BucketRuleStore.SetAlertState(ctx, userID, state)
+}
+
+func (s *countingAlertStateStore) DeleteAlertState(ctx context.Context, userID, namespace, group string) error {
+	s.deletes++
+	return s.BucketRuleStore.DeleteAlertState(ctx, userID, namespace, group)
+}
+
+func newAlertStateTestGroup(t *testing.T, queryable storage.Queryable, active *bool) *rules.Group {
+	return newNamedAlertStateTestGroup(t, "/rules/user/my%2Fnamespace", "group", queryable, active)
+}
+
+func newNamedAlertStateTestGroup(t *testing.T, file, name string, queryable storage.Queryable, active *bool) *rules.Group {
+	expr, err := GroupLoader{}.Parse(`sum by (job) (rate({job="app"}[1m])) > 1`)
+	require.NoError(t, err)
+
+	rule := rules.NewAlertingRule("HighRate", expr, 10*time.Minute, 0, labels.FromStrings("severity", "page"), labels.EmptyLabels(), labels.EmptyLabels(), "", false, nil)
+	return rules.NewGroup(rules.GroupOptions{
+		Name:          name,
+		File:          file,
+		Interval:      time.Minute,
+		Rules:         []rules.Rule{rule},
+		ShouldRestore: true,
+		Opts: &rules.ManagerOptions{
+			QueryFunc: func(_ context.Context, _ string, t time.Time) (promql.Vector, error) {
+				if !*active {
+					return nil, nil
+				}
+				return promql.Vector{{Metric: labels.FromStrings("job", "app"), T: t.UnixMilli(), F: 2}}, nil
+			},
+			NotifyFunc:      func(context.Context, string, ...*rules.Alert) {},
+			Appendable:      nopAppendable{},
+			Queryable:       queryable,
+			Context:         context.Background(),
+			OutageTolerance: time.Hour,
+			ForGracePeriod:  time.Minute,
+		},
+	})
+}
+
+func TestAlertStatePersistence(t *testing.T) {
+	store := &countingAlertStateStore{BucketRuleStore: bucketclient.NewBucketRuleStore(objstore.NewInMemBucket(), nil, log.NewNopLogger())}
+	cfg := AlertStateConfig{Enabled: true, UpdatePeriod: 2 * time.Minute}
+
+	active := true
+	persister := newAlertStatePersister("user", cfg, store, nil, log.NewNopLogger())
+	g := newAlertStateTestGroup(t, persister, &active)
+	persister.groups = func() []*rules.Group { return []*rules.Group{g} }
+	eval := persister.evalIterationFunc(nil)
+
+	// like rules.Group.run, the group is evaluated twice before its alerts
+	// are restored, and nothing is persisted until then
+	start := time.Unix(0, 0).UTC().Add(24 * time.Hour)
+	eval(context.Background(), g, start)
+	eval(context.Background(), g, start.Add(time.Minute))
+	require.Equal(t, 0, store.sets)
+	g.RestoreForState(start.Add(time.Minute))
+
+	eval(context.Background(), g, start.Add(2*time.Minute))
+	eval(context.Background(), g, start.Add(3*time.Minute))
+	// the active alerts didn't change since the last update
+	require.Equal(t, 1, store.sets)
+	eval(context.Background(), g, start.Add(5*time.Minute))
+	require.Equal(t, 2, store.sets)
+
+	expected := []*rulestore.AlertStateDesc{{
+		Namespace:   "my/namespace",
+		Group:       "group",
+		EvaluatedAt: start.Add(5 * time.Minute),
+		Alerts: []rulestore.ActiveAlertDesc{{
+			Labels:   map[string]string{"alertname": "HighRate", "job": "app", "severity": "page"},
+			ActiveAt: start,
+		}},
+	}}
+	states, err := store.ListAlertStates(context.Background(), "user")
+	require.NoError(t, err)
+	require.Equal(t, expected, states)
+
+	// another ruler starts evaluating the group one minute later
+	restarted := newAlertStatePersister("user", cfg, store, nil, log.NewNopLogger())
+	g = newAlertStateTestGroup(t, restarted, &active)
+	restarted.groups = func() []*rules.Group { return []*rules.Group{g} }
+	eval = restarted.evalIterationFunc(nil)
+	ts := start.Add(6 * time.Minute)
+	eval(context.Background(), g, ts)
+	eval(context.Background(), g, ts.Add(time.Minute))
+
+	// the state is not overwritten by the evaluations before the restoration
+	require.Equal(t, 2, store.sets)
+	states, err = store.ListAlertStates(context.Background(), "user")
+	require.NoError(t, err)
+	require.Equal(t, expected, states)
+
+	g.RestoreForState(ts.Add(time.Minute))
+	alerts := g.Rules()[0].(*rules.AlertingRule).ActiveAlerts()
+	require.Len(t, alerts, 1)
+	// pending for 5m before the restart, the downtime is not counted
+	require.Equal(t, start.Add(2*time.Minute), alerts[0].ActiveAt)
+
+	// the state of the groups without active alerts is deleted
+	active = false
+	eval(context.Background(), g, ts.Add(2*time.Minute))
+	eval(context.Background(), g, ts.Add(3*time.Minute))
+	require.Equal(t, 1, store.deletes)
+
+	states, err = store.ListAlertStates(context.Background(), "user")
+	require.NoError(t, err)
+	require.Empty(t, states)
+}
+
+func TestAlertStateQuerierOutageTolerance(t *testing.T) {
+	store := bucketclient.NewBucketRuleStore(objstore.NewInMemBucket(), nil, log.NewNopLogger())
+	evaluatedAt := time.Unix(3600, 0).UTC()
+	require.NoError(t, store.SetAlertState(context.Background(), "user", &rulestore.AlertStateDesc{
+		Namespace:   "namespace",
+		Group:       "group",
+		EvaluatedAt: evaluatedAt,
+		Alerts: []rulestore.ActiveAlertDesc{
+			{Labels: map[string]string{"alertname": "HighRate", "job": "app", "severity": "page"}, ActiveAt: evaluatedAt.Add(-time.Minute)},
+		},
+	}))
+
+	p := newAlertStatePersister("user", AlertStateConfig{Enabled: true, UpdatePeriod: time.Minute}, store, nil, log.NewNopLogger())
+	active := true
+	g := newNamedAlertStateTestGroup(t, "/rules/user/namespace", "group", p, &active)
+	p.groups = func() []*rules.Group { return []*rules.Group{g} }
+	matchers := []*labels.Matcher{
+		labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, AlertForStateMetricName),
+		labels.MustNewMatcher(labels.MatchEqual, labels.AlertName, "HighRate"),
+		labels.MustNewMatcher(labels.MatchEqual, "severity", "page"),
+	}
+
+	for _, tc := range []struct {
+		name       string
+		mint, maxt time.Time
+		expected   int
+	}{
+		{name: "in range", mint: evaluatedAt.Add(-time.Hour), maxt: evaluatedAt.Add(time.Minute), expected: 1},
+		{name: "older than the outage tolerance", mint: evaluatedAt.Add(time.Second), maxt: evaluatedAt.Add(time.Hour), expected: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := p.Querier(tc.mint.UnixMilli(), tc.maxt.UnixMilli())
+			require.NoError(t, err)
+
+			set := q.Select(context.Background(), false, nil, matchers...)
+			var n int
+			for set.Next() {
+				require.Equal(t, ForStateMetric(labels.FromStrings("job", "app", "severity", "page"), "HighRate"), set.At().Labels())
+				n++
+			}
+			require.NoError(t, set.Err())
+			require.Equal(t, tc.expected, n)
+		})
+	}
+}
+
+func TestAlertStateRestoredAlerts(t *testing.T) {
+	store := bucketclient.NewBucketRuleStore(objstore.NewInMemBucket(), nil, log.NewNopLogger())
+	evaluatedAt := time.Unix(3600, 0).UTC()
+	require.NoError(t, store.SetAlertState(context.Background(), "user", &rulestore.AlertStateDesc{
+		Namespace:   "namespace",
+		Group:       "group",
+		EvaluatedAt: evaluatedAt,
+		Alerts: []rulestore.ActiveAlertDesc{
+			{Labels: map[string]string{"alertname": "HighRate", "job": "app", LogOutputLabel: "true"}, ActiveAt: evaluatedAt.Add(-10 * time.Minute)},
+			{Labels: map[string]string{"alertname": "HighRate", "job": "other", LogOutputLabel: "true"}, ActiveAt: evaluatedAt.Add(-5 * time.Minute)},
+			{Labels: map[string]string{"alertname": "OtherAlert", "job": "app"}, ActiveAt: evaluatedAt.Add(-time.Hour)},
+		},
+	}))
+
+	p := newAlertStatePersister("user", AlertStateConfig{Enabled: true, UpdatePeriod: time.Minute}, store, nil, log.NewNopLogger())
+	active := true
+	g := newNamedAlertStateTestGroup(t, "/rules/user/namespace", "group", p, &active)
+	p.groups = func() []*rules.Group { return []*rules.Group{g} }
+
+	// the alerts fire once active for the 10m for duration of the rule
+	firing := labels.FromStrings(labels.AlertName, "HighRate", "job", "app")
+	pending := labels.FromStrings(labels.AlertName, "HighRate", "job", "other")
+	require.Equal(t, map[string]alertInstance{
+		firing.String():  {state: rules.StateFiring, labels: firing},
+		pending.String(): {state: rules.StatePending, labels: pending},
+	}, p.restoredAlerts(context.Background(), "namespace", "group", "HighRate"))
+
+	require.Nil(t, p.restoredAlerts(context.Background(), "namespace", "other", "HighRate"))
+}
+
+func TestAlertStateRestoresGroup(t *testing.T) {
+	store := bucketclient.NewBucketRuleStore(objstore.NewInMemBucket(), nil, log.NewNopLogger())
+	cfg := AlertStateConfig{Enabled: true, UpdatePeriod: time.Minute}
+	start := time.Unix(0, 0).UTC().Add(24 * time.Hour)
+	require.NoError(t, store.SetAlertState(context.Background(), "user", &rulestore.AlertStateDesc{
+		Namespace:   "my/namespace",
+		Group:       "group",
+		EvaluatedAt: start.Add(5 * time.Minute),
+		Alerts: []rulestore.ActiveAlertDesc{{
+			Labels:   map[string]string{"alertname": "HighRate", "job": "app", "severity": "page"},
+			ActiveAt: start,
+		}},
+	}))
+
+	restore := func(groups ...[2]string) []*rules.Group {
+		active := true
+		p := newAlertStatePersister("user", cfg, store, nil, log.NewNopLogger())
+		var res []*rules.Group
+		for _, g := range groups {
+			res = append(res, newNamedAlertStateTestGroup(t, g[0], g[1], p, &active))
+		}
+		p.groups = func() []*rules.Group { return res }
+
+		ts := start.Add(6 * time.Minute)
+		for _, g := range res {
+			g.Eval(context.Background(), ts)
+		}
+		for _, g := range res {
+			g.RestoreForState(ts)
+		}
+		return res
+	}
+	activeAt := func(g *rules.Group) time.Time {
+		alerts := g.Rules()[0].(*rules.AlertingRule).ActiveAlerts()
+		require.Len(t, alerts, 1)
+		return alerts[0].ActiveAt
+	}
+
+	// the state of another group with the same alerting rule is not restored
+	groups := restore([2]string{"/rules/user/my%2Fnamespace", "other"})
+	require.Equal(t, start.Add(6*time.Minute), activeAt(groups[0]))
+
+	groups = restore([2]string{"/rules/user/other", "group"})
+	require.Equal(t, start.Add(6*time.Minute), activeAt(groups[0]))
+
+	groups = restore([2]string{"/rules/user/my%2Fnamespace", "group"})
+	require.Equal(t, start.Add(time.Minute), activeAt(groups[0]))
+
+	// the alerting rule can't be attributed to a single group while both are restored
+	groups = restore([2]string{"/rules/user/my%2Fnamespace", "group"}, [2]string{"/rules/user/my%2Fnamespace", "other"})
+	require.Equal(t, start.Add(6*time.Minute), activeAt(groups[0]))
+	require.Equal(t, start.Add(6*time.Minute), activeAt(groups[1]))
+}
+
+func TestAlertStateDeleteRemovedGroups(t *testing.T) {
+	store := &countingAlertStateStore{BucketRuleStore: bucketclient.NewBucketRuleStore(objstore.NewInMemBucket(), nil, log.NewNopLogger())}
+	ctx := context.Background()
+	for _, group := range []string{"deleted", "moved", "kept"} {
+		require.NoError(t, store.SetAlertState(ctx, "user", &rulestore.AlertStateDesc{Namespace: "namespace", Group: group}))
+	}
+	// the moved group is now evaluated by another ruler
+	for _, group := range []string{"moved", "kept"} {
+		require.NoError(t, store.SetRuleGroup(ctx, "user", "namespace", rulespb.ToProto("user", "namespace", rulefmt.RuleGroup{Name: group})))
+	}
+
+	active := false
+	p := newAlertStatePersister("user", AlertStateConfig{Enabled: true, UpdatePeriod: time.Minute}, store, nil, log.NewNopLogger())
+	var groups []*rules.Group
+	for _, group := range []string{"deleted", "moved", "kept"} {
+		groups = append(groups, newNamedAlertStateTestGroup(t, "/rules/user/namespace", group, p, &active))
+	}
+
+	p.deleteRemovedGroups(ctx, groups, groups[2:])
+	require.Equal(t, 1, store.deletes)
+
+	states, err := store.ListAlertStates(ctx, "user")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*rulestore.AlertStateDesc{
+		{Namespace: "namespace", Group: "moved"},
+		{Namespace: "namespace", Group: "kept"},
+	}, states)
+}
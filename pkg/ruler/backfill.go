@@ -0,0 +1,711 @@
+package ruler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/golang/snappy"
+	"github.com/gorilla/mux"
+	"github.com/grafana/dskit/backoff"
+	"github.com/grafana/dskit/tenant"
+	"github.com/grafana/dskit/user"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage/remote"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/grafana/loki/v3/pkg/ruler/rulespb"
+	"github.com/grafana/loki/v3/pkg/ruler/rulestore"
+	"github.com/grafana/loki/v3/pkg/util"
+	util_log "github.com/grafana/loki/v3/pkg/util/log"
+)
+
+const (
+	BackfillStateRunning = "running"
+	BackfillStateDone    = "done"
+	BackfillStateFailed  = "failed"
+
+	// backfillBatchRange is the range of the evaluations run concurrently
+	// before their samples are sent.
+	backfillBatchRange = 2 * time.Hour
+	// backfillStatusRetention is how long the status of a finished backfill
+	// is kept.
+	backfillStatusRetention = time.Hour
+	// backfillStatusUpdatePeriod is how often the status of a running
+	// backfill is stored in the rule store.
+	backfillStatusUpdatePeriod = time.Minute
+	// backfillStatusStaleTimeout is how long after its last update a stored
+	// running backfill is considered failed, when the ruler running it
+	// stopped without storing its final status.
+	backfillStatusStaleTimeout = 5 * backfillStatusUpdatePeriod
+	// backfillMaxRetries is the number of retries of a remote-write request
+	// failing with a recoverable error.
+	backfillMaxRetries = 10
+)
+
+var (
+	errBackfillDisabled = errors.New("backfill is disabled for this tenant")
+	errInvalidBackfill  = errors.New("invalid backfill request")
+	errBackfillRunning  = errors.New("a backfill of this rule group is already running")
+)
+
+// BackfillStatus is the status of a rule group backfill.
+type BackfillStatus struct {
+	Namespace   string    `json:"namespace"`
+	Group       string    `json:"group"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	State       string    `json:"state"`
+	Evaluations int       `json:"evaluations"`
+	Samples     int       `json:"samples"`
+	Error       string    `json:"error,omitempty"`
+
+	finishedAt time.Time
+}
+
+// Backfiller evaluates the recording rules of a rule group over a historical
+// range at the group interval, so that the series of a new rule do not start
+// at deployment time, and sends the samples to the remote-write clients of the
+// tenant. Alerting rules are not backfilled.
+//
+// Backfills run in the background, one at a time per rule group. The rule
+// evaluations of a tenant run concurrently up to the
+// ruler_backfill_max_concurrency limit, shared by all its backfills.
+//
+// When the rule store implements rulestore.BackfillStatusStore, the status of
+// the backfills is stored in it, so that any ruler can return it and refuse to
+// start a backfill running on another ruler.
+type Backfiller struct {
+	cfg       Config
+	evaluator Evaluator
+	store     rulestore.RuleStore
+	statuses  rulestore.BackfillStatusStore
+	limits    RulesLimits
+	logger    log.Logger
+
+	// remoteWrite resolves the remote-write configuration of the tenants.
+	remoteWrite *walRegistry
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mtx        sync.Mutex
+	semaphores map[string]*tenantSemaphore
+	jobs       map[backfillKey]*BackfillStatus
+}
+
+type tenantSemaphore struct {
+	*semaphore.Weighted
+	size int
+}
+
+type backfillKey struct {
+	userID, namespace, group string
+}
+
+func NewBackfiller(cfg Config, evaluator Evaluator, store rulestore.RuleStore, limits RulesLimits, logger log.Logger) *Backfiller {
+	ctx, cancel := context.WithCancel(context.Background())
+	logger = log.With(logger, "component", "ruler-backfill")
+	statuses, _ := store.(rulestore.BackfillStatusStore)
+	return &Backfiller{
+		cfg:         cfg,
+		evaluator:   evaluator,
+		store:       store,
+		statuses:    statuses,
+		limits:      limits,
+		logger:      logger,
+		remoteWrite: &walRegistry{logger: logger, config: cfg, overrides: limits},
+		ctx:         ctx,
+		cancel:      cancel,
+		semaphores:  make(map[string]*tenantSemaphore),
+		jobs:        make(map[backfillKey]*BackfillStatus),
+	}
+}
+
+// Stop cancels the running backfills and waits for them to return.
+func (b *Backfiller) Stop() {
+	b.cancel()
+	b.wg.Wait()
+}
+
+// ServeHTTP starts a backfill of the rule group of the request path with the
+// start and end of the request parameters on POST, and returns the status of
+// the last backfill of the rule group on GET.
+func (b *Backfiller) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := util_log.WithContext(req.Context(), b.logger)
+
+	userID, err := tenant.TenantID(req.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(req)
+	namespace, err := url.PathUnescape(vars["namespace"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	groupName, err := url.PathUnescape(vars["groupName"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Method == http.MethodGet {
+		status, ok, err := b.Status(req.Context(), userID, namespace, groupName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.Error(w, "no backfill of this rule group", http.StatusNotFound)
+			return
+		}
+		writeBackfillStatus(w, logger, http.StatusOK, status)
+		return
+	}
+
+	start, end, err := parseBackfillRange(req.FormValue("start"), req.FormValue("end"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rg, err := b.store.GetRuleGroup(req.Context(), userID, namespace, groupName)
+	if err != nil {
+		if errors.Is(err, rulestore.ErrGroupNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	status, err := b.Start(req.Context(), userID, namespace, rulespb.FromProto(rg), start, end)
+	switch {
+	case errors.Is(err, errBackfillDisabled):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case errors.Is(err, errInvalidBackfill):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, errBackfillRunning):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeBackfillStatus(w, logger, http.StatusAccepted, status)
+}
+
+func writeBackfillStatus(w http.ResponseWriter, logger log.Logger, code int, status BackfillStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		level.Error(logger).Log("msg", "error writing response", "err", err)
+	}
+}
+
+func parseBackfillRange(startParam, endParam string) (time.Time, time.Time, error) {
+	if startParam == "" {
+		return time.Time{}, time.Time{}, errors.New("start is required")
+	}
+	start, err := util.ParseTime(startParam)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end := time.Now()
+	if endParam != "" {
+		ms, err := util.ParseTime(endParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = util.TimeFromMillis(ms)
+	}
+	return util.TimeFromMillis(start), end, nil
+}
+
+// Status returns the status of the running or last finished backfill of a
+// rule group, run by this ruler or, if the status is stored, by any ruler.
+func (b *Backfiller) Status(ctx context.Context, userID, namespace, group string) (BackfillStatus, bool, error) {
+	b.mtx.Lock()
+	b.expireJobsLocked()
+	var status BackfillStatus
+	job, ok := b.jobs[backfillKey{userID, namespace, group}]
+	if ok {
+		status = *job
+	}
+	b.mtx.Unlock()
+
+	// the status of a backfill finished by this ruler may be replaced by a
+	// backfill started on another ruler since
+	if (ok && status.State == BackfillStateRunning) || b.statuses == nil {
+		return status, ok, nil
+	}
+
+	stored, storedOK, err := b.storedStatus(ctx, userID, namespace, group)
+	if err != nil {
+		return BackfillStatus{}, false, err
+	}
+	if storedOK {
+		return stored, true, nil
+	}
+	return status, ok, nil
+}
+
+// storedStatus returns the stored status of the last backfill of a rule
+// group, unless it finished for longer than backfillStatusRetention. A running
+// backfill whose status was not updated for backfillStatusStaleTimeout is
+// returned as failed.
+func (b *Backfiller) storedStatus(ctx context.Context, userID, namespace, group string) (BackfillStatus, bool, error) {
+	desc, err := b.statuses.GetBackfillStatus(ctx, userID, namespace, group)
+	if errors.Is(err, rulestore.ErrBackfillStatusNotFound) {
+		return BackfillStatus{}, false, nil
+	}
+	if err != nil {
+		return BackfillStatus{}, false, fmt.Errorf("get backfill status: %w", err)
+	}
+
+	status := BackfillStatus{
+		Namespace:   desc.Namespace,
+		Group:       desc.Group,
+		Start:       desc.Start,
+		End:         desc.End,
+		State:       desc.State,
+		Evaluations: desc.Evaluations,
+		Samples:     desc.Samples,
+		Error:       desc.Error,
+		finishedAt:  desc.FinishedAt,
+	}
+	if status.State == BackfillStateRunning && time.Since(desc.UpdatedAt) > backfillStatusStaleTimeout {
+		status.State = BackfillStateFailed
+		status.Error = fmt.Sprintf("the ruler running the backfill stopped updating its status at %s", desc.UpdatedAt.Format(time.RFC3339))
+		status.finishedAt = desc.UpdatedAt
+	}
+	if status.State != BackfillStateRunning && time.Since(status.finishedAt) > backfillStatusRetention {
+		return BackfillStatus{}, false, nil
+	}
+	return status, true, nil
+}
+
+// persistStatus stores the current status of a backfill run by this ruler, if
+// the rule store supports it.
+func (b *Backfiller) persistStatus(userID string, job *BackfillStatus) {
+	if b.statuses == nil {
+		return
+	}
+
+	b.mtx.Lock()
+	status := *job
+	b.mtx.Unlock()
+
+	// the backfills are canceled when the ruler stops, but their final status
+	// must still be stored
+	ctx, cancel := context.WithTimeout(context.Background(), backfillStatusUpdatePeriod)
+	defer cancel()
+	err := b.statuses.SetBackfillStatus(ctx, userID, &rulestore.BackfillStatusDesc{
+		Namespace:   status.Namespace,
+		Group:       status.Group,
+		Start:       status.Start,
+		End:         status.End,
+		State:       status.State,
+		Evaluations: status.Evaluations,
+		Samples:     status.Samples,
+		Error:       status.Error,
+		UpdatedAt:   time.Now(),
+		FinishedAt:  status.finishedAt,
+	})
+	if err != nil {
+		level.Warn(b.logger).Log("msg", "unable to store backfill status", "user", userID, "namespace", status.Namespace, "group", status.Group, "err", err)
+	}
+}
+
+// updateStatus stores the status of a backfill every
+// backfillStatusUpdatePeriod while it runs, and once more when done is closed.
+// The status of a backfill is only stored by this goroutine once it runs, so
+// that a running status is never stored after the final one.
+func (b *Backfiller) updateStatus(userID string, job *BackfillStatus, done <-chan struct{}) {
+	ticker := time.NewTicker(backfillStatusUpdatePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.persistStatus(userID, job)
+		case <-done:
+			b.persistStatus(userID, job)
+			return
+		}
+	}
+}
+
+// expireJobsLocked drops the status of the backfills finished for longer than
+// backfillStatusRetention.
+func (b *Backfiller) expireJobsLocked() {
+	for key, status := range b.jobs {
+		if status.State != BackfillStateRunning && time.Since(status.finishedAt) > backfillStatusRetention {
+			delete(b.jobs, key)
+		}
+	}
+}
+
+// Start validates the backfill of the recording rules of the group from start
+// to end and runs it in the background.
+func (b *Backfiller) Start(ctx context.Context, userID, namespace string, group rulefmt.RuleGroup, start, end time.Time) (BackfillStatus, error) {
+	limit := b.limits.RulerBackfillMaxConcurrency(userID)
+	if limit <= 0 {
+		return BackfillStatus{}, errBackfillDisabled
+	}
+
+	if !end.After(start) {
+		return BackfillStatus{}, fmt.Errorf("%w: end must be after start", errInvalidBackfill)
+	}
+	if end.After(time.Now()) {
+		return BackfillStatus{}, fmt.Errorf("%w: end must not be in the future", errInvalidBackfill)
+	}
+	if maxRange := b.limits.RulerBackfillMaxRange(userID); maxRange > 0 && end.Sub(start) > maxRange {
+		return BackfillStatus{}, fmt.Errorf("%w: the range (%s) exceeds the limit (%s)", errInvalidBackfill, end.Sub(start), maxRange)
+	}
+
+	var recordingRules []rulefmt.Rule
+	for _, r := range group.Rules {
+		if r.Record != "" {
+			recordingRules = append(recordingRules, r)
+		}
+	}
+	if len(recordingRules) == 0 {
+		return BackfillStatus{}, fmt.Errorf("%w: rule group %q has no recording rules", errInvalidBackfill, group.Name)
+	}
+
+	clients, err := b.remoteWriteClients(userID)
+	if err != nil {
+		return BackfillStatus{}, err
+	}
+
+	// a backfill started at the same time on another ruler is not seen, as the
+	// rule store has no conditional writes
+	if b.statuses != nil {
+		stored, ok, err := b.storedStatus(ctx, userID, namespace, group.Name)
+		if err != nil {
+			return BackfillStatus{}, err
+		}
+		if ok && stored.State == BackfillStateRunning {
+			return BackfillStatus{}, errBackfillRunning
+		}
+	}
+
+	b.mtx.Lock()
+	key := backfillKey{userID, namespace, group.Name}
+	if status, ok := b.jobs[key]; ok && status.State == BackfillStateRunning {
+		b.mtx.Unlock()
+		return BackfillStatus{}, errBackfillRunning
+	}
+	b.expireJobsLocked()
+
+	status := &BackfillStatus{
+		Namespace: namespace,
+		Group:     group.Name,
+		Start:     start,
+		End:       end,
+		State:     BackfillStateRunning,
+	}
+	b.jobs[key] = status
+	started := *status
+	b.mtx.Unlock()
+
+	logger := log.With(b.logger, "user", userID, "namespace", namespace, "group", group.Name, "start", start, "end", end)
+	level.Info(logger).Log("msg", "backfilling rule group")
+	b.persistStatus(userID, status)
+
+	done := make(chan struct{})
+	b.wg.Add(2)
+	go func() {
+		defer b.wg.Done()
+		b.updateStatus(userID, status, done)
+	}()
+	go func() {
+		defer b.wg.Done()
+		defer close(done)
+
+		err := b.backfill(b.ctx, userID, key, group, recordingRules, clients, start, end, limit)
+
+		b.mtx.Lock()
+		defer b.mtx.Unlock()
+		status.finishedAt = time.Now()
+		if err != nil {
+			level.Error(logger).Log("msg", "rule group backfill failed", "err", err)
+			status.State = BackfillStateFailed
+			status.Error = err.Error()
+			return
+		}
+		level.Info(logger).Log("msg", "rule group backfilled", "evaluations", status.Evaluations, "samples", status.Samples)
+		status.State = BackfillStateDone
+	}()
+
+	return started, nil
+}
+
+// backfill evaluates the recording rules from start to end at the group
+// interval and sends the samples to the remote-write clients.
+//
+// The range is processed in batches: the evaluations of a batch run
+// concurrently, then their samples are sent in order.
+func (b *Backfiller) backfill(ctx context.Context, userID string, key backfillKey, group rulefmt.RuleGroup, recordingRules []rulefmt.Rule, clients []backfillClient, start, end time.Time, limit int) error {
+	interval := time.Duration(group.Interval)
+	if interval <= 0 {
+		interval = b.cfg.EvaluationInterval
+	}
+
+	ctx = user.InjectOrgID(ctx, userID)
+	sem := b.semaphore(userID, limit)
+	batchRange := backfillBatchRange.Milliseconds()
+
+	for ts := start; !ts.After(end); {
+		// the timestamps of the current batch
+		var steps []time.Time
+		batch := ts.UnixMilli() / batchRange
+		for ; !ts.After(end) && ts.UnixMilli()/batchRange == batch; ts = ts.Add(interval) {
+			steps = append(steps, ts)
+		}
+
+		vectors := make([]promql.Vector, len(steps))
+		g, gctx := errgroup.WithContext(ctx)
+		g.SetLimit(limit)
+		for i, step := range steps {
+			g.Go(func() error {
+				if err := sem.Acquire(gctx, 1); err != nil {
+					return err
+				}
+				defer sem.Release(1)
+
+				v, err := b.evaluate(gctx, group, recordingRules, step)
+				vectors[i] = v
+				return err
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return err
+		}
+
+		var samples int
+		for _, c := range clients {
+			n, err := c.write(ctx, steps, vectors)
+			if err != nil {
+				return err
+			}
+			samples = max(samples, n)
+		}
+
+		b.mtx.Lock()
+		b.jobs[key].Samples += samples
+		b.jobs[key].Evaluations += len(steps) * len(recordingRules)
+		b.mtx.Unlock()
+	}
+	return nil
+}
+
+// evaluate evaluates the recording rules of the group at ts and returns their
+// samples, named after the rules.
+func (b *Backfiller) evaluate(ctx context.Context, group rulefmt.RuleGroup, recordingRules []rulefmt.Rule, ts time.Time) (promql.Vector, error) {
+	var samples promql.Vector
+	for _, r := range recordingRules {
+		res, err := b.evaluator.Eval(AddRuleDetailsToContext(ctx, r.Record, string(rules.KindRecording)), r.Expr, ts)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s evaluation failed at %s: %w", r.Record, ts.Format(time.RFC3339), err)
+		}
+		v, err := resultVector(res)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", r.Record, err)
+		}
+
+		for i := range v {
+			lb := labels.NewBuilder(v[i].Metric)
+			lb.Set(labels.MetricName, r.Record)
+			for _, ls := range []map[string]string{group.Labels, r.Labels} {
+				for name, value := range ls {
+					if value == "" {
+						lb.Del(name)
+						continue
+					}
+					lb.Set(name, value)
+				}
+			}
+			v[i].Metric = lb.Labels()
+		}
+		if v.ContainsSameLabelset() {
+			return nil, fmt.Errorf("rule %s: vector contains metrics with the same labelset after applying rule labels", r.Record)
+		}
+		samples = append(samples, v...)
+	}
+	return samples, nil
+}
+
+// semaphore returns the semaphore limiting the concurrent evaluations of the
+// tenant, which is replaced when the limit changes.
+func (b *Backfiller) semaphore(userID string, limit int) *tenantSemaphore {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	sem, ok := b.semaphores[userID]
+	if !ok || sem.size != limit {
+		sem = &tenantSemaphore{Weighted: semaphore.NewWeighted(int64(limit)), size: limit}
+		b.semaphores[userID] = sem
+	}
+	return sem
+}
+
+// remoteWriteClients returns the remote-write clients of the tenant, with the
+// same configuration as the clients sending the samples of the rule
+// evaluations.
+func (b *Backfiller) remoteWriteClients(userID string) ([]backfillClient, error) {
+	if !b.cfg.RemoteWrite.Enabled {
+		return nil, fmt.Errorf("%w: remote-write is disabled", errInvalidBackfill)
+	}
+	conf, err := b.remoteWrite.getTenantConfig(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(conf.RemoteWrite) == 0 {
+		return nil, fmt.Errorf("%w: remote-write is disabled", errInvalidBackfill)
+	}
+
+	clients := make([]backfillClient, 0, len(conf.RemoteWrite))
+	for _, rwConf := range conf.RemoteWrite {
+		c, err := remote.NewWriteClient(rwConf.Name, &remote.ClientConfig{
+			URL: rwConf.URL,
+			// the samples are always encoded with the 1.0 protocol
+			WriteProtoMsg:    config.RemoteWriteProtoMsgV1,
+			Timeout:          rwConf.RemoteTimeout,
+			HTTPClientConfig: rwConf.HTTPClientConfig,
+			SigV4Config:      rwConf.SigV4Config,
+			AzureADConfig:    rwConf.AzureADConfig,
+			GoogleIAMConfig:  rwConf.GoogleIAMConfig,
+			Headers:          rwConf.Headers,
+			RetryOnRateLimit: rwConf.QueueConfig.RetryOnRateLimit,
+			RoundRobinDNS:    rwConf.RoundRobinDNS,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create remote-write client %s: %w", rwConf.Name, err)
+		}
+		clients = append(clients, backfillClient{client: c, cfg: rwConf})
+	}
+	return clients, nil
+}
+
+// backfillClient sends the samples of a backfill to a remote-write endpoint.
+type backfillClient struct {
+	client remote.WriteClient
+	cfg    *config.RemoteWriteConfig
+}
+
+// write relabels and sends the samples of a batch, at most
+// max_samples_per_send samples per request, and returns the number of samples
+// sent.
+func (c backfillClient) write(ctx context.Context, steps []time.Time, vectors []promql.Vector) (int, error) {
+	// group the samples by series, in timestamp order
+	var series []prompb.TimeSeries
+	index := map[uint64]int{}
+	for i, v := range vectors {
+		for _, s := range v {
+			lbls, keep := relabel.Process(s.Metric, c.cfg.WriteRelabelConfigs...)
+			if !keep || lbls.IsEmpty() {
+				continue
+			}
+			idx, ok := index[lbls.Hash()]
+			if !ok {
+				idx = len(series)
+				index[lbls.Hash()] = idx
+				series = append(series, prompb.TimeSeries{Labels: prompb.FromLabels(lbls, nil)})
+			}
+			series[idx].Samples = append(series[idx].Samples, prompb.Sample{Timestamp: steps[i].UnixMilli(), Value: s.F})
+		}
+	}
+
+	maxSamples := c.cfg.QueueConfig.MaxSamplesPerSend
+	if maxSamples <= 0 {
+		maxSamples = config.DefaultQueueConfig.MaxSamplesPerSend
+	}
+
+	var (
+		req     []prompb.TimeSeries
+		pending int
+		sent    int
+	)
+	for _, ts := range series {
+		for len(ts.Samples) > 0 {
+			n := min(len(ts.Samples), maxSamples-pending)
+			req = append(req, prompb.TimeSeries{Labels: ts.Labels, Samples: ts.Samples[:n]})
+			ts.Samples = ts.Samples[n:]
+			pending += n
+
+			if pending == maxSamples {
+				if err := c.send(ctx, req); err != nil {
+					return 0, err
+				}
+				sent += pending
+				req, pending = nil, 0
+			}
+		}
+	}
+	if pending > 0 {
+		if err := c.send(ctx, req); err != nil {
+			return 0, err
+		}
+		sent += pending
+	}
+	return sent, nil
+}
+
+// send sends a remote-write request, retrying on recoverable errors.
+func (c backfillClient) send(ctx context.Context, series []prompb.TimeSeries) error {
+	data, err := (&prompb.WriteRequest{Timeseries: series}).Marshal()
+	if err != nil {
+		return fmt.Errorf("marshal remote-write request: %w", err)
+	}
+	data = snappy.Encode(nil, data)
+
+	backoffCfg := backoff.Config{
+		MinBackoff: time.Duration(c.cfg.QueueConfig.MinBackoff),
+		MaxBackoff: time.Duration(c.cfg.QueueConfig.MaxBackoff),
+		MaxRetries: backfillMaxRetries,
+	}
+	if backoffCfg.MinBackoff <= 0 || backoffCfg.MaxBackoff < backoffCfg.MinBackoff {
+		backoffCfg.MinBackoff = time.Duration(config.DefaultQueueConfig.MinBackoff)
+		backoffCfg.MaxBackoff = time.Duration(config.DefaultQueueConfig.MaxBackoff)
+	}
+	retries := backoff.New(ctx, backoffCfg)
+	for {
+		_, err = c.client.Store(ctx, data, retries.NumRetries())
+		var recoverable remote.RecoverableError
+		if err == nil || !errors.As(err, &recoverable) {
+			break
+		}
+		retries.Wait()
+		if !retries.Ongoing() {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("remote-write to %s: %w", c.client.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package ruler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/golang/snappy"
+	promConfig "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
+	"github.com/grafana/loki/v3/pkg/ruler/rulestore"
+	"github.com/grafana/loki/v3/pkg/ruler/rulestore/bucketclient"
+	"github.com/grafana/loki/v3/pkg/validation"
+)
+
+// stepEval returns one sample per evaluation, valued with the evaluation time
+// in seconds.
+type stepEval struct {
+	calls atomic.Int64
+	// block makes the evaluations wait until it is closed, if set
+	block chan struct{}
+}
+
+func (e *stepEval) Eval(ctx context.Context, _ string, now time.Time) (*logqlmodel.Result, error) {
+	e.calls.Add(1)
+	if e.block != nil {
+		select {
+		case <-e.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return &logqlmodel.Result{
+		Data: promql.Vector{{
+			T:      now.UnixMilli(),
+			F:      float64(now.Unix()),
+			Metric: labels.FromStrings("job", "app", "drop", "me"),
+		}},
+	}, nil
+}
+
+// remoteWriteServer records the samples of the remote-write requests it
+// receives.
+type remoteWriteServer struct {
+	*httptest.Server
+
+	mtx      sync.Mutex
+	requests int
+	samples  map[string][]prompb.Sample
+}
+
+func newRemoteWriteServer(t *testing.T) *remoteWriteServer {
+	s := &remoteWriteServer{samples: map[string][]prompb.Sample{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		compressed, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		data, err := snappy.Decode(nil, compressed)
+		require.NoError(t, err)
+
+		var req prompb.WriteRequest
+		require.NoError(t, req.Unmarshal(data))
+
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		s.requests++
+		for _, ts := range req.Timeseries {
+			lbls := labels.NewScratchBuilder(len(ts.Labels))
+			for _, l := range ts.Labels {
+				lbls.Add(l.Name, l.Value)
+			}
+			key := lbls.Labels().String()
+			s.samples[key] = append(s.samples[key], ts.Samples...)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestBackfiller(t *testing.T, maxConcurrency int, remoteWriteURL string, store rulestore.RuleStore) (*Backfiller, *stepEval) {
+	defaultLimits := defaultLimitsTestConfig()
+	defaultLimits.RulerBackfillMaxConcurrency = maxConcurrency
+	limits, err := validation.NewOverrides(defaultLimits, nil)
+	require.NoError(t, err)
+
+	var cfg Config
+	cfg.EvaluationInterval = time.Minute
+	if remoteWriteURL != "" {
+		u, err := url.Parse(remoteWriteURL)
+		require.NoError(t, err)
+
+		queueConfig := config.DefaultQueueConfig
+		queueConfig.MaxSamplesPerSend = 10
+		cfg.RemoteWrite.Enabled = true
+		cfg.RemoteWrite.Clients = map[string]config.RemoteWriteConfig{
+			"default": {
+				URL:           &promConfig.URL{URL: u},
+				RemoteTimeout: model.Duration(time.Second),
+				QueueConfig:   queueConfig,
+			},
+		}
+	}
+
+	eval := &stepEval{}
+	b := NewBackfiller(cfg, eval, store, limits, log.NewNopLogger())
+	t.Cleanup(b.Stop)
+	return b, eval
+}
+
+func waitBackfill(t *testing.T, b *Backfiller, userID, namespace, group string) BackfillStatus {
+	var status BackfillStatus
+	require.Eventually(t, func() bool {
+		var (
+			ok  bool
+			err error
+		)
+		status, ok, err = b.Status(context.Background(), userID, namespace, group)
+		require.NoError(t, err)
+		require.True(t, ok)
+		return status.State != BackfillStateRunning
+	}, 10*time.Second, 10*time.Millisecond)
+	return status
+}
+
+func TestBackfill(t *testing.T) {
+	server := newRemoteWriteServer(t)
+	b, eval := newTestBackfiller(t, 4, server.URL, nil)
+
+	group := rulefmt.RuleGroup{
+		Name:     "group",
+		Interval: model.Duration(10 * time.Minute),
+		Labels:   map[string]string{"team": "a"},
+		Rules: []rulefmt.Rule{
+			{Record: "job:requests:rate1m", Expr: `sum by (job) (rate({job="app"}[1m]))`, Labels: map[string]string{"drop": ""}},
+			{Alert: "HighRate", Expr: `sum(rate({job="app"}[1m])) > 1`},
+		},
+	}
+	// spans three batches
+	start := time.Unix(0, 0).Add(time.Hour)
+	end := start.Add(4 * time.Hour)
+
+	status, err := b.Start(context.Background(), "user", "namespace", group, start, end)
+	require.NoError(t, err)
+	require.Equal(t, BackfillStateRunning, status.State)
+
+	status = waitBackfill(t, b, "user", "namespace", "group")
+	require.Equal(t, BackfillStateDone, status.State, status.Error)
+	require.Equal(t, 25, status.Evaluations)
+	require.Equal(t, 25, status.Samples)
+	require.EqualValues(t, 25, eval.calls.Load())
+
+	server.mtx.Lock()
+	defer server.mtx.Unlock()
+	// the batches of 6, 12 and 7 samples are sent 10 samples at most at a time
+	require.Equal(t, 4, server.requests)
+	samples := server.samples[labels.FromStrings(labels.MetricName, "job:requests:rate1m", "job", "app", "team", "a").String()]
+	require.Len(t, samples, 25)
+	require.Len(t, server.samples, 1)
+	for i, s := range samples {
+		ts := start.Add(time.Duration(i) * 10 * time.Minute)
+		require.Equal(t, prompb.Sample{Timestamp: ts.UnixMilli(), Value: float64(ts.Unix())}, s)
+	}
+}
+
+func TestBackfillRunning(t *testing.T) {
+	server := newRemoteWriteServer(t)
+	b, eval := newTestBackfiller(t, 1, server.URL, nil)
+	eval.block = make(chan struct{})
+
+	group := rulefmt.RuleGroup{
+		Name:  "group",
+		Rules: []rulefmt.Rule{{Record: "job:requests:rate1m", Expr: `sum(rate({job="app"}[1m]))`}},
+	}
+	end := time.Now().Add(-time.Hour)
+
+	_, err := b.Start(context.Background(), "user", "namespace", group, end.Add(-time.Hour), end)
+	require.NoError(t, err)
+	_, err = b.Start(context.Background(), "user", "namespace", group, end.Add(-time.Hour), end)
+	require.ErrorIs(t, err, errBackfillRunning)
+
+	// the rule group of another tenant can be backfilled
+	_, err = b.Start(context.Background(), "other", "namespace", group, end.Add(-time.Hour), end)
+	require.NoError(t, err)
+
+	close(eval.block)
+	require.Equal(t, BackfillStateDone, waitBackfill(t, b, "user", "namespace", "group").State)
+	_, err = b.Start(context.Background(), "user", "namespace", group, end.Add(-time.Hour), end)
+	require.NoError(t, err)
+}
+
+func TestBackfillErrors(t *testing.T) {
+	server := newRemoteWriteServer(t)
+	group := rulefmt.RuleGroup{
+		Name:  "group",
+		Rules: []rulefmt.Rule{{Record: "job:requests:rate1m", Expr: `sum(rate({job="app"}[1m]))`}},
+	}
+	end := time.Now().Add(-time.Hour)
+
+	for _, tc := range []struct {
+		name           string
+		maxConcurrency int
+		remoteWriteURL string
+		group          rulefmt.RuleGroup
+		start, end     time.Time
+		err            error
+	}{
+		{name: "disabled", remoteWriteURL: server.URL, group: group, start: end.Add(-time.Hour), end: end, err: errBackfillDisabled},
+		{name: "empty range", maxConcurrency: 1, remoteWriteURL: server.URL, group: group, start: end, end: end, err: errInvalidBackfill},
+		{name: "future", maxConcurrency: 1, remoteWriteURL: server.URL, group: group, start: end, end: time.Now().Add(time.Hour), err: errInvalidBackfill},
+		{name: "range too long", maxConcurrency: 1, remoteWriteURL: server.URL, group: group, start: end.Add(-8 * 24 * time.Hour), end: end, err: errInvalidBackfill},
+		{name: "remote-write disabled", maxConcurrency: 1, group: group, start: end.Add(-time.Hour), end: end, err: errInvalidBackfill},
+		{
+			name: "no recording rules", maxConcurrency: 1, remoteWriteURL: server.URL, start: end.Add(-time.Hour), end: end, err: errInvalidBackfill,
+			group: rulefmt.RuleGroup{Name: "group", Rules: []rulefmt.Rule{{Alert: "HighRate", Expr: `sum(rate({job="app"}[1m])) > 1`}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, eval := newTestBackfiller(t, tc.maxConcurrency, tc.remoteWriteURL, nil)
+
+			_, err := b.Start(context.Background(), "user", "namespace", tc.group, tc.start, tc.end)
+			require.ErrorIs(t, err, tc.err)
+			_, ok, err := b.Status(context.Background(), "user", "namespace", tc.group.Name)
+			require.NoError(t, err)
+			require.False(t, ok)
+			require.Zero(t, eval.calls.Load())
+		})
+	}
+}
+
+func TestBackfillStatusStore(t *testing.T) {
+	server := newRemoteWriteServer(t)
+	store := bucketclient.NewBucketRuleStore(objstore.NewInMemBucket(), nil, log.NewNopLogger())
+	// the rulers behind a load balancer
+	b1, eval := newTestBackfiller(t, 1, server.URL, store)
+	b2, _ := newTestBackfiller(t, 1, server.URL, store)
+	eval.block = make(chan struct{})
+	ctx := context.Background()
+
+	group := rulefmt.RuleGroup{
+		Name:  "group",
+		Rules: []rulefmt.Rule{{Record: "job:requests:rate1m", Expr: `sum(rate({job="app"}[1m]))`}},
+	}
+	end := time.Now().Add(-time.Hour)
+	start := end.Add(-10 * time.Minute)
+
+	_, ok, err := b2.Status(ctx, "user", "namespace", "group")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = b1.Start(ctx, "user", "namespace", group, start, end)
+	require.NoError(t, err)
+
+	// the other ruler returns the status of the running backfill, and does not
+	// start another one
+	status, ok, err := b2.Status(ctx, "user", "namespace", "group")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, BackfillStateRunning, status.State)
+	_, err = b2.Start(ctx, "user", "namespace", group, start, end)
+	require.ErrorIs(t, err, errBackfillRunning)
+
+	close(eval.block)
+	status = waitBackfill(t, b2, "user", "namespace", "group")
+	require.Equal(t, BackfillStateDone, status.State, status.Error)
+	require.Equal(t, 11, status.Evaluations)
+	require.Equal(t, 11, status.Samples)
+
+	// a backfill started on another ruler replaces the status of the finished
+	// one
+	_, err = b2.Start(ctx, "user", "namespace", group, start, end)
+	require.NoError(t, err)
+	status = waitBackfill(t, b1, "user", "namespace", "group")
+	require.Equal(t, BackfillStateDone, status.State, status.Error)
+
+	// a running backfill not updated for too long is failed, as the ruler
+	// running it stopped
+	updatedAt := time.Now().Add(-backfillStatusStaleTimeout - time.Minute)
+	require.NoError(t, store.SetBackfillStatus(ctx, "user", &rulestore.BackfillStatusDesc{
+		Namespace: "namespace",
+		Group:     "group",
+		Start:     start,
+		End:       end,
+		State:     BackfillStateRunning,
+		UpdatedAt: updatedAt,
+	}))
+	b3, _ := newTestBackfiller(t, 1, server.URL, store)
+	status, ok, err = b3.Status(ctx, "user", "namespace", "group")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, BackfillStateFailed, status.State)
+	_, err = b3.Start(ctx, "user", "namespace", group, start, end)
+	require.NoError(t, err)
+	require.Equal(t, BackfillStateDone, waitBackfill(t, b3, "user", "namespace", "group").State)
+}
@@ -22,9 +22,11 @@ import (
 	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/prometheus/prometheus/promql/parser/posrange"
 	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/template"
 
 	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/logqlmodel"
 	ruler "github.com/grafana/loki/v3/pkg/ruler/base"
 	"github.com/grafana/loki/v3/pkg/ruler/rulespb"
 	rulerutil "github.com/grafana/loki/v3/pkg/ruler/util"
@@ -55,6 +57,9 @@ type RulesLimits interface {
 
 	RulerRemoteEvaluationTimeout(userID string) time.Duration
 	RulerRemoteEvaluationMaxResponseSize(userID string) int64
+
+	RulerBackfillMaxConcurrency(userID string) int
+	RulerBackfillMaxRange(userID string) time.Duration
 }
 
 // queryFunc returns a new query function using the rules.EngineQueryFunc function
@@ -85,18 +90,27 @@ func queryFunc(evaluator Evaluator, checker readyChecker, userID string, logger
 			level.Error(detailLog).Log("msg", "rule evaluation failed", "err", err)
 			return nil, fmt.Errorf("rule evaluation failed: %w", err)
 		}
-		switch v := res.Data.(type) {
-		case promql.Vector:
-			return v, nil
-		case promql.Scalar:
-			return promql.Vector{promql.Sample{
-				T: v.T, F: v.V,
-				Metric: labels.Labels{},
-			}}, nil
-		default:
+		v, err := resultVector(res)
+		if err != nil {
 			level.Error(detailLog).Log("msg", "rule result is not a vector or scalar", "err", err)
-			return nil, errors.New("rule result is not a vector or scalar")
+			return nil, err
 		}
+		return v, nil
+	}
+}
+
+// resultVector returns the result of a rule evaluation as a vector.
+func resultVector(res *logqlmodel.Result) (promql.Vector, error) {
+	switch v := res.Data.(type) {
+	case promql.Vector:
+		return v, nil
+	case promql.Scalar:
+		return promql.Vector{promql.Sample{
+			T: v.T, F: v.V,
+			Metric: labels.Labels{},
+		}}, nil
+	default:
+		return nil, errors.New("rule result is not a vector or scalar")
 	}
 }
 
@@ -136,7 +150,8 @@ const MetricsPrefix = "loki_ruler_wal_"
 
 var registry storageRegistry
 
-func MultiTenantRuleManager(cfg Config, evaluator Evaluator, overrides RulesLimits, logger log.Logger, reg prometheus.Registerer) ruler.ManagerFactory {
+func MultiTenantRuleManager(cfg Config, evaluator Evaluator, overrides RulesLimits, stateStore alertStateRuleStore, logger log.Logger, reg prometheus.Registerer) ruler.ManagerFactory {
+	outputReg := reg
 	reg = prometheus.WrapRegistererWithPrefix(MetricsPrefix, reg)
 
 	registry = newWALRegistry(log.With(logger, "storage", "registry"), reg, cfg, overrides)
@@ -153,18 +168,28 @@ func MultiTenantRuleManager(cfg Config, evaluator Evaluator, overrides RulesLimi
 		logger = log.With(logger, "user", userID)
 		queryFn := queryFunc(evaluator, registry, userID, logger)
 		memStore := NewMemStore(userID, queryFn, newMemstoreMetrics(reg), 5*time.Minute, log.With(logger, "subcomponent", "MemStore"))
+		logOutput := newRuleLogOutput(userID, cfg.LogOutput, outputReg, logger)
+
+		// the for state of the alerts is restored from the persisted alert states
+		// when enabled, and by re-evaluating the alerting rules otherwise
+		var queryable storage.Queryable = memStore
+		var alertState *alertStatePersister
+		if stateStore != nil {
+			alertState = newAlertStatePersister(userID, cfg.AlertState, stateStore, memStore, logger)
+			queryable = alertState
+		}
 
 		// GroupLoader builds a cache of the rules as they're loaded by the
 		// manager.This is used to back the memstore
 		groupLoader := NewCachingGroupLoader(GroupLoader{})
 
 		mgr := rules.NewManager(&rules.ManagerOptions{
-			Appendable:               registry,
-			Queryable:                memStore,
+			Appendable:               logOutput.wrap(registry),
+			Queryable:                queryable,
 			QueryFunc:                queryFn,
 			Context:                  user.InjectOrgID(ctx, userID),
 			ExternalURL:              cfg.ExternalURL.URL,
-			NotifyFunc:               ruler.SendAlerts(notifier, cfg.ExternalURL.URL.String(), cfg.DatasourceUID),
+			NotifyFunc:               logOutput.notifyFunc(ruler.SendAlerts(notifier, cfg.ExternalURL.URL.String(), cfg.DatasourceUID)),
 			Logger:                   util_log.SlogFromGoKit(logger),
 			Registerer:               reg,
 			OutageTolerance:          cfg.OutageTolerance,
@@ -172,11 +197,20 @@ func MultiTenantRuleManager(cfg Config, evaluator Evaluator, overrides RulesLimi
 			ResendDelay:              cfg.ResendDelay,
 			GroupLoader:              groupLoader,
 			RuleDependencyController: &noopRuleDependencyController{},
+			// the groups this ruler starts owning are restored as well
+			RestoreNewRuleGroups: alertState != nil,
 		})
 
+		if alertState != nil {
+			alertState.groups = mgr.RuleGroups
+			logOutput.restored = alertState.restoredAlerts
+		}
+
 		cachingManager := &CachingRulesManager{
 			manager:     mgr,
 			groupLoader: groupLoader,
+			logOutput:   logOutput,
+			alertState:  alertState,
 		}
 
 		memStore.Start(groupLoader)
@@ -191,16 +225,25 @@ func MultiTenantRuleManager(cfg Config, evaluator Evaluator, overrides RulesLimi
 type CachingRulesManager struct {
 	manager     ruler.RulesManager
 	groupLoader *CachingGroupLoader
+	logOutput   *ruleLogOutput
+	alertState  *alertStatePersister
 }
 
 // Update reconciles the state of the CachingGroupLoader after a manager.Update.
 // The GroupLoader is mutated as part of a call to Update but it might still
 // contain removed files. Update tells the loader which files to keep
 func (m *CachingRulesManager) Update(interval time.Duration, files []string, externalLabels labels.Labels, externalURL string, ruleGroupPostProcessFunc rules.GroupEvalIterationFunc) error {
+	if m.alertState != nil {
+		ruleGroupPostProcessFunc = m.alertState.evalIterationFunc(ruleGroupPostProcessFunc)
+	}
+	before := m.manager.RuleGroups()
 	err := m.manager.Update(interval, files, externalLabels, externalURL, ruleGroupPostProcessFunc)
 	if err != nil {
 		return err
 	}
+	if m.alertState != nil {
+		m.alertState.deleteRemovedGroups(context.Background(), before, m.manager.RuleGroups())
+	}
 
 	m.groupLoader.Prune(files)
 	return nil
@@ -212,6 +255,10 @@ func (m *CachingRulesManager) Run() {
 
 func (m *CachingRulesManager) Stop() {
 	m.manager.Stop()
+
+	if m.logOutput != nil {
+		m.logOutput.stop()
+	}
 }
 
 func (m *CachingRulesManager) RuleGroups() []*rules.Group {
@@ -283,6 +330,10 @@ func validateRule(r *rulefmt.Rule, groupName string) error {
 		if !model.LabelValue(v).IsValid() {
 			return errors.Errorf("invalid label value: %s", v)
 		}
+
+		if k == LogOutputLabel && v != "true" {
+			return errors.Errorf("invalid %s label value: %s, only true is supported", LogOutputLabel, v)
+		}
 	}
 
 	for k := range r.Annotations {
@@ -24,6 +24,10 @@ type Config struct {
 	RemoteWrite RemoteWriteConfig `yaml:"remote_write,omitempty" doc:"description=Remote-write configuration to send rule samples to a Prometheus remote-write endpoint."`
 
 	Evaluation EvaluationConfig `yaml:"evaluation,omitempty" doc:"description=Configuration for rule evaluation."`
+
+	LogOutput LogOutputConfig `yaml:"log_output,omitempty" doc:"description=Configures how the outputs of the log output rules, the rules labeled with '__log_output__', are pushed back to Loki as log streams."`
+
+	AlertState AlertStateConfig `yaml:"alert_state,omitempty" doc:"description=Configures the persistence of the alert states in the rule storage, to restore the 'for' state of the alerts across ruler restarts and rule group ownership changes."`
 }
 
 func (c *Config) RegisterFlags(f *flag.FlagSet) {
@@ -32,6 +36,8 @@ func (c *Config) RegisterFlags(f *flag.FlagSet) {
 	c.WAL.RegisterFlags(f)
 	c.WALCleaner.RegisterFlags(f)
 	c.Evaluation.RegisterFlags(f)
+	c.LogOutput.RegisterFlags(f)
+	c.AlertState.RegisterFlags(f)
 }
 
 // Validate overrides the embedded cortex variant which expects a cortex limits struct. Instead, copy the relevant bits over.
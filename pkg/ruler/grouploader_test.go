@@ -203,6 +203,20 @@ groups:
             'se.verity': page
         annotations:
             summary: High request latency
+`,
+		},
+		{
+			desc:  "fail invalid log output label value",
+			match: "invalid __log_output__ label value:",
+			data: `
+groups:
+  - name: grp1
+    interval: 0s
+    rules:
+      - record: HighThroughputLogStreams
+        expr: sum by (cluster, job, pod) (rate({namespace=~"%s"} |~ "http(s?)://(\\w+):(\\w+)@" [5m]) > 0)
+        labels:
+            __log_output__: "yes"
 `,
 		},
 		{
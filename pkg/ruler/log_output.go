@@ -0,0 +1,368 @@
+package ruler
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/backoff"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/pattern/aggregation"
+)
+
+const (
+	// LogOutputLabel is the rule label making a rule a log output rule, whose
+	// outputs are written back to Loki. It is removed from the series and the
+	// alerts of the rule.
+	LogOutputLabel = "__log_output__"
+
+	// logOutputServiceName is the service_name of the streams written by the ruler.
+	logOutputServiceName = "loki-ruler"
+
+	alertMetricName         = "ALERTS"
+	alertForStateMetricName = "ALERTS_FOR_STATE"
+	alertStateLabel         = "alertstate"
+)
+
+// LogOutputConfig configures how the rule outputs are pushed back to Loki.
+type LogOutputConfig struct {
+	LokiAddr         string                  `yaml:"loki_address,omitempty" doc:"description=The address of the Loki instance to push rule outputs to."`
+	WriteTimeout     time.Duration           `yaml:"timeout,omitempty" doc:"description=The timeout for writing rule outputs to Loki."`
+	PushPeriod       time.Duration           `yaml:"push_period,omitempty" doc:"description=How long to wait between rule output pushes to Loki."`
+	HTTPClientConfig config.HTTPClientConfig `yaml:"http_client_config,omitempty" doc:"description=The HTTP client configuration for pushing rule outputs to Loki."`
+	UseTLS           bool                    `yaml:"use_tls,omitempty" doc:"description=Whether to use TLS for pushing rule outputs to Loki."`
+	BasicAuth        aggregation.BasicAuth   `yaml:"basic_auth,omitempty" doc:"description=The basic auth configuration for pushing rule outputs to Loki."`
+	BackoffConfig    backoff.Config          `yaml:"backoff_config,omitempty" doc:"description=The backoff configuration for pushing rule outputs to Loki."`
+}
+
+func (cfg *LogOutputConfig) RegisterFlags(fs *flag.FlagSet) {
+	cfg.RegisterFlagsWithPrefix(fs, "ruler.log-output.")
+}
+
+func (cfg *LogOutputConfig) RegisterFlagsWithPrefix(fs *flag.FlagSet, prefix string) {
+	fs.StringVar(&cfg.LokiAddr, prefix+"loki-address", "", "Loki address to send rule outputs to.")
+	fs.DurationVar(&cfg.WriteTimeout, prefix+"timeout", 10*time.Second, "How long to wait for write response from Loki.")
+	fs.DurationVar(&cfg.PushPeriod, prefix+"push-period", 10*time.Second, "How long to wait between rule output pushes to Loki.")
+	fs.BoolVar(&cfg.UseTLS, prefix+"tls", false, "Does the loki connection use TLS?")
+
+	cfg.BackoffConfig.RegisterFlagsWithPrefix(prefix[:len(prefix)-1], fs)
+	cfg.BasicAuth.RegisterFlagsWithPrefix(prefix, fs)
+}
+
+// ruleLogOutput writes the outputs of the log output rules of a tenant back to
+// Loki as log streams labeled with the rule and the tenant:
+//   - recording rules write one line per sample, with the sample labels as
+//     structured metadata.
+//   - alerting rules write one line per alert state transition, with the alert
+//     labels as structured metadata, which gives an alert state history
+//     queryable with LogQL.
+type ruleLogOutput struct {
+	userID  string
+	cfg     LogOutputConfig
+	reg     prometheus.Registerer
+	logger  log.Logger
+	writeFn func(ts time.Time, line string, lbls labels.Labels, structuredMetadata []logproto.LabelAdapter)
+
+	// restored returns the alerts of an alerting rule in the restored alert
+	// state of its group, by labels. It is nil if the alert state isn't persisted.
+	restored func(ctx context.Context, namespace, group, alertname string) map[string]alertInstance
+
+	writerMtx sync.Mutex
+	writer    aggregation.EntryWriter
+
+	// alerts of the previous evaluation, by rule and alert labels
+	alertsMtx sync.Mutex
+	alerts    map[string]map[string]alertInstance
+}
+
+type alertInstance struct {
+	state  rules.AlertState
+	labels labels.Labels
+}
+
+func newRuleLogOutput(userID string, cfg LogOutputConfig, reg prometheus.Registerer, logger log.Logger) *ruleLogOutput {
+	o := &ruleLogOutput{
+		userID: userID,
+		cfg:    cfg,
+		reg:    reg,
+		logger: log.With(logger, "component", "rule-log-output"),
+		alerts: make(map[string]map[string]alertInstance),
+	}
+	o.writeFn = o.push
+	return o
+}
+
+// wrap returns an Appendable appending to the given one and writing the
+// committed outputs of the log output rules to Loki.
+func (o *ruleLogOutput) wrap(appendable storage.Appendable) storage.Appendable {
+	return appendableFunc(func(ctx context.Context) storage.Appender {
+		app := appendable.Appender(ctx)
+		namespace, group, ok := ruleGroupFromContext(ctx)
+		if !ok {
+			return app
+		}
+		return &logOutputAppender{
+			Appender:  app,
+			ctx:       ctx,
+			output:    o,
+			namespace: namespace,
+			group:     group,
+		}
+	})
+}
+
+// notifyFunc returns a NotifyFunc sending the alerts to the given one without
+// the log output label.
+func (o *ruleLogOutput) notifyFunc(next rules.NotifyFunc) rules.NotifyFunc {
+	return func(ctx context.Context, expr string, alerts ...*rules.Alert) {
+		res := make([]*rules.Alert, 0, len(alerts))
+		for _, a := range alerts {
+			if a.Labels.Has(LogOutputLabel) {
+				alert := *a
+				alert.Labels = withoutLogOutputLabel(a.Labels)
+				a = &alert
+			}
+			res = append(res, a)
+		}
+		next(ctx, expr, res...)
+	}
+}
+
+func withoutLogOutputLabel(lbls labels.Labels) labels.Labels {
+	return labels.NewBuilder(lbls).Del(LogOutputLabel).Labels()
+}
+
+// ruleGroupFromContext returns the namespace and the name of the rule group
+// being evaluated, which the rules manager adds to the evaluation context.
+func ruleGroupFromContext(ctx context.Context) (string, string, bool) {
+	origin, ok := ctx.Value(promql.QueryOrigin{}).(map[string]interface{})
+	if !ok {
+		return "", "", false
+	}
+	group, ok := origin["ruleGroup"].(map[string]string)
+	if !ok {
+		return "", "", false
+	}
+
+	namespace, err := ruleGroupNamespace(group["file"])
+	if err != nil {
+		return "", "", false
+	}
+	return namespace, group["name"], true
+}
+
+// ruleGroupNamespace returns the namespace of the rules file of a group, the
+// files being named after the url path escaped namespaces.
+func ruleGroupNamespace(file string) (string, error) {
+	return url.PathUnescape(filepath.Base(file))
+}
+
+type appendableFunc func(ctx context.Context) storage.Appender
+
+func (f appendableFunc) Appender(ctx context.Context) storage.Appender { return f(ctx) }
+
+// logOutputAppender keeps the samples of the log output rules of a rule
+// evaluation to write them to Loki once committed.
+type logOutputAppender struct {
+	storage.Appender
+
+	ctx              context.Context
+	output           *ruleLogOutput
+	namespace, group string
+	samples          []promql.Sample
+}
+
+func (a *logOutputAppender) Append(ref storage.SeriesRef, l labels.Labels, t int64, v float64) (storage.SeriesRef, error) {
+	if l.Get(LogOutputLabel) != "true" {
+		return a.Appender.Append(ref, l, t, v)
+	}
+
+	l = withoutLogOutputLabel(l)
+	ref, err := a.Appender.Append(ref, l, t, v)
+	if err == nil {
+		a.samples = append(a.samples, promql.Sample{Metric: l, T: t, F: v})
+	}
+	return ref, err
+}
+
+func (a *logOutputAppender) Commit() error {
+	if err := a.Appender.Commit(); err != nil {
+		return err
+	}
+	if len(a.samples) > 0 {
+		a.output.write(a.ctx, a.namespace, a.group, a.samples)
+	}
+	return nil
+}
+
+func (a *logOutputAppender) Rollback() error {
+	a.samples = nil
+	return a.Appender.Rollback()
+}
+
+// write writes the samples committed by a rule evaluation: the ALERTS series
+// of alerting rules are turned into alert state transitions, the series of
+// recording rules are written as is.
+func (o *ruleLogOutput) write(ctx context.Context, namespace, group string, samples []promql.Sample) {
+	// the alerts of each alerting rule of the evaluation, by labels
+	alerts := make(map[string]map[string]alertInstance)
+
+	for _, s := range samples {
+		name := s.Metric.Get(labels.MetricName)
+		switch {
+		case name == alertMetricName:
+			alertname := s.Metric.Get(labels.AlertName)
+			if alerts[alertname] == nil {
+				alerts[alertname] = make(map[string]alertInstance)
+			}
+			if value.IsStaleNaN(s.F) {
+				continue
+			}
+			lbls := labels.NewBuilder(s.Metric).Del(labels.MetricName, alertStateLabel).Labels()
+			alerts[alertname][lbls.String()] = alertInstance{
+				state:  alertState(s.Metric.Get(alertStateLabel)),
+				labels: lbls,
+			}
+		case value.IsStaleNaN(s.F) || name == alertForStateMetricName:
+			// stale markers of recording rules and the alerts `for` state are not written
+		default:
+			line := "value=" + strconv.FormatFloat(s.F, 'f', -1, 64)
+			o.writeFn(time.UnixMilli(s.T), line, o.streamLabels(namespace, group, name, rules.KindRecording), structuredMetadata(s.Metric))
+		}
+	}
+
+	if len(alerts) == 0 {
+		return
+	}
+
+	ts := time.UnixMilli(samples[0].T)
+	o.alertsMtx.Lock()
+	defer o.alertsMtx.Unlock()
+	for alertname, current := range alerts {
+		ruleKey := namespace + "/" + group + "/" + alertname
+		previous, ok := o.alerts[ruleKey]
+		// The alerts of a rule first evaluated by this ruler transition from the
+		// state they were in before the ruler restored them.
+		restoring := !ok && o.restored != nil
+		if restoring {
+			previous = o.restored(ctx, namespace, group, alertname)
+		}
+		stream := o.streamLabels(namespace, group, alertname, rules.KindAlerting)
+
+		for key, alert := range current {
+			prev, ok := previous[key]
+			if restoring && ok && prev.state == rules.StateFiring && alert.state == rules.StatePending {
+				// The rules manager evaluates the restored alerts as pending
+				// until it restores their for state, they keep firing.
+				current[key] = prev
+				continue
+			}
+			if !ok || prev.state != alert.state {
+				o.writeFn(ts, transitionLine(alert.state, prev.state), stream, structuredMetadata(alert.labels))
+			}
+		}
+		for key, prev := range previous {
+			if _, ok := current[key]; !ok {
+				o.writeFn(ts, transitionLine(rules.StateInactive, prev.state), stream, structuredMetadata(prev.labels))
+			}
+		}
+		o.alerts[ruleKey] = current
+	}
+}
+
+func alertState(s string) rules.AlertState {
+	switch s {
+	case rules.StateFiring.String():
+		return rules.StateFiring
+	case rules.StatePending.String():
+		return rules.StatePending
+	}
+	return rules.StateInactive
+}
+
+func transitionLine(state, previous rules.AlertState) string {
+	return fmt.Sprintf("state=%s previous_state=%s", state, previous)
+}
+
+func (o *ruleLogOutput) streamLabels(namespace, group, rule, kind string) labels.Labels {
+	return labels.FromStrings(
+		"service_name", logOutputServiceName,
+		"tenant", o.userID,
+		"rule_namespace", namespace,
+		"rule_group", group,
+		"rule_name", rule,
+		"rule_type", kind,
+	)
+}
+
+// structuredMetadata returns the labels of a sample or of an alert, without
+// the metric and alert names which are already in the stream labels.
+func structuredMetadata(lbls labels.Labels) []logproto.LabelAdapter {
+	var res []logproto.LabelAdapter
+	lbls.Range(func(l labels.Label) {
+		if l.Name == labels.MetricName || l.Name == labels.AlertName {
+			return
+		}
+		res = append(res, logproto.LabelAdapter{Name: l.Name, Value: l.Value})
+	})
+	return res
+}
+
+// push writes an entry to Loki, creating the push client on first use.
+func (o *ruleLogOutput) push(ts time.Time, line string, lbls labels.Labels, structuredMetadata []logproto.LabelAdapter) {
+	o.writerMtx.Lock()
+	defer o.writerMtx.Unlock()
+
+	if o.writer == nil {
+		if o.cfg.LokiAddr == "" {
+			level.Warn(o.logger).Log("msg", "rule log output is enabled but no loki address is configured, dropping entry", "user", o.userID)
+			return
+		}
+
+		writer, err := aggregation.NewPush(
+			o.cfg.LokiAddr,
+			o.userID,
+			o.cfg.WriteTimeout,
+			o.cfg.PushPeriod,
+			o.cfg.HTTPClientConfig,
+			o.cfg.BasicAuth.Username,
+			string(o.cfg.BasicAuth.Password),
+			o.cfg.UseTLS,
+			&o.cfg.BackoffConfig,
+			log.With(o.logger, "user", o.userID),
+			aggregation.NewMetrics(o.reg),
+		)
+		if err != nil {
+			level.Error(o.logger).Log("msg", "failed to create rule log output writer, dropping entry", "user", o.userID, "err", err)
+			return
+		}
+		o.writer = writer
+	}
+
+	o.writer.WriteEntry(ts, line, lbls, structuredMetadata)
+}
+
+func (o *ruleLogOutput) stop() {
+	o.writerMtx.Lock()
+	defer o.writerMtx.Unlock()
+
+	if o.writer != nil {
+		o.writer.Stop()
+		o.writer = nil
+	}
+}
@@ -0,0 +1,207 @@
+package ruler
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+)
+
+type logOutputEntry struct {
+	line               string
+	stream             labels.Labels
+	structuredMetadata []logproto.LabelAdapter
+}
+
+type nopAppendable struct{}
+
+func (nopAppendable) Appender(context.Context) storage.Appender { return nopAppender{} }
+
+type nopAppender struct{ storage.Appender }
+
+func (nopAppender) Append(storage.SeriesRef, labels.Labels, int64, float64) (storage.SeriesRef, error) {
+	return 0, nil
+}
+func (nopAppender) Commit() error                     { return nil }
+func (nopAppender) Rollback() error                   { return nil }
+func (nopAppender) SetOptions(*storage.AppendOptions) {}
+
+// labelsAppendable records the labels of the appended series
+type labelsAppendable struct{ appended []labels.Labels }
+
+func (a *labelsAppendable) Appender(context.Context) storage.Appender {
+	return labelsAppender{labelsAppendable: a}
+}
+
+type labelsAppender struct {
+	nopAppender
+	*labelsAppendable
+}
+
+func (a labelsAppender) Append(_ storage.SeriesRef, l labels.Labels, _ int64, _ float64) (storage.SeriesRef, error) {
+	a.appended = append(a.appended, l)
+	return 0, nil
+}
+
+func newTestRuleLogOutput() (*ruleLogOutput, *[]logOutputEntry) {
+	var entries []logOutputEntry
+	o := newRuleLogOutput("user", LogOutputConfig{}, nil, log.NewNopLogger())
+	o.writeFn = func(_ time.Time, line string, lbls labels.Labels, structuredMetadata []logproto.LabelAdapter) {
+		entries = append(entries, logOutputEntry{line: line, stream: lbls, structuredMetadata: structuredMetadata})
+	}
+	return o, &entries
+}
+
+func ruleGroupContext() context.Context {
+	return promql.NewOriginContext(context.Background(), map[string]interface{}{
+		"ruleGroup": map[string]string{"file": "/rules/user/my%2Fnamespace", "name": "group"},
+	})
+}
+
+func commitSamples(t *testing.T, app storage.Appendable, samples ...promql.Sample) {
+	a := app.Appender(ruleGroupContext())
+	for _, s := range samples {
+		_, err := a.Append(0, s.Metric, s.T, s.F)
+		require.NoError(t, err)
+	}
+	require.NoError(t, a.Commit())
+}
+
+func alertSample(state string, ts int64) promql.Sample {
+	return promql.Sample{
+		Metric: labels.FromStrings(labels.MetricName, alertMetricName, labels.AlertName, "HighRate", alertStateLabel, state, "job", "app", LogOutputLabel, "true"),
+		T:      ts,
+		F:      1,
+	}
+}
+
+func TestRuleLogOutputRecording(t *testing.T) {
+	o, entries := newTestRuleLogOutput()
+	app := o.wrap(nopAppendable{})
+
+	commitSamples(t, app,
+		promql.Sample{Metric: labels.FromStrings(labels.MetricName, "job:requests:rate1m", "job", "app", LogOutputLabel, "true"), T: 1000, F: 2.5},
+		promql.Sample{Metric: labels.FromStrings(labels.MetricName, "job:requests:rate1m", "job", "gone", LogOutputLabel, "true"), T: 1000, F: math.Float64frombits(value.StaleNaN)},
+	)
+
+	require.Equal(t, []logOutputEntry{{
+		line: "value=2.5",
+		stream: labels.FromStrings(
+			"service_name", logOutputServiceName, "tenant", "user",
+			"rule_namespace", "my/namespace", "rule_group", "group",
+			"rule_name", "job:requests:rate1m", "rule_type", "recording",
+		),
+		structuredMetadata: []logproto.LabelAdapter{{Name: "job", Value: "app"}},
+	}}, *entries)
+}
+
+func TestRuleLogOutputAlertTransitions(t *testing.T) {
+	o, entries := newTestRuleLogOutput()
+	app := o.wrap(nopAppendable{})
+
+	forState := promql.Sample{Metric: labels.FromStrings(labels.MetricName, alertForStateMetricName, labels.AlertName, "HighRate", "job", "app", LogOutputLabel, "true"), T: 1000, F: 1}
+	commitSamples(t, app, alertSample("pending", 1000), forState)
+	// no transition
+	commitSamples(t, app, alertSample("pending", 2000), forState)
+	commitSamples(t, app, alertSample("firing", 3000), forState)
+	// the alert resolves: the rules manager only appends a stale marker
+	stale := alertSample("firing", 4000)
+	stale.F = math.Float64frombits(value.StaleNaN)
+	commitSamples(t, app, stale)
+
+	var lines []string
+	for _, e := range *entries {
+		require.Equal(t, "HighRate", e.stream.Get("rule_name"))
+		require.Equal(t, "alerting", e.stream.Get("rule_type"))
+		require.Equal(t, []logproto.LabelAdapter{{Name: "job", Value: "app"}}, e.structuredMetadata)
+		lines = append(lines, e.line)
+	}
+	require.Equal(t, []string{
+		"state=pending previous_state=inactive",
+		"state=firing previous_state=pending",
+		"state=inactive previous_state=firing",
+	}, lines)
+}
+
+func TestRuleLogOutputRestoredAlerts(t *testing.T) {
+	o, entries := newTestRuleLogOutput()
+	o.restored = func(_ context.Context, namespace, group, alertname string) map[string]alertInstance {
+		require.Equal(t, []string{"my/namespace", "group", "HighRate"}, []string{namespace, group, alertname})
+		firing := labels.FromStrings(labels.AlertName, "HighRate", "job", "app")
+		pending := labels.FromStrings(labels.AlertName, "HighRate", "job", "other")
+		return map[string]alertInstance{
+			firing.String():  {state: rules.StateFiring, labels: firing},
+			pending.String(): {state: rules.StatePending, labels: pending},
+		}
+	}
+	app := o.wrap(nopAppendable{})
+
+	// the restored firing alert is pending until its for state is restored
+	commitSamples(t, app, alertSample("pending", 1000))
+	commitSamples(t, app, alertSample("firing", 2000))
+	stale := alertSample("firing", 3000)
+	stale.F = math.Float64frombits(value.StaleNaN)
+	commitSamples(t, app, stale)
+
+	var lines []string
+	for _, e := range *entries {
+		lines = append(lines, e.line)
+	}
+	require.Equal(t, []string{
+		// the pending alert resolved while the rule wasn't evaluated
+		"state=inactive previous_state=pending",
+		"state=inactive previous_state=firing",
+	}, lines)
+}
+
+func TestRuleLogOutputRules(t *testing.T) {
+	o, entries := newTestRuleLogOutput()
+	appendable := &labelsAppendable{}
+	app := o.wrap(appendable)
+
+	// the rules without the log output label are not written
+	recording := promql.Sample{Metric: labels.FromStrings(labels.MetricName, "job:requests:rate1m", "job", "app"), T: 1000, F: 1}
+	commitSamples(t, app, recording)
+	require.Empty(t, *entries)
+
+	// the log output label is removed from the series of the log output rules
+	commitSamples(t, app, alertSample("firing", 1000))
+	require.Len(t, *entries, 1)
+	require.Equal(t, []labels.Labels{
+		recording.Metric,
+		labels.FromStrings(labels.MetricName, alertMetricName, labels.AlertName, "HighRate", alertStateLabel, "firing", "job", "app"),
+	}, appendable.appended)
+
+	// evaluations without rule group are not written
+	o, entries = newTestRuleLogOutput()
+	a := o.wrap(nopAppendable{}).Appender(context.Background())
+	_, err := a.Append(0, alertSample("firing", 1000).Metric, 1000, 1)
+	require.NoError(t, err)
+	require.NoError(t, a.Commit())
+	require.Empty(t, *entries)
+}
+
+func TestRuleLogOutputNotify(t *testing.T) {
+	o, _ := newTestRuleLogOutput()
+	alert := &rules.Alert{State: rules.StateFiring, Labels: labels.FromStrings(labels.AlertName, "HighRate", LogOutputLabel, "true")}
+
+	var notified []*rules.Alert
+	notify := o.notifyFunc(func(_ context.Context, _ string, alerts ...*rules.Alert) {
+		notified = alerts
+	})
+	notify(context.Background(), "expr", alert)
+
+	require.Equal(t, []*rules.Alert{{State: rules.StateFiring, Labels: labels.FromStrings(labels.AlertName, "HighRate")}}, notified)
+	// the alert of the rule is unchanged
+	require.Equal(t, "true", alert.Labels.Get(LogOutputLabel))
+}
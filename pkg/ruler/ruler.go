@@ -28,9 +28,14 @@ func NewRuler(cfg Config, evaluator Evaluator, reg prometheus.Registerer, logger
 		cfg.RemoteWrite.Clients["default"] = *cfg.RemoteWrite.Client
 	}
 
+	stateStore, err := alertStateStore(cfg.AlertState, ruleStore)
+	if err != nil {
+		return nil, err
+	}
+
 	mgr, err := ruler.NewDefaultMultiTenantManager(
 		cfg.Config,
-		MultiTenantRuleManager(cfg, evaluator, limits, logger, reg),
+		MultiTenantRuleManager(cfg, evaluator, limits, stateStore, logger, reg),
 		reg,
 		logger,
 		limits,
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -23,6 +24,10 @@ import (
 const (
 	// The bucket prefix under which all tenants rule groups are stored.
 	rulesPrefix = "rules"
+	// The bucket prefix under which all tenants alert states are stored.
+	alertStatePrefix = "alert-state"
+	// The bucket prefix under which all tenants rule group backfill statuses are stored.
+	backfillStatusPrefix = "backfill-status"
 
 	loadConcurrency = 10
 )
@@ -37,16 +42,20 @@ var (
 // BucketRuleStore is used to support the RuleStore interface against an object storage backend. It is implemented
 // using the Thanos objstore.Bucket interface
 type BucketRuleStore struct {
-	bucket      objstore.Bucket
-	cfgProvider bucket.SSEConfigProvider
-	logger      log.Logger
+	bucket         objstore.Bucket
+	stateBucket    objstore.Bucket
+	backfillBucket objstore.Bucket
+	cfgProvider    bucket.SSEConfigProvider
+	logger         log.Logger
 }
 
 func NewBucketRuleStore(bkt objstore.Bucket, cfgProvider bucket.SSEConfigProvider, logger log.Logger) *BucketRuleStore {
 	return &BucketRuleStore{
-		bucket:      bucket.NewPrefixedBucketClient(bkt, rulesPrefix),
-		cfgProvider: cfgProvider,
-		logger:      logger,
+		bucket:         bucket.NewPrefixedBucketClient(bkt, rulesPrefix),
+		stateBucket:    bucket.NewPrefixedBucketClient(bkt, alertStatePrefix),
+		backfillBucket: bucket.NewPrefixedBucketClient(bkt, backfillStatusPrefix),
+		cfgProvider:    cfgProvider,
+		logger:         logger,
 	}
 }
 
@@ -236,7 +245,12 @@ func (b *BucketRuleStore) DeleteRuleGroup(ctx context.Context, userID string, na
 	if b.bucket.IsObjNotFoundErr(err) {
 		return rulestore.ErrGroupNotFound
 	}
-	return err
+	if err != nil {
+		return err
+	}
+
+	b.deleteAlertStateOfGroup(ctx, userID, namespace, group)
+	return nil
 }
 
 // DeleteNamespace implements rules.RuleStore.
@@ -262,11 +276,138 @@ func (b *BucketRuleStore) DeleteNamespace(ctx context.Context, userID string, na
 			level.Error(b.logger).Log("msg", "unable to delete rule group from namespace", "user", userID, "namespace", namespace, "key", objectKey, "err", err)
 			return err
 		}
+		b.deleteAlertStateOfGroup(ctx, userID, rg.Namespace, rg.Name)
 	}
 
 	return nil
 }
 
+// deleteAlertStateOfGroup deletes the alert state of a deleted rule group. A
+// failure is not returned: the rulers evaluating the group delete its state
+// once they stop evaluating it.
+func (b *BucketRuleStore) deleteAlertStateOfGroup(ctx context.Context, userID, namespace, group string) {
+	err := b.DeleteAlertState(ctx, userID, namespace, group)
+	if err != nil && !errors.Is(err, rulestore.ErrAlertStateNotFound) {
+		level.Warn(b.logger).Log("msg", "unable to delete alert state of deleted rule group", "user", userID, "namespace", namespace, "group", group, "err", err)
+	}
+}
+
+// ListAlertStates implements rulestore.AlertStateStore.
+func (b *BucketRuleStore) ListAlertStates(ctx context.Context, userID string) ([]*rulestore.AlertStateDesc, error) {
+	userBucket := bucket.NewUserBucketClient(userID, b.stateBucket, b.cfgProvider)
+
+	var keys []string
+	err := userBucket.Iter(ctx, "", func(key string) error {
+		if _, _, err := parseRuleGroupObjectKey(key); err != nil {
+			level.Warn(b.logger).Log("msg", "invalid alert state object key found while listing alert states", "user", userID, "key", key, "err", err)
+
+			// Do not fail just because of a spurious item in the bucket.
+			return nil
+		}
+		keys = append(keys, key)
+		return nil
+	}, objstore.WithRecursiveIter())
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*rulestore.AlertStateDesc, len(keys))
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(loadConcurrency)
+	for i, key := range keys {
+		g.Go(func() error {
+			state, err := b.getAlertState(gCtx, userBucket, key)
+			if err != nil {
+				return err
+			}
+			states[i] = state
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// alert states deleted while listing them are skipped
+	res := states[:0]
+	for _, state := range states {
+		if state != nil {
+			res = append(res, state)
+		}
+	}
+	return res, nil
+}
+
+func (b *BucketRuleStore) getAlertState(ctx context.Context, userBucket objstore.Bucket, objectKey string) (*rulestore.AlertStateDesc, error) {
+	reader, err := userBucket.Get(ctx, objectKey)
+	if userBucket.IsObjNotFoundErr(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get alert state %s", objectKey)
+	}
+	defer func() { _ = reader.Close() }()
+
+	var state rulestore.AlertStateDesc
+	if err := json.NewDecoder(reader).Decode(&state); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal alert state %s", objectKey)
+	}
+	return &state, nil
+}
+
+// SetAlertState implements rulestore.AlertStateStore.
+func (b *BucketRuleStore) SetAlertState(ctx context.Context, userID string, state *rulestore.AlertStateDesc) error {
+	userBucket := bucket.NewUserBucketClient(userID, b.stateBucket, b.cfgProvider)
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return userBucket.Upload(ctx, getRuleGroupObjectKey(state.Namespace, state.Group), bytes.NewBuffer(data))
+}
+
+// DeleteAlertState implements rulestore.AlertStateStore.
+func (b *BucketRuleStore) DeleteAlertState(ctx context.Context, userID string, namespace string, group string) error {
+	userBucket := bucket.NewUserBucketClient(userID, b.stateBucket, b.cfgProvider)
+	err := userBucket.Delete(ctx, getRuleGroupObjectKey(namespace, group))
+	if b.stateBucket.IsObjNotFoundErr(err) {
+		return rulestore.ErrAlertStateNotFound
+	}
+	return err
+}
+
+// GetBackfillStatus implements rulestore.BackfillStatusStore.
+func (b *BucketRuleStore) GetBackfillStatus(ctx context.Context, userID, namespace, group string) (*rulestore.BackfillStatusDesc, error) {
+	userBucket := bucket.NewUserBucketClient(userID, b.backfillBucket, b.cfgProvider)
+	objectKey := getRuleGroupObjectKey(namespace, group)
+
+	reader, err := userBucket.Get(ctx, objectKey)
+	if userBucket.IsObjNotFoundErr(err) {
+		return nil, rulestore.ErrBackfillStatusNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get backfill status %s", objectKey)
+	}
+	defer func() { _ = reader.Close() }()
+
+	var status rulestore.BackfillStatusDesc
+	if err := json.NewDecoder(reader).Decode(&status); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal backfill status %s", objectKey)
+	}
+	return &status, nil
+}
+
+// SetBackfillStatus implements rulestore.BackfillStatusStore.
+func (b *BucketRuleStore) SetBackfillStatus(ctx context.Context, userID string, status *rulestore.BackfillStatusDesc) error {
+	userBucket := bucket.NewUserBucketClient(userID, b.backfillBucket, b.cfgProvider)
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	return userBucket.Upload(ctx, getRuleGroupObjectKey(status.Namespace, status.Group), bytes.NewBuffer(data))
+}
+
 func getNamespacePrefix(namespace string) string {
 	return base64.URLEncoding.EncodeToString([]byte(namespace)) + objstore.DirDelim
 }
@@ -231,6 +231,110 @@ func TestDelete(t *testing.T) {
 	})
 }
 
+func TestAlertStates(t *testing.T) {
+	bucketClient := objstore.NewInMemBucket()
+	rs := NewBucketRuleStore(bucketClient, nil, log.NewNopLogger())
+	ctx := context.Background()
+
+	evaluatedAt := time.Unix(1000, 0).UTC()
+	states := []*rulestore.AlertStateDesc{
+		{
+			Namespace:   "A",
+			Group:       "1",
+			EvaluatedAt: evaluatedAt,
+			Alerts: []rulestore.ActiveAlertDesc{
+				{Labels: map[string]string{"alertname": "HighRate", "job": "app"}, ActiveAt: evaluatedAt.Add(-time.Minute)},
+			},
+		},
+		{Namespace: "B", Group: "2", EvaluatedAt: evaluatedAt},
+	}
+	for _, state := range states {
+		require.NoError(t, rs.SetAlertState(ctx, "user1", state))
+	}
+	require.NoError(t, rs.SetRuleGroup(ctx, "user1", "A", rulespb.ToProto("user1", "A", rulefmt.RuleGroup{Name: "1"})))
+
+	// alert states are stored apart from the rule groups
+	require.Equal(t, []string{
+		"alert-state/user1/" + getRuleGroupObjectKey("A", "1"),
+		"alert-state/user1/" + getRuleGroupObjectKey("B", "2"),
+		"rules/user1/" + getRuleGroupObjectKey("A", "1"),
+	}, getSortedObjectKeys(bucketClient))
+
+	users, err := rs.ListAllUsers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"user1"}, users)
+
+	got, err := rs.ListAlertStates(ctx, "user1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, states, got)
+
+	got, err = rs.ListAlertStates(ctx, "user2")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	require.NoError(t, rs.DeleteAlertState(ctx, "user1", "B", "2"))
+	require.Equal(t, rulestore.ErrAlertStateNotFound, rs.DeleteAlertState(ctx, "user1", "B", "2"))
+
+	got, err = rs.ListAlertStates(ctx, "user1")
+	require.NoError(t, err)
+	require.Equal(t, states[:1], got)
+
+	// the alert state of a rule group is deleted with the group
+	require.NoError(t, rs.DeleteRuleGroup(ctx, "user1", "A", "1"))
+	got, err = rs.ListAlertStates(ctx, "user1")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	require.NoError(t, rs.SetRuleGroup(ctx, "user1", "A", rulespb.ToProto("user1", "A", rulefmt.RuleGroup{Name: "1"})))
+	require.NoError(t, rs.SetAlertState(ctx, "user1", states[0]))
+	require.NoError(t, rs.DeleteNamespace(ctx, "user1", "A"))
+	got, err = rs.ListAlertStates(ctx, "user1")
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestBackfillStatuses(t *testing.T) {
+	bucketClient := objstore.NewInMemBucket()
+	rs := NewBucketRuleStore(bucketClient, nil, log.NewNopLogger())
+	ctx := context.Background()
+
+	_, err := rs.GetBackfillStatus(ctx, "user1", "A", "1")
+	require.Equal(t, rulestore.ErrBackfillStatusNotFound, err)
+
+	start := time.Unix(1000, 0).UTC()
+	status := &rulestore.BackfillStatusDesc{
+		Namespace: "A",
+		Group:     "1",
+		Start:     start,
+		End:       start.Add(time.Hour),
+		State:     "running",
+		UpdatedAt: start.Add(2 * time.Hour),
+	}
+	require.NoError(t, rs.SetBackfillStatus(ctx, "user1", status))
+	require.NoError(t, rs.SetRuleGroup(ctx, "user1", "A", rulespb.ToProto("user1", "A", rulefmt.RuleGroup{Name: "1"})))
+
+	// backfill statuses are stored apart from the rule groups
+	require.Equal(t, []string{
+		"backfill-status/user1/" + getRuleGroupObjectKey("A", "1"),
+		"rules/user1/" + getRuleGroupObjectKey("A", "1"),
+	}, getSortedObjectKeys(bucketClient))
+
+	got, err := rs.GetBackfillStatus(ctx, "user1", "A", "1")
+	require.NoError(t, err)
+	require.Equal(t, status, got)
+
+	_, err = rs.GetBackfillStatus(ctx, "user2", "A", "1")
+	require.Equal(t, rulestore.ErrBackfillStatusNotFound, err)
+
+	status.State = "done"
+	status.Samples = 7
+	status.FinishedAt = start.Add(3 * time.Hour)
+	require.NoError(t, rs.SetBackfillStatus(ctx, "user1", status))
+	got, err = rs.GetBackfillStatus(ctx, "user1", "A", "1")
+	require.NoError(t, err)
+	require.Equal(t, status, got)
+}
+
 func runForEachRuleStore(t *testing.T, testFn func(t *testing.T, store rulestore.RuleStore, bucketClient interface{})) {
 	legacyClient := testutils.NewMockStorage()
 	legacyStore := objectclient.NewRuleStore(legacyClient, 5, log.NewNopLogger())
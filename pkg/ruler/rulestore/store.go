@@ -3,6 +3,7 @@ package rulestore
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/grafana/loki/v3/pkg/ruler/rulespb"
 )
@@ -14,6 +15,10 @@ var (
 	ErrGroupNamespaceNotFound = errors.New("group namespace does not exist")
 	// ErrUserNotFound is returned if the user does not currently exist
 	ErrUserNotFound = errors.New("no rule groups found for user")
+	// ErrAlertStateNotFound is returned if no alert state is stored for a rule group
+	ErrAlertStateNotFound = errors.New("alert state does not exist")
+	// ErrBackfillStatusNotFound is returned if no backfill status is stored for a rule group
+	ErrBackfillStatusNotFound = errors.New("backfill status does not exist")
 )
 
 // RuleStore is used to store and retrieve rules.
@@ -45,3 +50,55 @@ type RuleStore interface {
 	// If namespace is empty, deletes all rule groups for user.
 	DeleteNamespace(ctx context.Context, userID, namespace string) error
 }
+
+// AlertStateStore is used to persist the state of the active alerts of the rule groups,
+// so that the `for` state of the alerts can be restored by the ruler evaluating them next.
+type AlertStateStore interface {
+	// ListAlertStates returns the alert states of all the rule groups of a user.
+	ListAlertStates(ctx context.Context, userID string) ([]*AlertStateDesc, error)
+
+	// SetAlertState stores the alert state of a rule group, replacing the previous one.
+	SetAlertState(ctx context.Context, userID string, state *AlertStateDesc) error
+
+	// DeleteAlertState deletes the alert state of a rule group.
+	DeleteAlertState(ctx context.Context, userID, namespace, group string) error
+}
+
+// AlertStateDesc is the state of the active alerts of a rule group at an evaluation.
+type AlertStateDesc struct {
+	Namespace   string            `json:"namespace"`
+	Group       string            `json:"group"`
+	EvaluatedAt time.Time         `json:"evaluated_at"`
+	Alerts      []ActiveAlertDesc `json:"alerts"`
+}
+
+// ActiveAlertDesc is a pending or firing alert.
+type ActiveAlertDesc struct {
+	Labels   map[string]string `json:"labels"`
+	ActiveAt time.Time         `json:"active_at"`
+}
+
+// BackfillStatusStore is used to persist the status of the rule group backfills,
+// so that it is known by all the rulers and not only by the one running them.
+type BackfillStatusStore interface {
+	// GetBackfillStatus returns the status of the last backfill of a rule group.
+	GetBackfillStatus(ctx context.Context, userID, namespace, group string) (*BackfillStatusDesc, error)
+
+	// SetBackfillStatus stores the status of a rule group backfill, replacing the previous one.
+	SetBackfillStatus(ctx context.Context, userID string, status *BackfillStatusDesc) error
+}
+
+// BackfillStatusDesc is the status of a rule group backfill.
+type BackfillStatusDesc struct {
+	Namespace   string    `json:"namespace"`
+	Group       string    `json:"group"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	State       string    `json:"state"`
+	Evaluations int       `json:"evaluations"`
+	Samples     int       `json:"samples"`
+	Error       string    `json:"error,omitempty"`
+	// UpdatedAt is when the ruler running the backfill last stored its status.
+	UpdatedAt  time.Time `json:"updated_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
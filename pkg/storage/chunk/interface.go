@@ -48,7 +48,8 @@ type Data interface {
 	Encoding() Encoding
 	// Rewrite rewrites the chunk after filtering out lines based on response from filter.Func.
 	// Filter.Func would be called for each log entry, and the ones for which it returns true would be removed.
-	Rewrite(filter filter.Func) (Data, error)
+	// If set, filter.RewriteFunc would then be called for each remaining log entry to rewrite it.
+	Rewrite(filter filter.Func, rewrite filter.RewriteFunc) (Data, error)
 	// Size returns the approximate length of the chunk in bytes.
 	Size() int
 	// UncompressedSize returns the length of uncompressed bytes.
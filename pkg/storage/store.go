@@ -27,6 +27,7 @@ import (
 	"github.com/grafana/loki/v3/pkg/iter"
 	"github.com/grafana/loki/v3/pkg/logproto"
 	"github.com/grafana/loki/v3/pkg/logql"
+	"github.com/grafana/loki/v3/pkg/logql/lookup"
 	"github.com/grafana/loki/v3/pkg/logqlmodel/stats"
 	"github.com/grafana/loki/v3/pkg/querier/astmapper"
 	"github.com/grafana/loki/v3/pkg/storage/chunk"
@@ -69,6 +70,8 @@ type Instrumentable interface {
 	SetExtractorWrapper(wrapper lokilog.SampleExtractorWrapper)
 
 	SetPipelineWrapper(wrapper lokilog.PipelineWrapper)
+
+	SetLookupTables(tables lokilog.LookupTables)
 }
 
 type Store interface {
@@ -101,6 +104,7 @@ type LokiStore struct {
 	chunkFilterer               chunk.RequestChunkFilterer
 	extractorWrapper            lokilog.SampleExtractorWrapper
 	pipelineWrapper             lokilog.PipelineWrapper
+	lookupTables                lokilog.LookupTables
 	congestionControllerFactory func(cfg congestion.Config, logger log.Logger, metrics *congestion.Metrics) congestion.Controller
 
 	metricsNamespace string
@@ -389,6 +393,10 @@ func (s *LokiStore) SetPipelineWrapper(wrapper lokilog.PipelineWrapper) {
 	s.pipelineWrapper = wrapper
 }
 
+func (s *LokiStore) SetLookupTables(tables lokilog.LookupTables) {
+	s.lookupTables = tables
+}
+
 // lazyChunks is an internal function used to resolve a set of lazy chunks from the store without actually loading them.
 func (s *LokiStore) lazyChunks(
 	ctx context.Context,
@@ -497,6 +505,10 @@ func (s *LokiStore) SelectLogs(ctx context.Context, req logql.SelectLogParams) (
 		return nil, err
 	}
 
+	if err := lookup.ResolveTables(ctx, expr, s.lookupTables); err != nil {
+		return nil, err
+	}
+
 	pipeline, err := expr.Pipeline()
 	if err != nil {
 		return nil, err
@@ -544,6 +556,10 @@ func (s *LokiStore) SelectSamples(ctx context.Context, req logql.SelectSamplePar
 		return nil, err
 	}
 
+	if err := lookup.ResolveTables(ctx, expr, s.lookupTables); err != nil {
+		return nil, err
+	}
+
 	extractors, err := expr.Extractors()
 	if err != nil {
 		return nil, err
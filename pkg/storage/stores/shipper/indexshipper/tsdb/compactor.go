@@ -316,6 +316,8 @@ func (c *compactedIndex) ForEachSeries(ctx context.Context, callback retention.S
 				ChunkID: schemaCfg.ExternalKey(logprotoChunkRef),
 				From:    logprotoChunkRef.From,
 				Through: logprotoChunkRef.Through,
+				KB:      chk.KB,
+				Entries: chk.Entries,
 			})
 		}
 		if ctx.Err() != nil {
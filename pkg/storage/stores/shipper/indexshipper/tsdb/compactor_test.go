@@ -633,6 +633,8 @@ func chunkMetasToRetentionChunk(schemaCfg config.SchemaConfig, userID string, lb
 			ChunkID: schemaCfg.ExternalKey(chunkMetaToChunkRef(userID, chunkMeta, lbls)),
 			From:    chunkMeta.From(),
 			Through: chunkMeta.Through(),
+			KB:      chunkMeta.KB,
+			Entries: chunkMeta.Entries,
 		})
 	}
 
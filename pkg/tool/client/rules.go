@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -108,3 +110,54 @@ func (r *LokiClient) ListRules(ctx context.Context, namespace string) (map[strin
 
 	return ruleSet, nil
 }
+
+// BackfillStatus is the status of a rule group backfill
+type BackfillStatus struct {
+	Namespace   string    `json:"namespace"`
+	Group       string    `json:"group"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	State       string    `json:"state"`
+	Evaluations int       `json:"evaluations"`
+	Samples     int       `json:"samples"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// BackfillRuleGroup starts evaluating the recording rules of a rule group over a historical range
+func (r *LokiClient) BackfillRuleGroup(ctx context.Context, namespace, groupName, start, end string) (*BackfillStatus, error) {
+	params := url.Values{}
+	params.Set("start", start)
+	if end != "" {
+		params.Set("end", end)
+	}
+	return r.backfillRequest(ctx, namespace, groupName, "POST", "?"+params.Encode())
+}
+
+// GetBackfillStatus returns the status of the last backfill of a rule group
+func (r *LokiClient) GetBackfillStatus(ctx context.Context, namespace, groupName string) (*BackfillStatus, error) {
+	return r.backfillRequest(ctx, namespace, groupName, "GET", "")
+}
+
+func (r *LokiClient) backfillRequest(ctx context.Context, namespace, groupName, method, query string) (*BackfillStatus, error) {
+	escapedNamespace := url.PathEscape(namespace)
+	escapedGroupName := url.PathEscape(groupName)
+	path := r.apiPath + "/" + escapedNamespace + "/" + escapedGroupName + "/backfill" + query
+
+	res, err := r.doRequest(ctx, path, method, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	status := BackfillStatus{}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal response")
+	}
+
+	return &status, nil
+}
@@ -74,3 +74,42 @@ func TestLokiClient(t *testing.T) {
 	}
 
 }
+
+func TestLokiClient_BackfillRuleGroup(t *testing.T) {
+	requestCh := make(chan *http.Request, 2)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCh <- r
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintln(w, `{"namespace":"My/Namespace","group":"my-name","state":"running"}`)
+			return
+		}
+		fmt.Fprintln(w, `{"namespace":"My/Namespace","group":"my-name","state":"done","evaluations":25,"samples":50}`)
+	}))
+	defer ts.Close()
+
+	client, err := New(Config{
+		Address: ts.URL,
+		ID:      "my-id",
+	})
+	require.NoError(t, err)
+
+	status, err := client.BackfillRuleGroup(context.Background(), "My/Namespace", "my-name", "2024-01-01T00:00:00Z", "")
+	require.NoError(t, err)
+	require.Equal(t, &BackfillStatus{Namespace: "My/Namespace", Group: "my-name", State: "running"}, status)
+
+	req := <-requestCh
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, "/api/v1/rules/My%2FNamespace/my-name/backfill", req.URL.EscapedPath())
+	require.Equal(t, "2024-01-01T00:00:00Z", req.URL.Query().Get("start"))
+	require.False(t, req.URL.Query().Has("end"))
+
+	status, err = client.GetBackfillStatus(context.Background(), "My/Namespace", "my-name")
+	require.NoError(t, err)
+	require.Equal(t, &BackfillStatus{Namespace: "My/Namespace", Group: "my-name", State: "done", Evaluations: 25, Samples: 50}, status)
+
+	req = <-requestCh
+	require.Equal(t, http.MethodGet, req.Method)
+	require.Equal(t, "/api/v1/rules/My%2FNamespace/my-name/backfill", req.URL.EscapedPath())
+}
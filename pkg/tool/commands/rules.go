@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/pkg/errors"
@@ -83,6 +84,15 @@ type RuleCommand struct {
 
 	// Diff Rules Config
 	Verbose bool
+
+	// Backfill Rules Config
+	BackfillStart        string
+	BackfillEnd          string
+	BackfillWait         bool
+	BackfillPollInterval time.Duration
+
+	// Unit Test Rules Config
+	TestFilesList []string
 }
 
 // Register rule related commands and flags with the kingpin application
@@ -123,9 +133,15 @@ func (r *RuleCommand) Register(app *kingpin.Application) {
 	checkCmd := rulesCmd.
 		Command("check", "runs various best practice checks against rules.").
 		Action(r.checkRecordingRuleNames)
+	backfillCmd := rulesCmd.
+		Command("backfill", "Evaluate the recording rules of a rulegroup over a historical range and send the results to the ruler remote-write clients.").
+		Action(r.backfillRuleGroup)
+	testCmd := rulesCmd.
+		Command("test", "Unit tests the rules by evaluating them over the input log streams of test files, and comparing their outputs to the expected alerts and samples.").
+		Action(r.testRules)
 
 	// Require Loki cluster address and tentant ID on all these commands
-	for _, c := range []*kingpin.CmdClause{listCmd, printRulesCmd, getRuleGroupCmd, deleteRuleGroupCmd, loadRulesCmd, diffRulesCmd, syncRulesCmd} {
+	for _, c := range []*kingpin.CmdClause{listCmd, printRulesCmd, getRuleGroupCmd, deleteRuleGroupCmd, loadRulesCmd, diffRulesCmd, syncRulesCmd, backfillCmd} {
 		c.Flag("address", "Address of the loki cluster, alternatively set LOKI_ADDRESS.").
 			Envar("LOKI_ADDRESS").
 			Required().
@@ -170,6 +186,14 @@ func (r *RuleCommand) Register(app *kingpin.Application) {
 	deleteRuleGroupCmd.Arg("namespace", "Namespace of the rulegroup to delete.").Required().StringVar(&r.Namespace)
 	deleteRuleGroupCmd.Arg("group", "Name of the rulegroup ot delete.").Required().StringVar(&r.RuleGroup)
 
+	// Backfill RuleGroup Command
+	backfillCmd.Arg("namespace", "Namespace of the rulegroup to backfill.").Required().StringVar(&r.Namespace)
+	backfillCmd.Arg("group", "Name of the rulegroup to backfill.").Required().StringVar(&r.RuleGroup)
+	backfillCmd.Flag("start", "Start of the range to backfill, as a RFC3339 or unix timestamp.").Required().StringVar(&r.BackfillStart)
+	backfillCmd.Flag("end", "End of the range to backfill, as a RFC3339 or unix timestamp. Defaults to now.").StringVar(&r.BackfillEnd)
+	backfillCmd.Flag("wait", "Wait for the backfill to finish.").Default("true").BoolVar(&r.BackfillWait)
+	backfillCmd.Flag("poll-interval", "How often the status of the backfill is checked while waiting for it to finish.").Default("10s").DurationVar(&r.BackfillPollInterval)
+
 	// Load Rules Command
 	loadRulesCmd.Arg("rule-files", "The rule files to check.").Required().ExistingFilesVar(&r.RuleFilesList)
 
@@ -227,6 +251,9 @@ func (r *RuleCommand) Register(app *kingpin.Application) {
 	).StringVar(&r.RuleFilesPath)
 	checkCmd.Flag("strict", "fails rules checks that do not match best practices exactly").BoolVar(&r.Strict)
 
+	// Test Command
+	testCmd.Arg("test-files", "The unit test files to run.").Required().ExistingFilesVar(&r.TestFilesList)
+
 	// List Command
 	listCmd.Flag("format", "Backend type to interact with: <json|yaml|table>").Default("table").EnumVar(&r.Format, formats...)
 	listCmd.Flag("disable-color", "disable colored output").BoolVar(&r.DisableColor)
@@ -374,6 +401,44 @@ func (r *RuleCommand) deleteRuleGroup(_ *kingpin.ParseContext) error {
 	return nil
 }
 
+func (r *RuleCommand) backfillRuleGroup(_ *kingpin.ParseContext) error {
+	ctx := context.Background()
+	status, err := r.cli.BackfillRuleGroup(ctx, r.Namespace, r.RuleGroup, r.BackfillStart, r.BackfillEnd)
+	if err != nil {
+		if err == client.ErrResourceNotFound {
+			log.Infof("this rule group does not currently exist")
+			return nil
+		}
+		log.Fatalf("unable to backfill rule group, %v", err)
+	}
+	log.WithFields(log.Fields{
+		"start": status.Start,
+		"end":   status.End,
+	}).Infof("rule group backfill started")
+	if !r.BackfillWait {
+		return nil
+	}
+
+	// the status of the backfill is only known by the ruler running it
+	for status.State == "running" {
+		time.Sleep(r.BackfillPollInterval)
+		status, err = r.cli.GetBackfillStatus(ctx, r.Namespace, r.RuleGroup)
+		if err != nil {
+			log.Fatalf("unable to get the rule group backfill status, %v", err)
+		}
+	}
+
+	fields := log.Fields{
+		"evaluations": status.Evaluations,
+		"samples":     status.Samples,
+	}
+	if status.State != "done" {
+		log.WithFields(fields).Fatalf("rule group backfill failed, %s", status.Error)
+	}
+	log.WithFields(fields).Infof("rule group backfilled")
+	return nil
+}
+
 func (r *RuleCommand) loadRules(_ *kingpin.ParseContext) error {
 	nss, err := rules.ParseFiles(r.RuleFilesList)
 	if err != nil {
@@ -720,6 +785,30 @@ func (r *RuleCommand) checkRecordingRuleNames(_ *kingpin.ParseContext) error {
 	return nil
 }
 
+func (r *RuleCommand) testRules(_ *kingpin.ParseContext) error {
+	var failed int
+	for _, file := range r.TestFilesList {
+		fmt.Printf("Unit Testing: %s\n", file)
+
+		errs := rules.RunUnitTests(file)
+		if len(errs) == 0 {
+			fmt.Println("  SUCCESS")
+			continue
+		}
+
+		failed++
+		fmt.Println("  FAILED:")
+		for _, err := range errs {
+			fmt.Printf("    %s\n", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d unit test file(s) failed", failed)
+	}
+	return nil
+}
+
 // Taken from https://github.com/prometheus/prometheus/blob/8c8de46003d1800c9d40121b4a5e5de8582ef6e1/cmd/promtool/main.go#L403
 type compareRuleType struct {
 	metric string
@@ -0,0 +1,442 @@
+package rules
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/dskit/user"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/metadata"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logql"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/ruler"
+)
+
+const unitTestOrgID = "lokitool"
+
+// UnitTestFile is the format of the files of rule unit tests, modeled after
+// the promtool ones with log streams as inputs.
+type UnitTestFile struct {
+	RuleFiles          []string        `yaml:"rule_files"`
+	EvaluationInterval model.Duration  `yaml:"evaluation_interval,omitempty"`
+	Tests              []UnitTestGroup `yaml:"tests"`
+}
+
+// UnitTestGroup is a set of input log streams and of expected rule outputs.
+type UnitTestGroup struct {
+	Name               string              `yaml:"name,omitempty"`
+	InputStreams       []InputStream       `yaml:"input_streams"`
+	AlertRuleTests     []AlertTestCase     `yaml:"alert_rule_test,omitempty"`
+	RecordingRuleTests []RecordingTestCase `yaml:"recording_rule_test,omitempty"`
+}
+
+// InputStream is a log stream the rules are evaluated over.
+type InputStream struct {
+	Labels  string       `yaml:"labels"`
+	Entries []InputEntry `yaml:"entries"`
+}
+
+// InputEntry is a log line, with its timestamp relative to the start of the test.
+type InputEntry struct {
+	Timestamp model.Duration `yaml:"ts"`
+	Line      string         `yaml:"line"`
+}
+
+// AlertTestCase lists the alerts expected to be firing at a given time.
+type AlertTestCase struct {
+	EvalTime  model.Duration `yaml:"eval_time"`
+	Alertname string         `yaml:"alertname"`
+	ExpAlerts []Alert        `yaml:"exp_alerts"`
+}
+
+// Alert is an expected alert, the alertname label is added from the test case.
+type Alert struct {
+	ExpLabels      map[string]string `yaml:"exp_labels"`
+	ExpAnnotations map[string]string `yaml:"exp_annotations"`
+}
+
+// RecordingTestCase lists the samples expected to be recorded by a rule at a
+// given time.
+type RecordingTestCase struct {
+	EvalTime   model.Duration `yaml:"eval_time"`
+	Record     string         `yaml:"record"`
+	ExpSamples []Sample       `yaml:"exp_samples"`
+}
+
+// Sample is an expected sample, the metric name is taken from the test case.
+type Sample struct {
+	Labels string  `yaml:"labels"`
+	Value  float64 `yaml:"value"`
+}
+
+// RunUnitTests runs the rule unit tests of the given file and returns the
+// failures.
+func RunUnitTests(filename string) []error {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return []error{err}
+	}
+
+	var file UnitTestFile
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return []error{errors.Wrap(err, "unable to parse unit tests file")}
+	}
+	if file.EvaluationInterval == 0 {
+		file.EvaluationInterval = model.Duration(time.Minute)
+	}
+
+	// rule files are relative to the test file
+	ruleFiles := make([]string, 0, len(file.RuleFiles))
+	for _, f := range file.RuleFiles {
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(filepath.Dir(filename), f)
+		}
+		ruleFiles = append(ruleFiles, f)
+	}
+	namespaces, err := ParseFiles(ruleFiles)
+	if err != nil {
+		return []error{errors.Wrap(err, "unable to parse rules files")}
+	}
+
+	var errs []error
+	for _, test := range file.Tests {
+		for _, err := range test.run(namespaces, time.Duration(file.EvaluationInterval)) {
+			if test.Name != "" {
+				err = errors.Wrapf(err, "name: %s", test.Name)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (tg UnitTestGroup) run(namespaces map[string]RuleNamespace, evalInterval time.Duration) []error {
+	streams, err := tg.streams()
+	if err != nil {
+		return []error{err}
+	}
+
+	engine := logql.NewEngine(logql.EngineOpts{}, logql.NewMockQuerier(0, streams), logql.NoLimits, nil)
+	app := &recordingAppendable{}
+	opts := &rules.ManagerOptions{
+		QueryFunc:  unitTestQueryFunc(engine),
+		NotifyFunc: func(context.Context, string, ...*rules.Alert) {},
+		Appendable: app,
+		Context:    context.Background(),
+		Logger:     promslog.NewNopLogger(),
+	}
+	groups, err := unitTestGroups(namespaces, evalInterval, opts)
+	if err != nil {
+		return []error{err}
+	}
+
+	alertTests := append([]AlertTestCase(nil), tg.AlertRuleTests...)
+	sort.SliceStable(alertTests, func(i, j int) bool { return alertTests[i].EvalTime < alertTests[j].EvalTime })
+	recordingTests := append([]RecordingTestCase(nil), tg.RecordingRuleTests...)
+	sort.SliceStable(recordingTests, func(i, j int) bool { return recordingTests[i].EvalTime < recordingTests[j].EvalTime })
+
+	var maxEvalTime time.Duration
+	if len(alertTests) > 0 {
+		maxEvalTime = time.Duration(alertTests[len(alertTests)-1].EvalTime)
+	}
+	if len(recordingTests) > 0 {
+		maxEvalTime = max(maxEvalTime, time.Duration(recordingTests[len(recordingTests)-1].EvalTime))
+	}
+
+	var errs []error
+	for offset := time.Duration(0); offset <= maxEvalTime; offset += evalInterval {
+		ts := time.Unix(0, 0).UTC().Add(offset)
+		for _, g := range groups {
+			if offset%g.Interval() == 0 {
+				g.Eval(opts.Context, ts)
+			}
+		}
+
+		// the test cases are checked against the last evaluation at or before
+		// their evaluation time
+		for len(alertTests) > 0 && time.Duration(alertTests[0].EvalTime) < offset+evalInterval {
+			if err := alertTests[0].check(groups); err != nil {
+				errs = append(errs, err)
+			}
+			alertTests = alertTests[1:]
+		}
+		for len(recordingTests) > 0 && time.Duration(recordingTests[0].EvalTime) < offset+evalInterval {
+			if err := recordingTests[0].check(app.samples); err != nil {
+				errs = append(errs, err)
+			}
+			recordingTests = recordingTests[1:]
+		}
+	}
+
+	for _, g := range groups {
+		for _, r := range g.Rules() {
+			if err := r.LastError(); err != nil {
+				errs = append(errs, errors.Wrapf(err, "rule %s", r.Name()))
+			}
+		}
+	}
+	return errs
+}
+
+func (tg UnitTestGroup) streams() ([]logproto.Stream, error) {
+	streams := make([]logproto.Stream, 0, len(tg.InputStreams))
+	for _, s := range tg.InputStreams {
+		lbls, err := syntax.ParseLabels(s.Labels)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid input stream labels %q", s.Labels)
+		}
+
+		stream := logproto.Stream{Labels: lbls.String()}
+		for _, e := range s.Entries {
+			stream.Entries = append(stream.Entries, logproto.Entry{
+				Timestamp: time.Unix(0, 0).UTC().Add(time.Duration(e.Timestamp)),
+				Line:      e.Line,
+			})
+		}
+		sort.SliceStable(stream.Entries, func(i, j int) bool {
+			return stream.Entries[i].Timestamp.Before(stream.Entries[j].Timestamp)
+		})
+		streams = append(streams, stream)
+	}
+	return streams, nil
+}
+
+// unitTestGroups builds the rule groups of the namespaces the same way the
+// ruler rules manager does.
+func unitTestGroups(namespaces map[string]RuleNamespace, evalInterval time.Duration, opts *rules.ManagerOptions) ([]*rules.Group, error) {
+	var loader ruler.GroupLoader
+	var groups []*rules.Group
+	for _, ns := range namespaces {
+		for _, rg := range ns.Groups {
+			interval := evalInterval
+			if rg.Interval != 0 {
+				interval = time.Duration(rg.Interval)
+			}
+			if interval%evalInterval != 0 {
+				return nil, fmt.Errorf("the interval of the rule group %s must be a multiple of the evaluation interval", rg.Name)
+			}
+
+			var rls []rules.Rule
+			for _, r := range rg.Rules {
+				expr, err := loader.Parse(r.Expr)
+				if err != nil {
+					return nil, errors.Wrapf(err, "rule group %s", rg.Name)
+				}
+
+				lbls := rules.FromMaps(rg.Labels, r.Labels)
+				if r.Alert != "" {
+					rls = append(rls, rules.NewAlertingRule(
+						r.Alert, expr, time.Duration(r.For), time.Duration(r.KeepFiringFor),
+						lbls, labels.FromMap(r.Annotations), labels.EmptyLabels(), "", true, opts.Logger,
+					))
+					continue
+				}
+				rls = append(rls, rules.NewRecordingRule(r.Record, expr, lbls))
+			}
+
+			groups = append(groups, rules.NewGroup(rules.GroupOptions{
+				Name:     rg.Name,
+				File:     ns.Namespace,
+				Interval: interval,
+				Limit:    rg.Limit,
+				Rules:    rls,
+				Opts:     opts,
+			}))
+		}
+	}
+	return groups, nil
+}
+
+func unitTestQueryFunc(engine *logql.QueryEngine) rules.QueryFunc {
+	return func(ctx context.Context, qs string, t time.Time) (promql.Vector, error) {
+		params, err := logql.NewLiteralParams(qs, t, t, 0, 0, logproto.FORWARD, 0, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := engine.Query(params).Exec(user.InjectOrgID(ctx, unitTestOrgID))
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := res.Data.(type) {
+		case promql.Vector:
+			return v, nil
+		case promql.Scalar:
+			return promql.Vector{promql.Sample{T: v.T, F: v.V, Metric: labels.EmptyLabels()}}, nil
+		default:
+			return nil, errors.New("rule result is not a vector or scalar")
+		}
+	}
+}
+
+func (tc AlertTestCase) check(groups []*rules.Group) error {
+	var got []string
+	for _, g := range groups {
+		for _, r := range g.Rules() {
+			ar, ok := r.(*rules.AlertingRule)
+			if !ok || ar.Name() != tc.Alertname {
+				continue
+			}
+			for _, a := range ar.ActiveAlerts() {
+				if a.State == rules.StateFiring {
+					got = append(got, alertString(a.Labels, a.Annotations))
+				}
+			}
+		}
+	}
+
+	exp := make([]string, 0, len(tc.ExpAlerts))
+	for _, a := range tc.ExpAlerts {
+		lbls := labels.NewBuilder(labels.FromMap(a.ExpLabels)).Set(labels.AlertName, tc.Alertname).Labels()
+		exp = append(exp, alertString(lbls, labels.FromMap(a.ExpAnnotations)))
+	}
+
+	sort.Strings(got)
+	sort.Strings(exp)
+	if strings.Join(got, "\n") != strings.Join(exp, "\n") {
+		return fmt.Errorf("alertname: %s, time: %s,\n        exp:%s,\n        got:%s", tc.Alertname, tc.EvalTime, listString(exp), listString(got))
+	}
+	return nil
+}
+
+func (tc RecordingTestCase) check(samples []promql.Sample) error {
+	evalTime := time.Unix(0, 0).UTC().Add(time.Duration(tc.EvalTime)).UnixMilli()
+
+	// the samples of the last evaluation of the rule
+	var last int64 = math.MinInt64
+	for _, s := range samples {
+		if s.Metric.Get(labels.MetricName) == tc.Record && s.T <= evalTime {
+			last = max(last, s.T)
+		}
+	}
+	got := map[string]float64{}
+	for _, s := range samples {
+		if s.Metric.Get(labels.MetricName) != tc.Record || s.T != last || value.IsStaleNaN(s.F) {
+			continue
+		}
+		got[labels.NewBuilder(s.Metric).Del(labels.MetricName).Labels().String()] = s.F
+	}
+
+	exp := map[string]float64{}
+	for _, s := range tc.ExpSamples {
+		lbls, err := syntax.ParseLabels(s.Labels)
+		if err != nil {
+			return errors.Wrapf(err, "record: %s, time: %s, invalid expected sample labels %q", tc.Record, tc.EvalTime, s.Labels)
+		}
+		exp[lbls.String()] = s.Value
+	}
+
+	equal := len(got) == len(exp)
+	for lbls, v := range exp {
+		if g, ok := got[lbls]; !ok || !almostEqual(g, v) {
+			equal = false
+		}
+	}
+	if !equal {
+		return fmt.Errorf("record: %s, time: %s,\n        exp:%s,\n        got:%s", tc.Record, tc.EvalTime, samplesString(exp), samplesString(got))
+	}
+	return nil
+}
+
+func alertString(lbls, annotations labels.Labels) string {
+	return fmt.Sprintf("labels: %s, annotations: %s", lbls, annotations)
+}
+
+func samplesString(samples map[string]float64) string {
+	res := make([]string, 0, len(samples))
+	for lbls, v := range samples {
+		res = append(res, fmt.Sprintf("%s %v", lbls, v))
+	}
+	sort.Strings(res)
+	return listString(res)
+}
+
+func listString(l []string) string {
+	if len(l) == 0 {
+		return " []"
+	}
+	return "\n            " + strings.Join(l, "\n            ")
+}
+
+func almostEqual(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	const epsilon = 1e-6
+	return a == b || math.Abs(a-b) <= epsilon*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// recordingAppendable keeps the samples appended by the rule evaluations.
+type recordingAppendable struct {
+	samples []promql.Sample
+}
+
+func (a *recordingAppendable) Appender(context.Context) storage.Appender {
+	return &recordingAppender{app: a}
+}
+
+// recordingAppender only records float samples, the only samples produced by
+// LogQL rules. The other kinds of samples and the metadata are ignored.
+type recordingAppender struct {
+	app     *recordingAppendable
+	pending []promql.Sample
+}
+
+func (a *recordingAppender) SetOptions(*storage.AppendOptions) {}
+
+func (a *recordingAppender) Append(ref storage.SeriesRef, l labels.Labels, t int64, v float64) (storage.SeriesRef, error) {
+	a.pending = append(a.pending, promql.Sample{Metric: l, T: t, F: v})
+	return ref, nil
+}
+
+func (a *recordingAppender) Commit() error {
+	a.app.samples = append(a.app.samples, a.pending...)
+	a.pending = nil
+	return nil
+}
+
+func (a *recordingAppender) Rollback() error {
+	a.pending = nil
+	return nil
+}
+
+func (a *recordingAppender) AppendExemplar(ref storage.SeriesRef, _ labels.Labels, _ exemplar.Exemplar) (storage.SeriesRef, error) {
+	return ref, nil
+}
+
+func (a *recordingAppender) AppendHistogram(ref storage.SeriesRef, _ labels.Labels, _ int64, _ *histogram.Histogram, _ *histogram.FloatHistogram) (storage.SeriesRef, error) {
+	return ref, nil
+}
+
+func (a *recordingAppender) AppendHistogramCTZeroSample(ref storage.SeriesRef, _ labels.Labels, _ int64, _ int64, _ *histogram.Histogram, _ *histogram.FloatHistogram) (storage.SeriesRef, error) {
+	return ref, nil
+}
+
+func (a *recordingAppender) UpdateMetadata(ref storage.SeriesRef, _ labels.Labels, _ metadata.Metadata) (storage.SeriesRef, error) {
+	return ref, nil
+}
+
+func (a *recordingAppender) AppendCTZeroSample(ref storage.SeriesRef, _ labels.Labels, _ int64, _ int64) (storage.SeriesRef, error) {
+	return ref, nil
+}
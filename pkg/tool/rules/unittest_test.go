@@ -0,0 +1,17 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunUnitTests(t *testing.T) {
+	require.Empty(t, RunUnitTests("testdata/unittest.yaml"))
+
+	errs := RunUnitTests("testdata/unittest_failure.yaml")
+	require.Len(t, errs, 2)
+	require.ErrorContains(t, errs[0], "name: wrong expectations: record: job:errors:count5m, time: 1m")
+	require.ErrorContains(t, errs[0], `{job="app"} 1`)
+	require.ErrorContains(t, errs[1], "name: wrong expectations: alertname: HighErrorRate, time: 5m")
+}
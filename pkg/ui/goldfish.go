@@ -93,6 +93,9 @@ type SampledQuery struct {
 	// Comparison outcome - computed by backend logic
 	ComparisonStatus string `json:"comparisonStatus" db:"comparison_status"`
 
+	// Semantic diff of the results - only set when the results of both cells differ
+	ResultDiff *goldfish.ResultDiff `json:"resultDiff,omitempty"`
+
 	// UI-only fields - generated based on configuration, not stored in database
 	CellATraceLink *string `json:"cellATraceLink,omitempty"`
 	CellBTraceLink *string `json:"cellBTraceLink,omitempty"`
@@ -240,6 +243,8 @@ func (s *Service) GetSampledQueriesWithContext(ctx context.Context, page, pageSi
 			CellBSpanID:        strPtr(q.CellBSpanID),
 			CellAUsedNewEngine: q.CellAUsedNewEngine,
 			CellBUsedNewEngine: q.CellBUsedNewEngine,
+
+			ResultDiff: q.ResultDiff,
 		}
 
 		// Determine comparison status based on response codes and hashes
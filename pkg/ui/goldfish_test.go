@@ -332,6 +332,33 @@ func TestGoldfishQueriesHandler_ReturnsTraceIDs(t *testing.T) {
 	assert.Equal(t, "trace-b-1", *response.Queries[0].CellBTraceID)
 }
 
+func TestGoldfishQueriesHandler_ReturnsResultDiff(t *testing.T) {
+	query := createTestQuerySample("1", "tenant1", 200, 200, "hash1", "hash2")
+	query.ResultDiff = &goldfish.ResultDiff{
+		ResultType:    "streams",
+		MissingSeries: []string{`{job="a"}`},
+	}
+	storage := &mockStorage{
+		queries: []goldfish.QuerySample{query},
+	}
+
+	service := createTestService(storage)
+
+	handler := service.goldfishQueriesHandler()
+
+	req := httptest.NewRequest("GET", "/api/v1/goldfish/queries", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response GoldfishAPIResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Queries, 1)
+	assert.Equal(t, query.ResultDiff, response.Queries[0].ResultDiff)
+}
+
 func TestGoldfishQueriesHandler_ErrorCases(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -27,6 +27,7 @@ const (
 	analyticsPath   = prefixPath + "/api/v1/analytics"
 	featuresPath    = prefixPath + "/api/v1/features"
 	goldfishPath    = prefixPath + "/api/v1/goldfish/queries"
+	usagePath       = prefixPath + "/api/v1/ingest-limits/usage/{tenant}"
 	notFoundPath    = prefixPath + "/api/v1/404"
 	contentTypeJSON = "application/json"
 )
@@ -47,6 +48,7 @@ func (s *Service) RegisterHandler() {
 	s.router.Path(detailsPath).Handler(s.detailsHandler())
 	s.router.Path(featuresPath).Handler(s.featuresHandler())
 	s.router.Path(goldfishPath).Handler(s.goldfishQueriesHandler())
+	s.router.Path(usagePath).Handler(s.ingestLimitsUsageHandler())
 
 	s.router.PathPrefix(proxyPath).Handler(s.clusterProxyHandler())
 	s.router.PathPrefix(notFoundPath).Handler(s.notFoundHandler())
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/go-kit/log/level"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ingestLimitsFrontendService is the name of the ingest limits frontend
+	// service as listed by the /services endpoint.
+	ingestLimitsFrontendService = "ingest-limits-frontend"
+	ingestLimitsUsageEndpoint   = "/ingest-limits-frontend/usage/"
+)
+
+var errNoIngestLimitsFrontend = errors.New("no running ingest-limits-frontend found in the cluster")
+
+// ingestLimitsUsageHandler returns the usage of a tenant, as tracked by the
+// ingest limits service. The request is forwarded to a cluster member
+// running the ingest limits frontend, which aggregates the usage across all
+// ingest limits instances.
+func (s *Service) ingestLimitsUsageHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := mux.Vars(r)["tenant"]
+		if tenant == "" {
+			s.writeJSONError(w, http.StatusBadRequest, "tenant is required")
+			return
+		}
+		resp, err := s.fetchIngestLimitsUsage(r.Context(), s.discoverInstances(), tenant, r.URL.RawQuery)
+		if err != nil {
+			level.Error(s.logger).Log("msg", "failed to fetch ingest limits usage", "tenant", tenant, "err", err)
+			if errors.Is(err, errNoIngestLimitsFrontend) {
+				s.writeJSONError(w, http.StatusServiceUnavailable, err.Error())
+				return
+			}
+			s.writeJSONError(w, http.StatusBadGateway, "failed to fetch ingest limits usage")
+			return
+		}
+		defer resp.Body.Close()
+		w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+		w.WriteHeader(resp.StatusCode)
+		if _, err := io.Copy(w, resp.Body); err != nil {
+			level.Error(s.logger).Log("msg", "failed to copy ingest limits usage response", "err", err)
+		}
+	})
+}
+
+// fetchIngestLimitsUsage requests the usage of the tenant from the first
+// instance, ordered by instance ID, that runs the ingest limits frontend.
+func (s *Service) fetchIngestLimitsUsage(ctx context.Context, instances map[string]string, tenant, rawQuery string) (*http.Response, error) {
+	addr, err := s.findIngestLimitsFrontend(ctx, instances)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := ingestLimitsUsageEndpoint + url.PathEscape(tenant)
+	if rawQuery != "" {
+		endpoint += "?" + rawQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.buildDownstreamPath(addr, endpoint), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	return resp, nil
+}
+
+// findIngestLimitsFrontend returns the address of an instance running the
+// ingest limits frontend.
+func (s *Service) findIngestLimitsFrontend(ctx context.Context, instances map[string]string) (string, error) {
+	ids := make([]string, 0, len(instances))
+	for id := range instances {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		services, err := s.fetchServices(ctx, instances[id])
+		if err != nil {
+			level.Debug(s.logger).Log("msg", "failed to fetch services", "instance", id, "err", err)
+			continue
+		}
+		for _, service := range services {
+			if service.Service == ingestLimitsFrontendService && service.Status == "Running" {
+				return instances[id], nil
+			}
+		}
+	}
+	return "", errNoIngestLimitsFrontend
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchIngestLimitsUsage(t *testing.T) {
+	// The querier does not run the ingest limits frontend.
+	querier := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/services", r.URL.Path)
+		_, _ = io.WriteString(w, "querier => Running\n")
+	}))
+	defer querier.Close()
+	frontend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services":
+			_, _ = io.WriteString(w, "ingest-limits-frontend => Running\nserver => Running\n")
+		case "/ingest-limits-frontend/usage/tenant-1":
+			require.Equal(t, "top=5", r.URL.RawQuery)
+			w.Header().Set("Content-Type", contentTypeJSON)
+			_, _ = io.WriteString(w, `{"tenant":"tenant-1"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer frontend.Close()
+
+	s := &Service{
+		client: http.DefaultClient,
+		logger: log.NewNopLogger(),
+	}
+
+	t.Run("forwards the request to the ingest limits frontend", func(t *testing.T) {
+		instances := map[string]string{
+			"querier-0":                strings.TrimPrefix(querier.URL, "http://"),
+			"ingest-limits-frontend-0": strings.TrimPrefix(frontend.URL, "http://"),
+		}
+		resp, err := s.fetchIngestLimitsUsage(context.Background(), instances, "tenant-1", "top=5")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"tenant":"tenant-1"}`, string(b))
+	})
+
+	t.Run("returns an error without an ingest limits frontend", func(t *testing.T) {
+		instances := map[string]string{
+			"querier-0": strings.TrimPrefix(querier.URL, "http://"),
+		}
+		_, err := s.fetchIngestLimitsUsage(context.Background(), instances, "tenant-1", "")
+		require.ErrorIs(t, err, errNoIngestLimitsFrontend)
+	})
+}
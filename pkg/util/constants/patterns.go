@@ -0,0 +1,5 @@
+package constants
+
+// DetectedPatternLabel is the name of the label holding the Drain pattern of a series
+// returned by the pattern_count_over_time range aggregation.
+const DetectedPatternLabel = "detected_pattern"
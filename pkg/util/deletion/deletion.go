@@ -51,6 +51,7 @@ func deleteFilters(deletes []*logproto.Delete) ([]log.PipelineFilter, error) {
 			End:      d.End,
 			Matchers: expr.Matchers(),
 			Pipeline: pipeline,
+			Rewrite:  d.Rewrite,
 		})
 	}
 
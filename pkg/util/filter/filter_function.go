@@ -7,3 +7,7 @@ import (
 )
 
 type Func func(ts time.Time, s string, structuredMetadata labels.Labels) bool
+
+// RewriteFunc returns the line and the structured metadata to rewrite the given log entry with,
+// and false if the entry is to be kept as is.
+type RewriteFunc func(ts time.Time, s string, structuredMetadata labels.Labels) (string, labels.Labels, bool)
@@ -8,6 +8,8 @@ import (
 	"github.com/grafana/loki/v3/pkg/distributor"
 	"github.com/grafana/loki/v3/pkg/indexgateway"
 	"github.com/grafana/loki/v3/pkg/ingester"
+	"github.com/grafana/loki/v3/pkg/limits"
+	"github.com/grafana/loki/v3/pkg/logql/lookup"
 	"github.com/grafana/loki/v3/pkg/pattern"
 	querier_limits "github.com/grafana/loki/v3/pkg/querier/limits"
 	queryrange_limits "github.com/grafana/loki/v3/pkg/querier/queryrange/limits"
@@ -21,6 +23,7 @@ type CombinedLimits interface {
 	compactor.Limits
 	distributor.Limits
 	ingester.Limits
+	limits.Limits
 	querier_limits.Limits
 	queryrange_limits.Limits
 	ruler.RulesLimits
@@ -31,5 +34,6 @@ type CombinedLimits interface {
 	bloomplanner.Limits
 	bloombuilder.Limits
 	pattern.Limits
+	lookup.Limits
 	bucket.SSEConfigProvider
 }
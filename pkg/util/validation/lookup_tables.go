@@ -0,0 +1,7 @@
+package validation
+
+// LookupTable configures a lookup table stored in object storage.
+type LookupTable struct {
+	Path   string `yaml:"path" json:"path"`
+	Format string `yaml:"format" json:"format"`
+}
@@ -24,7 +24,9 @@ import (
 	"github.com/grafana/loki/v3/pkg/distributor/shardstreams"
 	"github.com/grafana/loki/v3/pkg/loghttp/push"
 	"github.com/grafana/loki/v3/pkg/logql"
+	"github.com/grafana/loki/v3/pkg/logql/log"
 	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/grafana/loki/v3/pkg/pattern/drain"
 	ruler_config "github.com/grafana/loki/v3/pkg/ruler/config"
 	"github.com/grafana/loki/v3/pkg/ruler/util"
 	"github.com/grafana/loki/v3/pkg/storage/stores/shipper/indexshipper/tsdb/sharding"
@@ -108,6 +110,7 @@ type Limits struct {
 	// Querier enforced limits.
 	MaxChunksPerQuery          int              `yaml:"max_chunks_per_query" json:"max_chunks_per_query"`
 	MaxQuerySeries             int              `yaml:"max_query_series" json:"max_query_series"`
+	MaxJoinEntries             int              `yaml:"max_join_entries" json:"max_join_entries" category:"experimental"`
 	MaxQueryLookback           model.Duration   `yaml:"max_query_lookback" json:"max_query_lookback"`
 	MaxQueryLength             model.Duration   `yaml:"max_query_length" json:"max_query_length"`
 	MaxQueryRange              model.Duration   `yaml:"max_query_range" json:"max_query_range"`
@@ -147,6 +150,8 @@ type Limits struct {
 	RulerAlertManagerConfig     *ruler_config.AlertManagerConfig `yaml:"ruler_alertmanager_config" json:"ruler_alertmanager_config" doc:"hidden"`
 	RulerTenantShardSize        int                              `yaml:"ruler_tenant_shard_size" json:"ruler_tenant_shard_size"`
 	RulerEnableWALReplay        bool                             `yaml:"ruler_enable_wal_replay" json:"ruler_enable_wal_replay" doc:"description=Enable WAL replay on ruler startup. Disabling this can reduce memory usage on startup at the cost of not recovering in-memory WAL metrics on restart."`
+	RulerBackfillMaxConcurrency int                              `yaml:"ruler_backfill_max_concurrency" json:"ruler_backfill_max_concurrency"`
+	RulerBackfillMaxRange       model.Duration                   `yaml:"ruler_backfill_max_range" json:"ruler_backfill_max_range"`
 
 	// TODO(dannyk): add HTTP client overrides (basic auth / tls config, etc)
 	// Ruler remote-write limits.
@@ -209,6 +214,8 @@ type Limits struct {
 	RequiredLabels       []string `yaml:"required_labels,omitempty" json:"required_labels,omitempty" doc:"description=Define a list of required selector labels."`
 	RequiredNumberLabels int      `yaml:"minimum_labels_number,omitempty" json:"minimum_labels_number,omitempty" doc:"description=Minimum number of label matchers a query should contain."`
 
+	LookupTables map[string]validation.LookupTable `yaml:"lookup_tables,omitempty" json:"lookup_tables,omitempty" category:"experimental" doc:"description=Lookup tables that can be used with the LogQL lookup stage, keyed by table name. Each table is loaded from the object storage path and is either a csv file with a header row whose first column holds the keys, or a json object mapping each key to an object of labels. Example:\n lookup_tables:\n  customers:\n   path: lookup/customers.csv\n   format: csv"`
+
 	IndexGatewayShardSize int `yaml:"index_gateway_shard_size" json:"index_gateway_shard_size"`
 
 	BloomGatewayEnabled bool `yaml:"bloom_gateway_enable_filtering" json:"bloom_gateway_enable_filtering" category:"experimental"`
@@ -254,10 +261,14 @@ type Limits struct {
 	PatternIngesterTokenizableJSONFieldsDefault dskit_flagext.StringSliceCSV `yaml:"pattern_ingester_tokenizable_json_fields_default" json:"pattern_ingester_tokenizable_json_fields_default" doc:"hidden"`
 	PatternIngesterTokenizableJSONFieldsAppend  dskit_flagext.StringSliceCSV `yaml:"pattern_ingester_tokenizable_json_fields_append"  json:"pattern_ingester_tokenizable_json_fields_append"  doc:"hidden"`
 	PatternIngesterTokenizableJSONFieldsDelete  dskit_flagext.StringSliceCSV `yaml:"pattern_ingester_tokenizable_json_fields_delete"  json:"pattern_ingester_tokenizable_json_fields_delete"  doc:"hidden"`
+	PatternIngesterMasks                        dskit_flagext.StringSliceCSV `yaml:"pattern_ingester_masks"                           json:"pattern_ingester_masks"`
+	PatternIngesterTokenizer                    string                       `yaml:"pattern_ingester_tokenizer"                       json:"pattern_ingester_tokenizer"`
 	MetricAggregationEnabled                    bool                         `yaml:"metric_aggregation_enabled"                       json:"metric_aggregation_enabled"`
 	PatternPersistenceEnabled                   bool                         `yaml:"pattern_persistence_enabled"                      json:"pattern_persistence_enabled"`
 	PatternPersistenceGranularity               model.Duration               `yaml:"pattern_persistence_granularity"                  json:"pattern_persistence_granularity"`
 	PatternRateThreshold                        float64                      `yaml:"pattern_rate_threshold"                           json:"pattern_rate_threshold"`
+	PatternAnomalyDetectionEnabled              bool                         `yaml:"pattern_anomaly_detection_enabled"                json:"pattern_anomaly_detection_enabled"`
+	PatternAnomalyDeviationRatio                float64                      `yaml:"pattern_anomaly_deviation_ratio"                  json:"pattern_anomaly_deviation_ratio"`
 
 	// This config doesn't have a CLI flag registered here because they're registered in
 	// their own original config struct.
@@ -343,6 +354,7 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	_ = l.MaxQueryLength.Set("721h")
 	f.Var(&l.MaxQueryLength, "store.max-query-length", "The limit to length of chunk store queries. 0 to disable.")
 	f.IntVar(&l.MaxQuerySeries, "querier.max-query-series", 500, "Limit the maximum of unique series that is returned by a metric query. When the limit is reached an error is returned.")
+	f.IntVar(&l.MaxJoinEntries, "querier.max-join-entries", 10000, "Experimental: Limit the maximum number of log entries that are read from each side of a join query. When the limit is reached an error is returned. 0 to disable.")
 	_ = l.MaxQueryRange.Set("0s")
 	f.Var(&l.MaxQueryRange, "querier.max-query-range", "Limit the length of the [range] inside a range query. Default is 0 or unlimited")
 	_ = l.QueryTimeout.Set(DefaultPerTenantQueryTimeout)
@@ -393,6 +405,9 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&l.RulerMaxRuleGroupsPerTenant, "ruler.max-rule-groups-per-tenant", 0, "Maximum number of rule groups per-tenant. 0 to disable.")
 	f.IntVar(&l.RulerTenantShardSize, "ruler.tenant-shard-size", 0, "The default tenant's shard size when shuffle-sharding is enabled in the ruler. When this setting is specified in the per-tenant overrides, a value of 0 disables shuffle sharding for the tenant.")
 	f.BoolVar(&l.RulerEnableWALReplay, "ruler.enable-wal-replay", true, "Enable WAL replay on ruler startup. Disabling this can reduce memory usage on startup at the cost of not recovering in-memory WAL metrics on restart.")
+	f.IntVar(&l.RulerBackfillMaxConcurrency, "ruler.backfill-max-concurrency", 0, "Maximum number of rule evaluations run concurrently by the backfills of a tenant. 0 to disable backfilling for the tenant.")
+	_ = l.RulerBackfillMaxRange.Set("7d")
+	f.Var(&l.RulerBackfillMaxRange, "ruler.backfill-max-range", "Maximum time range of a rule group backfill. 0 to disable the limit.")
 
 	f.StringVar(&l.PerTenantOverrideConfig, "limits.per-user-override-config", "", "Feature renamed to 'runtime configuration', flag deprecated in favor of -runtime-config.file (runtime_config.file in YAML).")
 	_ = l.RetentionPeriod.Set("0s")
@@ -474,6 +489,8 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.Var(&l.PatternIngesterTokenizableJSONFieldsDefault, "limits.pattern-ingester-tokenizable-json-fields", "List of JSON fields that should be tokenized in the pattern ingester.")
 	f.Var(&l.PatternIngesterTokenizableJSONFieldsAppend, "limits.pattern-ingester-tokenizable-json-fields-append", "List of JSON fields that should be appended to the default list of tokenizable fields in the pattern ingester.")
 	f.Var(&l.PatternIngesterTokenizableJSONFieldsDelete, "limits.pattern-ingester-tokenizable-json-fields-delete", "List of JSON fields that should be deleted from the (default U append) list of tokenizable fields in the pattern ingester.")
+	f.Var(&l.PatternIngesterMasks, "limits.pattern-ingester-masks", "List of masks applied to tokens before pattern detection. Tokens matching a mask are replaced by a typed placeholder. Supported masks: uuid, ip, hex, duration. Overrides the pattern ingester configuration when set.")
+	f.StringVar(&l.PatternIngesterTokenizer, "limits.pattern-ingester-tokenizer", "", "Tokenizer used for pattern detection. Supported tokenizers: punctuation, splitting, logfmt, json. Overrides the pattern ingester configuration when set.")
 
 	f.BoolVar(
 		&l.MetricAggregationEnabled,
@@ -494,6 +511,18 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 		1.0,
 		"Minimum pattern rate (samples per second) required for a pattern to be persisted. Patterns with lower rates will be filtered out during persistence.",
 	)
+	f.BoolVar(
+		&l.PatternAnomalyDetectionEnabled,
+		"limits.pattern-anomaly-detection-enabled",
+		false,
+		"Enable detection of anomalous patterns when patterns are persisted. New patterns and patterns whose volume deviates from their baseline are persisted with an anomaly field, regardless of the pattern rate threshold.",
+	)
+	f.Float64Var(
+		&l.PatternAnomalyDeviationRatio,
+		"limits.pattern-anomaly-deviation-ratio",
+		3.0,
+		"Ratio between the volume of a pattern in a persistence bucket and its baseline above which the bucket is flagged as a spike, and below the inverse of which the bucket is flagged as a drop. Must be greater than 1.",
+	)
 
 	f.DurationVar(&l.SimulatedPushLatency, "limits.simulated-push-latency", 0, "Simulated latency to add to push requests. This is used to test the performance of the write path under different latency conditions.")
 
@@ -617,6 +646,27 @@ func (l *Limits) Validate() error {
 		return errors.New("querier.tsdb-max-bytes-per-shard must be greater than 0")
 	}
 
+	if err := drain.ValidateMasks(l.PatternIngesterMasks); err != nil {
+		return err
+	}
+
+	if err := drain.ValidateTokenizer(l.PatternIngesterTokenizer); err != nil {
+		return err
+	}
+
+	if l.PatternAnomalyDetectionEnabled && l.PatternAnomalyDeviationRatio <= 1 {
+		return fmt.Errorf("pattern_anomaly_deviation_ratio must be greater than 1, was %v", l.PatternAnomalyDeviationRatio)
+	}
+
+	for name, table := range l.LookupTables {
+		if table.Path == "" {
+			return fmt.Errorf("lookup table %s: path must be set", name)
+		}
+		if table.Format != log.LookupTableFormatCSV && table.Format != log.LookupTableFormatJSON {
+			return fmt.Errorf("lookup table %s: unsupported format %q, must be one of %s or %s", name, table.Format, log.LookupTableFormatCSV, log.LookupTableFormatJSON)
+		}
+	}
+
 	return nil
 }
 
@@ -762,6 +812,30 @@ func (o *Overrides) PolicyMaxGlobalStreamsPerUser(userID, policy string) int {
 	return 0
 }
 
+// PolicyIngestionRateBytes returns the ingestion rate limit in bytes per second
+// for a specific policy. Returns 0 if no policy-specific override is set.
+func (o *Overrides) PolicyIngestionRateBytes(userID, policy string) float64 {
+	if policy == "" {
+		return 0
+	}
+	if policyLimits, exists := o.getOverridesForUser(userID).PolicyOverrideLimits[policy]; exists {
+		return policyLimits.IngestionRateMB * bytesInMB
+	}
+	return 0
+}
+
+// PolicyIngestionBurstSizeBytes returns the burst size for the ingestion rate
+// of a specific policy. Returns 0 if no policy-specific override is set.
+func (o *Overrides) PolicyIngestionBurstSizeBytes(userID, policy string) int {
+	if policy == "" {
+		return 0
+	}
+	if policyLimits, exists := o.getOverridesForUser(userID).PolicyOverrideLimits[policy]; exists {
+		return int(policyLimits.IngestionBurstSizeMB * bytesInMB)
+	}
+	return 0
+}
+
 // MaxChunksPerQuery returns the maximum number of chunks allowed per query.
 func (o *Overrides) MaxChunksPerQuery(userID string) int {
 	return o.getOverridesForUser(userID).MaxChunksPerQuery
@@ -781,6 +855,11 @@ func (o *Overrides) MaxQuerySeries(_ context.Context, userID string) int {
 	return o.getOverridesForUser(userID).MaxQuerySeries
 }
 
+// MaxJoinEntries returns the limit of the entries read from each side of a join query.
+func (o *Overrides) MaxJoinEntries(_ context.Context, userID string) int {
+	return o.getOverridesForUser(userID).MaxJoinEntries
+}
+
 // MaxQueryRange returns the limit for the max [range] value that can be in a range query
 func (o *Overrides) MaxQueryRange(_ context.Context, userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).MaxQueryRange)
@@ -939,6 +1018,16 @@ func (o *Overrides) RulerEnableWALReplay(userID string) bool {
 	return o.getOverridesForUser(userID).RulerEnableWALReplay
 }
 
+// RulerBackfillMaxConcurrency returns the maximum number of concurrent backfill evaluations for a given user.
+func (o *Overrides) RulerBackfillMaxConcurrency(userID string) int {
+	return o.getOverridesForUser(userID).RulerBackfillMaxConcurrency
+}
+
+// RulerBackfillMaxRange returns the maximum time range of a rule group backfill for a given user.
+func (o *Overrides) RulerBackfillMaxRange(userID string) time.Duration {
+	return time.Duration(o.getOverridesForUser(userID).RulerBackfillMaxRange)
+}
+
 func (o *Overrides) IngestionPartitionsTenantShardSize(userID string) int {
 	return o.getOverridesForUser(userID).IngestionPartitionsTenantShardSize
 }
@@ -1091,6 +1180,10 @@ func (o *Overrides) BlockedQueries(_ context.Context, userID string) []*validati
 	return o.getOverridesForUser(userID).BlockedQueries
 }
 
+func (o *Overrides) LookupTables(userID string) map[string]validation.LookupTable {
+	return o.getOverridesForUser(userID).LookupTables
+}
+
 func (o *Overrides) RequiredLabels(_ context.Context, userID string) []string {
 	return o.getOverridesForUser(userID).RequiredLabels
 }
@@ -1301,6 +1394,14 @@ func (o *Overrides) PatternIngesterTokenizableJSONFieldsDelete(userID string) []
 	return o.getOverridesForUser(userID).PatternIngesterTokenizableJSONFieldsDelete
 }
 
+func (o *Overrides) PatternIngesterMasks(userID string) []string {
+	return o.getOverridesForUser(userID).PatternIngesterMasks
+}
+
+func (o *Overrides) PatternIngesterTokenizer(userID string) string {
+	return o.getOverridesForUser(userID).PatternIngesterTokenizer
+}
+
 func (o *Overrides) MetricAggregationEnabled(userID string) bool {
 	return o.getOverridesForUser(userID).MetricAggregationEnabled
 }
@@ -1317,6 +1418,14 @@ func (o *Overrides) PatternRateThreshold(userID string) float64 {
 	return o.getOverridesForUser(userID).PatternRateThreshold
 }
 
+func (o *Overrides) PatternAnomalyDetectionEnabled(userID string) bool {
+	return o.getOverridesForUser(userID).PatternAnomalyDetectionEnabled
+}
+
+func (o *Overrides) PatternAnomalyDeviationRatio(userID string) float64 {
+	return o.getOverridesForUser(userID).PatternAnomalyDeviationRatio
+}
+
 func (o *Overrides) EnableMultiVariantQueries(userID string) bool {
 	return o.getOverridesForUser(userID).EnableMultiVariantQueries
 }
@@ -1356,6 +1465,10 @@ type OverwriteMarshalingStringMap struct {
 type PolicyOverridableLimits struct {
 	MaxLocalStreamsPerUser  int `yaml:"max_streams_per_user" json:"max_streams_per_user"`
 	MaxGlobalStreamsPerUser int `yaml:"max_global_streams_per_user" json:"max_global_streams_per_user"`
+	// IngestionRateMB and IngestionBurstSizeMB are only enforced by the
+	// ingest-limits service.
+	IngestionRateMB      float64 `yaml:"ingestion_rate_mb" json:"ingestion_rate_mb"`
+	IngestionBurstSizeMB float64 `yaml:"ingestion_burst_size_mb" json:"ingestion_burst_size_mb"`
 }
 
 func NewOverwriteMarshalingStringMap(m map[string]string) OverwriteMarshalingStringMap {
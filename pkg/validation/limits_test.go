@@ -2,6 +2,7 @@ package validation
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/grafana/loki/v3/pkg/compression"
 	"github.com/grafana/loki/v3/pkg/loghttp/push"
 	"github.com/grafana/loki/v3/pkg/logql"
+	"github.com/grafana/loki/v3/pkg/util/validation"
 )
 
 func TestLimitsTagsYamlMatchJson(t *testing.T) {
@@ -377,6 +379,22 @@ func TestLimitsValidation(t *testing.T) {
 			limits:   Limits{DeletionMode: "disabled", BloomBlockEncoding: "unknown", OTLPConfig: &push.OTLPConfig{}},
 			expected: fmt.Errorf("invalid encoding: unknown, supported: %s", compression.SupportedCodecs()),
 		},
+		{
+			limits:   Limits{DeletionMode: "disabled", BloomBlockEncoding: "none", LookupTables: map[string]validation.LookupTable{"customers": {Format: "csv"}}},
+			expected: errors.New("lookup table customers: path must be set"),
+		},
+		{
+			limits:   Limits{DeletionMode: "disabled", BloomBlockEncoding: "none", LookupTables: map[string]validation.LookupTable{"customers": {Path: "customers.yaml", Format: "yaml"}}},
+			expected: errors.New(`lookup table customers: unsupported format "yaml", must be one of csv or json`),
+		},
+		{
+			limits:   Limits{DeletionMode: "disabled", BloomBlockEncoding: "none", PatternAnomalyDetectionEnabled: true, PatternAnomalyDeviationRatio: 3},
+			expected: nil,
+		},
+		{
+			limits:   Limits{DeletionMode: "disabled", BloomBlockEncoding: "none", PatternAnomalyDetectionEnabled: true, PatternAnomalyDeviationRatio: 1},
+			expected: errors.New("pattern_anomaly_deviation_ratio must be greater than 1, was 1"),
+		},
 	} {
 		desc := fmt.Sprintf("%s/%s", tc.limits.DeletionMode, tc.limits.BloomBlockEncoding)
 		t.Run(desc, func(t *testing.T) {
@@ -608,6 +626,8 @@ func TestLimits_PolicyOverrideLimits(t *testing.T) {
 			"finance": {
 				MaxLocalStreamsPerUser:  100,
 				MaxGlobalStreamsPerUser: 1000,
+				IngestionRateMB:         2,
+				IngestionBurstSizeMB:    4,
 			},
 			"ops": {
 				MaxLocalStreamsPerUser:  50,
@@ -626,19 +646,25 @@ func TestLimits_PolicyOverrideLimits(t *testing.T) {
 	require.Equal(t, 1000, overrides.PolicyMaxGlobalStreamsPerUser("tenant1", "finance"))
 	require.Equal(t, 50, overrides.PolicyMaxLocalStreamsPerUser("tenant1", "ops"))
 	require.Equal(t, 500, overrides.PolicyMaxGlobalStreamsPerUser("tenant1", "ops"))
+	require.Equal(t, float64(2<<20), overrides.PolicyIngestionRateBytes("tenant1", "finance"))
+	require.Equal(t, 4<<20, overrides.PolicyIngestionBurstSizeBytes("tenant1", "finance"))
+	require.Zero(t, overrides.PolicyIngestionRateBytes("tenant1", "ops"))
 
 	// Test non-existent policy returns 0
 	require.Equal(t, 0, overrides.PolicyMaxLocalStreamsPerUser("tenant1", "nonexistent"))
 	require.Equal(t, 0, overrides.PolicyMaxGlobalStreamsPerUser("tenant1", "nonexistent"))
+	require.Zero(t, overrides.PolicyIngestionRateBytes("tenant1", "nonexistent"))
 
 	// Test empty policy returns 0
 	require.Equal(t, 0, overrides.PolicyMaxLocalStreamsPerUser("tenant1", ""))
 	require.Equal(t, 0, overrides.PolicyMaxGlobalStreamsPerUser("tenant1", ""))
+	require.Zero(t, overrides.PolicyIngestionRateBytes("tenant1", ""))
 
 	// Test nil PolicyOverrideLimits returns 0
 	limits.PolicyOverrideLimits = nil
 	require.Equal(t, 0, overrides.PolicyMaxLocalStreamsPerUser("tenant1", "finance"))
 	require.Equal(t, 0, overrides.PolicyMaxGlobalStreamsPerUser("tenant1", "finance"))
+	require.Zero(t, overrides.PolicyIngestionBurstSizeBytes("tenant1", "finance"))
 }
 
 func TestOTLPConfig(t *testing.T) {
@@ -20,6 +20,9 @@ type Config struct {
 
 	// Performance comparison tolerance (0.0-1.0, where 0.1 = 10%)
 	PerformanceTolerance float64 `yaml:"performance_tolerance"`
+
+	// Absolute tolerance when diffing the sample values of mismatching results
+	ValueTolerance float64 `yaml:"value_tolerance"`
 }
 
 // SamplingConfig defines how queries are sampled
@@ -30,7 +33,7 @@ type SamplingConfig struct {
 
 // StorageConfig defines storage backend configuration
 type StorageConfig struct {
-	Type string `yaml:"type"` // "cloudsql", "rds", or empty string for no storage
+	Type string `yaml:"type"` // "cloudsql", "rds", "postgres", "sqlite", or empty string for no storage
 
 	// CloudSQL specific (via proxy)
 	CloudSQLHost     string `yaml:"cloudsql_host"`
@@ -45,6 +48,17 @@ type StorageConfig struct {
 	RDSUser     string `yaml:"rds_user"`
 	// RDSPassword provided via GOLDFISH_DB_PASSWORD environment variable
 
+	// PostgreSQL specific
+	PostgresHost     string `yaml:"postgres_host"`
+	PostgresPort     int    `yaml:"postgres_port"`
+	PostgresDatabase string `yaml:"postgres_database"`
+	PostgresUser     string `yaml:"postgres_user"`
+	PostgresSSLMode  string `yaml:"postgres_ssl_mode"`
+	// PostgresPassword provided via GOLDFISH_DB_PASSWORD environment variable
+
+	// SQLite specific
+	SQLitePath string `yaml:"sqlite_path"`
+
 	// Common settings
 	MaxConnections int `yaml:"max_connections"`
 	MaxIdleTime    int `yaml:"max_idle_time_seconds"`
@@ -59,7 +73,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.Var(&tenantRulesFlag{&cfg.SamplingConfig.TenantRules}, "goldfish.sampling.tenant-rules", "Tenant-specific sampling rules (format: tenant1:0.1,tenant2:0.5)")
 
 	// Storage flags
-	f.StringVar(&cfg.StorageConfig.Type, "goldfish.storage.type", "", "Storage backend type (cloudsql, rds, or empty for no storage)")
+	f.StringVar(&cfg.StorageConfig.Type, "goldfish.storage.type", "", "Storage backend type (cloudsql, rds, postgres, sqlite, or empty for no storage)")
 
 	// CloudSQL flags
 	f.StringVar(&cfg.StorageConfig.CloudSQLHost, "goldfish.storage.cloudsql.host", "cloudsql-proxy", "CloudSQL proxy host")
@@ -71,11 +85,23 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.StringVar(&cfg.StorageConfig.RDSEndpoint, "goldfish.storage.rds.endpoint", "", "RDS endpoint (host:port)")
 	f.StringVar(&cfg.StorageConfig.RDSDatabase, "goldfish.storage.rds.database", "", "RDS database name")
 	f.StringVar(&cfg.StorageConfig.RDSUser, "goldfish.storage.rds.user", "", "RDS database user")
+
+	// PostgreSQL flags
+	f.StringVar(&cfg.StorageConfig.PostgresHost, "goldfish.storage.postgres.host", "localhost", "PostgreSQL host")
+	f.IntVar(&cfg.StorageConfig.PostgresPort, "goldfish.storage.postgres.port", 5432, "PostgreSQL port")
+	f.StringVar(&cfg.StorageConfig.PostgresDatabase, "goldfish.storage.postgres.database", "", "PostgreSQL database name")
+	f.StringVar(&cfg.StorageConfig.PostgresUser, "goldfish.storage.postgres.user", "", "PostgreSQL database user")
+	f.StringVar(&cfg.StorageConfig.PostgresSSLMode, "goldfish.storage.postgres.ssl-mode", "", "PostgreSQL SSL mode (disable, require, verify-ca, verify-full), defaults to the driver default")
+
+	// SQLite flags
+	f.StringVar(&cfg.StorageConfig.SQLitePath, "goldfish.storage.sqlite.path", "", "Path of the SQLite database file, created if it doesn't exist")
+
 	f.IntVar(&cfg.StorageConfig.MaxConnections, "goldfish.storage.max-connections", 10, "Maximum database connections")
 	f.IntVar(&cfg.StorageConfig.MaxIdleTime, "goldfish.storage.max-idle-time", 300, "Maximum idle time in seconds")
 
 	// Performance comparison flags
 	f.Float64Var(&cfg.PerformanceTolerance, "goldfish.performance-tolerance", 0.1, "Performance comparison tolerance (0.0-1.0, where 0.1 = 10%)")
+	f.Float64Var(&cfg.ValueTolerance, "goldfish.value-tolerance", 0.000001, "Absolute tolerance applied to sample values when diffing mismatching results")
 }
 
 // Validate validates the configuration
@@ -98,6 +124,10 @@ func (cfg *Config) Validate() error {
 		return errors.New("performance tolerance must be between 0 and 1")
 	}
 
+	if cfg.ValueTolerance < 0 {
+		return errors.New("value tolerance must not be negative")
+	}
+
 	// Only validate storage if one is configured
 	if cfg.StorageConfig.Type == "" {
 		return nil
@@ -112,6 +142,14 @@ func (cfg *Config) Validate() error {
 		if cfg.StorageConfig.RDSEndpoint == "" || cfg.StorageConfig.RDSDatabase == "" || cfg.StorageConfig.RDSUser == "" {
 			return errors.New("RDS endpoint, database, and user must be specified")
 		}
+	case "postgres":
+		if cfg.StorageConfig.PostgresDatabase == "" || cfg.StorageConfig.PostgresUser == "" {
+			return errors.New("PostgreSQL database and user must be specified")
+		}
+	case "sqlite":
+		if cfg.StorageConfig.SQLitePath == "" {
+			return errors.New("SQLite database path must be specified")
+		}
 	default:
 		return fmt.Errorf("unsupported storage type: %s", cfg.StorageConfig.Type)
 	}
@@ -169,6 +169,16 @@ func (m *Manager) ProcessQueryPair(ctx context.Context, req *http.Request, cellA
 	start := time.Now()
 	result := CompareResponses(sample, m.config.PerformanceTolerance)
 
+	// Explain content mismatches with a semantic diff of the results
+	if _, ok := result.DifferenceDetails["content_hash"]; ok {
+		diff, err := DiffResults(cellAResp.Body, cellBResp.Body, m.config.ValueTolerance)
+		if err != nil {
+			level.Warn(m.logger).Log("msg", "failed to diff query results", "correlation_id", correlationID, "err", err)
+		} else {
+			result.DifferenceDetails[goldfish.ResultDiffKey] = diff
+		}
+	}
+
 	m.metrics.comparisonDuration.Observe(time.Since(start).Seconds())
 
 	m.metrics.comparisonResults.WithLabelValues(string(result.ComparisonStatus)).Inc()
@@ -226,6 +236,17 @@ func (m *Manager) ProcessQueryPair(ctx context.Context, req *http.Request, cellA
 		}
 	}
 
+	if diff, ok := result.DifferenceDetails[goldfish.ResultDiffKey].(*goldfish.ResultDiff); ok {
+		logFields = append(logFields,
+			"missing_series", len(diff.MissingSeries),
+			"extra_series", len(diff.ExtraSeries),
+			"label_differences", len(diff.LabelDifferences),
+			"value_differences", len(diff.ValueDifferences),
+			"entry_differences", len(diff.EntryDifferences),
+			"ordering_only", diff.OrderingOnly,
+		)
+	}
+
 	logLevel(m.logger).Log(logFields...)
 
 	// Log specific performance differences if significant
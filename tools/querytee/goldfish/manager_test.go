@@ -177,6 +177,45 @@ func TestManager_ProcessQueryPair(t *testing.T) {
 	assert.Equal(t, time.Duration(120)*time.Millisecond, result.PerformanceMetrics.CellBQueryTime)
 }
 
+func TestManager_ProcessQueryPair_StoresResultDiff(t *testing.T) {
+	config := Config{
+		Enabled: true,
+		SamplingConfig: SamplingConfig{
+			DefaultRate: 1.0,
+		},
+	}
+
+	storage := &mockStorage{}
+	manager, err := NewManager(config, storage, log.NewNopLogger(), prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", "/loki/api/v1/query?query=count_over_time({job=\"test\"}[5m])", nil)
+	req.Header.Set("X-Scope-OrgID", "tenant1")
+
+	cellAResp := &ResponseData{
+		Body:       []byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"test"},"value":[1700000000,"1"]}]}}`),
+		StatusCode: 200,
+		Hash:       "hash-a",
+	}
+	cellBResp := &ResponseData{
+		Body:       []byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"test"},"value":[1700000000,"2"]}]}}`),
+		StatusCode: 200,
+		Hash:       "hash-b",
+	}
+
+	manager.ProcessQueryPair(context.Background(), req, cellAResp, cellBResp)
+
+	require.Len(t, storage.results, 1)
+	result := storage.results[0]
+	assert.Equal(t, goldfish.ComparisonStatusMismatch, result.ComparisonStatus)
+	assert.Equal(t, &goldfish.ResultDiff{
+		ResultType: "vector",
+		ValueDifferences: []goldfish.ValueDiff{
+			{Series: `{job="test"}`, Timestamp: time.Unix(1700000000, 0).UTC(), CellA: "1", CellB: "2", Delta: 1},
+		},
+	}, result.DifferenceDetails[goldfish.ResultDiffKey])
+}
+
 func Test_CaptureResponse_withTraceID(t *testing.T) {
 	tests := []struct {
 		name     string
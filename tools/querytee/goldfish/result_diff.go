@@ -0,0 +1,305 @@
+package goldfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/loki/v3/pkg/goldfish"
+	"github.com/grafana/loki/v3/pkg/loghttp"
+)
+
+// maxResultDiffItems bounds the number of differences of each kind kept in a result diff,
+// so that it stays small enough to be stored with the comparison outcome.
+const maxResultDiffItems = 20
+
+// diffSeries is a stream or a metric series of a query result
+type diffSeries struct {
+	labels   string
+	labelSet map[string]string
+	samples  []model.SamplePair
+	entries  []loghttp.Entry
+}
+
+// DiffResults computes the semantic difference between the query results returned by both cells.
+// Sample values are compared with the given absolute tolerance.
+func DiffResults(cellABody, cellBBody []byte, tolerance float64) (*goldfish.ResultDiff, error) {
+	var cellA, cellB loghttp.QueryResponse
+	if err := json.Unmarshal(cellABody, &cellA); err != nil {
+		return nil, fmt.Errorf("failed to parse cell A response: %w", err)
+	}
+	if err := json.Unmarshal(cellBBody, &cellB); err != nil {
+		return nil, fmt.Errorf("failed to parse cell B response: %w", err)
+	}
+
+	if cellA.Data.ResultType != cellB.Data.ResultType {
+		return nil, fmt.Errorf("cell A returned a %s result but cell B returned a %s result", cellA.Data.ResultType, cellB.Data.ResultType)
+	}
+
+	seriesA, err := toDiffSeries(cellA.Data.Result)
+	if err != nil {
+		return nil, err
+	}
+	seriesB, err := toDiffSeries(cellB.Data.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &goldfish.ResultDiff{ResultType: string(cellA.Data.ResultType)}
+
+	indexB := make(map[string]int, len(seriesB))
+	for i, s := range seriesB {
+		indexB[s.labels] = i
+	}
+
+	matchedB := make([]bool, len(seriesB))
+	var unmatchedA []diffSeries
+	lastMatched := -1
+	for _, a := range seriesA {
+		i, ok := indexB[a.labels]
+		if !ok {
+			unmatchedA = append(unmatchedA, a)
+			continue
+		}
+
+		matchedB[i] = true
+		if i < lastMatched {
+			diff.SeriesReordered = true
+		}
+		lastMatched = i
+
+		if a.entries != nil || seriesB[i].entries != nil {
+			diffEntries(diff, a, seriesB[i])
+		} else {
+			diffSamples(diff, a, seriesB[i], tolerance)
+		}
+	}
+
+	var unmatchedB []diffSeries
+	for i, b := range seriesB {
+		if !matchedB[i] {
+			unmatchedB = append(unmatchedB, b)
+		}
+	}
+
+	// Series left on both sides with the same values only differ by their labels
+	contentB := make(map[string][]int, len(unmatchedB))
+	for i, b := range unmatchedB {
+		key := b.contentKey()
+		contentB[key] = append(contentB[key], i)
+	}
+	pairedB := make([]bool, len(unmatchedB))
+	for _, a := range unmatchedA {
+		key := a.contentKey()
+		if candidates := contentB[key]; len(candidates) > 0 {
+			b := unmatchedB[candidates[0]]
+			contentB[key] = candidates[1:]
+			pairedB[candidates[0]] = true
+
+			diff.LabelDifferences = appendDiffItem(diff, diff.LabelDifferences, goldfish.LabelSetDiff{
+				CellA:     a.labels,
+				CellB:     b.labels,
+				CellAOnly: labelsOnlyIn(a.labelSet, b.labelSet),
+				CellBOnly: labelsOnlyIn(b.labelSet, a.labelSet),
+			})
+			continue
+		}
+
+		diff.MissingSeries = appendDiffItem(diff, diff.MissingSeries, a.labels)
+	}
+	for i, b := range unmatchedB {
+		if !pairedB[i] {
+			diff.ExtraSeries = appendDiffItem(diff, diff.ExtraSeries, b.labels)
+		}
+	}
+
+	diff.OrderingOnly = (diff.SeriesReordered || len(diff.ReorderedSeries) > 0) &&
+		len(diff.MissingSeries) == 0 && len(diff.ExtraSeries) == 0 && len(diff.LabelDifferences) == 0 &&
+		len(diff.ValueDifferences) == 0 && len(diff.EntryDifferences) == 0
+
+	return diff, nil
+}
+
+// toDiffSeries converts a query result to its series, a scalar being a series without labels
+func toDiffSeries(result loghttp.ResultValue) ([]diffSeries, error) {
+	switch r := result.(type) {
+	case nil:
+		return nil, nil
+	case loghttp.Streams:
+		series := make([]diffSeries, 0, len(r))
+		for _, s := range r {
+			entries := s.Entries
+			if entries == nil {
+				entries = []loghttp.Entry{}
+			}
+			series = append(series, diffSeries{labels: s.Labels.String(), labelSet: s.Labels, entries: entries})
+		}
+		return series, nil
+	case loghttp.Matrix:
+		series := make([]diffSeries, 0, len(r))
+		for _, s := range r {
+			series = append(series, newMetricDiffSeries(s.Metric, s.Values))
+		}
+		return series, nil
+	case loghttp.Vector:
+		series := make([]diffSeries, 0, len(r))
+		for _, s := range r {
+			series = append(series, newMetricDiffSeries(s.Metric, []model.SamplePair{{Timestamp: s.Timestamp, Value: s.Value}}))
+		}
+		return series, nil
+	case loghttp.Scalar:
+		return []diffSeries{newMetricDiffSeries(model.Metric{}, []model.SamplePair{{Timestamp: r.Timestamp, Value: r.Value}})}, nil
+	default:
+		return nil, fmt.Errorf("unsupported result type %s", result.Type())
+	}
+}
+
+func newMetricDiffSeries(metric model.Metric, samples []model.SamplePair) diffSeries {
+	labelSet := make(map[string]string, len(metric))
+	for name, value := range metric {
+		labelSet[string(name)] = string(value)
+	}
+	return diffSeries{labels: metric.String(), labelSet: labelSet, samples: samples}
+}
+
+// contentKey identifies the values of a series regardless of its labels
+func (s diffSeries) contentKey() string {
+	var b []byte
+	for _, e := range s.entries {
+		b = append(b, entryKey(e)...)
+		b = append(b, 0)
+	}
+	for _, p := range s.samples {
+		b = strconv.AppendInt(b, int64(p.Timestamp), 10)
+		b = append(b, ' ')
+		b = strconv.AppendFloat(b, float64(p.Value), 'g', -1, 64)
+		b = append(b, 0)
+	}
+	return string(b)
+}
+
+func entryKey(e loghttp.Entry) string {
+	return strconv.FormatInt(e.Timestamp.UnixNano(), 10) + " " + e.StructuredMetadata.String() + " " + e.Parsed.String() + " " + e.Line
+}
+
+// diffSamples records the samples of a metric series differing between both cells
+func diffSamples(diff *goldfish.ResultDiff, a, b diffSeries, tolerance float64) {
+	valuesB := make(map[model.Time]model.SampleValue, len(b.samples))
+	for _, p := range b.samples {
+		valuesB[p.Timestamp] = p.Value
+	}
+
+	for _, p := range a.samples {
+		valueB, ok := valuesB[p.Timestamp]
+		if !ok {
+			diff.ValueDifferences = appendDiffItem(diff, diff.ValueDifferences, goldfish.ValueDiff{
+				Series:    a.labels,
+				Timestamp: p.Timestamp.Time().UTC(),
+				CellA:     p.Value.String(),
+			})
+			continue
+		}
+		delete(valuesB, p.Timestamp)
+
+		if sampleValuesEqual(p.Value, valueB, tolerance) {
+			continue
+		}
+		valueDiff := goldfish.ValueDiff{
+			Series:    a.labels,
+			Timestamp: p.Timestamp.Time().UTC(),
+			CellA:     p.Value.String(),
+			CellB:     valueB.String(),
+		}
+		if delta := float64(valueB - p.Value); !math.IsNaN(delta) && !math.IsInf(delta, 0) {
+			valueDiff.Delta = delta
+		}
+		diff.ValueDifferences = appendDiffItem(diff, diff.ValueDifferences, valueDiff)
+	}
+
+	for _, p := range b.samples {
+		if valueB, ok := valuesB[p.Timestamp]; ok {
+			diff.ValueDifferences = appendDiffItem(diff, diff.ValueDifferences, goldfish.ValueDiff{
+				Series:    b.labels,
+				Timestamp: p.Timestamp.Time().UTC(),
+				CellB:     valueB.String(),
+			})
+		}
+	}
+}
+
+func sampleValuesEqual(a, b model.SampleValue, tolerance float64) bool {
+	fa, fb := float64(a), float64(b)
+	if (math.IsNaN(fa) && math.IsNaN(fb)) || (math.IsInf(fa, 1) && math.IsInf(fb, 1)) || (math.IsInf(fa, -1) && math.IsInf(fb, -1)) {
+		return true
+	}
+	return math.Abs(fa-fb) <= tolerance
+}
+
+// diffEntries records the entries of a stream returned by a single cell,
+// or the stream as reordered when both cells returned the same entries in a different order.
+func diffEntries(diff *goldfish.ResultDiff, a, b diffSeries) {
+	counts := make(map[string]int, len(a.entries))
+	for _, e := range a.entries {
+		counts[entryKey(e)]++
+	}
+	for _, e := range b.entries {
+		counts[entryKey(e)]--
+	}
+
+	entryDiff := goldfish.EntryDiff{Stream: a.labels}
+	var differences []time.Time
+	for _, e := range a.entries {
+		if key := entryKey(e); counts[key] > 0 {
+			counts[key]--
+			entryDiff.MissingEntries++
+			differences = append(differences, e.Timestamp)
+		}
+	}
+	for _, e := range b.entries {
+		if key := entryKey(e); counts[key] < 0 {
+			counts[key]++
+			entryDiff.ExtraEntries++
+			differences = append(differences, e.Timestamp)
+		}
+	}
+
+	if len(differences) == 0 {
+		for i := range a.entries {
+			if entryKey(a.entries[i]) != entryKey(b.entries[i]) {
+				diff.ReorderedSeries = appendDiffItem(diff, diff.ReorderedSeries, a.labels)
+				return
+			}
+		}
+		return
+	}
+
+	sort.Slice(differences, func(i, j int) bool { return differences[i].Before(differences[j]) })
+	entryDiff.FirstDifference = differences[0].UTC()
+	diff.EntryDifferences = appendDiffItem(diff, diff.EntryDifferences, entryDiff)
+}
+
+// labelsOnlyIn returns the labels of a which aren't in b, as name="value"
+func labelsOnlyIn(a, b map[string]string) []string {
+	var labels []string
+	for name, value := range a {
+		if other, ok := b[name]; !ok || other != value {
+			labels = append(labels, name+"="+strconv.Quote(value))
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// appendDiffItem appends a difference unless enough differences of its kind are already recorded
+func appendDiffItem[T any](diff *goldfish.ResultDiff, items []T, item T) []T {
+	if len(items) >= maxResultDiffItems {
+		diff.Truncated = true
+		return items
+	}
+	return append(items, item)
+}
@@ -0,0 +1,164 @@
+package goldfish
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/v3/pkg/goldfish"
+)
+
+func TestDiffResults(t *testing.T) {
+	ts := time.Unix(1700000000, 0).UTC()
+	response := func(resultType, result string) []byte {
+		return []byte(fmt.Sprintf(`{"status":"success","data":{"resultType":%q,"result":%s}}`, resultType, result))
+	}
+
+	for _, tc := range []struct {
+		name         string
+		cellA, cellB []byte
+		tolerance    float64
+		expected     *goldfish.ResultDiff
+	}{
+		{
+			name:     "identical matrices",
+			cellA:    response("matrix", `[{"metric":{"job":"a"},"values":[[1700000000,"1"]]}]`),
+			cellB:    response("matrix", `[{"metric":{"job":"a"},"values":[[1700000000,"1"]]}]`),
+			expected: &goldfish.ResultDiff{ResultType: "matrix"},
+		},
+		{
+			name:  "missing and extra series",
+			cellA: response("matrix", `[{"metric":{"job":"a"},"values":[[1700000000,"1"]]},{"metric":{"job":"b"},"values":[[1700000000,"2"]]}]`),
+			cellB: response("matrix", `[{"metric":{"job":"a"},"values":[[1700000000,"1"]]},{"metric":{"job":"c"},"values":[[1700000000,"3"]]}]`),
+			expected: &goldfish.ResultDiff{
+				ResultType:    "matrix",
+				MissingSeries: []string{`{job="b"}`},
+				ExtraSeries:   []string{`{job="c"}`},
+			},
+		},
+		{
+			name:  "label set differences",
+			cellA: response("matrix", `[{"metric":{"job":"a","level":"info"},"values":[[1700000000,"1"]]}]`),
+			cellB: response("matrix", `[{"metric":{"job":"a","level":"INFO","pod":"p"},"values":[[1700000000,"1"]]}]`),
+			expected: &goldfish.ResultDiff{
+				ResultType: "matrix",
+				LabelDifferences: []goldfish.LabelSetDiff{{
+					CellA:     `{job="a", level="info"}`,
+					CellB:     `{job="a", level="INFO", pod="p"}`,
+					CellAOnly: []string{`level="info"`},
+					CellBOnly: []string{`level="INFO"`, `pod="p"`},
+				}},
+			},
+		},
+		{
+			name:      "value deltas with tolerance",
+			cellA:     response("matrix", `[{"metric":{"job":"a"},"values":[[1700000000,"1"],[1700000060,"2"],[1700000120,"3"]]}]`),
+			cellB:     response("matrix", `[{"metric":{"job":"a"},"values":[[1700000000,"1.05"],[1700000060,"2.5"],[1700000180,"4"]]}]`),
+			tolerance: 0.1,
+			expected: &goldfish.ResultDiff{
+				ResultType: "matrix",
+				ValueDifferences: []goldfish.ValueDiff{
+					{Series: `{job="a"}`, Timestamp: ts.Add(time.Minute), CellA: "2", CellB: "2.5", Delta: 0.5},
+					{Series: `{job="a"}`, Timestamp: ts.Add(2 * time.Minute), CellA: "3"},
+					{Series: `{job="a"}`, Timestamp: ts.Add(3 * time.Minute), CellB: "4"},
+				},
+			},
+		},
+		{
+			name:  "non finite values",
+			cellA: response("vector", `[{"metric":{"job":"a"},"value":[1700000000,"NaN"]},{"metric":{"job":"b"},"value":[1700000000,"+Inf"]}]`),
+			cellB: response("vector", `[{"metric":{"job":"a"},"value":[1700000000,"NaN"]},{"metric":{"job":"b"},"value":[1700000000,"1"]}]`),
+			expected: &goldfish.ResultDiff{
+				ResultType:       "vector",
+				ValueDifferences: []goldfish.ValueDiff{{Series: `{job="b"}`, Timestamp: ts, CellA: "+Inf", CellB: "1"}},
+			},
+		},
+		{
+			name:  "scalars",
+			cellA: response("scalar", `[1700000000,"1"]`),
+			cellB: response("scalar", `[1700000000,"2"]`),
+			expected: &goldfish.ResultDiff{
+				ResultType:       "scalar",
+				ValueDifferences: []goldfish.ValueDiff{{Series: "{}", Timestamp: ts, CellA: "1", CellB: "2", Delta: 1}},
+			},
+		},
+		{
+			name:  "series ordering only",
+			cellA: response("vector", `[{"metric":{"job":"a"},"value":[1700000000,"1"]},{"metric":{"job":"b"},"value":[1700000000,"2"]}]`),
+			cellB: response("vector", `[{"metric":{"job":"b"},"value":[1700000000,"2"]},{"metric":{"job":"a"},"value":[1700000000,"1"]}]`),
+			expected: &goldfish.ResultDiff{
+				ResultType:      "vector",
+				SeriesReordered: true,
+				OrderingOnly:    true,
+			},
+		},
+		{
+			name:  "entries ordering only",
+			cellA: response("streams", `[{"stream":{"job":"a"},"values":[["1700000001000000000","second"],["1700000000000000000","first"]]}]`),
+			cellB: response("streams", `[{"stream":{"job":"a"},"values":[["1700000000000000000","first"],["1700000001000000000","second"]]}]`),
+			expected: &goldfish.ResultDiff{
+				ResultType:      "streams",
+				ReorderedSeries: []string{`{job="a"}`},
+				OrderingOnly:    true,
+			},
+		},
+		{
+			name:  "stream entries and labels",
+			cellA: response("streams", `[{"stream":{"job":"a"},"values":[["1700000002000000000","third"],["1700000001000000000","second"],["1700000000000000000","first"]]},{"stream":{"job":"b","level":"info"},"values":[["1700000000000000000","line"]]},{"stream":{"job":"c"},"values":[["1700000000000000000","line"]]}]`),
+			cellB: response("streams", `[{"stream":{"job":"a"},"values":[["1700000002000000000","third"],["1700000001000000000","changed"]]},{"stream":{"job":"b"},"values":[["1700000000000000000","line"]]}]`),
+			expected: &goldfish.ResultDiff{
+				ResultType:    "streams",
+				MissingSeries: []string{`{job="c"}`},
+				LabelDifferences: []goldfish.LabelSetDiff{{
+					CellA:     `{job="b", level="info"}`,
+					CellB:     `{job="b"}`,
+					CellAOnly: []string{`level="info"`},
+				}},
+				EntryDifferences: []goldfish.EntryDiff{{
+					Stream:          `{job="a"}`,
+					MissingEntries:  2,
+					ExtraEntries:    1,
+					FirstDifference: ts,
+				}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := DiffResults(tc.cellA, tc.cellB, tc.tolerance)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, diff)
+		})
+	}
+}
+
+func TestDiffResults_Truncated(t *testing.T) {
+	var cellA, cellB string
+	for i := 0; i < maxResultDiffItems+5; i++ {
+		if i > 0 {
+			cellA += ","
+			cellB += ","
+		}
+		cellA += fmt.Sprintf(`[%d,"1"]`, 1700000000+i)
+		cellB += fmt.Sprintf(`[%d,"2"]`, 1700000000+i)
+	}
+
+	diff, err := DiffResults(
+		[]byte(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"a"},"values":[`+cellA+`]}]}}`),
+		[]byte(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"a"},"values":[`+cellB+`]}]}}`),
+		0,
+	)
+	require.NoError(t, err)
+	require.Len(t, diff.ValueDifferences, maxResultDiffItems)
+	require.True(t, diff.Truncated)
+}
+
+func TestDiffResults_DifferentResultTypes(t *testing.T) {
+	_, err := DiffResults(
+		[]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`),
+		[]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`),
+		0,
+	)
+	require.EqualError(t, err, "cell A returned a matrix result but cell B returned a vector result")
+}
@@ -19,6 +19,12 @@ func NewStorage(config StorageConfig, logger log.Logger) (goldfish.Storage, erro
 		RDSEndpoint:      config.RDSEndpoint,
 		RDSDatabase:      config.RDSDatabase,
 		RDSUser:          config.RDSUser,
+		PostgresHost:     config.PostgresHost,
+		PostgresPort:     config.PostgresPort,
+		PostgresDatabase: config.PostgresDatabase,
+		PostgresUser:     config.PostgresUser,
+		PostgresSSLMode:  config.PostgresSSLMode,
+		SQLitePath:       config.SQLitePath,
 		MaxConnections:   config.MaxConnections,
 		MaxIdleTime:      config.MaxIdleTime,
 	}
@@ -29,6 +35,10 @@ func NewStorage(config StorageConfig, logger log.Logger) (goldfish.Storage, erro
 		return goldfish.NewNoopStorage(), nil
 	case "cloudsql", "rds":
 		return goldfish.NewMySQLStorage(sharedConfig, logger)
+	case "postgres":
+		return goldfish.NewPostgresStorage(sharedConfig, logger)
+	case "sqlite":
+		return goldfish.NewSQLiteStorage(sharedConfig, logger)
 	default:
 		return nil, fmt.Errorf("unsupported storage type: %s", config.Type)
 	}
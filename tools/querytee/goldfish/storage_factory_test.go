@@ -0,0 +1,88 @@
+package goldfish
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/loki/v3/pkg/goldfish"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStorage_SQLite(t *testing.T) {
+	config := Config{
+		Enabled: true,
+		SamplingConfig: SamplingConfig{
+			DefaultRate: 1.0,
+		},
+		StorageConfig: StorageConfig{
+			Type:       "sqlite",
+			SQLitePath: filepath.Join(t.TempDir(), "goldfish.db"),
+		},
+	}
+	require.NoError(t, config.Validate())
+
+	storage, err := NewStorage(config.StorageConfig, log.NewNopLogger())
+	require.NoError(t, err)
+	defer storage.Close()
+
+	manager, err := NewManager(config, storage, log.NewNopLogger(), prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", "/loki/api/v1/query_range?query=count_over_time({job=\"test\"}[5m])&start=1700000000&end=1700001000&step=60s", nil)
+	req.Header.Set("X-Scope-OrgID", "tenant1")
+
+	cellAResp := &ResponseData{
+		StatusCode: 200,
+		Duration:   100 * time.Millisecond,
+		Stats:      goldfish.QueryStats{ExecTimeMs: 100},
+		Hash:       "hash123",
+	}
+	cellBResp := &ResponseData{
+		StatusCode: 200,
+		Duration:   120 * time.Millisecond,
+		Stats:      goldfish.QueryStats{ExecTimeMs: 120},
+		Hash:       "hash123",
+	}
+	manager.ProcessQueryPair(context.Background(), req, cellAResp, cellBResp)
+
+	// The sample is stored asynchronously
+	require.Eventually(t, func() bool {
+		resp, err := storage.GetSampledQueries(context.Background(), 1, 10, goldfish.QueryFilter{Tenant: "tenant1"})
+		return err == nil && len(resp.Queries) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resp, err := storage.GetSampledQueries(context.Background(), 1, 10, goldfish.QueryFilter{})
+	require.NoError(t, err)
+	require.Len(t, resp.Queries, 1)
+	assert.Equal(t, "count_over_time({job=\"test\"}[5m])", resp.Queries[0].Query)
+	assert.Equal(t, int64(120), resp.Queries[0].CellBStats.ExecTimeMs)
+}
+
+func TestConfig_ValidateStorage(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		storage StorageConfig
+		err     string
+	}{
+		{name: "postgres", storage: StorageConfig{Type: "postgres", PostgresDatabase: "goldfish", PostgresUser: "goldfish"}},
+		{name: "postgres without user", storage: StorageConfig{Type: "postgres", PostgresDatabase: "goldfish"}, err: "PostgreSQL database and user must be specified"},
+		{name: "sqlite", storage: StorageConfig{Type: "sqlite", SQLitePath: "goldfish.db"}},
+		{name: "sqlite without path", storage: StorageConfig{Type: "sqlite"}, err: "SQLite database path must be specified"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{Enabled: true, StorageConfig: tc.storage}
+			err := config.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}